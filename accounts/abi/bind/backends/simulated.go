@@ -487,7 +487,7 @@ func (b *SimulatedBackend) callContract(_ context.Context, call klaytn.CallMsg,
 	// Execute the call.
 	nonce := from.Nonce()
 	intrinsicGas, _ := types.IntrinsicGas(call.Data, nil, call.To == nil, b.config.Rules(block.Number()))
-	msg := types.NewMessage(call.From, call.To, nonce, call.Value, call.Gas, call.GasPrice, call.Data, true, intrinsicGas)
+	msg := types.NewMessage(call.From, call.To, nonce, call.Value, call.Gas, call.GasPrice, call.Data, true, intrinsicGas, nil)
 
 	evmContext := blockchain.NewEVMContext(msg, block.Header(), b.blockchain, nil)
 	// Create a new environment which holds all relevant information
@@ -700,6 +700,10 @@ func (fb *filterBackend) SubscribeNewTxsEvent(_ chan<- blockchain.NewTxsEvent) e
 	return nullSubscription()
 }
 
+func (fb *filterBackend) SubscribeDroppedTxsEvent(_ chan<- blockchain.DroppedTxsEvent) event.Subscription {
+	return nullSubscription()
+}
+
 func (fb *filterBackend) SubscribeChainEvent(ch chan<- blockchain.ChainEvent) event.Subscription {
 	return fb.bc.SubscribeChainEvent(ch)
 }
@@ -708,6 +712,10 @@ func (fb *filterBackend) SubscribeRemovedLogsEvent(ch chan<- blockchain.RemovedL
 	return fb.bc.SubscribeRemovedLogsEvent(ch)
 }
 
+func (fb *filterBackend) SubscribeReorgEvent(ch chan<- blockchain.ReorgEvent) event.Subscription {
+	return fb.bc.SubscribeReorgEvent(ch)
+}
+
 func (fb *filterBackend) SubscribeLogsEvent(ch chan<- []*types.Log) event.Subscription {
 	return fb.bc.SubscribeLogsEvent(ch)
 }
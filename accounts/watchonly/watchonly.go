@@ -0,0 +1,234 @@
+// Copyright 2022 The klaytn Authors
+// This file is part of the klaytn library.
+//
+// The klaytn library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The klaytn library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the klaytn library. If not, see <http://www.gnu.org/licenses/>.
+
+// Package watchonly implements an account backend for addresses without private keys.
+// Watch-only accounts appear in the wallet listings of the account manager, but they
+// cannot sign anything. The transactions of the watch-only accounts entering the
+// transaction pool or included in blocks are notified by the activity feed.
+package watchonly
+
+import (
+	"errors"
+	"math/big"
+	"reflect"
+	"sort"
+	"sync"
+
+	"github.com/klaytn/klaytn"
+	"github.com/klaytn/klaytn/accounts"
+	"github.com/klaytn/klaytn/blockchain/types"
+	"github.com/klaytn/klaytn/common"
+	"github.com/klaytn/klaytn/event"
+)
+
+// BackendType is the reflect type of a watch-only backend.
+var BackendType = reflect.TypeOf(&Backend{})
+
+// Scheme is the protocol scheme prefixing account and wallet URLs.
+var Scheme = "watchonly"
+
+// ErrWatchOnly is returned when a watch-only account is requested to sign.
+var ErrWatchOnly = errors.New("watch-only account cannot sign")
+
+// ActivityEvent is a transaction sent, received or paid by a watch-only account.
+// Receipt is nil when the transaction has entered the transaction pool, and it is
+// set when the transaction has been included in a block.
+type ActivityEvent struct {
+	Address common.Address
+	Tx      *types.Transaction
+	Receipt *types.Receipt
+}
+
+// Backend manages watch-only accounts. Each account is wrapped by its own wallet.
+type Backend struct {
+	wallets map[common.Address]*wallet
+
+	updateFeed  event.Feed              // Event feed to notify wallet additions/removals
+	updateScope event.SubscriptionScope // Subscription scope tracking current live listeners
+
+	activityFeed  event.Feed              // Event feed to notify the transactions of the watch-only accounts
+	activityScope event.SubscriptionScope // Subscription scope tracking current activity listeners
+
+	mu sync.RWMutex
+}
+
+// NewBackend creates a watch-only backend watching the given addresses.
+func NewBackend(addrs ...common.Address) *Backend {
+	b := &Backend{wallets: make(map[common.Address]*wallet)}
+	for _, addr := range addrs {
+		b.wallets[addr] = newWallet(addr)
+	}
+	return b
+}
+
+// Wallets implements accounts.Backend, returning all the watch-only wallets sorted by URL.
+func (b *Backend) Wallets() []accounts.Wallet {
+	b.mu.RLock()
+	defer b.mu.RUnlock()
+
+	wallets := make([]accounts.Wallet, 0, len(b.wallets))
+	for _, w := range b.wallets {
+		wallets = append(wallets, w)
+	}
+	sort.Slice(wallets, func(i, j int) bool { return wallets[i].URL().Cmp(wallets[j].URL()) < 0 })
+	return wallets
+}
+
+// Subscribe implements accounts.Backend, creating an async subscription to
+// receive notifications on the addition or removal of watch-only wallets.
+func (b *Backend) Subscribe(sink chan<- accounts.WalletEvent) event.Subscription {
+	return b.updateScope.Track(b.updateFeed.Subscribe(sink))
+}
+
+// SubscribeActivity creates an async subscription to receive notifications on the
+// transactions of the watch-only accounts.
+func (b *Backend) SubscribeActivity(sink chan<- ActivityEvent) event.Subscription {
+	return b.activityScope.Track(b.activityFeed.Subscribe(sink))
+}
+
+// NotifyTx sends an ActivityEvent for each of the given accounts of the transaction
+// which is watched. The receipt should be nil if the transaction is pending.
+func (b *Backend) NotifyTx(tx *types.Transaction, receipt *types.Receipt, accounts []common.Address) {
+	for _, addr := range accounts {
+		if b.Contains(addr) {
+			b.activityFeed.Send(ActivityEvent{Address: addr, Tx: tx, Receipt: receipt})
+		}
+	}
+}
+
+// Add starts watching the given address. It returns false if the address is already watched.
+func (b *Backend) Add(addr common.Address) bool {
+	b.mu.Lock()
+	if _, ok := b.wallets[addr]; ok {
+		b.mu.Unlock()
+		return false
+	}
+	w := newWallet(addr)
+	b.wallets[addr] = w
+	b.mu.Unlock()
+
+	b.updateFeed.Send(accounts.WalletEvent{Wallet: w, Kind: accounts.WalletArrived})
+	return true
+}
+
+// Remove stops watching the given address. It returns false if the address is not watched.
+func (b *Backend) Remove(addr common.Address) bool {
+	b.mu.Lock()
+	w, ok := b.wallets[addr]
+	if !ok {
+		b.mu.Unlock()
+		return false
+	}
+	delete(b.wallets, addr)
+	b.mu.Unlock()
+
+	b.updateFeed.Send(accounts.WalletEvent{Wallet: w, Kind: accounts.WalletDropped})
+	return true
+}
+
+// Len returns the number of the watched addresses.
+func (b *Backend) Len() int {
+	b.mu.RLock()
+	defer b.mu.RUnlock()
+
+	return len(b.wallets)
+}
+
+// Contains returns whether the given address is watched.
+func (b *Backend) Contains(addr common.Address) bool {
+	b.mu.RLock()
+	defer b.mu.RUnlock()
+
+	_, ok := b.wallets[addr]
+	return ok
+}
+
+// wallet implements the accounts.Wallet interface for a single watch-only account.
+type wallet struct {
+	account accounts.Account
+}
+
+func newWallet(addr common.Address) *wallet {
+	return &wallet{account: accounts.Account{
+		Address: addr,
+		URL:     accounts.URL{Scheme: Scheme, Path: addr.Hex()},
+	}}
+}
+
+// URL implements accounts.Wallet, returning the URL of the account within.
+func (w *wallet) URL() accounts.URL {
+	return w.account.URL
+}
+
+// Status implements accounts.Wallet. A watch-only wallet is always watch-only.
+func (w *wallet) Status() (string, error) {
+	return "Watch-only", nil
+}
+
+// Open implements accounts.Wallet, but is a noop for watch-only wallets.
+func (w *wallet) Open(passphrase string) error { return nil }
+
+// Close implements accounts.Wallet, but is a noop for watch-only wallets.
+func (w *wallet) Close() error { return nil }
+
+// Accounts implements accounts.Wallet, returning the watch-only account.
+func (w *wallet) Accounts() []accounts.Account {
+	return []accounts.Account{w.account}
+}
+
+// Contains implements accounts.Wallet, returning whether a particular account is
+// or is not wrapped by this wallet instance.
+func (w *wallet) Contains(account accounts.Account) bool {
+	return account.Address == w.account.Address && (account.URL == (accounts.URL{}) || account.URL == w.account.URL)
+}
+
+// Derive implements accounts.Wallet, but is not supported for watch-only wallets.
+func (w *wallet) Derive(path accounts.DerivationPath, pin bool) (accounts.Account, error) {
+	return accounts.Account{}, accounts.ErrNotSupported
+}
+
+// SelfDerive implements accounts.Wallet, but is a noop for watch-only wallets.
+func (w *wallet) SelfDerive(base accounts.DerivationPath, chain klaytn.ChainReader) {}
+
+// SignHash implements accounts.Wallet, but always fails with ErrWatchOnly.
+func (w *wallet) SignHash(account accounts.Account, hash []byte) ([]byte, error) {
+	return nil, ErrWatchOnly
+}
+
+// SignTx implements accounts.Wallet, but always fails with ErrWatchOnly.
+func (w *wallet) SignTx(account accounts.Account, tx *types.Transaction, chainID *big.Int) (*types.Transaction, error) {
+	return nil, ErrWatchOnly
+}
+
+// SignTxAsFeePayer implements accounts.Wallet, but always fails with ErrWatchOnly.
+func (w *wallet) SignTxAsFeePayer(account accounts.Account, tx *types.Transaction, chainID *big.Int) (*types.Transaction, error) {
+	return nil, ErrWatchOnly
+}
+
+// SignHashWithPassphrase implements accounts.Wallet, but always fails with ErrWatchOnly.
+func (w *wallet) SignHashWithPassphrase(account accounts.Account, passphrase string, hash []byte) ([]byte, error) {
+	return nil, ErrWatchOnly
+}
+
+// SignTxWithPassphrase implements accounts.Wallet, but always fails with ErrWatchOnly.
+func (w *wallet) SignTxWithPassphrase(account accounts.Account, passphrase string, tx *types.Transaction, chainID *big.Int) (*types.Transaction, error) {
+	return nil, ErrWatchOnly
+}
+
+// SignTxAsFeePayerWithPassphrase implements accounts.Wallet, but always fails with ErrWatchOnly.
+func (w *wallet) SignTxAsFeePayerWithPassphrase(account accounts.Account, passphrase string, tx *types.Transaction, chainID *big.Int) (*types.Transaction, error) {
+	return nil, ErrWatchOnly
+}
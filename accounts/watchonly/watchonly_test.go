@@ -0,0 +1,110 @@
+// Copyright 2022 The klaytn Authors
+// This file is part of the klaytn library.
+//
+// The klaytn library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The klaytn library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the klaytn library. If not, see <http://www.gnu.org/licenses/>.
+
+package watchonly
+
+import (
+	"testing"
+	"time"
+
+	"github.com/klaytn/klaytn/accounts"
+	"github.com/klaytn/klaytn/blockchain/types"
+	"github.com/klaytn/klaytn/common"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestWatchOnlyManager(t *testing.T) {
+	var (
+		addr1 = common.HexToAddress("0x1111")
+		addr2 = common.HexToAddress("0x2222")
+	)
+	backend := NewBackend(addr1)
+	am := accounts.NewManager(backend)
+	defer am.Close()
+
+	events := make(chan accounts.WalletEvent, 4)
+	sub := am.Subscribe(events)
+	defer sub.Unsubscribe()
+
+	assert.Len(t, am.Wallets(), 1)
+	assert.False(t, backend.Add(addr1))
+	assert.True(t, backend.Add(addr2))
+
+	select {
+	case ev := <-events:
+		assert.Equal(t, accounts.WalletArrived, ev.Kind)
+		assert.Equal(t, addr2, ev.Wallet.Accounts()[0].Address)
+	case <-time.After(time.Second):
+		t.Fatal("wallet arrival is not notified")
+	}
+	assert.Len(t, am.Wallets(), 2)
+
+	// Watch-only accounts can be found, but cannot sign.
+	wallet, err := am.Find(accounts.Account{Address: addr2})
+	assert.NoError(t, err)
+	_, err = wallet.SignHash(accounts.Account{Address: addr2}, make([]byte, 32))
+	assert.Equal(t, ErrWatchOnly, err)
+
+	assert.True(t, backend.Remove(addr2))
+	assert.False(t, backend.Remove(addr2))
+	select {
+	case ev := <-events:
+		assert.Equal(t, accounts.WalletDropped, ev.Kind)
+	case <-time.After(time.Second):
+		t.Fatal("wallet drop is not notified")
+	}
+	assert.Len(t, am.Wallets(), 1)
+}
+
+func TestWatchOnlyActivity(t *testing.T) {
+	var (
+		watched = common.HexToAddress("0x1111")
+		other   = common.HexToAddress("0x2222")
+		tx      = types.NewTransaction(0, watched, common.Big0, 21000, common.Big1, nil)
+		receipt = &types.Receipt{Status: types.ReceiptStatusSuccessful, TxHash: tx.Hash()}
+	)
+	backend := NewBackend(watched)
+
+	events := make(chan ActivityEvent, 4)
+	sub := backend.SubscribeActivity(events)
+	defer sub.Unsubscribe()
+
+	// Only the watched accounts of the transaction are notified.
+	backend.NotifyTx(tx, nil, []common.Address{other, watched})
+	backend.NotifyTx(tx, receipt, []common.Address{watched})
+	backend.NotifyTx(tx, receipt, []common.Address{other})
+
+	for _, expected := range []*types.Receipt{nil, receipt} {
+		select {
+		case ev := <-events:
+			assert.Equal(t, watched, ev.Address)
+			assert.Equal(t, tx, ev.Tx)
+			assert.Equal(t, expected, ev.Receipt)
+		case <-time.After(time.Second):
+			t.Fatal("activity is not notified")
+		}
+	}
+	select {
+	case ev := <-events:
+		t.Fatalf("unexpected activity of %s", ev.Address.String())
+	default:
+	}
+
+	// The activity of an address is not notified after it is removed.
+	backend.Remove(watched)
+	backend.NotifyTx(tx, nil, []common.Address{watched})
+	assert.Empty(t, events)
+}
@@ -29,6 +29,10 @@ import (
 type AddrLocker struct {
 	mu    sync.Mutex
 	locks map[common.Address]*sync.Mutex
+
+	// reserved holds the reservations of the reserved nonces of each account.
+	reservedMu sync.Mutex
+	reserved   map[common.Address]map[uint64]nonceReservation
 }
 
 // lock returns the lock of the given address.
@@ -0,0 +1,127 @@
+// Copyright 2022 The klaytn Authors
+// This file is part of the klaytn library.
+//
+// The klaytn library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The klaytn library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the klaytn library. If not, see <http://www.gnu.org/licenses/>.
+
+package api
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/klaytn/klaytn/common"
+	"github.com/klaytn/klaytn/common/hexutil"
+	"github.com/klaytn/klaytn/networks/rpc"
+)
+
+const (
+	// maxBalanceHistoryPoints is the maximum number of blocks queried by a balance history request.
+	maxBalanceHistoryPoints = 1000
+	// maxBalanceHistoryAddresses is the maximum number of addresses queried by a batch balance history request.
+	maxBalanceHistoryAddresses = 100
+)
+
+var (
+	errZeroBalanceHistoryStep     = errors.New("step should be greater than 0")
+	errTooManyBalanceHistoryAddrs = fmt.Errorf("too many addresses (max %d)", maxBalanceHistoryAddresses)
+)
+
+// BalanceAt is the balance of an account at a block.
+type BalanceAt struct {
+	BlockNumber hexutil.Uint64 `json:"blockNumber"`
+	Balance     *hexutil.Big   `json:"balance"`
+}
+
+// GetBalanceHistory returns the balances of the given address at every step blocks from fromBlock to toBlock.
+// The states of the blocks should be available, so an archive node is required for old blocks.
+func (s *PublicBlockChainAPI) GetBalanceHistory(ctx context.Context, address common.Address, fromBlock, toBlock rpc.BlockNumber, step hexutil.Uint64) ([]*BalanceAt, error) {
+	history, err := s.GetBalancesHistory(ctx, []common.Address{address}, fromBlock, toBlock, step)
+	if err != nil {
+		return nil, err
+	}
+	return history[address], nil
+}
+
+// GetBalancesHistory returns the balances of the given addresses at every step blocks from fromBlock to toBlock.
+// The state of each block is opened once for all the addresses.
+// The states of the blocks should be available, so an archive node is required for old blocks.
+func (s *PublicBlockChainAPI) GetBalancesHistory(ctx context.Context, addresses []common.Address, fromBlock, toBlock rpc.BlockNumber, step hexutil.Uint64) (map[common.Address][]*BalanceAt, error) {
+	if len(addresses) > maxBalanceHistoryAddresses {
+		return nil, errTooManyBalanceHistoryAddrs
+	}
+	blockNums, err := s.blockNumbersInRange(fromBlock, toBlock, uint64(step), maxBalanceHistoryPoints)
+	if err != nil {
+		return nil, err
+	}
+
+	history := make(map[common.Address][]*BalanceAt, len(addresses))
+	for _, addr := range addresses {
+		history[addr] = make([]*BalanceAt, 0, len(blockNums))
+	}
+	for _, num := range blockNums {
+		if err := ctx.Err(); err != nil {
+			return nil, err
+		}
+		state, _, err := s.b.StateAndHeaderByNumber(ctx, rpc.BlockNumber(num))
+		if err != nil {
+			return nil, err
+		}
+		if state == nil {
+			return nil, fmt.Errorf("state of block %d is not available", num)
+		}
+		for addr := range history {
+			history[addr] = append(history[addr], &BalanceAt{
+				BlockNumber: hexutil.Uint64(num),
+				Balance:     (*hexutil.Big)(state.GetBalance(addr)),
+			})
+		}
+		if err := state.Error(); err != nil {
+			return nil, err
+		}
+	}
+	return history, nil
+}
+
+// blockNumbersInRange returns the numbers of the blocks from fromBlock to toBlock at every step blocks,
+// failing if there are more than maxPoints blocks.
+// The latest and the pending block numbers are regarded as the current block number.
+func (s *PublicBlockChainAPI) blockNumbersInRange(fromBlock, toBlock rpc.BlockNumber, step uint64, maxPoints uint64) ([]uint64, error) {
+	if step == 0 {
+		return nil, errZeroBalanceHistoryStep
+	}
+	head := s.b.CurrentBlock().NumberU64()
+	resolve := func(n rpc.BlockNumber) uint64 {
+		if n < 0 || uint64(n) > head {
+			return head
+		}
+		return uint64(n)
+	}
+	from, to := resolve(fromBlock), resolve(toBlock)
+	if from > to {
+		return nil, fmt.Errorf("fromBlock %d is later than toBlock %d", from, to)
+	}
+	if points := (to-from)/step + 1; points > maxPoints {
+		return nil, fmt.Errorf("too many blocks to query (%d > %d)", points, maxPoints)
+	}
+
+	var blockNums []uint64
+	for num := from; num <= to; num += step {
+		blockNums = append(blockNums, num)
+		if num+step < num {
+			break
+		}
+	}
+	return blockNums, nil
+}
@@ -0,0 +1,71 @@
+// Copyright 2022 The klaytn Authors
+// This file is part of the klaytn library.
+//
+// The klaytn library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The klaytn library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the klaytn library. If not, see <http://www.gnu.org/licenses/>.
+
+package api
+
+import (
+	"context"
+	"math/big"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	mock_api "github.com/klaytn/klaytn/api/mocks"
+	"github.com/klaytn/klaytn/blockchain/state"
+	"github.com/klaytn/klaytn/blockchain/types"
+	"github.com/klaytn/klaytn/common"
+	"github.com/klaytn/klaytn/common/hexutil"
+	"github.com/klaytn/klaytn/networks/rpc"
+	"github.com/klaytn/klaytn/storage/database"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestGetBalancesHistory(t *testing.T) {
+	var (
+		ctx   = context.Background()
+		addr1 = common.HexToAddress("0x1111")
+		addr2 = common.HexToAddress("0x2222")
+	)
+	mockCtrl := gomock.NewController(t)
+	defer mockCtrl.Finish()
+	mockBackend := mock_api.NewMockBackend(mockCtrl)
+	mockBackend.EXPECT().CurrentBlock().Return(types.NewBlockWithHeader(&types.Header{Number: big.NewInt(10)})).AnyTimes()
+
+	// The balance of addr1 at block n is n, and addr2 has no balance.
+	mockBackend.EXPECT().StateAndHeaderByNumber(ctx, gomock.Any()).DoAndReturn(
+		func(_ context.Context, num rpc.BlockNumber) (*state.StateDB, *types.Header, error) {
+			st, err := state.New(common.Hash{}, state.NewDatabase(database.NewMemoryDBManager()), nil)
+			require.NoError(t, err)
+			st.AddBalance(addr1, big.NewInt(num.Int64()))
+			return st, &types.Header{Number: big.NewInt(num.Int64())}, nil
+		}).Times(4)
+
+	api := NewPublicBlockChainAPI(mockBackend)
+	history, err := api.GetBalancesHistory(ctx, []common.Address{addr1, addr2}, 1, rpc.LatestBlockNumber, 3)
+	require.NoError(t, err)
+	assert.Len(t, history, 2)
+	for i, num := range []uint64{1, 4, 7, 10} {
+		assert.Equal(t, &BalanceAt{hexutil.Uint64(num), (*hexutil.Big)(new(big.Int).SetUint64(num))}, history[addr1][i])
+		assert.Equal(t, &BalanceAt{hexutil.Uint64(num), (*hexutil.Big)(new(big.Int))}, history[addr2][i])
+	}
+
+	_, err = api.GetBalanceHistory(ctx, addr1, 1, 10, 0)
+	assert.Equal(t, errZeroBalanceHistoryStep, err)
+	_, err = api.GetBalanceHistory(ctx, addr1, 5, 1, 1)
+	assert.Error(t, err)
+	_, err = api.GetBalancesHistory(ctx, make([]common.Address, maxBalanceHistoryAddresses+1), 1, 10, 1)
+	assert.Equal(t, errTooManyBalanceHistoryAddrs, err)
+}
@@ -0,0 +1,186 @@
+// Copyright 2022 The klaytn Authors
+// This file is part of the klaytn library.
+//
+// The klaytn library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The klaytn library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the klaytn library. If not, see <http://www.gnu.org/licenses/>.
+
+package api
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math/big"
+	"sync"
+
+	"github.com/klaytn/klaytn/blockchain"
+	"github.com/klaytn/klaytn/blockchain/types"
+	"github.com/klaytn/klaytn/blockchain/vm"
+	"github.com/klaytn/klaytn/common"
+	"github.com/klaytn/klaytn/common/hexutil"
+	"github.com/klaytn/klaytn/networks/rpc"
+)
+
+const (
+	// maxCallManyBlocks is the maximum number of blocks at which a single CallMany executes the call.
+	maxCallManyBlocks = 1000
+	// callManyParallelism is the maximum number of blocks at which a CallMany executes the call concurrently.
+	callManyParallelism = 8
+)
+
+var (
+	errNoCallManyBlocks      = errors.New("no block is given")
+	errCallManyPendingBlock  = errors.New("pending block is not supported")
+	errInvalidCallManyBlocks = errors.New("blocks should be a list of blocks or a range of {fromBlock, toBlock, step}")
+)
+
+// CallManyBlocks is the set of blocks at which CallMany executes the call.
+// It is either a JSON list of block numbers or hashes, or a range object of
+// {"fromBlock", "toBlock", "step"} which selects the blocks at every step blocks.
+type CallManyBlocks struct {
+	Blocks []rpc.BlockNumberOrHash
+
+	FromBlock *rpc.BlockNumber
+	ToBlock   *rpc.BlockNumber
+	Step      hexutil.Uint64
+}
+
+func (b *CallManyBlocks) UnmarshalJSON(data []byte) error {
+	data = bytes.TrimSpace(data)
+	if len(data) > 0 && data[0] == '[' {
+		return json.Unmarshal(data, &b.Blocks)
+	}
+	var blockRange struct {
+		FromBlock *rpc.BlockNumber `json:"fromBlock"`
+		ToBlock   *rpc.BlockNumber `json:"toBlock"`
+		Step      *hexutil.Uint64  `json:"step"`
+	}
+	if err := json.Unmarshal(data, &blockRange); err != nil {
+		return errInvalidCallManyBlocks
+	}
+	if blockRange.FromBlock == nil {
+		return errInvalidCallManyBlocks
+	}
+	latest := rpc.LatestBlockNumber
+	b.FromBlock, b.ToBlock, b.Step = blockRange.FromBlock, &latest, 1
+	if blockRange.ToBlock != nil {
+		b.ToBlock = blockRange.ToBlock
+	}
+	if blockRange.Step != nil {
+		b.Step = *blockRange.Step
+	}
+	return nil
+}
+
+// CallManyResult is the result of a call executed at a block by CallMany.
+type CallManyResult struct {
+	BlockNumber hexutil.Uint64 `json:"blockNumber"`
+	BlockHash   common.Hash    `json:"blockHash"`
+	ReturnData  hexutil.Bytes  `json:"returnData"`
+	GasUsed     hexutil.Uint64 `json:"gasUsed"`
+	Status      hexutil.Uint   `json:"status"`
+	Error       string         `json:"error,omitempty"`
+}
+
+// CallMany executes the same call against the states of the given blocks, and returns the result at each block
+// in the order of the blocks. A failure of the call at a block is reported in the result of the block.
+// The states of the blocks should be available, so an archive node is required for old blocks.
+func (s *PublicBlockChainAPI) CallMany(ctx context.Context, args CallArgs, blocks CallManyBlocks) ([]*CallManyResult, error) {
+	targets, err := s.callManyTargets(ctx, blocks)
+	if err != nil {
+		return nil, err
+	}
+	gasCap := big.NewInt(0)
+	if rpcGasCap := s.b.RPCGasCap(); rpcGasCap != nil {
+		gasCap = rpcGasCap
+	}
+
+	var (
+		results = make([]*CallManyResult, len(targets))
+		sem     = make(chan struct{}, callManyParallelism)
+		wg      sync.WaitGroup
+	)
+	for i, target := range targets {
+		results[i] = &CallManyResult{BlockNumber: hexutil.Uint64(target.Number.Uint64()), BlockHash: target.Hash()}
+
+		sem <- struct{}{}
+		wg.Add(1)
+		go func(result *CallManyResult) {
+			defer func() {
+				<-sem
+				wg.Done()
+			}()
+			if err := ctx.Err(); err != nil {
+				result.Error = err.Error()
+				return
+			}
+			blockNrOrHash := rpc.NewBlockNumberOrHashWithHash(result.BlockHash, false)
+			ret, gas, _, status, err := DoCall(ctx, s.b, args, blockNrOrHash, vm.Config{}, s.b.RPCEVMTimeout(), gasCap)
+			result.ReturnData, result.GasUsed, result.Status = common.CopyBytes(ret), hexutil.Uint64(gas), hexutil.Uint(status)
+			if err == nil {
+				err = blockchain.GetVMerrFromReceiptStatus(status)
+				if err != nil && isReverted(err) && len(ret) > 0 {
+					err = newRevertError(ret)
+				}
+			}
+			if err != nil {
+				result.Error = err.Error()
+			}
+		}(results[i])
+	}
+	wg.Wait()
+
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+	return results, nil
+}
+
+// callManyTargets resolves the headers of the blocks at which CallMany executes the call.
+func (s *PublicBlockChainAPI) callManyTargets(ctx context.Context, blocks CallManyBlocks) ([]*types.Header, error) {
+	list := blocks.Blocks
+	if blocks.FromBlock != nil {
+		nums, err := s.blockNumbersInRange(*blocks.FromBlock, *blocks.ToBlock, uint64(blocks.Step), maxCallManyBlocks)
+		if err != nil {
+			return nil, err
+		}
+		list = make([]rpc.BlockNumberOrHash, len(nums))
+		for i, num := range nums {
+			list[i] = rpc.NewBlockNumberOrHashWithNumber(rpc.BlockNumber(num))
+		}
+	}
+	if len(list) == 0 {
+		return nil, errNoCallManyBlocks
+	}
+	if len(list) > maxCallManyBlocks {
+		return nil, fmt.Errorf("too many blocks to call (%d > %d)", len(list), maxCallManyBlocks)
+	}
+
+	targets := make([]*types.Header, len(list))
+	for i, blockNrOrHash := range list {
+		if num, ok := blockNrOrHash.Number(); ok && num == rpc.PendingBlockNumber {
+			return nil, errCallManyPendingBlock
+		}
+		header, err := s.b.HeaderByNumberOrHash(ctx, blockNrOrHash)
+		if err != nil {
+			return nil, err
+		}
+		if header == nil {
+			return nil, fmt.Errorf("block at index %d not found", i)
+		}
+		targets[i] = header
+	}
+	return targets, nil
+}
@@ -0,0 +1,92 @@
+// Copyright 2022 The klaytn Authors
+// This file is part of the klaytn library.
+//
+// The klaytn library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The klaytn library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the klaytn library. If not, see <http://www.gnu.org/licenses/>.
+
+package api
+
+import (
+	"context"
+	"encoding/json"
+	"math/big"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	mock_api "github.com/klaytn/klaytn/api/mocks"
+	"github.com/klaytn/klaytn/blockchain/types"
+	"github.com/klaytn/klaytn/networks/rpc"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCallManyBlocks_UnmarshalJSON(t *testing.T) {
+	var blocks CallManyBlocks
+	require.NoError(t, json.Unmarshal([]byte(`["0x1", "latest", {"blockHash": "0x0000000000000000000000000000000000000000000000000000000000000001"}]`), &blocks))
+	assert.Len(t, blocks.Blocks, 3)
+	assert.Nil(t, blocks.FromBlock)
+
+	blocks = CallManyBlocks{}
+	require.NoError(t, json.Unmarshal([]byte(`{"fromBlock": "0x1"}`), &blocks))
+	assert.Equal(t, rpc.BlockNumber(1), *blocks.FromBlock)
+	assert.Equal(t, rpc.LatestBlockNumber, *blocks.ToBlock)
+	assert.EqualValues(t, 1, blocks.Step)
+
+	blocks = CallManyBlocks{}
+	require.NoError(t, json.Unmarshal([]byte(`{"fromBlock": "0x1", "toBlock": "0xa", "step": "0x3"}`), &blocks))
+	assert.Equal(t, rpc.BlockNumber(10), *blocks.ToBlock)
+	assert.EqualValues(t, 3, blocks.Step)
+
+	assert.Error(t, json.Unmarshal([]byte(`{"toBlock": "0xa"}`), &blocks))
+	assert.Error(t, json.Unmarshal([]byte(`"latest"`), &blocks))
+}
+
+func TestCallManyTargets(t *testing.T) {
+	ctx := context.Background()
+	mockCtrl := gomock.NewController(t)
+	defer mockCtrl.Finish()
+	mockBackend := mock_api.NewMockBackend(mockCtrl)
+	mockBackend.EXPECT().CurrentBlock().Return(types.NewBlockWithHeader(&types.Header{Number: big.NewInt(10)})).AnyTimes()
+	mockBackend.EXPECT().HeaderByNumberOrHash(ctx, gomock.Any()).DoAndReturn(
+		func(_ context.Context, blockNrOrHash rpc.BlockNumberOrHash) (*types.Header, error) {
+			num, _ := blockNrOrHash.Number()
+			if num > 10 {
+				return nil, nil
+			}
+			return &types.Header{Number: big.NewInt(num.Int64())}, nil
+		}).AnyTimes()
+	api := NewPublicBlockChainAPI(mockBackend)
+
+	from, to := rpc.BlockNumber(1), rpc.LatestBlockNumber
+	targets, err := api.callManyTargets(ctx, CallManyBlocks{FromBlock: &from, ToBlock: &to, Step: 3})
+	require.NoError(t, err)
+	require.Len(t, targets, 4)
+	for i, num := range []int64{1, 4, 7, 10} {
+		assert.Equal(t, num, targets[i].Number.Int64())
+	}
+
+	list := []rpc.BlockNumberOrHash{rpc.NewBlockNumberOrHashWithNumber(5), rpc.NewBlockNumberOrHashWithNumber(2)}
+	targets, err = api.callManyTargets(ctx, CallManyBlocks{Blocks: list})
+	require.NoError(t, err)
+	assert.Equal(t, int64(5), targets[0].Number.Int64())
+	assert.Equal(t, int64(2), targets[1].Number.Int64())
+
+	_, err = api.callManyTargets(ctx, CallManyBlocks{})
+	assert.Equal(t, errNoCallManyBlocks, err)
+	_, err = api.callManyTargets(ctx, CallManyBlocks{Blocks: []rpc.BlockNumberOrHash{rpc.NewBlockNumberOrHashWithNumber(rpc.PendingBlockNumber)}})
+	assert.Equal(t, errCallManyPendingBlock, err)
+	_, err = api.callManyTargets(ctx, CallManyBlocks{Blocks: []rpc.BlockNumberOrHash{rpc.NewBlockNumberOrHashWithNumber(11)}})
+	assert.Error(t, err)
+	_, err = api.callManyTargets(ctx, CallManyBlocks{Blocks: make([]rpc.BlockNumberOrHash, maxCallManyBlocks+1)})
+	assert.Error(t, err)
+}
@@ -36,7 +36,7 @@ import (
 	"github.com/klaytn/klaytn/common"
 	"github.com/klaytn/klaytn/common/hexutil"
 	"github.com/klaytn/klaytn/common/math"
-	"github.com/klaytn/klaytn/crypto"
+	"github.com/klaytn/klaytn/consensus/misc"
 	"github.com/klaytn/klaytn/governance"
 	"github.com/klaytn/klaytn/networks/rpc"
 	"github.com/klaytn/klaytn/node/cn/filters"
@@ -54,11 +54,15 @@ const (
 	ZeroHashrate uint64 = 0
 	// ZeroUncleCount is always zero because there is no uncle blocks in Klaytn.
 	ZeroUncleCount uint = 0
+	// MaxHeadersByRange is the maximum number of headers returned by GetHeadersByRange at once.
+	MaxHeadersByRange uint64 = 1024
 )
 
 var (
-	errNoMiningWork  = errors.New("no mining work available yet")
-	errNotFoundBlock = errors.New("can't find a block in database")
+	errNoMiningWork          = errors.New("no mining work available yet")
+	errNotFoundBlock         = errors.New("can't find a block in database")
+	errTooManyHeaders        = fmt.Errorf("count exceeds the maximum of %d headers", MaxHeadersByRange)
+	errPendingHeadersByRange = errors.New("pending header is not supported for the header range")
 )
 
 // EthereumAPI provides an API to access the Klaytn through the `eth` namespace.
@@ -72,6 +76,8 @@ type EthereumAPI struct {
 	publicTransactionPoolAPI *PublicTransactionPoolAPI
 	publicAccountAPI         *PublicAccountAPI
 	publicGovernanceAPI      *governance.PublicGovernanceAPI
+
+	klaytnTxMode EthKlaytnTxMode
 }
 
 // NewEthereumAPI creates a new ethereum API.
@@ -79,7 +85,7 @@ type EthereumAPI struct {
 // Therefore, it is necessary to use APIs defined in two different packages(cn and api),
 // so those apis will be defined through a setter.
 func NewEthereumAPI() *EthereumAPI {
-	return &EthereumAPI{nil, nil, nil, nil, nil, nil, nil}
+	return &EthereumAPI{nil, nil, nil, nil, nil, nil, nil, EthKlaytnTxModeLegacy}
 }
 
 // SetPublicFilterAPI sets publicFilterAPI
@@ -117,6 +123,11 @@ func (api *EthereumAPI) SetPublicGovernanceAPI(publicGovernanceAPI *governance.P
 	api.publicGovernanceAPI = publicGovernanceAPI
 }
 
+// SetKlaytnTxMode sets the representation of Klaytn transactions in the eth namespace.
+func (api *EthereumAPI) SetKlaytnTxMode(mode EthKlaytnTxMode) {
+	api.klaytnTxMode = mode
+}
+
 // Etherbase is the address of operating node.
 // Unlike Ethereum, it only returns the node address because Klaytn does not have a POW mechanism.
 func (api *EthereumAPI) Etherbase() (common.Address, error) {
@@ -200,8 +211,18 @@ func (api *EthereumAPI) NewPendingTransactionFilter() rpc.ID {
 
 // NewPendingTransactions creates a subscription that is triggered each time a transaction
 // enters the transaction pool and was signed from one of the transactions this nodes manages.
-func (api *EthereumAPI) NewPendingTransactions(ctx context.Context) (*rpc.Subscription, error) {
-	return api.publicFilterAPI.NewPendingTransactions(ctx)
+// If fullTx is true, the complete transactions are notified instead of their hashes.
+// Only the transactions matched by the optional criteria are notified.
+func (api *EthereumAPI) NewPendingTransactions(ctx context.Context, fullTx *bool, crit *filters.PendingTxCriteria) (*rpc.Subscription, error) {
+	if fullTx == nil || !*fullTx {
+		return api.publicFilterAPI.NewPendingTransactions(ctx, fullTx, crit)
+	}
+	return filters.SubscribePendingTransactions(ctx, api.publicFilterAPI, crit, func(tx *types.Transaction) interface{} {
+		if rpcTx := newEthRPCPendingTransaction(tx, api.klaytnTxMode); rpcTx != nil {
+			return rpcTx
+		}
+		return nil
+	})
 }
 
 // NewBlockFilter creates a filter that fetches blocks that are imported into the chain.
@@ -213,6 +234,7 @@ func (api *EthereumAPI) NewBlockFilter() rpc.ID {
 }
 
 // NewHeads send a notification each time a new (header) block is appended to the chain.
+// The headers are marshalled in the same Ethereum-compatible format as eth_getHeaderByNumber.
 func (api *EthereumAPI) NewHeads(ctx context.Context) (*rpc.Subscription, error) {
 	notifier, supported := rpc.NotifierFromContext(ctx)
 	if !supported {
@@ -229,9 +251,9 @@ func (api *EthereumAPI) NewHeads(ctx context.Context) (*rpc.Subscription, error)
 			case h := <-headers:
 				header, err := api.rpcMarshalHeader(h)
 				if err != nil {
-					logger.Error("Failed to marshal header during newHeads subscription", "err", err)
-					headersSub.Unsubscribe()
-					return
+					// Skip the header only, so that a subscriber keeps receiving the following headers.
+					logger.Error("Failed to marshal header during newHeads subscription", "number", h.Number, "err", err)
+					continue
 				}
 				notifier.Notify(rpcSub.ID, header)
 			case <-rpcSub.Err():
@@ -276,6 +298,12 @@ func (api *EthereumAPI) GetLogs(ctx context.Context, crit filters.FilterCriteria
 	return api.publicFilterAPI.GetLogs(ctx, crit)
 }
 
+// GetLogsPage returns logs matching the given argument like GetLogs, but returns the logs found so far
+// with a cursor to continue the query when the query is too large.
+func (api *EthereumAPI) GetLogsPage(ctx context.Context, crit filters.FilterCriteria, maxResults *hexutil.Uint) (*filters.LogsPage, error) {
+	return api.publicFilterAPI.GetLogsPage(ctx, crit, maxResults)
+}
+
 // UninstallFilter removes the filter with the given filter id.
 //
 // https://eth.wiki/json-rpc/API#eth_uninstallfilter
@@ -317,7 +345,7 @@ func (api *EthereumAPI) LowerBoundGasPrice(ctx context.Context) *hexutil.Big {
 
 // MaxPriorityFeePerGas returns a suggestion for a gas tip cap for dynamic fee transactions.
 func (api *EthereumAPI) MaxPriorityFeePerGas(ctx context.Context) (*hexutil.Big, error) {
-	return api.GasPrice(ctx)
+	return api.publicKlayAPI.MaxPriorityFeePerGas(ctx)
 }
 
 // DecimalOrHex unmarshals a non-negative decimal or hex parameter into a uint64.
@@ -395,34 +423,16 @@ type EthStorageResult struct {
 }
 
 // GetProof returns the Merkle-proof for a given account and optionally some storage keys.
-// This feature is not supported in Klaytn yet. It just returns account information from state trie.
 func (api *EthereumAPI) GetProof(ctx context.Context, address common.Address, storageKeys []string, blockNrOrHash rpc.BlockNumberOrHash) (*EthAccountResult, error) {
 	state, _, err := api.publicKlayAPI.b.StateAndHeaderByNumberOrHash(ctx, blockNrOrHash)
 	if state == nil || err != nil {
 		return nil, err
 	}
-	storageTrie := state.StorageTrie(address)
-	storageHash := types.EmptyRootHashOriginal
-	codeHash := state.GetCodeHash(address)
-	storageProof := make([]EthStorageResult, len(storageKeys))
-
-	// if we have a storageTrie, (which means the account exists), we can update the storagehash
-	if storageTrie != nil {
-		storageHash = storageTrie.Hash()
-	} else {
-		// no storageTrie means the account does not exist, so the codeHash is the hash of an empty bytearray.
-		codeHash = crypto.Keccak256Hash(nil)
+	result, err := newAccountProver(state).prove(address, storageKeys)
+	if err != nil {
+		return nil, err
 	}
-
-	return &EthAccountResult{
-		Address:      address,
-		AccountProof: []string{},
-		Balance:      (*hexutil.Big)(state.GetBalance(address)),
-		CodeHash:     codeHash,
-		Nonce:        hexutil.Uint64(state.GetNonce(address)),
-		StorageHash:  storageHash,
-		StorageProof: storageProof,
-	}, state.Error()
+	return result, state.Error()
 }
 
 // GetHeaderByNumber returns the requested canonical block header.
@@ -450,6 +460,49 @@ func (api *EthereumAPI) GetHeaderByNumber(ctx context.Context, number rpc.BlockN
 	return response, nil
 }
 
+// GetHeadersByRange returns at most count contiguous canonical headers starting at the given block.
+// The headers are ordered by ascending block number, or by descending block number when reverse is
+// true. The range stops at the chain head or at the genesis block, so fewer headers may be returned.
+func (api *EthereumAPI) GetHeadersByRange(ctx context.Context, start rpc.BlockNumber, count hexutil.Uint64, reverse bool) ([]map[string]interface{}, error) {
+	if uint64(count) > MaxHeadersByRange {
+		return nil, errTooManyHeaders
+	}
+	if start == rpc.PendingBlockNumber {
+		return nil, errPendingHeadersByRange
+	}
+	head := api.publicBlockChainAPI.b.CurrentBlock().NumberU64()
+	number := uint64(start.Int64())
+	if start == rpc.LatestBlockNumber {
+		number = head
+	}
+
+	headers := make([]map[string]interface{}, 0, count)
+	for i := uint64(0); i < uint64(count) && number <= head; i++ {
+		klaytnHeader, err := api.publicBlockChainAPI.b.HeaderByNumber(ctx, rpc.BlockNumber(number))
+		if err != nil {
+			if strings.Contains(err.Error(), "does not exist") {
+				break
+			}
+			return nil, err
+		}
+		response, err := api.rpcMarshalHeader(klaytnHeader)
+		if err != nil {
+			return nil, err
+		}
+		headers = append(headers, response)
+
+		if reverse {
+			if number == 0 {
+				break
+			}
+			number--
+		} else {
+			number++
+		}
+	}
+	return headers, nil
+}
+
 // GetHeaderByHash returns the requested header by hash.
 func (api *EthereumAPI) GetHeaderByHash(ctx context.Context, hash common.Hash) map[string]interface{} {
 	// In Ethereum, err is always nil because the backend of Ethereum always return nil.
@@ -479,8 +532,11 @@ func (api *EthereumAPI) GetBlockByNumber(ctx context.Context, number rpc.BlockNu
 		}
 		return nil, err
 	}
+	if number != rpc.PendingBlockNumber {
+		return api.rpcMarshalBlockCached(klaytnBlock, fullTx)
+	}
 	response, err := api.rpcMarshalBlock(klaytnBlock, true, fullTx)
-	if err == nil && number == rpc.PendingBlockNumber {
+	if err == nil {
 		// Pending blocks need to nil out a few fields
 		for _, field := range []string{"hash", "nonce", "miner"} {
 			response[field] = nil
@@ -501,20 +557,38 @@ func (api *EthereumAPI) GetBlockByHash(ctx context.Context, hash common.Hash, fu
 		}
 		return nil, err
 	}
-	return api.rpcMarshalBlock(klaytnBlock, true, fullTx)
+	return api.rpcMarshalBlockCached(klaytnBlock, fullTx)
+}
+
+// rpcMarshalBlockCached marshals the sealed block including its transactions as Ethereum compatible format.
+// The result is cached by the block hash and a copy of it is returned.
+func (api *EthereumAPI) rpcMarshalBlockCached(block *types.Block, fullTx bool) (map[string]interface{}, error) {
+	key := ethBlockCacheKey{block.Hash(), fullTx, api.klaytnTxMode}
+	if cached, ok := ethBlockCache.get(key); ok {
+		return copyRPCFields(cached.(map[string]interface{})), nil
+	}
+	fields, err := api.rpcMarshalBlock(block, true, fullTx)
+	if err != nil {
+		return nil, err
+	}
+	ethBlockCache.add(key, fields)
+	return copyRPCFields(fields), nil
 }
 
 // GetUncleByBlockNumberAndIndex returns nil because there is no uncle block in Klaytn.
+// Like go-ethereum does for an uncle index out of range, it responds with null rather than an error.
 func (api *EthereumAPI) GetUncleByBlockNumberAndIndex(ctx context.Context, blockNr rpc.BlockNumber, index hexutil.Uint) (map[string]interface{}, error) {
 	return nil, nil
 }
 
 // GetUncleByBlockHashAndIndex returns nil because there is no uncle block in Klaytn.
+// Like go-ethereum does for an uncle index out of range, it responds with null rather than an error.
 func (api *EthereumAPI) GetUncleByBlockHashAndIndex(ctx context.Context, blockHash common.Hash, index hexutil.Uint) (map[string]interface{}, error) {
 	return nil, nil
 }
 
 // GetUncleCountByBlockNumber returns 0 when given blockNr exists because there is no uncle block in Klaytn.
+// It returns nil for a non-existing block, which is encoded as null like go-ethereum.
 func (api *EthereumAPI) GetUncleCountByBlockNumber(ctx context.Context, blockNr rpc.BlockNumber) *hexutil.Uint {
 	if block, _ := api.publicBlockChainAPI.b.BlockByNumber(ctx, blockNr); block != nil {
 		n := hexutil.Uint(ZeroUncleCount)
@@ -524,6 +598,7 @@ func (api *EthereumAPI) GetUncleCountByBlockNumber(ctx context.Context, blockNr
 }
 
 // GetUncleCountByBlockHash returns 0 when given blockHash exists because there is no uncle block in Klaytn.
+// It returns nil for a non-existing block, which is encoded as null like go-ethereum.
 func (api *EthereumAPI) GetUncleCountByBlockHash(ctx context.Context, blockHash common.Hash) *hexutil.Uint {
 	if block, _ := api.publicBlockChainAPI.b.BlockByHash(ctx, blockHash); block != nil {
 		n := hexutil.Uint(ZeroUncleCount)
@@ -599,22 +674,76 @@ func (diff *EthStateOverride) Apply(state *state.StateDB) error {
 	return nil
 }
 
+// EthBlockOverrides is a set of header fields to override during the execution of a message call.
+// BlockOverrides in go-ethereum has been renamed to EthBlockOverrides.
+// BlockOverrides is defined in go-ethereum's internal package, so BlockOverrides is redefined here as EthBlockOverrides.
+type EthBlockOverrides struct {
+	Number     *hexutil.Big    `json:"number"`
+	Difficulty *hexutil.Big    `json:"difficulty"`
+	Time       *hexutil.Uint64 `json:"time"`
+	GasLimit   *hexutil.Uint64 `json:"gasLimit"`
+	Coinbase   *common.Address `json:"coinbase"`
+	Random     *common.Hash    `json:"random"`
+	BaseFee    *hexutil.Big    `json:"baseFee"`
+}
+
+// Apply overrides the given EVM context with the block overrides.
+// Note that the hardfork rules of the EVM are still determined by the original block.
+func (diff *EthBlockOverrides) Apply(blockCtx *vm.Context) {
+	if diff == nil {
+		return
+	}
+	if diff.Number != nil {
+		blockCtx.BlockNumber = new(big.Int).Set(diff.Number.ToInt())
+	}
+	if diff.Difficulty != nil {
+		blockCtx.BlockScore = new(big.Int).Set(diff.Difficulty.ToInt())
+	}
+	if diff.Time != nil {
+		blockCtx.Time = new(big.Int).SetUint64(uint64(*diff.Time))
+	}
+	if diff.GasLimit != nil {
+		blockCtx.GasLimit = uint64(*diff.GasLimit)
+	}
+	if diff.Coinbase != nil {
+		blockCtx.Coinbase = *diff.Coinbase
+	}
+	if diff.BaseFee != nil {
+		blockCtx.BaseFee = new(big.Int).Set(diff.BaseFee.ToInt())
+	}
+	if diff.Random != nil && blockCtx.BlockNumber.Sign() > 0 {
+		// PREVRANDAO of Klaytn returns the hash of the parent block, so the hash is overridden.
+		random, parent, getHash := *diff.Random, blockCtx.BlockNumber.Uint64()-1, blockCtx.GetHash
+		blockCtx.GetHash = func(n uint64) common.Hash {
+			if n == parent {
+				return random
+			}
+			if getHash == nil {
+				return common.Hash{}
+			}
+			return getHash(n)
+		}
+	}
+}
+
 // Call executes the given transaction on the state for the given block number.
 //
-// Additionally, the caller can specify a batch of contract for fields overriding.
+// Additionally, the caller can specify a batch of contract for fields overriding
+// and a set of header fields for block context overriding.
 //
 // Note, this function doesn't make and changes in the state/blockchain and is
 // useful to execute and retrieve values.
-func (api *EthereumAPI) Call(ctx context.Context, args EthTransactionArgs, blockNrOrHash rpc.BlockNumberOrHash, overrides *EthStateOverride) (hexutil.Bytes, error) {
+func (api *EthereumAPI) Call(ctx context.Context, args EthTransactionArgs, blockNrOrHash rpc.BlockNumberOrHash, overrides *EthStateOverride, blockOverrides *EthBlockOverrides) (hexutil.Bytes, error) {
 	bcAPI := api.publicBlockChainAPI.b
 	gasCap := uint64(0)
 	if rpcGasCap := bcAPI.RPCGasCap(); rpcGasCap != nil {
 		gasCap = rpcGasCap.Uint64()
 	}
-	result, _, status, err := EthDoCall(ctx, bcAPI, args, blockNrOrHash, overrides, bcAPI.RPCEVMTimeout(), gasCap)
+	result, usedGas, status, err := EthDoCall(ctx, bcAPI, args, blockNrOrHash, overrides, blockOverrides, bcAPI.RPCEVMTimeout(), gasCap)
 	if err != nil {
 		return nil, err
 	}
+	rpc.ConsumeBatchGas(ctx, usedGas)
 
 	err = blockchain.GetVMerrFromReceiptStatus(status)
 	if err != nil && isReverted(err) && len(result) > 0 {
@@ -623,6 +752,19 @@ func (api *EthereumAPI) Call(ctx context.Context, args EthTransactionArgs, block
 	return common.CopyBytes(result), err
 }
 
+// Multicall executes the given calls in order against the state of the given block.
+// If applyChanges is true, the state changes made by a call are visible to the following calls.
+// Otherwise, every call is executed against the same state.
+// A failed call does not abort the others; its error is returned in the result of the call.
+func (api *EthereumAPI) Multicall(ctx context.Context, calls []EthTransactionArgs, blockNrOrHash rpc.BlockNumberOrHash, overrides *EthStateOverride, applyChanges *bool) ([]*EthMulticallResult, error) {
+	bcAPI := api.publicBlockChainAPI.b
+	gasCap := uint64(0)
+	if rpcGasCap := bcAPI.RPCGasCap(); rpcGasCap != nil {
+		gasCap = rpcGasCap.Uint64()
+	}
+	return EthDoMulticall(ctx, bcAPI, calls, blockNrOrHash, overrides, applyChanges != nil && *applyChanges, bcAPI.RPCEVMTimeout(), gasCap)
+}
+
 // EstimateGas returns an estimate of the amount of gas needed to execute the
 // given transaction against the current pending block.
 func (api *EthereumAPI) EstimateGas(ctx context.Context, args EthTransactionArgs, blockNrOrHash *rpc.BlockNumberOrHash) (hexutil.Uint64, error) {
@@ -651,14 +793,20 @@ func (api *EthereumAPI) GetBlockTransactionCountByHash(ctx context.Context, bloc
 }
 
 // CreateAccessList creates a EIP-2930 type AccessList for the given transaction.
-// Reexec and BlockNrOrHash can be specified to create the accessList on top of a certain state.
+// BlockNrOrHash can be specified to create the accessList on top of a certain state.
 func (api *EthereumAPI) CreateAccessList(ctx context.Context, args EthTransactionArgs, blockNrOrHash *rpc.BlockNumberOrHash) (*AccessListResult, error) {
-	// To use CreateAccess of PublicBlockChainAPI, we need to convert the EthTransactionArgs to SendTxArgs.
-	// However, since SendTxArgs does not yet support MaxFeePerGas and MaxPriorityFeePerGas, the conversion logic is bound to be incomplete.
-	// Since this parameter is not actually used and currently only returns an empty result value, implement the logic to return an empty result separately,
-	// and later, when the API is actually implemented, add the relevant fields to SendTxArgs and call the function in PublicBlockChainAPI.
-	// TODO-Klaytn: Modify below logic to use api.publicBlockChainAPI.CreateAccessList
-	result := &AccessListResult{Accesslist: &types.AccessList{}, GasUsed: hexutil.Uint64(0)}
+	bNrOrHash := rpc.NewBlockNumberOrHashWithNumber(rpc.PendingBlockNumber)
+	if blockNrOrHash != nil {
+		bNrOrHash = *blockNrOrHash
+	}
+	acl, gasUsed, vmerr, err := EthAccessList(ctx, api.publicBlockChainAPI.b, bNrOrHash, args)
+	if err != nil {
+		return nil, err
+	}
+	result := &AccessListResult{Accesslist: &acl, GasUsed: hexutil.Uint64(gasUsed)}
+	if vmerr != nil {
+		result.Error = vmerr.Error()
+	}
 	return result, nil
 }
 
@@ -685,6 +833,14 @@ type EthRPCTransaction struct {
 	V                *hexutil.Big      `json:"v"`
 	R                *hexutil.Big      `json:"r"`
 	S                *hexutil.Big      `json:"s"`
+
+	// Only set for Klaytn transactions in EthKlaytnTxModeTyped.
+	KlaytnSpecific map[string]interface{} `json:"klaytnSpecific,omitempty"`
+
+	// Only set for fee-delegated transactions in EthKlaytnTxModeFeePayer.
+	FeePayer           *common.Address        `json:"feePayer,omitempty"`
+	FeePayerSignatures types.TxSignaturesJSON `json:"feePayerSignatures,omitempty"`
+	FeeRatio           *hexutil.Uint          `json:"feeRatio,omitempty"`
 }
 
 // ethTxJSON is the JSON representation of Ethereum transaction.
@@ -719,23 +875,13 @@ type ethTxJSON struct {
 }
 
 // newEthRPCTransactionFromBlockIndex creates an EthRPCTransaction from block and index parameters.
-func newEthRPCTransactionFromBlockIndex(b *types.Block, index uint64) *EthRPCTransaction {
+func newEthRPCTransactionFromBlockIndex(b *types.Block, index uint64, mode EthKlaytnTxMode) *EthRPCTransaction {
 	txs := b.Transactions()
 	if index >= uint64(len(txs)) {
 		logger.Error("invalid transaction index", "given index", index, "length of txs", len(txs))
 		return nil
 	}
-	return newEthRPCTransaction(b, txs[index], b.Hash(), b.NumberU64(), index)
-}
-
-// newEthRPCTransactionFromBlockHash returns a transaction that will serialize to the RPC representation.
-func newEthRPCTransactionFromBlockHash(b *types.Block, hash common.Hash) *EthRPCTransaction {
-	for idx, tx := range b.Transactions() {
-		if tx.Hash() == hash {
-			return newEthRPCTransactionFromBlockIndex(b, uint64(idx))
-		}
-	}
-	return nil
+	return newEthRPCTransaction(b, txs[index], b.Hash(), b.NumberU64(), index, mode)
 }
 
 // resolveToField returns value which fits to `to` field based on transaction types.
@@ -754,7 +900,8 @@ func resolveToField(tx *types.Transaction) *common.Address {
 }
 
 // newEthRPCTransaction creates an EthRPCTransaction from Klaytn transaction.
-func newEthRPCTransaction(block *types.Block, tx *types.Transaction, blockHash common.Hash, blockNumber, index uint64) *EthRPCTransaction {
+// Klaytn transactions are represented according to the given mode.
+func newEthRPCTransaction(block *types.Block, tx *types.Transaction, blockHash common.Hash, blockNumber, index uint64, mode EthKlaytnTxMode) *EthRPCTransaction {
 	// When an unknown transaction is requested through rpc call,
 	// nil is returned by Klaytn API, and it is handled.
 	if tx == nil {
@@ -783,6 +930,15 @@ func newEthRPCTransaction(block *types.Block, tx *types.Transaction, blockHash c
 		R:        (*hexutil.Big)(signature.R),
 		S:        (*hexutil.Big)(signature.S),
 	}
+	if mode == EthKlaytnTxModeTyped && !tx.IsEthereumTransaction() {
+		result.Type = hexutil.Uint64(EthKlaytnTxType)
+		result.To = tx.To()
+		result.KlaytnSpecific = klaytnSpecificFields(tx)
+	}
+	if mode == EthKlaytnTxModeFeePayer && tx.IsFeeDelegatedTransaction() {
+		feePayer, sigs, ratio := feePayerFields(tx)
+		result.FeePayer, result.FeePayerSignatures, result.FeeRatio = &feePayer, sigs, &ratio
+	}
 
 	if blockHash != (common.Hash{}) {
 		result.BlockHash = &blockHash
@@ -812,8 +968,8 @@ func newEthRPCTransaction(block *types.Block, tx *types.Transaction, blockHash c
 }
 
 // newEthRPCPendingTransaction creates an EthRPCTransaction for pending tx.
-func newEthRPCPendingTransaction(tx *types.Transaction) *EthRPCTransaction {
-	return newEthRPCTransaction(nil, tx, common.Hash{}, 0, 0)
+func newEthRPCPendingTransaction(tx *types.Transaction, mode EthKlaytnTxMode) *EthRPCTransaction {
+	return newEthRPCTransaction(nil, tx, common.Hash{}, 0, 0, mode)
 }
 
 // formatTxToEthTxJSON formats types.Transaction to ethTxJSON.
@@ -875,7 +1031,7 @@ func (api *EthereumAPI) GetTransactionByBlockNumberAndIndex(ctx context.Context,
 		return nil
 	}
 
-	return newEthRPCTransactionFromBlockIndex(block, uint64(index))
+	return newEthRPCTransactionFromBlockIndex(block, uint64(index), api.klaytnTxMode)
 }
 
 // GetTransactionByBlockHashAndIndex returns the transaction for the given block hash and index.
@@ -884,7 +1040,7 @@ func (api *EthereumAPI) GetTransactionByBlockHashAndIndex(ctx context.Context, b
 	if err != nil || block == nil {
 		return nil
 	}
-	return newEthRPCTransactionFromBlockIndex(block, uint64(index))
+	return newEthRPCTransactionFromBlockIndex(block, uint64(index), api.klaytnTxMode)
 }
 
 // GetRawTransactionByBlockNumberAndIndex returns the bytes of the transaction for the given block number and index.
@@ -929,14 +1085,14 @@ func (api *EthereumAPI) GetTransactionByHash(ctx context.Context, hash common.Ha
 		if block == nil {
 			return nil, errNotFoundBlock
 		}
-		return newEthRPCTransaction(block, tx, blockHash, blockNumber, index), nil
+		return newEthRPCTransaction(block, tx, blockHash, blockNumber, index, api.klaytnTxMode), nil
 	}
 	// No finalized transaction, try to retrieve it from the pool
 	if tx := txpoolAPI.GetPoolTransaction(hash); tx != nil {
-		return newEthRPCPendingTransaction(tx), nil
+		return newEthRPCPendingTransaction(tx, api.klaytnTxMode), nil
 	}
-	// Transaction unknown, return as such
-	return nil, nil
+	// Transaction unknown, return as such unless the old blocks are being unindexed
+	return nil, txLookupError(txpoolAPI)
 }
 
 // GetRawTransactionByHash returns the bytes of the transaction for the given hash.
@@ -959,9 +1115,16 @@ func (api *EthereumAPI) GetTransactionReceipt(ctx context.Context, hash common.H
 	tx, blockHash, blockNumber, index, receipt := txpoolAPI.GetTxLookupInfoAndReceipt(ctx, hash)
 
 	if tx == nil {
-		return nil, nil
+		return nil, txLookupSupportError(txpoolAPI)
+	}
+	key := ethReceiptCacheKey{blockHash, hash, api.klaytnTxMode}
+	if cached, ok := ethReceiptCache.get(key); ok {
+		return copyRPCFields(cached.(map[string]interface{})), nil
 	}
 	receipts := txpoolAPI.GetBlockReceipts(ctx, blockHash)
+	if uint64(len(receipts)) <= index {
+		return nil, fmt.Errorf("receipts of block %s are missing", blockHash.Hex())
+	}
 	cumulativeGasUsed := uint64(0)
 	for i := uint64(0); i <= index; i++ {
 		cumulativeGasUsed += receipts[i].GasUsed
@@ -971,15 +1134,26 @@ func (api *EthereumAPI) GetTransactionReceipt(ctx context.Context, hash common.H
 	// Header is checked in the following newEthTransactionReceipt function
 	header, _ := txpoolAPI.HeaderByHash(ctx, blockHash)
 
-	ethTx, err := newEthTransactionReceipt(header, tx, txpoolAPI, blockHash, blockNumber, index, cumulativeGasUsed, receipt)
+	ethTx, err := api.rpcMarshalReceipt(txpoolAPI, header, tx, blockHash, blockNumber, index, cumulativeGasUsed, receipt)
 	if err != nil {
 		return nil, err
 	}
-	return ethTx, nil
+	if ethTx == nil {
+		return nil, nil
+	}
+	ethReceiptCache.add(key, ethTx)
+	return copyRPCFields(ethTx), nil
+}
+
+// rpcMarshalReceipt marshals the receipt of the transaction as Ethereum compatible format
+// according to the options of the API.
+func (api *EthereumAPI) rpcMarshalReceipt(b Backend, header *types.Header, tx *types.Transaction, blockHash common.Hash, blockNumber, index, cumulativeGasUsed uint64, receipt *types.Receipt) (map[string]interface{}, error) {
+	return newEthTransactionReceipt(header, tx, b, blockHash, blockNumber, index, cumulativeGasUsed, receipt, api.klaytnTxMode)
 }
 
 // newEthTransactionReceipt creates a transaction receipt in Ethereum format.
-func newEthTransactionReceipt(header *types.Header, tx *types.Transaction, b Backend, blockHash common.Hash, blockNumber, index, cumulativeGasUsed uint64, receipt *types.Receipt) (map[string]interface{}, error) {
+// Klaytn transactions are represented according to the given mode.
+func newEthTransactionReceipt(header *types.Header, tx *types.Transaction, b Backend, blockHash common.Hash, blockNumber, index, cumulativeGasUsed uint64, receipt *types.Receipt, mode EthKlaytnTxMode) (map[string]interface{}, error) {
 	// When an unknown transaction receipt is requested through rpc call,
 	// nil is returned by Klaytn API, and it is handled.
 	if tx == nil || receipt == nil {
@@ -1006,12 +1180,21 @@ func newEthTransactionReceipt(header *types.Header, tx *types.Transaction, b Bac
 		"logsBloom":         receipt.Bloom,
 		"type":              hexutil.Uint(byte(typeInt)),
 	}
+	if mode == EthKlaytnTxModeTyped && !tx.IsEthereumTransaction() {
+		fields["type"] = hexutil.Uint(EthKlaytnTxType)
+		fields["to"] = tx.To()
+		fields["klaytnSpecific"] = klaytnSpecificFields(tx)
+	}
+	if mode == EthKlaytnTxModeFeePayer && tx.IsFeeDelegatedTransaction() {
+		fields["feePayer"], fields["feePayerSignatures"], fields["feeRatio"] = feePayerFields(tx)
+	}
 
 	// After Magma hard fork : return header.baseFee
 	// After EthTxType hard fork : use zero baseFee to calculate effective gas price for EthereumDynamicFeeTx :
 	//  return gas price of tx.
 	// Before EthTxType hard fork : return gas price of tx. (typed ethereum txs are not available.)
-	fields["effectiveGasPrice"] = hexutil.Uint64(tx.EffectiveGasPrice(header).Uint64())
+	// It is marshalled as a big integer like Ethereum, since the fee cap of a transaction may exceed uint64.
+	fields["effectiveGasPrice"] = (*hexutil.Big)(tx.EffectiveGasPrice(header))
 
 	// Always use the "status" field and Ignore the "root" field.
 	if receipt.Status != types.ReceiptStatusSuccessful {
@@ -1084,8 +1267,7 @@ func (args *EthTransactionArgs) setDefaults(ctx context.Context, b Backend) erro
 	// After london, default to 1559 uncles gasPrice is set
 	head := b.CurrentBlock().Header()
 	isMagma := head.BaseFee != nil
-
-	fixedBaseFee := new(big.Int).SetUint64(params.ZeroBaseFee)
+	baseFee := misc.HeaderBaseFee(head)
 
 	// b.SuggestPrice = unitPrice, for before Magma
 	//                = baseFee,   for after Magma
@@ -1099,17 +1281,29 @@ func (args *EthTransactionArgs) setDefaults(ctx context.Context, b Backend) erro
 	if args.MaxPriorityFeePerGas == nil || args.MaxFeePerGas == nil {
 		if b.ChainConfig().IsEthTxTypeForkEnabled(head.Number) && args.GasPrice == nil {
 			if args.MaxPriorityFeePerGas == nil {
-				args.MaxPriorityFeePerGas = (*hexutil.Big)(gasPrice)
+				// Before Magma hard fork, only the unit price is allowed as the tip.
+				tipCap := gasPrice
+				if isMagma {
+					// After Magma hard fork, the tip is sampled from the recent blocks apart from the base fee.
+					if tipCap, err = b.SuggestTipCap(ctx); err != nil {
+						return err
+					}
+				}
+				args.MaxPriorityFeePerGas = (*hexutil.Big)(tipCap)
 			}
 			if args.MaxFeePerGas == nil {
 				// Before Magma hard fork, `gasFeeCap` was set to `baseFee*2 + maxPriorityFeePerGas` by default.
 				gasFeeCap := new(big.Int).Add(
 					(*big.Int)(args.MaxPriorityFeePerGas),
-					new(big.Int).Mul(fixedBaseFee, big.NewInt(2)),
+					new(big.Int).Mul(baseFee, big.NewInt(2)),
 				)
 				if isMagma {
-					// After Magma hard fork, `gasFeeCap` was set to `baseFee*2` by default.
+					// After Magma hard fork, `gasFeeCap` was set to `baseFee*2` by default,
+					// but it is not lower than the tip not to reject the default values.
 					gasFeeCap = gasPrice
+					if gasFeeCap.Cmp(args.MaxPriorityFeePerGas.ToInt()) < 0 {
+						gasFeeCap = args.MaxPriorityFeePerGas.ToInt()
+					}
 				}
 				args.MaxFeePerGas = (*hexutil.Big)(gasFeeCap)
 			}
@@ -1129,13 +1323,8 @@ func (args *EthTransactionArgs) setDefaults(ctx context.Context, b Backend) erro
 			}
 			if args.GasPrice == nil {
 				// TODO-Klaytn: Original logic of Ethereum uses b.SuggestTipCap which suggests TipCap, not a GasPrice.
-				// But Klaytn currently uses fixed unit price determined by Governance, so using b.SuggestPrice
-				// is fine as now.
-				if b.ChainConfig().IsEthTxTypeForkEnabled(head.Number) {
-					// TODO-Klaytn: Klaytn is using fixed BaseFee(0) as now but
-					// if we apply dynamic BaseFee, we should add calculated BaseFee instead of params.ZeroBaseFee.
-					gasPrice.Add(gasPrice, new(big.Int).SetUint64(params.ZeroBaseFee))
-				}
+				// But Klaytn uses the unit price determined by Governance before Magma, and b.SuggestPrice
+				// already includes the base fee after Magma, so using b.SuggestPrice is fine as now.
 				args.GasPrice = (*hexutil.Big)(gasPrice)
 			}
 		}
@@ -1194,6 +1383,8 @@ func (args *EthTransactionArgs) setDefaults(ctx context.Context, b Backend) erro
 	if args.ChainID == nil {
 		id := (*hexutil.Big)(b.ChainConfig().ChainID)
 		args.ChainID = id
+	} else if have, want := (*big.Int)(args.ChainID), b.ChainConfig().ChainID; have.Cmp(want) != 0 {
+		return fmt.Errorf("chainId does not match node's (have=%v, want=%v)", have, want)
 	}
 	return nil
 }
@@ -1251,12 +1442,11 @@ func (args *EthTransactionArgs) ToMessage(globalGasCap uint64, baseFee *big.Int,
 	}
 	data := args.data()
 
-	// TODO-Klaytn: Klaytn does not support accessList yet.
-	// var accessList types.AccessList
-	// if args.AccessList != nil {
-	//	 accessList = *args.AccessList
-	// }
-	return types.NewMessage(addr, args.To, 0, value, gas, gasPrice, data, false, intrinsicGas), nil
+	var accessList types.AccessList
+	if args.AccessList != nil {
+		accessList = *args.AccessList
+	}
+	return types.NewMessage(addr, args.To, 0, value, gas, gasPrice, data, false, intrinsicGas, accessList), nil
 }
 
 // toTransaction converts the arguments to a transaction.
@@ -1316,11 +1506,17 @@ func (api *EthereumAPI) SendTransaction(ctx context.Context, args EthTransaction
 		// the same nonce to multiple accounts.
 		api.publicTransactionPoolAPI.nonceLock.LockAddr(args.from())
 		defer api.publicTransactionPoolAPI.nonceLock.UnlockAddr(args.from())
+		nonce := api.publicTransactionPoolAPI.nonceLock.nextNonce(ctx, api.publicTransactionPoolAPI.b, args.from())
+		args.Nonce = (*hexutil.Uint64)(&nonce)
 	}
 	if err := args.setDefaults(ctx, api.publicTransactionPoolAPI.b); err != nil {
 		return common.Hash{}, err
 	}
 	tx := args.toTransaction()
+	// Ensure the transaction fee is reasonable before signing and submitting it.
+	if err := checkTxFee(tx.GasPrice(), tx.Gas(), api.publicTransactionPoolAPI.b.RPCTxFeeCap()); err != nil {
+		return common.Hash{}, err
+	}
 	signedTx, err := api.publicTransactionPoolAPI.sign(args.from(), tx)
 	if err != nil {
 		return common.Hash{}, err
@@ -1365,13 +1561,25 @@ func (api *EthereumAPI) FillTransaction(ctx context.Context, args EthTransaction
 // SendRawTransaction will add the signed transaction to the transaction pool.
 // The sender is responsible for signing the transaction and using the correct nonce.
 func (api *EthereumAPI) SendRawTransaction(ctx context.Context, input hexutil.Bytes) (common.Hash, error) {
+	if len(input) == 0 {
+		return common.Hash{}, errors.New("empty transaction")
+	}
+	inputBytes := []byte(input)
 	if 0 < input[0] && input[0] < 0x7f {
-		inputBytes := []byte{byte(types.EthereumTxTypeEnvelope)}
-		inputBytes = append(inputBytes, input...)
-		return api.publicTransactionPoolAPI.SendRawTransaction(ctx, inputBytes)
+		inputBytes = append([]byte{byte(types.EthereumTxTypeEnvelope)}, input...)
+	}
+	tx := new(types.Transaction)
+	if err := rlp.DecodeBytes(inputBytes, tx); err != nil {
+		return common.Hash{}, err
 	}
-	// legacy transaction
-	return api.publicTransactionPoolAPI.SendRawTransaction(ctx, input)
+	// Reject the transaction signed for another chain before it reaches the transaction pool.
+	b := api.publicTransactionPoolAPI.b
+	if tx.Protected() {
+		if have, want := tx.ChainId(), b.ChainConfig().ChainID; have.Cmp(want) != 0 {
+			return common.Hash{}, fmt.Errorf("invalid chain id of the transaction (have=%v, want=%v)", have, want)
+		}
+	}
+	return submitTransaction(ctx, b, tx)
 }
 
 // Sign calculates an ECDSA signature for:
@@ -1418,32 +1626,29 @@ func (api *EthereumAPI) SignTransaction(ctx context.Context, args EthTransaction
 	if err != nil {
 		return nil, err
 	}
-	if tx.IsEthTypedTransaction() {
+	// The signed transaction is returned so that the hash and the signature are filled.
+	if signed.IsEthTypedTransaction() {
 		// Return rawTx except types.TxTypeEthEnvelope: 0x78(= 1 byte)
-		return &EthSignTransactionResult{data[1:], formatTxToEthTxJSON(tx)}, nil
+		return &EthSignTransactionResult{data[1:], formatTxToEthTxJSON(signed)}, nil
 	}
-	return &EthSignTransactionResult{data, formatTxToEthTxJSON(tx)}, nil
+	return &EthSignTransactionResult{data, formatTxToEthTxJSON(signed)}, nil
 }
 
 // PendingTransactions returns the transactions that are in the transaction pool
 // and have a from address that is one of the accounts this node manages.
+// Only the executable transactions without a nonce gap in front are returned.
 func (api *EthereumAPI) PendingTransactions() ([]*EthRPCTransaction, error) {
-	b := api.publicTransactionPoolAPI.b
-	pending, err := b.GetPoolTransactions()
+	pending, err := localExecutableTransactions(api.publicTransactionPoolAPI.b)
 	if err != nil {
 		return nil, err
 	}
-	accounts := getAccountsFromWallets(b.AccountManager().Wallets())
 	transactions := make([]*EthRPCTransaction, 0, len(pending))
 	for _, tx := range pending {
-		from := getFrom(tx)
-		if _, exists := accounts[from]; exists {
-			ethTx := newEthRPCPendingTransaction(tx)
-			if ethTx == nil {
-				return nil, nil
-			}
-			transactions = append(transactions, ethTx)
+		ethTx := newEthRPCPendingTransaction(tx, api.klaytnTxMode)
+		if ethTx == nil {
+			return nil, nil
 		}
+		transactions = append(transactions, ethTx)
 	}
 	return transactions, nil
 }
@@ -1499,11 +1704,7 @@ func (api *EthereumAPI) rpcMarshalHeader(head *types.Header) (map[string]interfa
 	}
 
 	if api.publicBlockChainAPI.b.ChainConfig().IsEthTxTypeForkEnabled(head.Number) {
-		if head.BaseFee == nil {
-			result["baseFeePerGas"] = (*hexutil.Big)(new(big.Int).SetUint64(params.ZeroBaseFee))
-		} else {
-			result["baseFeePerGas"] = (*hexutil.Big)(head.BaseFee)
-		}
+		result["baseFeePerGas"] = (*hexutil.Big)(misc.HeaderBaseFee(head))
 	}
 	return result, nil
 }
@@ -1517,21 +1718,20 @@ func (api *EthereumAPI) rpcMarshalBlock(block *types.Block, inclTx, fullTx bool)
 	fields["size"] = hexutil.Uint64(block.Size())
 
 	if inclTx {
-		formatTx := func(tx *types.Transaction) (interface{}, error) {
-			return tx.Hash(), nil
+		formatTx := func(idx int, tx *types.Transaction) interface{} {
+			return tx.Hash()
 		}
 		if fullTx {
-			formatTx = func(tx *types.Transaction) (interface{}, error) {
-				return newEthRPCTransactionFromBlockHash(block, tx.Hash()), nil
+			// The transactions are looked up by their indices, not by their hashes,
+			// so that marshalling a block with many transactions takes a linear time.
+			formatTx = func(idx int, tx *types.Transaction) interface{} {
+				return newEthRPCTransactionFromBlockIndex(block, uint64(idx), api.klaytnTxMode)
 			}
 		}
 		txs := block.Transactions()
 		transactions := make([]interface{}, len(txs))
-		var err error
 		for i, tx := range txs {
-			if transactions[i], err = formatTx(tx); err != nil {
-				return nil, err
-			}
+			transactions[i] = formatTx(i, tx)
 		}
 		fields["transactions"] = transactions
 	}
@@ -1541,10 +1741,10 @@ func (api *EthereumAPI) rpcMarshalBlock(block *types.Block, inclTx, fullTx bool)
 	return fields, nil
 }
 
-func EthDoCall(ctx context.Context, b Backend, args EthTransactionArgs, blockNrOrHash rpc.BlockNumberOrHash, overrides *EthStateOverride, timeout time.Duration, globalGasCap uint64) ([]byte, uint64, uint, error) {
+func EthDoCall(ctx context.Context, b Backend, args EthTransactionArgs, blockNrOrHash rpc.BlockNumberOrHash, overrides *EthStateOverride, blockOverrides *EthBlockOverrides, timeout time.Duration, globalGasCap uint64) ([]byte, uint64, uint, error) {
 	defer func(start time.Time) { logger.Debug("Executing EVM call finished", "runtime", time.Since(start)) }(time.Now())
 
-	st, header, err := b.StateAndHeaderByNumberOrHash(ctx, blockNrOrHash)
+	st, header, err := callStateAndHeader(ctx, b, blockNrOrHash)
 	if st == nil || err != nil {
 		return nil, 0, 0, err
 	}
@@ -1564,14 +1764,25 @@ func EthDoCall(ctx context.Context, b Backend, args EthTransactionArgs, blockNrO
 	// this makes sure resources are cleaned up.
 	defer cancel()
 
-	// header.BaseFee != nil means magma hardforked
-	var baseFee *big.Int
-	if header.BaseFee != nil {
-		baseFee = header.BaseFee
-	} else {
-		baseFee = new(big.Int).SetUint64(params.ZeroBaseFee)
+	return ethDoCallWithState(ctx, b, args, st, header, blockOverrides, vm.Config{}, false, timeout, globalGasCap)
+}
+
+// ethDoCallWithState executes the given call on top of the given state and header.
+// The state is modified by the call, so the caller should pass a copy or a snapshot
+// of the state if it is reused. The timeout is only used for the error message;
+// the caller is responsible for cancelling ctx after the timeout.
+// If validation is true, the call is validated like a transaction: the nonce must match
+// and the sender must be able to pay the fee, which is not funded by the node.
+func ethDoCallWithState(ctx context.Context, b Backend, args EthTransactionArgs, st *state.StateDB, header *types.Header, blockOverrides *EthBlockOverrides, vmCfg vm.Config, validation bool, timeout time.Duration, globalGasCap uint64) ([]byte, uint64, uint, error) {
+	baseFee := misc.HeaderBaseFee(header)
+	if blockOverrides != nil && blockOverrides.BaseFee != nil {
+		baseFee = blockOverrides.BaseFee.ToInt()
 	}
-	intrinsicGas, err := types.IntrinsicGas(args.data(), nil, args.To == nil, b.ChainConfig().Rules(header.Number))
+	var accessList types.AccessList
+	if args.AccessList != nil {
+		accessList = *args.AccessList
+	}
+	intrinsicGas, err := types.IntrinsicGas(args.data(), accessList, args.To == nil, b.ChainConfig().Rules(header.Number))
 	if err != nil {
 		return nil, 0, 0, err
 	}
@@ -1579,14 +1790,30 @@ func EthDoCall(ctx context.Context, b Backend, args EthTransactionArgs, blockNrO
 	if err != nil {
 		return nil, 0, 0, err
 	}
-	var balanceBaseFee *big.Int
-	if header.BaseFee != nil {
-		balanceBaseFee = new(big.Int).Mul(baseFee, common.Big2)
+	if validation {
+		if err := validateCallAsTx(args, msg, st, baseFee); err != nil {
+			return nil, 0, 0, err
+		}
 	} else {
-		balanceBaseFee = msg.GasPrice()
+		var balanceBaseFee *big.Int
+		if header.BaseFee != nil {
+			balanceBaseFee = new(big.Int).Mul(baseFee, common.Big2)
+		} else {
+			balanceBaseFee = msg.GasPrice()
+		}
+		// Add gas fee to sender for estimating gasLimit/computing cost or calling a function by insufficient balance sender.
+		// The value is not funded, so the call fails if the sender cannot afford it. The state may be reused
+		// by the following calls, so the added balance is taken back after the call.
+		sender := msg.ValidatedSender()
+		funded := new(big.Int).Mul(new(big.Int).SetUint64(msg.Gas()), balanceBaseFee)
+		st.AddBalance(sender, funded)
+		defer func() {
+			if balance := st.GetBalance(sender); balance.Cmp(funded) < 0 {
+				funded = balance
+			}
+			st.SubBalance(sender, funded)
+		}()
 	}
-	// Add gas fee to sender for estimating gasLimit/computing cost or calling a function by insufficient balance sender.
-	st.AddBalance(msg.ValidatedSender(), new(big.Int).Mul(new(big.Int).SetUint64(msg.Gas()), balanceBaseFee))
 
 	// The intrinsicGas is checked again later in the blockchain.ApplyMessage function,
 	// but we check in advance here in order to keep StateTransition.TransactionDb method as unchanged as possible
@@ -1595,10 +1822,15 @@ func EthDoCall(ctx context.Context, b Backend, args EthTransactionArgs, blockNrO
 	if msg.Gas() < intrinsicGas {
 		return nil, 0, 0, fmt.Errorf("%w: msg.gas %d, want %d", blockchain.ErrIntrinsicGas, msg.Gas(), intrinsicGas)
 	}
-	evm, vmError, err := b.GetEVM(ctx, msg, st, header, vm.Config{})
+	evm, vmError, err := b.GetEVM(ctx, msg, st, header, vmCfg)
 	if err != nil {
 		return nil, 0, 0, err
 	}
+	blockOverrides.Apply(&evm.Context)
+	// Cancel the context when the call has completed, so that the goroutine below exits
+	// even if the caller executes many calls with the same context.
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
 	// Wait for the context to be done and cancel the evm. Even if the
 	// EVM has finished, cancelling may be done (repeatedly)
 	go func() {
@@ -1623,7 +1855,121 @@ func EthDoCall(ctx context.Context, b Backend, args EthTransactionArgs, blockNrO
 	return res, gas, kerr.Status, nil
 }
 
+// validateCallAsTx checks the nonce, the gas price and the balance of the sender
+// as the transaction pool does for a transaction.
+func validateCallAsTx(args EthTransactionArgs, msg *types.Transaction, st *state.StateDB, baseFee *big.Int) error {
+	from := msg.ValidatedSender()
+	if args.Nonce != nil {
+		if nonce := st.GetNonce(from); uint64(*args.Nonce) < nonce {
+			return fmt.Errorf("%w: address %v, tx: %d state: %d", blockchain.ErrNonceTooLow, from.Hex(), uint64(*args.Nonce), nonce)
+		} else if uint64(*args.Nonce) > nonce {
+			return fmt.Errorf("%w: address %v, tx: %d state: %d", blockchain.ErrNonceTooHigh, from.Hex(), uint64(*args.Nonce), nonce)
+		}
+	}
+	if msg.GasPrice().Cmp(baseFee) < 0 {
+		return fmt.Errorf("gas price %v is lower than the base fee %v", msg.GasPrice(), baseFee)
+	}
+	cost := new(big.Int).Mul(new(big.Int).SetUint64(msg.Gas()), msg.GasPrice())
+	cost.Add(cost, msg.Value())
+	if balance := st.GetBalance(from); balance.Cmp(cost) < 0 {
+		return fmt.Errorf("%w: address %v have %v want %v", blockchain.ErrInsufficientFunds, from.Hex(), balance, cost)
+	}
+	return nil
+}
+
+// EthMulticallResult is the result of a call executed by Multicall.
+type EthMulticallResult struct {
+	ReturnData hexutil.Bytes  `json:"returnData"`
+	GasUsed    hexutil.Uint64 `json:"gasUsed"`
+	Status     hexutil.Uint   `json:"status"`
+	Error      string         `json:"error,omitempty"`
+}
+
+// maxMulticallSize is the maximum number of calls which can be executed by a single Multicall.
+const maxMulticallSize = 5000
+
+// EthDoMulticall executes the given calls in order against a single resolved state.
+// The timeout is applied to the whole calls, not to each call.
+func EthDoMulticall(ctx context.Context, b Backend, calls []EthTransactionArgs, blockNrOrHash rpc.BlockNumberOrHash, overrides *EthStateOverride, applyChanges bool, timeout time.Duration, globalGasCap uint64) ([]*EthMulticallResult, error) {
+	defer func(start time.Time) {
+		logger.Debug("Executing EVM multicall finished", "calls", len(calls), "runtime", time.Since(start))
+	}(time.Now())
+
+	if len(calls) > maxMulticallSize {
+		return nil, fmt.Errorf("too many calls (have %d, max %d)", len(calls), maxMulticallSize)
+	}
+	st, header, err := callStateAndHeader(ctx, b, blockNrOrHash)
+	if st == nil || err != nil {
+		return nil, err
+	}
+	if err := overrides.Apply(st); err != nil {
+		return nil, err
+	}
+
+	var cancel context.CancelFunc
+	if timeout > 0 {
+		ctx, cancel = context.WithTimeout(ctx, timeout)
+	} else {
+		ctx, cancel = context.WithCancel(ctx)
+	}
+	defer cancel()
+
+	results := make([]*EthMulticallResult, len(calls))
+	for i, args := range calls {
+		// Every call is charged to the batch request, so that a multicall cannot exceed its gas limit.
+		if err := rpc.CheckBatchGas(ctx); err != nil {
+			return nil, err
+		}
+		snapshot := st.Snapshot()
+		ret, gas, status, err := ethDoCallWithState(ctx, b, args, st, header, nil, vm.Config{}, false, timeout, globalGasCap)
+		if ctx.Err() != nil {
+			return nil, fmt.Errorf("execution aborted at call %d (timeout = %v)", i, timeout)
+		}
+		rpc.ConsumeBatchGas(ctx, gas)
+		result := &EthMulticallResult{ReturnData: common.CopyBytes(ret), GasUsed: hexutil.Uint64(gas), Status: hexutil.Uint(status)}
+		if err == nil {
+			err = blockchain.GetVMerrFromReceiptStatus(status)
+			if err != nil && isReverted(err) && len(ret) > 0 {
+				err = newRevertError(ret)
+			}
+		}
+		if err != nil {
+			result.Error = err.Error()
+		}
+		results[i] = result
+
+		if applyChanges && err == nil {
+			st.Finalise(true, true)
+		} else {
+			st.RevertToSnapshot(snapshot)
+		}
+	}
+	return results, nil
+}
+
+// EstimateGasWithTrace is like EstimateGas, but if the transaction always fails, the returned error
+// also has the call trace of the execution failed at the highest gas allowance in its data.
+func (api *EthereumAPI) EstimateGasWithTrace(ctx context.Context, args EthTransactionArgs, blockNrOrHash *rpc.BlockNumberOrHash) (hexutil.Uint64, error) {
+	bcAPI := api.publicBlockChainAPI.b
+	bNrOrHash := rpc.NewBlockNumberOrHashWithNumber(rpc.LatestBlockNumber)
+	if blockNrOrHash != nil {
+		bNrOrHash = *blockNrOrHash
+	}
+	gasCap := uint64(0)
+	if rpcGasCap := bcAPI.RPCGasCap(); rpcGasCap != nil {
+		gasCap = rpcGasCap.Uint64()
+	}
+	return ethDoEstimateGas(ctx, bcAPI, args, bNrOrHash, gasCap, true)
+}
+
 func EthDoEstimateGas(ctx context.Context, b Backend, args EthTransactionArgs, blockNrOrHash rpc.BlockNumberOrHash, gasCap uint64) (hexutil.Uint64, error) {
+	return ethDoEstimateGas(ctx, b, args, blockNrOrHash, gasCap, false)
+}
+
+// ethDoEstimateGas estimates the gas of the given transaction by a binary search.
+// If the transaction fails at the highest gas allowance, the error of the execution is returned
+// with the revert reason, and with the call trace of the execution if traceFailure is true.
+func ethDoEstimateGas(ctx context.Context, b Backend, args EthTransactionArgs, blockNrOrHash rpc.BlockNumberOrHash, gasCap uint64, traceFailure bool) (hexutil.Uint64, error) {
 	// Binary search the gas requirement, as it may be higher than the amount used
 	var (
 		lo  uint64 = params.TxGas - 1
@@ -1690,32 +2036,82 @@ func EthDoEstimateGas(ctx context.Context, b Backend, args EthTransactionArgs, b
 	// Create a helper to check if a gas allowance results in an executable transaction.
 	// executable returns
 	// - bool: true when a call with given args and gas is executable.
+	// - uint64: gas used by the execution.
 	// - []byte: EVM execution result.
 	// - error: error occurred during EVM execution.
 	// - error: consensus error which is not EVM related error (less balance of caller, wrong nonce, etc...).
-	executable := func(gas uint64) (bool, []byte, error, error) {
+	// If tracer is not nil, the execution is traced by it.
+	executable := func(gas uint64, tracer vm.Tracer) (bool, uint64, []byte, error, error) {
 		args.Gas = (*hexutil.Uint64)(&gas)
-		ret, _, status, err := EthDoCall(ctx, b, args, rpc.NewBlockNumberOrHashWithNumber(rpc.LatestBlockNumber), nil, 0, gasCap)
+		ret, gasUsed, status, err := ethDoTracedCall(ctx, b, args, blockNrOrHash, tracer, gasCap)
 		if err != nil {
 			if errors.Is(err, blockchain.ErrIntrinsicGas) {
 				// Special case, raise gas limit
-				return false, ret, nil, nil
+				return false, 0, ret, nil, nil
 			}
 			// Returns error when it is not VM error (less balance or wrong nonce, etc...).
-			return false, nil, nil, err
+			return false, 0, nil, nil, err
 		}
 		// If err is vmError, return vmError with returned data
 		vmErr := blockchain.GetVMerrFromReceiptStatus(status)
 		if vmErr != nil {
-			return false, ret, vmErr, nil
+			return false, gasUsed, ret, vmErr, nil
+		}
+		return true, gasUsed, ret, vmErr, nil
+	}
+
+	// Execute at the highest allowance first. If it fails, the transaction is rejected as invalid.
+	var (
+		tracer   *vm.InternalTxTracer
+		vmTracer vm.Tracer
+	)
+	if traceFailure {
+		tracer = vm.NewInternalTxTracer()
+		vmTracer = tracer
+	}
+	isExecutable, gasUsed, ret, vmErr, err := executable(hi, vmTracer)
+	if err != nil {
+		return 0, err
+	}
+	if !isExecutable {
+		if vmErr != nil {
+			// Treat vmErr as RevertError only when there was returned data from call.
+			if isReverted(vmErr) && len(ret) > 0 {
+				vmErr = newRevertError(ret)
+			}
+		} else {
+			// Otherwise, the specified gas cap is too low
+			vmErr = fmt.Errorf("gas required exceeds allowance (%d)", cap)
+		}
+		if tracer == nil {
+			return 0, vmErr
+		}
+		return 0, newEstimateGasError(vmErr, tracer)
+	}
+	// The transaction cannot be executed with less gas than it used.
+	if gasUsed-1 > lo {
+		lo = gasUsed - 1
+	}
+	// Most transactions need just a bit more gas than they used, to cover the refund
+	// and the gas withheld from the calls (EIP-150). Try such an optimistic limit
+	// once, which narrows the window of the binary search in most cases.
+	optimistic := (gasUsed + params.CallStipend) * 64 / 63
+	if optimistic > lo && optimistic < hi {
+		isExecutable, _, _, _, err := executable(optimistic, nil)
+		if err != nil {
+			return 0, err
+		}
+		if isExecutable {
+			hi = optimistic
+		} else {
+			lo = optimistic
 		}
-		return true, ret, vmErr, nil
 	}
 
 	// Execute the binary search and hone in on an executable gas limit
 	for lo+1 < hi {
 		mid := (hi + lo) / 2
-		isExecutable, _, _, err := executable(mid)
+		isExecutable, _, _, _, err := executable(mid, nil)
 		if err != nil {
 			return 0, err
 		}
@@ -1726,27 +2122,23 @@ func EthDoEstimateGas(ctx context.Context, b Backend, args EthTransactionArgs, b
 			hi = mid
 		}
 	}
-	// Reject the transaction as invalid if it still fails at the highest allowance
-	if hi == cap {
-		isExecutable, ret, vmErr, err := executable(hi)
-		if err != nil {
-			return 0, err
-		}
-		if !isExecutable {
-			if vmErr != nil {
-				// Treat vmErr as RevertError only when there was returned data from call.
-				if isReverted(vmErr) && len(ret) > 0 {
-					return 0, newRevertError(ret)
-				}
-				return 0, vmErr
-			}
-			// Otherwise, the specified gas cap is too low
-			return 0, fmt.Errorf("gas required exceeds allowance (%d)", cap)
-		}
-	}
 	return hexutil.Uint64(hi), nil
 }
 
+// ethDoTracedCall executes the given call like EthDoCall, with the given tracer if it is not nil.
+func ethDoTracedCall(ctx context.Context, b Backend, args EthTransactionArgs, blockNrOrHash rpc.BlockNumberOrHash, tracer vm.Tracer, globalGasCap uint64) ([]byte, uint64, uint, error) {
+	if tracer == nil {
+		return EthDoCall(ctx, b, args, blockNrOrHash, nil, nil, 0, globalGasCap)
+	}
+	st, header, err := callStateAndHeader(ctx, b, blockNrOrHash)
+	if st == nil || err != nil {
+		return nil, 0, 0, err
+	}
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+	return ethDoCallWithState(ctx, b, args, st, header, nil, vm.Config{Debug: true, Tracer: tracer}, false, 0, globalGasCap)
+}
+
 // checkTxFee is an internal function used to check whether the fee of
 // the given transaction is _reasonable_(under the cap).
 func checkTxFee(gasPrice *big.Int, gas uint64, cap float64) error {
@@ -0,0 +1,77 @@
+// Copyright 2022 The klaytn Authors
+// This file is part of the klaytn library.
+//
+// The klaytn library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The klaytn library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the klaytn library. If not, see <http://www.gnu.org/licenses/>.
+
+package api
+
+import (
+	"fmt"
+
+	"github.com/klaytn/klaytn/blockchain/types"
+	"github.com/klaytn/klaytn/common"
+	"github.com/klaytn/klaytn/common/hexutil"
+)
+
+// EthKlaytnTxMode decides how Klaytn transactions, which do not exist in Ethereum,
+// are represented in the transactions, blocks and receipts of the eth namespace.
+type EthKlaytnTxMode string
+
+const (
+	// EthKlaytnTxModeLegacy represents Klaytn transactions as legacy transactions.
+	// The `to` field of a transaction without a recipient is filled with the sender.
+	EthKlaytnTxModeLegacy EthKlaytnTxMode = "legacy"
+	// EthKlaytnTxModeTyped represents Klaytn transactions with the type EthKlaytnTxType
+	// and the Klaytn-specific fields in the `klaytnSpecific` field.
+	EthKlaytnTxModeTyped EthKlaytnTxMode = "typed"
+	// EthKlaytnTxModeFeePayer represents Klaytn transactions as EthKlaytnTxModeLegacy does,
+	// and adds `feePayer`, `feePayerSignatures` and `feeRatio` to fee-delegated transactions and their receipts.
+	EthKlaytnTxModeFeePayer EthKlaytnTxMode = "feepayer"
+)
+
+// EthKlaytnTxType is the transaction type of Klaytn transactions in EthKlaytnTxModeTyped.
+// It is not used by any Ethereum transaction type.
+const EthKlaytnTxType = 0x7e
+
+// ParseEthKlaytnTxMode returns the EthKlaytnTxMode of the given name.
+func ParseEthKlaytnTxMode(name string) (EthKlaytnTxMode, error) {
+	switch mode := EthKlaytnTxMode(name); mode {
+	case EthKlaytnTxModeLegacy, EthKlaytnTxModeTyped, EthKlaytnTxModeFeePayer:
+		return mode, nil
+	}
+	return "", fmt.Errorf("unknown klaytn tx mode %q (want %q, %q or %q)", name, EthKlaytnTxModeLegacy, EthKlaytnTxModeTyped, EthKlaytnTxModeFeePayer)
+}
+
+// ethCommonTxFields are the fields of the Klaytn RPC output which are also Ethereum transaction fields.
+var ethCommonTxFields = []string{"from", "to", "gas", "gasPrice", "nonce", "value", "input", "hash"}
+
+// klaytnSpecificFields returns the fields of the Klaytn transaction which are not represented
+// by the Ethereum transaction fields, e.g. the Klaytn type, all signatures and the fee payer.
+func klaytnSpecificFields(tx *types.Transaction) map[string]interface{} {
+	fields := tx.MakeRPCOutput()
+	for _, name := range ethCommonTxFields {
+		delete(fields, name)
+	}
+	return fields
+}
+
+// feePayerFields returns the fee payer, the fee payer signatures and the fee ratio of the fee-delegated transaction.
+// The fee ratio is the percentage of the fee paid by the fee payer, so it is types.MaxFeeRatio
+// if the transaction has no fee ratio.
+func feePayerFields(tx *types.Transaction) (common.Address, types.TxSignaturesJSON, hexutil.Uint) {
+	feePayer, _ := tx.FeePayer()
+	sigs, _ := tx.GetFeePayerSignatures()
+	ratio, _ := tx.FeeRatio()
+	return feePayer, sigs.ToJSON(), hexutil.Uint(ratio)
+}
@@ -0,0 +1,156 @@
+// Copyright 2022 The klaytn Authors
+// This file is part of the klaytn library.
+//
+// The klaytn library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The klaytn library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the klaytn library. If not, see <http://www.gnu.org/licenses/>.
+
+package api
+
+import (
+	"math/big"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/klaytn/klaytn/blockchain/types"
+	"github.com/klaytn/klaytn/common"
+	"github.com/klaytn/klaytn/common/hexutil"
+	"github.com/klaytn/klaytn/consensus/mocks"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseEthKlaytnTxMode(t *testing.T) {
+	mode, err := ParseEthKlaytnTxMode("typed")
+	assert.NoError(t, err)
+	assert.Equal(t, EthKlaytnTxModeTyped, mode)
+
+	mode, err = ParseEthKlaytnTxMode("legacy")
+	assert.NoError(t, err)
+	assert.Equal(t, EthKlaytnTxModeLegacy, mode)
+
+	mode, err = ParseEthKlaytnTxMode("feepayer")
+	assert.NoError(t, err)
+	assert.Equal(t, EthKlaytnTxModeFeePayer, mode)
+
+	_, err = ParseEthKlaytnTxMode("unknown")
+	assert.Error(t, err)
+}
+
+func TestFeePayerFields(t *testing.T) {
+	feePayer := common.HexToAddress("0x2222")
+	newValues := func() map[types.TxValueKeyType]interface{} {
+		return map[types.TxValueKeyType]interface{}{
+			types.TxValueKeyNonce:    uint64(0),
+			types.TxValueKeyTo:       common.HexToAddress("0x3333"),
+			types.TxValueKeyAmount:   big.NewInt(1),
+			types.TxValueKeyGasLimit: uint64(100000),
+			types.TxValueKeyGasPrice: big.NewInt(25),
+			types.TxValueKeyFrom:     common.HexToAddress("0x1111"),
+			types.TxValueKeyFeePayer: feePayer,
+		}
+	}
+
+	tx, err := types.NewTransactionWithMap(types.TxTypeFeeDelegatedValueTransfer, newValues())
+	require.NoError(t, err)
+	payer, _, ratio := feePayerFields(tx)
+	assert.Equal(t, feePayer, payer)
+	assert.Equal(t, hexutil.Uint(types.MaxFeeRatio), ratio)
+
+	values := newValues()
+	values[types.TxValueKeyFeeRatioOfFeePayer] = types.FeeRatio(30)
+	tx, err = types.NewTransactionWithMap(types.TxTypeFeeDelegatedValueTransferWithRatio, values)
+	require.NoError(t, err)
+	_, _, ratio = feePayerFields(tx)
+	assert.Equal(t, hexutil.Uint(30), ratio)
+}
+
+func TestEthKlaytnTxModeFeePayer(t *testing.T) {
+	mockCtrl, mockBackend, api := testInitForEthApi(t)
+	defer mockCtrl.Finish()
+
+	mockEngine := mocks.NewMockEngine(mockCtrl)
+	mockBackend.EXPECT().Engine().Return(mockEngine).AnyTimes()
+	mockEngine.EXPECT().Author(gomock.Any()).Return(common.Address{}, nil).AnyTimes()
+	mockBackend.EXPECT().ChainConfig().Return(dummyChainConfigForEthereumAPITest).AnyTimes()
+	mockBackend.EXPECT().GetTd(gomock.Any()).Return(big.NewInt(1)).AnyTimes()
+
+	// The block has a fee-delegated transaction and a transaction paying its own fee.
+	from, to, feePayer := common.HexToAddress("0x1111"), common.HexToAddress("0x3333"), common.HexToAddress("0x2222")
+	values := map[types.TxValueKeyType]interface{}{
+		types.TxValueKeyNonce:              uint64(0),
+		types.TxValueKeyTo:                 to,
+		types.TxValueKeyAmount:             big.NewInt(1),
+		types.TxValueKeyGasLimit:           uint64(100000),
+		types.TxValueKeyGasPrice:           big.NewInt(25),
+		types.TxValueKeyFrom:               from,
+		types.TxValueKeyFeePayer:           feePayer,
+		types.TxValueKeyFeeRatioOfFeePayer: types.FeeRatio(30),
+	}
+	feeDelegatedTx, err := types.NewTransactionWithMap(types.TxTypeFeeDelegatedValueTransferWithRatio, values)
+	require.NoError(t, err)
+	delete(values, types.TxValueKeyFeePayer)
+	delete(values, types.TxValueKeyFeeRatioOfFeePayer)
+	values[types.TxValueKeyNonce] = uint64(1)
+	valueTransferTx, err := types.NewTransactionWithMap(types.TxTypeValueTransfer, values)
+	require.NoError(t, err)
+
+	txs := types.Transactions{feeDelegatedTx, valueTransferTx}
+	receipts := types.Receipts{
+		types.NewReceipt(types.ReceiptStatusSuccessful, feeDelegatedTx.Hash(), 21000),
+		types.NewReceipt(types.ReceiptStatusSuccessful, valueTransferTx.Hash(), 21000),
+	}
+	block := types.NewBlock(&types.Header{Number: big.NewInt(1)}, txs, receipts)
+
+	for _, mode := range []EthKlaytnTxMode{EthKlaytnTxModeLegacy, EthKlaytnTxModeFeePayer} {
+		api.SetKlaytnTxMode(mode)
+		enabled := mode == EthKlaytnTxModeFeePayer
+
+		// The transactions in the block are represented as legacy transactions in both modes.
+		fields, err := api.rpcMarshalBlock(block, true, true)
+		require.NoError(t, err)
+		blockTxs := fields["transactions"].([]interface{})
+		require.Len(t, blockTxs, 2)
+		for i, blockTx := range blockTxs {
+			rpcTx := blockTx.(*EthRPCTransaction)
+			assert.Equal(t, hexutil.Uint64(types.TxTypeLegacyTransaction), rpcTx.Type)
+			assert.Nil(t, rpcTx.KlaytnSpecific)
+			if enabled && i == 0 {
+				assert.Equal(t, &feePayer, rpcTx.FeePayer)
+				assert.Equal(t, hexutil.Uint(30), *rpcTx.FeeRatio)
+				assert.NotNil(t, rpcTx.FeePayerSignatures)
+			} else {
+				assert.Nil(t, rpcTx.FeePayer)
+				assert.Nil(t, rpcTx.FeeRatio)
+				assert.Nil(t, rpcTx.FeePayerSignatures)
+			}
+		}
+
+		// The pending transactions are represented in the same way.
+		pendingTx := newEthRPCPendingTransaction(feeDelegatedTx, mode)
+		assert.Equal(t, enabled, pendingTx.FeePayer != nil)
+
+		for i, tx := range txs {
+			receipt, err := api.rpcMarshalReceipt(mockBackend, block.Header(), tx, block.Hash(), block.NumberU64(), uint64(i), 21000*uint64(i+1), receipts[i])
+			require.NoError(t, err)
+			if enabled && i == 0 {
+				assert.Equal(t, feePayer, receipt["feePayer"])
+				assert.Equal(t, hexutil.Uint(30), receipt["feeRatio"])
+				assert.Contains(t, receipt, "feePayerSignatures")
+			} else {
+				assert.NotContains(t, receipt, "feePayer")
+				assert.NotContains(t, receipt, "feeRatio")
+				assert.NotContains(t, receipt, "feePayerSignatures")
+			}
+		}
+	}
+}
@@ -0,0 +1,153 @@
+// Copyright 2022 The klaytn Authors
+// This file is part of the klaytn library.
+//
+// The klaytn library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The klaytn library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the klaytn library. If not, see <http://www.gnu.org/licenses/>.
+
+package api
+
+import (
+	"context"
+	"fmt"
+	"math/big"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	mock_api "github.com/klaytn/klaytn/api/mocks"
+	"github.com/klaytn/klaytn/blockchain/state"
+	"github.com/klaytn/klaytn/blockchain/types"
+	"github.com/klaytn/klaytn/blockchain/vm"
+	"github.com/klaytn/klaytn/common"
+	"github.com/klaytn/klaytn/common/hexutil"
+	"github.com/klaytn/klaytn/networks/rpc"
+	"github.com/klaytn/klaytn/storage/database"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+var (
+	// counterCode increases the slot 0 by one and returns the increased value.
+	counterCode = hexutil.Bytes(common.FromHex("0x60005460010160005560005460005260206000f3"))
+	// revertCode reverts without any data.
+	revertCode = hexutil.Bytes(common.FromHex("0x60006000fd"))
+	// loopCode loops forever.
+	loopCode = hexutil.Bytes(common.FromHex("0x5b600056"))
+)
+
+// newMulticallTestBackend returns a backend serving a new empty state for every request,
+// as the states of the blocks are not shared between the requests.
+func newMulticallTestBackend(mockCtrl *gomock.Controller, parent *types.Header) *mock_api.MockBackend {
+	mockBackend := mock_api.NewMockBackend(mockCtrl)
+	mockBackend.EXPECT().StateAndHeaderByNumberOrHash(gomock.Any(), gomock.Any()).DoAndReturn(
+		func(ctx context.Context, blockNrOrHash rpc.BlockNumberOrHash) (*state.StateDB, *types.Header, error) {
+			st, err := state.New(common.Hash{}, state.NewDatabase(database.NewMemoryDBManager()), nil)
+			return st, parent, err
+		}).AnyTimes()
+	mockBackend.EXPECT().ChainConfig().Return(dummyChainConfigForEthereumAPITest).AnyTimes()
+	mockBackend.EXPECT().GetEVM(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).DoAndReturn(newSimulateTestEVM).AnyTimes()
+	return mockBackend
+}
+
+func TestEthDoMulticall(t *testing.T) {
+	mockCtrl := gomock.NewController(t)
+	defer mockCtrl.Finish()
+
+	var (
+		from     = common.HexToAddress("0x1111")
+		counter  = common.HexToAddress("0x2222")
+		reverter = common.HexToAddress("0x3333")
+		gas      = hexutil.Uint64(100000)
+		parent   = &types.Header{Number: big.NewInt(10), Time: big.NewInt(1700000000), BlockScore: big.NewInt(1)}
+		blockNr  = rpc.NewBlockNumberOrHashWithNumber(rpc.LatestBlockNumber)
+	)
+	backend := newMulticallTestBackend(mockCtrl, parent)
+
+	// The code and the storage of the contracts are given by the state overrides.
+	overrides := &EthStateOverride{
+		counter:  EthOverrideAccount{Code: &counterCode, State: &map[common.Hash]common.Hash{{}: common.BigToHash(big.NewInt(10))}},
+		reverter: EthOverrideAccount{Code: &revertCode},
+	}
+	calls := []EthTransactionArgs{
+		{From: &from, To: &counter, Gas: &gas},
+		{From: &from, To: &reverter, Gas: &gas},
+		{From: &from, To: &counter, Gas: &gas},
+	}
+	returned := func(results []*EthMulticallResult, i int) *big.Int {
+		return new(big.Int).SetBytes(results[i].ReturnData)
+	}
+
+	// Every call is executed against the overridden state if the changes are not applied.
+	results, err := EthDoMulticall(context.Background(), backend, calls, blockNr, overrides, false, 0, 0)
+	require.NoError(t, err)
+	require.Len(t, results, 3)
+	assert.Equal(t, big.NewInt(11), returned(results, 0))
+	assert.Equal(t, big.NewInt(11), returned(results, 2))
+	assert.Equal(t, hexutil.Uint(types.ReceiptStatusSuccessful), results[0].Status)
+	assert.Empty(t, results[0].Error)
+	assert.NotZero(t, results[0].GasUsed)
+
+	// The failed call is reported without aborting the following calls.
+	assert.Equal(t, hexutil.Uint(types.ReceiptStatusErrExecutionReverted), results[1].Status)
+	assert.Equal(t, vm.ErrExecutionReverted.Error(), results[1].Error)
+
+	// The changes made by a successful call are visible to the following calls if they are applied.
+	results, err = EthDoMulticall(context.Background(), backend, calls, blockNr, overrides, true, 0, 0)
+	require.NoError(t, err)
+	require.Len(t, results, 3)
+	assert.Equal(t, big.NewInt(11), returned(results, 0))
+	assert.Equal(t, vm.ErrExecutionReverted.Error(), results[1].Error)
+	assert.Equal(t, big.NewInt(12), returned(results, 2))
+
+	// Without the state overrides, the calls are executed against the state of the block.
+	results, err = EthDoMulticall(context.Background(), backend, calls[:1], blockNr, nil, true, 0, 0)
+	require.NoError(t, err)
+	require.Len(t, results, 1)
+	assert.Empty(t, results[0].ReturnData)
+}
+
+func TestEthDoMulticall_TooManyCalls(t *testing.T) {
+	mockCtrl := gomock.NewController(t)
+	defer mockCtrl.Finish()
+
+	// The state is not even resolved if there are too many calls.
+	backend := mock_api.NewMockBackend(mockCtrl)
+	calls := make([]EthTransactionArgs, maxMulticallSize+1)
+	_, err := EthDoMulticall(context.Background(), backend, calls, rpc.NewBlockNumberOrHashWithNumber(rpc.LatestBlockNumber), nil, false, 0, 0)
+	assert.EqualError(t, err, fmt.Sprintf("too many calls (have %d, max %d)", maxMulticallSize+1, maxMulticallSize))
+}
+
+func TestEthDoMulticall_Timeout(t *testing.T) {
+	mockCtrl := gomock.NewController(t)
+	defer mockCtrl.Finish()
+
+	var (
+		from    = common.HexToAddress("0x1111")
+		looper  = common.HexToAddress("0x2222")
+		to      = common.HexToAddress("0x3333")
+		gas     = hexutil.Uint64(1 << 62)
+		timeout = 10 * time.Millisecond
+		parent  = &types.Header{Number: big.NewInt(10), Time: big.NewInt(1700000000), BlockScore: big.NewInt(1)}
+	)
+	backend := newMulticallTestBackend(mockCtrl, parent)
+
+	// The timeout is applied to the whole calls, so the calls after the aborted one are not executed.
+	overrides := &EthStateOverride{looper: EthOverrideAccount{Code: &loopCode}}
+	calls := []EthTransactionArgs{
+		{From: &from, To: &to},
+		{From: &from, To: &looper, Gas: &gas},
+		{From: &from, To: &to},
+	}
+	_, err := EthDoMulticall(context.Background(), backend, calls, rpc.NewBlockNumberOrHashWithNumber(rpc.LatestBlockNumber), overrides, false, timeout, 0)
+	assert.EqualError(t, err, fmt.Sprintf("execution aborted at call 1 (timeout = %v)", timeout))
+}
@@ -0,0 +1,263 @@
+// Copyright 2022 The klaytn Authors
+// This file is part of the klaytn library.
+//
+// The klaytn library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The klaytn library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the klaytn library. If not, see <http://www.gnu.org/licenses/>.
+
+package api
+
+import (
+	"context"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"math/big"
+	"time"
+
+	"github.com/klaytn/klaytn/blockchain"
+	"github.com/klaytn/klaytn/blockchain/types"
+	"github.com/klaytn/klaytn/blockchain/vm"
+	"github.com/klaytn/klaytn/common"
+	"github.com/klaytn/klaytn/common/hexutil"
+	"github.com/klaytn/klaytn/crypto"
+	"github.com/klaytn/klaytn/networks/rpc"
+)
+
+// maxSimulateBlocks is the maximum number of blocks which can be simulated by a single SimulateV1.
+const maxSimulateBlocks = 256
+
+var errEmptySimulation = errors.New("empty simulation: no block is given")
+
+// EthSimBlock is a hypothetical block to be simulated on top of the previous block.
+type EthSimBlock struct {
+	BlockOverrides *EthBlockOverrides   `json:"blockOverrides"`
+	StateOverrides *EthStateOverride    `json:"stateOverrides"`
+	Calls          []EthTransactionArgs `json:"calls"`
+}
+
+// EthSimOpts is the input of SimulateV1.
+type EthSimOpts struct {
+	BlockStateCalls []EthSimBlock `json:"blockStateCalls"`
+	TraceCalls      bool          `json:"traceCalls"` // if true, the internal calls of each call are traced
+	Validation      bool          `json:"validation"` // if true, each call is validated like a transaction
+}
+
+// EthSimCallResult is the receipt-like result of a simulated call.
+type EthSimCallResult struct {
+	TransactionHash   common.Hash         `json:"transactionHash"`
+	TransactionIndex  hexutil.Uint64      `json:"transactionIndex"`
+	ReturnData        hexutil.Bytes       `json:"returnData"`
+	Status            hexutil.Uint64      `json:"status"`
+	GasUsed           hexutil.Uint64      `json:"gasUsed"`
+	CumulativeGasUsed hexutil.Uint64      `json:"cumulativeGasUsed"`
+	ContractAddress   *common.Address     `json:"contractAddress"`
+	Logs              []*types.Log        `json:"logs"`
+	LogsBloom         types.Bloom         `json:"logsBloom"`
+	Error             string              `json:"error,omitempty"`
+	Trace             *vm.InternalTxTrace `json:"trace,omitempty"`
+}
+
+// EthSimBlockResult is the result of a simulated block.
+type EthSimBlockResult struct {
+	Number        hexutil.Uint64      `json:"number"`
+	Hash          common.Hash         `json:"hash"`
+	ParentHash    common.Hash         `json:"parentHash"`
+	Timestamp     hexutil.Uint64      `json:"timestamp"`
+	GasUsed       hexutil.Uint64      `json:"gasUsed"`
+	BaseFeePerGas *hexutil.Big        `json:"baseFeePerGas,omitempty"`
+	Calls         []*EthSimCallResult `json:"calls"`
+}
+
+// SimulateV1 executes the given hypothetical blocks in order on top of the given block
+// and returns the receipts, logs and optionally the traces of the calls of each block.
+// The state changes made by a block are visible to the following blocks.
+func (api *EthereumAPI) SimulateV1(ctx context.Context, opts EthSimOpts, blockNrOrHash *rpc.BlockNumberOrHash) ([]*EthSimBlockResult, error) {
+	bcAPI := api.publicBlockChainAPI.b
+	bNrOrHash := rpc.NewBlockNumberOrHashWithNumber(rpc.LatestBlockNumber)
+	if blockNrOrHash != nil {
+		bNrOrHash = *blockNrOrHash
+	}
+	gasCap := uint64(0)
+	if rpcGasCap := bcAPI.RPCGasCap(); rpcGasCap != nil {
+		gasCap = rpcGasCap.Uint64()
+	}
+	return EthDoSimulate(ctx, bcAPI, opts, bNrOrHash, bcAPI.RPCEVMTimeout(), gasCap)
+}
+
+// EthDoSimulate simulates the blocks of opts on top of the given block.
+// The timeout is applied to the whole simulation.
+func EthDoSimulate(ctx context.Context, b Backend, opts EthSimOpts, blockNrOrHash rpc.BlockNumberOrHash, timeout time.Duration, globalGasCap uint64) ([]*EthSimBlockResult, error) {
+	defer func(start time.Time) {
+		logger.Debug("Executing EVM simulation finished", "blocks", len(opts.BlockStateCalls), "runtime", time.Since(start))
+	}(time.Now())
+
+	if len(opts.BlockStateCalls) == 0 {
+		return nil, errEmptySimulation
+	}
+	if len(opts.BlockStateCalls) > maxSimulateBlocks {
+		return nil, fmt.Errorf("too many blocks (have %d, max %d)", len(opts.BlockStateCalls), maxSimulateBlocks)
+	}
+	st, parent, err := b.StateAndHeaderByNumberOrHash(ctx, blockNrOrHash)
+	if st == nil || err != nil {
+		return nil, err
+	}
+
+	var cancel context.CancelFunc
+	if timeout > 0 {
+		ctx, cancel = context.WithTimeout(ctx, timeout)
+	} else {
+		ctx, cancel = context.WithCancel(ctx)
+	}
+	defer cancel()
+
+	results := make([]*EthSimBlockResult, 0, len(opts.BlockStateCalls))
+	for bi, block := range opts.BlockStateCalls {
+		header, err := makeSimulatedHeader(parent, block.BlockOverrides)
+		if err != nil {
+			return nil, fmt.Errorf("block %d: %w", bi, err)
+		}
+		if err := block.StateOverrides.Apply(st); err != nil {
+			return nil, fmt.Errorf("block %d: %w", bi, err)
+		}
+
+		var (
+			calls   = make([]*EthSimCallResult, len(block.Calls))
+			gasUsed uint64
+		)
+		for ti, args := range block.Calls {
+			txHash := simulatedTxHash(header.Number, ti)
+			st.Prepare(txHash, common.Hash{}, ti)
+
+			vmCfg := vm.Config{}
+			var tracer *vm.InternalTxTracer
+			if opts.TraceCalls {
+				tracer = vm.NewInternalTxTracer()
+				vmCfg = vm.Config{Debug: true, Tracer: tracer}
+			}
+
+			var contractAddr *common.Address
+			if args.To == nil {
+				addr := crypto.CreateAddress(args.from(), st.GetNonce(args.from()))
+				contractAddr = &addr
+			}
+
+			snapshot := st.Snapshot()
+			ret, gas, status, err := ethDoCallWithState(ctx, b, args, st, header, block.BlockOverrides, vmCfg, opts.Validation, timeout, globalGasCap)
+			if ctx.Err() != nil {
+				return nil, fmt.Errorf("execution aborted at block %d call %d (timeout = %v)", bi, ti, timeout)
+			}
+			if err != nil {
+				// An invalid call is not included in a block, so the whole simulation fails.
+				if opts.Validation {
+					return nil, fmt.Errorf("block %d call %d: %w", bi, ti, err)
+				}
+				st.RevertToSnapshot(snapshot)
+				calls[ti] = &EthSimCallResult{TransactionHash: txHash, TransactionIndex: hexutil.Uint64(ti), Error: err.Error()}
+				continue
+			}
+			gasUsed += gas
+
+			result := &EthSimCallResult{
+				TransactionHash:   txHash,
+				TransactionIndex:  hexutil.Uint64(ti),
+				ReturnData:        common.CopyBytes(ret),
+				GasUsed:           hexutil.Uint64(gas),
+				CumulativeGasUsed: hexutil.Uint64(gasUsed),
+				Logs:              st.GetLogs(txHash),
+			}
+			if status == types.ReceiptStatusSuccessful {
+				result.Status = hexutil.Uint64(types.ReceiptStatusSuccessful)
+				result.ContractAddress = contractAddr
+			} else {
+				vmErr := blockchain.GetVMerrFromReceiptStatus(status)
+				if isReverted(vmErr) && len(ret) > 0 {
+					vmErr = newRevertError(ret)
+				}
+				result.Error = vmErr.Error()
+			}
+			if result.Logs == nil {
+				result.Logs = []*types.Log{}
+			}
+			result.LogsBloom = types.BytesToBloom(types.LogsBloom(result.Logs).Bytes())
+			if tracer != nil {
+				if result.Trace, err = tracer.GetResult(); err != nil {
+					return nil, fmt.Errorf("block %d call %d: %w", bi, ti, err)
+				}
+			}
+			calls[ti] = result
+			st.Finalise(true, true)
+		}
+
+		header.GasUsed = gasUsed
+		hash := header.Hash()
+		for _, call := range calls {
+			for _, log := range call.Logs {
+				log.BlockHash = hash
+				log.BlockNumber = header.Number.Uint64()
+			}
+		}
+		blockResult := &EthSimBlockResult{
+			Number:     hexutil.Uint64(header.Number.Uint64()),
+			Hash:       hash,
+			ParentHash: header.ParentHash,
+			Timestamp:  hexutil.Uint64(header.Time.Uint64()),
+			GasUsed:    hexutil.Uint64(gasUsed),
+			Calls:      calls,
+		}
+		if header.BaseFee != nil {
+			blockResult.BaseFeePerGas = (*hexutil.Big)(header.BaseFee)
+		}
+		results = append(results, blockResult)
+		parent = header
+	}
+	return results, nil
+}
+
+// makeSimulatedHeader returns the header of a simulated block on top of the parent.
+// The number and the timestamp must increase; if not overridden, they are increased by one.
+func makeSimulatedHeader(parent *types.Header, overrides *EthBlockOverrides) (*types.Header, error) {
+	header := types.CopyHeader(parent)
+	header.ParentHash = parent.Hash()
+	header.Number = new(big.Int).Add(parent.Number, common.Big1)
+	header.Time = new(big.Int).Add(parent.Time, common.Big1)
+	header.GasUsed = 0
+	if overrides == nil {
+		return header, nil
+	}
+	if overrides.Number != nil {
+		if overrides.Number.ToInt().Cmp(parent.Number) <= 0 {
+			return nil, fmt.Errorf("block number %v must be greater than the parent number %v", overrides.Number.ToInt(), parent.Number)
+		}
+		header.Number = new(big.Int).Set(overrides.Number.ToInt())
+	}
+	if overrides.Time != nil {
+		if uint64(*overrides.Time) <= parent.Time.Uint64() {
+			return nil, fmt.Errorf("block timestamp %d must be greater than the parent timestamp %v", uint64(*overrides.Time), parent.Time)
+		}
+		header.Time = new(big.Int).SetUint64(uint64(*overrides.Time))
+	}
+	if overrides.Difficulty != nil {
+		header.BlockScore = new(big.Int).Set(overrides.Difficulty.ToInt())
+	}
+	if overrides.BaseFee != nil {
+		header.BaseFee = new(big.Int).Set(overrides.BaseFee.ToInt())
+	}
+	return header, nil
+}
+
+// simulatedTxHash returns a unique hash for the call at the given index of a simulated block.
+func simulatedTxHash(number *big.Int, index int) common.Hash {
+	var buf [8]byte
+	binary.BigEndian.PutUint64(buf[:], uint64(index))
+	return crypto.Keccak256Hash([]byte("simulated"), number.Bytes(), buf[:])
+}
@@ -0,0 +1,259 @@
+// Copyright 2022 The klaytn Authors
+// This file is part of the klaytn library.
+//
+// The klaytn library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The klaytn library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the klaytn library. If not, see <http://www.gnu.org/licenses/>.
+
+package api
+
+import (
+	"context"
+	"math/big"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	mock_api "github.com/klaytn/klaytn/api/mocks"
+	"github.com/klaytn/klaytn/blockchain"
+	"github.com/klaytn/klaytn/blockchain/state"
+	"github.com/klaytn/klaytn/blockchain/types"
+	"github.com/klaytn/klaytn/blockchain/vm"
+	"github.com/klaytn/klaytn/common"
+	"github.com/klaytn/klaytn/common/hexutil"
+	"github.com/klaytn/klaytn/networks/rpc"
+	"github.com/klaytn/klaytn/params"
+	"github.com/klaytn/klaytn/storage/database"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// newSimulateTestEVM returns an EVM executing the given message on top of the given header.
+func newSimulateTestEVM(ctx context.Context, msg blockchain.Message, st *state.StateDB, header *types.Header, vmCfg vm.Config) (*vm.EVM, func() error, error) {
+	blockCtx := vm.Context{
+		CanTransfer: blockchain.CanTransfer,
+		Transfer:    blockchain.Transfer,
+		GetHash:     func(uint64) common.Hash { return common.Hash{} },
+		Origin:      msg.ValidatedSender(),
+		BlockNumber: new(big.Int).Set(header.Number),
+		Time:        new(big.Int).Set(header.Time),
+		BlockScore:  new(big.Int).Set(header.BlockScore),
+		GasPrice:    msg.GasPrice(),
+		BaseFee:     new(big.Int),
+	}
+	return vm.NewEVM(blockCtx, st, dummyChainConfigForEthereumAPITest, &vmCfg), func() error { return nil }, nil
+}
+
+// newSimulateTestBackend returns a backend serving the given state on top of the given header,
+// and executing the calls with an EVM built from the header of each simulated block.
+func newSimulateTestBackend(mockCtrl *gomock.Controller, st *state.StateDB, parent *types.Header) *mock_api.MockBackend {
+	mockBackend := mock_api.NewMockBackend(mockCtrl)
+	mockBackend.EXPECT().StateAndHeaderByNumberOrHash(gomock.Any(), gomock.Any()).Return(st, parent, nil).AnyTimes()
+	mockBackend.EXPECT().ChainConfig().Return(dummyChainConfigForEthereumAPITest).AnyTimes()
+	mockBackend.EXPECT().GetEVM(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).DoAndReturn(newSimulateTestEVM).AnyTimes()
+	return mockBackend
+}
+
+func TestEthDoSimulate(t *testing.T) {
+	mockCtrl := gomock.NewController(t)
+	defer mockCtrl.Finish()
+
+	var (
+		from     = common.HexToAddress("0x1111")
+		to       = common.HexToAddress("0x2222")
+		contract = common.HexToAddress("0x3333")
+		// SSTORE(0, 1), LOG0(0, 0) and STOP
+		code   = hexutil.Bytes(common.FromHex("0x600160005560006000a000"))
+		gas    = hexutil.Uint64(100000)
+		value  = (*hexutil.Big)(big.NewInt(10))
+		parent = &types.Header{Number: big.NewInt(10), Time: big.NewInt(1700000000), BlockScore: big.NewInt(1)}
+	)
+	st, err := state.New(common.Hash{}, state.NewDatabase(database.NewMemoryDBManager()), nil)
+	require.NoError(t, err)
+	st.AddBalance(from, big.NewInt(100))
+	backend := newSimulateTestBackend(mockCtrl, st, parent)
+
+	timestamp := hexutil.Uint64(1700000100)
+	opts := EthSimOpts{BlockStateCalls: []EthSimBlock{
+		{
+			// The code of the contract is given by the state overrides.
+			StateOverrides: &EthStateOverride{contract: EthOverrideAccount{Code: &code}},
+			Calls: []EthTransactionArgs{
+				{From: &from, To: &contract, Gas: &gas},
+				{From: &from, To: &to, Gas: &gas, Value: value},
+			},
+		},
+		{
+			BlockOverrides: &EthBlockOverrides{Time: &timestamp},
+			Calls:          []EthTransactionArgs{{From: &from, To: &to, Gas: &gas, Value: value}},
+		},
+	}}
+	results, err := EthDoSimulate(context.Background(), backend, opts, rpc.NewBlockNumberOrHashWithNumber(rpc.LatestBlockNumber), 0, 0)
+	require.NoError(t, err)
+	require.Len(t, results, 2)
+
+	// The blocks are built on top of each other.
+	assert.Equal(t, hexutil.Uint64(11), results[0].Number)
+	assert.Equal(t, parent.Hash(), results[0].ParentHash)
+	assert.Equal(t, hexutil.Uint64(12), results[1].Number)
+	assert.Equal(t, results[0].Hash, results[1].ParentHash)
+	assert.Equal(t, timestamp, results[1].Timestamp)
+
+	// The logs and the gas used of each call are returned.
+	require.Len(t, results[0].Calls, 2)
+	call := results[0].Calls[0]
+	assert.Equal(t, hexutil.Uint64(types.ReceiptStatusSuccessful), call.Status)
+	assert.Empty(t, call.Error)
+	require.Len(t, call.Logs, 1)
+	assert.Equal(t, contract, call.Logs[0].Address)
+	assert.Equal(t, results[0].Hash, call.Logs[0].BlockHash)
+	assert.Equal(t, results[0].Calls[0].GasUsed+results[0].Calls[1].GasUsed, results[0].GasUsed)
+	assert.Equal(t, results[0].GasUsed, results[0].Calls[1].CumulativeGasUsed)
+
+	// The state changes are shared by the following calls and blocks.
+	assert.Equal(t, common.BigToHash(big.NewInt(1)), st.GetState(contract, common.Hash{}))
+	assert.Equal(t, big.NewInt(20), st.GetBalance(to))
+
+	// The simulation without any block is rejected.
+	_, err = EthDoSimulate(context.Background(), backend, EthSimOpts{}, rpc.NewBlockNumberOrHashWithNumber(rpc.LatestBlockNumber), 0, 0)
+	assert.Equal(t, errEmptySimulation, err)
+}
+
+func TestEthDoSimulate_FundedBalance(t *testing.T) {
+	mockCtrl := gomock.NewController(t)
+	defer mockCtrl.Finish()
+
+	var (
+		from     = common.HexToAddress("0x1111")
+		to       = common.HexToAddress("0x2222")
+		gas      = hexutil.Uint64(100000)
+		gasPrice = (*hexutil.Big)(big.NewInt(1))
+		parent   = &types.Header{Number: big.NewInt(10), Time: big.NewInt(1700000000), BlockScore: big.NewInt(1)}
+	)
+	st, err := state.New(common.Hash{}, state.NewDatabase(database.NewMemoryDBManager()), nil)
+	require.NoError(t, err)
+	backend := newSimulateTestBackend(mockCtrl, st, parent)
+
+	call := EthTransactionArgs{From: &from, To: &to, Gas: &gas, GasPrice: gasPrice}
+	opts := EthSimOpts{BlockStateCalls: []EthSimBlock{{Calls: []EthTransactionArgs{call, call}}, {Calls: []EthTransactionArgs{call}}}}
+	results, err := EthDoSimulate(context.Background(), backend, opts, rpc.NewBlockNumberOrHashWithNumber(rpc.LatestBlockNumber), 0, 0)
+	require.NoError(t, err)
+	for _, block := range results {
+		for _, call := range block.Calls {
+			assert.Empty(t, call.Error)
+		}
+	}
+	// The sender without balance is funded for each call, but the funds are not left in the shared state.
+	assert.Zero(t, st.GetBalance(from).Sign())
+
+	// The sender with enough balance is not funded, so only the fees are taken.
+	st.AddBalance(from, big.NewInt(1000000))
+	results, err = EthDoSimulate(context.Background(), backend, opts, rpc.NewBlockNumberOrHashWithNumber(rpc.LatestBlockNumber), 0, 0)
+	require.NoError(t, err)
+	var fees uint64
+	for _, block := range results {
+		fees += uint64(block.GasUsed)
+	}
+	assert.Equal(t, big.NewInt(int64(1000000-fees)), st.GetBalance(from))
+
+	// Only the gas fee is funded, so sending more value than the balance fails.
+	balance := st.GetBalance(from)
+	call.Value = (*hexutil.Big)(new(big.Int).Add(balance, common.Big1))
+	opts = EthSimOpts{BlockStateCalls: []EthSimBlock{{Calls: []EthTransactionArgs{call}}}}
+	results, err = EthDoSimulate(context.Background(), backend, opts, rpc.NewBlockNumberOrHashWithNumber(rpc.LatestBlockNumber), 0, 0)
+	require.NoError(t, err)
+	assert.NotEmpty(t, results[0].Calls[0].Error)
+	assert.Zero(t, st.GetBalance(to).Sign())
+}
+
+func TestEthDoEstimateGas_FailureTrace(t *testing.T) {
+	mockCtrl := gomock.NewController(t)
+	defer mockCtrl.Finish()
+
+	var (
+		from     = common.HexToAddress("0x1111")
+		contract = common.HexToAddress("0x3333")
+		// MSTORE(0, 42) and REVERT(0, 32)
+		code   = common.FromHex("0x602a60005260206000fd")
+		parent = &types.Header{Number: big.NewInt(10), Time: big.NewInt(1700000000), BlockScore: big.NewInt(1)}
+		reason = hexutil.Encode(common.BigToHash(big.NewInt(42)).Bytes())
+	)
+	st, err := state.New(common.Hash{}, state.NewDatabase(database.NewMemoryDBManager()), nil)
+	require.NoError(t, err)
+	st.SetCode(contract, code)
+	backend := newSimulateTestBackend(mockCtrl, st, parent)
+	args := EthTransactionArgs{From: &from, To: &contract}
+	blockNrOrHash := rpc.NewBlockNumberOrHashWithNumber(rpc.LatestBlockNumber)
+
+	// The revert reason is returned without the trace by default.
+	_, err = EthDoEstimateGas(context.Background(), backend, args, blockNrOrHash, 1000000)
+	require.Error(t, err)
+	revertErr, ok := err.(*revertError)
+	require.True(t, ok)
+	assert.Equal(t, reason, revertErr.ErrorData())
+
+	// The call trace of the failed execution is returned together if requested.
+	_, err = ethDoEstimateGas(context.Background(), backend, args, blockNrOrHash, 1000000, true)
+	require.Error(t, err)
+	estimateErr, ok := err.(*estimateGasError)
+	require.True(t, ok)
+	assert.EqualError(t, estimateErr, "execution reverted")
+	assert.Equal(t, 3, estimateErr.ErrorCode())
+	assert.Equal(t, reason, estimateErr.reason)
+	require.NotNil(t, estimateErr.trace)
+	assert.Equal(t, &contract, estimateErr.trace.To)
+}
+
+func TestEthDoEstimateGas(t *testing.T) {
+	mockCtrl := gomock.NewController(t)
+	defer mockCtrl.Finish()
+
+	var (
+		from     = common.HexToAddress("0x1111")
+		to       = common.HexToAddress("0x2222")
+		contract = common.HexToAddress("0x3333")
+		// SSTORE(0, 1), LOG0(0, 0) and STOP
+		code   = common.FromHex("0x600160005560006000a000")
+		parent = &types.Header{Number: big.NewInt(10), Time: big.NewInt(1700000000), BlockScore: big.NewInt(1)}
+	)
+	st, err := state.New(common.Hash{}, state.NewDatabase(database.NewMemoryDBManager()), nil)
+	require.NoError(t, err)
+	st.SetCode(contract, code)
+	root, err := st.Commit(true)
+	require.NoError(t, err)
+	db := st.Database()
+	backend := mock_api.NewMockBackend(mockCtrl)
+	backend.EXPECT().StateAndHeaderByNumberOrHash(gomock.Any(), gomock.Any()).DoAndReturn(
+		func(context.Context, rpc.BlockNumberOrHash) (*state.StateDB, *types.Header, error) {
+			// Every execution starts from the same state.
+			st, err := state.New(root, db, nil)
+			return st, parent, err
+		}).AnyTimes()
+	backend.EXPECT().ChainConfig().Return(dummyChainConfigForEthereumAPITest).AnyTimes()
+	backend.EXPECT().GetEVM(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).DoAndReturn(newSimulateTestEVM).AnyTimes()
+	blockNrOrHash := rpc.NewBlockNumberOrHashWithNumber(rpc.LatestBlockNumber)
+
+	// A value transfer needs the intrinsic gas only.
+	gas, err := EthDoEstimateGas(context.Background(), backend, EthTransactionArgs{From: &from, To: &to}, blockNrOrHash, 1000000)
+	require.NoError(t, err)
+	assert.Equal(t, hexutil.Uint64(params.TxGas), gas)
+
+	// The estimated gas is the lowest gas limit the call succeeds with.
+	args := EthTransactionArgs{From: &from, To: &contract}
+	gas, err = EthDoEstimateGas(context.Background(), backend, args, blockNrOrHash, 1000000)
+	require.NoError(t, err)
+	for _, limit := range []uint64{uint64(gas), uint64(gas) - 1} {
+		args.Gas = (*hexutil.Uint64)(&limit)
+		_, _, status, err := EthDoCall(context.Background(), backend, args, blockNrOrHash, nil, nil, 0, 1000000)
+		require.NoError(t, err)
+		assert.Equal(t, limit == uint64(gas), status == types.ReceiptStatusSuccessful, "gas limit %d", limit)
+	}
+}
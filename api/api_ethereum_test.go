@@ -3,12 +3,17 @@ package api
 import (
 	"bytes"
 	"context"
+	"crypto/ecdsa"
 	"encoding/json"
 	"errors"
 	"fmt"
+	"io/ioutil"
 	"math/big"
+	"os"
 	"reflect"
+	"strings"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/require"
 
@@ -16,16 +21,23 @@ import (
 	"github.com/klaytn/klaytn/governance"
 
 	"github.com/golang/mock/gomock"
+	"github.com/klaytn/klaytn"
 	"github.com/klaytn/klaytn/accounts"
+	"github.com/klaytn/klaytn/accounts/keystore"
 	mock_accounts "github.com/klaytn/klaytn/accounts/mocks"
 	mock_api "github.com/klaytn/klaytn/api/mocks"
 	"github.com/klaytn/klaytn/blockchain"
 	"github.com/klaytn/klaytn/blockchain/types"
 	"github.com/klaytn/klaytn/blockchain/types/accountkey"
+	"github.com/klaytn/klaytn/blockchain/vm"
 	"github.com/klaytn/klaytn/common"
 	"github.com/klaytn/klaytn/common/hexutil"
+	"github.com/klaytn/klaytn/consensus/misc"
 	"github.com/klaytn/klaytn/consensus/mocks"
+	"github.com/klaytn/klaytn/event"
 	"github.com/klaytn/klaytn/networks/rpc"
+	"github.com/klaytn/klaytn/node/cn/filters"
+	mock_filters "github.com/klaytn/klaytn/node/cn/filters/mock"
 	"github.com/klaytn/klaytn/params"
 	"github.com/klaytn/klaytn/rlp"
 	"github.com/klaytn/klaytn/storage/database"
@@ -104,6 +116,29 @@ func TestEthereumAPI_GetHashrate(t *testing.T) {
 	assert.Equal(t, ZeroHashrate, api.GetHashrate())
 }
 
+// TestEthereumAPI_Syncing tests that Syncing returns false when the node is synced,
+// and the progress of the downloader otherwise.
+func TestEthereumAPI_Syncing(t *testing.T) {
+	mockCtrl, mockBackend, api := testInitForEthApi(t)
+	defer mockCtrl.Finish()
+
+	mockBackend.EXPECT().Progress().Return(klaytn.SyncProgress{StartingBlock: 10, CurrentBlock: 100, HighestBlock: 100})
+	syncing, err := api.Syncing()
+	require.NoError(t, err)
+	assert.Equal(t, false, syncing)
+
+	mockBackend.EXPECT().Progress().Return(klaytn.SyncProgress{StartingBlock: 10, CurrentBlock: 50, HighestBlock: 100, PulledStates: 7, KnownStates: 9})
+	syncing, err = api.Syncing()
+	require.NoError(t, err)
+	assert.Equal(t, map[string]interface{}{
+		"startingBlock": hexutil.Uint64(10),
+		"currentBlock":  hexutil.Uint64(50),
+		"highestBlock":  hexutil.Uint64(100),
+		"pulledStates":  hexutil.Uint64(7),
+		"knownStates":   hexutil.Uint64(9),
+	}, syncing)
+}
+
 // TestEthereumAPI_GetUncleByBlockNumberAndIndex tests GetUncleByBlockNumberAndIndex.
 func TestEthereumAPI_GetUncleByBlockNumberAndIndex(t *testing.T) {
 	api := &EthereumAPI{}
@@ -120,6 +155,46 @@ func TestEthereumAPI_GetUncleByBlockHashAndIndex(t *testing.T) {
 	assert.Nil(t, uncleBlock)
 }
 
+// TestEthereumAPI_UnclesOverRPC tests that the uncle APIs respond as go-ethereum does for the blocks
+// without uncles, so that SDKs do not regard the responses as errors: null for the uncles, 0x0 for the
+// uncle counts of the existing blocks, and null for the uncle counts of the non-existing blocks.
+func TestEthereumAPI_UnclesOverRPC(t *testing.T) {
+	mockCtrl, mockBackend, api := testInitForEthApi(t)
+	defer mockCtrl.Finish()
+
+	block, _, _, _, _ := createTestData(t, nil)
+	mockBackend.EXPECT().BlockByNumber(gomock.Any(), rpc.BlockNumber(block.Number().Int64())).Return(block, nil).AnyTimes()
+	mockBackend.EXPECT().BlockByNumber(gomock.Any(), rpc.BlockNumber(100)).Return(nil, errors.New("the block does not exist")).AnyTimes()
+	mockBackend.EXPECT().BlockByHash(gomock.Any(), block.Hash()).Return(block, nil).AnyTimes()
+	mockBackend.EXPECT().BlockByHash(gomock.Any(), common.Hash{}).Return(nil, errors.New("the block does not exist")).AnyTimes()
+
+	server := rpc.NewServer()
+	defer server.Stop()
+	require.NoError(t, server.RegisterName("eth", &api))
+	client := rpc.DialInProc(server)
+	defer client.Close()
+
+	number := hexutil.EncodeBig(block.Number())
+	testcases := []struct {
+		method   string
+		args     []interface{}
+		expected string
+	}{
+		{"eth_getUncleByBlockNumberAndIndex", []interface{}{number, "0x0"}, "null"},
+		{"eth_getUncleByBlockNumberAndIndex", []interface{}{"0x64", "0x0"}, "null"},
+		{"eth_getUncleByBlockHashAndIndex", []interface{}{block.Hash(), "0x0"}, "null"},
+		{"eth_getUncleCountByBlockNumber", []interface{}{number}, `"0x0"`},
+		{"eth_getUncleCountByBlockNumber", []interface{}{"0x64"}, "null"},
+		{"eth_getUncleCountByBlockHash", []interface{}{block.Hash()}, `"0x0"`},
+		{"eth_getUncleCountByBlockHash", []interface{}{common.Hash{}}, "null"},
+	}
+	for _, tc := range testcases {
+		var result json.RawMessage
+		require.NoError(t, client.Call(&result, tc.method, tc.args...), tc.method)
+		assert.Equal(t, tc.expected, string(result), "%s %v", tc.method, tc.args)
+	}
+}
+
 // TestTestEthereumAPI_GetUncleCountByBlockNumber tests GetUncleCountByBlockNumber.
 func TestTestEthereumAPI_GetUncleCountByBlockNumber(t *testing.T) {
 	mockCtrl, mockBackend, api := testInitForEthApi(t)
@@ -286,6 +361,57 @@ func testGetHeader(t *testing.T, testAPIName string, forkEnabled bool) {
 	checkEthereumBlockOrHeaderFormat(t, expected, ethHeader)
 }
 
+// TestEthereumAPI_GetHeadersByRange tests GetHeadersByRange.
+func TestEthereumAPI_GetHeadersByRange(t *testing.T) {
+	mockCtrl, mockBackend, api := testInitForEthApi(t)
+	defer mockCtrl.Finish()
+
+	const head = 10
+	mockEngine := mocks.NewMockEngine(mockCtrl)
+	mockBackend.EXPECT().Engine().Return(mockEngine).AnyTimes()
+	mockBackend.EXPECT().ChainConfig().Return(dummyChainConfigForEthereumAPITest).AnyTimes()
+	mockEngine.EXPECT().Author(gomock.Any()).Return(common.Address{}, nil).AnyTimes()
+	mockBackend.EXPECT().GetTd(gomock.Any()).Return(big.NewInt(1)).AnyTimes()
+	mockBackend.EXPECT().CurrentBlock().Return(
+		types.NewBlockWithHeader(&types.Header{Number: big.NewInt(head), Time: big.NewInt(0), BlockScore: big.NewInt(1)})).AnyTimes()
+	mockBackend.EXPECT().HeaderByNumber(gomock.Any(), gomock.Any()).DoAndReturn(
+		func(ctx context.Context, number rpc.BlockNumber) (*types.Header, error) {
+			return &types.Header{Number: big.NewInt(number.Int64()), Time: big.NewInt(0), BlockScore: big.NewInt(1)}, nil
+		}).AnyTimes()
+
+	numbers := func(headers []map[string]interface{}) []uint64 {
+		result := make([]uint64, len(headers))
+		for i, header := range headers {
+			result[i] = header["number"].(*hexutil.Big).ToInt().Uint64()
+		}
+		return result
+	}
+	testcases := []struct {
+		start    rpc.BlockNumber
+		count    hexutil.Uint64
+		reverse  bool
+		expected []uint64
+	}{
+		{3, 3, false, []uint64{3, 4, 5}},
+		{3, 3, true, []uint64{3, 2, 1}},
+		{8, 5, false, []uint64{8, 9, 10}},
+		{1, 5, true, []uint64{1, 0}},
+		{rpc.LatestBlockNumber, 2, true, []uint64{10, 9}},
+		{11, 2, false, []uint64{}},
+		{5, 0, false, []uint64{}},
+	}
+	for _, tc := range testcases {
+		headers, err := api.GetHeadersByRange(context.Background(), tc.start, tc.count, tc.reverse)
+		require.NoError(t, err)
+		assert.Equal(t, tc.expected, numbers(headers), "start %d, count %d, reverse %v", tc.start, tc.count, tc.reverse)
+	}
+
+	_, err := api.GetHeadersByRange(context.Background(), 0, hexutil.Uint64(MaxHeadersByRange+1), false)
+	assert.Equal(t, errTooManyHeaders, err)
+	_, err = api.GetHeadersByRange(context.Background(), rpc.PendingBlockNumber, 1, false)
+	assert.Equal(t, errPendingHeadersByRange, err)
+}
+
 // TestEthereumAPI_GetBlockByNumber tests GetBlockByNumber.
 func TestEthereumAPI_GetBlockByNumber(t *testing.T) {
 	testGetBlock(t, "GetBlockByNumber", false)
@@ -934,6 +1060,63 @@ func TestEthereumAPI_GetTransactionByHashFromPool(t *testing.T) {
 	mockCtrl.Finish()
 }
 
+// TestEthereumAPI_GetTransactionByHashUnknown tests GetTransactionByHash with a transaction
+// which is neither in the chain nor in the transaction pool.
+func TestEthereumAPI_GetTransactionByHashUnknown(t *testing.T) {
+	mockCtrl, mockBackend, api := testInitForEthApi(t)
+	defer mockCtrl.Finish()
+	block, _, txHashMap, _, _ := createTestData(t, nil)
+
+	mockDBManager := &MockDatabaseManager{txHashMap: txHashMap, blockData: block, queryFromPool: true}
+	mockBackend.EXPECT().ChainDB().Return(mockDBManager)
+	mockBackend.EXPECT().GetPoolTransaction(gomock.Any()).Return(nil)
+	mockBackend.EXPECT().IsTxLookupSupported().Return(true)
+	mockBackend.EXPECT().TxIndexDone().Return(true)
+
+	ethTx, err := api.GetTransactionByHash(context.Background(), common.HexToHash("0x1234"))
+	assert.NoError(t, err)
+	assert.Nil(t, ethTx)
+}
+
+// TestEthereumAPI_GetTransactionByHashUnindexed tests GetTransactionByHash with a transaction
+// which is not found while the transactions of the old blocks are being unindexed.
+func TestEthereumAPI_GetTransactionByHashUnindexed(t *testing.T) {
+	mockCtrl, mockBackend, api := testInitForEthApi(t)
+	defer mockCtrl.Finish()
+	block, _, txHashMap, _, _ := createTestData(t, nil)
+
+	mockDBManager := &MockDatabaseManager{txHashMap: txHashMap, blockData: block, queryFromPool: true}
+	mockBackend.EXPECT().ChainDB().Return(mockDBManager)
+	mockBackend.EXPECT().GetPoolTransaction(gomock.Any()).Return(nil)
+	mockBackend.EXPECT().IsTxLookupSupported().Return(true)
+	mockBackend.EXPECT().TxIndexDone().Return(false)
+
+	ethTx, err := api.GetTransactionByHash(context.Background(), common.HexToHash("0x1234"))
+	assert.Equal(t, errTxIndexOutOfRange, err)
+	assert.Nil(t, ethTx)
+}
+
+// TestEthereumAPI_GetTransactionByHashLightMode tests GetTransactionByHash with a transaction
+// which is not found on a light node, which does not index the transactions by hash.
+func TestEthereumAPI_GetTransactionByHashLightMode(t *testing.T) {
+	mockCtrl, mockBackend, api := testInitForEthApi(t)
+	defer mockCtrl.Finish()
+	block, _, txHashMap, _, _ := createTestData(t, nil)
+
+	mockDBManager := &MockDatabaseManager{txHashMap: txHashMap, blockData: block, queryFromPool: true}
+	mockBackend.EXPECT().ChainDB().Return(mockDBManager).Times(2)
+	mockBackend.EXPECT().GetPoolTransaction(gomock.Any()).Return(nil).Times(2)
+	mockBackend.EXPECT().IsTxLookupSupported().Return(false).Times(2)
+
+	ethTx, err := api.GetTransactionByHash(context.Background(), common.HexToHash("0x1234"))
+	assert.Equal(t, errTxLookupUnsupported, err)
+	assert.Nil(t, ethTx)
+
+	klayTx, err := api.publicTransactionPoolAPI.GetTransactionByHash(context.Background(), common.HexToHash("0x1234"))
+	assert.Equal(t, errTxLookupUnsupported, err)
+	assert.Nil(t, klayTx)
+}
+
 // TestEthereumAPI_PendingTransactionstests PendingTransactions.
 func TestEthereumAPI_PendingTransactions(t *testing.T) {
 	mockCtrl, mockBackend, api := testInitForEthApi(t)
@@ -960,6 +1143,35 @@ func TestEthereumAPI_PendingTransactions(t *testing.T) {
 	mockCtrl.Finish()
 }
 
+// TestEthereumAPI_PendingTransactionsNonceGap tests PendingTransactions excludes the transactions behind a nonce gap.
+func TestEthereumAPI_PendingTransactionsNonceGap(t *testing.T) {
+	mockCtrl, mockBackend, api := testInitForEthApi(t)
+	defer mockCtrl.Finish()
+
+	localKey, _ := crypto.GenerateKey()
+	remoteKey, _ := crypto.GenerateKey()
+	signer := types.LatestSignerForChainID(dummyChainConfigForEthereumAPITest.ChainID)
+	newTx := func(nonce uint64, key *ecdsa.PrivateKey) *types.Transaction {
+		tx, err := types.SignTx(types.NewTransaction(nonce, common.HexToAddress("0x1111"), big.NewInt(1), 21000, big.NewInt(25*params.Ston), nil), signer, key)
+		assert.NoError(t, err)
+		return tx
+	}
+	local := types.Transactions{newTx(1, localKey), newTx(0, localKey), newTx(3, localKey)}
+	remote := types.Transactions{newTx(0, remoteKey)}
+
+	mockAccountManager := mock_accounts.NewMockAccountManager(mockCtrl)
+	mockBackend.EXPECT().AccountManager().Return(mockAccountManager)
+	mockBackend.EXPECT().GetPoolTransactions().Return(append(local, remote...), nil)
+	mockAccountManager.EXPECT().Wallets().Return([]accounts.Wallet{NewMockWallet(local)})
+
+	pendingTxs, err := api.PendingTransactions()
+	assert.NoError(t, err)
+	if assert.Len(t, pendingTxs, 2) {
+		assert.Equal(t, local[1].Hash(), pendingTxs[0].Hash)
+		assert.Equal(t, local[0].Hash(), pendingTxs[1].Hash)
+	}
+}
+
 // TestEthereumAPI_GetTransactionReceipt tests GetTransactionReceipt.
 func TestEthereumAPI_GetTransactionReceipt(t *testing.T) {
 	mockCtrl, mockBackend, api := testInitForEthApi(t)
@@ -1146,7 +1358,7 @@ func checkEthTransactionReceiptFormat(t *testing.T, block *types.Block, receipts
 	if !ok {
 		t.Fatal("effectiveGasPrice is not defined in Ethereum transaction receipt format.")
 	}
-	assert.Equal(t, effectiveGasPrice, hexutil.Uint64(kReceipt["gasPrice"].(*hexutil.Big).ToInt().Uint64()))
+	assert.Equal(t, kReceipt["gasPrice"].(*hexutil.Big).String(), effectiveGasPrice.(*hexutil.Big).String())
 
 	status, ok := ethReceipt["status"]
 	if !ok {
@@ -2039,7 +2251,6 @@ func TestEthTransactionArgs_setDefaults(t *testing.T) {
 		expectedResult      EthTransactionArgs
 		dynamicFeeParamsSet bool
 		nonceSet            bool
-		chainIdSet          bool
 		expectedError       error
 	}{
 		{
@@ -2073,7 +2284,6 @@ func TestEthTransactionArgs_setDefaults(t *testing.T) {
 			},
 			dynamicFeeParamsSet: false,
 			nonceSet:            false,
-			chainIdSet:          false,
 			expectedError:       nil,
 		},
 		{
@@ -2107,7 +2317,6 @@ func TestEthTransactionArgs_setDefaults(t *testing.T) {
 			},
 			dynamicFeeParamsSet: false,
 			nonceSet:            false,
-			chainIdSet:          false,
 			expectedError:       nil,
 		},
 		{
@@ -2128,7 +2337,6 @@ func TestEthTransactionArgs_setDefaults(t *testing.T) {
 			expectedResult:      EthTransactionArgs{},
 			dynamicFeeParamsSet: false,
 			nonceSet:            false,
-			chainIdSet:          false,
 			expectedError:       fmt.Errorf("only %s is allowed to be used as maxFeePerGas and maxPriorityPerGas", unitPrice.Text(16)),
 		},
 		{
@@ -2162,7 +2370,6 @@ func TestEthTransactionArgs_setDefaults(t *testing.T) {
 			},
 			dynamicFeeParamsSet: false,
 			nonceSet:            false,
-			chainIdSet:          false,
 			expectedError:       nil,
 		},
 		{
@@ -2183,7 +2390,6 @@ func TestEthTransactionArgs_setDefaults(t *testing.T) {
 			expectedResult:      EthTransactionArgs{},
 			dynamicFeeParamsSet: false,
 			nonceSet:            false,
-			chainIdSet:          false,
 			expectedError:       fmt.Errorf("only %s is allowed to be used as maxFeePerGas and maxPriorityPerGas", unitPrice.Text(16)),
 		},
 		{
@@ -2204,7 +2410,6 @@ func TestEthTransactionArgs_setDefaults(t *testing.T) {
 			expectedResult:      EthTransactionArgs{},
 			dynamicFeeParamsSet: false,
 			nonceSet:            false,
-			chainIdSet:          false,
 			expectedError:       errors.New("both gasPrice and (maxFeePerGas or maxPriorityFeePerGas) specified"),
 		},
 		{
@@ -2238,7 +2443,6 @@ func TestEthTransactionArgs_setDefaults(t *testing.T) {
 			},
 			dynamicFeeParamsSet: true,
 			nonceSet:            false,
-			chainIdSet:          false,
 			expectedError:       nil,
 		},
 		{
@@ -2272,7 +2476,6 @@ func TestEthTransactionArgs_setDefaults(t *testing.T) {
 			},
 			dynamicFeeParamsSet: false,
 			nonceSet:            true,
-			chainIdSet:          false,
 			expectedError:       nil,
 		},
 		{
@@ -2306,7 +2509,6 @@ func TestEthTransactionArgs_setDefaults(t *testing.T) {
 			},
 			dynamicFeeParamsSet: true,
 			nonceSet:            true,
-			chainIdSet:          false,
 			expectedError:       nil,
 		},
 		{
@@ -2324,24 +2526,10 @@ func TestEthTransactionArgs_setDefaults(t *testing.T) {
 				AccessList:           nil,
 				ChainID:              (*hexutil.Big)(new(big.Int).SetUint64(1234)),
 			},
-			expectedResult: EthTransactionArgs{
-				From:                 &from,
-				To:                   &to,
-				Gas:                  &gas,
-				GasPrice:             nil,
-				MaxFeePerGas:         (*hexutil.Big)(unitPrice),
-				MaxPriorityFeePerGas: (*hexutil.Big)(unitPrice),
-				Value:                (*hexutil.Big)(value),
-				Nonce:                (*hexutil.Uint64)(&accountNonce),
-				Data:                 (*hexutil.Bytes)(&byteCode),
-				Input:                nil,
-				AccessList:           nil,
-				ChainID:              (*hexutil.Big)(new(big.Int).SetUint64(1234)),
-			},
+			expectedResult:      EthTransactionArgs{},
 			dynamicFeeParamsSet: true,
 			nonceSet:            true,
-			chainIdSet:          true,
-			expectedError:       nil,
+			expectedError:       errors.New("chainId does not match node's (have=1234, want=111111)"),
 		},
 		{
 			txArgs: EthTransactionArgs{
@@ -2361,7 +2549,6 @@ func TestEthTransactionArgs_setDefaults(t *testing.T) {
 			expectedResult:      EthTransactionArgs{},
 			dynamicFeeParamsSet: true,
 			nonceSet:            true,
-			chainIdSet:          true,
 			expectedError:       errors.New(`both "data" and "input" are set and not equal. Please use "input" to pass transaction call data`),
 		},
 	}
@@ -2376,9 +2563,8 @@ func TestEthTransactionArgs_setDefaults(t *testing.T) {
 		if !test.nonceSet {
 			mockBackend.EXPECT().GetPoolNonce(context.Background(), gomock.Any()).Return(poolNonce)
 		}
-		if !test.chainIdSet {
-			mockBackend.EXPECT().ChainConfig().Return(dummyChainConfigForEthereumAPITest)
-		}
+		// The chain config is read to fill or to validate the chain id.
+		mockBackend.EXPECT().ChainConfig().Return(dummyChainConfigForEthereumAPITest)
 		mockBackend.EXPECT().RPCGasCap().Return(nil)
 		txArgs := test.txArgs
 		err := txArgs.setDefaults(context.Background(), mockBackend)
@@ -2389,6 +2575,40 @@ func TestEthTransactionArgs_setDefaults(t *testing.T) {
 	}
 }
 
+// TestEthTransactionArgs_setDefaultsTipCap tests that the tip is suggested apart from the gas price after Magma.
+func TestEthTransactionArgs_setDefaultsTipCap(t *testing.T) {
+	_, mockBackend, _ := testInitForEthApi(t)
+	var (
+		gas      = hexutil.Uint64(21000)
+		nonce    = hexutil.Uint64(0)
+		to       = common.HexToAddress("0x9712f943b296758aaae79944ec975884188d3a96")
+		baseFee  = big.NewInt(25 * params.Ston)
+		gasPrice = new(big.Int).Mul(baseFee, common.Big2)
+	)
+	testSet := []struct {
+		tipCap               *big.Int
+		expectedMaxFeePerGas *big.Int
+	}{
+		{big.NewInt(params.Ston), gasPrice},
+		// The default fee cap is raised to the tip
+		{big.NewInt(60 * params.Ston), big.NewInt(60 * params.Ston)},
+	}
+	for _, test := range testSet {
+		mockBackend.EXPECT().CurrentBlock().Return(
+			types.NewBlockWithHeader(&types.Header{Number: big.NewInt(10), BaseFee: baseFee}),
+		)
+		mockBackend.EXPECT().SuggestPrice(gomock.Any()).Return(gasPrice, nil)
+		mockBackend.EXPECT().SuggestTipCap(gomock.Any()).Return(test.tipCap, nil)
+		mockBackend.EXPECT().ChainConfig().Return(dummyChainConfigForEthereumAPITest).AnyTimes()
+
+		txArgs := EthTransactionArgs{To: &to, Gas: &gas, Nonce: &nonce}
+		require.NoError(t, txArgs.setDefaults(context.Background(), mockBackend))
+		assert.Equal(t, test.tipCap, txArgs.MaxPriorityFeePerGas.ToInt())
+		assert.Equal(t, test.expectedMaxFeePerGas, txArgs.MaxFeePerGas.ToInt())
+		assert.Nil(t, txArgs.GasPrice)
+	}
+}
+
 func TestEthereumAPI_GetRawTransactionByHash(t *testing.T) {
 	mockCtrl, mockBackend, api := testInitForEthApi(t)
 	block, txs, txHashMap, _, _ := createEthereumTypedTestData(t, nil)
@@ -2431,3 +2651,409 @@ func TestEthereumAPI_GetRawTransactionByBlockNumberAndIndex(t *testing.T) {
 
 	mockCtrl.Finish()
 }
+
+func TestEthBlockOverrides_Apply(t *testing.T) {
+	var (
+		number    = hexutil.Big(*big.NewInt(100))
+		timestamp = hexutil.Uint64(1700000000)
+		coinbase  = common.HexToAddress("0x1234")
+		baseFee   = hexutil.Big(*big.NewInt(50))
+	)
+	blockCtx := vm.Context{
+		BlockNumber: big.NewInt(1),
+		Time:        big.NewInt(1),
+		BlockScore:  big.NewInt(1),
+		BaseFee:     big.NewInt(25),
+	}
+
+	// nil overrides do not change anything
+	var nilOverrides *EthBlockOverrides
+	nilOverrides.Apply(&blockCtx)
+	assert.Equal(t, big.NewInt(1), blockCtx.BlockNumber)
+
+	overrides := &EthBlockOverrides{Number: &number, Time: &timestamp, Coinbase: &coinbase, BaseFee: &baseFee}
+	overrides.Apply(&blockCtx)
+	assert.Equal(t, big.NewInt(100), blockCtx.BlockNumber)
+	assert.Equal(t, big.NewInt(1700000000), blockCtx.Time)
+	assert.Equal(t, coinbase, blockCtx.Coinbase)
+	assert.Equal(t, big.NewInt(50), blockCtx.BaseFee)
+	assert.Equal(t, big.NewInt(1), blockCtx.BlockScore)
+
+	// random overrides the hash of the parent block of the overridden number, which is returned by PREVRANDAO.
+	random := common.HexToHash("0xabcd")
+	blockCtx.GetHash = func(n uint64) common.Hash { return common.BigToHash(new(big.Int).SetUint64(n)) }
+	overrides = &EthBlockOverrides{Number: &number, Random: &random}
+	overrides.Apply(&blockCtx)
+	assert.Equal(t, random, blockCtx.GetHash(99))
+	assert.Equal(t, common.BigToHash(big.NewInt(98)), blockCtx.GetHash(98))
+}
+
+// TestEthereumAPI_GetLogs tests that GetLogs filters the logs of the block given by its hash
+// with the address and topic criteria decoded from an Ethereum-style request.
+func TestEthereumAPI_GetLogs(t *testing.T) {
+	mockCtrl := gomock.NewController(t)
+	defer mockCtrl.Finish()
+
+	var (
+		backend = mock_filters.NewMockBackend(mockCtrl)
+		addr1   = common.HexToAddress("0x1111")
+		addr2   = common.HexToAddress("0x2222")
+		topic1  = common.HexToHash("0xaaaa")
+		topic2  = common.HexToHash("0xbbbb")
+		txHash  = common.HexToHash("0xcccc")
+		logs    = []*types.Log{
+			{Address: addr1, Topics: []common.Hash{topic1}, TxHash: txHash, Index: 0},
+			{Address: addr2, Topics: []common.Hash{topic1, topic2}, TxHash: txHash, Index: 1},
+		}
+		header = &types.Header{
+			Number: big.NewInt(10),
+			Bloom:  types.CreateBloom(types.Receipts{{Logs: logs}}),
+		}
+		unknownHash = common.HexToHash("0xdddd")
+	)
+	expectFilterEvents(backend, new(testFilterFeeds))
+	backend.EXPECT().HeaderByHash(gomock.Any(), header.Hash()).Return(header, nil).AnyTimes()
+	backend.EXPECT().HeaderByHash(gomock.Any(), unknownHash).Return(nil, nil).AnyTimes()
+	backend.EXPECT().GetLogs(gomock.Any(), header.Hash()).Return([][]*types.Log{logs}, nil).AnyTimes()
+
+	api := NewEthereumAPI()
+	api.SetPublicFilterAPI(filters.NewPublicFilterAPI(backend, false))
+
+	tests := []struct {
+		request string
+		expLogs []*types.Log
+	}{
+		{fmt.Sprintf(`{"blockHash": "%s"}`, header.Hash().Hex()), logs},
+		{fmt.Sprintf(`{"blockHash": "%s", "address": "%s"}`, header.Hash().Hex(), addr2.Hex()), logs[1:]},
+		{fmt.Sprintf(`{"blockHash": "%s", "address": ["%s", "%s"]}`, header.Hash().Hex(), addr1.Hex(), addr2.Hex()), logs},
+		{fmt.Sprintf(`{"blockHash": "%s", "topics": ["%s"]}`, header.Hash().Hex(), topic1.Hex()), logs},
+		{fmt.Sprintf(`{"blockHash": "%s", "topics": [null, "%s"]}`, header.Hash().Hex(), topic2.Hex()), logs[1:]},
+		{fmt.Sprintf(`{"blockHash": "%s", "topics": ["%s"]}`, header.Hash().Hex(), topic2.Hex()), []*types.Log{}},
+	}
+	for i, tt := range tests {
+		var crit filters.FilterCriteria
+		require.NoError(t, json.Unmarshal([]byte(tt.request), &crit), "test %d", i)
+		found, err := api.GetLogs(context.Background(), crit)
+		require.NoError(t, err, "test %d", i)
+		assert.Equal(t, tt.expLogs, found, "test %d", i)
+	}
+
+	// A block hash cannot be combined with a block range.
+	var crit filters.FilterCriteria
+	assert.Error(t, json.Unmarshal([]byte(fmt.Sprintf(`{"blockHash": "%s", "fromBlock": "0x1"}`, header.Hash().Hex())), &crit))
+
+	// An unknown block is reported as an error like Ethereum does.
+	_, err := api.GetLogs(context.Background(), filters.FilterCriteria{BlockHash: &unknownHash})
+	assert.Error(t, err)
+}
+
+// testFilterFeeds are the event feeds of the blockchain read by the filters.
+type testFilterFeeds struct {
+	txs, logs, rmLogs, chain event.Feed
+}
+
+// expectFilterEvents makes the mock filter backend serve the events sent to the given feeds.
+func expectFilterEvents(backend *mock_filters.MockBackend, feeds *testFilterFeeds) {
+	backend.EXPECT().ChainDB().Return(database.NewMemoryDBManager()).AnyTimes()
+	backend.EXPECT().EventMux().Return(new(event.TypeMux)).AnyTimes()
+	backend.EXPECT().SubscribeNewTxsEvent(gomock.Any()).DoAndReturn(func(ch chan<- blockchain.NewTxsEvent) event.Subscription {
+		return feeds.txs.Subscribe(ch)
+	}).AnyTimes()
+	backend.EXPECT().SubscribeLogsEvent(gomock.Any()).DoAndReturn(func(ch chan<- []*types.Log) event.Subscription {
+		return feeds.logs.Subscribe(ch)
+	}).AnyTimes()
+	backend.EXPECT().SubscribeRemovedLogsEvent(gomock.Any()).DoAndReturn(func(ch chan<- blockchain.RemovedLogsEvent) event.Subscription {
+		return feeds.rmLogs.Subscribe(ch)
+	}).AnyTimes()
+	backend.EXPECT().SubscribeChainEvent(gomock.Any()).DoAndReturn(func(ch chan<- blockchain.ChainEvent) event.Subscription {
+		return feeds.chain.Subscribe(ch)
+	}).AnyTimes()
+}
+
+// TestEthereumAPI_Subscriptions tests that eth_subscribe notifies the Ethereum-shaped headers of
+// the imported blocks and the logs matching the criteria.
+func TestEthereumAPI_Subscriptions(t *testing.T) {
+	mockCtrl, mockBackend, api := testInitForEthApi(t)
+	defer mockCtrl.Finish()
+
+	var (
+		filterBackend = mock_filters.NewMockBackend(mockCtrl)
+		feeds         = new(testFilterFeeds)
+		miner         = common.HexToAddress("0x9712f943b296758aaae79944ec975884188d3a96")
+		mockEngine    = mocks.NewMockEngine(mockCtrl)
+		header        = &types.Header{
+			Number:     big.NewInt(10),
+			BlockScore: big.NewInt(1),
+			Time:       big.NewInt(1700000000),
+			GasUsed:    21000,
+		}
+		block = types.NewBlockWithHeader(header)
+		log1  = &types.Log{Address: common.HexToAddress("0x1111"), TxHash: common.HexToHash("0xaaaa"), BlockNumber: 10}
+		log2  = &types.Log{Address: common.HexToAddress("0x2222"), TxHash: common.HexToHash("0xaaaa"), BlockNumber: 10, Index: 1}
+	)
+	expectFilterEvents(filterBackend, feeds)
+	mockBackend.EXPECT().Engine().Return(mockEngine).AnyTimes()
+	mockBackend.EXPECT().ChainConfig().Return(dummyChainConfigForEthereumAPITest).AnyTimes()
+	mockBackend.EXPECT().GetTd(gomock.Any()).Return(big.NewInt(10)).AnyTimes()
+	mockEngine.EXPECT().Author(gomock.Any()).Return(miner, nil).AnyTimes()
+	api.SetPublicFilterAPI(filters.NewPublicFilterAPI(filterBackend, false))
+
+	server := rpc.NewServer()
+	defer server.Stop()
+	require.NoError(t, server.RegisterName("eth", &api))
+	client := rpc.DialInProc(server)
+	defer client.Close()
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	heads := make(chan map[string]interface{}, 16)
+	headsSub, err := client.Subscribe(ctx, "eth", heads, "newHeads")
+	require.NoError(t, err)
+	defer headsSub.Unsubscribe()
+	logs := make(chan *types.Log, 16)
+	logsSub, err := client.Subscribe(ctx, "eth", logs, "logs", map[string]interface{}{"address": log2.Address})
+	require.NoError(t, err)
+	defer logsSub.Unsubscribe()
+
+	// The subscriptions are installed in the event system asynchronously, so the events are resent until notified.
+	var head map[string]interface{}
+	require.Eventually(t, func() bool {
+		feeds.chain.Send(blockchain.ChainEvent{Block: block, Hash: block.Hash()})
+		select {
+		case head = <-heads:
+			return true
+		case <-time.After(50 * time.Millisecond):
+			return false
+		}
+	}, 5*time.Second, 10*time.Millisecond)
+	assert.Equal(t, block.Hash().Hex(), head["hash"])
+	assert.Equal(t, "0xa", head["number"])
+	assert.Equal(t, strings.ToLower(miner.Hex()), head["miner"])
+	assert.Equal(t, EmptySha3Uncles, head["sha3Uncles"])
+	assert.Equal(t, "0x", head["extraData"])
+	assert.Contains(t, head, "baseFeePerGas")
+
+	var log *types.Log
+	require.Eventually(t, func() bool {
+		feeds.logs.Send([]*types.Log{log1, log2})
+		select {
+		case log = <-logs:
+			return true
+		case <-time.After(50 * time.Millisecond):
+			return false
+		}
+	}, 5*time.Second, 10*time.Millisecond)
+	assert.Equal(t, log2.Address, log.Address)
+	assert.Equal(t, log2.Index, log.Index)
+}
+
+// TestEthereumAPI_SignAndSendTransaction tests SignTransaction and SendTransaction with an unlocked account.
+func TestEthereumAPI_SignAndSendTransaction(t *testing.T) {
+	mockCtrl, mockBackend, api := testInitForEthApi(t)
+	defer mockCtrl.Finish()
+
+	dir, err := ioutil.TempDir("", "klay-keystore-test")
+	require.NoError(t, err)
+	defer os.RemoveAll(dir)
+	ks := keystore.NewKeyStore(dir, 2, 1)
+	acc, err := ks.ImportECDSA(senderPrvKey, "")
+	require.NoError(t, err)
+	require.NoError(t, ks.Unlock(acc, ""))
+
+	mockAccountManager := mock_accounts.NewMockAccountManager(mockCtrl)
+	mockAccountManager.EXPECT().Find(accounts.Account{Address: acc.Address}).Return(ks.Wallets()[0], nil).AnyTimes()
+	mockBackend.EXPECT().AccountManager().Return(mockAccountManager).AnyTimes()
+	mockBackend.EXPECT().CurrentBlock().Return(
+		types.NewBlockWithHeader(&types.Header{Number: new(big.Int).SetUint64(0)}),
+	).AnyTimes()
+	mockBackend.EXPECT().SuggestPrice(gomock.Any()).Return((*big.Int)(testGasPrice), nil).AnyTimes()
+	mockBackend.EXPECT().ChainConfig().Return(dummyChainConfigForEthereumAPITest).AnyTimes()
+	mockBackend.EXPECT().RPCTxFeeCap().Return(float64(1)).AnyTimes()
+
+	newArgs := func() EthTransactionArgs {
+		nonce, gas := testNonce, testGas
+		return EthTransactionArgs{
+			From:     &acc.Address,
+			To:       &testTo,
+			Gas:      &gas,
+			GasPrice: testGasPrice,
+			Value:    testValue,
+			Nonce:    &nonce,
+		}
+	}
+
+	// The signed transaction is returned with its signature and hash.
+	result, err := api.SignTransaction(context.Background(), newArgs())
+	require.NoError(t, err)
+	signed := new(types.Transaction)
+	require.NoError(t, signed.UnmarshalBinary(result.Raw))
+	assert.Equal(t, acc.Address, getFrom(signed))
+	assert.Equal(t, signed.Hash(), result.Tx.Hash)
+	assert.NotZero(t, result.Tx.R.ToInt().Sign())
+	assert.NotZero(t, result.Tx.S.ToInt().Sign())
+
+	// The transaction submitted to the pool is the same as the signed one.
+	mockBackend.EXPECT().SendTx(gomock.Any(), gomock.Any()).DoAndReturn(
+		func(ctx context.Context, tx *types.Transaction) error {
+			assert.Equal(t, signed.Hash(), tx.Hash())
+			return nil
+		})
+	hash, err := api.SendTransaction(context.Background(), newArgs())
+	require.NoError(t, err)
+	assert.Equal(t, signed.Hash(), hash)
+
+	// The transaction exceeding the fee cap is neither signed nor submitted.
+	args := newArgs()
+	args.GasPrice = (*hexutil.Big)(new(big.Int).Mul(big.NewInt(params.Ston), big.NewInt(1000000)))
+	_, err = api.SignTransaction(context.Background(), args)
+	assert.Error(t, err)
+	_, err = api.SendTransaction(context.Background(), args)
+	assert.Error(t, err)
+}
+
+// TestEthereumAPI_PollingFilters tests that the filters installed by eth_newBlockFilter, eth_newFilter and
+// eth_newPendingTransactionFilter return their changes through eth_getFilterChanges until they are uninstalled.
+func TestEthereumAPI_PollingFilters(t *testing.T) {
+	mockCtrl := gomock.NewController(t)
+	defer mockCtrl.Finish()
+
+	var (
+		backend = mock_filters.NewMockBackend(mockCtrl)
+		feeds   = new(testFilterFeeds)
+		block   = types.NewBlockWithHeader(&types.Header{Number: big.NewInt(10), BlockScore: big.NewInt(1)})
+		tx      = types.NewTransaction(0, common.HexToAddress("0x1111"), big.NewInt(1), 21000, big.NewInt(1), nil)
+		log1    = &types.Log{Address: common.HexToAddress("0x1111"), BlockNumber: 10}
+		log2    = &types.Log{Address: common.HexToAddress("0x2222"), BlockNumber: 10, Index: 1}
+	)
+	expectFilterEvents(backend, feeds)
+
+	api := NewEthereumAPI()
+	api.SetPublicFilterAPI(filters.NewPublicFilterAPI(backend, false))
+
+	blockFilter := api.NewBlockFilter()
+	txFilter := api.NewPendingTransactionFilter()
+	logFilter, err := api.NewFilter(filters.FilterCriteria{Addresses: []common.Address{log2.Address}})
+	require.NoError(t, err)
+
+	feeds.chain.Send(blockchain.ChainEvent{Block: block, Hash: block.Hash()})
+	feeds.txs.Send(blockchain.NewTxsEvent{Txs: types.Transactions{tx}})
+	feeds.logs.Send([]*types.Log{log1, log2})
+
+	// The changes are collected by the filters asynchronously, so they are polled until they arrive.
+	var blockHashes, txHashes []common.Hash
+	var logs []*types.Log
+	require.Eventually(t, func() bool {
+		if changes, err := api.GetFilterChanges(blockFilter); err == nil {
+			blockHashes = append(blockHashes, changes.([]common.Hash)...)
+		}
+		if changes, err := api.GetFilterChanges(txFilter); err == nil {
+			txHashes = append(txHashes, changes.([]common.Hash)...)
+		}
+		if changes, err := api.GetFilterChanges(logFilter); err == nil {
+			logs = append(logs, changes.([]*types.Log)...)
+		}
+		return len(blockHashes) > 0 && len(txHashes) > 0 && len(logs) > 0
+	}, 5*time.Second, 10*time.Millisecond)
+	assert.Equal(t, []common.Hash{block.Hash()}, blockHashes)
+	assert.Equal(t, []common.Hash{tx.Hash()}, txHashes)
+	assert.Equal(t, []*types.Log{log2}, logs)
+
+	// The changes are returned only once.
+	changes, err := api.GetFilterChanges(blockFilter)
+	require.NoError(t, err)
+	assert.Empty(t, changes)
+
+	// The uninstalled filters are not found anymore.
+	for _, id := range []rpc.ID{blockFilter, txFilter, logFilter} {
+		assert.True(t, api.UninstallFilter(id))
+		assert.False(t, api.UninstallFilter(id))
+		_, err := api.GetFilterChanges(id)
+		assert.Error(t, err)
+	}
+}
+
+// TestEthereumAPI_SendRawTransactionChainId tests that SendRawTransaction rejects the transactions signed for another chain.
+func TestEthereumAPI_SendRawTransactionChainId(t *testing.T) {
+	mockCtrl, mockBackend, api := testInitForEthApi(t)
+	defer mockCtrl.Finish()
+	mockBackend.EXPECT().ChainConfig().Return(dummyChainConfigForEthereumAPITest).AnyTimes()
+
+	signTx := func(chainId *big.Int) hexutil.Bytes {
+		tx := types.NewTransaction(0, testTo, big.NewInt(1), 21000, (*big.Int)(testGasPrice), nil)
+		signed, err := types.SignTx(tx, types.LatestSignerForChainID(chainId), senderPrvKey)
+		require.NoError(t, err)
+		raw, err := rlp.EncodeToBytes(signed)
+		require.NoError(t, err)
+		return raw
+	}
+
+	_, err := api.SendRawTransaction(context.Background(), signTx(big.NewInt(1)))
+	assert.EqualError(t, err, "invalid chain id of the transaction (have=1, want=111111)")
+
+	mockBackend.EXPECT().SendTx(gomock.Any(), gomock.Any()).Return(nil)
+	_, err = api.SendRawTransaction(context.Background(), signTx(dummyChainConfigForEthereumAPITest.ChainID))
+	assert.NoError(t, err)
+
+	_, err = api.SendRawTransaction(context.Background(), hexutil.Bytes{})
+	assert.Error(t, err)
+}
+
+// TestNewRevertError tests that the revert reason is returned as the message and the data of the error.
+func TestNewRevertError(t *testing.T) {
+	// The abi-encoded Error("reason") returned by require(false, "reason").
+	data := common.FromHex("0x08c379a0" +
+		"0000000000000000000000000000000000000000000000000000000000000020" +
+		"0000000000000000000000000000000000000000000000000000000000000006" +
+		"726561736f6e0000000000000000000000000000000000000000000000000000")
+	err := newRevertError(data)
+	assert.EqualError(t, err, "execution reverted: reason")
+	assert.Equal(t, 3, err.ErrorCode())
+	assert.Equal(t, hexutil.Encode(data), err.ErrorData())
+
+	// The data of a custom error is returned as it is.
+	custom := common.FromHex("0xcafebabe")
+	err = newRevertError(custom)
+	assert.EqualError(t, err, "execution reverted")
+	assert.Equal(t, "0xcafebabe", err.ErrorData())
+}
+
+// TestRpcOutputBlockBaseFee tests the base fee of the blocks before and after the magma hardfork.
+func TestRpcOutputBlockBaseFee(t *testing.T) {
+	var (
+		kip71      = params.GetDefaultKIP71Config()
+		magmaBlock = uint64(3)
+		parent     = &types.Header{Number: common.Big0, BlockScore: common.Big1, Time: common.Big0}
+		lowerBound = new(big.Int).SetUint64(kip71.LowerBoundBaseFee)
+	)
+	for num := uint64(1); num <= 5; num++ {
+		header := &types.Header{
+			ParentHash: parent.Hash(),
+			Number:     new(big.Int).SetUint64(num),
+			BlockScore: common.Big1,
+			Time:       new(big.Int).SetUint64(num),
+			// Use more gas than the gas target to raise the base fee of the next block.
+			GasUsed: kip71.MaxBlockGasUsedForBaseFee,
+		}
+		expected := new(big.Int).SetUint64(params.ZeroBaseFee)
+		if num >= magmaBlock {
+			header.BaseFee = misc.NextMagmaBlockBaseFee(parent, kip71)
+			expected = header.BaseFee
+		}
+
+		fields, err := RpcOutputBlock(types.NewBlockWithHeader(header), common.Big1, false, false, true)
+		require.NoError(t, err)
+		assert.Equal(t, (*hexutil.Big)(expected), fields["baseFeePerGas"], "block %d", num)
+
+		switch {
+		case num < magmaBlock:
+			assert.Zero(t, expected.Sign(), "block %d", num)
+		case num == magmaBlock:
+			// The first magma block starts from the lower bound.
+			assert.Equal(t, lowerBound, expected)
+		default:
+			// The base fee is raised by KIP-71 since the parent used more gas than the target.
+			assert.Equal(t, 1, expected.Cmp(parent.BaseFee), "block %d", num)
+		}
+		parent = header
+	}
+}
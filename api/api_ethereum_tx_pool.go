@@ -0,0 +1,80 @@
+// Copyright 2022 The klaytn Authors
+// This file is part of the klaytn library.
+//
+// The klaytn library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The klaytn library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the klaytn library. If not, see <http://www.gnu.org/licenses/>.
+
+package api
+
+import (
+	"fmt"
+
+	"github.com/klaytn/klaytn/blockchain/types"
+	"github.com/klaytn/klaytn/common"
+)
+
+// EthTxPoolAPI offers the go-ethereum compatible txpool APIs. The transactions are represented
+// in the same way as the eth namespace APIs, so Klaytn transactions follow the EthKlaytnTxMode.
+// It is registered over PublicTxPoolAPI, whose txpool_status is already compatible.
+type EthTxPoolAPI struct {
+	b      Backend
+	ethAPI *EthereumAPI
+}
+
+// NewEthTxPoolAPI creates a new tx pool service representing the transactions in the Ethereum format.
+func NewEthTxPoolAPI(b Backend, ethAPI *EthereumAPI) *EthTxPoolAPI {
+	return &EthTxPoolAPI{b, ethAPI}
+}
+
+// Content returns the transactions contained within the transaction pool.
+func (s *EthTxPoolAPI) Content() map[string]map[string]map[string]*EthRPCTransaction {
+	pending, queue := s.b.TxPoolContent()
+	return map[string]map[string]map[string]*EthRPCTransaction{
+		"pending": s.flatten(pending),
+		"queued":  s.flatten(queue),
+	}
+}
+
+// ContentFrom returns the transactions contained within the transaction pool
+// sent from the given address.
+func (s *EthTxPoolAPI) ContentFrom(addr common.Address) map[string]map[string]*EthRPCTransaction {
+	pending, queue := s.b.TxPoolContentFrom(addr)
+	return map[string]map[string]*EthRPCTransaction{
+		"pending": s.byNonce(pending),
+		"queued":  s.byNonce(queue),
+	}
+}
+
+// flatten converts the transactions of each account into a map from the nonce to the Ethereum transaction.
+func (s *EthTxPoolAPI) flatten(content map[common.Address]types.Transactions) map[string]map[string]*EthRPCTransaction {
+	result := make(map[string]map[string]*EthRPCTransaction)
+	for account, txs := range content {
+		result[account.Hex()] = s.byNonce(txs)
+	}
+	return result
+}
+
+// byNonce converts the transactions into a map from the nonce to the Ethereum transaction.
+func (s *EthTxPoolAPI) byNonce(txs types.Transactions) map[string]*EthRPCTransaction {
+	dump := make(map[string]*EthRPCTransaction, len(txs))
+	for _, tx := range txs {
+		dump[fmt.Sprintf("%d", tx.Nonce())] = newEthRPCPendingTransaction(tx, s.ethAPI.klaytnTxMode)
+	}
+	return dump
+}
+
+// Inspect retrieves the content of the transaction pool and flattens it into an
+// easily inspectable list.
+func (s *EthTxPoolAPI) Inspect() map[string]map[string]map[string]string {
+	return inspectTxPool(s.b, "wei")
+}
@@ -0,0 +1,80 @@
+// Copyright 2022 The klaytn Authors
+// This file is part of the klaytn library.
+//
+// The klaytn library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The klaytn library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the klaytn library. If not, see <http://www.gnu.org/licenses/>.
+
+package api
+
+import (
+	"math/big"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	mock_api "github.com/klaytn/klaytn/api/mocks"
+	"github.com/klaytn/klaytn/blockchain/types"
+	"github.com/klaytn/klaytn/common"
+	"github.com/klaytn/klaytn/common/hexutil"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestEthTxPoolAPI(t *testing.T) {
+	var (
+		from     = common.HexToAddress("0x1111")
+		feePayer = common.HexToAddress("0x2222")
+		to       = common.HexToAddress("0x3333")
+	)
+	mockCtrl := gomock.NewController(t)
+	defer mockCtrl.Finish()
+	mockBackend := mock_api.NewMockBackend(mockCtrl)
+
+	// A fee-delegated value transfer is pending, and a legacy transaction is queued.
+	pendingTx, err := types.NewTransactionWithMap(types.TxTypeFeeDelegatedValueTransfer, map[types.TxValueKeyType]interface{}{
+		types.TxValueKeyNonce:    uint64(0),
+		types.TxValueKeyTo:       to,
+		types.TxValueKeyAmount:   big.NewInt(1),
+		types.TxValueKeyGasLimit: uint64(100000),
+		types.TxValueKeyGasPrice: big.NewInt(25),
+		types.TxValueKeyFrom:     from,
+		types.TxValueKeyFeePayer: feePayer,
+	})
+	require.NoError(t, err)
+	queuedTx := types.NewTransaction(2, to, big.NewInt(2), 21000, big.NewInt(25), nil)
+	mockBackend.EXPECT().TxPoolContent().Return(
+		map[common.Address]types.Transactions{from: {pendingTx}},
+		map[common.Address]types.Transactions{from: {queuedTx}},
+	).Times(2)
+
+	ethAPI := NewEthereumAPI()
+	ethAPI.SetKlaytnTxMode(EthKlaytnTxModeFeePayer)
+	api := NewEthTxPoolAPI(mockBackend, ethAPI)
+
+	content := api.Content()
+	pending := content["pending"][from.Hex()]["0"]
+	require.NotNil(t, pending)
+	assert.Equal(t, pendingTx.Hash(), pending.Hash)
+	assert.Equal(t, hexutil.Uint64(types.TxTypeLegacyTransaction), pending.Type)
+	assert.Equal(t, &feePayer, pending.FeePayer)
+	assert.Nil(t, pending.BlockHash)
+
+	queued := content["queued"][from.Hex()]["2"]
+	require.NotNil(t, queued)
+	assert.Equal(t, queuedTx.Hash(), queued.Hash)
+	assert.Equal(t, hexutil.Uint64(types.TxTypeLegacyTransaction), queued.Type)
+	assert.Nil(t, queued.FeePayer)
+
+	inspect := api.Inspect()
+	assert.Equal(t, to.Hex()+": 1 wei + 100000 gas × 25 wei", inspect["pending"][from.Hex()]["0"])
+	assert.Equal(t, to.Hex()+": 2 wei + 21000 gas × 25 wei", inspect["queued"][from.Hex()]["2"])
+}
@@ -28,11 +28,13 @@ import (
 
 	"github.com/klaytn/klaytn/accounts"
 	"github.com/klaytn/klaytn/accounts/keystore"
+	"github.com/klaytn/klaytn/accounts/watchonly"
 	"github.com/klaytn/klaytn/blockchain/types"
 	"github.com/klaytn/klaytn/common"
 	"github.com/klaytn/klaytn/common/hexutil"
 	"github.com/klaytn/klaytn/common/math"
 	"github.com/klaytn/klaytn/crypto"
+	"github.com/klaytn/klaytn/networks/rpc"
 	"github.com/klaytn/klaytn/rlp"
 )
 
@@ -140,6 +142,87 @@ func fetchKeystore(am accounts.AccountManager) *keystore.KeyStore {
 	return am.Backends(keystore.KeyStoreType)[0].(*keystore.KeyStore)
 }
 
+// fetchWatchOnly retrieves the watch-only backend from the account manager.
+func fetchWatchOnly(am accounts.AccountManager) (*watchonly.Backend, error) {
+	backends := am.Backends(watchonly.BackendType)
+	if len(backends) == 0 {
+		return nil, errors.New("watch-only accounts are not supported")
+	}
+	return backends[0].(*watchonly.Backend), nil
+}
+
+// WatchAccount registers the given address as a watch-only account. The account is listed
+// with the accounts of this node, but it cannot sign. It returns false if the address is
+// already watched.
+func (s *PrivateAccountAPI) WatchAccount(addr common.Address) (bool, error) {
+	backend, err := fetchWatchOnly(s.am)
+	if err != nil {
+		return false, err
+	}
+	if fetchKeystore(s.am).HasAddress(addr) {
+		return false, errors.New("account is already in the keystore")
+	}
+	return backend.Add(addr), nil
+}
+
+// UnwatchAccount unregisters the given watch-only account.
+// It returns false if the address is not watched.
+func (s *PrivateAccountAPI) UnwatchAccount(addr common.Address) (bool, error) {
+	backend, err := fetchWatchOnly(s.am)
+	if err != nil {
+		return false, err
+	}
+	return backend.Remove(addr), nil
+}
+
+// RPCWatchedActivity is the notification of a transaction sent, received or paid by a watch-only account.
+// Status and GasUsed are nil if the transaction is pending.
+type RPCWatchedActivity struct {
+	Address         common.Address  `json:"address"`
+	TransactionHash common.Hash     `json:"transactionHash"`
+	Pending         bool            `json:"pending"`
+	Status          *hexutil.Uint   `json:"status"`
+	GasUsed         *hexutil.Uint64 `json:"gasUsed"`
+}
+
+// WatchedActivity sends a notification each time a transaction of a watch-only account enters
+// the transaction pool or is included in a block.
+func (s *PrivateAccountAPI) WatchedActivity(ctx context.Context) (*rpc.Subscription, error) {
+	backend, err := fetchWatchOnly(s.am)
+	if err != nil {
+		return nil, err
+	}
+	notifier, supported := rpc.NotifierFromContext(ctx)
+	if !supported {
+		return &rpc.Subscription{}, rpc.ErrNotificationsUnsupported
+	}
+
+	rpcSub := notifier.CreateSubscription()
+	go func() {
+		activities := make(chan watchonly.ActivityEvent)
+		activitySub := backend.SubscribeActivity(activities)
+		defer activitySub.Unsubscribe()
+
+		for {
+			select {
+			case ev := <-activities:
+				activity := &RPCWatchedActivity{Address: ev.Address, TransactionHash: ev.Tx.Hash(), Pending: ev.Receipt == nil}
+				if ev.Receipt != nil {
+					status, gasUsed := hexutil.Uint(ev.Receipt.Status), hexutil.Uint64(ev.Receipt.GasUsed)
+					activity.Status, activity.GasUsed = &status, &gasUsed
+				}
+				notifier.Notify(rpcSub.ID, activity)
+			case <-rpcSub.Err():
+				return
+			case <-notifier.Closed():
+				return
+			}
+		}
+	}()
+
+	return rpcSub, nil
+}
+
 func parseKlaytnWalletKey(k string) (string, string, *common.Address, error) {
 	// if key length is not 110, just return.
 	if len(k) != 110 {
@@ -239,6 +322,8 @@ func (s *PrivateAccountAPI) SendTransaction(ctx context.Context, args SendTxArgs
 		// the same nonce to multiple accounts.
 		s.nonceLock.LockAddr(args.From)
 		defer s.nonceLock.UnlockAddr(args.From)
+		nonce := s.nonceLock.nextNonce(ctx, s.b, args.From)
+		args.AccountNonce = (*hexutil.Uint64)(&nonce)
 	}
 	signedTx, err := s.SignTransaction(ctx, args, passwd)
 	if err != nil {
@@ -311,6 +396,8 @@ func (s *PrivateAccountAPI) SendAccountUpdate(ctx context.Context, args AccountU
 		// the same nonce to multiple accounts.
 		s.nonceLock.LockAddr(args.From)
 		defer s.nonceLock.UnlockAddr(args.From)
+		nonce := s.nonceLock.nextNonce(ctx, s.b, args.From)
+		args.Nonce = (*hexutil.Uint64)(&nonce)
 	}
 
 	signed, err := s.signNewTransaction(ctx, &args, passwd)
@@ -330,6 +417,8 @@ func (s *PrivateAccountAPI) SendValueTransfer(ctx context.Context, args ValueTra
 		// the same nonce to multiple accounts.
 		s.nonceLock.LockAddr(args.From)
 		defer s.nonceLock.UnlockAddr(args.From)
+		nonce := s.nonceLock.nextNonce(ctx, s.b, args.From)
+		args.Nonce = (*hexutil.Uint64)(&nonce)
 	}
 
 	signed, err := s.signNewTransaction(ctx, &args, passwd)
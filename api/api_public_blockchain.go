@@ -37,6 +37,7 @@ import (
 	"github.com/klaytn/klaytn/common"
 	"github.com/klaytn/klaytn/common/hexutil"
 	"github.com/klaytn/klaytn/common/math"
+	"github.com/klaytn/klaytn/consensus/misc"
 	"github.com/klaytn/klaytn/log"
 	"github.com/klaytn/klaytn/networks/rpc"
 	"github.com/klaytn/klaytn/params"
@@ -102,6 +103,9 @@ func (s *PublicBlockChainAPI) IsContractAccount(ctx context.Context, address com
 
 // GetBlockReceipts returns all the transaction receipts for the given block hash.
 func (s *PublicBlockChainAPI) GetBlockReceipts(ctx context.Context, blockHash common.Hash) ([]map[string]interface{}, error) {
+	if cached, ok := blockReceiptsCache.get(blockHash); ok {
+		return copyRPCFieldsList(cached.([]map[string]interface{})), nil
+	}
 	receipts := s.b.GetBlockReceipts(ctx, blockHash)
 	block, err := s.b.BlockByHash(ctx, blockHash)
 	if err != nil {
@@ -116,16 +120,21 @@ func (s *PublicBlockChainAPI) GetBlockReceipts(ctx context.Context, blockHash co
 		fields := RpcOutputReceipt(block.Header(), txs[index], blockHash, block.NumberU64(), uint64(index), receipt)
 		fieldsList = append(fieldsList, fields)
 	}
-	return fieldsList, nil
+	blockReceiptsCache.add(blockHash, fieldsList)
+	return copyRPCFieldsList(fieldsList), nil
 }
 
 // GetBalance returns the amount of peb for the given address in the state of the
 // given block number or hash. The rpc.LatestBlockNumber and rpc.PendingBlockNumber meta
 // block numbers and hash are also allowed.
 func (s *PublicBlockChainAPI) GetBalance(ctx context.Context, address common.Address, blockNrOrHash rpc.BlockNumberOrHash) (*hexutil.Big, error) {
-	state, _, err := s.b.StateAndHeaderByNumberOrHash(ctx, blockNrOrHash)
+	state, header, err := s.b.StateAndHeaderByNumberOrHash(ctx, blockNrOrHash)
 	if err != nil {
-		return nil, err
+		acc, err := historicalAccount(ctx, s.b, address, header, err)
+		if err != nil || acc == nil {
+			return (*hexutil.Big)(new(big.Int)), err
+		}
+		return (*hexutil.Big)(acc.GetBalance()), nil
 	}
 	return (*hexutil.Big)(state.GetBalance(address)), state.Error()
 }
@@ -133,18 +142,23 @@ func (s *PublicBlockChainAPI) GetBalance(ctx context.Context, address common.Add
 // AccountCreated returns true if the account associated with the address is created.
 // It returns false otherwise.
 func (s *PublicBlockChainAPI) AccountCreated(ctx context.Context, address common.Address, blockNrOrHash rpc.BlockNumberOrHash) (bool, error) {
-	state, _, err := s.b.StateAndHeaderByNumberOrHash(ctx, blockNrOrHash)
+	state, header, err := s.b.StateAndHeaderByNumberOrHash(ctx, blockNrOrHash)
 	if err != nil {
-		return false, err
+		acc, err := historicalAccount(ctx, s.b, address, header, err)
+		return acc != nil, err
 	}
 	return state.Exist(address), state.Error()
 }
 
 // GetAccount returns account information of an input address.
 func (s *PublicBlockChainAPI) GetAccount(ctx context.Context, address common.Address, blockNrOrHash rpc.BlockNumberOrHash) (*account.AccountSerializer, error) {
-	state, _, err := s.b.StateAndHeaderByNumberOrHash(ctx, blockNrOrHash)
+	state, header, err := s.b.StateAndHeaderByNumberOrHash(ctx, blockNrOrHash)
 	if err != nil {
-		return &account.AccountSerializer{}, err
+		acc, err := historicalAccount(ctx, s.b, address, header, err)
+		if err != nil || acc == nil {
+			return &account.AccountSerializer{}, err
+		}
+		return account.NewAccountSerializerWithAccount(acc), nil
 	}
 	acc := state.GetAccount(address)
 	if acc == nil {
@@ -154,6 +168,24 @@ func (s *PublicBlockChainAPI) GetAccount(ctx context.Context, address common.Add
 	return serAcc, state.Error()
 }
 
+// GetDecodedAccount returns the account information of an input address with the fully decoded account key.
+// For a role-based key, the keys of all roles are returned, including the ones inherited from RoleTransaction.
+func (s *PublicBlockChainAPI) GetDecodedAccount(ctx context.Context, address common.Address, blockNrOrHash rpc.BlockNumberOrHash) (*DecodedAccount, error) {
+	state, _, err := s.b.StateAndHeaderByNumberOrHash(ctx, blockNrOrHash)
+	if err != nil {
+		return nil, err
+	}
+	acc := state.GetAccount(address)
+	if acc == nil {
+		return nil, state.Error()
+	}
+	decoded, err := decodeAccount(acc, state.GetKey(address))
+	if err != nil {
+		return nil, err
+	}
+	return decoded, state.Error()
+}
+
 // rpcMarshalHeader converts the given header to the RPC output.
 func (s *PublicBlockChainAPI) rpcMarshalHeader(header *types.Header) map[string]interface{} {
 	fields := filters.RPCMarshalHeader(header, s.b.ChainConfig().IsEthTxTypeForkEnabled(header.Number))
@@ -207,9 +239,16 @@ func (s *PublicBlockChainAPI) GetBlockByHash(ctx context.Context, blockHash comm
 
 // GetCode returns the code stored at the given address in the state for the given block number or hash.
 func (s *PublicBlockChainAPI) GetCode(ctx context.Context, address common.Address, blockNrOrHash rpc.BlockNumberOrHash) (hexutil.Bytes, error) {
-	state, _, err := s.b.StateAndHeaderByNumberOrHash(ctx, blockNrOrHash)
+	state, header, err := s.b.StateAndHeaderByNumberOrHash(ctx, blockNrOrHash)
 	if err != nil {
-		return nil, err
+		acc, err := historicalAccount(ctx, s.b, address, header, err)
+		if err != nil {
+			return nil, err
+		}
+		if pa := account.GetProgramAccount(acc); pa != nil {
+			return s.b.ChainDB().ReadCode(common.BytesToHash(pa.GetCodeHash())), nil
+		}
+		return nil, nil
 	}
 	code := state.GetCode(address)
 	return code, state.Error()
@@ -219,14 +258,42 @@ func (s *PublicBlockChainAPI) GetCode(ctx context.Context, address common.Addres
 // block number. The rpc.LatestBlockNumber and rpc.PendingBlockNumber meta block
 // numbers and hash are also allowed.
 func (s *PublicBlockChainAPI) GetStorageAt(ctx context.Context, address common.Address, key string, blockNrOrHash rpc.BlockNumberOrHash) (hexutil.Bytes, error) {
-	state, _, err := s.b.StateAndHeaderByNumberOrHash(ctx, blockNrOrHash)
+	state, header, err := s.b.StateAndHeaderByNumberOrHash(ctx, blockNrOrHash)
 	if err != nil {
-		return nil, err
+		if header == nil {
+			return nil, err
+		}
+		res, herr := s.b.HistoricalStorage(ctx, address, common.HexToHash(key), header)
+		if herr != nil {
+			return nil, historicalStateError(err, herr)
+		}
+		return res[:], nil
 	}
 	res := state.GetState(address, common.HexToHash(key))
 	return res[:], state.Error()
 }
 
+// historicalAccount returns the account of the given address at the given header from the
+// state history, which is used if the state of the header is not available. The given error
+// of the state lookup is returned with the error of the state history if the account cannot be
+// reconstructed.
+func historicalAccount(ctx context.Context, b Backend, address common.Address, header *types.Header, stateErr error) (account.Account, error) {
+	if header == nil {
+		return nil, stateErr
+	}
+	acc, err := b.HistoricalAccount(ctx, address, header)
+	if err != nil {
+		return nil, historicalStateError(stateErr, err)
+	}
+	return acc, nil
+}
+
+// historicalStateError wraps the error of the state lookup with the reason why the state
+// history could not serve the request, such as the history being disabled or not stored.
+func historicalStateError(stateErr, historyErr error) error {
+	return fmt.Errorf("%w (state history: %v)", stateErr, historyErr)
+}
+
 // GetAccountKey returns the account key of EOA at a given address.
 // If the account of the given address is a Legacy Account or a Smart Contract Account, it will return nil.
 func (s *PublicBlockChainAPI) GetAccountKey(ctx context.Context, address common.Address, blockNrOrHash rpc.BlockNumberOrHash) (*accountkey.AccountKeySerializer, error) {
@@ -277,10 +344,30 @@ func (args *CallArgs) data() []byte {
 	return nil
 }
 
+// toEthTransactionArgs converts CallArgs to EthTransactionArgs to share the call logic of the eth namespace.
+func (args *CallArgs) toEthTransactionArgs() EthTransactionArgs {
+	from, value := args.From, args.Value
+	data := hexutil.Bytes(args.data())
+	ethArgs := EthTransactionArgs{
+		From:                 &from,
+		To:                   args.To,
+		GasPrice:             args.GasPrice,
+		MaxFeePerGas:         args.MaxFeePerGas,
+		MaxPriorityFeePerGas: args.MaxPriorityFeePerGas,
+		Value:                &value,
+		Input:                &data,
+	}
+	if args.Gas != 0 {
+		gas := args.Gas
+		ethArgs.Gas = &gas
+	}
+	return ethArgs
+}
+
 func DoCall(ctx context.Context, b Backend, args CallArgs, blockNrOrHash rpc.BlockNumberOrHash, vmCfg vm.Config, timeout time.Duration, globalGasCap *big.Int) ([]byte, uint64, uint64, uint, error) {
 	defer func(start time.Time) { logger.Debug("Executing EVM call finished", "runtime", time.Since(start)) }(time.Now())
 
-	state, header, err := b.StateAndHeaderByNumberOrHash(ctx, blockNrOrHash)
+	state, header, err := callStateAndHeader(ctx, b, blockNrOrHash)
 	if state == nil || err != nil {
 		return nil, 0, 0, 0, err
 	}
@@ -301,13 +388,7 @@ func DoCall(ctx context.Context, b Backend, args CallArgs, blockNrOrHash rpc.Blo
 		return nil, 0, 0, 0, err
 	}
 
-	// header.BaseFee != nil means magma hardforked
-	var baseFee *big.Int
-	if header.BaseFee != nil {
-		baseFee = header.BaseFee
-	} else {
-		baseFee = new(big.Int).SetUint64(params.ZeroBaseFee)
-	}
+	baseFee := misc.HeaderBaseFee(header)
 	msg, err := args.ToMessage(globalGasCap.Uint64(), baseFee, intrinsicGas)
 	if err != nil {
 		return nil, 0, 0, 0, err
@@ -363,10 +444,11 @@ func (s *PublicBlockChainAPI) Call(ctx context.Context, args CallArgs, blockNrOr
 	if rpcGasCap := s.b.RPCGasCap(); rpcGasCap != nil {
 		gasCap = rpcGasCap
 	}
-	result, _, _, status, err := DoCall(ctx, s.b, args, blockNrOrHash, vm.Config{}, s.b.RPCEVMTimeout(), gasCap)
+	result, usedGas, _, status, err := DoCall(ctx, s.b, args, blockNrOrHash, vm.Config{}, s.b.RPCEVMTimeout(), gasCap)
 	if err != nil {
 		return nil, err
 	}
+	rpc.ConsumeBatchGas(ctx, usedGas)
 
 	err = blockchain.GetVMerrFromReceiptStatus(status)
 	if err != nil && isReverted(err) && len(result) > 0 {
@@ -375,6 +457,21 @@ func (s *PublicBlockChainAPI) Call(ctx context.Context, args CallArgs, blockNrOr
 	return common.CopyBytes(result), err
 }
 
+// Multicall executes the given calls in order against the state of the given block.
+// If applyChanges is true, the state changes made by a call are visible to the following calls.
+// Otherwise, every call is executed against the same state.
+func (s *PublicBlockChainAPI) Multicall(ctx context.Context, calls []CallArgs, blockNrOrHash rpc.BlockNumberOrHash, applyChanges *bool) ([]*EthMulticallResult, error) {
+	gasCap := uint64(0)
+	if rpcGasCap := s.b.RPCGasCap(); rpcGasCap != nil {
+		gasCap = rpcGasCap.Uint64()
+	}
+	ethCalls := make([]EthTransactionArgs, len(calls))
+	for i := range calls {
+		ethCalls[i] = calls[i].toEthTransactionArgs()
+	}
+	return EthDoMulticall(ctx, s.b, ethCalls, blockNrOrHash, nil, applyChanges != nil && *applyChanges, s.b.RPCEVMTimeout(), gasCap)
+}
+
 func (s *PublicBlockChainAPI) EstimateComputationCost(ctx context.Context, args CallArgs, blockNrOrHash rpc.BlockNumberOrHash) (hexutil.Uint64, error) {
 	gasCap := big.NewInt(0)
 	if rpcGasCap := s.b.RPCGasCap(); rpcGasCap != nil {
@@ -393,6 +490,71 @@ func (s *PublicBlockChainAPI) EstimateGas(ctx context.Context, args CallArgs) (h
 	return s.DoEstimateGas(ctx, s.b, args, big.NewInt(int64(gasCap)))
 }
 
+// EstimateGasResult is the result of EstimateGasDetailed.
+type EstimateGasResult struct {
+	Gas        hexutil.Uint64                     `json:"gas"`
+	ReturnData hexutil.Bytes                      `json:"returnData"`
+	Logs       []*types.Log                       `json:"logs"`
+	StateDiff  map[common.Address]*vm.AccountDiff `json:"stateDiff"`
+}
+
+// EstimateGasDetailed returns the estimated gas of the given transaction against the latest block
+// together with the return data, the logs and the state changes of the execution with the estimated gas.
+// Unlike EstimateGas, the sender must be able to pay the fee, since the fee payment is a part of the state changes.
+func (s *PublicBlockChainAPI) EstimateGasDetailed(ctx context.Context, args CallArgs) (*EstimateGasResult, error) {
+	gasCap := uint64(0)
+	if rpcGasCap := s.b.RPCGasCap(); rpcGasCap != nil {
+		gasCap = rpcGasCap.Uint64()
+	}
+	gas, err := s.DoEstimateGas(ctx, s.b, args, new(big.Int).SetUint64(gasCap))
+	if err != nil {
+		return nil, err
+	}
+
+	st, header, err := s.b.StateAndHeaderByNumberOrHash(ctx, rpc.NewBlockNumberOrHashWithNumber(rpc.LatestBlockNumber))
+	if st == nil || err != nil {
+		return nil, err
+	}
+	timeout := s.b.RPCEVMTimeout()
+	var cancel context.CancelFunc
+	if timeout > 0 {
+		ctx, cancel = context.WithTimeout(ctx, timeout)
+	} else {
+		ctx, cancel = context.WithCancel(ctx)
+	}
+	defer cancel()
+
+	args.Gas = gas
+	pre := st.Copy()
+	st.Prepare(common.Hash{}, common.Hash{}, 0)
+	tracer := vm.NewStateDiffTracer()
+	tracer.AddAccount(header.Rewardbase)
+
+	ret, _, status, err := ethDoCallWithState(ctx, s.b, args.toEthTransactionArgs(), st, header, nil, vm.Config{Debug: true, Tracer: tracer}, true, timeout, gasCap)
+	if err != nil {
+		return nil, err
+	}
+	// The estimated gas may still fail if the state has changed after the estimation.
+	if vmErr := blockchain.GetVMerrFromReceiptStatus(status); vmErr != nil {
+		if isReverted(vmErr) && len(ret) > 0 {
+			return nil, newRevertError(ret)
+		}
+		return nil, vmErr
+	}
+	st.Finalise(true, true)
+
+	logs := st.GetLogs(common.Hash{})
+	if logs == nil {
+		logs = []*types.Log{}
+	}
+	return &EstimateGasResult{
+		Gas:        gas,
+		ReturnData: common.CopyBytes(ret),
+		Logs:       logs,
+		StateDiff:  tracer.Diff(pre, st),
+	}, nil
+}
+
 func (s *PublicBlockChainAPI) DoEstimateGas(ctx context.Context, b Backend, args CallArgs, gasCap *big.Int) (hexutil.Uint64, error) {
 	// Binary search the gas requirement, as it may be higher than the amount used
 	var (
@@ -585,11 +747,7 @@ func RpcOutputBlock(b *types.Block, td *big.Int, inclTx bool, fullTx bool, isEna
 	}
 
 	if isEnabledEthTxTypeFork {
-		if head.BaseFee == nil {
-			fields["baseFeePerGas"] = (*hexutil.Big)(new(big.Int).SetUint64(params.ZeroBaseFee))
-		} else {
-			fields["baseFeePerGas"] = (*hexutil.Big)(head.BaseFee)
-		}
+		fields["baseFeePerGas"] = (*hexutil.Big)(misc.HeaderBaseFee(head))
 	}
 
 	return fields, nil
@@ -605,8 +763,14 @@ func (s *PublicBlockChainAPI) rpcOutputBlock(b *types.Block, inclTx bool, fullTx
 func getFrom(tx *types.Transaction) common.Address {
 	var from common.Address
 	if tx.IsEthereumTransaction() {
+		if cached, ok := senderCache.get(tx.Hash()); ok {
+			return cached.(common.Address)
+		}
 		signer := types.LatestSignerForChainID(tx.ChainId())
-		from, _ = types.Sender(signer, tx)
+		var err error
+		if from, err = types.Sender(signer, tx); err == nil {
+			senderCache.add(tx.Hash(), from)
+		}
 	} else {
 		from, _ = tx.From()
 	}
@@ -719,10 +883,5 @@ func (args *CallArgs) ToMessage(globalGasCap uint64, baseFee *big.Int, intrinsic
 		value = args.Value.ToInt()
 	}
 
-	// TODO-Klaytn: Klaytn does not support accessList yet.
-	// var accessList types.AccessList
-	// if args.AccessList != nil {
-	//	 accessList = *args.AccessList
-	// }
-	return types.NewMessage(addr, args.To, 0, value, gas, gasPrice, args.data(), false, intrinsicGas), nil
+	return types.NewMessage(addr, args.To, 0, value, gas, gasPrice, args.data(), false, intrinsicGas, nil), nil
 }
@@ -0,0 +1,87 @@
+// Copyright 2022 The klaytn Authors
+// This file is part of the klaytn library.
+//
+// The klaytn library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The klaytn library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the klaytn library. If not, see <http://www.gnu.org/licenses/>.
+
+package api
+
+import (
+	"context"
+	"errors"
+	"math/big"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	mock_api "github.com/klaytn/klaytn/api/mocks"
+	"github.com/klaytn/klaytn/blockchain/types"
+	"github.com/klaytn/klaytn/blockchain/types/account"
+	"github.com/klaytn/klaytn/common"
+	"github.com/klaytn/klaytn/common/hexutil"
+	"github.com/klaytn/klaytn/networks/rpc"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestPublicBlockChainAPI_HistoricalState(t *testing.T) {
+	var (
+		ctx            = context.Background()
+		addr           = common.HexToAddress("0x1111")
+		slot           = common.HexToHash("0x1")
+		header         = &types.Header{Number: big.NewInt(10)}
+		blockNr        = rpc.NewBlockNumberOrHashWithNumber(10)
+		errStatePruned = errors.New("missing trie node")
+	)
+	mockCtrl := gomock.NewController(t)
+	defer mockCtrl.Finish()
+	mockBackend := mock_api.NewMockBackend(mockCtrl)
+	mockBackend.EXPECT().StateAndHeaderByNumberOrHash(ctx, blockNr).Return(nil, header, errStatePruned).AnyTimes()
+
+	acc, err := account.NewAccountWithMap(account.ExternallyOwnedAccountType, map[account.AccountValueKeyType]interface{}{
+		account.AccountValueKeyNonce:   uint64(3),
+		account.AccountValueKeyBalance: big.NewInt(100),
+	})
+	require.NoError(t, err)
+	mockBackend.EXPECT().HistoricalAccount(ctx, addr, header).Return(acc, nil).AnyTimes()
+	mockBackend.EXPECT().HistoricalStorage(ctx, addr, slot, header).Return(common.HexToHash("0x2a"), nil)
+
+	// The pruned state is served from the state history
+	api := NewPublicBlockChainAPI(mockBackend)
+	balance, err := api.GetBalance(ctx, addr, blockNr)
+	require.NoError(t, err)
+	assert.Equal(t, (*hexutil.Big)(big.NewInt(100)), balance)
+
+	created, err := api.AccountCreated(ctx, addr, blockNr)
+	require.NoError(t, err)
+	assert.True(t, created)
+
+	value, err := api.GetStorageAt(ctx, addr, slot.Hex(), blockNr)
+	require.NoError(t, err)
+	assert.Equal(t, hexutil.Bytes(common.HexToHash("0x2a").Bytes()), value)
+
+	nonce, err := NewPublicTransactionPoolAPI(mockBackend, new(AddrLocker)).GetTransactionCount(ctx, addr, blockNr)
+	require.NoError(t, err)
+	assert.Equal(t, hexutil.Uint64(3), *nonce)
+
+	// The error of the state lookup is returned with the reason if the state history is not available
+	other := common.HexToAddress("0x2222")
+	mockBackend.EXPECT().HistoricalAccount(ctx, other, header).Return(nil, errors.New("state history is disabled"))
+	_, err = api.GetBalance(ctx, other, blockNr)
+	assert.True(t, errors.Is(err, errStatePruned))
+	assert.EqualError(t, err, "missing trie node (state history: state history is disabled)")
+
+	mockBackend.EXPECT().HistoricalStorage(ctx, other, slot, header).Return(common.Hash{}, errors.New("state history of the block is not stored"))
+	_, err = api.GetStorageAt(ctx, other, slot.Hex(), blockNr)
+	assert.True(t, errors.Is(err, errStatePruned))
+	assert.EqualError(t, err, "missing trie node (state history: state history of the block is not stored)")
+}
@@ -62,8 +62,13 @@ func (s *PublicKlayAPI) ProtocolVersion() hexutil.Uint {
 }
 
 // MaxPriorityFeePerGas returns a suggestion for a gas tip cap for dynamic fee transactions.
+// It is sampled from the transactions of the recent blocks.
 func (s *PublicKlayAPI) MaxPriorityFeePerGas(ctx context.Context) (*hexutil.Big, error) {
-	return s.GasPrice(ctx)
+	tipcap, err := s.b.SuggestTipCap(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return (*hexutil.Big)(tipcap), nil
 }
 
 type FeeHistoryResult struct {
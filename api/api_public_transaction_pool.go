@@ -26,8 +26,10 @@ import (
 	"errors"
 	"fmt"
 	"math/big"
+	"sort"
 
 	"github.com/klaytn/klaytn/accounts"
+	"github.com/klaytn/klaytn/blockchain"
 	"github.com/klaytn/klaytn/blockchain/types"
 	"github.com/klaytn/klaytn/common"
 	"github.com/klaytn/klaytn/common/hexutil"
@@ -112,15 +114,23 @@ func (s *PublicTransactionPoolAPI) GetTransactionCount(ctx context.Context, addr
 	}
 
 	// Resolve block number and use its state to ask for the nonce
-	state, _, err := s.b.StateAndHeaderByNumberOrHash(ctx, blockNrOrHash)
+	state, header, err := s.b.StateAndHeaderByNumberOrHash(ctx, blockNrOrHash)
 	if err != nil {
-		return nil, err
+		acc, err := historicalAccount(ctx, s.b, address, header, err)
+		if err != nil {
+			return nil, err
+		}
+		var nonce uint64
+		if acc != nil {
+			nonce = acc.GetNonce()
+		}
+		return (*hexutil.Uint64)(&nonce), nil
 	}
 	nonce := state.GetNonce(address)
 	return (*hexutil.Uint64)(&nonce), state.Error()
 }
 
-func (s *PublicTransactionPoolAPI) GetTransactionBySenderTxHash(ctx context.Context, senderTxHash common.Hash) map[string]interface{} {
+func (s *PublicTransactionPoolAPI) GetTransactionBySenderTxHash(ctx context.Context, senderTxHash common.Hash) (map[string]interface{}, error) {
 	txhash := s.b.ChainDB().ReadTxHashFromSenderTxHash(senderTxHash)
 	if common.EmptyHash(txhash) {
 		txhash = senderTxHash
@@ -129,16 +139,45 @@ func (s *PublicTransactionPoolAPI) GetTransactionBySenderTxHash(ctx context.Cont
 }
 
 // GetTransactionByHash returns the transaction for the given hash
-func (s *PublicTransactionPoolAPI) GetTransactionByHash(ctx context.Context, hash common.Hash) map[string]interface{} {
+func (s *PublicTransactionPoolAPI) GetTransactionByHash(ctx context.Context, hash common.Hash) (map[string]interface{}, error) {
 	// Try to return an already finalized transaction
 	if tx, blockHash, blockNumber, index := s.b.ChainDB().ReadTxAndLookupInfo(hash); tx != nil {
-		return newRPCTransaction(nil, tx, blockHash, blockNumber, index)
+		return newRPCTransaction(nil, tx, blockHash, blockNumber, index), nil
 	}
 	// No finalized transaction, try to retrieve it from the pool
 	if tx := s.b.GetPoolTransaction(hash); tx != nil {
-		return newRPCPendingTransaction(tx)
+		return newRPCPendingTransaction(tx), nil
+	}
+	// Transaction unknown, return as such unless the transactions are not indexed
+	return nil, txLookupSupportError(s.b)
+}
+
+// errTxLookupUnsupported is returned for a transaction not found on a light node, which does not index
+// the transactions by hash, as the transaction may be in a block.
+var errTxLookupUnsupported = errors.New("transaction lookup by hash is unsupported in light mode")
+
+// txLookupSupportError returns errTxLookupUnsupported if the node does not index the transactions by hash.
+// Otherwise, it returns nil.
+func txLookupSupportError(b Backend) error {
+	if !b.IsTxLookupSupported() {
+		return errTxLookupUnsupported
+	}
+	return nil
+}
+
+// errTxIndexOutOfRange is returned for a transaction not found while the transactions of the old blocks are
+// being unindexed.
+var errTxIndexOutOfRange = errors.New("transaction indexing out of range")
+
+// txLookupError returns errTxIndexOutOfRange while the transactions of the old blocks are being unindexed,
+// as a transaction not found may be known but not indexed anymore. Otherwise, it returns nil.
+func txLookupError(b Backend) error {
+	if err := txLookupSupportError(b); err != nil {
+		return err
+	}
+	if !b.TxIndexDone() {
+		return errTxIndexOutOfRange
 	}
-	// Transaction unknown, return as such
 	return nil
 }
 
@@ -155,6 +194,9 @@ func (s *PublicTransactionPoolAPI) GetDecodedAnchoringTransactionByHash(ctx cont
 	if tx = s.b.GetPoolTransaction(hash); tx != nil {
 		goto decode
 	}
+	if err := txLookupSupportError(s.b); err != nil {
+		return nil, err
+	}
 	return nil, errors.New("can't find the transaction")
 
 decode:
@@ -192,7 +234,7 @@ func (s *PublicTransactionPoolAPI) GetRawTransactionByHash(ctx context.Context,
 	if tx, _, _, _ = s.b.ChainDB().ReadTxAndLookupInfo(hash); tx == nil {
 		if tx = s.b.GetPoolTransaction(hash); tx == nil {
 			// Transaction not found anywhere, abort
-			return nil, nil
+			return nil, txLookupSupportError(s.b)
 		}
 	}
 
@@ -251,6 +293,9 @@ func (s *PublicTransactionPoolAPI) GetTransactionReceiptBySenderTxHash(ctx conte
 // GetTransactionReceipt returns the transaction receipt for the given transaction hash.
 func (s *PublicTransactionPoolAPI) GetTransactionReceipt(ctx context.Context, hash common.Hash) (map[string]interface{}, error) {
 	tx, blockHash, blockNumber, index, receipt := s.b.GetTxLookupInfoAndReceipt(ctx, hash)
+	if tx == nil {
+		return nil, txLookupSupportError(s.b)
+	}
 	return s.getTransactionReceipt(ctx, tx, blockHash, blockNumber, index, receipt)
 }
 
@@ -283,8 +328,26 @@ func (s *PublicTransactionPoolAPI) sign(addr common.Address, tx *types.Transacti
 	return wallet.SignTx(account, tx, s.b.ChainConfig().ChainID)
 }
 
+var (
+	errFeePayerNotWhitelisted      = errors.New("fee payer is not whitelisted to sign on this node")
+	errFeePayerWhitelistNotEnabled = errors.New("signing raw transactions as a fee payer is disabled without a fee payer whitelist")
+)
+
+// isWhitelistedFeePayer returns true if the account is in the fee payer whitelist of the backend.
+func isWhitelistedFeePayer(b Backend, addr common.Address) bool {
+	for _, whitelisted := range b.RPCFeePayerWhitelist() {
+		if whitelisted == addr {
+			return true
+		}
+	}
+	return false
+}
+
 // signAsFeePayer is a helper function that signs a transaction as a fee payer with the private key of the given address.
 func (s *PublicTransactionPoolAPI) signAsFeePayer(addr common.Address, tx *types.Transaction) (*types.Transaction, error) {
+	if len(s.b.RPCFeePayerWhitelist()) > 0 && !isWhitelistedFeePayer(s.b, addr) {
+		return nil, errFeePayerNotWhitelisted
+	}
 	// Look up the wallet containing the requested signer
 	account := accounts.Account{Address: addr}
 
@@ -304,6 +367,9 @@ func submitTransaction(ctx context.Context, b Backend, tx *types.Transaction) (c
 	// log.Error("### submitTransaction","tx",submitTxCount)
 
 	if err := b.SendTx(ctx, tx); err != nil {
+		if err == blockchain.ErrReplaceUnderpriced {
+			return common.Hash{}, &replaceUnderpricedError{err}
+		}
 		return common.Hash{}, err
 	}
 	// TODO-Klaytn only enable on logging
@@ -330,6 +396,8 @@ func (s *PublicTransactionPoolAPI) SendTransaction(ctx context.Context, args Sen
 		// the same nonce to multiple accounts.
 		s.nonceLock.LockAddr(args.From)
 		defer s.nonceLock.UnlockAddr(args.From)
+		nonce := s.nonceLock.nextNonce(ctx, s.b, args.From)
+		args.AccountNonce = (*hexutil.Uint64)(&nonce)
 	}
 
 	signedTx, err := s.SignTransaction(ctx, args)
@@ -369,6 +437,36 @@ func (s *PublicTransactionPoolAPI) SendTransactionAsFeePayer(ctx context.Context
 	return submitTransaction(ctx, s.b, feePayerSignedTx.Tx)
 }
 
+// SendRawTransactionAsFeePayer accepts a fee-delegated transaction signed by the sender, signs it
+// as the fee payer set in the transaction with an account of this node, and submits it to the transaction pool.
+// Since any caller can make the fee payer pay for any transaction, it is disabled unless the fee
+// payer whitelist is configured, and the fee payer should be whitelisted and unlocked.
+func (s *PublicTransactionPoolAPI) SendRawTransactionAsFeePayer(ctx context.Context, encodedTx hexutil.Bytes) (common.Hash, error) {
+	if len(s.b.RPCFeePayerWhitelist()) == 0 {
+		return common.Hash{}, errFeePayerWhitelistNotEnabled
+	}
+	tx := new(types.Transaction)
+	if err := rlp.DecodeBytes(encodedTx, tx); err != nil {
+		// Decoding fails with ErrInvalidSig if the sender did not sign the transaction
+		if err == types.ErrInvalidSig {
+			return common.Hash{}, errTxArgNilSenderSig
+		}
+		return common.Hash{}, err
+	}
+	if !tx.IsFeeDelegatedTransaction() {
+		return common.Hash{}, errTxArgNotFeeDelegated
+	}
+	feePayer, err := tx.FeePayer()
+	if err != nil {
+		return common.Hash{}, errTxArgInvalidFeePayer
+	}
+	feePayerSignedTx, err := s.signAsFeePayer(feePayer, tx)
+	if err != nil {
+		return common.Hash{}, err
+	}
+	return submitTransaction(ctx, s.b, feePayerSignedTx)
+}
+
 // SendRawTransaction will add the signed transaction to the transaction pool.
 // The sender is responsible for signing the transaction and using the correct nonce.
 func (s *PublicTransactionPoolAPI) SendRawTransaction(ctx context.Context, encodedTx hexutil.Bytes) (common.Hash, error) {
@@ -471,6 +569,82 @@ func (s *PublicTransactionPoolAPI) SignTransactionAsFeePayer(ctx context.Context
 	return &SignTransactionResult{data, feePayerSignedTx}, nil
 }
 
+// FeeDelegatedTxResult represents a fee-delegated transaction with the hashes to be signed
+// by the sender and the fee payer.
+type FeeDelegatedTxResult struct {
+	Raw             hexutil.Bytes      `json:"raw"`
+	Tx              *types.Transaction `json:"tx"`
+	SenderSigHash   common.Hash        `json:"senderSigHash"`
+	FeePayerSigHash common.Hash        `json:"feePayerSigHash"`
+}
+
+// newFeeDelegatedTxResult encodes the given fee-delegated transaction and computes its sig hashes.
+func (s *PublicTransactionPoolAPI) newFeeDelegatedTxResult(tx *types.Transaction) (*FeeDelegatedTxResult, error) {
+	signer := types.LatestSignerForChainID(s.b.ChainConfig().ChainID)
+	feePayerSigHash, err := signer.HashFeePayer(tx)
+	if err != nil {
+		return nil, err
+	}
+	data, err := rlp.EncodeToBytes(tx)
+	if err != nil {
+		return nil, err
+	}
+	return &FeeDelegatedTxResult{data, tx, signer.Hash(tx), feePayerSigHash}, nil
+}
+
+// BuildFeeDelegatedTx fills the default values of the given fee-delegated transaction and returns
+// the RLP-encoded transaction with the hashes to be signed by the sender and the fee payer.
+// It does not require any key in the node, so that clients without Klaytn transaction encoding
+// can use fee delegation. If the sender signatures are given, they are included in the result.
+func (s *PublicTransactionPoolAPI) BuildFeeDelegatedTx(ctx context.Context, args SendTxArgs) (*FeeDelegatedTxResult, error) {
+	if args.TypeInt == nil {
+		return nil, errTxArgNilTxType
+	}
+	if !args.TypeInt.IsFeeDelegatedTransaction() {
+		return nil, errTxArgNotFeeDelegated
+	}
+	if err := args.setDefaults(ctx, s.b); err != nil {
+		return nil, err
+	}
+	tx, err := args.toTransaction()
+	if err != nil {
+		return nil, err
+	}
+	if args.TxSignatures != nil {
+		tx.SetSignature(args.TxSignatures.ToTxSignatures())
+	}
+	return s.newFeeDelegatedTxResult(tx)
+}
+
+// AppendFeePayerSignature sets the given fee payer signatures to the RLP-encoded fee-delegated transaction
+// and returns the final RLP-encoded transaction. The signatures are validated against the account key
+// of the fee payer at the latest block.
+func (s *PublicTransactionPoolAPI) AppendFeePayerSignature(ctx context.Context, encodedTx hexutil.Bytes, feePayerSigs types.TxSignaturesJSON) (*FeeDelegatedTxResult, error) {
+	tx := new(types.Transaction)
+	if err := rlp.DecodeBytes(encodedTx, tx); err != nil {
+		return nil, err
+	}
+	if !tx.IsFeeDelegatedTransaction() {
+		return nil, errTxArgNotFeeDelegated
+	}
+	if len(feePayerSigs) == 0 {
+		return nil, errTxArgNilFeePayerSig
+	}
+	if err := tx.SetFeePayerSignatures(feePayerSigs.ToTxSignatures()); err != nil {
+		return nil, err
+	}
+
+	state, header, err := s.b.StateAndHeaderByNumber(ctx, rpc.LatestBlockNumber)
+	if state == nil || err != nil {
+		return nil, err
+	}
+	signer := types.LatestSignerForChainID(s.b.ChainConfig().ChainID)
+	if _, err := tx.ValidateFeePayer(signer, state, header.Number.Uint64()); err != nil {
+		return nil, err
+	}
+	return s.newFeeDelegatedTxResult(tx)
+}
+
 func getAccountsFromWallets(wallets []accounts.Wallet) map[common.Address]struct{} {
 	accounts := make(map[common.Address]struct{})
 	for _, wallet := range wallets {
@@ -481,20 +655,53 @@ func getAccountsFromWallets(wallets []accounts.Wallet) map[common.Address]struct
 	return accounts
 }
 
+// localExecutableTransactions returns the pending transactions sent from the accounts this node manages.
+// The transactions behind a nonce gap of their sender are excluded as they cannot be executed yet.
+func localExecutableTransactions(b Backend) (types.Transactions, error) {
+	pending, err := b.GetPoolTransactions()
+	if err != nil {
+		return nil, err
+	}
+	accounts := getAccountsFromWallets(b.AccountManager().Wallets())
+
+	var senders []common.Address
+	bySender := make(map[common.Address]types.Transactions)
+	for _, tx := range pending {
+		from := getFrom(tx)
+		if _, exists := accounts[from]; !exists {
+			continue
+		}
+		if _, exists := bySender[from]; !exists {
+			senders = append(senders, from)
+		}
+		bySender[from] = append(bySender[from], tx)
+	}
+
+	executables := make(types.Transactions, 0, len(pending))
+	for _, from := range senders {
+		txs := bySender[from]
+		sort.Sort(types.TxByNonce(txs))
+		for i, tx := range txs {
+			if i > 0 && tx.Nonce() != txs[i-1].Nonce()+1 {
+				break
+			}
+			executables = append(executables, tx)
+		}
+	}
+	return executables, nil
+}
+
 // PendingTransactions returns the transactions that are in the transaction pool
 // and have a from address that is one of the accounts this node manages.
+// Only the executable transactions without a nonce gap in front are returned.
 func (s *PublicTransactionPoolAPI) PendingTransactions() ([]map[string]interface{}, error) {
-	pending, err := s.b.GetPoolTransactions()
+	pending, err := localExecutableTransactions(s.b)
 	if err != nil {
 		return nil, err
 	}
-	accounts := getAccountsFromWallets(s.b.AccountManager().Wallets())
 	transactions := make([]map[string]interface{}, 0, len(pending))
 	for _, tx := range pending {
-		from := getFrom(tx)
-		if _, exists := accounts[from]; exists {
-			transactions = append(transactions, newRPCPendingTransaction(tx))
-		}
+		transactions = append(transactions, newRPCPendingTransaction(tx))
 	}
 	return transactions, nil
 }
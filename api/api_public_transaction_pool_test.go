@@ -2,6 +2,7 @@ package api
 
 import (
 	"context"
+	"crypto/ecdsa"
 	"io/ioutil"
 	"math/big"
 	"os"
@@ -13,12 +14,17 @@ import (
 	"github.com/klaytn/klaytn/accounts/keystore"
 	mock_accounts "github.com/klaytn/klaytn/accounts/mocks"
 	mock_api "github.com/klaytn/klaytn/api/mocks"
+	"github.com/klaytn/klaytn/blockchain/state"
 	"github.com/klaytn/klaytn/blockchain/types"
 	"github.com/klaytn/klaytn/common"
 	"github.com/klaytn/klaytn/common/hexutil"
 	"github.com/klaytn/klaytn/crypto"
+	"github.com/klaytn/klaytn/networks/rpc"
 	"github.com/klaytn/klaytn/params"
+	"github.com/klaytn/klaytn/rlp"
+	"github.com/klaytn/klaytn/storage/database"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 )
 
 // test tx types and internal data to be supported by APIs in PublicTransactionPoolAPI.
@@ -179,6 +185,9 @@ func testTxTypeSupport_normalCase(t *testing.T, api PublicTransactionPoolAPI, ct
 
 		_, err = api.SendTransactionAsFeePayer(ctx, args)
 		assert.Equal(t, nil, err)
+
+		_, err = api.BuildFeeDelegatedTx(ctx, args)
+		assert.Equal(t, nil, err)
 	}
 
 	// test for all txs
@@ -339,3 +348,191 @@ func testTxTypeSupport_unnecessaryFieldValues(t *testing.T, api PublicTransactio
 		assert.Equal(t, "json:\"feeRatio\" is not a field of "+(*args.TypeInt).String(), err.Error())
 	}
 }
+
+// TestSendRawTransactionAsFeePayer tests SendRawTransactionAsFeePayer with the fee payer whitelist.
+func TestSendRawTransactionAsFeePayer(t *testing.T) {
+	ctx := context.Background()
+	chainConf := params.ChainConfig{ChainID: big.NewInt(1)}
+	signer := types.LatestSignerForChainID(chainConf.ChainID)
+
+	dir, err := ioutil.TempDir("", "klay-keystore-test")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+	ks := keystore.NewKeyStore(dir, 2, 1)
+	accFeePayer, err := ks.ImportECDSA(feePayerPrvKey, "")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := ks.Unlock(accFeePayer, ""); err != nil {
+		t.Fatal(err)
+	}
+
+	mockCtrl := gomock.NewController(t)
+	defer mockCtrl.Finish()
+	mockBackend := mock_api.NewMockBackend(mockCtrl)
+	mockAccountManager := mock_accounts.NewMockAccountManager(mockCtrl)
+	mockBackend.EXPECT().AccountManager().Return(mockAccountManager).AnyTimes()
+	mockBackend.EXPECT().ChainConfig().Return(&chainConf).AnyTimes()
+	mockAccountManager.EXPECT().Find(accounts.Account{Address: accFeePayer.Address}).Return(ks.Wallets()[0], nil).AnyTimes()
+
+	var whitelist []common.Address
+	mockBackend.EXPECT().RPCFeePayerWhitelist().DoAndReturn(func() []common.Address { return whitelist }).AnyTimes()
+
+	var sent *types.Transaction
+	mockBackend.EXPECT().SendTx(ctx, gomock.Any()).DoAndReturn(func(ctx context.Context, tx *types.Transaction) error {
+		sent = tx
+		return nil
+	})
+
+	api := PublicTransactionPoolAPI{
+		b:         mockBackend,
+		nonceLock: new(AddrLocker),
+	}
+
+	tx, err := types.NewTransactionWithMap(types.TxTypeFeeDelegatedValueTransfer, map[types.TxValueKeyType]interface{}{
+		types.TxValueKeyNonce:    uint64(testNonce),
+		types.TxValueKeyTo:       testTo,
+		types.TxValueKeyAmount:   (*big.Int)(testValue),
+		types.TxValueKeyGasLimit: uint64(testGas),
+		types.TxValueKeyGasPrice: (*big.Int)(testGasPrice),
+		types.TxValueKeyFrom:     crypto.PubkeyToAddress(senderPrvKey.PublicKey),
+		types.TxValueKeyFeePayer: accFeePayer.Address,
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// It is disabled by default without the whitelist
+	unsigned, _ := rlp.EncodeToBytes(tx)
+	_, err = api.SendRawTransactionAsFeePayer(ctx, unsigned)
+	assert.Equal(t, errFeePayerWhitelistNotEnabled, err)
+
+	// The transaction should be signed by the sender
+	whitelist = []common.Address{testFrom}
+	_, err = api.SendRawTransactionAsFeePayer(ctx, unsigned)
+	assert.Equal(t, errTxArgNilSenderSig, err)
+
+	if err := tx.Sign(signer, senderPrvKey); err != nil {
+		t.Fatal(err)
+	}
+	raw, _ := rlp.EncodeToBytes(tx)
+
+	// The fee payer should be whitelisted
+	_, err = api.SendRawTransactionAsFeePayer(ctx, raw)
+	assert.Equal(t, errFeePayerNotWhitelisted, err)
+
+	whitelist = []common.Address{testFrom, accFeePayer.Address}
+	hash, err := api.SendRawTransactionAsFeePayer(ctx, raw)
+	assert.NoError(t, err)
+	if assert.NotNil(t, sent) {
+		assert.Equal(t, sent.Hash(), hash)
+		feePayer, err := types.SenderFeePayer(signer, sent)
+		assert.NoError(t, err)
+		assert.Equal(t, accFeePayer.Address, feePayer)
+	}
+}
+
+func TestBuildFeeDelegatedTxAndAppendFeePayerSignature(t *testing.T) {
+	ctx := context.Background()
+	chainConf := params.ChainConfig{ChainID: big.NewInt(1)}
+	signer := types.LatestSignerForChainID(chainConf.ChainID)
+	sender := crypto.PubkeyToAddress(senderPrvKey.PublicKey)
+	feePayer := crypto.PubkeyToAddress(feePayerPrvKey.PublicKey)
+
+	mockCtrl := gomock.NewController(t)
+	defer mockCtrl.Finish()
+	mockBackend := mock_api.NewMockBackend(mockCtrl)
+	mockBackend.EXPECT().ChainConfig().Return(&chainConf).AnyTimes()
+	mockBackend.EXPECT().CurrentBlock().Return(
+		types.NewBlockWithHeader(&types.Header{Number: new(big.Int).SetUint64(0)}),
+	).AnyTimes()
+	mockBackend.EXPECT().SuggestPrice(gomock.Any()).Return((*big.Int)(testGasPrice), nil).AnyTimes()
+	mockBackend.EXPECT().GetPoolNonce(gomock.Any(), sender).Return(uint64(testNonce)).AnyTimes()
+	mockBackend.EXPECT().StateAndHeaderByNumber(gomock.Any(), rpc.LatestBlockNumber).DoAndReturn(
+		func(ctx context.Context, blockNr rpc.BlockNumber) (*state.StateDB, *types.Header, error) {
+			st, err := state.New(common.Hash{}, state.NewDatabase(database.NewMemoryDBManager()), nil)
+			return st, &types.Header{Number: big.NewInt(0)}, err
+		}).AnyTimes()
+
+	api := PublicTransactionPoolAPI{
+		b:         mockBackend,
+		nonceLock: new(AddrLocker),
+	}
+
+	txType := types.TxTypeFeeDelegatedValueTransfer
+	args := SendTxArgs{
+		TypeInt:   &txType,
+		From:      sender,
+		Recipient: &testTo,
+		Amount:    testValue,
+		GasLimit:  &testGas,
+		FeePayer:  &feePayer,
+	}
+
+	// The built transaction is filled with the default values and not signed yet.
+	built, err := api.BuildFeeDelegatedTx(ctx, args)
+	require.NoError(t, err)
+	decoded := new(types.Transaction)
+	require.NoError(t, rlp.DecodeBytes(built.Raw, decoded))
+	assert.Equal(t, txType, decoded.Type())
+	assert.Equal(t, uint64(testNonce), decoded.Nonce())
+	assert.Equal(t, (*big.Int)(testGasPrice), decoded.GasPrice())
+	assert.Equal(t, signer.Hash(decoded), built.SenderSigHash)
+
+	// The sender signs the sender sig hash and the signatures are included in the built transaction.
+	senderSig, err := types.NewTxSignatureWithValues(signer, decoded, built.SenderSigHash, senderPrvKey)
+	require.NoError(t, err)
+	args.TxSignatures = types.TxSignatures{senderSig}.ToJSON()
+	built, err = api.BuildFeeDelegatedTx(ctx, args)
+	require.NoError(t, err)
+
+	// The fee payer signs the fee payer sig hash of the sender-signed transaction.
+	feePayerSig, err := types.NewTxSignatureWithValues(signer, built.Tx, built.FeePayerSigHash, feePayerPrvKey)
+	require.NoError(t, err)
+	appended, err := api.AppendFeePayerSignature(ctx, built.Raw, types.TxSignatures{feePayerSig}.ToJSON())
+	require.NoError(t, err)
+
+	// Both the sender and the fee payer are recovered from the final transaction.
+	final := new(types.Transaction)
+	require.NoError(t, rlp.DecodeBytes(appended.Raw, final))
+	assert.Equal(t, built.SenderSigHash, appended.SenderSigHash)
+	senderPubkeys, err := types.SenderPubkey(signer, final)
+	require.NoError(t, err)
+	require.Len(t, senderPubkeys, 1)
+	assert.Equal(t, sender, crypto.PubkeyToAddress(*senderPubkeys[0]))
+	recoveredFeePayer, err := types.SenderFeePayer(signer, final)
+	require.NoError(t, err)
+	assert.Equal(t, feePayer, recoveredFeePayer)
+
+	// The signature of another key than the fee payer is rejected.
+	wrongSig, err := types.NewTxSignatureWithValues(signer, built.Tx, built.FeePayerSigHash, senderPrvKey)
+	require.NoError(t, err)
+	_, err = api.AppendFeePayerSignature(ctx, built.Raw, types.TxSignatures{wrongSig}.ToJSON())
+	assert.Equal(t, types.ErrInvalidSigFeePayer, err)
+
+	_, err = api.AppendFeePayerSignature(ctx, built.Raw, nil)
+	assert.Equal(t, errTxArgNilFeePayerSig, err)
+
+	// The fee payer signatures cannot be appended to a non-fee-delegated transaction.
+	tx, err := types.NewTransactionWithMap(types.TxTypeValueTransfer, map[types.TxValueKeyType]interface{}{
+		types.TxValueKeyNonce:    uint64(testNonce),
+		types.TxValueKeyFrom:     sender,
+		types.TxValueKeyTo:       testTo,
+		types.TxValueKeyAmount:   (*big.Int)(testValue),
+		types.TxValueKeyGasLimit: uint64(testGas),
+		types.TxValueKeyGasPrice: (*big.Int)(testGasPrice),
+	})
+	require.NoError(t, err)
+	require.NoError(t, tx.SignWithKeys(signer, []*ecdsa.PrivateKey{senderPrvKey}))
+	raw, err := rlp.EncodeToBytes(tx)
+	require.NoError(t, err)
+	_, err = api.AppendFeePayerSignature(ctx, raw, types.TxSignatures{feePayerSig}.ToJSON())
+	assert.Equal(t, errTxArgNotFeeDelegated, err)
+
+	valueTransfer := types.TxTypeValueTransfer
+	args.TypeInt = &valueTransfer
+	_, err = api.BuildFeeDelegatedTx(ctx, args)
+	assert.Equal(t, errTxArgNotFeeDelegated, err)
+}
@@ -24,6 +24,7 @@ import (
 	"fmt"
 
 	"github.com/klaytn/klaytn/blockchain/types"
+	"github.com/klaytn/klaytn/common"
 	"github.com/klaytn/klaytn/common/hexutil"
 )
 
@@ -64,6 +65,29 @@ func (s *PublicTxPoolAPI) Content() map[string]map[string]map[string]map[string]
 	return content
 }
 
+// ContentFrom returns the transactions contained within the transaction pool
+// sent from the given address.
+func (s *PublicTxPoolAPI) ContentFrom(addr common.Address) map[string]map[string]map[string]interface{} {
+	content := make(map[string]map[string]map[string]interface{}, 2)
+	pending, queue := s.b.TxPoolContentFrom(addr)
+
+	// Build the pending transactions
+	dump := make(map[string]map[string]interface{}, len(pending))
+	for _, tx := range pending {
+		dump[fmt.Sprintf("%d", tx.Nonce())] = newRPCPendingTransaction(tx)
+	}
+	content["pending"] = dump
+
+	// Build the queued transactions
+	dump = make(map[string]map[string]interface{}, len(queue))
+	for _, tx := range queue {
+		dump[fmt.Sprintf("%d", tx.Nonce())] = newRPCPendingTransaction(tx)
+	}
+	content["queued"] = dump
+
+	return content
+}
+
 // Status returns the number of pending and queued transaction in the pool.
 func (s *PublicTxPoolAPI) Status() map[string]hexutil.Uint {
 	pending, queue := s.b.Stats()
@@ -76,18 +100,24 @@ func (s *PublicTxPoolAPI) Status() map[string]hexutil.Uint {
 // Inspect retrieves the content of the transaction pool and flattens it into an
 // easily inspectable list.
 func (s *PublicTxPoolAPI) Inspect() map[string]map[string]map[string]string {
+	return inspectTxPool(s.b, "peb")
+}
+
+// inspectTxPool retrieves the content of the transaction pool and flattens it into an
+// easily inspectable list, in which the values and the gas prices are shown in the given unit.
+func inspectTxPool(b Backend, unit string) map[string]map[string]map[string]string {
 	content := map[string]map[string]map[string]string{
 		"pending": make(map[string]map[string]string),
 		"queued":  make(map[string]map[string]string),
 	}
-	pending, queue := s.b.TxPoolContent()
+	pending, queue := b.TxPoolContent()
 
 	// Define a formatter to flatten a transaction into a string
 	format := func(tx *types.Transaction) string {
 		if to := tx.To(); to != nil {
-			return fmt.Sprintf("%s: %v peb + %v gas × %v peb", tx.To().Hex(), tx.Value(), tx.Gas(), tx.GasPrice())
+			return fmt.Sprintf("%s: %v %s + %v gas × %v %s", tx.To().Hex(), tx.Value(), unit, tx.Gas(), tx.GasPrice(), unit)
 		}
-		return fmt.Sprintf("contract creation: %v peb + %v gas × %v peb", tx.Value(), tx.Gas(), tx.GasPrice())
+		return fmt.Sprintf("contract creation: %v %s + %v gas × %v %s", tx.Value(), unit, tx.Gas(), tx.GasPrice(), unit)
 	}
 	// Flatten the pending transactions
 	for account, txs := range pending {
@@ -0,0 +1,224 @@
+// Copyright 2022 The klaytn Authors
+// This file is part of the klaytn library.
+//
+// The klaytn library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The klaytn library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the klaytn library. If not, see <http://www.gnu.org/licenses/>.
+
+package api
+
+import (
+	"bytes"
+	"context"
+	"encoding/binary"
+	"errors"
+	"math/big"
+	"sort"
+
+	"github.com/klaytn/klaytn/blockchain"
+	"github.com/klaytn/klaytn/blockchain/types"
+	"github.com/klaytn/klaytn/blockchain/vm"
+	"github.com/klaytn/klaytn/common"
+	"github.com/klaytn/klaytn/common/hexutil"
+	"github.com/klaytn/klaytn/crypto"
+	"github.com/klaytn/klaytn/networks/rpc"
+	"github.com/klaytn/klaytn/rlp"
+)
+
+// Token standards of the indexed token transfers.
+const (
+	TokenStandardKIP7  = "KIP-7"  // fungible tokens compatible with ERC-20
+	TokenStandardKIP17 = "KIP-17" // non-fungible tokens compatible with ERC-721
+)
+
+// maxTokenBalances is the maximum number of token balances returned by GetTokenBalances at once.
+const maxTokenBalances = 100
+
+var (
+	// transferEventTopic is the topic of the Transfer event of both KIP-7 and KIP-17.
+	transferEventTopic = crypto.Keccak256Hash([]byte("Transfer(address,address,uint256)"))
+	// balanceOfSelector is the function selector of balanceOf(address) of both KIP-7 and KIP-17.
+	balanceOfSelector = crypto.Keccak256([]byte("balanceOf(address)"))[:4]
+
+	errTokenTransferIndexingDisabled = errors.New("token transfer indexing is not enabled")
+)
+
+// TokenTransfer is a token transfer made by a Transfer event of KIP-7 or KIP-17.
+type TokenTransfer struct {
+	Token    common.Address
+	Standard string
+	From     common.Address
+	To       common.Address
+	Value    *big.Int // the amount for KIP-7, and the token id for KIP-17
+	TxHash   common.Hash
+	TxIndex  uint64
+	LogIndex uint64
+}
+
+// ParseTokenTransfer returns the token transfer of the given log.
+// It returns false if the log is not a Transfer event of KIP-7 or KIP-17.
+func ParseTokenTransfer(log *types.Log) (*TokenTransfer, bool) {
+	if len(log.Topics) == 0 || log.Topics[0] != transferEventTopic {
+		return nil, false
+	}
+	transfer := &TokenTransfer{
+		Token:    log.Address,
+		TxHash:   log.TxHash,
+		TxIndex:  uint64(log.TxIndex),
+		LogIndex: uint64(log.Index),
+	}
+	switch {
+	case len(log.Topics) == 3 && len(log.Data) == 32:
+		transfer.Standard = TokenStandardKIP7
+		transfer.Value = new(big.Int).SetBytes(log.Data)
+	case len(log.Topics) == 4 && len(log.Data) == 0:
+		transfer.Standard = TokenStandardKIP17
+		transfer.Value = log.Topics[3].Big()
+	default:
+		return nil, false
+	}
+	transfer.From = common.BytesToAddress(log.Topics[1].Bytes())
+	transfer.To = common.BytesToAddress(log.Topics[2].Bytes())
+	return transfer, true
+}
+
+// RPCTokenTransfer is the RPC representation of TokenTransfer.
+type RPCTokenTransfer struct {
+	Token            common.Address `json:"token"`
+	Standard         string         `json:"standard"`
+	From             common.Address `json:"from"`
+	To               common.Address `json:"to"`
+	Value            *hexutil.Big   `json:"value"`
+	TransactionHash  common.Hash    `json:"transactionHash"`
+	BlockNumber      hexutil.Uint64 `json:"blockNumber"`
+	TransactionIndex hexutil.Uint64 `json:"transactionIndex"`
+	LogIndex         hexutil.Uint64 `json:"logIndex"`
+}
+
+// TokenTransfers is a page of token transfers.
+// Cursor is the position of the next page, and it is nil if there are no more token transfers.
+type TokenTransfers struct {
+	Transfers []*RPCTokenTransfer `json:"transfers"`
+	Cursor    *hexutil.Bytes      `json:"cursor"`
+}
+
+// TokenBalance is the balance of a token. For KIP-17, it is the number of the tokens owned.
+type TokenBalance struct {
+	Token    common.Address `json:"token"`
+	Standard string         `json:"standard"`
+	Balance  *hexutil.Big   `json:"balance"`
+}
+
+// GetTokenTransfers returns the canonical KIP-7 and KIP-17 token transfers from or to the given
+// address from fromBlock to toBlock in ascending order. The token transfers are paginated, and the
+// next page is requested with the cursor of the previous page.
+// The node should be started with the token transfer indexing enabled. The token transfers of the
+// blocks before the indexing was enabled are not returned.
+func (s *PublicBlockChainAPI) GetTokenTransfers(ctx context.Context, address common.Address, fromBlock, toBlock *rpc.BlockNumber, cursor *hexutil.Bytes) (*TokenTransfers, error) {
+	db := s.b.ChainDB()
+	tail, ok := db.ReadTokenTransferIndexTail()
+	if !ok {
+		return nil, errTokenTransferIndexingDisabled
+	}
+	from, fromLogIndex, to, err := txIndexRange(tail, s.b.CurrentBlock().NumberU64(), fromBlock, toBlock, cursor)
+	if err != nil {
+		return nil, err
+	}
+
+	// Read one more entry to find the cursor of the next page.
+	entries := db.ReadTokenTransfers(address, from, fromLogIndex, to, maxAccountTxsPerPage+1)
+	result := &TokenTransfers{Transfers: make([]*RPCTokenTransfer, 0, len(entries))}
+	var block *types.Block
+	for i, entry := range entries {
+		if i == maxAccountTxsPerPage {
+			next := make(hexutil.Bytes, 16)
+			binary.BigEndian.PutUint64(next, entry.BlockNumber)
+			binary.BigEndian.PutUint64(next[8:], entry.LogIndex)
+			result.Cursor = &next
+			break
+		}
+		var transfer TokenTransfer
+		if err := rlp.DecodeBytes(entry.Transfer, &transfer); err != nil {
+			return nil, err
+		}
+		if block == nil || block.NumberU64() != entry.BlockNumber {
+			if block, err = s.b.BlockByNumber(ctx, rpc.BlockNumber(entry.BlockNumber)); err != nil {
+				return nil, err
+			}
+			if block == nil {
+				return nil, errNotFoundBlock
+			}
+		}
+		// The index is not removed on reorganizations, so skip the transfers not in the canonical chain.
+		txs := block.Transactions()
+		if transfer.TxIndex >= uint64(len(txs)) || txs[transfer.TxIndex].Hash() != transfer.TxHash {
+			continue
+		}
+		result.Transfers = append(result.Transfers, &RPCTokenTransfer{
+			Token:            transfer.Token,
+			Standard:         transfer.Standard,
+			From:             transfer.From,
+			To:               transfer.To,
+			Value:            (*hexutil.Big)(transfer.Value),
+			TransactionHash:  transfer.TxHash,
+			BlockNumber:      hexutil.Uint64(entry.BlockNumber),
+			TransactionIndex: hexutil.Uint64(transfer.TxIndex),
+			LogIndex:         hexutil.Uint64(transfer.LogIndex),
+		})
+	}
+	return result, nil
+}
+
+// GetTokenBalances returns the balances of the KIP-7 and KIP-17 tokens which the given address has
+// ever received or sent at the given block, sorted by the token addresses. At most maxTokenBalances
+// tokens are returned. The balances are read by calling balanceOf(address) of the tokens, and the
+// tokens failed to be called are skipped.
+// The node should be started with the token transfer indexing enabled.
+func (s *PublicBlockChainAPI) GetTokenBalances(ctx context.Context, address common.Address, blockNrOrHash rpc.BlockNumberOrHash) ([]*TokenBalance, error) {
+	db := s.b.ChainDB()
+	if _, ok := db.ReadTokenTransferIndexTail(); !ok {
+		return nil, errTokenTransferIndexingDisabled
+	}
+
+	holdings := db.ReadTokenHoldings(address)
+	tokens := make([]common.Address, 0, len(holdings))
+	for token := range holdings {
+		tokens = append(tokens, token)
+	}
+	sort.Slice(tokens, func(i, j int) bool { return bytes.Compare(tokens[i][:], tokens[j][:]) < 0 })
+	if len(tokens) > maxTokenBalances {
+		tokens = tokens[:maxTokenBalances]
+	}
+
+	gasCap := big.NewInt(0)
+	if rpcGasCap := s.b.RPCGasCap(); rpcGasCap != nil {
+		gasCap = rpcGasCap
+	}
+	data := append(append([]byte{}, balanceOfSelector...), common.LeftPadBytes(address.Bytes(), 32)...)
+	balances := make([]*TokenBalance, 0, len(tokens))
+	for _, token := range tokens {
+		to := token
+		result, _, _, status, err := DoCall(ctx, s.b, CallArgs{To: &to, Data: data}, blockNrOrHash, vm.Config{}, s.b.RPCEVMTimeout(), gasCap)
+		if err != nil {
+			return nil, err
+		}
+		if blockchain.GetVMerrFromReceiptStatus(status) != nil || len(result) != 32 {
+			continue
+		}
+		balances = append(balances, &TokenBalance{
+			Token:    token,
+			Standard: string(holdings[token]),
+			Balance:  (*hexutil.Big)(new(big.Int).SetBytes(result)),
+		})
+	}
+	return balances, nil
+}
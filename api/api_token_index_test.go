@@ -0,0 +1,64 @@
+// Copyright 2022 The klaytn Authors
+// This file is part of the klaytn library.
+//
+// The klaytn library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The klaytn library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the klaytn library. If not, see <http://www.gnu.org/licenses/>.
+
+package api
+
+import (
+	"math/big"
+	"testing"
+
+	"github.com/klaytn/klaytn/blockchain/types"
+	"github.com/klaytn/klaytn/common"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParseTokenTransfer(t *testing.T) {
+	var (
+		token = common.HexToAddress("0xaaaa")
+		from  = common.HexToAddress("0x1111")
+		to    = common.HexToAddress("0x2222")
+	)
+
+	// KIP-7 carries the amount in the data.
+	log := &types.Log{
+		Address: token,
+		Topics:  []common.Hash{transferEventTopic, from.Hash(), to.Hash()},
+		Data:    common.BigToHash(big.NewInt(100)).Bytes(),
+		TxIndex: 1,
+		Index:   2,
+	}
+	transfer, ok := ParseTokenTransfer(log)
+	assert.True(t, ok)
+	assert.Equal(t, &TokenTransfer{
+		Token: token, Standard: TokenStandardKIP7, From: from, To: to, Value: big.NewInt(100), TxIndex: 1, LogIndex: 2,
+	}, transfer)
+
+	// KIP-17 carries the token id in the last topic.
+	log = &types.Log{
+		Address: token,
+		Topics:  []common.Hash{transferEventTopic, from.Hash(), to.Hash(), common.BigToHash(big.NewInt(7))},
+	}
+	transfer, ok = ParseTokenTransfer(log)
+	assert.True(t, ok)
+	assert.Equal(t, TokenStandardKIP17, transfer.Standard)
+	assert.Equal(t, big.NewInt(7), transfer.Value)
+
+	// Other events and malformed Transfer events are ignored.
+	_, ok = ParseTokenTransfer(&types.Log{Topics: []common.Hash{common.HexToHash("0x1"), from.Hash(), to.Hash()}, Data: make([]byte, 32)})
+	assert.False(t, ok)
+	_, ok = ParseTokenTransfer(&types.Log{Topics: []common.Hash{transferEventTopic, from.Hash(), to.Hash()}})
+	assert.False(t, ok)
+}
@@ -0,0 +1,250 @@
+// Copyright 2022 The klaytn Authors
+// This file is part of the klaytn library.
+//
+// The klaytn library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The klaytn library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the klaytn library. If not, see <http://www.gnu.org/licenses/>.
+
+package api
+
+import (
+	"context"
+	"encoding/binary"
+	"errors"
+	"fmt"
+
+	"github.com/klaytn/klaytn/blockchain/types"
+	"github.com/klaytn/klaytn/blockchain/vm"
+	"github.com/klaytn/klaytn/common"
+	"github.com/klaytn/klaytn/common/hexutil"
+	"github.com/klaytn/klaytn/networks/rpc"
+	"github.com/klaytn/klaytn/rlp"
+	"github.com/klaytn/klaytn/storage/database"
+)
+
+// maxAccountTxsPerPage is the maximum number of transactions returned by GetTransactionsByAccount
+// and GetInternalTransactions at once.
+const maxAccountTxsPerPage = 100
+
+var (
+	errAccountTxIndexingDisabled  = errors.New("account tx indexing is not enabled")
+	errInternalTxIndexingDisabled = errors.New("internal tx indexing is not enabled")
+	errInvalidAccountTxCursor     = errors.New("invalid cursor")
+	errInvalidInternalTxQuery     = errors.New("query should be a transaction hash or an address")
+)
+
+// AccountTransactions is a page of the transactions related to an account.
+// Cursor is the position of the next page, and it is nil if there are no more transactions.
+type AccountTransactions struct {
+	Transactions []map[string]interface{} `json:"transactions"`
+	Cursor       *hexutil.Bytes           `json:"cursor"`
+}
+
+// RPCInternalTransaction is a value transfer or a contract creation made by a contract.
+type RPCInternalTransaction struct {
+	TransactionHash  common.Hash    `json:"transactionHash"`
+	BlockNumber      hexutil.Uint64 `json:"blockNumber"`
+	TransactionIndex hexutil.Uint64 `json:"transactionIndex"`
+	Type             string         `json:"type"`
+	From             common.Address `json:"from"`
+	To               common.Address `json:"to"`
+	Value            *hexutil.Big   `json:"value"`
+}
+
+// InternalTransactions is a page of internal transactions.
+// Cursor is the position of the next page, and it is nil if there are no more internal transactions.
+type InternalTransactions struct {
+	InternalTransactions []*RPCInternalTransaction `json:"internalTransactions"`
+	Cursor               *hexutil.Bytes            `json:"cursor"`
+}
+
+// GetTransactionsByAccount returns the canonical transactions sent, received or paid by the given
+// address from fromBlock to toBlock in ascending order. The transactions are paginated, and the next
+// page is requested with the cursor of the previous page.
+// The node should be started with the account tx indexing enabled. The transactions of the blocks
+// before the indexing was enabled are not returned.
+func (s *PublicTransactionPoolAPI) GetTransactionsByAccount(ctx context.Context, address common.Address, fromBlock, toBlock *rpc.BlockNumber, cursor *hexutil.Bytes) (*AccountTransactions, error) {
+	db := s.b.ChainDB()
+	tail, ok := db.ReadAccountTxIndexTail()
+	if !ok {
+		return nil, errAccountTxIndexingDisabled
+	}
+	from, fromIndex, to, err := txIndexRange(tail, s.b.CurrentBlock().NumberU64(), fromBlock, toBlock, cursor)
+	if err != nil {
+		return nil, err
+	}
+
+	// Read one more entry to find the cursor of the next page.
+	entries := db.ReadAccountTxIndex(address, from, fromIndex, to, maxAccountTxsPerPage+1)
+	result := &AccountTransactions{Transactions: make([]map[string]interface{}, 0, len(entries))}
+	err = s.forEachCanonicalTx(ctx, entries, func(block *types.Block, entry database.AccountTxIndexEntry) error {
+		tx := block.Transactions()[entry.Index]
+		result.Transactions = append(result.Transactions, newRPCTransaction(block, tx, block.Hash(), entry.BlockNumber, entry.Index))
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	result.Cursor = txIndexCursor(entries)
+	return result, nil
+}
+
+// GetInternalTransactions returns the internal transactions of the given transaction hash, or the
+// internal transactions from or to the given address from fromBlock to toBlock in ascending order.
+// The internal transactions of an address are paginated by their transactions, and the next page is
+// requested with the cursor of the previous page. The range and the cursor are ignored for a
+// transaction hash.
+// The node should be started with the internal tx indexing enabled. The internal transactions of
+// the blocks before the indexing was enabled are not returned.
+func (s *PublicTransactionPoolAPI) GetInternalTransactions(ctx context.Context, query string, fromBlock, toBlock *rpc.BlockNumber, cursor *hexutil.Bytes) (*InternalTransactions, error) {
+	db := s.b.ChainDB()
+	tail, ok := db.ReadInternalTxIndexTail()
+	if !ok {
+		return nil, errInternalTxIndexingDisabled
+	}
+
+	switch {
+	case len(query) == 2+2*common.HashLength:
+		txHash := common.HexToHash(query)
+		blockHash, blockNum, index := db.ReadTxLookupEntry(txHash)
+		if blockHash == (common.Hash{}) {
+			return &InternalTransactions{InternalTransactions: []*RPCInternalTransaction{}}, nil
+		}
+		internalTxs, err := readInternalTxs(db, txHash, blockNum, index, nil)
+		if err != nil {
+			return nil, err
+		}
+		return &InternalTransactions{InternalTransactions: internalTxs}, nil
+
+	case common.IsHexAddress(query):
+		address := common.HexToAddress(query)
+		from, fromIndex, to, err := txIndexRange(tail, s.b.CurrentBlock().NumberU64(), fromBlock, toBlock, cursor)
+		if err != nil {
+			return nil, err
+		}
+
+		entries := db.ReadInternalTxAccountIndex(address, from, fromIndex, to, maxAccountTxsPerPage+1)
+		result := &InternalTransactions{InternalTransactions: []*RPCInternalTransaction{}}
+		err = s.forEachCanonicalTx(ctx, entries, func(block *types.Block, entry database.AccountTxIndexEntry) error {
+			internalTxs, err := readInternalTxs(db, entry.TxHash, entry.BlockNumber, entry.Index, &address)
+			if err != nil {
+				return err
+			}
+			result.InternalTransactions = append(result.InternalTransactions, internalTxs...)
+			return nil
+		})
+		if err != nil {
+			return nil, err
+		}
+		result.Cursor = txIndexCursor(entries)
+		return result, nil
+	}
+	return nil, errInvalidInternalTxQuery
+}
+
+// readInternalTxs returns the internal transactions of the given transaction.
+// If address is not nil, only the internal transactions from or to the address are returned.
+func readInternalTxs(db database.DBManager, txHash common.Hash, blockNum, index uint64, address *common.Address) ([]*RPCInternalTransaction, error) {
+	result := []*RPCInternalTransaction{}
+	data := db.ReadInternalTxs(txHash)
+	if len(data) == 0 {
+		return result, nil
+	}
+	var internalTxs []*vm.InternalTx
+	if err := rlp.DecodeBytes(data, &internalTxs); err != nil {
+		return nil, err
+	}
+	for _, internalTx := range internalTxs {
+		if address != nil && internalTx.From != *address && internalTx.To != *address {
+			continue
+		}
+		result = append(result, &RPCInternalTransaction{
+			TransactionHash:  txHash,
+			BlockNumber:      hexutil.Uint64(blockNum),
+			TransactionIndex: hexutil.Uint64(index),
+			Type:             internalTx.Type,
+			From:             internalTx.From,
+			To:               internalTx.To,
+			Value:            (*hexutil.Big)(internalTx.Value),
+		})
+	}
+	return result, nil
+}
+
+// forEachCanonicalTx calls fn for the first maxAccountTxsPerPage entries whose transactions are
+// in the canonical chain. The index is not removed on reorganizations, so the others are skipped.
+// It stops at the first error returned by fn and returns it.
+func (s *PublicTransactionPoolAPI) forEachCanonicalTx(ctx context.Context, entries []database.AccountTxIndexEntry, fn func(*types.Block, database.AccountTxIndexEntry) error) error {
+	var block *types.Block
+	for i, entry := range entries {
+		if i == maxAccountTxsPerPage {
+			break
+		}
+		if block == nil || block.NumberU64() != entry.BlockNumber {
+			var err error
+			if block, err = s.b.BlockByNumber(ctx, rpc.BlockNumber(entry.BlockNumber)); err != nil {
+				return err
+			}
+			if block == nil {
+				return errNotFoundBlock
+			}
+		}
+		txs := block.Transactions()
+		if entry.Index >= uint64(len(txs)) || txs[entry.Index].Hash() != entry.TxHash {
+			continue
+		}
+		if err := fn(block, entry); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// txIndexRange returns the starting position and the last block of a query to a transaction index.
+// The range is limited to the blocks from the tail of the index to the head of the chain.
+func txIndexRange(tail, head uint64, fromBlock, toBlock *rpc.BlockNumber, cursor *hexutil.Bytes) (uint64, uint64, uint64, error) {
+	from, to := tail, head
+	if fromBlock != nil && *fromBlock >= 0 && uint64(*fromBlock) > from {
+		from = uint64(*fromBlock)
+	}
+	if toBlock != nil && *toBlock >= 0 && uint64(*toBlock) < to {
+		to = uint64(*toBlock)
+	}
+	if from > to {
+		return 0, 0, 0, fmt.Errorf("fromBlock %d is later than toBlock %d", from, to)
+	}
+
+	var fromIndex uint64
+	if cursor != nil {
+		if len(*cursor) != 16 {
+			return 0, 0, 0, errInvalidAccountTxCursor
+		}
+		cursorBlock := binary.BigEndian.Uint64(*cursor)
+		if cursorBlock < from {
+			return 0, 0, 0, errInvalidAccountTxCursor
+		}
+		from, fromIndex = cursorBlock, binary.BigEndian.Uint64((*cursor)[8:])
+	}
+	return from, fromIndex, to, nil
+}
+
+// txIndexCursor returns the cursor of the next page if there are more entries than a page.
+// The entries should be read with the limit maxAccountTxsPerPage+1.
+func txIndexCursor(entries []database.AccountTxIndexEntry) *hexutil.Bytes {
+	if len(entries) <= maxAccountTxsPerPage {
+		return nil
+	}
+	next := make(hexutil.Bytes, 16)
+	binary.BigEndian.PutUint64(next, entries[maxAccountTxsPerPage].BlockNumber)
+	binary.BigEndian.PutUint64(next[8:], entries[maxAccountTxsPerPage].Index)
+	return &next
+}
@@ -30,6 +30,7 @@ import (
 	"github.com/klaytn/klaytn/blockchain"
 	"github.com/klaytn/klaytn/blockchain/state"
 	"github.com/klaytn/klaytn/blockchain/types"
+	"github.com/klaytn/klaytn/blockchain/types/account"
 	"github.com/klaytn/klaytn/blockchain/vm"
 	"github.com/klaytn/klaytn/common"
 	"github.com/klaytn/klaytn/consensus"
@@ -47,14 +48,16 @@ type Backend interface {
 	Progress() klaytn.SyncProgress
 	ProtocolVersion() int
 	SuggestPrice(ctx context.Context) (*big.Int, error)
+	SuggestTipCap(ctx context.Context) (*big.Int, error)
 	UpperBoundGasPrice(ctx context.Context) *big.Int
 	LowerBoundGasPrice(ctx context.Context) *big.Int
 	ChainDB() database.DBManager
 	EventMux() *event.TypeMux
 	AccountManager() accounts.AccountManager
-	RPCEVMTimeout() time.Duration // global timeout for klay_call
-	RPCGasCap() *big.Int          // global gas cap for klay_call over rpc: DoS protection
-	RPCTxFeeCap() float64         // global tx fee cap for all transaction related APIs
+	RPCEVMTimeout() time.Duration           // global timeout for klay_call
+	RPCGasCap() *big.Int                    // global gas cap for klay_call over rpc: DoS protection
+	RPCTxFeeCap() float64                   // global tx fee cap for all transaction related APIs
+	RPCFeePayerWhitelist() []common.Address // accounts allowed to sign as a fee payer over rpc
 	Engine() consensus.Engine
 	FeeHistory(ctx context.Context, blockCount int, lastBlock rpc.BlockNumber, rewardPercentiles []float64) (*big.Int, [][]*big.Int, []*big.Int, []float64, error)
 
@@ -68,6 +71,8 @@ type Backend interface {
 	BlockByNumberOrHash(ctx context.Context, blockNrOrHash rpc.BlockNumberOrHash) (*types.Block, error)
 	StateAndHeaderByNumber(ctx context.Context, number rpc.BlockNumber) (*state.StateDB, *types.Header, error)
 	StateAndHeaderByNumberOrHash(ctx context.Context, blockNrOrHash rpc.BlockNumberOrHash) (*state.StateDB, *types.Header, error)
+	HistoricalAccount(ctx context.Context, address common.Address, header *types.Header) (account.Account, error)
+	HistoricalStorage(ctx context.Context, address common.Address, key common.Hash, header *types.Header) (common.Hash, error)
 	GetBlockReceipts(ctx context.Context, blockHash common.Hash) types.Receipts
 	GetTxLookupInfoAndReceipt(ctx context.Context, hash common.Hash) (*types.Transaction, common.Hash, uint64, uint64, *types.Receipt)
 	GetTxAndLookupInfo(hash common.Hash) (*types.Transaction, common.Hash, uint64, uint64)
@@ -79,6 +84,8 @@ type Backend interface {
 	IsParallelDBWrite() bool
 
 	IsSenderTxHashIndexingEnabled() bool
+	TxIndexDone() bool
+	IsTxLookupSupported() bool
 
 	// TxPool API
 	SendTx(ctx context.Context, signedTx *types.Transaction) error
@@ -87,6 +94,7 @@ type Backend interface {
 	GetPoolNonce(ctx context.Context, addr common.Address) uint64
 	Stats() (pending int, queued int)
 	TxPoolContent() (map[common.Address]types.Transactions, map[common.Address]types.Transactions)
+	TxPoolContentFrom(addr common.Address) (types.Transactions, types.Transactions)
 	SubscribeNewTxsEvent(chan<- blockchain.NewTxsEvent) event.Subscription
 
 	ChainConfig() *params.ChainConfig
@@ -148,6 +156,9 @@ func GetAPIs(apiBackend Backend, disableUnsafeDebug bool) ([]rpc.API, *EthereumA
 			Version:   "1.0",
 			Service:   NewPrivateAccountAPI(apiBackend, nonceLock),
 			Public:    false,
+			Deprecated: map[string]string{
+				"signAndSendTransaction": "personal_sendTransaction",
+			},
 		},
 	}
 	privateDebugApi := []rpc.API{
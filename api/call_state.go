@@ -0,0 +1,127 @@
+// Copyright 2022 The klaytn Authors
+// This file is part of the klaytn library.
+//
+// The klaytn library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The klaytn library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the klaytn library. If not, see <http://www.gnu.org/licenses/>.
+
+package api
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/klaytn/klaytn/blockchain/state"
+	"github.com/klaytn/klaytn/blockchain/types"
+	"github.com/klaytn/klaytn/common"
+	"github.com/klaytn/klaytn/networks/rpc"
+	"github.com/rcrowley/go-metrics"
+)
+
+// maxPinnedCallStates is the maximum number of the states pinned for calls at the same time.
+const maxPinnedCallStates = 16
+
+var (
+	callStateHits   = metrics.NewRegisteredCounter("api/callstate/hits", nil)
+	callStateMisses = metrics.NewRegisteredCounter("api/callstate/misses", nil)
+
+	callStates = &callStatePool{states: make(map[common.Hash]*pinnedCallState)}
+)
+
+// pinnedCallState is a state of a block opened for calls, which is reused until it expires.
+type pinnedCallState struct {
+	state  *state.StateDB
+	header *types.Header
+	expiry time.Time
+}
+
+// callStatePool pins the states opened for calls, so that a burst of calls against the same block
+// reuses the resolved state instead of resolving the state root and warming up the caches again.
+// The pinned states are never modified; each call gets its own copy.
+type callStatePool struct {
+	mu     sync.Mutex
+	window time.Duration // how long a pinned state is reused, 0 if disabled
+	states map[common.Hash]*pinnedCallState
+}
+
+// SetCallStateReuseWindow sets how long a state opened for a call is reused by the following
+// calls against the same block. The reuse is disabled if the window is 0.
+func SetCallStateReuseWindow(window time.Duration) {
+	callStates.mu.Lock()
+	defer callStates.mu.Unlock()
+
+	callStates.window = window
+	if window <= 0 {
+		callStates.states = make(map[common.Hash]*pinnedCallState)
+	}
+}
+
+// callStateAndHeader returns a copy of the state and the header of the given block for a call.
+// The state is pinned and reused by the calls within the reuse window.
+func callStateAndHeader(ctx context.Context, b Backend, blockNrOrHash rpc.BlockNumberOrHash) (*state.StateDB, *types.Header, error) {
+	callStates.mu.Lock()
+	window := callStates.window
+	callStates.mu.Unlock()
+
+	if number, ok := blockNrOrHash.Number(); window <= 0 || (ok && number == rpc.PendingBlockNumber) {
+		return b.StateAndHeaderByNumberOrHash(ctx, blockNrOrHash)
+	}
+	header, err := b.HeaderByNumberOrHash(ctx, blockNrOrHash)
+	if header == nil || err != nil {
+		return b.StateAndHeaderByNumberOrHash(ctx, blockNrOrHash)
+	}
+	hash := header.Hash()
+	if st, header := callStates.get(hash); st != nil {
+		callStateHits.Inc(1)
+		return st, header, nil
+	}
+	callStateMisses.Inc(1)
+
+	st, header, err := b.StateAndHeaderByNumberOrHash(ctx, rpc.NewBlockNumberOrHashWithHash(hash, false))
+	if st == nil || err != nil {
+		return st, header, err
+	}
+	callStates.put(hash, st, header, window)
+	return st.Copy(), header, nil
+}
+
+func (p *callStatePool) get(hash common.Hash) (*state.StateDB, *types.Header) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	pinned, ok := p.states[hash]
+	if !ok {
+		return nil, nil
+	}
+	if time.Now().After(pinned.expiry) {
+		delete(p.states, hash)
+		return nil, nil
+	}
+	return pinned.state.Copy(), pinned.header
+}
+
+func (p *callStatePool) put(hash common.Hash, st *state.StateDB, header *types.Header, window time.Duration) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	now := time.Now()
+	for h, pinned := range p.states {
+		if now.After(pinned.expiry) {
+			delete(p.states, h)
+		}
+	}
+	if len(p.states) >= maxPinnedCallStates {
+		return
+	}
+	p.states[hash] = &pinnedCallState{state: st, header: header, expiry: now.Add(window)}
+}
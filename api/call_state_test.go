@@ -0,0 +1,69 @@
+// Copyright 2022 The klaytn Authors
+// This file is part of the klaytn library.
+//
+// The klaytn library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The klaytn library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the klaytn library. If not, see <http://www.gnu.org/licenses/>.
+
+package api
+
+import (
+	"context"
+	"math/big"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	mock_api "github.com/klaytn/klaytn/api/mocks"
+	"github.com/klaytn/klaytn/blockchain/state"
+	"github.com/klaytn/klaytn/blockchain/types"
+	"github.com/klaytn/klaytn/common"
+	"github.com/klaytn/klaytn/networks/rpc"
+	"github.com/klaytn/klaytn/storage/database"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCallStateAndHeader(t *testing.T) {
+	var (
+		ctx    = context.Background()
+		addr   = common.HexToAddress("0x1111")
+		header = &types.Header{Number: big.NewInt(10)}
+		latest = rpc.NewBlockNumberOrHashWithNumber(rpc.LatestBlockNumber)
+	)
+	mockCtrl := gomock.NewController(t)
+	defer mockCtrl.Finish()
+	mockBackend := mock_api.NewMockBackend(mockCtrl)
+
+	SetCallStateReuseWindow(time.Minute)
+	defer SetCallStateReuseWindow(0)
+
+	// The state is opened only once and its copies are used by the calls.
+	mockBackend.EXPECT().HeaderByNumberOrHash(ctx, latest).Return(header, nil).Times(3)
+	mockBackend.EXPECT().StateAndHeaderByNumberOrHash(ctx, rpc.NewBlockNumberOrHashWithHash(header.Hash(), false)).DoAndReturn(
+		func(context.Context, rpc.BlockNumberOrHash) (*state.StateDB, *types.Header, error) {
+			st, err := state.New(common.Hash{}, state.NewDatabase(database.NewMemoryDBManager()), nil)
+			require.NoError(t, err)
+			st.AddBalance(addr, big.NewInt(1))
+			return st, header, nil
+		}).Times(1)
+
+	for i := 0; i < 3; i++ {
+		st, h, err := callStateAndHeader(ctx, mockBackend, latest)
+		require.NoError(t, err)
+		assert.Equal(t, header, h)
+		assert.Equal(t, big.NewInt(1), st.GetBalance(addr))
+
+		// A call modifying its state does not affect the following calls.
+		st.AddBalance(addr, big.NewInt(1))
+	}
+}
@@ -0,0 +1,150 @@
+// Copyright 2022 The klaytn Authors
+// This file is part of the klaytn library.
+//
+// The klaytn library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The klaytn library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the klaytn library. If not, see <http://www.gnu.org/licenses/>.
+
+package api
+
+import (
+	"crypto/ecdsa"
+	"errors"
+	"fmt"
+
+	"github.com/klaytn/klaytn/blockchain/types"
+	"github.com/klaytn/klaytn/blockchain/types/account"
+	"github.com/klaytn/klaytn/blockchain/types/accountkey"
+	"github.com/klaytn/klaytn/common"
+	"github.com/klaytn/klaytn/common/hexutil"
+	"github.com/klaytn/klaytn/crypto"
+)
+
+// DecodedAccount is a human-friendly view of an account.
+// Unlike account.AccountSerializer, the account key is fully decoded.
+type DecodedAccount struct {
+	AccType       string              `json:"accType"`
+	AccTypeID     account.AccountType `json:"accTypeId"`
+	Nonce         hexutil.Uint64      `json:"nonce"`
+	Balance       *hexutil.Big        `json:"balance"`
+	HumanReadable bool                `json:"humanReadable"`
+	Key           *DecodedAccountKey  `json:"key"`
+	CodeHash      common.Hash         `json:"codeHash"`
+	StorageRoot   common.Hash         `json:"storageRoot"`
+}
+
+// DecodedAccountKey is a human-friendly view of an account key.
+// Only the fields related to the key type are set.
+type DecodedAccountKey struct {
+	KeyType   string                    `json:"keyType"`
+	KeyTypeID accountkey.AccountKeyType `json:"keyTypeId"`
+
+	// AccountKeyPublic
+	PublicKey *DecodedPublicKey `json:"publicKey,omitempty"`
+
+	// AccountKeyWeightedMultiSig
+	Threshold *hexutil.Uint         `json:"threshold,omitempty"`
+	Keys      []*DecodedWeightedKey `json:"keys,omitempty"`
+
+	// AccountKeyRoleBased
+	Roles []*DecodedRoleKey `json:"roles,omitempty"`
+}
+
+// DecodedPublicKey contains a public key in the compressed and the uncompressed forms.
+type DecodedPublicKey struct {
+	Compressed hexutil.Bytes `json:"compressed"`
+	X          *hexutil.Big  `json:"x"`
+	Y          *hexutil.Big  `json:"y"`
+}
+
+// DecodedWeightedKey is a public key of AccountKeyWeightedMultiSig with its weight.
+type DecodedWeightedKey struct {
+	Weight    hexutil.Uint      `json:"weight"`
+	PublicKey *DecodedPublicKey `json:"publicKey"`
+}
+
+// DecodedRoleKey is the key of a role of AccountKeyRoleBased.
+// If the key of the role is not set, the key of RoleTransaction is used and Inherited is true.
+type DecodedRoleKey struct {
+	Role      string             `json:"role"`
+	Key       *DecodedAccountKey `json:"key"`
+	Inherited bool               `json:"inherited"`
+}
+
+// decodeAccount returns the decoded view of the given account.
+// The code hash and the storage root of an account without a program are those of an empty account.
+func decodeAccount(acc account.Account, key accountkey.AccountKey) (*DecodedAccount, error) {
+	decodedKey, err := decodeAccountKey(key)
+	if err != nil {
+		return nil, err
+	}
+	decoded := &DecodedAccount{
+		AccType:       acc.Type().String(),
+		AccTypeID:     acc.Type(),
+		Nonce:         hexutil.Uint64(acc.GetNonce()),
+		Balance:       (*hexutil.Big)(acc.GetBalance()),
+		HumanReadable: acc.GetHumanReadable(),
+		Key:           decodedKey,
+		CodeHash:      crypto.Keccak256Hash(nil),
+		StorageRoot:   types.EmptyRootHashOriginal,
+	}
+	if pa := account.GetProgramAccount(acc); pa != nil {
+		decoded.CodeHash = common.BytesToHash(pa.GetCodeHash())
+		decoded.StorageRoot = pa.GetStorageRoot()
+	}
+	return decoded, nil
+}
+
+// decodeAccountKey returns the decoded view of the given account key.
+func decodeAccountKey(key accountkey.AccountKey) (*DecodedAccountKey, error) {
+	decoded := &DecodedAccountKey{KeyType: key.Type().String(), KeyTypeID: key.Type()}
+
+	switch k := key.(type) {
+	case *accountkey.AccountKeyNil, *accountkey.AccountKeyLegacy, *accountkey.AccountKeyFail:
+	case *accountkey.AccountKeyPublic:
+		decoded.PublicKey = decodePublicKey(k.PublicKeySerializable)
+	case *accountkey.AccountKeyWeightedMultiSig:
+		threshold := hexutil.Uint(k.Threshold)
+		decoded.Threshold = &threshold
+		decoded.Keys = make([]*DecodedWeightedKey, len(k.Keys))
+		for i, wk := range k.Keys {
+			decoded.Keys[i] = &DecodedWeightedKey{Weight: hexutil.Uint(wk.Weight), PublicKey: decodePublicKey(wk.Key)}
+		}
+	case *accountkey.AccountKeyRoleBased:
+		if len(*k) == 0 {
+			return nil, errors.New("role-based key has no key")
+		}
+		decoded.Roles = make([]*DecodedRoleKey, accountkey.RoleLast)
+		for r := accountkey.RoleTransaction; r < accountkey.RoleLast; r++ {
+			roleKey, inherited := (*k)[accountkey.RoleTransaction], true
+			if int(r) < len(*k) {
+				roleKey, inherited = (*k)[r], false
+			}
+			decodedRoleKey, err := decodeAccountKey(roleKey)
+			if err != nil {
+				return nil, err
+			}
+			decoded.Roles[r] = &DecodedRoleKey{Role: r.String(), Key: decodedRoleKey, Inherited: inherited}
+		}
+	default:
+		return nil, fmt.Errorf("undefined account key type %d", key.Type())
+	}
+	return decoded, nil
+}
+
+func decodePublicKey(pk *accountkey.PublicKeySerializable) *DecodedPublicKey {
+	return &DecodedPublicKey{
+		Compressed: crypto.CompressPubkey((*ecdsa.PublicKey)(pk)),
+		X:          (*hexutil.Big)(pk.X),
+		Y:          (*hexutil.Big)(pk.Y),
+	}
+}
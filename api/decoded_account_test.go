@@ -0,0 +1,60 @@
+// Copyright 2022 The klaytn Authors
+// This file is part of the klaytn library.
+//
+// The klaytn library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The klaytn library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the klaytn library. If not, see <http://www.gnu.org/licenses/>.
+
+package api
+
+import (
+	"testing"
+
+	"github.com/klaytn/klaytn/blockchain/types/accountkey"
+	"github.com/klaytn/klaytn/crypto"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDecodeAccountKey(t *testing.T) {
+	k1, _ := crypto.GenerateKey()
+	k2, _ := crypto.GenerateKey()
+
+	multiSig := accountkey.NewAccountKeyWeightedMultiSigWithValues(2, accountkey.WeightedPublicKeys{
+		accountkey.NewWeightedPublicKey(1, (*accountkey.PublicKeySerializable)(&k1.PublicKey)),
+		accountkey.NewWeightedPublicKey(1, (*accountkey.PublicKeySerializable)(&k2.PublicKey)),
+	})
+	// RoleFeePayer is not set, so it inherits the key of RoleTransaction.
+	roleBased := accountkey.NewAccountKeyRoleBasedWithValues([]accountkey.AccountKey{
+		accountkey.NewAccountKeyPublicWithValue(&k1.PublicKey),
+		multiSig,
+	})
+
+	decoded, err := decodeAccountKey(roleBased)
+	require.NoError(t, err)
+	assert.Equal(t, "AccountKeyRoleBased", decoded.KeyType)
+	require.Len(t, decoded.Roles, int(accountkey.RoleLast))
+
+	txRole := decoded.Roles[accountkey.RoleTransaction]
+	assert.Equal(t, "RoleTransaction", txRole.Role)
+	assert.False(t, txRole.Inherited)
+	assert.Equal(t, crypto.CompressPubkey(&k1.PublicKey), []byte(txRole.Key.PublicKey.Compressed))
+
+	updateRole := decoded.Roles[accountkey.RoleAccountUpdate]
+	assert.Equal(t, "AccountKeyWeightedMultiSig", updateRole.Key.KeyType)
+	assert.Equal(t, uint(2), uint(*updateRole.Key.Threshold))
+	assert.Len(t, updateRole.Key.Keys, 2)
+
+	feePayerRole := decoded.Roles[accountkey.RoleFeePayer]
+	assert.True(t, feePayerRole.Inherited)
+	assert.Equal(t, txRole.Key, feePayerRole.Key)
+}
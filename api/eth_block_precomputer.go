@@ -0,0 +1,110 @@
+// Copyright 2022 The klaytn Authors
+// This file is part of the klaytn library.
+//
+// The klaytn library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The klaytn library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the klaytn library. If not, see <http://www.gnu.org/licenses/>.
+
+package api
+
+import (
+	"sync"
+
+	"github.com/klaytn/klaytn/blockchain"
+	"github.com/klaytn/klaytn/blockchain/types"
+	"github.com/klaytn/klaytn/event"
+)
+
+// ethBlockPrecomputeChanSize is the size of channel listening to ChainEvent.
+const ethBlockPrecomputeChanSize = 10
+
+// EthBlockPrecomputer marshals each new block and its receipts as Ethereum compatible format
+// when the block is inserted into the chain, and stores them in the RPC caches, so that
+// the eth namespace APIs serve the new blocks by cache reads instead of repeated conversions.
+type EthBlockPrecomputer struct {
+	api     *EthereumAPI
+	b       Backend
+	chainCh chan blockchain.ChainEvent
+	sub     event.Subscription
+	quit    chan struct{}
+	wg      sync.WaitGroup
+}
+
+// NewEthBlockPrecomputer subscribes the chain events of the backend and starts precomputing
+// the RPC outputs of the new blocks by the given EthereumAPI.
+func NewEthBlockPrecomputer(api *EthereumAPI, b Backend) *EthBlockPrecomputer {
+	p := &EthBlockPrecomputer{
+		api:     api,
+		b:       b,
+		chainCh: make(chan blockchain.ChainEvent, ethBlockPrecomputeChanSize),
+		quit:    make(chan struct{}),
+	}
+	p.sub = b.SubscribeChainEvent(p.chainCh)
+
+	p.wg.Add(1)
+	go p.loop()
+	return p
+}
+
+func (p *EthBlockPrecomputer) loop() {
+	defer p.wg.Done()
+	defer p.sub.Unsubscribe()
+
+	for {
+		select {
+		case ev := <-p.chainCh:
+			if err := p.precompute(ev.Block, ev.Receipts); err != nil {
+				logger.Warn("Failed to precompute the Ethereum-format block", "blockNum", ev.Block.NumberU64(), "err", err)
+			}
+		case <-p.sub.Err():
+			return
+		case <-p.quit:
+			return
+		}
+	}
+}
+
+// precompute stores the marshaled block with and without the full transactions, and the marshaled receipts.
+func (p *EthBlockPrecomputer) precompute(block *types.Block, receipts types.Receipts) error {
+	hash := block.Hash()
+	for _, fullTx := range []bool{false, true} {
+		fields, err := p.api.rpcMarshalBlock(block, true, fullTx)
+		if err != nil {
+			return err
+		}
+		ethBlockCache.add(ethBlockCacheKey{hash, fullTx, p.api.klaytnTxMode}, fields)
+	}
+
+	txs := block.Transactions()
+	if len(receipts) != len(txs) {
+		return nil
+	}
+	header := block.Header()
+	cumulativeGasUsed := uint64(0)
+	for i, tx := range txs {
+		cumulativeGasUsed += receipts[i].GasUsed
+		fields, err := p.api.rpcMarshalReceipt(p.b, header, tx, hash, block.NumberU64(), uint64(i), cumulativeGasUsed, receipts[i])
+		if err != nil {
+			return err
+		}
+		if fields != nil {
+			ethReceiptCache.add(ethReceiptCacheKey{hash, tx.Hash(), p.api.klaytnTxMode}, fields)
+		}
+	}
+	return nil
+}
+
+// Stop stops precomputing the new blocks.
+func (p *EthBlockPrecomputer) Stop() {
+	close(p.quit)
+	p.wg.Wait()
+}
@@ -0,0 +1,129 @@
+// Copyright 2022 The klaytn Authors
+// This file is part of the klaytn library.
+//
+// The klaytn library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The klaytn library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the klaytn library. If not, see <http://www.gnu.org/licenses/>.
+
+package api
+
+import (
+	"context"
+	"math/big"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/klaytn/klaytn/blockchain"
+	"github.com/klaytn/klaytn/blockchain/types"
+	"github.com/klaytn/klaytn/common"
+	"github.com/klaytn/klaytn/common/hexutil"
+	"github.com/klaytn/klaytn/consensus/mocks"
+	"github.com/klaytn/klaytn/event"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestEthBlockPrecomputer(t *testing.T) {
+	mockCtrl, mockBackend, api := testInitForEthApi(t)
+	defer mockCtrl.Finish()
+
+	mockEngine := mocks.NewMockEngine(mockCtrl)
+	mockBackend.EXPECT().Engine().Return(mockEngine).AnyTimes()
+	mockEngine.EXPECT().Author(gomock.Any()).Return(common.Address{}, nil).AnyTimes()
+	mockBackend.EXPECT().ChainConfig().Return(dummyChainConfigForEthereumAPITest).AnyTimes()
+	mockBackend.EXPECT().GetTd(gomock.Any()).Return(big.NewInt(1)).AnyTimes()
+
+	var chainFeed event.Feed
+	mockBackend.EXPECT().SubscribeChainEvent(gomock.Any()).DoAndReturn(
+		func(ch chan<- blockchain.ChainEvent) event.Subscription {
+			return chainFeed.Subscribe(ch)
+		})
+
+	require.NoError(t, ResizeRPCCache(RPCCacheEthBlocks, 10))
+	require.NoError(t, ResizeRPCCache(RPCCacheEthReceipts, 100))
+	defer ResizeRPCCache(RPCCacheEthBlocks, 0)
+	defer ResizeRPCCache(RPCCacheEthReceipts, 0)
+
+	p := NewEthBlockPrecomputer(&api, mockBackend)
+	defer p.Stop()
+
+	// The receipts are not precomputed if they do not match the transactions of the block.
+	mismatched, _, _, _, mismatchedReceipts := createTestData(t, &types.Header{Number: big.NewInt(1)})
+	chainFeed.Send(blockchain.ChainEvent{Block: mismatched, Hash: mismatched.Hash(), Receipts: mismatchedReceipts[1:]})
+
+	block, txs, txHashMap, receiptMap, receipts := createTestData(t, &types.Header{Number: big.NewInt(2)})
+	chainFeed.Send(blockchain.ChainEvent{Block: block, Hash: block.Hash(), Receipts: receipts})
+
+	// The receipts of the last transaction are stored last, after the chain events are handled in order.
+	lastKey := ethReceiptCacheKey{block.Hash(), txs[len(txs)-1].Hash(), api.klaytnTxMode}
+	assert.Eventually(t, func() bool {
+		_, ok := ethReceiptCache.get(lastKey)
+		return ok
+	}, 5*time.Second, 10*time.Millisecond)
+
+	for _, fullTx := range []bool{false, true} {
+		_, ok := ethBlockCache.get(ethBlockCacheKey{mismatched.Hash(), fullTx, api.klaytnTxMode})
+		assert.True(t, ok)
+	}
+	for _, tx := range txs {
+		_, ok := ethReceiptCache.get(ethReceiptCacheKey{mismatched.Hash(), tx.Hash(), api.klaytnTxMode})
+		assert.False(t, ok)
+	}
+
+	// The precomputed block is served without marshaling it again, and a copy of it is returned.
+	mockBackend.EXPECT().BlockByHash(gomock.Any(), block.Hash()).Return(block, nil).AnyTimes()
+	for _, fullTx := range []bool{false, true} {
+		cached, ok := ethBlockCache.get(ethBlockCacheKey{block.Hash(), fullTx, api.klaytnTxMode})
+		require.True(t, ok)
+		fields, err := api.GetBlockByHash(context.Background(), block.Hash(), fullTx)
+		require.NoError(t, err)
+		assert.NotEqual(t, reflect.ValueOf(cached).Pointer(), reflect.ValueOf(fields).Pointer())
+		assert.Equal(t, reflect.ValueOf(cached.(map[string]interface{})["transactions"]).Pointer(), reflect.ValueOf(fields["transactions"]).Pointer())
+		assert.Len(t, fields["transactions"], len(txs))
+
+		// Modifying the returned block does not modify the cached one.
+		fields["hash"] = nil
+		again, err := api.GetBlockByHash(context.Background(), block.Hash(), fullTx)
+		require.NoError(t, err)
+		assert.Equal(t, block.Hash(), again["hash"])
+	}
+
+	// The precomputed receipts are served without reading the receipts of the block.
+	mockBackend.EXPECT().GetTxLookupInfoAndReceipt(gomock.Any(), gomock.Any()).DoAndReturn(
+		func(ctx context.Context, hash common.Hash) (*types.Transaction, common.Hash, uint64, uint64, *types.Receipt) {
+			tx := txHashMap[hash]
+			return tx, block.Hash(), block.NumberU64(), tx.Nonce(), receiptMap[hash]
+		}).Times(len(txs))
+	cumulativeGasUsed := uint64(0)
+	for i, tx := range txs {
+		cumulativeGasUsed += receipts[i].GasUsed
+		cached, ok := ethReceiptCache.get(ethReceiptCacheKey{block.Hash(), tx.Hash(), api.klaytnTxMode})
+		require.True(t, ok)
+		fields, err := api.GetTransactionReceipt(context.Background(), tx.Hash())
+		require.NoError(t, err)
+		assert.Equal(t, cached, fields)
+		assert.NotEqual(t, reflect.ValueOf(cached).Pointer(), reflect.ValueOf(fields).Pointer())
+		assert.Equal(t, hexutil.Uint64(cumulativeGasUsed), fields["cumulativeGasUsed"])
+		assert.Equal(t, hexutil.Uint64(i), fields["transactionIndex"])
+	}
+
+	// The block precomputed in another mode of the Klaytn transactions is not served.
+	api.SetKlaytnTxMode(EthKlaytnTxModeTyped)
+	_, ok := ethBlockCache.get(ethBlockCacheKey{block.Hash(), true, api.klaytnTxMode})
+	assert.False(t, ok)
+	_, err := api.GetBlockByHash(context.Background(), block.Hash(), true)
+	require.NoError(t, err)
+	_, ok = ethBlockCache.get(ethBlockCacheKey{block.Hash(), true, EthKlaytnTxModeTyped})
+	assert.True(t, ok)
+}
@@ -0,0 +1,167 @@
+// Copyright 2022 The klaytn Authors
+// This file is part of the klaytn library.
+//
+// The klaytn library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The klaytn library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the klaytn library. If not, see <http://www.gnu.org/licenses/>.
+
+package api
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/klaytn/klaytn/blockchain/state"
+	"github.com/klaytn/klaytn/blockchain/types"
+	"github.com/klaytn/klaytn/common"
+	"github.com/klaytn/klaytn/common/hexutil"
+	"github.com/klaytn/klaytn/crypto"
+	"github.com/klaytn/klaytn/networks/rpc"
+)
+
+// maxProofRequests is the maximum number of the accounts proved by a GetProofs call.
+const maxProofRequests = 1000
+
+// EthProofRequest is an account and its storage keys to be proved by GetProofs.
+type EthProofRequest struct {
+	Address     common.Address `json:"address"`
+	StorageKeys []string       `json:"storageKeys"`
+}
+
+// GetProofs returns the Merkle-proofs for multiple accounts and their storage keys against a single state root.
+// The state is opened once for all the accounts, the storage trie of each account is opened once for all its keys,
+// and the trie nodes shared by the proofs, such as the nodes near the root, are encoded only once.
+func (api *EthereumAPI) GetProofs(ctx context.Context, requests []EthProofRequest, blockNrOrHash rpc.BlockNumberOrHash) ([]*EthAccountResult, error) {
+	if len(requests) > maxProofRequests {
+		return nil, fmt.Errorf("too many accounts requested: %d > %d", len(requests), maxProofRequests)
+	}
+	state, _, err := api.publicKlayAPI.b.StateAndHeaderByNumberOrHash(ctx, blockNrOrHash)
+	if state == nil || err != nil {
+		return nil, err
+	}
+	prover := newAccountProver(state)
+	results := make([]*EthAccountResult, len(requests))
+	for i, req := range requests {
+		if err := ctx.Err(); err != nil {
+			return nil, err
+		}
+		if results[i], err = prover.prove(req.Address, req.StorageKeys); err != nil {
+			return nil, err
+		}
+	}
+	return results, state.Error()
+}
+
+// accountProver makes the proofs of the accounts in a state.
+// It caches the hex encodings of the proof nodes, which are shared by the proofs of the same trie.
+type accountProver struct {
+	state    *state.StateDB
+	encoding map[string]string // hex encoding of the proof nodes by the node hash
+}
+
+func newAccountProver(state *state.StateDB) *accountProver {
+	return &accountProver{state: state, encoding: make(map[string]string)}
+}
+
+// prove returns the proof of the given account and its storage keys.
+func (p *accountProver) prove(address common.Address, storageKeys []string) (*EthAccountResult, error) {
+	keys := make([]common.Hash, len(storageKeys))
+	for i, key := range storageKeys {
+		hash, err := decodeStorageKey(key)
+		if err != nil {
+			return nil, err
+		}
+		keys[i] = hash
+	}
+
+	storageTrie := p.state.StorageTrie(address)
+	storageHash := types.EmptyRootHashOriginal
+	codeHash := p.state.GetCodeHash(address)
+	storageProof := make([]EthStorageResult, len(keys))
+
+	// if we have a storageTrie, (which means the account exists), we can update the storagehash
+	if storageTrie != nil {
+		storageHash = storageTrie.Hash()
+	} else {
+		// no storageTrie means the account does not exist, so the codeHash is the hash of an empty bytearray.
+		codeHash = crypto.Keccak256Hash(nil)
+	}
+
+	for i, key := range keys {
+		proof := &proofList{prover: p}
+		if storageTrie != nil {
+			if err := storageTrie.Prove(crypto.Keccak256(key.Bytes()), 0, proof); err != nil {
+				return nil, err
+			}
+		}
+		storageProof[i] = EthStorageResult{
+			Key:   storageKeys[i],
+			Value: (*hexutil.Big)(p.state.GetState(address, key).Big()),
+			Proof: proof.nodes(),
+		}
+	}
+
+	accountProof := &proofList{prover: p}
+	if err := p.state.GetProof(address, accountProof); err != nil {
+		return nil, err
+	}
+	return &EthAccountResult{
+		Address:      address,
+		AccountProof: accountProof.nodes(),
+		Balance:      (*hexutil.Big)(p.state.GetBalance(address)),
+		CodeHash:     codeHash,
+		Nonce:        hexutil.Uint64(p.state.GetNonce(address)),
+		StorageHash:  storageHash,
+		StorageProof: storageProof,
+	}, nil
+}
+
+// proofList collects the proof nodes written by a trie in order from the root to the leaf.
+type proofList struct {
+	prover *accountProver
+	list   []string
+}
+
+func (l *proofList) WriteMerkleProof(key, value []byte) {
+	enc, ok := l.prover.encoding[string(key)]
+	if !ok {
+		enc = hexutil.Encode(value)
+		l.prover.encoding[string(key)] = enc
+	}
+	l.list = append(l.list, enc)
+}
+
+func (l *proofList) nodes() []string {
+	if l.list == nil {
+		return []string{}
+	}
+	return l.list
+}
+
+// decodeStorageKey parses a hex-encoded storage key of up to 32 bytes.
+func decodeStorageKey(s string) (common.Hash, error) {
+	if strings.HasPrefix(s, "0x") || strings.HasPrefix(s, "0X") {
+		s = s[2:]
+	}
+	if (len(s) & 1) > 0 {
+		s = "0" + s
+	}
+	b, err := hexutil.Decode("0x" + s)
+	if err != nil {
+		return common.Hash{}, fmt.Errorf("invalid storage key %q: %v", s, err)
+	}
+	if len(b) > common.HashLength {
+		return common.Hash{}, fmt.Errorf("storage key too long: %d > %d bytes", len(b), common.HashLength)
+	}
+	return common.BytesToHash(b), nil
+}
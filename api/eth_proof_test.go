@@ -0,0 +1,151 @@
+// Copyright 2022 The klaytn Authors
+// This file is part of the klaytn library.
+//
+// The klaytn library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The klaytn library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the klaytn library. If not, see <http://www.gnu.org/licenses/>.
+
+package api
+
+import (
+	"context"
+	"math/big"
+	"strings"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	mock_api "github.com/klaytn/klaytn/api/mocks"
+	"github.com/klaytn/klaytn/blockchain/state"
+	"github.com/klaytn/klaytn/blockchain/types"
+	"github.com/klaytn/klaytn/common"
+	"github.com/klaytn/klaytn/common/hexutil"
+	"github.com/klaytn/klaytn/crypto"
+	"github.com/klaytn/klaytn/networks/rpc"
+	"github.com/klaytn/klaytn/params"
+	"github.com/klaytn/klaytn/storage/database"
+	"github.com/klaytn/klaytn/storage/statedb"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// verifyProofNodes checks the proof nodes against the given root and returns the proved value.
+func verifyProofNodes(t *testing.T, root common.Hash, key []byte, proof []string) []byte {
+	proofDB := database.NewMemoryDBManager()
+	for _, node := range proof {
+		enc, err := hexutil.Decode(node)
+		require.NoError(t, err)
+		proofDB.WriteMerkleProof(crypto.Keccak256(enc), enc)
+	}
+	value, err, _ := statedb.VerifyProof(root, key, proofDB)
+	require.NoError(t, err)
+	return value
+}
+
+var (
+	proofTestEOA      = common.HexToAddress("0x1111")
+	proofTestContract = common.HexToAddress("0x2222")
+	proofTestSlot     = common.HexToHash("0x01")
+)
+
+// newProofTestAPI returns an API serving a state with an EOA and a contract with a storage slot
+// for the given number of times, and the root of the state.
+func newProofTestAPI(t *testing.T, mockCtrl *gomock.Controller, blockNrOrHash rpc.BlockNumberOrHash, times int) (*EthereumAPI, common.Hash) {
+	db := state.NewDatabase(database.NewMemoryDBManager())
+	st, err := state.New(common.Hash{}, db, nil)
+	require.NoError(t, err)
+	st.AddBalance(proofTestEOA, big.NewInt(100))
+	st.CreateSmartContractAccount(proofTestContract, params.CodeFormatEVM, params.Rules{IsIstanbul: true})
+	st.SetState(proofTestContract, proofTestSlot, common.HexToHash("0x2a"))
+	root, err := st.Commit(true)
+	require.NoError(t, err)
+	require.NoError(t, db.TrieDB().Commit(root, false, 0))
+
+	mockBackend := mock_api.NewMockBackend(mockCtrl)
+	mockBackend.EXPECT().StateAndHeaderByNumberOrHash(gomock.Any(), blockNrOrHash).DoAndReturn(
+		func(context.Context, rpc.BlockNumberOrHash) (*state.StateDB, *types.Header, error) {
+			st, err := state.New(root, db, nil)
+			return st, &types.Header{Number: big.NewInt(1), Root: root}, err
+		}).Times(times)
+	return &EthereumAPI{publicKlayAPI: NewPublicKlayAPI(mockBackend)}, root
+}
+
+func TestEthereumAPI_GetProof(t *testing.T) {
+	var (
+		ctx    = context.Background()
+		latest = rpc.NewBlockNumberOrHashWithNumber(rpc.LatestBlockNumber)
+	)
+	mockCtrl := gomock.NewController(t)
+	defer mockCtrl.Finish()
+	api, root := newProofTestAPI(t, mockCtrl, latest, 3)
+
+	result, err := api.GetProof(ctx, proofTestContract, []string{"0x01"}, latest)
+	require.NoError(t, err)
+	assert.Equal(t, proofTestContract, result.Address)
+	assert.NotNil(t, verifyProofNodes(t, root, crypto.Keccak256(proofTestContract.Bytes()), result.AccountProof))
+	require.Len(t, result.StorageProof, 1)
+	assert.Equal(t, "0x01", result.StorageProof[0].Key)
+	assert.Equal(t, big.NewInt(0x2a), result.StorageProof[0].Value.ToInt())
+	assert.NotNil(t, verifyProofNodes(t, result.StorageHash, crypto.Keccak256(proofTestSlot.Bytes()), result.StorageProof[0].Proof))
+
+	// The proof of an account is the same as the one returned by GetProofs.
+	results, err := api.GetProofs(ctx, []EthProofRequest{{Address: proofTestContract, StorageKeys: []string{"0x01"}}}, latest)
+	require.NoError(t, err)
+	assert.Equal(t, result, results[0])
+
+	_, err = api.GetProof(ctx, proofTestEOA, []string{"0x" + strings.Repeat("00", common.HashLength+1)}, latest)
+	assert.Error(t, err)
+}
+
+func TestEthereumAPI_GetProofs(t *testing.T) {
+	var (
+		ctx      = context.Background()
+		eoa      = proofTestEOA
+		contract = proofTestContract
+		missing  = common.HexToAddress("0x3333")
+		slot     = proofTestSlot
+		latest   = rpc.NewBlockNumberOrHashWithNumber(rpc.LatestBlockNumber)
+	)
+	mockCtrl := gomock.NewController(t)
+	defer mockCtrl.Finish()
+	api, root := newProofTestAPI(t, mockCtrl, latest, 2)
+
+	results, err := api.GetProofs(ctx, []EthProofRequest{
+		{Address: eoa},
+		{Address: contract, StorageKeys: []string{"0x1", "0x02"}},
+		{Address: missing, StorageKeys: []string{"0x1"}},
+	}, latest)
+	require.NoError(t, err)
+	require.Len(t, results, 3)
+
+	// Existing accounts are proved by their account proofs.
+	for _, result := range results[:2] {
+		assert.NotNil(t, verifyProofNodes(t, root, crypto.Keccak256(result.Address.Bytes()), result.AccountProof))
+	}
+	assert.Equal(t, big.NewInt(100), results[0].Balance.ToInt())
+
+	// The storage proofs are proved against the storage root of the account.
+	for _, proof := range results[1].StorageProof {
+		assert.Equal(t, big.NewInt(0x2a), proof.Value.ToInt())
+		assert.NotNil(t, verifyProofNodes(t, results[1].StorageHash, crypto.Keccak256(slot.Bytes()), proof.Proof))
+	}
+
+	// The absence of an account is proved by its account proof.
+	assert.Nil(t, verifyProofNodes(t, root, crypto.Keccak256(missing.Bytes()), results[2].AccountProof))
+	assert.Equal(t, crypto.Keccak256Hash(nil), results[2].CodeHash)
+	assert.Equal(t, []string{}, results[2].StorageProof[0].Proof)
+
+	// Invalid storage keys and too many accounts are rejected.
+	_, err = api.GetProofs(ctx, []EthProofRequest{{Address: eoa, StorageKeys: []string{"0xzz"}}}, latest)
+	assert.Error(t, err)
+	_, err = api.GetProofs(ctx, make([]EthProofRequest, maxProofRequests+1), latest)
+	assert.Error(t, err)
+}
@@ -16,6 +16,7 @@ import (
 	blockchain "github.com/klaytn/klaytn/blockchain"
 	state "github.com/klaytn/klaytn/blockchain/state"
 	types "github.com/klaytn/klaytn/blockchain/types"
+	account "github.com/klaytn/klaytn/blockchain/types/account"
 	vm "github.com/klaytn/klaytn/blockchain/vm"
 	common "github.com/klaytn/klaytn/common"
 	consensus "github.com/klaytn/klaytn/consensus"
@@ -411,6 +412,36 @@ func (mr *MockBackendMockRecorder) HeaderByNumberOrHash(arg0, arg1 interface{})
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "HeaderByNumberOrHash", reflect.TypeOf((*MockBackend)(nil).HeaderByNumberOrHash), arg0, arg1)
 }
 
+// HistoricalAccount mocks base method.
+func (m *MockBackend) HistoricalAccount(arg0 context.Context, arg1 common.Address, arg2 *types.Header) (account.Account, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "HistoricalAccount", arg0, arg1, arg2)
+	ret0, _ := ret[0].(account.Account)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// HistoricalAccount indicates an expected call of HistoricalAccount.
+func (mr *MockBackendMockRecorder) HistoricalAccount(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "HistoricalAccount", reflect.TypeOf((*MockBackend)(nil).HistoricalAccount), arg0, arg1, arg2)
+}
+
+// HistoricalStorage mocks base method.
+func (m *MockBackend) HistoricalStorage(arg0 context.Context, arg1 common.Address, arg2 common.Hash, arg3 *types.Header) (common.Hash, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "HistoricalStorage", arg0, arg1, arg2, arg3)
+	ret0, _ := ret[0].(common.Hash)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// HistoricalStorage indicates an expected call of HistoricalStorage.
+func (mr *MockBackendMockRecorder) HistoricalStorage(arg0, arg1, arg2, arg3 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "HistoricalStorage", reflect.TypeOf((*MockBackend)(nil).HistoricalStorage), arg0, arg1, arg2, arg3)
+}
+
 // IsParallelDBWrite mocks base method.
 func (m *MockBackend) IsParallelDBWrite() bool {
 	m.ctrl.T.Helper()
@@ -425,6 +456,34 @@ func (mr *MockBackendMockRecorder) IsParallelDBWrite() *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "IsParallelDBWrite", reflect.TypeOf((*MockBackend)(nil).IsParallelDBWrite))
 }
 
+// TxIndexDone mocks base method.
+func (m *MockBackend) TxIndexDone() bool {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "TxIndexDone")
+	ret0, _ := ret[0].(bool)
+	return ret0
+}
+
+// TxIndexDone indicates an expected call of TxIndexDone.
+func (mr *MockBackendMockRecorder) TxIndexDone() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "TxIndexDone", reflect.TypeOf((*MockBackend)(nil).TxIndexDone))
+}
+
+// IsTxLookupSupported mocks base method.
+func (m *MockBackend) IsTxLookupSupported() bool {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "IsTxLookupSupported")
+	ret0, _ := ret[0].(bool)
+	return ret0
+}
+
+// IsTxLookupSupported indicates an expected call of IsTxLookupSupported.
+func (mr *MockBackendMockRecorder) IsTxLookupSupported() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "IsTxLookupSupported", reflect.TypeOf((*MockBackend)(nil).IsTxLookupSupported))
+}
+
 // IsSenderTxHashIndexingEnabled mocks base method.
 func (m *MockBackend) IsSenderTxHashIndexingEnabled() bool {
 	m.ctrl.T.Helper()
@@ -495,6 +554,20 @@ func (mr *MockBackendMockRecorder) RPCEVMTimeout() *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RPCEVMTimeout", reflect.TypeOf((*MockBackend)(nil).RPCEVMTimeout))
 }
 
+// RPCFeePayerWhitelist mocks base method.
+func (m *MockBackend) RPCFeePayerWhitelist() []common.Address {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RPCFeePayerWhitelist")
+	ret0, _ := ret[0].([]common.Address)
+	return ret0
+}
+
+// RPCFeePayerWhitelist indicates an expected call of RPCFeePayerWhitelist.
+func (mr *MockBackendMockRecorder) RPCFeePayerWhitelist() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RPCFeePayerWhitelist", reflect.TypeOf((*MockBackend)(nil).RPCFeePayerWhitelist))
+}
+
 // RPCGasCap mocks base method.
 func (m *MockBackend) RPCGasCap() *big.Int {
 	m.ctrl.T.Helper()
@@ -667,6 +740,21 @@ func (mr *MockBackendMockRecorder) SuggestPrice(arg0 interface{}) *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SuggestPrice", reflect.TypeOf((*MockBackend)(nil).SuggestPrice), arg0)
 }
 
+// SuggestTipCap mocks base method.
+func (m *MockBackend) SuggestTipCap(arg0 context.Context) (*big.Int, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SuggestTipCap", arg0)
+	ret0, _ := ret[0].(*big.Int)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// SuggestTipCap indicates an expected call of SuggestTipCap.
+func (mr *MockBackendMockRecorder) SuggestTipCap(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SuggestTipCap", reflect.TypeOf((*MockBackend)(nil).SuggestTipCap), arg0)
+}
+
 // TxPoolContent mocks base method.
 func (m *MockBackend) TxPoolContent() (map[common.Address]types.Transactions, map[common.Address]types.Transactions) {
 	m.ctrl.T.Helper()
@@ -682,6 +770,21 @@ func (mr *MockBackendMockRecorder) TxPoolContent() *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "TxPoolContent", reflect.TypeOf((*MockBackend)(nil).TxPoolContent))
 }
 
+// TxPoolContentFrom mocks base method.
+func (m *MockBackend) TxPoolContentFrom(arg0 common.Address) (types.Transactions, types.Transactions) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "TxPoolContentFrom", arg0)
+	ret0, _ := ret[0].(types.Transactions)
+	ret1, _ := ret[1].(types.Transactions)
+	return ret0, ret1
+}
+
+// TxPoolContentFrom indicates an expected call of TxPoolContentFrom.
+func (mr *MockBackendMockRecorder) TxPoolContentFrom(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "TxPoolContentFrom", reflect.TypeOf((*MockBackend)(nil).TxPoolContentFrom), arg0)
+}
+
 // UpperBoundGasPrice mocks base method.
 func (m *MockBackend) UpperBoundGasPrice(arg0 context.Context) *big.Int {
 	m.ctrl.T.Helper()
@@ -0,0 +1,170 @@
+// Copyright 2022 The klaytn Authors
+// This file is part of the klaytn library.
+//
+// The klaytn library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The klaytn library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the klaytn library. If not, see <http://www.gnu.org/licenses/>.
+
+package api
+
+import (
+	"context"
+	"crypto/rand"
+	"errors"
+	"time"
+
+	"github.com/klaytn/klaytn/accounts"
+	"github.com/klaytn/klaytn/accounts/watchonly"
+	"github.com/klaytn/klaytn/common"
+	"github.com/klaytn/klaytn/common/hexutil"
+)
+
+const (
+	// nonceReservationTimeout is the duration after which an unused nonce reservation is released.
+	nonceReservationTimeout = 5 * time.Minute
+	// maxNonceReservations is the maximum number of nonces reserved at once for an account.
+	maxNonceReservations = 64
+	// nonceReservationTokenLength is the length of the token returned for a reservation.
+	nonceReservationTokenLength = 16
+)
+
+var errTooManyNonceReservations = errors.New("too many nonces are reserved for the account")
+
+// nonceReservationToken is the secret given to the caller which reserved a nonce.
+// Only the holder of the token can release the reservation.
+type nonceReservationToken [nonceReservationTokenLength]byte
+
+// nonceReservation is a nonce reserved for an account.
+type nonceReservation struct {
+	token  nonceReservationToken
+	expiry time.Time
+}
+
+// NonceReservation is the result of ReserveNonce. The token is required to release the nonce.
+type NonceReservation struct {
+	Nonce hexutil.Uint64 `json:"nonce"`
+	Token hexutil.Bytes  `json:"token"`
+}
+
+// nextNonce returns the lowest nonce of the account which is not lower than the pool
+// nonce and is not reserved. The caller should hold the lock of the account.
+func (l *AddrLocker) nextNonce(ctx context.Context, b Backend, address common.Address) uint64 {
+	poolNonce := b.GetPoolNonce(ctx, address)
+
+	l.reservedMu.Lock()
+	defer l.reservedMu.Unlock()
+	return l.unreservedNonce(address, poolNonce, time.Now())
+}
+
+// reserveNonce reserves the next nonce of the account, so that it is not assigned to any
+// other transaction until it is released, used or expired. It returns the reserved nonce
+// and the token to release it.
+func (l *AddrLocker) reserveNonce(ctx context.Context, b Backend, address common.Address) (uint64, nonceReservationToken, error) {
+	var token nonceReservationToken
+	if _, err := rand.Read(token[:]); err != nil {
+		return 0, token, err
+	}
+
+	l.LockAddr(address)
+	defer l.UnlockAddr(address)
+	poolNonce := b.GetPoolNonce(ctx, address)
+
+	l.reservedMu.Lock()
+	defer l.reservedMu.Unlock()
+	now := time.Now()
+	nonce := l.unreservedNonce(address, poolNonce, now)
+	if len(l.reserved[address]) >= maxNonceReservations {
+		return 0, token, errTooManyNonceReservations
+	}
+	if l.reserved == nil {
+		l.reserved = make(map[common.Address]map[uint64]nonceReservation)
+	}
+	if l.reserved[address] == nil {
+		l.reserved[address] = make(map[uint64]nonceReservation)
+	}
+	l.reserved[address][nonce] = nonceReservation{token: token, expiry: now.Add(nonceReservationTimeout)}
+	return nonce, token, nil
+}
+
+// releaseNonce releases the nonce of the account reserved with the given token.
+// It returns false if no nonce of the account is reserved with the token.
+func (l *AddrLocker) releaseNonce(address common.Address, token nonceReservationToken) bool {
+	l.reservedMu.Lock()
+	defer l.reservedMu.Unlock()
+
+	for nonce, reservation := range l.reserved[address] {
+		if reservation.token == token {
+			delete(l.reserved[address], nonce)
+			if len(l.reserved[address]) == 0 {
+				delete(l.reserved, address)
+			}
+			return true
+		}
+	}
+	return false
+}
+
+// unreservedNonce drops the reservations which are used or expired, and returns the
+// lowest nonce not lower than the pool nonce which is not reserved.
+// The caller should hold reservedMu.
+func (l *AddrLocker) unreservedNonce(address common.Address, poolNonce uint64, now time.Time) uint64 {
+	reserved := l.reserved[address]
+	for nonce, reservation := range reserved {
+		if nonce < poolNonce || now.After(reservation.expiry) {
+			delete(reserved, nonce)
+		}
+	}
+	if len(reserved) == 0 {
+		delete(l.reserved, address)
+	}
+
+	nonce := poolNonce
+	for {
+		if _, ok := reserved[nonce]; !ok {
+			return nonce
+		}
+		nonce++
+	}
+}
+
+// ReserveNonce reserves the next nonce of the given account, so that the wallet backends
+// sending transactions of the same account through this node do not collide on nonces.
+// The reserved nonce is skipped when a nonce is assigned to the other transactions of the
+// account by this node, until it is released by ReleaseNonce with the returned token,
+// used by a transaction in the transaction pool or expired after nonceReservationTimeout.
+// Only the accounts which this node can sign for can reserve nonces, as the node assigns
+// nonces only to the transactions it signs. It is served in the personal namespace, since
+// the reservations hold back the nonces of the transactions sent by the node itself.
+func (s *PrivateAccountAPI) ReserveNonce(ctx context.Context, address common.Address) (*NonceReservation, error) {
+	if _, err := s.am.Find(accounts.Account{Address: address}); err != nil {
+		return nil, err
+	}
+	if backend, err := fetchWatchOnly(s.am); err == nil && backend.Contains(address) {
+		return nil, watchonly.ErrWatchOnly
+	}
+	nonce, token, err := s.nonceLock.reserveNonce(ctx, s.b, address)
+	if err != nil {
+		return nil, err
+	}
+	return &NonceReservation{Nonce: hexutil.Uint64(nonce), Token: token[:]}, nil
+}
+
+// ReleaseNonce releases the nonce of the given account reserved by ReserveNonce.
+// It returns false if no nonce of the account is reserved with the given token.
+func (s *PrivateAccountAPI) ReleaseNonce(address common.Address, token hexutil.Bytes) bool {
+	var t nonceReservationToken
+	if len(token) != len(t) {
+		return false
+	}
+	copy(t[:], token)
+	return s.nonceLock.releaseNonce(address, t)
+}
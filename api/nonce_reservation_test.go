@@ -0,0 +1,131 @@
+// Copyright 2022 The klaytn Authors
+// This file is part of the klaytn library.
+//
+// The klaytn library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The klaytn library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the klaytn library. If not, see <http://www.gnu.org/licenses/>.
+
+package api
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/klaytn/klaytn/accounts"
+	mock_accounts "github.com/klaytn/klaytn/accounts/mocks"
+	"github.com/klaytn/klaytn/accounts/watchonly"
+	mock_api "github.com/klaytn/klaytn/api/mocks"
+	"github.com/klaytn/klaytn/common"
+	"github.com/klaytn/klaytn/common/hexutil"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNonceReservation(t *testing.T) {
+	var (
+		ctx       = context.Background()
+		addr      = common.HexToAddress("0x1111")
+		other     = common.HexToAddress("0x2222")
+		poolNonce = uint64(5)
+	)
+	mockCtrl := gomock.NewController(t)
+	defer mockCtrl.Finish()
+	mockBackend := mock_api.NewMockBackend(mockCtrl)
+	mockBackend.EXPECT().GetPoolNonce(ctx, gomock.Any()).DoAndReturn(func(context.Context, common.Address) uint64 {
+		return poolNonce
+	}).AnyTimes()
+
+	l := new(AddrLocker)
+
+	// Reserved nonces are skipped by the other reservations and the nonce assignment.
+	nonce, token5, err := l.reserveNonce(ctx, mockBackend, addr)
+	assert.NoError(t, err)
+	assert.Equal(t, uint64(5), nonce)
+	nonce, token6, err := l.reserveNonce(ctx, mockBackend, addr)
+	assert.NoError(t, err)
+	assert.Equal(t, uint64(6), nonce)
+	assert.NotEqual(t, token5, token6)
+	assert.Equal(t, uint64(7), l.nextNonce(ctx, mockBackend, addr))
+
+	// A released nonce can be assigned again.
+	assert.True(t, l.releaseNonce(addr, token5))
+	assert.False(t, l.releaseNonce(addr, token5))
+	assert.Equal(t, uint64(5), l.nextNonce(ctx, mockBackend, addr))
+
+	// Reservations lower than the pool nonce are already used.
+	poolNonce = 7
+	assert.Equal(t, uint64(7), l.nextNonce(ctx, mockBackend, addr))
+	assert.False(t, l.releaseNonce(addr, token6))
+
+	// Expired reservations are dropped.
+	l.reserved = map[common.Address]map[uint64]nonceReservation{addr: {7: {token: token6, expiry: time.Now().Add(-time.Second)}}}
+	assert.Equal(t, uint64(7), l.nextNonce(ctx, mockBackend, addr))
+
+	// A reservation can be released only with its token and account.
+	_, token, err := l.reserveNonce(ctx, mockBackend, addr)
+	assert.NoError(t, err)
+	assert.False(t, l.releaseNonce(addr, nonceReservationToken{}))
+	assert.False(t, l.releaseNonce(other, token))
+	assert.True(t, l.releaseNonce(addr, token))
+
+	// The reservations of an account are limited.
+	for i := 0; i < maxNonceReservations; i++ {
+		_, _, err = l.reserveNonce(ctx, mockBackend, addr)
+		assert.NoError(t, err)
+	}
+	_, _, err = l.reserveNonce(ctx, mockBackend, addr)
+	assert.Equal(t, errTooManyNonceReservations, err)
+
+	// The limit of an account does not limit the other accounts.
+	nonce, _, err = l.reserveNonce(ctx, mockBackend, other)
+	assert.NoError(t, err)
+	assert.Equal(t, poolNonce, nonce)
+}
+
+func TestPrivateAccountAPI_ReserveNonce(t *testing.T) {
+	var (
+		ctx       = context.Background()
+		addr      = common.HexToAddress("0x1111")
+		unmanaged = common.HexToAddress("0x2222")
+		watched   = common.HexToAddress("0x3333")
+	)
+	mockCtrl := gomock.NewController(t)
+	defer mockCtrl.Finish()
+	mockBackend := mock_api.NewMockBackend(mockCtrl)
+	mockAccountManager := mock_accounts.NewMockAccountManager(mockCtrl)
+	mockBackend.EXPECT().AccountManager().Return(mockAccountManager).AnyTimes()
+	watchOnly := watchonly.NewBackend(watched)
+	mockAccountManager.EXPECT().Backends(watchonly.BackendType).Return([]accounts.Backend{watchOnly}).AnyTimes()
+	mockAccountManager.EXPECT().Find(accounts.Account{Address: addr}).Return(NewMockWallet(nil), nil).AnyTimes()
+	mockAccountManager.EXPECT().Find(accounts.Account{Address: unmanaged}).Return(nil, accounts.ErrUnknownAccount).AnyTimes()
+	mockAccountManager.EXPECT().Find(accounts.Account{Address: watched}).Return(watchOnly.Wallets()[0], nil).AnyTimes()
+	mockBackend.EXPECT().GetPoolNonce(ctx, addr).Return(uint64(3)).AnyTimes()
+
+	api := NewPrivateAccountAPI(mockBackend, new(AddrLocker))
+
+	// The accounts not managed by the node cannot reserve nonces.
+	_, err := api.ReserveNonce(ctx, unmanaged)
+	assert.Equal(t, accounts.ErrUnknownAccount, err)
+
+	// The watch-only accounts cannot reserve nonces, as the node cannot sign for them.
+	_, err = api.ReserveNonce(ctx, watched)
+	assert.Equal(t, watchonly.ErrWatchOnly, err)
+
+	reservation, err := api.ReserveNonce(ctx, addr)
+	assert.NoError(t, err)
+	assert.Equal(t, hexutil.Uint64(3), reservation.Nonce)
+	assert.Len(t, reservation.Token, nonceReservationTokenLength)
+
+	assert.False(t, api.ReleaseNonce(addr, reservation.Token[1:]))
+	assert.True(t, api.ReleaseNonce(addr, reservation.Token))
+}
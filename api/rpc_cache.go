@@ -0,0 +1,197 @@
+// Copyright 2022 The klaytn Authors
+// This file is part of the klaytn library.
+//
+// The klaytn library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The klaytn library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the klaytn library. If not, see <http://www.gnu.org/licenses/>.
+
+package api
+
+import (
+	"fmt"
+	"sync"
+
+	lru "github.com/hashicorp/golang-lru"
+	"github.com/klaytn/klaytn/common"
+	"github.com/rcrowley/go-metrics"
+)
+
+// Names of the RPC caches.
+const (
+	RPCCacheReceipts    = "receipts"    // RPC outputs of the receipts by block hash
+	RPCCacheEthBlocks   = "ethblocks"   // Ethereum-format RPC outputs of the blocks by block hash
+	RPCCacheEthReceipts = "ethreceipts" // Ethereum-format RPC outputs of the receipts by block hash and tx hash
+	RPCCacheSenders     = "senders"     // recovered senders of the Ethereum transactions by tx hash
+)
+
+// Default capacities of the RPC caches.
+const (
+	DefaultRPCReceiptsCacheSize    = 128
+	DefaultRPCEthBlocksCacheSize   = 128
+	DefaultRPCEthReceiptsCacheSize = 30000
+	DefaultRPCSendersCacheSize     = 30000
+)
+
+// The RPC caches are disabled until they are resized by ResizeRPCCache.
+var (
+	blockReceiptsCache = newRPCCache(RPCCacheReceipts)
+	ethBlockCache      = newRPCCache(RPCCacheEthBlocks)
+	ethReceiptCache    = newRPCCache(RPCCacheEthReceipts)
+	senderCache        = newRPCCache(RPCCacheSenders)
+
+	rpcCaches = map[string]*rpcCache{
+		RPCCacheReceipts:    blockReceiptsCache,
+		RPCCacheEthBlocks:   ethBlockCache,
+		RPCCacheEthReceipts: ethReceiptCache,
+		RPCCacheSenders:     senderCache,
+	}
+)
+
+// ethBlockCacheKey is the key of ethBlockCache, since a block is marshaled differently by fullTx
+// and by the mode of the Klaytn transactions.
+type ethBlockCacheKey struct {
+	hash   common.Hash
+	fullTx bool
+	mode   EthKlaytnTxMode
+}
+
+// ethReceiptCacheKey is the key of ethReceiptCache. The block hash is included,
+// since a transaction can be included in another block after a reorganization.
+// The mode of the Klaytn transactions is included, since it changes the marshaled receipt.
+type ethReceiptCacheKey struct {
+	blockHash common.Hash
+	txHash    common.Hash
+	mode      EthKlaytnTxMode
+}
+
+// copyRPCFields returns a copy of the cached RPC output, so that the callers can add, remove or
+// replace its fields without modifying the cache. The nested values are shared with the cache.
+func copyRPCFields(fields map[string]interface{}) map[string]interface{} {
+	cpy := make(map[string]interface{}, len(fields))
+	for k, v := range fields {
+		cpy[k] = v
+	}
+	return cpy
+}
+
+// copyRPCFieldsList returns a copy of the cached list of RPC outputs by copyRPCFields.
+func copyRPCFieldsList(fieldsList []map[string]interface{}) []map[string]interface{} {
+	cpy := make([]map[string]interface{}, len(fieldsList))
+	for i, fields := range fieldsList {
+		cpy[i] = copyRPCFields(fields)
+	}
+	return cpy
+}
+
+// RPCCacheStats is the statistics of an RPC cache.
+type RPCCacheStats struct {
+	Size    int     `json:"size"`
+	Len     int     `json:"len"`
+	Hits    int64   `json:"hits"`
+	Misses  int64   `json:"misses"`
+	HitRate float64 `json:"hitRate"`
+}
+
+// rpcCache is an LRU cache of the values computed for RPC outputs, which can be resized at runtime.
+// The cache is disabled if its size is 0. The cached values must not be modified,
+// so the marshaled outputs are copied by copyRPCFields before they are returned to the callers.
+type rpcCache struct {
+	mu     sync.RWMutex
+	lru    *lru.Cache
+	size   int
+	hits   metrics.Counter
+	misses metrics.Counter
+}
+
+func newRPCCache(name string) *rpcCache {
+	return &rpcCache{
+		hits:   metrics.NewRegisteredCounter("api/cache/"+name+"/hits", nil),
+		misses: metrics.NewRegisteredCounter("api/cache/"+name+"/misses", nil),
+	}
+}
+
+func (c *rpcCache) get(key interface{}) (interface{}, bool) {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+
+	if c.lru == nil {
+		return nil, false
+	}
+	value, ok := c.lru.Get(key)
+	if ok {
+		c.hits.Inc(1)
+	} else {
+		c.misses.Inc(1)
+	}
+	return value, ok
+}
+
+func (c *rpcCache) add(key, value interface{}) {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+
+	if c.lru != nil {
+		c.lru.Add(key, value)
+	}
+}
+
+func (c *rpcCache) resize(size int) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	switch {
+	case size <= 0:
+		c.lru, size = nil, 0
+	case c.lru == nil:
+		c.lru, _ = lru.New(size)
+	default:
+		c.lru.Resize(size)
+	}
+	c.size = size
+}
+
+func (c *rpcCache) stats() RPCCacheStats {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+
+	stats := RPCCacheStats{Size: c.size, Hits: c.hits.Count(), Misses: c.misses.Count()}
+	if c.lru != nil {
+		stats.Len = c.lru.Len()
+	}
+	if total := stats.Hits + stats.Misses; total > 0 {
+		stats.HitRate = float64(stats.Hits) / float64(total)
+	}
+	return stats
+}
+
+// ResizeRPCCache changes the capacity of the RPC cache of the given name.
+// The cache is disabled if the size is 0.
+func ResizeRPCCache(name string, size int) error {
+	cache, ok := rpcCaches[name]
+	if !ok {
+		return fmt.Errorf("unknown RPC cache %q", name)
+	}
+	if size < 0 {
+		return fmt.Errorf("negative RPC cache size %d", size)
+	}
+	cache.resize(size)
+	return nil
+}
+
+// RPCCachesStats returns the statistics of the RPC caches by their names.
+func RPCCachesStats() map[string]RPCCacheStats {
+	stats := make(map[string]RPCCacheStats, len(rpcCaches))
+	for name, cache := range rpcCaches {
+		stats[name] = cache.stats()
+	}
+	return stats
+}
@@ -0,0 +1,57 @@
+// Copyright 2022 The klaytn Authors
+// This file is part of the klaytn library.
+//
+// The klaytn library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The klaytn library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the klaytn library. If not, see <http://www.gnu.org/licenses/>.
+
+package api
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRPCCache(t *testing.T) {
+	cache := newRPCCache("test")
+
+	// The cache is disabled until it is resized.
+	cache.add(1, "one")
+	_, ok := cache.get(1)
+	assert.False(t, ok)
+
+	cache.resize(2)
+	cache.add(1, "one")
+	cache.add(2, "two")
+	value, ok := cache.get(1)
+	assert.True(t, ok)
+	assert.Equal(t, "one", value)
+	_, ok = cache.get(3)
+	assert.False(t, ok)
+
+	stats := cache.stats()
+	assert.Equal(t, RPCCacheStats{Size: 2, Len: 2, Hits: 1, Misses: 1, HitRate: 0.5}, stats)
+
+	// Shrinking evicts the least recently used entry.
+	cache.resize(1)
+	_, ok = cache.get(2)
+	assert.False(t, ok)
+	_, ok = cache.get(1)
+	assert.True(t, ok)
+
+	cache.resize(0)
+	assert.Equal(t, 0, cache.stats().Len)
+
+	assert.Error(t, ResizeRPCCache("unknown", 1))
+	assert.Error(t, ResizeRPCCache(RPCCacheSenders, -1))
+}
@@ -34,6 +34,7 @@ import (
 	"github.com/klaytn/klaytn/blockchain/vm"
 	"github.com/klaytn/klaytn/common"
 	"github.com/klaytn/klaytn/common/hexutil"
+	"github.com/klaytn/klaytn/consensus/misc"
 	"github.com/klaytn/klaytn/params"
 	"github.com/klaytn/klaytn/rlp"
 )
@@ -47,6 +48,8 @@ var (
 	errTxArgNilNonce         = errors.New("nonce of the sender is not set")
 	errTxArgNilGas           = errors.New("gas limit is not set")
 	errTxArgNilGasPrice      = errors.New("gas price is not set")
+	errTxArgNotFeeDelegated  = errors.New("tx should be a fee-delegated transaction")
+	errTxArgNilFeePayerSig   = errors.New("fee payer signature is not set")
 	errNotForFeeDelegationTx = errors.New("fee-delegation type transactions are not allowed to use this API")
 )
 
@@ -177,7 +180,7 @@ func (args *SendTxArgs) setDefaults(ctx context.Context, b Backend) error {
 			// Before Magma hard fork, `gasFeeCap` was set to `baseFee*2 + maxPriorityFeePerGas` by default.
 			gasFeeCap := new(big.Int).Add(
 				(*big.Int)(args.MaxPriorityFeePerGas),
-				new(big.Int).Mul(new(big.Int).SetUint64(params.ZeroBaseFee), big.NewInt(2)),
+				new(big.Int).Mul(misc.HeaderBaseFee(b.CurrentBlock().Header()), big.NewInt(2)),
 			)
 			if isMagma {
 				// After Magma hard fork, `gasFeeCap` was set to `baseFee*2` by default.
@@ -499,3 +502,52 @@ func (e *revertError) ErrorCode() int {
 func (e *revertError) ErrorData() interface{} {
 	return e.reason
 }
+
+// estimateGasError is an API error of a transaction always failing in the gas estimation,
+// with the call trace of the failed execution.
+type estimateGasError struct {
+	error
+	reason string // revert reason hex encoded, empty if not reverted
+	trace  *vm.InternalTxTrace
+}
+
+// newEstimateGasError returns an estimateGasError of the given execution error and the traced calls.
+func newEstimateGasError(err error, tracer *vm.InternalTxTracer) *estimateGasError {
+	estimateErr := &estimateGasError{error: err}
+	if revertErr, ok := err.(*revertError); ok {
+		estimateErr.reason = revertErr.reason
+	}
+	// The trace is not available if the execution failed before the EVM ran.
+	if trace, traceErr := tracer.GetResult(); traceErr == nil {
+		estimateErr.trace = trace
+	}
+	return estimateErr
+}
+
+// ErrorCode returns the JSON error code of a revertal if reverted, or the default error code otherwise.
+func (e *estimateGasError) ErrorCode() int {
+	if e.reason != "" {
+		return 3
+	}
+	return -32000
+}
+
+// ErrorData returns the hex encoded revert reason and the call trace.
+func (e *estimateGasError) ErrorData() interface{} {
+	return struct {
+		Reason string              `json:"reason,omitempty"`
+		Trace  *vm.InternalTxTrace `json:"trace,omitempty"`
+	}{e.reason, e.trace}
+}
+
+// replaceUnderpricedError is an API error of a transaction failing to replace the pooled one
+// with the same nonce, because its gas price is not bumped enough.
+type replaceUnderpricedError struct {
+	error
+}
+
+// ErrorCode returns the JSON error code distinguishing an underpriced replacement,
+// so wallets can bump the gas price and retry.
+func (e *replaceUnderpricedError) ErrorCode() int {
+	return -32010
+}
@@ -87,6 +87,9 @@ var (
 	blockPrefetchExecuteTimer   = klaytnmetrics.NewRegisteredHybridTimer("chain/prefetch/executes", nil)
 	blockPrefetchInterruptMeter = metrics.NewRegisteredMeter("chain/prefetch/interrupts", nil)
 
+	accountPrefetchTimer          = klaytnmetrics.NewRegisteredHybridTimer("chain/prefetch/accounts", nil)
+	accountPrefetchInterruptMeter = metrics.NewRegisteredMeter("chain/prefetch/accounts/interrupts", nil)
+
 	ErrNoGenesis            = errors.New("genesis not found in chain")
 	ErrNotExistNode         = errors.New("the node does not exist in cached node")
 	ErrQuitBySignal         = errors.New("quit by signal")
@@ -123,14 +126,18 @@ const (
 // 2) trie caching/pruning resident in a blockchain.
 type CacheConfig struct {
 	// TODO-Klaytn-Issue1666 Need to check the benefit of trie caching.
-	ArchiveMode          bool                         // If true, state trie is not pruned and always written to database
-	CacheSize            int                          // Size of in-memory cache of a trie (MiB) to flush matured singleton trie nodes to disk
-	BlockInterval        uint                         // Block interval to flush the trie. Each interval state trie will be flushed into disk
-	TriesInMemory        uint64                       // Maximum number of recent state tries according to its block number
-	SenderTxHashIndexing bool                         // Enables saving senderTxHash to txHash mapping information to database and cache
-	TrieNodeCacheConfig  *statedb.TrieNodeCacheConfig // Configures trie node cache
-	SnapshotCacheSize    int                          // Memory allowance (MB) to use for caching snapshot entries in memory
-	SnapshotAsyncGen     bool                         // Enables snapshot data generation asynchronously
+	ArchiveMode               bool                         // If true, state trie is not pruned and always written to database
+	CacheSize                 int                          // Size of in-memory cache of a trie (MiB) to flush matured singleton trie nodes to disk
+	BlockInterval             uint                         // Block interval to flush the trie. Each interval state trie will be flushed into disk
+	TriesInMemory             uint64                       // Maximum number of recent state tries according to its block number
+	SenderTxHashIndexing      bool                         // Enables saving senderTxHash to txHash mapping information to database and cache
+	TrieNodeCacheConfig       *statedb.TrieNodeCacheConfig // Configures trie node cache
+	SnapshotCacheSize         int                          // Memory allowance (MB) to use for caching snapshot entries in memory
+	SnapshotAsyncGen          bool                         // Enables snapshot data generation asynchronously
+	TxLookupLimit             uint64                       // Number of recent blocks whose transactions are indexed by hashes (0 = entire chain)
+	PeriodicMigrationInterval uint64                       // Number of blocks between state migrations removing the stale state (0 = disabled)
+	ParallelTxWorkers         int                          // Number of workers executing the transactions of a block in parallel (0 = serial execution)
+	StateHistory              bool                         // Enables storing the reverse state diff of each block next to the hash-based state tries to serve the accounts and storage of pruned states
 }
 
 // gcBlock is used for priority queue for GC.
@@ -164,6 +171,7 @@ type BlockChain struct {
 
 	hc            *HeaderChain
 	rmLogsFeed    event.Feed
+	reorgFeed     event.Feed
 	chainFeed     event.Feed
 	chainSideFeed event.Feed
 	chainHeadFeed event.Feed
@@ -203,6 +211,7 @@ type BlockChain struct {
 	progress              float64
 	migrationErr          error
 	testMigrationHook     func()
+	lastPeriodicMigration uint64 // Block number of the last state migration started periodically
 
 	// Warm up
 	lastCommittedBlock uint64
@@ -238,6 +247,11 @@ func NewBlockChain(db database.DBManager, cacheConfig *CacheConfig, chainConfig
 		cacheConfig.TrieNodeCacheConfig = statedb.GetEmptyTrieNodeCacheConfig()
 	}
 
+	if cacheConfig.PeriodicMigrationInterval > 0 && (cacheConfig.ArchiveMode || db.GetDBConfig().SingleDB) {
+		logger.Warn("Periodic state migration is available for the non-archive mode with non-single database only")
+		cacheConfig.PeriodicMigrationInterval = 0
+	}
+
 	state.EnabledExpensive = db.GetDBConfig().EnableDBPerfMetrics
 
 	futureBlocks, _ := lru.New(maxFutureBlocks)
@@ -286,6 +300,10 @@ func NewBlockChain(db database.DBManager, cacheConfig *CacheConfig, chainConfig
 	// Make sure the state associated with the block is available
 	head := bc.CurrentBlock()
 	if _, err := state.New(head.Root(), bc.stateCache, bc.snaps); err != nil {
+		// A read replica cannot repair the chain, since the database is written by the primary node.
+		if bc.db.IsReadOnly() {
+			return nil, fmt.Errorf("head state of the read-only database is missing (number: %d, hash: %s): %w", head.NumberU64(), head.Hash().String(), err)
+		}
 		// Head state is missing, before the state recovery, find out the
 		// disk layer point of snapshot(if it's enabled). Make sure the
 		// rewound point is lower than disk layer.
@@ -352,6 +370,10 @@ func NewBlockChain(db database.DBManager, cacheConfig *CacheConfig, chainConfig
 
 	// Take ownership of this particular state
 	go bc.update()
+	if cacheConfig.TxLookupLimit > 0 {
+		bc.wg.Add(1)
+		go bc.maintainTxIndex()
+	}
 	bc.gcCachedNodeLoop()
 	bc.restartStateMigration()
 
@@ -467,6 +489,10 @@ func (bc *BlockChain) loadLastState() error {
 	// Everything seems to be fine, set as the head block
 	bc.currentBlock.Store(currentBlock)
 	bc.lastCommittedBlock = currentBlock.NumberU64()
+	bc.lastPeriodicMigration = currentBlock.NumberU64()
+	if lastPeriodicMigration, ok := bc.db.ReadPeriodicMigrationBlock(); ok {
+		bc.lastPeriodicMigration = lastPeriodicMigration
+	}
 
 	// Restore the last known head header
 	currentHeader := currentBlock.Header()
@@ -1026,7 +1052,8 @@ func (bc *BlockChain) Stop() {
 	}
 
 	triedb := bc.stateCache.TrieDB()
-	if !bc.isArchiveMode() {
+	// The state of a read replica is written by the primary node.
+	if !bc.isArchiveMode() && !bc.db.IsReadOnly() {
 		number := bc.CurrentBlock().NumberU64()
 		recent := bc.GetBlockByNumber(number)
 		if recent == nil {
@@ -1327,6 +1354,7 @@ func (bc *BlockChain) writeStateTrie(block *types.Block, state *state.StateDB) e
 				return err
 			}
 
+			bc.checkPeriodicMigration(block.NumberU64())
 			if bc.checkStartStateMigration(block.NumberU64(), root) {
 				// flush referenced trie nodes out to new stateTrieDB
 				if err := trieDB.Cap(0); err != nil {
@@ -1532,6 +1560,12 @@ func (bc *BlockChain) writeBlockWithStateSerial(block *types.Block, receipts []*
 	}
 	trieWriteTime := time.Since(trieWriteStart)
 
+	if bc.cacheConfig.StateHistory {
+		if err := bc.writeStateHistory(block, state); err != nil {
+			return WriteResult{Status: NonStatTy}, err
+		}
+	}
+
 	bc.writeReceipts(block.Hash(), block.NumberU64(), receipts)
 
 	// TODO-Klaytn-Issue264 If we are using istanbul BFT, then we always have a canonical chain.
@@ -1607,8 +1641,14 @@ func (bc *BlockChain) writeBlockWithStateParallel(block *types.Block, receipts [
 		defer parallelDBWriteWG.Done()
 		if err := bc.writeStateTrie(block, state); err != nil {
 			parallelDBWriteErrCh <- err
+			return
 		}
 		trieWriteTime = time.Since(trieWriteStart)
+		if bc.cacheConfig.StateHistory {
+			if err := bc.writeStateHistory(block, state); err != nil {
+				parallelDBWriteErrCh <- err
+			}
+		}
 	}()
 
 	go func() {
@@ -1836,6 +1876,31 @@ func (bc *BlockChain) insertChain(chain types.Blocks) (int, []interface{}, []*ty
 				}(time.Now())
 			}
 		}
+		// Warm the accounts and slots listed in the transactions of the followup block
+		// while the current block is processed and committed.
+		if bc.cacheConfig.TrieNodeCacheConfig.PrefetchBlockAccounts && parent != nil && i < len(chain)-1 {
+			followup := chain[i+1]
+			go func(start time.Time) {
+				defer func() {
+					if err := recover(); err != nil {
+						logger.Error("Got panic and recovered from account prefetcher", "err", err)
+					}
+				}()
+
+				throwaway, err := state.NewForPrefetching(parent.Root(), bc.stateCache, bc.snaps)
+				if throwaway == nil || err != nil {
+					logger.Warn("failed to get StateDB for account prefetcher", "err", err,
+						"parentBlockNum", parent.NumberU64(), "currBlockNum", bc.CurrentBlock().NumberU64())
+					return
+				}
+				bc.prefetcher.PrefetchAccounts(followup, throwaway, &followupInterrupt)
+
+				accountPrefetchTimer.Update(time.Since(start))
+				if atomic.LoadUint32(&followupInterrupt) == 1 {
+					accountPrefetchInterruptMeter.Mark(1)
+				}
+			}(time.Now())
+		}
 		// If the header is a banned one, straight out abort
 		if BadHashes[block.Hash()] {
 			bc.reportBlock(block, nil, ErrBlacklistedHash)
@@ -1919,6 +1984,9 @@ func (bc *BlockChain) insertChain(chain types.Blocks) (int, []interface{}, []*ty
 		if err != nil {
 			return i, events, coalescedLogs, err
 		}
+		if bc.cacheConfig.StateHistory {
+			stateDB.StartHistoryRecording()
+		}
 
 		// Process block using the parent state as reference point.
 		receipts, logs, usedGas, internalTxTraces, procStats, err := bc.processor.Process(block, stateDB, bc.vmConfig)
@@ -2333,6 +2401,16 @@ func (bc *BlockChain) reorg(oldBlock, newBlock *types.Block) error {
 			}
 		}()
 	}
+	if len(oldChain) > 0 && len(newChain) > 0 {
+		go bc.reorgFeed.Send(ReorgEvent{
+			OldHead:        oldChain[0].Header(),
+			NewHead:        newChain[0].Header(),
+			CommonAncestor: commonBlock.Header(),
+			Depth:          uint64(len(oldChain)),
+			RemovedTxs:     diff,
+			ReincludedTxs:  types.TxDifference(deletedTxs, diff),
+		})
+	}
 
 	return nil
 }
@@ -2508,6 +2586,11 @@ func (bc *BlockChain) SubscribeRemovedLogsEvent(ch chan<- RemovedLogsEvent) even
 	return bc.scope.Track(bc.rmLogsFeed.Subscribe(ch))
 }
 
+// SubscribeReorgEvent registers a subscription of ReorgEvent.
+func (bc *BlockChain) SubscribeReorgEvent(ch chan<- ReorgEvent) event.Subscription {
+	return bc.scope.Track(bc.reorgFeed.Subscribe(ch))
+}
+
 // SubscribeChainEvent registers a subscription of ChainEvent.
 func (bc *BlockChain) SubscribeChainEvent(ch chan<- ChainEvent) event.Subscription {
 	return bc.scope.Track(bc.chainFeed.Subscribe(ch))
@@ -2546,6 +2629,24 @@ func (bc *BlockChain) IsSenderTxHashIndexingEnabled() bool {
 	return bc.cacheConfig.SenderTxHashIndexing
 }
 
+// TxIndexDone returns whether the transactions of the blocks behind TxLookupLimit have been unindexed,
+// allowing the lag of a block which is unindexed after the head has advanced.
+// It is false while the unindexing catches up, e.g. after the limit is set on an existing database.
+func (bc *BlockChain) TxIndexDone() bool {
+	limit := bc.cacheConfig.TxLookupLimit
+	head := bc.CurrentBlock().NumberU64()
+	if limit == 0 || head < limit {
+		return true
+	}
+	tail := bc.db.ReadTxIndexTail()
+	return tail != nil && *tail+1 >= head-limit+1
+}
+
+// IsInternalTxTracingEnabled returns if the internal transactions are traced while processing blocks.
+func (bc *BlockChain) IsInternalTxTracingEnabled() bool {
+	return bc.vmConfig.EnableInternalTxTracing
+}
+
 func (bc *BlockChain) SaveTrieNodeCacheToDisk() error {
 	if err := bc.stateCache.TrieDB().CanSaveTrieNodeCacheToFile(); err != nil {
 		return err
@@ -2554,6 +2655,11 @@ func (bc *BlockChain) SaveTrieNodeCacheToDisk() error {
 	return nil
 }
 
+// ResizeTrieNodeCache changes the size of the trie node cache in MiB without restarting the node.
+func (bc *BlockChain) ResizeTrieNodeCache(sizeMiB int) error {
+	return bc.stateCache.TrieDB().ResizeTrieNodeCache(sizeMiB)
+}
+
 // ApplyTransaction attempts to apply a transaction to the given state database
 // and uses the input parameters for its environment. It returns the receipt
 // for the transaction, gas used and an error if the transaction failed,
@@ -2636,6 +2742,9 @@ func CheckBlockChainVersion(chainDB database.DBManager) error {
 	if bcVersion != nil && *bcVersion > BlockChainVersion {
 		return fmt.Errorf("database version is v%d, Klaytn %s only supports v%d", *bcVersion, params.Version, BlockChainVersion)
 	} else if bcVersion == nil || *bcVersion < BlockChainVersion {
+		if chainDB.IsReadOnly() {
+			return fmt.Errorf("database version is not upgraded to v%d by the primary node", BlockChainVersion)
+		}
 		bcVersionStr := "N/A"
 		if bcVersion != nil {
 			bcVersionStr = strconv.Itoa(int(*bcVersion))
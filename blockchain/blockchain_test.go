@@ -904,6 +904,8 @@ func TestLogReorgs(t *testing.T) {
 
 	rmLogsCh := make(chan RemovedLogsEvent)
 	blockchain.SubscribeRemovedLogsEvent(rmLogsCh)
+	reorgCh := make(chan ReorgEvent, 1)
+	blockchain.SubscribeReorgEvent(reorgCh)
 	chain, _ := GenerateChain(params.TestChainConfig, genesis, gxhash.NewFaker(), db, 2, func(i int, gen *BlockGen) {
 		if i == 1 {
 			tx, err := types.SignTx(types.NewContractCreation(gen.TxNonce(addr1), new(big.Int), 1000000, new(big.Int), code), signer, key1)
@@ -931,6 +933,18 @@ func TestLogReorgs(t *testing.T) {
 	case <-timeout.C:
 		t.Fatal("Timeout. There is no RemovedLogsEvent has been sent.")
 	}
+
+	select {
+	case ev := <-reorgCh:
+		if ev.OldHead.Number.Uint64() != 2 || ev.Depth != 2-ev.CommonAncestor.Number.Uint64() {
+			t.Errorf("unexpected reorg: old head %d, depth %d, common ancestor %d", ev.OldHead.Number, ev.Depth, ev.CommonAncestor.Number)
+		}
+		if len(ev.RemovedTxs) != 1 || len(ev.ReincludedTxs) != 0 {
+			t.Errorf("unexpected reorg txs: removed %d, reincluded %d", len(ev.RemovedTxs), len(ev.ReincludedTxs))
+		}
+	case <-timeout.C:
+		t.Fatal("Timeout. There is no ReorgEvent has been sent.")
+	}
 }
 
 func TestReorgSideEvent(t *testing.T) {
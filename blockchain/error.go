@@ -99,6 +99,10 @@ var (
 	// ErrInvalidChainId is returned if the chain id of transaction is not equal to the chain id of the chain config.
 	ErrInvalidChainId = errors.New("invalid chain id")
 
+	// ErrMaxInitCodeSizeExceeded is returned if creation transaction provides the init code bigger
+	// than init code size limit after the shanghai hardfork (EIP-3860).
+	ErrMaxInitCodeSizeExceeded = errors.New("max initcode size exceeded")
+
 	// ErrNotYetImplementedAPI is returned if API is not yet implemented
 	ErrNotYetImplementedAPI = errors.New("not yet implemented API")
 
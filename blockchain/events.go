@@ -31,6 +31,14 @@ import (
 // NewTxsEvent is posted when a batch of transactions enter the transaction pool.
 type NewTxsEvent struct{ Txs []*types.Transaction }
 
+// DroppedTxsEvent is posted when transactions are removed from the transaction pool without being
+// included in a block. Replacement is the transaction with the same nonce if they are replaced.
+type DroppedTxsEvent struct {
+	Txs         []*types.Transaction
+	Reason      string
+	Replacement *types.Transaction
+}
+
 // PendingLogsEvent is posted pre mining and notifies of pending logs.
 type PendingLogsEvent struct {
 	Logs []*types.Log
@@ -45,6 +53,16 @@ type NewMinedBlockEvent struct{ Block *types.Block }
 // RemovedLogsEvent is posted when a reorg happens
 type RemovedLogsEvent struct{ Logs []*types.Log }
 
+// ReorgEvent is posted when the canonical chain is reorganized.
+type ReorgEvent struct {
+	OldHead        *types.Header
+	NewHead        *types.Header
+	CommonAncestor *types.Header
+	Depth          uint64             // the number of the blocks removed from the canonical chain
+	RemovedTxs     types.Transactions // the removed transactions which are not included in the new chain
+	ReincludedTxs  types.Transactions // the removed transactions which are included again in the new chain
+}
+
 type ChainEvent struct {
 	Block            *types.Block
 	Hash             common.Hash
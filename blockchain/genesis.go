@@ -31,6 +31,7 @@ import (
 
 	"github.com/klaytn/klaytn/blockchain/state"
 	"github.com/klaytn/klaytn/blockchain/types"
+	"github.com/klaytn/klaytn/blockchain/vm"
 	"github.com/klaytn/klaytn/common"
 	"github.com/klaytn/klaytn/common/hexutil"
 	"github.com/klaytn/klaytn/common/math"
@@ -45,6 +46,7 @@ import (
 var (
 	errGenesisNoConfig = errors.New("genesis has no chain configuration")
 	errNoGenesis       = errors.New("genesis block is not provided")
+	errNoStoredGenesis = errors.New("genesis block is not written to the read-only database")
 )
 
 // Genesis specifies the header fields, state of a genesis block. It also defines hard
@@ -186,6 +188,10 @@ func SetupGenesisBlock(db database.DBManager, genesis *Genesis, networkId uint64
 	// Just commit the new block if there is no stored genesis block.
 	stored := db.ReadCanonicalHash(0)
 	if (stored == common.Hash{}) {
+		// A read replica can only open the database initialized by the primary node.
+		if db.IsReadOnly() {
+			return params.AllGxhashProtocolChanges, common.Hash{}, errNoStoredGenesis
+		}
 		if genesis == nil {
 			switch {
 			case isPrivate:
@@ -239,10 +245,18 @@ func SetupGenesisBlock(db database.DBManager, genesis *Genesis, networkId uint64
 	if err := newcfg.CheckConfigForkOrder(); err != nil {
 		return newcfg, common.Hash{}, err
 	}
+	if err := newcfg.CheckCustomPrecompiles(); err != nil {
+		return newcfg, common.Hash{}, err
+	}
+	if err := vm.ValidateOpcodeCostOverrides(newcfg); err != nil {
+		return newcfg, common.Hash{}, err
+	}
 	storedcfg := db.ReadChainConfig(stored)
 	if storedcfg == nil {
 		logger.Info("Found genesis block without chain config")
-		db.WriteChainConfig(stored, newcfg)
+		if !db.IsReadOnly() {
+			db.WriteChainConfig(stored, newcfg)
+		}
 		return newcfg, stored, nil
 	} else {
 		if storedcfg.Governance == nil {
@@ -275,7 +289,10 @@ func SetupGenesisBlock(db database.DBManager, genesis *Genesis, networkId uint64
 	if compatErr != nil && *height != 0 && compatErr.RewindTo != 0 {
 		return newcfg, stored, compatErr
 	}
-	db.WriteChainConfig(stored, newcfg)
+	// The chain config of a read replica is written by the primary node.
+	if !db.IsReadOnly() {
+		db.WriteChainConfig(stored, newcfg)
+	}
 	return newcfg, stored, nil
 }
 
@@ -366,6 +383,12 @@ func (g *Genesis) Commit(baseStateRoot common.Hash, db database.DBManager) (*typ
 	if err := config.CheckConfigForkOrder(); err != nil {
 		return nil, err
 	}
+	if err := config.CheckCustomPrecompiles(); err != nil {
+		return nil, err
+	}
+	if err := vm.ValidateOpcodeCostOverrides(config); err != nil {
+		return nil, err
+	}
 	db.WriteChainConfig(block.Hash(), config)
 	return block, nil
 }
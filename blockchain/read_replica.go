@@ -0,0 +1,198 @@
+// Copyright 2022 The klaytn Authors
+// This file is part of the klaytn library.
+//
+// The klaytn library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The klaytn library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the klaytn library. If not, see <http://www.gnu.org/licenses/>.
+
+package blockchain
+
+import (
+	"time"
+
+	"github.com/klaytn/klaytn/blockchain/types"
+	"github.com/klaytn/klaytn/common"
+)
+
+// FollowHeadLoop is run by a read replica, which shares the database with a primary node
+// instead of inserting blocks by itself. It polls the head block written by the primary node
+// at the given interval, and moves the current block of the replica to it.
+func (bc *BlockChain) FollowHeadLoop(interval time.Duration) {
+	logger.Info("Following the head block of the primary node", "interval", interval)
+	bc.followLoop(interval, bc.followHead)
+}
+
+// FollowHeaderLoop is run by a light node, which inserts only the headers of the blocks.
+// It moves the current block to the current header at the given interval. The body and
+// the state of the block are retrieved on demand by the database of the light node.
+func (bc *BlockChain) FollowHeaderLoop(interval time.Duration) {
+	logger.Info("Following the current header", "interval", interval)
+	bc.followLoop(interval, bc.followHeader)
+}
+
+func (bc *BlockChain) followLoop(interval time.Duration, follow func()) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ticker.C:
+			follow()
+		case <-bc.quit:
+			return
+		}
+	}
+}
+
+// followHead moves the current block to the head block in the database, and sends the chain
+// events of the blocks added since the previous head, so that the subscriptions keep working.
+// The head is compared by hash, since the primary node may have reorganized the chain to the
+// blocks of the same or a lower height.
+func (bc *BlockChain) followHead() {
+	head := bc.db.ReadHeadBlockHash()
+	oldHead := bc.CurrentBlock()
+	if head == (common.Hash{}) || head == oldHead.Hash() {
+		return
+	}
+	block := bc.GetBlockByHash(head)
+	if block == nil {
+		return
+	}
+
+	// Find the common ancestor of the old head and the new head. The blocks of the old chain are
+	// kept to send the removed logs, and only the hashes of the new chain are kept, since the
+	// replica may be far behind the primary node.
+	var (
+		oldChain  []*types.Block
+		newHashes []common.Hash
+		oldBlock  = oldHead
+		newHeader = block.Header()
+	)
+	for oldBlock.NumberU64() > newHeader.Number.Uint64() {
+		oldChain = append(oldChain, oldBlock)
+		if oldBlock = bc.GetBlock(oldBlock.ParentHash(), oldBlock.NumberU64()-1); oldBlock == nil {
+			logger.Warn("Missing a block of the old chain", "number", oldChain[len(oldChain)-1].NumberU64()-1)
+			return
+		}
+	}
+	for newHeader.Number.Uint64() > oldBlock.NumberU64() {
+		newHashes = append(newHashes, newHeader.Hash())
+		if newHeader = bc.GetHeader(newHeader.ParentHash, newHeader.Number.Uint64()-1); newHeader == nil {
+			logger.Warn("Missing a block added by the primary node", "hash", newHashes[len(newHashes)-1])
+			return
+		}
+	}
+	for oldBlock.Hash() != newHeader.Hash() {
+		oldChain = append(oldChain, oldBlock)
+		newHashes = append(newHashes, newHeader.Hash())
+		oldBlock = bc.GetBlock(oldBlock.ParentHash(), oldBlock.NumberU64()-1)
+		newHeader = bc.GetHeader(newHeader.ParentHash, newHeader.Number.Uint64()-1)
+		if oldBlock == nil || newHeader == nil {
+			logger.Warn("Missing the common ancestor of the old head and the new head", "oldHead", oldHead.Hash(), "newHead", head)
+			return
+		}
+	}
+
+	if len(oldChain) > 0 {
+		// The canonical hashes and the tx lookups of the removed blocks may be cached.
+		bc.db.ClearHeaderChainCache()
+		bc.db.ClearBlockChainCache()
+		logger.Info("Following the chain reorganized by the primary node", "number", oldBlock.NumberU64(),
+			"hash", oldBlock.Hash(), "drop", len(oldChain), "add", len(newHashes))
+	}
+	bc.followCurrentBlock(block)
+
+	var (
+		deletedLogs []*types.Log
+		deletedTxs  types.Transactions
+		addedTxs    types.Transactions
+	)
+	for _, removed := range oldChain {
+		for _, receipt := range bc.GetReceiptsByBlockHash(removed.Hash()) {
+			deletedLogs = append(deletedLogs, receipt.Logs...)
+		}
+		deletedTxs = append(deletedTxs, removed.Transactions()...)
+		bc.chainSideFeed.Send(ChainSideEvent{Block: removed})
+	}
+	if len(deletedLogs) > 0 {
+		bc.rmLogsFeed.Send(RemovedLogsEvent{deletedLogs})
+	}
+
+	for i := len(newHashes) - 1; i >= 0; i-- {
+		added := bc.GetBlockByHash(newHashes[i])
+		if added == nil {
+			logger.Warn("Missing a block added by the primary node", "hash", newHashes[i])
+			continue
+		}
+		if len(oldChain) > 0 {
+			addedTxs = append(addedTxs, added.Transactions()...)
+		}
+		receipts := bc.GetReceiptsByBlockHash(added.Hash())
+		var logs []*types.Log
+		for _, receipt := range receipts {
+			logs = append(logs, receipt.Logs...)
+		}
+		bc.chainFeed.Send(ChainEvent{Block: added, Hash: added.Hash(), Receipts: receipts, Logs: logs})
+		if len(logs) > 0 {
+			bc.logsFeed.Send(logs)
+		}
+	}
+
+	if len(oldChain) > 0 {
+		diff := types.TxDifference(deletedTxs, addedTxs)
+		bc.reorgFeed.Send(ReorgEvent{
+			OldHead:        oldHead.Header(),
+			NewHead:        block.Header(),
+			CommonAncestor: oldBlock.Header(),
+			Depth:          uint64(len(oldChain)),
+			RemovedTxs:     diff,
+			ReincludedTxs:  types.TxDifference(deletedTxs, diff),
+		})
+	}
+	bc.chainHeadFeed.Send(ChainHeadEvent{Block: block})
+}
+
+// followCurrentBlock sets the current block and the current header to the given block. Unlike
+// replaceCurrentBlock, nothing is written to the database, which is written by the primary node.
+func (bc *BlockChain) followCurrentBlock(block *types.Block) {
+	bc.mu.Lock()
+	defer bc.mu.Unlock()
+
+	bc.currentBlock.Store(block)
+	bc.currentFastBlock.Store(block)
+	bc.hc.currentHeader.Store(block.Header())
+	bc.hc.currentHeaderHash = block.Hash()
+
+	headBlockNumberGauge.Update(block.Number().Int64())
+	logger.Debug("Followed the head block of the primary node", "number", block.NumberU64(), "hash", block.Hash())
+}
+
+// followHeader moves the current block to the current header. Only the chain head event is sent,
+// since the receipts of the skipped blocks are not available to a light node.
+func (bc *BlockChain) followHeader() {
+	header := bc.CurrentHeader()
+	if header.Number.Uint64() <= bc.CurrentBlock().NumberU64() {
+		return
+	}
+	// The state root is loaded first, so that the head state can be opened after a restart.
+	if !bc.HasState(header.Root) {
+		logger.Debug("The state root of the current header is not available", "number", header.Number, "root", header.Root)
+		return
+	}
+	block := bc.GetBlock(header.Hash(), header.Number.Uint64())
+	if block == nil {
+		logger.Debug("The body of the current header is not available", "number", header.Number, "hash", header.Hash())
+		return
+	}
+	bc.replaceCurrentBlock(block)
+	bc.chainHeadFeed.Send(ChainHeadEvent{Block: block})
+}
@@ -0,0 +1,143 @@
+// Copyright 2022 The klaytn Authors
+// This file is part of the klaytn library.
+//
+// The klaytn library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The klaytn library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the klaytn library. If not, see <http://www.gnu.org/licenses/>.
+
+package blockchain
+
+import (
+	"testing"
+
+	"github.com/klaytn/klaytn/blockchain/types"
+	"github.com/klaytn/klaytn/blockchain/vm"
+	"github.com/klaytn/klaytn/consensus/gxhash"
+	"github.com/klaytn/klaytn/params"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestBlockChain_followHead(t *testing.T) {
+	engine := gxhash.NewFaker()
+	db, primary, err := newCanonical(engine, 2, true)
+	require.NoError(t, err)
+	defer primary.Stop()
+
+	// The replica opens the database written by the primary.
+	replica, err := NewBlockChain(db, nil, params.AllGxhashProtocolChanges, engine, vm.Config{})
+	require.NoError(t, err)
+	defer replica.Stop()
+	assert.Equal(t, primary.CurrentBlock().Hash(), replica.CurrentBlock().Hash())
+
+	chainCh := make(chan ChainEvent, 10)
+	headCh := make(chan ChainHeadEvent, 10)
+	replica.SubscribeChainEvent(chainCh)
+	replica.SubscribeChainHeadEvent(headCh)
+
+	// Nothing happens if the primary has not added blocks.
+	replica.followHead()
+	assert.Len(t, headCh, 0)
+
+	blocks := makeBlockChain(primary.CurrentBlock(), 3, engine, db, canonicalSeed)
+	_, err = primary.InsertChain(blocks)
+	require.NoError(t, err)
+
+	// The replica follows the head, and sends the events of all the added blocks.
+	replica.followHead()
+	assert.Equal(t, primary.CurrentBlock().Hash(), replica.CurrentBlock().Hash())
+	assert.Equal(t, primary.CurrentHeader().Hash(), replica.CurrentHeader().Hash())
+	require.Len(t, chainCh, len(blocks))
+	for _, block := range blocks {
+		assert.Equal(t, block.Hash(), (<-chainCh).Hash)
+	}
+	require.Len(t, headCh, 1)
+	assert.Equal(t, blocks[len(blocks)-1].Hash(), (<-headCh).Block.Hash())
+}
+
+func TestBlockChain_followHeader(t *testing.T) {
+	engine := gxhash.NewFaker()
+	db, light, err := newCanonical(engine, 0, true)
+	require.NoError(t, err)
+	defer light.Stop()
+
+	headCh := make(chan ChainHeadEvent, 10)
+	light.SubscribeChainHeadEvent(headCh)
+
+	// The light node inserts only the headers.
+	blocks := makeBlockChain(light.CurrentBlock(), 3, engine, db, canonicalSeed)
+	headers := make([]*types.Header, len(blocks))
+	for i, block := range blocks {
+		headers[i] = block.Header()
+	}
+	_, err = light.InsertHeaderChain(headers, 1)
+	require.NoError(t, err)
+
+	// The current block does not move until the body of the current header is available.
+	light.followHeader()
+	assert.Equal(t, uint64(0), light.CurrentBlock().NumberU64())
+	assert.Len(t, headCh, 0)
+
+	head := blocks[len(blocks)-1]
+	db.WriteBody(head.Hash(), head.NumberU64(), head.Body())
+	light.followHeader()
+	assert.Equal(t, head.Hash(), light.CurrentBlock().Hash())
+	assert.Equal(t, head.Hash(), light.CurrentHeader().Hash())
+	require.Len(t, headCh, 1)
+	assert.Equal(t, head.Hash(), (<-headCh).Block.Hash())
+}
+
+func TestBlockChain_followHeadReorg(t *testing.T) {
+	engine := gxhash.NewFaker()
+	db, primary, err := newCanonical(engine, 0, true)
+	require.NoError(t, err)
+	defer primary.Stop()
+
+	genesis := primary.CurrentBlock()
+	blocks := makeBlockChain(genesis, 3, engine, db, canonicalSeed)
+	_, err = primary.InsertChain(blocks)
+	require.NoError(t, err)
+
+	replica, err := NewBlockChain(db, nil, params.AllGxhashProtocolChanges, engine, vm.Config{})
+	require.NoError(t, err)
+	defer replica.Stop()
+
+	chainCh := make(chan ChainEvent, 10)
+	sideCh := make(chan ChainSideEvent, 10)
+	reorgCh := make(chan ReorgEvent, 10)
+	replica.SubscribeChainEvent(chainCh)
+	replica.SubscribeChainSideEvent(sideCh)
+	replica.SubscribeReorgEvent(reorgCh)
+
+	// The primary reorganizes the chain to a longer fork from the genesis block.
+	fork := makeBlockChain(genesis, 4, engine, db, forkSeed)
+	_, err = primary.InsertChain(fork)
+	require.NoError(t, err)
+	require.Equal(t, fork[len(fork)-1].Hash(), primary.CurrentBlock().Hash())
+
+	// The replica drops the old blocks, and sends the events of the blocks of the fork.
+	replica.followHead()
+	assert.Equal(t, primary.CurrentBlock().Hash(), replica.CurrentBlock().Hash())
+	assert.Equal(t, fork[1].Hash(), replica.GetBlockByNumber(2).Hash())
+	require.Len(t, sideCh, len(blocks))
+	for i := len(blocks) - 1; i >= 0; i-- {
+		assert.Equal(t, blocks[i].Hash(), (<-sideCh).Block.Hash())
+	}
+	require.Len(t, chainCh, len(fork))
+	for _, block := range fork {
+		assert.Equal(t, block.Hash(), (<-chainCh).Hash)
+	}
+	require.Len(t, reorgCh, 1)
+	reorg := <-reorgCh
+	assert.Equal(t, genesis.Hash(), reorg.CommonAncestor.Hash())
+	assert.Equal(t, uint64(len(blocks)), reorg.Depth)
+}
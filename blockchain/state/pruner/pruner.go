@@ -0,0 +1,190 @@
+// Copyright 2022 The klaytn Authors
+// This file is part of the klaytn library.
+//
+// The klaytn library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The klaytn library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the klaytn library. If not, see <http://www.gnu.org/licenses/>.
+
+package pruner
+
+import (
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/klaytn/klaytn/blockchain/state"
+	"github.com/klaytn/klaytn/common"
+	"github.com/klaytn/klaytn/log"
+	"github.com/klaytn/klaytn/storage/database"
+)
+
+var logger = log.NewModuleLogger(log.BlockchainState)
+
+const (
+	// DefaultBloomSize is the default size of the bloom filter of the states to keep in megabytes.
+	DefaultBloomSize = 2048
+
+	// pruneLogInterval is the interval of logging the progress of pruning.
+	pruneLogInterval = 8 * time.Second
+)
+
+var (
+	errInMigration  = errors.New("state pruning is not available during the state migration")
+	errEmptyDB      = errors.New("empty database")
+	errStateMissing = errors.New("no state is persisted on disk")
+)
+
+// Pruner removes the state trie nodes and the contract codes which are not reachable from the
+// state of the target block and the genesis state, so that a full node reclaims the space taken by
+// the stale states without resyncing. It must be used offline, while the node is not running.
+type Pruner struct {
+	db    database.DBManager
+	bloom *stateBloom
+}
+
+// NewPruner creates a Pruner of the given database with a bloom filter of the given size in megabytes.
+func NewPruner(db database.DBManager, bloomSize uint64) (*Pruner, error) {
+	if db.InMigration() {
+		return nil, errInMigration
+	}
+	bloom, err := newStateBloom(bloomSize)
+	if err != nil {
+		return nil, err
+	}
+	return &Pruner{db: db, bloom: bloom}, nil
+}
+
+// Prune keeps the state of the given root and the genesis state, and removes all the other states.
+// If the root is empty, the state of the latest block whose state is persisted on disk is kept.
+func (p *Pruner) Prune(root common.Hash) error {
+	if root == (common.Hash{}) {
+		var err error
+		if root, err = p.latestRoot(); err != nil {
+			return err
+		}
+	} else if ok, _ := p.db.HasStateTrieNode(root.Bytes()); !ok {
+		return fmt.Errorf("state of the root %x is missing", root)
+	}
+
+	start := time.Now()
+	if err := p.markState(root); err != nil {
+		return err
+	}
+	if genesis := p.db.ReadBlockByNumber(0); genesis != nil && genesis.Root() != root {
+		if err := p.markState(genesis.Root()); err != nil {
+			return err
+		}
+	}
+	logger.Info("Marked the states to keep", "root", root, "elapsed", common.PrettyDuration(time.Since(start)))
+
+	return p.sweep()
+}
+
+// latestRoot returns the state root of the latest block whose state is persisted on disk.
+func (p *Pruner) latestRoot() (common.Hash, error) {
+	head := p.db.ReadHeadBlockHash()
+	if head == (common.Hash{}) {
+		return common.Hash{}, errEmptyDB
+	}
+	for block := p.db.ReadBlockByHash(head); block != nil; block = p.db.ReadBlock(block.ParentHash(), block.NumberU64()-1) {
+		if ok, _ := p.db.HasStateTrieNode(block.Root().Bytes()); ok {
+			logger.Info("Found the latest persisted state", "number", block.NumberU64(), "root", block.Root())
+			return block.Root(), nil
+		}
+		if block.NumberU64() == 0 {
+			break
+		}
+	}
+	return common.Hash{}, errStateMissing
+}
+
+// markState adds the trie nodes and the contract codes of the state of the given root to the bloom.
+func (p *Pruner) markState(root common.Hash) error {
+	sdb, err := state.New(root, state.NewDatabase(p.db), nil)
+	if err != nil {
+		return err
+	}
+	var (
+		it     = state.NewNodeIterator(sdb)
+		nodes  int
+		logged = time.Now()
+	)
+	for it.Next() {
+		if it.Hash != (common.Hash{}) {
+			p.bloom.add(it.Hash)
+			nodes++
+		}
+		if time.Since(logged) > pruneLogInterval {
+			logger.Info("Marking the state to keep", "root", root, "nodes", nodes)
+			logged = time.Now()
+		}
+	}
+	if it.Error != nil {
+		return fmt.Errorf("failed to iterate the state of the root %x: %v", root, it.Error)
+	}
+	return nil
+}
+
+// sweep removes the trie nodes and the contract codes which are not in the bloom.
+func (p *Pruner) sweep() error {
+	var (
+		db      = p.db.GetStateTrieDB()
+		batch   = db.NewBatch()
+		it      = db.NewIterator(nil, nil)
+		start   = time.Now()
+		logged  = time.Now()
+		count   int
+		removed int
+		size    common.StorageSize
+	)
+	defer it.Release()
+
+	for it.Next() {
+		count++
+		key := it.Key()
+
+		var hash common.Hash
+		if len(key) == common.HashLength {
+			hash = common.BytesToHash(key)
+		} else if isCode, codeHash := database.IsCodeKey(key); isCode {
+			hash = common.BytesToHash(codeHash)
+		} else {
+			continue
+		}
+		if p.bloom.contains(hash) {
+			continue
+		}
+		size += common.StorageSize(len(key) + len(it.Value()))
+		removed++
+		if err := batch.Delete(key); err != nil {
+			return err
+		}
+		if batch.ValueSize() >= database.IdealBatchSize {
+			if err := batch.Write(); err != nil {
+				return err
+			}
+			batch.Reset()
+		}
+		if time.Since(logged) > pruneLogInterval {
+			logger.Info("Pruning state data", "iterated", count, "removed", removed, "size", size, "elapsed", common.PrettyDuration(time.Since(start)))
+			logged = time.Now()
+		}
+	}
+	if err := it.Error(); err != nil {
+		return err
+	}
+	if err := batch.Write(); err != nil {
+		return err
+	}
+	logger.Info("Pruned state data", "iterated", count, "removed", removed, "size", size, "elapsed", common.PrettyDuration(time.Since(start)))
+	return nil
+}
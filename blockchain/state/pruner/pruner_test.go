@@ -0,0 +1,68 @@
+// Copyright 2022 The klaytn Authors
+// This file is part of the klaytn library.
+//
+// The klaytn library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The klaytn library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the klaytn library. If not, see <http://www.gnu.org/licenses/>.
+
+package pruner
+
+import (
+	"math/big"
+	"testing"
+
+	"github.com/klaytn/klaytn/blockchain/state"
+	"github.com/klaytn/klaytn/common"
+	"github.com/klaytn/klaytn/storage/database"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// commitState sets the balances of the accounts and persists the state on disk.
+func commitState(t *testing.T, sdb *state.StateDB, balance int64) common.Hash {
+	for i := 0; i < 100; i++ {
+		sdb.SetBalance(common.BigToAddress(big.NewInt(int64(i+1))), big.NewInt(balance+int64(i)))
+	}
+	root, err := sdb.Commit(false)
+	require.NoError(t, err)
+	require.NoError(t, sdb.Database().TrieDB().Commit(root, false, 0))
+	return root
+}
+
+func TestPruner(t *testing.T) {
+	db := database.NewMemoryDBManager()
+	sdb, err := state.New(common.Hash{}, state.NewDatabase(db), nil)
+	require.NoError(t, err)
+
+	oldRoot := commitState(t, sdb, 1)
+	newRoot := commitState(t, sdb, 1000)
+
+	pruner, err := NewPruner(db, 1)
+	require.NoError(t, err)
+	require.NoError(t, pruner.Prune(newRoot))
+
+	// The stale state is removed
+	ok, _ := db.HasStateTrieNode(oldRoot.Bytes())
+	assert.False(t, ok)
+
+	// The kept state is complete
+	kept, err := state.New(newRoot, state.NewDatabase(db), nil)
+	require.NoError(t, err)
+	it := state.NewNodeIterator(kept)
+	for it.Next() {
+	}
+	assert.NoError(t, it.Error)
+	assert.Equal(t, big.NewInt(1000), kept.GetBalance(common.BigToAddress(big.NewInt(1))))
+
+	// The root must be given if no block is stored
+	assert.Equal(t, errEmptyDB, pruner.Prune(common.Hash{}))
+}
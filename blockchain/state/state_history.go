@@ -0,0 +1,289 @@
+// Copyright 2022 The klaytn Authors
+// This file is part of the klaytn library.
+//
+// The klaytn library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The klaytn library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the klaytn library. If not, see <http://www.gnu.org/licenses/>.
+
+package state
+
+import (
+	"bytes"
+	"sort"
+
+	"github.com/klaytn/klaytn/blockchain/types/account"
+	"github.com/klaytn/klaytn/common"
+	"github.com/klaytn/klaytn/rlp"
+	"github.com/klaytn/klaytn/storage/statedb"
+)
+
+// maxDestructedHistorySlots is the maximum number of the storage slots of a self-destructed
+// or re-created contract recorded in a StateHistory. The storage is walked while the block is
+// imported, so the work is bounded and the storage of a bigger contract is not recorded.
+var maxDestructedHistorySlots = 10000
+
+// StateHistory is the reverse diff of a block. It holds the accounts and the storage
+// slots modified by the block with the values they had before the block was applied,
+// so that the state of the parent block can be reconstructed from the state of the block.
+// The diffs are stored in addition to the hash-based state tries. No path-based state
+// scheme is introduced, so the diffs do not replace the tries kept by an archive node and
+// do not reduce its disk usage. They let a node which prunes its state answer the queries
+// on the accounts and the storage slots of the pruned blocks instead.
+//
+// The whole storage of a contract which is self-destructed or re-created in the block is
+// recorded in Destructed, and it supersedes the slots of the contract recorded in Storage.
+type StateHistory struct {
+	Accounts   []HistoryAccount
+	Storage    []HistoryStorage
+	Destructed []HistoryDestructed
+}
+
+// Addresses returns the addresses of the accounts recorded in the history, without duplicates.
+func (h *StateHistory) Addresses() []common.Address {
+	seen := make(map[common.Address]struct{})
+	addrs := make([]common.Address, 0, len(h.Accounts))
+	add := func(addr common.Address) {
+		if _, ok := seen[addr]; !ok {
+			seen[addr] = struct{}{}
+			addrs = append(addrs, addr)
+		}
+	}
+	for _, acc := range h.Accounts {
+		add(acc.Address)
+	}
+	for _, slot := range h.Storage {
+		add(slot.Address)
+	}
+	for _, destructed := range h.Destructed {
+		add(destructed.Address)
+	}
+	return addrs
+}
+
+// HistoryAccount is the value of an account before the block was applied.
+type HistoryAccount struct {
+	Address common.Address
+	Data    []byte // RLP-encoded account, empty if the account did not exist
+}
+
+// HistoryStorage is the value of a storage slot before the block was applied.
+type HistoryStorage struct {
+	Address common.Address
+	Key     common.Hash
+	Value   common.Hash
+}
+
+// HistoryDestructed is the storage of a contract before the block was applied, which is
+// removed by the block. The slots are keyed by the hash of the storage key.
+// If the contract had more than maxDestructedHistorySlots slots, Incomplete is set and
+// no slot is recorded.
+type HistoryDestructed struct {
+	Address    common.Address
+	Storage    []HistorySlot
+	Incomplete bool `rlp:"optional"`
+}
+
+// HistorySlot is the value of a storage slot keyed by the hash of the storage key.
+type HistorySlot struct {
+	KeyHash common.Hash
+	Value   common.Hash
+}
+
+// historyRecorder keeps the original values of the accounts and the storage
+// slots at the first time they are written into the tries.
+type historyRecorder struct {
+	accounts   map[common.Address][]byte
+	storage    map[common.Address]map[common.Hash]common.Hash
+	destructed map[common.Address]map[common.Hash]common.Hash // nil if the storage is too big to be recorded
+}
+
+func newHistoryRecorder() *historyRecorder {
+	return &historyRecorder{
+		accounts:   make(map[common.Address][]byte),
+		storage:    make(map[common.Address]map[common.Hash]common.Hash),
+		destructed: make(map[common.Address]map[common.Hash]common.Hash),
+	}
+}
+
+// recordAccount reads the original value of the account from the account trie
+// if it has not been written since the recording started.
+func (r *historyRecorder) recordAccount(tr Trie, addr common.Address) error {
+	if _, ok := r.accounts[addr]; ok {
+		return nil
+	}
+	enc, err := tr.TryGet(addr[:])
+	if err != nil {
+		return err
+	}
+	r.accounts[addr] = common.CopyBytes(enc)
+	return nil
+}
+
+// recordStorage reads the original value of the storage slot from the storage trie
+// if it has not been written since the recording started.
+func (r *historyRecorder) recordStorage(tr Trie, addr common.Address, key common.Hash) error {
+	storage := r.storage[addr]
+	if storage == nil {
+		storage = make(map[common.Hash]common.Hash)
+		r.storage[addr] = storage
+	}
+	if _, ok := storage[key]; ok {
+		return nil
+	}
+	enc, err := tr.TryGet(key[:])
+	if err != nil {
+		return err
+	}
+	var value common.Hash
+	if len(enc) > 0 {
+		_, content, _, err := rlp.Split(enc)
+		if err != nil {
+			return err
+		}
+		value.SetBytes(content)
+	}
+	storage[key] = value
+	return nil
+}
+
+// recordDestruct reads the whole original storage of the account, which is about to be
+// deleted or replaced by a new object, if it has not been recorded since the recording started.
+// It stops reading at maxDestructedHistorySlots slots and records the storage as incomplete.
+func (r *historyRecorder) recordDestruct(db Database, tr Trie, addr common.Address) error {
+	if _, ok := r.destructed[addr]; ok {
+		return nil
+	}
+	enc, ok := r.accounts[addr]
+	if !ok {
+		var err error
+		if enc, err = tr.TryGet(addr[:]); err != nil {
+			return err
+		}
+	}
+	slots := make(map[common.Hash]common.Hash)
+	if len(enc) > 0 {
+		serializer := account.NewAccountSerializer()
+		if err := rlp.DecodeBytes(enc, serializer); err != nil {
+			return err
+		}
+		if pa := account.GetProgramAccount(serializer.GetAccount()); pa != nil && pa.GetStorageRoot() != emptyRoot {
+			storageTrie, err := db.OpenStorageTrie(pa.GetStorageRoot())
+			if err != nil {
+				return err
+			}
+			it := statedb.NewIterator(storageTrie.NodeIterator(nil))
+			for it.Next() {
+				if len(slots) >= maxDestructedHistorySlots {
+					logger.Warn("Storage of a destructed contract is too big to be recorded in the state history",
+						"address", addr, "limit", maxDestructedHistorySlots)
+					r.destructed[addr] = nil
+					return nil
+				}
+				_, content, _, err := rlp.Split(it.Value)
+				if err != nil {
+					return err
+				}
+				slots[common.BytesToHash(it.Key)] = common.BytesToHash(content)
+			}
+			if it.Err != nil {
+				return it.Err
+			}
+		}
+	}
+	r.destructed[addr] = slots
+	return nil
+}
+
+// mergeStorage adds the original storage values of the account recorded in the given
+// recorder, keeping the values already recorded.
+func (r *historyRecorder) mergeStorage(src *historyRecorder, addr common.Address) {
+	for key, value := range src.storage[addr] {
+		storage := r.storage[addr]
+		if storage == nil {
+			storage = make(map[common.Hash]common.Hash)
+			r.storage[addr] = storage
+		}
+		if _, ok := storage[key]; !ok {
+			storage[key] = value
+		}
+	}
+}
+
+func (r *historyRecorder) copy() *historyRecorder {
+	cpy := newHistoryRecorder()
+	for addr, enc := range r.accounts {
+		cpy.accounts[addr] = enc
+	}
+	for addr := range r.storage {
+		cpy.mergeStorage(r, addr)
+	}
+	for addr, slots := range r.destructed {
+		if slots == nil {
+			cpy.destructed[addr] = nil
+			continue
+		}
+		cpy.destructed[addr] = make(map[common.Hash]common.Hash, len(slots))
+		for keyHash, value := range slots {
+			cpy.destructed[addr][keyHash] = value
+		}
+	}
+	return cpy
+}
+
+// StartHistoryRecording makes the state record the original values of the accounts
+// and the storage slots written from now on. It should be called on the state of the
+// parent block before any transaction of the block is applied.
+func (s *StateDB) StartHistoryRecording() {
+	s.history = newHistoryRecorder()
+}
+
+// StateHistory returns the reverse diff of the changes finalised since the recording
+// started, sorted by address and key. It returns nil if the history is not recorded.
+func (s *StateDB) StateHistory() *StateHistory {
+	if s.history == nil {
+		return nil
+	}
+	history := &StateHistory{
+		Accounts: make([]HistoryAccount, 0, len(s.history.accounts)),
+	}
+	for addr, enc := range s.history.accounts {
+		history.Accounts = append(history.Accounts, HistoryAccount{Address: addr, Data: enc})
+	}
+	for addr, storage := range s.history.storage {
+		for key, value := range storage {
+			history.Storage = append(history.Storage, HistoryStorage{Address: addr, Key: key, Value: value})
+		}
+	}
+	for addr, slots := range s.history.destructed {
+		destructed := HistoryDestructed{Address: addr, Storage: make([]HistorySlot, 0, len(slots)), Incomplete: slots == nil}
+		for keyHash, value := range slots {
+			destructed.Storage = append(destructed.Storage, HistorySlot{KeyHash: keyHash, Value: value})
+		}
+		sort.Slice(destructed.Storage, func(i, j int) bool {
+			return bytes.Compare(destructed.Storage[i].KeyHash[:], destructed.Storage[j].KeyHash[:]) < 0
+		})
+		history.Destructed = append(history.Destructed, destructed)
+	}
+	sort.Slice(history.Accounts, func(i, j int) bool {
+		return bytes.Compare(history.Accounts[i].Address[:], history.Accounts[j].Address[:]) < 0
+	})
+	sort.Slice(history.Storage, func(i, j int) bool {
+		if cmp := bytes.Compare(history.Storage[i].Address[:], history.Storage[j].Address[:]); cmp != 0 {
+			return cmp < 0
+		}
+		return bytes.Compare(history.Storage[i].Key[:], history.Storage[j].Key[:]) < 0
+	})
+	sort.Slice(history.Destructed, func(i, j int) bool {
+		return bytes.Compare(history.Destructed[i].Address[:], history.Destructed[j].Address[:]) < 0
+	})
+	return history
+}
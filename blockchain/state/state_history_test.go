@@ -0,0 +1,159 @@
+// Copyright 2022 The klaytn Authors
+// This file is part of the klaytn library.
+//
+// The klaytn library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The klaytn library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the klaytn library. If not, see <http://www.gnu.org/licenses/>.
+
+package state
+
+import (
+	"math/big"
+	"testing"
+
+	"github.com/klaytn/klaytn/blockchain/types/account"
+	"github.com/klaytn/klaytn/common"
+	"github.com/klaytn/klaytn/crypto"
+	"github.com/klaytn/klaytn/rlp"
+	"github.com/klaytn/klaytn/storage/database"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestStateDB_StateHistory(t *testing.T) {
+	var (
+		db      = NewDatabase(database.NewMemoryDBManager())
+		addr    = common.BytesToAddress([]byte{1})
+		created = common.BytesToAddress([]byte{2})
+		key1    = common.BytesToHash([]byte{1})
+		key2    = common.BytesToHash([]byte{2})
+	)
+	stateDB, _ := New(common.Hash{}, db, nil)
+	stateDB.SetCode(addr, []byte{1})
+	stateDB.SetBalance(addr, big.NewInt(100))
+	stateDB.SetState(addr, key1, common.BytesToHash([]byte{10}))
+	root, err := stateDB.Commit(false)
+	require.NoError(t, err)
+
+	stateDB, err = New(root, db, nil)
+	require.NoError(t, err)
+	assert.Nil(t, stateDB.StateHistory())
+
+	stateDB.StartHistoryRecording()
+	stateDB.SetBalance(addr, big.NewInt(200))
+	stateDB.SetState(addr, key1, common.BytesToHash([]byte{20}))
+	stateDB.SetState(addr, key2, common.BytesToHash([]byte{30}))
+	stateDB.SetBalance(created, big.NewInt(1))
+	stateDB.IntermediateRoot(false)
+
+	// The values written again keep the values before the recording started
+	stateDB.SetBalance(addr, big.NewInt(300))
+	stateDB.SetState(addr, key1, common.BytesToHash([]byte{40}))
+	stateDB.IntermediateRoot(false)
+
+	history := stateDB.StateHistory()
+	require.NotNil(t, history)
+	require.Len(t, history.Accounts, 2)
+	assert.Equal(t, addr, history.Accounts[0].Address)
+	assert.Equal(t, created, history.Accounts[1].Address)
+	assert.Empty(t, history.Accounts[1].Data)
+
+	serializer := account.NewAccountSerializer()
+	require.NoError(t, rlp.DecodeBytes(history.Accounts[0].Data, serializer))
+	assert.Equal(t, big.NewInt(100), serializer.GetAccount().GetBalance())
+
+	assert.Equal(t, []HistoryStorage{
+		{Address: addr, Key: key1, Value: common.BytesToHash([]byte{10})},
+		{Address: addr, Key: key2, Value: common.Hash{}},
+	}, history.Storage)
+
+	// The history is kept in the copies of the state
+	assert.Equal(t, history, stateDB.Copy().StateHistory())
+
+	enc, err := rlp.EncodeToBytes(history)
+	require.NoError(t, err)
+	decoded := new(StateHistory)
+	require.NoError(t, rlp.DecodeBytes(enc, decoded))
+	assert.Equal(t, history.Storage, decoded.Storage)
+	assert.Empty(t, history.Destructed)
+}
+
+func TestStateDB_StateHistoryDestructed(t *testing.T) {
+	var (
+		db   = NewDatabase(database.NewMemoryDBManager())
+		addr = common.BytesToAddress([]byte{1})
+		key1 = common.BytesToHash([]byte{1})
+		key2 = common.BytesToHash([]byte{2})
+	)
+	stateDB, _ := New(common.Hash{}, db, nil)
+	stateDB.SetCode(addr, []byte{1})
+	stateDB.SetState(addr, key1, common.BytesToHash([]byte{10}))
+	stateDB.SetState(addr, key2, common.BytesToHash([]byte{20}))
+	root, err := stateDB.Commit(false)
+	require.NoError(t, err)
+
+	stateDB, err = New(root, db, nil)
+	require.NoError(t, err)
+	stateDB.StartHistoryRecording()
+	stateDB.SetState(addr, key1, common.BytesToHash([]byte{30}))
+	stateDB.IntermediateRoot(false)
+	stateDB.Suicide(addr)
+	stateDB.IntermediateRoot(false)
+
+	// The whole storage before the recording started is kept, not the one of the intermediate state
+	history := stateDB.StateHistory()
+	require.NotNil(t, history)
+	require.Len(t, history.Destructed, 1)
+	assert.Equal(t, addr, history.Destructed[0].Address)
+	assert.ElementsMatch(t, []HistorySlot{
+		{KeyHash: crypto.Keccak256Hash(key1[:]), Value: common.BytesToHash([]byte{10})},
+		{KeyHash: crypto.Keccak256Hash(key2[:]), Value: common.BytesToHash([]byte{20})},
+	}, history.Destructed[0].Storage)
+	assert.Equal(t, []common.Address{addr}, history.Addresses())
+	assert.Equal(t, history, stateDB.Copy().StateHistory())
+}
+
+func TestStateDB_StateHistoryDestructedIncomplete(t *testing.T) {
+	defer func(limit int) { maxDestructedHistorySlots = limit }(maxDestructedHistorySlots)
+	maxDestructedHistorySlots = 1
+
+	var (
+		db   = NewDatabase(database.NewMemoryDBManager())
+		addr = common.BytesToAddress([]byte{1})
+	)
+	stateDB, _ := New(common.Hash{}, db, nil)
+	stateDB.SetCode(addr, []byte{1})
+	stateDB.SetState(addr, common.BytesToHash([]byte{1}), common.BytesToHash([]byte{10}))
+	stateDB.SetState(addr, common.BytesToHash([]byte{2}), common.BytesToHash([]byte{20}))
+	root, err := stateDB.Commit(false)
+	require.NoError(t, err)
+
+	stateDB, err = New(root, db, nil)
+	require.NoError(t, err)
+	stateDB.StartHistoryRecording()
+	stateDB.Suicide(addr)
+	stateDB.IntermediateRoot(false)
+
+	// The storage bigger than the limit is not walked to the end and marked as incomplete
+	history := stateDB.StateHistory()
+	require.NotNil(t, history)
+	require.Len(t, history.Destructed, 1)
+	assert.True(t, history.Destructed[0].Incomplete)
+	assert.Empty(t, history.Destructed[0].Storage)
+	assert.Equal(t, history, stateDB.Copy().StateHistory())
+
+	enc, err := rlp.EncodeToBytes(history)
+	require.NoError(t, err)
+	decoded := new(StateHistory)
+	require.NoError(t, rlp.DecodeBytes(enc, decoded))
+	assert.True(t, decoded.Destructed[0].Incomplete)
+}
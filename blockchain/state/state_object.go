@@ -348,6 +348,9 @@ func (self *stateObject) updateStorageTrie(db Database) Trie {
 		}
 		self.originStorage[key] = value
 
+		if self.db.history != nil {
+			self.setError(self.db.history.recordStorage(tr, self.address, key))
+		}
 		var v []byte
 		if (value == common.Hash{}) {
 			self.setError(tr.TryDelete(key[:]))
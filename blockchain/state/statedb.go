@@ -102,6 +102,12 @@ type StateDB struct {
 
 	prefetching bool
 
+	// Accounts accessed by the transactions executed in parallel, nil if not tracked
+	tracker *accessTracker
+
+	// Original values of the written accounts and storage slots, nil if not recorded
+	history *historyRecorder
+
 	// Measurements gathered during execution for debugging purposes
 	AccountReads         time.Duration
 	AccountHashes        time.Duration
@@ -420,6 +426,12 @@ func (self *StateDB) Database() Database {
 	return self.db
 }
 
+// GetProof writes the Merkle proof of the given account into proofDB.
+// The proof nodes are written from the root to the leaf.
+func (self *StateDB) GetProof(addr common.Address, proofDB statedb.ProofDBWriter) error {
+	return self.trie.Prove(crypto.Keccak256(addr.Bytes()), 0, proofDB)
+}
+
 // StorageTrie returns the storage trie of an account.
 // The return value is a copy and is nil for non-existent accounts.
 func (self *StateDB) StorageTrie(addr common.Address) Trie {
@@ -548,6 +560,9 @@ func (self *StateDB) updateStateObject(stateObject *stateObject) {
 		defer func(start time.Time) { self.AccountUpdates += time.Since(start) }(time.Now())
 	}
 	addr := stateObject.Address()
+	if self.history != nil {
+		self.setError(self.history.recordAccount(self.trie, addr))
+	}
 	var snapshotData []byte
 	if data := stateObject.encoded.Load(); data != nil {
 		encodedData := data.(*encodedData)
@@ -584,6 +599,10 @@ func (self *StateDB) deleteStateObject(stateObject *stateObject) {
 	}
 	stateObject.deleted = true
 	addr := stateObject.Address()
+	if self.history != nil {
+		self.setError(self.history.recordDestruct(self.db, self.trie, addr))
+		self.setError(self.history.recordAccount(self.trie, addr))
+	}
 	self.setError(self.trie.TryDelete(addr[:]))
 }
 
@@ -602,6 +621,9 @@ func (self *StateDB) getStateObject(addr common.Address) *stateObject {
 // flag set. This is needed by the state journal to revert to the correct s-
 // destructed object instead of wiping all knowledge about the state object.
 func (self *StateDB) getDeletedStateObject(addr common.Address) *stateObject {
+	if self.tracker != nil {
+		self.tracker.reads[addr] = struct{}{}
+	}
 	// First, check stateObjects if there is "live" object.
 	if obj := self.stateObjects[addr]; obj != nil {
 		return obj
@@ -678,6 +700,9 @@ func (self *StateDB) GetOrNewSmartContract(addr common.Address) *stateObject {
 // the given address, it is overwritten and returned as the second return value.
 func (self *StateDB) createObject(addr common.Address) (newobj, prev *stateObject) {
 	prev = self.getDeletedStateObject(addr) // Note, prev might have been deleted, we need that!
+	if self.history != nil && prev != nil {
+		self.setError(self.history.recordDestruct(self.db, self.trie, addr))
+	}
 
 	var prevdestruct bool
 	if self.snap != nil && prev != nil {
@@ -711,6 +736,9 @@ func (self *StateDB) createObjectWithMap(addr common.Address, accountType accoun
 	values map[account.AccountValueKeyType]interface{},
 ) (newobj, prev *stateObject) {
 	prev = self.getDeletedStateObject(addr) // Note, prev might have been deleted, we need that!
+	if self.history != nil && prev != nil {
+		self.setError(self.history.recordDestruct(self.db, self.trie, addr))
+	}
 
 	var prevdestruct bool
 	if self.snap != nil && prev != nil {
@@ -805,13 +833,14 @@ func (self *StateDB) Copy() *StateDB {
 	state := &StateDB{
 		db:                self.db,
 		trie:              self.db.CopyTrie(self.trie),
-		stateObjects:      make(map[common.Address]*stateObject, len(self.journal.dirties)),
-		stateObjectsDirty: make(map[common.Address]struct{}, len(self.journal.dirties)),
-		refund:            self.refund,
-		logs:              make(map[common.Hash][]*types.Log, len(self.logs)),
-		logSize:           self.logSize,
-		preimages:         make(map[common.Hash][]byte),
-		journal:           newJournal(),
+		stateObjects:             make(map[common.Address]*stateObject, len(self.journal.dirties)),
+		stateObjectsDirty:        make(map[common.Address]struct{}, len(self.journal.dirties)),
+		stateObjectsDirtyStorage: make(map[common.Address]struct{}, len(self.stateObjectsDirtyStorage)),
+		refund:                   self.refund,
+		logs:                     make(map[common.Hash][]*types.Log, len(self.logs)),
+		logSize:                  self.logSize,
+		preimages:                make(map[common.Hash][]byte),
+		journal:                  newJournal(),
 	}
 	// Copy the dirty states, logs, and preimages
 	for addr := range self.journal.dirties {
@@ -834,6 +863,10 @@ func (self *StateDB) Copy() *StateDB {
 		}
 	}
 
+	for addr := range self.stateObjectsDirtyStorage {
+		state.stateObjectsDirtyStorage[addr] = struct{}{}
+	}
+
 	deepCopyLogs(self, state)
 
 	for hash, preimage := range self.preimages {
@@ -847,6 +880,10 @@ func (self *StateDB) Copy() *StateDB {
 	// to not blow up if we ever decide copy it in the middle of a transaction
 	state.accessList = self.accessList.Copy()
 
+	if self.history != nil {
+		state.history = self.history.copy()
+	}
+
 	if self.snaps != nil {
 		// In order for the miner to be able to use and make additions
 		// to the snapshot tree, we need to copy that aswell.
@@ -930,6 +967,9 @@ func (stateDB *StateDB) Finalise(deleteEmptyObjects bool, setStorageRoot bool) {
 			// Thus, we can safely ignore it here
 			continue
 		}
+		if stateDB.tracker != nil {
+			stateDB.tracker.writes[addr] = struct{}{}
+		}
 
 		if so.suicided || (deleteEmptyObjects && so.empty()) {
 			stateDB.deleteStateObject(so)
@@ -1111,9 +1151,10 @@ func (s *StateDB) GetContractStorageRoot(contractAddr common.Address) (common.Ha
 // - Add feepayer to access list (only for klaytn)
 // - Add destination to access list (2929)
 // - Add precompiles to access list (2929)
+// - Add the contents of the optional tx access list (2930)
 //
 // This method should only be called if Yolov3/Berlin/2929+2930 is applicable at the current number.
-func (s *StateDB) PrepareAccessList(sender common.Address, feepayer common.Address, dst *common.Address, precompiles []common.Address) {
+func (s *StateDB) PrepareAccessList(sender common.Address, feepayer common.Address, dst *common.Address, precompiles []common.Address, list types.AccessList) {
 	// Clear out any leftover from previous executions
 	s.accessList = newAccessList()
 
@@ -1128,14 +1169,12 @@ func (s *StateDB) PrepareAccessList(sender common.Address, feepayer common.Addre
 	for _, addr := range precompiles {
 		s.AddAddressToAccessList(addr)
 	}
-	// Uncommented below code because we do not support optional accessList yet (written at eip-2930).
-	// Optional accessList is the accessList mentioned through tx args.
-	//for _, el := range list {
-	//	s.AddAddressToAccessList(el.Address)
-	//	for _, key := range el.StorageKeys {
-	//		s.AddSlotToAccessList(el.Address, key)
-	//	}
-	//}
+	for _, el := range list {
+		s.AddAddressToAccessList(el.Address)
+		for _, key := range el.StorageKeys {
+			s.AddSlotToAccessList(el.Address, key)
+		}
+	}
 }
 
 // AddAddressToAccessList adds the given address to the access list
@@ -0,0 +1,110 @@
+// Copyright 2022 The klaytn Authors
+// This file is part of the klaytn library.
+//
+// The klaytn library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The klaytn library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the klaytn library. If not, see <http://www.gnu.org/licenses/>.
+
+package state
+
+import (
+	"github.com/klaytn/klaytn/common"
+)
+
+// accessTracker records the accounts accessed by the transactions,
+// so that the transactions executed in parallel can be checked for conflicts.
+type accessTracker struct {
+	reads  map[common.Address]struct{} // Accounts read or written
+	writes map[common.Address]struct{} // Accounts whose changes are finalised
+}
+
+// StartAccessTracking makes the state record the accounts accessed from now on.
+// The accounts recorded before are cleared.
+func (s *StateDB) StartAccessTracking() {
+	s.tracker = &accessTracker{
+		reads:  make(map[common.Address]struct{}),
+		writes: make(map[common.Address]struct{}),
+	}
+}
+
+// StopAccessTracking stops recording the accessed accounts.
+func (s *StateDB) StopAccessTracking() {
+	s.tracker = nil
+}
+
+// AccessedAccounts returns the accounts read or written, and the accounts whose changes are
+// finalised since the tracking started. It returns nil maps if the accesses are not tracked.
+func (s *StateDB) AccessedAccounts() (reads, writes map[common.Address]struct{}) {
+	if s.tracker == nil {
+		return nil, nil
+	}
+	return s.tracker.reads, s.tracker.writes
+}
+
+// MergeFinalised applies the finalised changes of the accounts written in the given state, and
+// the logs of the given transaction. The given state must be a copy of this state on which the
+// transaction has been executed with the access tracking, and no account it accessed must have
+// been changed in this state since it was copied.
+func (s *StateDB) MergeFinalised(src *StateDB, txHash common.Hash) {
+	if src.dbErr != nil {
+		s.setError(src.dbErr)
+	}
+	for addr := range src.tracker.writes {
+		obj, exist := src.stateObjects[addr]
+		if !exist {
+			continue
+		}
+		copied := obj.deepCopy(s)
+		s.stateObjects[addr] = copied
+		s.stateObjectsDirty[addr] = struct{}{}
+		if _, ok := src.stateObjectsDirtyStorage[addr]; ok {
+			s.stateObjectsDirtyStorage[addr] = struct{}{}
+		}
+		if s.history != nil && src.history != nil {
+			s.history.mergeStorage(src.history, addr)
+		}
+		if copied.deleted {
+			s.deleteStateObject(copied)
+		} else {
+			s.updateStateObject(copied)
+		}
+
+		if s.snap != nil {
+			if _, destructed := src.snapDestructs[copied.addrHash]; destructed {
+				s.snapDestructs[copied.addrHash] = struct{}{}
+				delete(s.snapAccounts, copied.addrHash)
+				delete(s.snapStorage, copied.addrHash)
+			}
+			if storage, ok := src.snapStorage[copied.addrHash]; ok {
+				merged := s.snapStorage[copied.addrHash]
+				if merged == nil {
+					merged = make(map[common.Hash][]byte, len(storage))
+					s.snapStorage[copied.addrHash] = merged
+				}
+				for key, value := range storage {
+					merged[key] = value
+				}
+			}
+		}
+	}
+
+	// The logs are indexed in the block after the logs of the preceding transactions
+	for _, log := range src.logs[txHash] {
+		log.Index = s.logSize
+		s.logSize++
+	}
+	s.logs[txHash] = src.logs[txHash]
+
+	for hash, preimage := range src.preimages {
+		s.preimages[hash] = preimage
+	}
+}
@@ -727,3 +727,32 @@ func TestStateDBAccessList(t *testing.T) {
 		t.Fatalf("expected empty, got %d", got)
 	}
 }
+
+func TestStateDBPrepareAccessList(t *testing.T) {
+	var (
+		sender     = common.HexToAddress("0xaa")
+		feePayer   = common.HexToAddress("0xbb")
+		dst        = common.HexToAddress("0xcc")
+		precompile = common.HexToAddress("0x01")
+		listed     = common.HexToAddress("0xdd")
+		slot       = common.HexToHash("0x01")
+	)
+	state, _ := New(common.Hash{}, NewDatabase(database.NewMemoryDBManager()), nil)
+	state.AddAddressToAccessList(common.HexToAddress("0xee"))
+
+	state.PrepareAccessList(sender, feePayer, &dst, []common.Address{precompile}, types.AccessList{
+		{Address: dst, StorageKeys: []common.Hash{slot}},
+		{Address: listed},
+	})
+
+	// The leftover of the previous execution is cleared
+	assert.False(t, state.AddressInAccessList(common.HexToAddress("0xee")))
+	for _, addr := range []common.Address{sender, feePayer, dst, precompile, listed} {
+		assert.True(t, state.AddressInAccessList(addr), addr.String())
+	}
+	addrOk, slotOk := state.SlotInAccessList(dst, slot)
+	assert.True(t, addrOk)
+	assert.True(t, slotOk)
+	_, slotOk = state.SlotInAccessList(listed, slot)
+	assert.False(t, slotOk)
+}
@@ -0,0 +1,196 @@
+// Copyright 2022 The klaytn Authors
+// This file is part of the klaytn library.
+//
+// The klaytn library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The klaytn library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the klaytn library. If not, see <http://www.gnu.org/licenses/>.
+
+package blockchain
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/klaytn/klaytn/blockchain/state"
+	"github.com/klaytn/klaytn/blockchain/types"
+	"github.com/klaytn/klaytn/blockchain/types/account"
+	"github.com/klaytn/klaytn/common"
+	"github.com/klaytn/klaytn/crypto"
+	"github.com/klaytn/klaytn/rlp"
+)
+
+var (
+	errStateHistoryDisabled = errors.New("state history is disabled")
+	errStateHistoryNotFound = errors.New("state history of the block is not stored")
+	errFutureStateHistory   = errors.New("block is higher than the current block")
+	errStateHistoryPartial  = errors.New("storage of a destructed contract is too big to be recorded in the state history")
+)
+
+// writeStateHistory stores the reverse state diff recorded while the block was processed,
+// keyed by the hash of the block and indexed by the modified accounts. It is called after the
+// state of the block has been committed and before the block becomes canonical, so the history
+// of a block failing to be written is never served, as the readers check the canonical hash.
+//
+// The histories are kept contiguous from the tail, since a historical state is reconstructed from
+// the histories of all the following blocks. If the history of the parent block is missing, e.g.
+// it was imported while the state history was disabled, the block is stored as the new tail.
+func (bc *BlockChain) writeStateHistory(block *types.Block, stateDB *state.StateDB) error {
+	history := stateDB.StateHistory()
+	if history == nil {
+		logger.Warn("State history is not recorded", "number", block.NumberU64(), "hash", block.Hash())
+		return nil
+	}
+	enc, err := rlp.EncodeToBytes(history)
+	if err != nil {
+		return err
+	}
+	_, ok := bc.db.ReadStateHistoryTail()
+	tail := !ok || !bc.db.HasStateHistory(block.ParentHash(), block.NumberU64()-1)
+	if tail && ok {
+		logger.Warn("State history of the parent block is missing, moving the tail", "number", block.NumberU64(), "hash", block.Hash())
+	}
+	return bc.db.WriteStateHistory(block.Hash(), block.NumberU64(), enc, history.Addresses(), tail)
+}
+
+// readStateHistory retrieves the reverse state diff of the block of the given hash and number.
+func (bc *BlockChain) readStateHistory(hash common.Hash, number uint64) (*state.StateHistory, error) {
+	enc := bc.db.ReadStateHistory(hash, number)
+	if enc == nil {
+		return nil, fmt.Errorf("%w (number: %d, hash: %s)", errStateHistoryNotFound, number, hash.String())
+	}
+	history := new(state.StateHistory)
+	if err := rlp.DecodeBytes(enc, history); err != nil {
+		return nil, err
+	}
+	return history, nil
+}
+
+// historicalState returns the state of the canonical block of the given number if it is still
+// available. Otherwise, the reverse state diffs of the canonical blocks which modified the given
+// address are walked in ascending order from the block next to the given block, and the state
+// of the current block is returned if none of them is accepted by visit. It fails if the diffs
+// of the blocks from the given block up to the current block are not all stored.
+func (bc *BlockChain) historicalState(addr common.Address, number uint64, visit func(history *state.StateHistory) bool) (*state.StateDB, error) {
+	if !bc.cacheConfig.StateHistory {
+		return nil, errStateHistoryDisabled
+	}
+	current := bc.CurrentBlock()
+	if number > current.NumberU64() {
+		return nil, errFutureStateHistory
+	}
+	if header := bc.GetHeaderByNumber(number); header != nil {
+		if stateDB, err := bc.StateAt(header.Root); err == nil {
+			return stateDB, nil
+		}
+	}
+	if tail, ok := bc.db.ReadStateHistoryTail(); !ok || number+1 < tail {
+		return nil, fmt.Errorf("%w (number: %d)", errStateHistoryNotFound, number+1)
+	}
+	// The tail is moved when a block is stored without the history of its parent, so only the
+	// current block can be missing, e.g. if it was imported while the state history was disabled.
+	if !bc.db.HasStateHistory(current.Hash(), current.NumberU64()) {
+		return nil, fmt.Errorf("%w (number: %d)", errStateHistoryNotFound, current.NumberU64())
+	}
+	var (
+		found bool
+		err   error
+	)
+	bc.db.ReadAccountStateHistoryIndex(addr, number+1, current.NumberU64(), func(n uint64, hash common.Hash) bool {
+		if bc.db.ReadCanonicalHash(n) != hash {
+			return true
+		}
+		var history *state.StateHistory
+		if history, err = bc.readStateHistory(hash, n); err != nil {
+			return false
+		}
+		found = visit(history)
+		return !found
+	})
+	if err != nil || found {
+		return nil, err
+	}
+	return bc.StateAt(current.Root())
+}
+
+// HistoricalAccount returns the account of the given address at the canonical block of the given
+// number. If the state of the block has been pruned, the account is reconstructed from the latest
+// state and the reverse state diffs of the following blocks. It returns nil if the account did not exist.
+func (bc *BlockChain) HistoricalAccount(addr common.Address, number uint64) (account.Account, error) {
+	var enc []byte
+	stateDB, err := bc.historicalState(addr, number, func(history *state.StateHistory) bool {
+		for _, acc := range history.Accounts {
+			if acc.Address == addr {
+				enc = acc.Data
+				return true
+			}
+		}
+		return false
+	})
+	if err != nil {
+		return nil, err
+	}
+	if stateDB != nil {
+		return stateDB.GetAccount(addr), nil
+	}
+	if len(enc) == 0 {
+		return nil, nil
+	}
+	serializer := account.NewAccountSerializer()
+	if err := rlp.DecodeBytes(enc, serializer); err != nil {
+		return nil, err
+	}
+	return serializer.GetAccount(), nil
+}
+
+// HistoricalStorage returns the value of the storage slot of the given address at the canonical
+// block of the given number. If the state of the block has been pruned, the value is reconstructed
+// from the latest state and the reverse state diffs of the following blocks. It fails if the storage
+// of the contract was too big to be recorded when the contract was destructed.
+func (bc *BlockChain) HistoricalStorage(addr common.Address, key common.Hash, number uint64) (common.Hash, error) {
+	var (
+		value   common.Hash
+		keyHash = crypto.Keccak256Hash(key[:])
+	)
+	var partial bool
+	stateDB, err := bc.historicalState(addr, number, func(history *state.StateHistory) bool {
+		for _, destructed := range history.Destructed {
+			if destructed.Address != addr {
+				continue
+			}
+			if destructed.Incomplete {
+				partial = true
+				return true
+			}
+			// The storage of a destructed contract is recorded as a whole, so a missing slot was empty.
+			for _, slot := range destructed.Storage {
+				if slot.KeyHash == keyHash {
+					value = slot.Value
+				}
+			}
+			return true
+		}
+		for _, slot := range history.Storage {
+			if slot.Address == addr && slot.Key == key {
+				value = slot.Value
+				return true
+			}
+		}
+		return false
+	})
+	if err != nil || stateDB == nil {
+		if err == nil && partial {
+			err = fmt.Errorf("%w (address: %s)", errStateHistoryPartial, addr.String())
+		}
+		return value, err
+	}
+	return stateDB.GetState(addr, key), nil
+}
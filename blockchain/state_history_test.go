@@ -0,0 +1,238 @@
+// Copyright 2022 The klaytn Authors
+// This file is part of the klaytn library.
+//
+// The klaytn library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The klaytn library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the klaytn library. If not, see <http://www.gnu.org/licenses/>.
+
+package blockchain
+
+import (
+	"math/big"
+	"testing"
+	"time"
+
+	"github.com/klaytn/klaytn/blockchain/types"
+	"github.com/klaytn/klaytn/blockchain/vm"
+	"github.com/klaytn/klaytn/common"
+	"github.com/klaytn/klaytn/consensus/gxhash"
+	"github.com/klaytn/klaytn/crypto"
+	"github.com/klaytn/klaytn/params"
+	"github.com/klaytn/klaytn/storage/database"
+	"github.com/klaytn/klaytn/storage/statedb"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestBlockChain_HistoricalAccount(t *testing.T) {
+	var (
+		key, _  = crypto.GenerateKey()
+		addr    = crypto.PubkeyToAddress(key.PublicKey)
+		to      = common.HexToAddress("0x1234")
+		gendb   = database.NewMemoryDBManager()
+		gspec   = &Genesis{Config: params.TestChainConfig, Alloc: GenesisAlloc{addr: {Balance: big.NewInt(params.KLAY)}}}
+		genesis = gspec.MustCommit(gendb)
+		signer  = types.LatestSignerForChainID(params.TestChainConfig.ChainID)
+	)
+	chain, _ := GenerateChain(params.TestChainConfig, genesis, gxhash.NewFaker(), gendb, 8, func(i int, gen *BlockGen) {
+		if i%2 == 1 {
+			return
+		}
+		tx, err := types.SignTx(types.NewTransaction(gen.TxNonce(addr), to, big.NewInt(int64(i+1)), params.TxGas, big.NewInt(1), nil), signer, key)
+		require.NoError(t, err)
+		gen.AddTx(tx)
+	})
+
+	// The fork replaces the chain, so the reverse state diffs of the replaced blocks must not be used.
+	fork, _ := GenerateChain(params.TestChainConfig, genesis, gxhash.NewFaker(), gendb, len(chain)+2, func(i int, gen *BlockGen) {
+		tx, err := types.SignTx(types.NewTransaction(gen.TxNonce(addr), to, big.NewInt(int64(100+i)), params.TxGas, big.NewInt(1), nil), signer, key)
+		require.NoError(t, err)
+		gen.AddTx(tx)
+	})
+	archive, bc := newStateHistoryTestChains(t, gspec, chain, fork)
+	defer archive.Stop()
+	defer bc.Stop()
+	chain = fork
+
+	_, err := archive.HistoricalAccount(addr, 1)
+	assert.ErrorIs(t, err, errStateHistoryDisabled)
+	_, err = bc.HistoricalAccount(addr, uint64(len(chain)+1))
+	assert.ErrorIs(t, err, errFutureStateHistory)
+
+	// The pruned states are reconstructed from the reverse state diffs
+	waitStatePruned(t, bc, chain[1].Root())
+	for number := uint64(0); number <= uint64(len(chain)); number++ {
+		expected, err := archive.StateAt(archive.GetHeaderByNumber(number).Root)
+		require.NoError(t, err)
+
+		for _, a := range []common.Address{addr, to} {
+			acc, err := bc.HistoricalAccount(a, number)
+			require.NoError(t, err)
+			if expected.Exist(a) {
+				require.NotNil(t, acc, "number %d", number)
+				assert.Equal(t, expected.GetBalance(a), acc.GetBalance(), "number %d", number)
+				assert.Equal(t, expected.GetNonce(a), acc.GetNonce(), "number %d", number)
+			} else {
+				assert.Nil(t, acc, "number %d", number)
+			}
+		}
+		value, err := bc.HistoricalStorage(addr, common.Hash{}, number)
+		require.NoError(t, err)
+		assert.Equal(t, common.Hash{}, value)
+	}
+}
+
+func TestBlockChain_HistoricalStorage(t *testing.T) {
+	var (
+		key, _   = crypto.GenerateKey()
+		addr     = crypto.PubkeyToAddress(key.PublicKey)
+		contract = common.HexToAddress("0xc0c0")
+		slot1    = common.BytesToHash([]byte{1})
+		slot2    = common.BytesToHash([]byte{2})
+		gendb    = database.NewMemoryDBManager()
+		signer   = types.LatestSignerForChainID(params.TestChainConfig.ChainID)
+	)
+	// The contract increments slot 1 if it is called without data, and self-destructs otherwise.
+	code := append(append([]byte{
+		byte(vm.CALLDATASIZE), byte(vm.ISZERO), byte(vm.PUSH1), 0x1b, byte(vm.JUMPI), byte(vm.PUSH20),
+	}, addr.Bytes()...), byte(vm.SELFDESTRUCT),
+		byte(vm.JUMPDEST), byte(vm.PUSH1), 0x01, byte(vm.SLOAD), byte(vm.PUSH1), 0x01, byte(vm.ADD),
+		byte(vm.PUSH1), 0x01, byte(vm.SSTORE), byte(vm.STOP))
+	gspec := &Genesis{Config: params.TestChainConfig, Alloc: GenesisAlloc{
+		addr:     {Balance: big.NewInt(params.KLAY)},
+		contract: {Code: code, Balance: big.NewInt(1), Storage: map[common.Hash]common.Hash{slot1: common.BigToHash(big.NewInt(0x2a)), slot2: common.BigToHash(big.NewInt(7))}},
+	}}
+	genesis := gspec.MustCommit(gendb)
+
+	chain, _ := GenerateChain(params.TestChainConfig, genesis, gxhash.NewFaker(), gendb, 6, func(i int, gen *BlockGen) {
+		var data []byte
+		switch i {
+		case 0, 2:
+		case 4:
+			data = []byte{1}
+		default:
+			return
+		}
+		tx, err := types.SignTx(types.NewTransaction(gen.TxNonce(addr), contract, common.Big0, 100000, big.NewInt(1), data), signer, key)
+		require.NoError(t, err)
+		gen.AddTx(tx)
+	})
+	archive, bc := newStateHistoryTestChains(t, gspec, chain)
+	defer archive.Stop()
+	defer bc.Stop()
+
+	waitStatePruned(t, bc, chain[1].Root())
+	for number := uint64(0); number <= uint64(len(chain)); number++ {
+		expected, err := archive.StateAt(archive.GetHeaderByNumber(number).Root)
+		require.NoError(t, err)
+
+		for _, slot := range []common.Hash{slot1, slot2} {
+			value, err := bc.HistoricalStorage(contract, slot, number)
+			require.NoError(t, err)
+			assert.Equal(t, expected.GetState(contract, slot), value, "number %d, slot %x", number, slot)
+		}
+	}
+	// The storage is recorded as a whole in the history of the block destructing the contract.
+	destructed := chain[4]
+	history, err := bc.readStateHistory(destructed.Hash(), destructed.NumberU64())
+	require.NoError(t, err)
+	require.Len(t, history.Destructed, 1)
+	assert.Equal(t, contract, history.Destructed[0].Address)
+	assert.Len(t, history.Destructed[0].Storage, 2)
+}
+
+func TestBlockChain_HistoricalStateGap(t *testing.T) {
+	var (
+		key, _  = crypto.GenerateKey()
+		addr    = crypto.PubkeyToAddress(key.PublicKey)
+		to      = common.HexToAddress("0x1234")
+		gendb   = database.NewMemoryDBManager()
+		gspec   = &Genesis{Config: params.TestChainConfig, Alloc: GenesisAlloc{addr: {Balance: big.NewInt(params.KLAY)}}}
+		genesis = gspec.MustCommit(gendb)
+		signer  = types.LatestSignerForChainID(params.TestChainConfig.ChainID)
+	)
+	chain, _ := GenerateChain(params.TestChainConfig, genesis, gxhash.NewFaker(), gendb, 6, func(i int, gen *BlockGen) {
+		tx, err := types.SignTx(types.NewTransaction(gen.TxNonce(addr), to, big.NewInt(int64(i+1)), params.TxGas, big.NewInt(1), nil), signer, key)
+		require.NoError(t, err)
+		gen.AddTx(tx)
+	})
+	archive, bc := newStateHistoryTestChains(t, gspec, chain[:2])
+	defer archive.Stop()
+	defer bc.Stop()
+	_, err := archive.InsertChain(chain[2:])
+	require.NoError(t, err)
+
+	// The blocks 3 and 4 are imported while the state history is disabled.
+	bc.cacheConfig.StateHistory = false
+	_, err = bc.InsertChain(chain[2:4])
+	require.NoError(t, err)
+	bc.cacheConfig.StateHistory = true
+	waitStatePruned(t, bc, chain[2].Root())
+
+	// The history of the current block is missing, so the pruned states are not served.
+	for number := uint64(1); number < 4; number++ {
+		_, err := bc.HistoricalAccount(to, number)
+		assert.ErrorIs(t, err, errStateHistoryNotFound, "number %d", number)
+	}
+
+	// The next block is stored as the tail, since the history of its parent is missing.
+	_, err = bc.InsertChain(chain[4:])
+	require.NoError(t, err)
+	tail, ok := bc.db.ReadStateHistoryTail()
+	require.True(t, ok)
+	assert.Equal(t, uint64(5), tail)
+	waitStatePruned(t, bc, chain[4].Root())
+
+	for number := uint64(1); number <= uint64(len(chain)); number++ {
+		acc, err := bc.HistoricalAccount(to, number)
+		if number < 4 {
+			assert.ErrorIs(t, err, errStateHistoryNotFound, "number %d", number)
+			continue
+		}
+		require.NoError(t, err)
+		expected, err := archive.StateAt(archive.GetHeaderByNumber(number).Root)
+		require.NoError(t, err)
+		assert.Equal(t, expected.GetBalance(to), acc.GetBalance(), "number %d", number)
+	}
+}
+
+// newStateHistoryTestChains returns an archive chain and a pruning chain recording the state
+// history, both of which have the given chains inserted in order.
+func newStateHistoryTestChains(t *testing.T, gspec *Genesis, chains ...types.Blocks) (*BlockChain, *BlockChain) {
+	newBlockChain := func(archive, history bool) *BlockChain {
+		db := database.NewMemoryDBManager()
+		gspec.MustCommit(db)
+		cacheConfig := &CacheConfig{
+			ArchiveMode:         archive,
+			CacheSize:           512,
+			BlockInterval:       DefaultBlockInterval,
+			TriesInMemory:       1,
+			TrieNodeCacheConfig: statedb.GetEmptyTrieNodeCacheConfig(),
+			StateHistory:        history,
+		}
+		bc, err := NewBlockChain(db, cacheConfig, params.TestChainConfig, gxhash.NewFaker(), vm.Config{})
+		require.NoError(t, err)
+		for _, chain := range chains {
+			_, err = bc.InsertChain(chain)
+			require.NoError(t, err)
+		}
+		return bc
+	}
+	return newBlockChain(true, false), newBlockChain(false, true)
+}
+
+func waitStatePruned(t *testing.T, bc *BlockChain, root common.Hash) {
+	require.Eventually(t, func() bool {
+		_, err := bc.StateAt(root)
+		return err != nil
+	}, time.Second, 10*time.Millisecond)
+}
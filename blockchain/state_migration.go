@@ -360,6 +360,26 @@ func (bc *BlockChain) checkStartStateMigration(number uint64, root common.Hash)
 	return false
 }
 
+// checkPeriodicMigration prepares a state migration if PeriodicMigrationInterval blocks have passed since the last one.
+// The state migration copies the whole state of the block to a new database and removes the old database with the
+// stale state, so that the disk usage of the state is kept bounded while the node is running. It is not an incremental
+// pruning: the trie nodes are not reference counted, and the state is copied as a whole at every interval.
+func (bc *BlockChain) checkPeriodicMigration(number uint64) {
+	interval := bc.cacheConfig.PeriodicMigrationInterval
+	if interval == 0 || bc.prepareStateMigration || bc.db.InMigration() {
+		return
+	}
+	if number < bc.lastPeriodicMigration+interval {
+		return
+	}
+	logger.Info("Starting a periodic state migration", "block", number, "lastMigrated", bc.lastPeriodicMigration)
+	bc.prepareStateMigration = true
+	bc.lastPeriodicMigration = number
+	if err := bc.db.WritePeriodicMigrationBlock(number); err != nil {
+		logger.Error("Failed to write the block of the periodic migration", "block", number, "err", err)
+	}
+}
+
 // migrationPrerequisites is a collection of functions that needs to be run
 // before state trie migration. If one of the functions fails to run,
 // the migration will not start.
@@ -89,3 +89,31 @@ func TestBlockChain_migrateState(t *testing.T) {
 		t.Fatalf("mismatch bytecodes: (expected: %v, actual: %v)", common.Bytes2Hex(expectedCode), common.Bytes2Hex(actualCode))
 	}
 }
+
+func TestBlockChain_checkPeriodicMigration(t *testing.T) {
+	bc := &BlockChain{
+		cacheConfig:           &CacheConfig{PeriodicMigrationInterval: 10},
+		db:                    database.NewMemoryDBManager(),
+		lastPeriodicMigration: 5,
+	}
+
+	bc.checkPeriodicMigration(14)
+	if bc.prepareStateMigration {
+		t.Fatal("state migration is prepared before the interval passes")
+	}
+
+	bc.checkPeriodicMigration(15)
+	if !bc.prepareStateMigration || bc.lastPeriodicMigration != 15 {
+		t.Fatalf("state migration is not prepared (prepared: %v, lastPeriodicMigration: %v)", bc.prepareStateMigration, bc.lastPeriodicMigration)
+	}
+	// The block of the periodic migration is kept across restarts
+	if stored, ok := bc.db.ReadPeriodicMigrationBlock(); !ok || stored != 15 {
+		t.Fatalf("the block of the periodic migration is not stored (stored: %v, ok: %v)", stored, ok)
+	}
+
+	// The pending migration is not prepared again
+	bc.checkPeriodicMigration(30)
+	if bc.lastPeriodicMigration != 15 {
+		t.Fatalf("state migration is prepared twice (lastPeriodicMigration: %v)", bc.lastPeriodicMigration)
+	}
+}
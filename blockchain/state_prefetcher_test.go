@@ -0,0 +1,76 @@
+// Copyright 2022 The klaytn Authors
+// This file is part of the klaytn library.
+//
+// The klaytn library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The klaytn library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the klaytn library. If not, see <http://www.gnu.org/licenses/>.
+
+package blockchain
+
+import (
+	"math/big"
+	"testing"
+
+	"github.com/klaytn/klaytn/blockchain/state"
+	"github.com/klaytn/klaytn/blockchain/types"
+	"github.com/klaytn/klaytn/blockchain/vm"
+	"github.com/klaytn/klaytn/common"
+	"github.com/klaytn/klaytn/consensus/gxhash"
+	"github.com/klaytn/klaytn/crypto"
+	"github.com/klaytn/klaytn/params"
+	"github.com/klaytn/klaytn/storage/database"
+	"github.com/klaytn/klaytn/storage/statedb"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestStatePrefetcher_PrefetchAccounts(t *testing.T) {
+	var (
+		key, _  = crypto.GenerateKey()
+		addr    = crypto.PubkeyToAddress(key.PublicKey)
+		gendb   = database.NewMemoryDBManager()
+		gspec   = &Genesis{Config: params.TestChainConfig, Alloc: GenesisAlloc{addr: {Balance: big.NewInt(params.KLAY)}}}
+		genesis = gspec.MustCommit(gendb)
+		signer  = types.LatestSignerForChainID(params.TestChainConfig.ChainID)
+	)
+	chain, _ := GenerateChain(params.TestChainConfig, genesis, gxhash.NewFaker(), gendb, 4, func(i int, gen *BlockGen) {
+		to := common.BigToAddress(big.NewInt(int64(i + 1)))
+		tx, err := types.SignTx(types.NewTransaction(gen.TxNonce(addr), to, big.NewInt(1), params.TxGas, big.NewInt(1), nil), signer, key)
+		require.NoError(t, err)
+		gen.AddTx(tx)
+	})
+
+	db := database.NewMemoryDBManager()
+	gspec.MustCommit(db)
+	trieNodeCacheConfig := statedb.GetEmptyTrieNodeCacheConfig()
+	trieNodeCacheConfig.PrefetchBlockAccounts = true
+	cacheConfig := &CacheConfig{
+		CacheSize:           512,
+		BlockInterval:       DefaultBlockInterval,
+		TriesInMemory:       DefaultTriesInMemory,
+		TrieNodeCacheConfig: trieNodeCacheConfig,
+	}
+	bc, err := NewBlockChain(db, cacheConfig, params.TestChainConfig, gxhash.NewFaker(), vm.Config{})
+	require.NoError(t, err)
+	defer bc.Stop()
+
+	// Warming the accounts must not modify the state
+	stateDB, err := state.NewForPrefetching(genesis.Root(), bc.stateCache, nil)
+	require.NoError(t, err)
+	bc.prefetcher.PrefetchAccounts(chain[0], stateDB, nil)
+	assert.Equal(t, genesis.Root(), stateDB.IntermediateRoot(true))
+
+	// The followup blocks are warmed while the chain is imported
+	_, err = bc.InsertChain(chain)
+	require.NoError(t, err)
+	assert.Equal(t, chain[len(chain)-1].Hash(), bc.CurrentBlock().Hash())
+}
@@ -79,16 +79,24 @@ func (p *StateProcessor) Process(block *types.Block, statedb *state.StateDB, cfg
 	author, _ := p.bc.Engine().Author(header) // Ignore error, we're past header validation
 
 	processStats.BeforeApplyTxs = time.Now()
-	// Iterate over and process the individual transactions
-	for i, tx := range block.Transactions() {
-		statedb.Prepare(tx.Hash(), block.Hash(), i)
-		receipt, internalTxTrace, err := p.bc.ApplyTransaction(p.config, &author, statedb, header, tx, usedGas, &cfg)
-		if err != nil {
+	if p.canProcessInParallel(block, &cfg) {
+		var err error
+		if receipts, allLogs, err = p.applyTransactionsInParallel(block, statedb, &author, usedGas, cfg); err != nil {
 			return nil, nil, 0, nil, processStats, err
 		}
-		receipts = append(receipts, receipt)
-		allLogs = append(allLogs, receipt.Logs...)
-		internalTxTraces = append(internalTxTraces, internalTxTrace)
+		internalTxTraces = make([]*vm.InternalTxTrace, len(receipts))
+	} else {
+		// Iterate over and process the individual transactions
+		for i, tx := range block.Transactions() {
+			statedb.Prepare(tx.Hash(), block.Hash(), i)
+			receipt, internalTxTrace, err := p.bc.ApplyTransaction(p.config, &author, statedb, header, tx, usedGas, &cfg)
+			if err != nil {
+				return nil, nil, 0, nil, processStats, err
+			}
+			receipts = append(receipts, receipt)
+			allLogs = append(allLogs, receipt.Logs...)
+			internalTxTraces = append(internalTxTraces, internalTxTrace)
+		}
 	}
 	processStats.AfterApplyTxs = time.Now()
 
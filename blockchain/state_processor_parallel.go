@@ -0,0 +1,140 @@
+// Copyright 2022 The klaytn Authors
+// This file is part of the klaytn library.
+//
+// The klaytn library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The klaytn library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the klaytn library. If not, see <http://www.gnu.org/licenses/>.
+
+package blockchain
+
+import (
+	"sync"
+
+	"github.com/klaytn/klaytn/blockchain/state"
+	"github.com/klaytn/klaytn/blockchain/types"
+	"github.com/klaytn/klaytn/blockchain/vm"
+	"github.com/klaytn/klaytn/common"
+	"github.com/rcrowley/go-metrics"
+)
+
+var (
+	parallelTxExecutedMeter   = metrics.NewRegisteredMeter("chain/parallel/executed", nil)
+	parallelTxReexecutedMeter = metrics.NewRegisteredMeter("chain/parallel/reexecuted", nil)
+)
+
+// parallelTxResult is the result of a transaction executed optimistically on a copy of the state.
+type parallelTxResult struct {
+	statedb *state.StateDB
+	receipt *types.Receipt
+	gas     uint64
+	err     error
+}
+
+// canProcessInParallel returns whether the transactions of the block can be executed in parallel.
+// Every transaction writes the block proposer's balance if the transaction fee is not deferred,
+// and the tracer of the internal transactions is shared by the transactions.
+func (p *StateProcessor) canProcessInParallel(block *types.Block, cfg *vm.Config) bool {
+	if p.bc.cacheConfig.ParallelTxWorkers <= 1 || block.Transactions().Len() < 2 {
+		return false
+	}
+	if p.config.Governance == nil || !p.config.Governance.DeferredTxFee() {
+		return false
+	}
+	return !cfg.Debug && !cfg.EnableInternalTxTracing
+}
+
+// applyTransactionsInParallel executes the transactions of the block concurrently, each on its own copy
+// of the state before the block, and records the accounts each transaction accessed. The results are
+// then merged into the state in the order of the transactions. A transaction which accessed an account
+// written by a preceding transaction, or which failed, is executed again on the merged state.
+func (p *StateProcessor) applyTransactionsInParallel(block *types.Block, statedb *state.StateDB, author *common.Address, usedGas *uint64, cfg vm.Config) (types.Receipts, []*types.Log, error) {
+	var (
+		txs     = block.Transactions()
+		header  = block.Header()
+		base    = statedb.Copy()
+		results = make([]parallelTxResult, len(txs))
+		taskCh  = make(chan int, len(txs))
+		wg      sync.WaitGroup
+	)
+	for i := range txs {
+		taskCh <- i
+	}
+	close(taskCh)
+
+	workers := p.bc.cacheConfig.ParallelTxWorkers
+	if workers > len(txs) {
+		workers = len(txs)
+	}
+	wg.Add(workers)
+	for w := 0; w < workers; w++ {
+		go func() {
+			defer wg.Done()
+			for i := range taskCh {
+				var (
+					copied = base.Copy()
+					gas    uint64
+					txCfg  = cfg // the interpreter modifies the config
+				)
+				copied.StartAccessTracking()
+				copied.Prepare(txs[i].Hash(), block.Hash(), i)
+				receipt, _, err := p.bc.ApplyTransaction(p.config, author, copied, header, txs[i], &gas, &txCfg)
+				results[i] = parallelTxResult{statedb: copied, receipt: receipt, gas: gas, err: err}
+			}
+		}()
+	}
+	wg.Wait()
+
+	var (
+		receipts types.Receipts
+		allLogs  []*types.Log
+		written  = make(map[common.Address]struct{})
+	)
+	for i, tx := range txs {
+		result := results[i]
+		results[i] = parallelTxResult{} // release the copy of the state
+
+		reads, writes := result.statedb.AccessedAccounts()
+		if result.err != nil || conflicts(reads, written) {
+			// Execute the transaction again on the state including the changes of the preceding transactions
+			parallelTxReexecutedMeter.Mark(1)
+			statedb.StartAccessTracking()
+			statedb.Prepare(tx.Hash(), block.Hash(), i)
+			receipt, _, err := p.bc.ApplyTransaction(p.config, author, statedb, header, tx, usedGas, &cfg)
+			_, writes = statedb.AccessedAccounts()
+			statedb.StopAccessTracking()
+			if err != nil {
+				return nil, nil, err
+			}
+			result.receipt = receipt
+		} else {
+			parallelTxExecutedMeter.Mark(1)
+			statedb.MergeFinalised(result.statedb, tx.Hash())
+			*usedGas += result.gas
+		}
+		for addr := range writes {
+			written[addr] = struct{}{}
+		}
+		receipts = append(receipts, result.receipt)
+		allLogs = append(allLogs, result.receipt.Logs...)
+	}
+	return receipts, allLogs, nil
+}
+
+// conflicts returns whether any of the accessed accounts has been written.
+func conflicts(accessed, written map[common.Address]struct{}) bool {
+	for addr := range accessed {
+		if _, ok := written[addr]; ok {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,193 @@
+// Copyright 2022 The klaytn Authors
+// This file is part of the klaytn library.
+//
+// The klaytn library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The klaytn library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the klaytn library. If not, see <http://www.gnu.org/licenses/>.
+
+package blockchain
+
+import (
+	"crypto/ecdsa"
+	"math/big"
+	"testing"
+
+	"github.com/klaytn/klaytn/blockchain/types"
+	"github.com/klaytn/klaytn/blockchain/vm"
+	"github.com/klaytn/klaytn/common"
+	"github.com/klaytn/klaytn/consensus/gxhash"
+	"github.com/klaytn/klaytn/crypto"
+	"github.com/klaytn/klaytn/params"
+	"github.com/klaytn/klaytn/storage/database"
+	"github.com/klaytn/klaytn/storage/statedb"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestProcessTransactionsInParallel(t *testing.T) {
+	config := params.TestChainConfig.Copy()
+	config.Governance = &params.GovernanceConfig{Reward: &params.RewardConfig{DeferredTxFee: true}}
+
+	var (
+		keys  = make([]*ecdsa.PrivateKey, 5)
+		addrs = make([]common.Address, 5)
+		alloc = GenesisAlloc{}
+	)
+	for i := range keys {
+		keys[i], _ = crypto.GenerateKey()
+		addrs[i] = crypto.PubkeyToAddress(keys[i].PublicKey)
+		alloc[addrs[i]] = GenesisAccount{Balance: big.NewInt(params.KLAY)}
+	}
+	var (
+		gendb   = database.NewMemoryDBManager()
+		gspec   = &Genesis{Config: config, Alloc: alloc}
+		genesis = gspec.MustCommit(gendb)
+		signer  = types.LatestSignerForChainID(config.ChainID)
+	)
+	transfer := func(gen *BlockGen, from int, to common.Address) {
+		tx, err := types.SignTx(types.NewTransaction(gen.TxNonce(addrs[from]), to, big.NewInt(1), params.TxGas, big.NewInt(1), nil), signer, keys[from])
+		require.NoError(t, err)
+		gen.AddTx(tx)
+	}
+	chain, _ := GenerateChain(config, genesis, gxhash.NewFaker(), gendb, 4, func(i int, gen *BlockGen) {
+		// Independent transactions
+		for from := 0; from < 4; from++ {
+			transfer(gen, from, common.BigToAddress(big.NewInt(int64(100*i+from+1))))
+		}
+		// Conflicting transactions with the same sender and with the recipient of a preceding transaction
+		transfer(gen, 0, addrs[4])
+		transfer(gen, 4, addrs[1])
+	})
+
+	for _, workers := range []int{0, 1, 4} {
+		// The state roots and the receipts are validated against the blocks processed serially
+		root, receipts := insertChainWithWorkers(t, gspec, chain, workers)
+		assert.Equal(t, chain[len(chain)-1].Root(), root)
+		for i, block := range chain {
+			assert.Len(t, receipts[i], block.Transactions().Len())
+		}
+	}
+}
+
+// insertChainWithWorkers inserts the chain into a new blockchain executing the transactions with the given
+// number of workers, and returns the state root of the last block and the stored receipts of the blocks.
+func insertChainWithWorkers(t *testing.T, gspec *Genesis, chain types.Blocks, workers int) (common.Hash, []types.Receipts) {
+	db := database.NewMemoryDBManager()
+	gspec.MustCommit(db)
+	cacheConfig := &CacheConfig{
+		CacheSize:           512,
+		BlockInterval:       DefaultBlockInterval,
+		TriesInMemory:       DefaultTriesInMemory,
+		TrieNodeCacheConfig: statedb.GetEmptyTrieNodeCacheConfig(),
+		ParallelTxWorkers:   workers,
+	}
+	bc, err := NewBlockChain(db, cacheConfig, gspec.Config, gxhash.NewFaker(), vm.Config{})
+	require.NoError(t, err)
+	defer bc.Stop()
+
+	_, err = bc.InsertChain(chain)
+	require.NoError(t, err, "workers %d", workers)
+
+	receipts := make([]types.Receipts, len(chain))
+	for i, block := range chain {
+		receipts[i] = bc.GetReceiptsByBlockHash(block.Hash())
+	}
+	return bc.CurrentBlock().Root(), receipts
+}
+
+func TestProcessTransactionsInParallel_ContractStorageAndLogs(t *testing.T) {
+	config := params.TestChainConfig.Copy()
+	config.Governance = &params.GovernanceConfig{Reward: &params.RewardConfig{DeferredTxFee: true}}
+
+	var (
+		// counterCode increases the slot 0 by one, and emits the increased value with the caller as a topic.
+		counterCode = common.FromHex("0x600054600101806000556000523360206000a100")
+		// revertCode reverts without any data.
+		revertCode = common.FromHex("0x60006000fd")
+
+		counterA = common.HexToAddress("0x0a")
+		counterB = common.HexToAddress("0x0b")
+		reverter = common.HexToAddress("0x0c")
+
+		keys  = make([]*ecdsa.PrivateKey, 5)
+		addrs = make([]common.Address, 5)
+		alloc = GenesisAlloc{
+			counterA: {Code: counterCode, Balance: common.Big0},
+			counterB: {Code: counterCode, Balance: common.Big0},
+			reverter: {Code: revertCode, Balance: common.Big0},
+		}
+	)
+	for i := range keys {
+		keys[i], _ = crypto.GenerateKey()
+		addrs[i] = crypto.PubkeyToAddress(keys[i].PublicKey)
+		alloc[addrs[i]] = GenesisAccount{Balance: big.NewInt(params.KLAY)}
+	}
+	var (
+		gendb   = database.NewMemoryDBManager()
+		gspec   = &Genesis{Config: config, Alloc: alloc}
+		genesis = gspec.MustCommit(gendb)
+		signer  = types.LatestSignerForChainID(config.ChainID)
+	)
+	call := func(gen *BlockGen, from int, to common.Address) {
+		tx, err := types.SignTx(types.NewTransaction(gen.TxNonce(addrs[from]), to, common.Big0, 100000, big.NewInt(1), nil), signer, keys[from])
+		require.NoError(t, err)
+		gen.AddTx(tx)
+	}
+	chain, serialReceipts := GenerateChain(config, genesis, gxhash.NewFaker(), gendb, 3, func(i int, gen *BlockGen) {
+		call(gen, 0, counterA) // writes the storage of counterA
+		call(gen, 1, counterB) // independent of the preceding transaction
+		call(gen, 2, counterA) // conflicts with the first transaction writing counterA
+		call(gen, 3, reverter) // fails without conflicts
+		call(gen, 4, counterB) // conflicts with the second transaction writing counterB
+	})
+
+	executed, reexecuted := parallelTxExecutedMeter.Count(), parallelTxReexecutedMeter.Count()
+	root, receipts := insertChainWithWorkers(t, gspec, chain, 4)
+
+	// Both the merged and the re-executed transactions are in every block
+	assert.Less(t, executed, parallelTxExecutedMeter.Count())
+	assert.Less(t, reexecuted, parallelTxReexecutedMeter.Count())
+
+	// The storage written by the conflicting transactions and the logs are the same as the serial processing
+	assert.Equal(t, chain[len(chain)-1].Root(), root)
+	serialRoot, serialStored := insertChainWithWorkers(t, gspec, chain, 0)
+	assert.Equal(t, serialRoot, root)
+	assert.Equal(t, serialStored, receipts)
+
+	for i, block := range chain {
+		require.Len(t, receipts[i], block.Transactions().Len())
+		assert.Equal(t, types.DeriveSha(serialReceipts[i], block.Number()), types.DeriveSha(receipts[i], block.Number()))
+		assert.Equal(t, types.ReceiptStatusErrExecutionReverted, receipts[i][3].Status)
+
+		// The logs are indexed in the block in the order of the transactions, and
+		// each counter emits its value increased by the preceding transactions.
+		var logIndex uint
+		for j, receipt := range receipts[i] {
+			assert.Equal(t, serialReceipts[i][j].GasUsed, receipt.GasUsed)
+			assert.Equal(t, serialReceipts[i][j].Status, receipt.Status)
+			require.Len(t, receipt.Logs, len(serialReceipts[i][j].Logs))
+			for _, log := range receipt.Logs {
+				assert.Equal(t, uint(j), log.TxIndex)
+				assert.Equal(t, logIndex, log.Index)
+				logIndex++
+			}
+		}
+		counter := func(j int) uint64 {
+			return new(big.Int).SetBytes(receipts[i][j].Logs[0].Data).Uint64()
+		}
+		assert.Equal(t, uint64(2*i+1), counter(0))
+		assert.Equal(t, uint64(2*i+1), counter(1))
+		assert.Equal(t, uint64(2*i+2), counter(2))
+		assert.Equal(t, uint64(2*i+2), counter(4))
+		assert.Equal(t, addrs[2].Hash(), receipts[i][2].Logs[0].Topics[0])
+	}
+}
@@ -22,6 +22,7 @@ package blockchain
 
 import (
 	"errors"
+	"fmt"
 	"math/big"
 
 	"github.com/klaytn/klaytn/blockchain/types"
@@ -92,6 +93,9 @@ type Message interface {
 	// FromFrontier() (common.Address, error)
 	To() *common.Address
 
+	// IsContractCreation returns true if the message creates a contract with its data as the init code.
+	IsContractCreation() bool
+
 	Hash() common.Hash
 
 	GasPrice() *big.Int
@@ -109,6 +113,9 @@ type Message interface {
 	CheckNonce() bool
 	Data() []byte
 
+	// AccessList returns the EIP-2930 access list of the transaction.
+	AccessList() types.AccessList
+
 	// IntrinsicGas returns `intrinsic gas` based on the tx type.
 	// This value is used to differentiate tx fee based on the tx type.
 	IntrinsicGas(currentBlockNumber uint64) (uint64, error)
@@ -263,8 +270,20 @@ func (st *StateTransition) TransitionDb() (ret []byte, usedGas uint64, kerr kerr
 	}
 
 	rules := st.evm.ChainConfig().Rules(st.evm.Context.BlockNumber)
+	// Check whether the init code size has been exceeded.
+	if rules.IsShanghai && msg.IsContractCreation() && len(msg.Data()) > params.MaxInitCodeSize {
+		kerr.ErrTxInvalid = fmt.Errorf("%w: code size %v limit %v", ErrMaxInitCodeSizeExceeded, len(msg.Data()), params.MaxInitCodeSize)
+		kerr.Status = getReceiptStatusFromErrTxFailed(nil)
+		return nil, 0, kerr
+	}
 	if rules.IsKore {
-		st.state.PrepareAccessList(msg.ValidatedSender(), msg.ValidatedFeePayer(), msg.To(), vm.ActivePrecompiles(rules))
+		// The optional access list of the tx is warmed only after the access list fork,
+		// so that the blocks before it are executed with their original gas usage.
+		var accessList types.AccessList
+		if rules.IsAccessList {
+			accessList = msg.AccessList()
+		}
+		st.state.PrepareAccessList(msg.ValidatedSender(), msg.ValidatedFeePayer(), msg.To(), st.evm.ActivePrecompiles(), accessList)
 	}
 	// vm errors do not effect consensus and are therefor
 	// not assigned to err, except for insufficient balance
@@ -19,10 +19,17 @@ package blockchain
 import (
 	"errors"
 	"fmt"
+	"math/big"
 	"testing"
 
+	"github.com/klaytn/klaytn/blockchain/state"
 	"github.com/klaytn/klaytn/blockchain/types"
 	"github.com/klaytn/klaytn/blockchain/vm"
+	"github.com/klaytn/klaytn/common"
+	"github.com/klaytn/klaytn/params"
+	"github.com/klaytn/klaytn/storage/database"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 )
 
 func TestGetVMerrFromReceiptStatus(t *testing.T) {
@@ -82,3 +89,80 @@ func TestPrintErrorCodeTable(t *testing.T) {
 		}
 	}
 }
+
+// TestAccessListFork checks that the access list of a tx is warmed only after the access
+// list fork, so that the blocks before the fork keep their original gas usage.
+func TestAccessListFork(t *testing.T) {
+	var (
+		forkBlock = big.NewInt(10)
+		config    = &params.ChainConfig{
+			ChainID:                   big.NewInt(1),
+			IstanbulCompatibleBlock:   new(big.Int),
+			LondonCompatibleBlock:     new(big.Int),
+			EthTxTypeCompatibleBlock:  new(big.Int),
+			KoreCompatibleBlock:       new(big.Int),
+			AccessListCompatibleBlock: forkBlock,
+		}
+		from     = common.HexToAddress("0xaa")
+		contract = common.HexToAddress("0xbb")
+		slot     = common.HexToHash("0x01")
+		// PUSH1 0x01 SLOAD POP STOP
+		code       = []byte{byte(vm.PUSH1), 0x01, byte(vm.SLOAD), byte(vm.POP), byte(vm.STOP)}
+		accessList = types.AccessList{{Address: contract, StorageKeys: []common.Hash{slot}}}
+	)
+
+	usedGas := func(number *big.Int) uint64 {
+		statedb, _ := state.New(common.Hash{}, state.NewDatabase(database.NewMemoryDBManager()), nil)
+		statedb.SetCode(contract, code)
+
+		msg := types.NewMessage(from, &contract, 0, new(big.Int), 100000, new(big.Int), nil, false, params.TxGas, accessList)
+		header := &types.Header{Number: number, Time: new(big.Int), BlockScore: common.Big1}
+		evm := vm.NewEVM(NewEVMContext(msg, header, nil, &common.Address{}), statedb, config, &vm.Config{})
+
+		_, gas, kerr := ApplyMessage(evm, msg)
+		require.NoError(t, kerr.ErrTxInvalid)
+		require.Equal(t, types.ReceiptStatusSuccessful, kerr.Status)
+		return gas
+	}
+
+	beforeFork := usedGas(new(big.Int).Sub(forkBlock, common.Big1))
+	afterFork := usedGas(forkBlock)
+
+	// Before the fork, the listed slot is loaded cold as in the blocks already produced.
+	assert.Equal(t, params.ColdSloadCostEIP2929-params.WarmStorageReadCostEIP2929, beforeFork-afterFork)
+}
+
+// TestMaxInitCodeSize checks that a creation transaction with the init code bigger than
+// params.MaxInitCodeSize is invalid only after the shanghai hardfork.
+func TestMaxInitCodeSize(t *testing.T) {
+	var (
+		forkBlock = big.NewInt(10)
+		config    = &params.ChainConfig{
+			ChainID:                 big.NewInt(1),
+			IstanbulCompatibleBlock: new(big.Int),
+			LondonCompatibleBlock:   new(big.Int),
+			ShanghaiCompatibleBlock: forkBlock,
+		}
+		from     = common.HexToAddress("0xaa")
+		initCode = make([]byte, params.MaxInitCodeSize+1)
+	)
+
+	applyCreation := func(number *big.Int) kerror {
+		statedb, _ := state.New(common.Hash{}, state.NewDatabase(database.NewMemoryDBManager()), nil)
+
+		intrinsicGas, err := types.IntrinsicGas(initCode, nil, true, config.Rules(number))
+		require.NoError(t, err)
+		msg := types.NewMessage(from, nil, 0, new(big.Int), 10000000, new(big.Int), initCode, false, intrinsicGas, nil)
+		header := &types.Header{Number: number, Time: new(big.Int), BlockScore: common.Big1}
+		evm := vm.NewEVM(NewEVMContext(msg, header, nil, &common.Address{}), statedb, config, &vm.Config{})
+
+		_, _, kerr := ApplyMessage(evm, msg)
+		return kerr
+	}
+
+	kerr := applyCreation(new(big.Int).Sub(forkBlock, common.Big1))
+	assert.NoError(t, kerr.ErrTxInvalid)
+
+	kerr = applyCreation(forkBlock)
+	assert.True(t, errors.Is(kerr.ErrTxInvalid, ErrMaxInitCodeSizeExceeded), kerr.ErrTxInvalid)
+}
@@ -98,7 +98,12 @@ func (journal *txJournal) load(add func([]*types.Transaction) []error) error {
 		// Parse the next transaction and terminate on error
 		tx := new(types.Transaction)
 		if err = stream.Decode(tx); err != nil {
-			if err != io.EOF {
+			switch err {
+			case io.EOF:
+			case io.ErrUnexpectedEOF:
+				// The last transaction was partially written if the node crashed, so drop it only
+				logger.Warn("Dropped truncated transaction at the end of the journal", "loaded", total)
+			default:
 				failure = err
 			}
 			if batch.Len() > 0 {
@@ -156,7 +161,11 @@ func (journal *txJournal) rotate(all map[common.Address]types.Transactions, sign
 		journaled++
 		txSetByTime.Shift()
 	}
-
+	// Make sure the replacement is on the disk before renaming it, not to lose the journal by a crash
+	if err = replacement.Sync(); err != nil {
+		replacement.Close()
+		return err
+	}
 	replacement.Close()
 
 	// Replace the live journal with the newly generated one
@@ -316,9 +316,12 @@ func (l *txList) Add(tx *types.Transaction, priceBump uint64, magmaHardforked bo
 		if tx.Type().IsCancelTransaction() {
 			logger.Trace("New tx is a cancel transaction. replace it!", "old", old.String(), "new", tx.String())
 		} else if magmaHardforked {
-			if old.GasPrice().Cmp(tx.GasPrice()) >= 0 {
-				// If gas price of older is bigger than newer, abort.
-				logger.Trace("already nonce exist and the gasprice is lower then older", "nonce", tx.Nonce(), "with gasprice", old.GasPrice(), "priceBump", priceBump, "new tx.gasprice", tx.GasPrice())
+			// The gas price of newer must be higher than older by the price bump percentage.
+			threshold := new(big.Int).Mul(old.GasPrice(), big.NewInt(100+int64(priceBump)))
+			threshold.Div(threshold, big.NewInt(100))
+			if old.GasPrice().Cmp(tx.GasPrice()) >= 0 || tx.GasPrice().Cmp(threshold) < 0 {
+				// If gas price of older is bigger than newer, or not bumped enough, abort.
+				logger.Trace("already nonce exist and the gasprice is not bumped enough", "nonce", tx.Nonce(), "with gasprice", old.GasPrice(), "priceBump", priceBump, "new tx.gasprice", tx.GasPrice())
 				return false, nil
 			}
 			// Otherwise overwrite the old transaction with the current one.
@@ -428,6 +431,23 @@ func (l *txList) Cap(threshold int) types.Transactions {
 	return l.txs.Cap(threshold)
 }
 
+// Gapped removes and returns all the transactions behind the first nonce gap
+// of the list starting at the provided nonce. The transactions are returned
+// all if there is no transaction of the starting nonce.
+func (l *txList) Gapped(start uint64) types.Transactions {
+	next := start
+	for _, tx := range l.Flatten() {
+		if tx.Nonce() < next {
+			continue
+		}
+		if tx.Nonce() > next {
+			return l.txs.Filter(func(tx *types.Transaction) bool { return tx.Nonce() >= next })
+		}
+		next++
+	}
+	return nil
+}
+
 // Remove deletes a transaction from the maintained list, returning whether the
 // transaction was found, and also returning any transaction invalidated due to
 // the deletion (strict mode only).
@@ -203,3 +203,26 @@ func TestSubstituteTransactionAbort(t *testing.T) {
 		t.Error("Expected to not substitute by a tx with lower gas price")
 	}
 }
+
+// TestTxListGapped checks whether Gapped() removes the transactions behind the first nonce gap.
+func TestTxListGapped(t *testing.T) {
+	key, _ := crypto.GenerateKey()
+
+	list := newTxList(true)
+	for _, nonce := range []uint64{3, 4, 5, 7, 8} {
+		list.Add(transaction(nonce, 0, key), DefaultTxPoolConfig.PriceBump, false)
+	}
+
+	// The transactions after the gap are removed, and nothing is removed once the list is continuous
+	gapped := list.Gapped(3)
+	assert.Equal(t, 2, len(gapped))
+	for _, tx := range gapped {
+		assert.True(t, tx.Nonce() >= 7)
+	}
+	assert.Equal(t, 3, list.Len())
+	assert.Nil(t, list.Gapped(3))
+
+	// All transactions are removed if the starting nonce is missing
+	assert.Equal(t, 3, len(list.Gapped(2)))
+	assert.True(t, list.Empty())
+}
@@ -100,6 +100,15 @@ type blockChain interface {
 	SubscribeChainHeadEvent(ch chan<- ChainHeadEvent) event.Subscription
 }
 
+// Reasons of DroppedTxsEvent.
+const (
+	DropReasonReplaced     = "replaced"     // replaced by a transaction with the same nonce
+	DropReasonExpired      = "expired"      // queued longer than the lifetime
+	DropReasonUnderpriced  = "underpriced"  // evicted by a higher priced transaction while the pool is full
+	DropReasonOverflow     = "overflow"     // exceeding the slots of the account or the pool
+	DropReasonUnexecutable = "unexecutable" // not executable by the balance or the gas limit anymore
+)
+
 // TxPoolConfig are the configuration parameters of the transaction pool.
 type TxPoolConfig struct {
 	NoLocals           bool          // Whether local transaction handling should be disabled
@@ -173,6 +182,7 @@ type TxPool struct {
 	chain        blockChain
 	gasPrice     *big.Int
 	txFeed       event.Feed
+	dropFeed     event.Feed
 	scope        event.SubscriptionScope
 	chainHeadCh  chan ChainHeadEvent
 	chainHeadSub event.Subscription
@@ -202,6 +212,8 @@ type TxPool struct {
 	eip2718 bool // Fork indicator whether we are using EIP-2718 type transactions.
 	eip1559 bool // Fork indicator whether we are using EIP-1559 type transactions.
 	magma   bool // Fork indicator whether we are using Magma type transactions.
+
+	shanghai bool // Fork indicator whether the init code size of creation transactions is limited.
 }
 
 // NewTxPool creates a new transaction pool to gather, sort and filter inbound
@@ -328,9 +340,11 @@ func (pool *TxPool) loop() {
 				// Any non-locals old enough should be removed
 				if time.Since(beat) > pool.config.Lifetime {
 					if pool.queue[addr] != nil {
-						for _, tx := range pool.queue[addr].Flatten() {
+						expired := pool.queue[addr].Flatten()
+						for _, tx := range expired {
 							pool.removeTx(tx.Hash(), true)
 						}
+						pool.notifyDropped(DropReasonExpired, nil, expired...)
 					}
 					delete(pool.beats, addr)
 				}
@@ -477,6 +491,8 @@ func (pool *TxPool) reset(oldHead, newHead *types.Header) {
 	pool.eip1559 = pool.chainconfig.IsEthTxTypeForkEnabled(next)
 	// Enable dynamic base fee
 	pool.magma = pool.chainconfig.IsMagmaForkEnabled(next)
+	// Limit the init code size
+	pool.shanghai = pool.chainconfig.IsShanghaiForkEnabled(next)
 
 	// It need to update gas price of tx pool after magma hardfork
 	if pool.magma {
@@ -507,6 +523,20 @@ func (pool *TxPool) SubscribeNewTxsEvent(ch chan<- NewTxsEvent) event.Subscripti
 	return pool.scope.Track(pool.txFeed.Subscribe(ch))
 }
 
+// SubscribeDroppedTxsEvent registers a subscription of DroppedTxsEvent and
+// starts sending event to the given channel.
+func (pool *TxPool) SubscribeDroppedTxsEvent(ch chan<- DroppedTxsEvent) event.Subscription {
+	return pool.scope.Track(pool.dropFeed.Subscribe(ch))
+}
+
+// notifyDropped posts a DroppedTxsEvent of the given transactions if any.
+// The transactions removed because their nonces are used by the new blocks are not notified.
+func (pool *TxPool) notifyDropped(reason string, replacement *types.Transaction, txs ...*types.Transaction) {
+	if len(txs) > 0 {
+		go pool.dropFeed.Send(DroppedTxsEvent{Txs: txs, Reason: reason, Replacement: replacement})
+	}
+}
+
 // GasPrice returns the current gas price enforced by the transaction pool.
 func (pool *TxPool) GasPrice() *big.Int {
 	pool.mu.RLock()
@@ -539,6 +569,56 @@ func (pool *TxPool) SetGasPrice(price *big.Int) {
 	}
 }
 
+// TxPoolLimits contains the limits of the transaction pool which can be tuned at runtime.
+type TxPoolLimits struct {
+	ExecSlotsAccount    uint64 `json:"exec_slots_account"`
+	ExecSlotsAll        uint64 `json:"exec_slots_all"`
+	NonExecSlotsAccount uint64 `json:"non_exec_slots_account"`
+	NonExecSlotsAll     uint64 `json:"non_exec_slots_all"`
+	LifetimeSeconds     uint64 `json:"lifetime_seconds"`
+}
+
+// Limits returns the current limits of the transaction pool.
+func (pool *TxPool) Limits() TxPoolLimits {
+	pool.mu.RLock()
+	defer pool.mu.RUnlock()
+
+	return TxPoolLimits{
+		ExecSlotsAccount:    pool.config.ExecSlotsAccount,
+		ExecSlotsAll:        pool.config.ExecSlotsAll,
+		NonExecSlotsAccount: pool.config.NonExecSlotsAccount,
+		NonExecSlotsAll:     pool.config.NonExecSlotsAll,
+		LifetimeSeconds:     uint64(pool.config.Lifetime / time.Second),
+	}
+}
+
+// SetLimits updates the limits of the transaction pool. The fields of zero value are left unchanged.
+// If the limits are tightened, the transactions exceeding them are dropped right away.
+func (pool *TxPool) SetLimits(limits TxPoolLimits) {
+	pool.mu.Lock()
+	defer pool.mu.Unlock()
+
+	if limits.ExecSlotsAccount != 0 {
+		pool.config.ExecSlotsAccount = limits.ExecSlotsAccount
+	}
+	if limits.ExecSlotsAll != 0 {
+		pool.config.ExecSlotsAll = limits.ExecSlotsAll
+	}
+	if limits.NonExecSlotsAccount != 0 {
+		pool.config.NonExecSlotsAccount = limits.NonExecSlotsAccount
+	}
+	if limits.NonExecSlotsAll != 0 {
+		pool.config.NonExecSlotsAll = limits.NonExecSlotsAll
+	}
+	if limits.LifetimeSeconds != 0 {
+		pool.config.Lifetime = time.Duration(limits.LifetimeSeconds) * time.Second
+	}
+	logger.Info("TxPool.SetLimits", "execSlotsAccount", pool.config.ExecSlotsAccount, "execSlotsAll", pool.config.ExecSlotsAll,
+		"nonExecSlotsAccount", pool.config.NonExecSlotsAccount, "nonExecSlotsAll", pool.config.NonExecSlotsAll, "lifetime", pool.config.Lifetime)
+
+	pool.promoteExecutables(nil)
+}
+
 // Stats retrieves the current pool stats, namely the number of pending and the
 // number of queued (non-executable) transactions.
 func (pool *TxPool) Stats() (int, int) {
@@ -581,6 +661,25 @@ func (pool *TxPool) Content() (map[common.Address]types.Transactions, map[common
 	return pending, queued
 }
 
+// ContentFrom retrieves the data content of the transaction pool, returning the
+// pending as well as queued transactions of this address, grouped by nonce.
+func (pool *TxPool) ContentFrom(addr common.Address) (types.Transactions, types.Transactions) {
+	pool.mu.Lock()
+	defer pool.mu.Unlock()
+	pool.txMu.Lock()
+	defer pool.txMu.Unlock()
+
+	var pending types.Transactions
+	if list, ok := pool.pending[addr]; ok {
+		pending = list.Flatten()
+	}
+	var queued types.Transactions
+	if list, ok := pool.queue[addr]; ok {
+		queued = list.Flatten()
+	}
+	return pending, queued
+}
+
 // Pending retrieves all currently processable transactions, groupped by origin
 // account and sorted by nonce. The returned transaction set is a copy and can be
 // freely modified by calling code.
@@ -726,6 +825,11 @@ func (pool *TxPool) validateTx(tx *types.Transaction) error {
 		}
 	}
 
+	// Check whether the init code size has been exceeded.
+	if pool.shanghai && tx.IsContractCreation() && len(tx.Data()) > params.MaxInitCodeSize {
+		return fmt.Errorf("%w: code size %v limit %v", ErrMaxInitCodeSizeExceeded, len(tx.Data()), params.MaxInitCodeSize)
+	}
+
 	// Reject transactions over MaxTxDataSize to prevent DOS attacks
 	if uint64(tx.Size()) > MaxTxDataSize {
 		return ErrOversizedData
@@ -883,6 +987,7 @@ func (pool *TxPool) add(tx *types.Transaction, local bool) (bool, error) {
 		if maxTx != tx {
 			// (2) remove an old Tx with the largest nonce from queue to make a room for a new Tx with missing nonce
 			pool.removeTx(maxTx.Hash(), true)
+			pool.notifyDropped(DropReasonOverflow, nil, maxTx)
 			logger.Trace("Removing an old Tx with the max nonce to insert a new Tx with missing nonce, because TxPool is full", "account", from, "new nonce(previously missing)", tx.Nonce(), "removed max nonce", maxTx.Nonce())
 		} else {
 			// (3) discard a new Tx if the new Tx does not have a missing nonce
@@ -905,6 +1010,7 @@ func (pool *TxPool) add(tx *types.Transaction, local bool) (bool, error) {
 			underpricedTxCounter.Inc(1)
 			pool.removeTx(tx.Hash(), false)
 		}
+		pool.notifyDropped(DropReasonUnderpriced, nil, drop...)
 	}
 	// If the transaction is replacing an already pending one, do directly
 	from, _ := types.Sender(pool.signer, tx) // already validated
@@ -913,13 +1019,14 @@ func (pool *TxPool) add(tx *types.Transaction, local bool) (bool, error) {
 		inserted, old := list.Add(tx, pool.config.PriceBump, pool.magma)
 		if !inserted {
 			pendingDiscardCounter.Inc(1)
-			return false, ErrAlreadyNonceExistInPool
+			return false, pool.nonceExistError()
 		}
 		// New transaction is better, replace old one
 		if old != nil {
 			pool.all.Remove(old.Hash())
 			pool.priced.Removed()
 			pendingReplaceCounter.Inc(1)
+			pool.notifyDropped(DropReasonReplaced, tx, old)
 		}
 		pool.all.Add(tx)
 		pool.priced.Put(tx)
@@ -947,6 +1054,15 @@ func (pool *TxPool) add(tx *types.Transaction, local bool) (bool, error) {
 	return replace, nil
 }
 
+// nonceExistError returns the error of a transaction failing to replace the one with the same nonce.
+// Since the Magma hardfork, a transaction can be replaced by one priced higher by the price bump.
+func (pool *TxPool) nonceExistError() error {
+	if pool.magma {
+		return ErrReplaceUnderpriced
+	}
+	return ErrAlreadyNonceExistInPool
+}
+
 // enqueueTx inserts a new transaction into the non-executable transaction queue.
 //
 // Note, this method assumes the pool lock is held!
@@ -960,13 +1076,14 @@ func (pool *TxPool) enqueueTx(hash common.Hash, tx *types.Transaction) (bool, er
 	if !inserted {
 		// An older transaction was better, discard this
 		queuedDiscardCounter.Inc(1)
-		return false, ErrAlreadyNonceExistInPool
+		return false, pool.nonceExistError()
 	}
 	// Discard any previous transaction and mark this
 	if old != nil {
 		pool.all.Remove(old.Hash())
 		pool.priced.Removed()
 		queuedReplaceCounter.Inc(1)
+		pool.notifyDropped(DropReasonReplaced, tx, old)
 	}
 	if pool.all.Get(hash) == nil {
 		pool.all.Add(tx)
@@ -1007,6 +1124,7 @@ func (pool *TxPool) promoteTx(addr common.Address, hash common.Hash, tx *types.T
 		pool.priced.Removed()
 
 		pendingDiscardCounter.Inc(1)
+		pool.notifyDropped(DropReasonReplaced, list.txs.Get(tx.Nonce()), tx)
 		return false
 	}
 	// Otherwise discard any previous transaction and mark this
@@ -1015,6 +1133,7 @@ func (pool *TxPool) promoteTx(addr common.Address, hash common.Hash, tx *types.T
 		pool.priced.Removed()
 
 		pendingReplaceCounter.Inc(1)
+		pool.notifyDropped(DropReasonReplaced, tx, old)
 	}
 	// Failsafe to work around direct pending inserts (tests)
 	if pool.all.Get(hash) == nil {
@@ -1392,6 +1511,7 @@ func (pool *TxPool) promoteExecutables(accounts []common.Address) {
 			pool.priced.Removed()
 			queuedNofundsCounter.Inc(1)
 		}
+		pool.notifyDropped(DropReasonUnexecutable, nil, drops...)
 
 		// Gather all executable transactions and promote them
 		var readyTxs types.Transactions
@@ -1410,13 +1530,15 @@ func (pool *TxPool) promoteExecutables(accounts []common.Address) {
 
 		// Drop all transactions over the allowed limit
 		if !pool.locals.contains(addr) {
-			for _, tx := range list.Cap(int(pool.config.NonExecSlotsAccount)) {
+			caps := list.Cap(int(pool.config.NonExecSlotsAccount))
+			for _, tx := range caps {
 				hash := tx.Hash()
 				pool.all.Remove(hash)
 				pool.priced.Removed()
 				queuedRateLimitCounter.Inc(1)
 				logger.Trace("Removed cap-exceeding queued transaction", "hash", hash)
 			}
+			pool.notifyDropped(DropReasonOverflow, nil, caps...)
 		}
 		// Delete the entire queue entry if it became empty.
 		if list.Empty() {
@@ -1459,7 +1581,8 @@ func (pool *TxPool) promoteExecutables(accounts []common.Address) {
 				for pending > pool.config.ExecSlotsAll && pool.pending[offenders[len(offenders)-2]].Len() > threshold {
 					for i := 0; i < len(offenders)-1; i++ {
 						list := pool.pending[offenders[i]]
-						for _, tx := range list.Cap(list.Len() - 1) {
+						caps := list.Cap(list.Len() - 1)
+						for _, tx := range caps {
 							// Drop the transaction from the global pools too
 							hash := tx.Hash()
 							pool.all.Remove(hash)
@@ -1469,6 +1592,7 @@ func (pool *TxPool) promoteExecutables(accounts []common.Address) {
 							pool.updatePendingNonce(offenders[i], tx.Nonce())
 							logger.Trace("Removed fairness-exceeding pending transaction", "hash", hash)
 						}
+						pool.notifyDropped(DropReasonOverflow, nil, caps...)
 						pending--
 					}
 				}
@@ -1479,7 +1603,8 @@ func (pool *TxPool) promoteExecutables(accounts []common.Address) {
 			for pending > pool.config.ExecSlotsAll && uint64(pool.pending[offenders[len(offenders)-1]].Len()) > pool.config.ExecSlotsAccount {
 				for _, addr := range offenders {
 					list := pool.pending[addr]
-					for _, tx := range list.Cap(list.Len() - 1) {
+					caps := list.Cap(list.Len() - 1)
+					for _, tx := range caps {
 						// Drop the transaction from the global pools too
 						hash := tx.Hash()
 						pool.all.Remove(hash)
@@ -1489,6 +1614,7 @@ func (pool *TxPool) promoteExecutables(accounts []common.Address) {
 						pool.updatePendingNonce(addr, tx.Nonce())
 						logger.Trace("Removed fairness-exceeding pending transaction", "hash", hash)
 					}
+					pool.notifyDropped(DropReasonOverflow, nil, caps...)
 					pending--
 				}
 			}
@@ -1520,20 +1646,24 @@ func (pool *TxPool) promoteExecutables(accounts []common.Address) {
 
 			// Drop all transactions if they are less than the overflow
 			if size := uint64(list.Len()); size <= drop {
-				for _, tx := range list.Flatten() {
+				txs := list.Flatten()
+				for _, tx := range txs {
 					pool.removeTx(tx.Hash(), true)
 				}
+				pool.notifyDropped(DropReasonOverflow, nil, txs...)
 				drop -= size
 				queuedRateLimitCounter.Inc(int64(size))
 				continue
 			}
 			// Otherwise drop only last few transactions
 			txs := list.Flatten()
-			for i := len(txs) - 1; i >= 0 && drop > 0; i-- {
+			i := len(txs) - 1
+			for ; i >= 0 && drop > 0; i-- {
 				pool.removeTx(txs[i].Hash(), true)
 				drop--
 				queuedRateLimitCounter.Inc(1)
 			}
+			pool.notifyDropped(DropReasonOverflow, nil, txs[i+1:]...)
 		}
 	}
 }
@@ -1577,19 +1707,19 @@ func (pool *TxPool) demoteUnexecutables() {
 			pool.priced.Removed()
 			pendingNofundsCounter.Inc(1)
 		}
+		pool.notifyDropped(DropReasonUnexecutable, nil, drops...)
 
 		for _, tx := range invalids {
 			hash := tx.Hash()
 			logger.Trace("Demoting pending transaction", "hash", hash)
 			pool.enqueueTx(hash, tx)
 		}
-		// If there's a gap in front, warn (should never happen) and postpone all transactions
-		if list.Len() > 0 && list.txs.Get(nonce) == nil {
-			for _, tx := range list.Cap(0) {
-				hash := tx.Hash()
-				logger.Error("Demoting invalidated transaction", "hash", hash)
-				pool.enqueueTx(hash, tx)
-			}
+		// If there's a nonce gap, warn (should never happen) and postpone all transactions behind it,
+		// so that the pending transactions are always executable in a row.
+		for _, tx := range list.Gapped(nonce) {
+			hash := tx.Hash()
+			logger.Error("Demoting invalidated transaction", "hash", hash)
+			pool.enqueueTx(hash, tx)
 		}
 
 		// Enqueue transaction if gasPrice of transaction is lower than gasPrice of txPool.
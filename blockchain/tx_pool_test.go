@@ -22,6 +22,7 @@ package blockchain
 
 import (
 	"crypto/ecdsa"
+	"errors"
 	"fmt"
 	"io"
 	"io/ioutil"
@@ -444,6 +445,32 @@ func TestInvalidTransactionsMagma(t *testing.T) {
 	}
 }
 
+// TestInvalidTransactionsShanghai checks that the pool rejects a creation transaction with
+// the init code bigger than params.MaxInitCodeSize after the shanghai hardfork.
+func TestInvalidTransactionsShanghai(t *testing.T) {
+	t.Parallel()
+
+	config := params.TestChainConfig.Copy()
+	config.ShanghaiCompatibleBlock = common.Big0
+	pool, key := setupTxPoolWithConfig(config)
+	defer pool.Stop()
+
+	tx, _ := types.SignTx(types.NewContractCreation(0, new(big.Int), 10000000, big.NewInt(1), make([]byte, params.MaxInitCodeSize+1)),
+		types.LatestSignerForChainID(params.TestChainConfig.ChainID), key)
+	from, _ := deriveSender(tx)
+	testAddBalance(pool, from, tx.Cost())
+
+	if err := pool.AddRemote(tx); !errors.Is(err, ErrMaxInitCodeSizeExceeded) {
+		t.Error("expected", ErrMaxInitCodeSizeExceeded, "got", err)
+	}
+	// A call with the same data is limited only by the size of the transaction
+	tx, _ = types.SignTx(types.NewTransaction(0, common.HexToAddress("0xAAAA"), new(big.Int), 10000000, big.NewInt(1), make([]byte, params.MaxInitCodeSize+1)),
+		types.LatestSignerForChainID(params.TestChainConfig.ChainID), key)
+	if err := pool.AddRemote(tx); err != ErrOversizedData {
+		t.Error("expected", ErrOversizedData, "got", err)
+	}
+}
+
 func genAnchorTx(nonce uint64) *types.Transaction {
 	key, _ := crypto.HexToECDSA("45a915e4d060149eb4365960e6a7a45f334393093061116b197e3240065ff2d8")
 	from := crypto.PubkeyToAddress(key.PublicKey)
@@ -979,6 +1006,41 @@ func TestTransactionQueueAccountLimiting(t *testing.T) {
 	}
 }
 
+// Tests that the limits tightened at runtime are enforced on the transactions already in the pool.
+func TestTransactionSetLimits(t *testing.T) {
+	t.Parallel()
+
+	pool, key := setupTxPool()
+	defer pool.Stop()
+
+	account := crypto.PubkeyToAddress(key.PublicKey)
+	testAddBalance(pool, account, big.NewInt(1000000))
+
+	for i := uint64(1); i <= testTxPoolConfig.NonExecSlotsAccount; i++ {
+		if err := pool.AddRemote(transaction(i, 100000, key)); err != nil {
+			t.Fatalf("tx %d: failed to add transaction: %v", i, err)
+		}
+	}
+	assert.Equal(t, int(testTxPoolConfig.NonExecSlotsAccount), pool.queue[account].Len())
+
+	pool.SetLimits(TxPoolLimits{NonExecSlotsAccount: 10, LifetimeSeconds: 60})
+	assert.Equal(t, TxPoolLimits{
+		ExecSlotsAccount:    testTxPoolConfig.ExecSlotsAccount,
+		ExecSlotsAll:        testTxPoolConfig.ExecSlotsAll,
+		NonExecSlotsAccount: 10,
+		NonExecSlotsAll:     testTxPoolConfig.NonExecSlotsAll,
+		LifetimeSeconds:     60,
+	}, pool.Limits())
+	assert.Equal(t, 10, pool.queue[account].Len())
+	assert.Equal(t, 10, pool.all.Count())
+
+	// The new transactions beyond the tightened limit are not queued either
+	if err := pool.AddRemote(transaction(11, 100000, key)); err != nil {
+		t.Fatalf("failed to add transaction: %v", err)
+	}
+	assert.Equal(t, 10, pool.queue[account].Len())
+}
+
 // Tests that if the transaction count belonging to multiple accounts go above
 // some threshold, the higher transactions are dropped to prevent DOS attacks.
 //
@@ -1757,19 +1819,15 @@ func TestTransactionPoolStableUnderpricing(t *testing.T) {
 }
 */
 
-// NOTE-Klaytn Disable this test, because we don't have a replacement rule.
 // Tests that the pool rejects replacement transactions that don't meet the minimum
-// price bump required.
-/*
+// price bump required. Transactions can be replaced since the Magma hardfork.
 func TestTransactionReplacement(t *testing.T) {
 	t.Parallel()
 
 	// Create the pool to test the pricing enforcement with
-	statedb, _ := state.New(common.Hash{}, state.NewDatabase(database.NewMemDB()))
-	blockchain := &testBlockChain{statedb, 1000000, new(event.Feed)}
-
-	pool := NewTxPool(testTxPoolConfig, params.TestChainConfig, blockchain)
+	pool, _ := setupTxPoolWithConfig(kip71Config)
 	defer pool.Stop()
+	pool.SetBaseFee(big.NewInt(1))
 
 	// Keep track of transaction events to ensure all executables get announced
 	events := make(chan NewTxsEvent, 32)
@@ -1778,7 +1836,7 @@ func TestTransactionReplacement(t *testing.T) {
 
 	// Create a test account to add transactions with
 	key, _ := crypto.GenerateKey()
-	pool.currentState.AddBalance(crypto.PubkeyToAddress(key.PublicKey), big.NewInt(1000000000))
+	testAddBalance(pool, crypto.PubkeyToAddress(key.PublicKey), big.NewInt(1000000000))
 
 	// Add pending transactions, ensuring the minimum price bump is enforced for replacement (for ultra low prices too)
 	price := int64(100)
@@ -1837,7 +1895,89 @@ func TestTransactionReplacement(t *testing.T) {
 		t.Fatalf("pool internal state corrupted: %v", err)
 	}
 }
-*/
+
+// Tests that the replaced transactions are announced with their replacements.
+func TestTransactionReplacementDroppedEvent(t *testing.T) {
+	t.Parallel()
+
+	pool, _ := setupTxPoolWithConfig(kip71Config)
+	defer pool.Stop()
+	pool.SetBaseFee(big.NewInt(1))
+
+	dropped := make(chan DroppedTxsEvent, 1)
+	sub := pool.SubscribeDroppedTxsEvent(dropped)
+	defer sub.Unsubscribe()
+
+	key, _ := crypto.GenerateKey()
+	testAddBalance(pool, crypto.PubkeyToAddress(key.PublicKey), big.NewInt(1000000000))
+
+	original := pricedTransaction(0, 100000, big.NewInt(100), key)
+	replacement := pricedTransaction(0, 100000, big.NewInt(200), key)
+	if err := pool.AddRemote(original); err != nil {
+		t.Fatalf("failed to add original pending transaction: %v", err)
+	}
+	if err := pool.AddRemote(replacement); err != nil {
+		t.Fatalf("failed to replace original pending transaction: %v", err)
+	}
+	select {
+	case ev := <-dropped:
+		assert.Equal(t, DropReasonReplaced, ev.Reason)
+		assert.Equal(t, replacement.Hash(), ev.Replacement.Hash())
+		if assert.Len(t, ev.Txs, 1) {
+			assert.Equal(t, original.Hash(), ev.Txs[0].Hash())
+		}
+	case <-time.After(time.Second):
+		t.Fatal("dropped transaction event not received")
+	}
+}
+
+// Tests that the transactions in the journal are recovered even if the last one
+// was partially written by a crash.
+func TestTransactionJournalTruncated(t *testing.T) {
+	t.Parallel()
+
+	file, err := ioutil.TempFile("", "")
+	if err != nil {
+		t.Fatalf("failed to create temporary journal: %v", err)
+	}
+	path := file.Name()
+	file.Close()
+	defer os.Remove(path)
+
+	key, _ := crypto.GenerateKey()
+	signer := types.LatestSignerForChainID(params.TestChainConfig.ChainID)
+	txs := types.Transactions{transaction(0, 100000, key), transaction(1, 100000, key)}
+
+	journal := newTxJournal(path)
+	if err := journal.rotate(map[common.Address]types.Transactions{crypto.PubkeyToAddress(key.PublicKey): txs}, signer); err != nil {
+		t.Fatalf("failed to rotate journal: %v", err)
+	}
+	// Write only a part of the next transaction as if the node crashed
+	enc, _ := rlp.EncodeToBytes(transaction(2, 100000, key))
+	if _, err := journal.writer.Write(enc[:len(enc)/2]); err != nil {
+		t.Fatalf("failed to write journal: %v", err)
+	}
+	if err := journal.close(); err != nil {
+		t.Fatalf("failed to close journal: %v", err)
+	}
+
+	var loaded types.Transactions
+	err = newTxJournal(path).load(func(batch []*types.Transaction) []error {
+		loaded = append(loaded, batch...)
+		return make([]error, len(batch))
+	})
+	if err != nil {
+		t.Fatalf("failed to load truncated journal: %v", err)
+	}
+	if len(loaded) != len(txs) {
+		t.Fatalf("loaded transaction count mismatch: have %d, want %d", len(loaded), len(txs))
+	}
+	for i, tx := range loaded {
+		if tx.Hash() != txs[i].Hash() {
+			t.Errorf("transaction %d mismatch: have %x, want %x", i, tx.Hash(), txs[i].Hash())
+		}
+	}
+}
 
 // Tests that local transactions are journaled to disk, but remote transactions
 // get discarded between restarts.
@@ -2734,3 +2874,34 @@ func benchmarkPoolBatchInsert(b *testing.B, size int) {
 		pool.AddRemotes(batch)
 	}
 }
+
+// Tests that the content of a single account is retrieved from both the pending and the queue.
+func TestTransactionContentFrom(t *testing.T) {
+	t.Parallel()
+
+	pool, key := setupTxPool()
+	defer pool.Stop()
+
+	account := crypto.PubkeyToAddress(key.PublicKey)
+	testAddBalance(pool, account, big.NewInt(1000000))
+
+	// Nonces 0 and 1 are executable, but nonce 3 is queued behind the gap
+	for _, nonce := range []uint64{0, 1, 3} {
+		if err := pool.AddRemote(transaction(nonce, 100000, key)); err != nil {
+			t.Fatalf("tx %d: failed to add transaction: %v", nonce, err)
+		}
+	}
+	pending, queued := pool.ContentFrom(account)
+	if len(pending) != 2 || pending[0].Nonce() != 0 || pending[1].Nonce() != 1 {
+		t.Errorf("pending transactions mismatch: have %v", pending)
+	}
+	if len(queued) != 1 || queued[0].Nonce() != 3 {
+		t.Errorf("queued transactions mismatch: have %v", queued)
+	}
+
+	other, _ := crypto.GenerateKey()
+	pending, queued = pool.ContentFrom(crypto.PubkeyToAddress(other.PublicKey))
+	if len(pending) != 0 || len(queued) != 0 {
+		t.Errorf("unknown account has transactions: pending %d, queued %d", len(pending), len(queued))
+	}
+}
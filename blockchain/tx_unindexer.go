@@ -0,0 +1,115 @@
+// Copyright 2022 The klaytn Authors
+// This file is part of the klaytn library.
+//
+// The klaytn library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The klaytn library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the klaytn library. If not, see <http://www.gnu.org/licenses/>.
+
+package blockchain
+
+import (
+	"time"
+
+	"github.com/klaytn/klaytn/common"
+)
+
+// txUnindexLogInterval is the interval of logging the progress of unindexing transactions.
+const txUnindexLogInterval = 8 * time.Second
+
+// maintainTxIndex keeps only the transactions of the recent TxLookupLimit blocks indexed by their hashes.
+// Whenever the chain head advances, the transactions of the blocks behind the limit are unindexed in
+// the background. The unindexed blocks are not indexed again even if the limit is raised later.
+func (bc *BlockChain) maintainTxIndex() {
+	defer bc.wg.Done()
+
+	headCh := make(chan ChainHeadEvent, 1)
+	sub := bc.SubscribeChainHeadEvent(headCh)
+	defer sub.Unsubscribe()
+
+	var (
+		done    = make(chan struct{})
+		running bool
+	)
+	run := func(head uint64) {
+		running = true
+		go func() {
+			bc.unindexTransactions(head)
+			done <- struct{}{}
+		}()
+	}
+	run(bc.CurrentBlock().NumberU64())
+
+	for {
+		select {
+		case ev := <-headCh:
+			if !running {
+				run(ev.Block.NumberU64())
+			}
+		case <-done:
+			running = false
+		case <-sub.Err():
+			if running {
+				<-done
+			}
+			return
+		case <-bc.quit:
+			if running {
+				<-done
+			}
+			return
+		}
+	}
+}
+
+// unindexTransactions removes the lookup entries of the transactions of the blocks from the current
+// tail to the block TxLookupLimit blocks behind the given head, and advances the tail.
+func (bc *BlockChain) unindexTransactions(head uint64) {
+	limit := bc.cacheConfig.TxLookupLimit
+	if head < limit {
+		return
+	}
+	tail := uint64(0)
+	if stored := bc.db.ReadTxIndexTail(); stored != nil {
+		tail = *stored
+	}
+	newTail := head - limit + 1
+	if tail >= newTail {
+		return
+	}
+
+	var (
+		start  = time.Now()
+		logged = time.Now()
+		txs    int
+	)
+	for number := tail; number < newTail; number++ {
+		select {
+		case <-bc.quit:
+			bc.db.WriteTxIndexTail(number)
+			logger.Info("Transaction unindexing interrupted", "tail", number, "txs", txs, "elapsed", common.PrettyDuration(time.Since(start)))
+			return
+		default:
+		}
+		hash := bc.db.ReadCanonicalHash(number)
+		if block := bc.db.ReadBlock(hash, number); block != nil {
+			bc.db.DeleteTxLookupEntries(block)
+			txs += block.Transactions().Len()
+		}
+		if time.Since(logged) > txUnindexLogInterval {
+			bc.db.WriteTxIndexTail(number + 1)
+			logger.Info("Unindexing transactions", "block", number, "target", newTail, "txs", txs, "elapsed", common.PrettyDuration(time.Since(start)))
+			logged = time.Now()
+		}
+	}
+	bc.db.WriteTxIndexTail(newTail)
+	logger.Debug("Unindexed transactions", "from", tail, "to", newTail-1, "txs", txs, "elapsed", common.PrettyDuration(time.Since(start)))
+}
@@ -0,0 +1,77 @@
+// Copyright 2022 The klaytn Authors
+// This file is part of the klaytn library.
+//
+// The klaytn library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The klaytn library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the klaytn library. If not, see <http://www.gnu.org/licenses/>.
+
+package blockchain
+
+import (
+	"math/big"
+	"testing"
+
+	"github.com/klaytn/klaytn/blockchain/types"
+	"github.com/klaytn/klaytn/blockchain/vm"
+	"github.com/klaytn/klaytn/consensus/gxhash"
+	"github.com/klaytn/klaytn/crypto"
+	"github.com/klaytn/klaytn/params"
+	"github.com/klaytn/klaytn/storage/database"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestUnindexTransactions(t *testing.T) {
+	var (
+		key, _  = crypto.HexToECDSA("b71c71a67e1177ad4e901695e1b4b9ee17ae16c6668d313eac2f96dbcda3f291")
+		addr    = crypto.PubkeyToAddress(key.PublicKey)
+		db      = database.NewMemoryDBManager()
+		gspec   = &Genesis{Config: params.TestChainConfig, Alloc: GenesisAlloc{addr: {Balance: big.NewInt(1000000)}}}
+		genesis = gspec.MustCommit(db)
+		signer  = types.LatestSignerForChainID(gspec.Config.ChainID)
+	)
+	chain, _ := GenerateChain(gspec.Config, genesis, gxhash.NewFaker(), db, 10, func(i int, gen *BlockGen) {
+		tx, _ := types.SignTx(types.NewTransaction(gen.TxNonce(addr), addr, big.NewInt(1), params.TxGas, nil, nil), signer, key)
+		gen.AddTx(tx)
+	})
+	bc, err := NewBlockChain(db, nil, gspec.Config, gxhash.NewFaker(), vm.Config{})
+	require.NoError(t, err)
+	defer bc.Stop()
+	_, err = bc.InsertChain(chain)
+	require.NoError(t, err)
+
+	assert.Nil(t, db.ReadTxIndexTail())
+	assert.True(t, bc.TxIndexDone())
+
+	// Only the transactions of the last 3 blocks are left indexed
+	bc.cacheConfig.TxLookupLimit = 3
+	assert.False(t, bc.TxIndexDone())
+	bc.unindexTransactions(bc.CurrentBlock().NumberU64())
+	assert.True(t, bc.TxIndexDone())
+
+	tail := db.ReadTxIndexTail()
+	require.NotNil(t, tail)
+	assert.Equal(t, uint64(8), *tail)
+	for _, block := range chain {
+		tx, _, _, _ := db.ReadTxAndLookupInfo(block.Transactions()[0].Hash())
+		if block.NumberU64() < *tail {
+			assert.Nil(t, tx, "block %d", block.NumberU64())
+		} else {
+			assert.NotNil(t, tx, "block %d", block.NumberU64())
+		}
+	}
+
+	// Nothing is unindexed until the head advances
+	bc.cacheConfig.TxLookupLimit = 5
+	bc.unindexTransactions(bc.CurrentBlock().NumberU64())
+	assert.Equal(t, uint64(8), *db.ReadTxIndexTail())
+}
@@ -46,6 +46,9 @@ type Prefetcher interface {
 	// only goal is to pre-cache transaction signatures and state trie nodes.
 	Prefetch(block *types.Block, stateDB *state.StateDB, cfg vm.Config, interrupt *uint32)
 	PrefetchTx(block *types.Block, ti int, stateDB *state.StateDB, cfg vm.Config, interrupt *uint32)
+	// PrefetchAccounts warms the accounts and storage slots listed in the transactions
+	// of the block without executing them.
+	PrefetchAccounts(block *types.Block, stateDB *state.StateDB, interrupt *uint32)
 }
 
 // Processor is an interface for processing blocks using a given initial state.
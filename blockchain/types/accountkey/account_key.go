@@ -48,6 +48,24 @@ func (a AccountKeyType) IsLegacyAccountKey() bool {
 	return a == AccountKeyTypeLegacy
 }
 
+func (a AccountKeyType) String() string {
+	switch a {
+	case AccountKeyTypeNil:
+		return "AccountKeyNil"
+	case AccountKeyTypeLegacy:
+		return "AccountKeyLegacy"
+	case AccountKeyTypePublic:
+		return "AccountKeyPublic"
+	case AccountKeyTypeFail:
+		return "AccountKeyFail"
+	case AccountKeyTypeWeightedMultiSig:
+		return "AccountKeyWeightedMultiSig"
+	case AccountKeyTypeRoleBased:
+		return "AccountKeyRoleBased"
+	}
+	return "UndefinedAccountKeyType"
+}
+
 // AccountKey is a common interface to exploit polymorphism of AccountKey.
 // Currently, we have the following implementations of AccountKey:
 // - AccountKeyLegacy
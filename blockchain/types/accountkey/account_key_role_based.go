@@ -37,6 +37,18 @@ const (
 	RoleLast
 )
 
+func (r RoleType) String() string {
+	switch r {
+	case RoleTransaction:
+		return "RoleTransaction"
+	case RoleAccountUpdate:
+		return "RoleAccountUpdate"
+	case RoleFeePayer:
+		return "RoleFeePayer"
+	}
+	return "UndefinedRoleType"
+}
+
 var (
 	errKeyLengthZero                    = errors.New("key length is zero")
 	errKeyShouldNotBeNilOrCompositeType = errors.New("key should not be nil or a composite type")
@@ -331,6 +331,11 @@ func (tx *Transaction) IsEthereumTransaction() bool {
 	return tx.Type().IsEthereumTransaction()
 }
 
+// IsContractCreation returns true if the payload of the transaction is the init code of a new contract.
+func (tx *Transaction) IsContractCreation() bool {
+	return tx.Type().IsContractDeploy() || (tx.IsEthereumTransaction() && tx.To() == nil)
+}
+
 func isProtectedV(V *big.Int) bool {
 	if V.BitLen() <= 8 {
 		v := V.Uint64()
@@ -1012,7 +1017,8 @@ func (t *TransactionsByTimeAndNonce) Pop() {
 }
 
 // NewMessage returns a `*Transaction` object with the given arguments.
-func NewMessage(from common.Address, to *common.Address, nonce uint64, amount *big.Int, gasLimit uint64, gasPrice *big.Int, data []byte, checkNonce bool, intrinsicGas uint64) *Transaction {
+// If the access list is not empty, the message is executed as an EIP-2930 access list transaction.
+func NewMessage(from common.Address, to *common.Address, nonce uint64, amount *big.Int, gasLimit uint64, gasPrice *big.Int, data []byte, checkNonce bool, intrinsicGas uint64, accessList AccessList) *Transaction {
 	transaction := &Transaction{
 		validatedIntrinsicGas: intrinsicGas,
 		validatedFeePayer:     from,
@@ -1020,7 +1026,12 @@ func NewMessage(from common.Address, to *common.Address, nonce uint64, amount *b
 		checkNonce:            checkNonce,
 	}
 
-	internalData := newTxInternalDataLegacyWithValues(nonce, to, amount, gasLimit, gasPrice, data)
+	var internalData TxInternalData
+	if len(accessList) > 0 {
+		internalData = newTxInternalDataEthereumAccessListWithValues(nonce, to, amount, gasLimit, gasPrice, data, accessList, nil)
+	} else {
+		internalData = newTxInternalDataLegacyWithValues(nonce, to, amount, gasLimit, gasPrice, data)
+	}
 	transaction.setDecoded(internalData, 0)
 
 	return transaction
@@ -26,6 +26,7 @@ import (
 	"encoding/hex"
 	"encoding/json"
 	"fmt"
+	"math"
 	"math/big"
 	"math/rand"
 	"reflect"
@@ -36,6 +37,7 @@ import (
 	"github.com/klaytn/klaytn/blockchain/types/accountkey"
 	"github.com/klaytn/klaytn/common"
 	"github.com/klaytn/klaytn/crypto"
+	"github.com/klaytn/klaytn/kerrors"
 	"github.com/klaytn/klaytn/params"
 	"github.com/klaytn/klaytn/rlp"
 	"github.com/stretchr/testify/assert"
@@ -630,6 +632,34 @@ func TestIntrinsicGas(t *testing.T) {
 	}
 }
 
+func TestIntrinsicGasInitCode(t *testing.T) {
+	rules := params.Rules{IsIstanbul: true, IsShanghai: true}
+
+	// The init code is charged per word only for the contract creation
+	for _, tc := range []struct {
+		size      int
+		expectGas uint64
+	}{
+		{0, 53000},
+		{1, 53000 + 16 + params.InitCodeWordGas},
+		{32, 53000 + 32*16 + params.InitCodeWordGas},
+		{33, 53000 + 33*16 + 2*params.InitCodeWordGas},
+	} {
+		data := bytes.Repeat([]byte{0xff}, tc.size)
+
+		gas, err := IntrinsicGas(data, nil, true, rules)
+		assert.NoError(t, err)
+		assert.Equal(t, tc.expectGas, gas)
+
+		gas, err = IntrinsicGas(data, nil, false, rules)
+		assert.NoError(t, err)
+		assert.Equal(t, params.TxGas+uint64(tc.size)*16, gas)
+	}
+
+	_, err := IntrinsicGasInitCode(math.MaxUint64, []byte{0xff})
+	assert.Equal(t, kerrors.ErrOutOfGas, err)
+}
+
 // Tests that if multiple transactions have the same price, the ones seen earlier
 // are prioritized to avoid network spam attacks aiming for a specific ordering.
 func TestTransactionTimeSort(t *testing.T) {
@@ -984,3 +1014,18 @@ func benchmarkTxSortByTime(b *testing.B, size int) {
 		sort.Sort(TxByPriceAndTime(batches))
 	}
 }
+
+func TestNewMessageAccessList(t *testing.T) {
+	from, to := common.HexToAddress("0xaa"), common.HexToAddress("0xbb")
+
+	legacy := NewMessage(from, &to, 0, big.NewInt(1), 100000, big.NewInt(25), nil, false, params.TxGas, nil)
+	assert.Equal(t, TxTypeLegacyTransaction, legacy.Type())
+	assert.Nil(t, legacy.AccessList())
+
+	accessList := AccessList{{Address: to, StorageKeys: []common.Hash{{0x1}}}}
+	msg := NewMessage(from, &to, 0, big.NewInt(1), 100000, big.NewInt(25), nil, false, params.TxGas, accessList)
+	assert.Equal(t, TxTypeEthereumAccessList, msg.Type())
+	assert.Equal(t, accessList, msg.AccessList())
+	assert.Equal(t, from, msg.ValidatedSender())
+	assert.Equal(t, params.TxGas, msg.ValidatedIntrinsicGas())
+}
@@ -577,6 +577,17 @@ func IntrinsicGasPayloadLegacy(gas uint64, data []byte) (uint64, error) {
 	return gas, nil
 }
 
+// IntrinsicGasInitCode adds the gas charged per word of the init code of a contract creation
+// transaction after the shanghai hardfork (EIP-3860).
+func IntrinsicGasInitCode(gas uint64, initCode []byte) (uint64, error) {
+	lenWords := (uint64(len(initCode)) + 31) / 32
+	// Make sure we don't exceed uint64 for all init code sizes
+	if (math.MaxUint64-gas)/params.InitCodeWordGas < lenWords {
+		return 0, kerrors.ErrOutOfGas
+	}
+	return gas + lenWords*params.InitCodeWordGas, nil
+}
+
 // IntrinsicGas computes the 'intrinsic gas' for a message with the given data.
 func IntrinsicGas(data []byte, accessList AccessList, contractCreation bool, r params.Rules) (uint64, error) {
 	// Set the starting gas for the raw transaction
@@ -599,6 +610,13 @@ func IntrinsicGas(data []byte, accessList AccessList, contractCreation bool, r p
 		return 0, err
 	}
 
+	if contractCreation && r.IsShanghai {
+		gasPayloadWithGas, err = IntrinsicGasInitCode(gasPayloadWithGas, data)
+		if err != nil {
+			return 0, err
+		}
+	}
+
 	// We charge additional gas for the accessList:
 	// ACCESS_LIST_ADDRESS_COST : gas per address in AccessList
 	// ACCESS_LIST_STORAGE_KEY_COST : gas per storage key in AccessList
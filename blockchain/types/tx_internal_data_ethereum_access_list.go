@@ -132,6 +132,7 @@ func newTxInternalDataEthereumAccessListWithValues(nonce uint64, to *common.Addr
 	}
 
 	if accessList != nil {
+		d.AccessList = make(AccessList, len(accessList))
 		copy(d.AccessList, accessList)
 	}
 
@@ -28,6 +28,7 @@ import (
 	"github.com/klaytn/klaytn/common/hexutil"
 	"github.com/klaytn/klaytn/crypto"
 	"github.com/klaytn/klaytn/crypto/sha3"
+	"github.com/klaytn/klaytn/fork"
 	"github.com/klaytn/klaytn/kerrors"
 	"github.com/klaytn/klaytn/params"
 	"github.com/klaytn/klaytn/rlp"
@@ -312,6 +313,10 @@ func (t *TxInternalDataFeeDelegatedSmartContractDeploy) IntrinsicGas(currentBloc
 		return 0, err
 	}
 
+	if fork.Rules(new(big.Int).SetUint64(currentBlockNumber)).IsShanghai {
+		return IntrinsicGasInitCode(gasPayloadWithGas, t.Payload)
+	}
+
 	return gasPayloadWithGas, nil
 }
 
@@ -0,0 +1,64 @@
+// Copyright 2022 The klaytn Authors
+// This file is part of the klaytn library.
+//
+// The klaytn library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The klaytn library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the klaytn library. If not, see <http://www.gnu.org/licenses/>.
+
+package vm
+
+import (
+	"math/big"
+	"testing"
+
+	"github.com/klaytn/klaytn/blockchain/types"
+	"github.com/klaytn/klaytn/common"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestAccessListTracer(t *testing.T) {
+	var (
+		from       = common.HexToAddress("0x1111")
+		to         = common.HexToAddress("0x2222")
+		callee     = common.HexToAddress("0x3333")
+		precompile = common.BytesToAddress([]byte{1})
+		slot       = common.BigToHash(big.NewInt(1))
+		contract   = NewContract(AccountRef(from), AccountRef(to), new(big.Int), 0)
+	)
+	capture := func(tracer *AccessListTracer, op OpCode, stackItems ...*big.Int) {
+		stack := newstack()
+		for i := len(stackItems) - 1; i >= 0; i-- {
+			stack.push(stackItems[i])
+		}
+		assert.NoError(t, tracer.CaptureState(nil, 0, op, 0, 0, nil, stack, contract, 1, nil))
+	}
+
+	tracer := NewAccessListTracer(nil, from, to, []common.Address{precompile})
+	capture(tracer, SLOAD, slot.Big())
+	// The arguments of CALL are gas, address, value, inOffset, inSize, retOffset and retSize.
+	capture(tracer, CALL, big.NewInt(0), new(big.Int).SetBytes(callee.Bytes()), big.NewInt(0), big.NewInt(0), big.NewInt(0))
+	capture(tracer, STATICCALL, big.NewInt(0), new(big.Int).SetBytes(precompile.Bytes()), big.NewInt(0), big.NewInt(0), big.NewInt(0))
+	capture(tracer, BALANCE, new(big.Int).SetBytes(from.Bytes()))
+
+	// The sender and the precompiles are excluded, but the storage slots of the recipient are included.
+	acl := tracer.AccessList()
+	assert.ElementsMatch(t, types.AccessList{
+		{Address: to, StorageKeys: []common.Hash{slot}},
+		{Address: callee, StorageKeys: []common.Hash{}},
+	}, acl)
+
+	// A tracer starting from the traced access list is equal if nothing else is accessed.
+	next := NewAccessListTracer(acl, from, to, []common.Address{precompile})
+	assert.True(t, next.Equal(tracer))
+	capture(next, SSTORE, big.NewInt(2), big.NewInt(0))
+	assert.False(t, next.Equal(tracer))
+}
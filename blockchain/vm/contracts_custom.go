@@ -0,0 +1,134 @@
+// Copyright 2022 The klaytn Authors
+// This file is part of the klaytn library.
+//
+// The klaytn library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The klaytn library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the klaytn library. If not, see <http://www.gnu.org/licenses/>.
+
+package vm
+
+import (
+	"errors"
+	"fmt"
+	"math/big"
+	"sync"
+
+	"github.com/klaytn/klaytn/common"
+	"github.com/klaytn/klaytn/common/math"
+	"github.com/klaytn/klaytn/params"
+)
+
+var errCustomPrecompileNotRegistered = errors.New("custom precompiled contract is not registered")
+
+// CustomPrecompiledContract is the interface for native Go contracts registered by
+// service chains. Unlike PrecompiledContract, the gas and the computation cost are
+// not defined by the implementation but by params.CustomPrecompileConfig in the chain config.
+type CustomPrecompiledContract interface {
+	// Run runs the custom precompiled contract.
+	Run(input []byte, contract *Contract, evm *EVM) ([]byte, error)
+}
+
+var (
+	customPrecompiledContracts   = make(map[string]CustomPrecompiledContract)
+	customPrecompiledContractsMu sync.RWMutex
+
+	// unregisteredReported records the names of unregistered custom precompiled contracts
+	// which have been reported, not to log the same error for every EVM.
+	unregisteredReported sync.Map
+)
+
+func init() {
+	params.IsCustomPrecompileRegistered = func(name string) bool {
+		_, ok := getCustomPrecompiledContract(name)
+		return ok
+	}
+}
+
+// RegisterCustomPrecompiledContract registers the implementation of a custom precompiled
+// contract with the given name. The contract is enabled at the address and the activation
+// block given by the params.CustomPrecompileConfig which has the same name.
+// It should be called before the blockchain is started, e.g. in an init function.
+func RegisterCustomPrecompiledContract(name string, impl CustomPrecompiledContract) error {
+	if name == "" || impl == nil {
+		return errors.New("invalid custom precompiled contract")
+	}
+	customPrecompiledContractsMu.Lock()
+	defer customPrecompiledContractsMu.Unlock()
+
+	if _, ok := customPrecompiledContracts[name]; ok {
+		return fmt.Errorf("custom precompiled contract %q is already registered", name)
+	}
+	customPrecompiledContracts[name] = impl
+	return nil
+}
+
+func getCustomPrecompiledContract(name string) (CustomPrecompiledContract, bool) {
+	customPrecompiledContractsMu.RLock()
+	defer customPrecompiledContractsMu.RUnlock()
+
+	impl, ok := customPrecompiledContracts[name]
+	return impl, ok
+}
+
+// customPrecompiledContract wraps a CustomPrecompiledContract with its gas schedule
+// to implement PrecompiledContract.
+type customPrecompiledContract struct {
+	impl   CustomPrecompiledContract
+	config *params.CustomPrecompileConfig
+}
+
+func (c *customPrecompiledContract) GetRequiredGasAndComputationCost(input []byte) (uint64, uint64) {
+	words := toWordSize(uint64(len(input)))
+	return linearCost(c.config.BaseGas, c.config.PerWordGas, words),
+		linearCost(c.config.BaseComputationCost, c.config.PerWordComputationCost, words)
+}
+
+func (c *customPrecompiledContract) Run(input []byte, contract *Contract, evm *EVM) ([]byte, error) {
+	if c.impl == nil {
+		return nil, errCustomPrecompileNotRegistered
+	}
+	return c.impl.Run(input, contract, evm)
+}
+
+// linearCost returns base + perWord * words, or MaxUint64 if it overflows.
+func linearCost(base, perWord, words uint64) uint64 {
+	cost, overflow := math.SafeMul(perWord, words)
+	if overflow {
+		return math.MaxUint64
+	}
+	if cost, overflow = math.SafeAdd(base, cost); overflow {
+		return math.MaxUint64
+	}
+	return cost
+}
+
+// activeCustomPrecompiledContracts returns the custom precompiled contracts activated at the given block number.
+func activeCustomPrecompiledContracts(config *params.ChainConfig, num *big.Int) map[common.Address]PrecompiledContract {
+	active := config.ActiveCustomPrecompiles(num)
+	if len(active) == 0 {
+		return nil
+	}
+	contracts := make(map[common.Address]PrecompiledContract, len(active))
+	for _, p := range active {
+		impl, ok := getCustomPrecompiledContract(p.Name)
+		if !ok {
+			// The node is not started with an unregistered contract by params.CheckCustomPrecompiles.
+			// If the chain config is not checked, the calls to the address fail instead of being
+			// handled as a normal account, but the results still differ from the other nodes.
+			if _, reported := unregisteredReported.LoadOrStore(p.Name, struct{}{}); !reported {
+				logger.Error("Custom precompiled contract is not registered", "name", p.Name, "address", p.Address.Hex())
+			}
+		}
+		contracts[p.Address] = &customPrecompiledContract{impl: impl, config: p}
+	}
+	return contracts
+}
@@ -0,0 +1,97 @@
+// Copyright 2022 The klaytn Authors
+// This file is part of the klaytn library.
+//
+// The klaytn library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The klaytn library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the klaytn library. If not, see <http://www.gnu.org/licenses/>.
+
+package vm
+
+import (
+	"math"
+	"math/big"
+	"testing"
+
+	"github.com/klaytn/klaytn/blockchain/state"
+	"github.com/klaytn/klaytn/common"
+	"github.com/klaytn/klaytn/params"
+	"github.com/klaytn/klaytn/storage/database"
+	"github.com/stretchr/testify/assert"
+)
+
+// reverseContract returns the input in the reverse order.
+type reverseContract struct{}
+
+func (c *reverseContract) Run(input []byte, contract *Contract, evm *EVM) ([]byte, error) {
+	ret := make([]byte, len(input))
+	for i, b := range input {
+		ret[len(input)-1-i] = b
+	}
+	return ret, nil
+}
+
+func TestCustomPrecompiledContract(t *testing.T) {
+	assert.NoError(t, RegisterCustomPrecompiledContract("testReverse", &reverseContract{}))
+	assert.Error(t, RegisterCustomPrecompiledContract("testReverse", &reverseContract{}))
+
+	var (
+		reverseAddr      = params.FirstCustomPrecompileAddress
+		unregisteredAddr = common.HexToAddress("0x0000000000000000000000000000000000000401")
+		config           = &params.ChainConfig{CustomPrecompiles: []*params.CustomPrecompileConfig{
+			{
+				Name:                   "testReverse",
+				Address:                reverseAddr,
+				ActivationBlock:        Block5,
+				BaseGas:                100,
+				PerWordGas:             10,
+				BaseComputationCost:    200,
+				PerWordComputationCost: 20,
+			},
+			{Name: "testUnregistered", Address: unregisteredAddr, ActivationBlock: Block5},
+		}}
+		callerAddr = common.BytesToAddress([]byte("contract"))
+		input      = common.Hex2Bytes("0102030405060708091011121314151617181920212223242526272829303132" + "33")
+	)
+	call := func(block *big.Int, addr common.Address) ([]byte, uint64, uint64, error) {
+		statedb, _ := state.New(common.Hash{}, state.NewDatabase(database.NewMemoryDBManager()), nil)
+		vmctx := Context{
+			CanTransfer: func(StateDB, common.Address, *big.Int) bool { return true },
+			Transfer:    func(StateDB, common.Address, common.Address, *big.Int) {},
+			BlockNumber: block,
+		}
+		vmenv := NewEVM(vmctx, statedb, config, &Config{})
+		ret, gas, err := vmenv.Call(AccountRef(callerAddr), addr, input, math.MaxUint64, new(big.Int))
+		return ret, math.MaxUint64 - gas, vmenv.opcodeComputationCostSum, err
+	}
+
+	// The address is a normal account before the activation
+	ret, gasUsed, _, err := call(Block4, reverseAddr)
+	assert.NoError(t, err)
+	assert.Empty(t, ret)
+	assert.Zero(t, gasUsed)
+
+	// The input of 2 words is charged by the schedule in the chain config
+	ret, gasUsed, computationCost, err := call(Block5, reverseAddr)
+	assert.NoError(t, err)
+	assert.Equal(t, "33"+"3231302928272625242322212019181716151413121110090807060504030201", common.Bytes2Hex(ret))
+	assert.Equal(t, uint64(100+2*10), gasUsed)
+	assert.Equal(t, uint64(200+2*20), computationCost)
+
+	// The call to an activated but unregistered contract fails
+	_, _, _, err = call(Block5, unregisteredAddr)
+	assert.Equal(t, errCustomPrecompileNotRegistered, err)
+
+	// The chain config with an unregistered contract is rejected, so that the node is not started
+	assert.Error(t, config.CheckCustomPrecompiles())
+	config.CustomPrecompiles = config.CustomPrecompiles[:1]
+	assert.NoError(t, config.CheckCustomPrecompiles())
+}
@@ -27,6 +27,10 @@ import (
 // defined jump tables are not polluted.
 func EnableEIP(eipNum int, jt *JumpTable) error {
 	switch eipNum {
+	case 3860:
+		enable3860(jt)
+	case 3855:
+		enable3855(jt)
 	case 4399:
 		enable4399(jt)
 	case 3529:
@@ -188,3 +192,30 @@ func enable4399(jt *JumpTable) {
 		computationCost: params.RandomComputationCost,
 	}
 }
+
+// enable3855 applies EIP-3855 (PUSH0 opcode)
+// - Adds an opcode that pushes the constant value 0 onto the stack.
+func enable3855(jt *JumpTable) {
+	// New opcode
+	jt[PUSH0] = &operation{
+		execute:         opPush0,
+		constantGas:     GasQuickStep,
+		minStack:        minStack(0, 1),
+		maxStack:        maxStack(0, 1),
+		computationCost: params.Push0ComputationCost,
+	}
+}
+
+// opPush0 implements the PUSH0 opcode
+func opPush0(pc *uint64, evm *EVM, contract *Contract, memory *Memory, stack *Stack) ([]byte, error) {
+	stack.push(evm.interpreter.intPool.getZero())
+	return nil, nil
+}
+
+// enable3860 applies EIP-3860 (Limit and meter initcode)
+// - Charges InitCodeWordGas per word of initcode in CREATE and CREATE2.
+// - Fails CREATE and CREATE2 if the initcode is larger than MaxInitCodeSize.
+func enable3860(jt *JumpTable) {
+	jt[CREATE].dynamicGas = gasCreateEip3860
+	jt[CREATE2].dynamicGas = gasCreate2Eip3860
+}
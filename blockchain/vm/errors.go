@@ -39,10 +39,11 @@ var (
 	ErrFailedOnSetCode                   = errors.New("failed on setting code to an account")
 
 	// EVM internal errors
-	ErrWriteProtection       = errors.New("evm: write protection")
-	ErrReturnDataOutOfBounds = errors.New("evm: return data out of bounds")
-	ErrExecutionReverted     = errors.New("evm: execution reverted")
-	ErrMaxCodeSizeExceeded   = errors.New("evm: max code size exceeded")
-	ErrInvalidJump           = errors.New("evm: invalid jump destination")
-	ErrInvalidCode           = errors.New("invalid code: must not begin with 0xef")
+	ErrWriteProtection         = errors.New("evm: write protection")
+	ErrReturnDataOutOfBounds   = errors.New("evm: return data out of bounds")
+	ErrExecutionReverted       = errors.New("evm: execution reverted")
+	ErrMaxCodeSizeExceeded     = errors.New("evm: max code size exceeded")
+	ErrMaxInitCodeSizeExceeded = errors.New("evm: max initcode size exceeded")
+	ErrInvalidJump             = errors.New("evm: invalid jump destination")
+	ErrInvalidCode             = errors.New("invalid code: must not begin with 0xef")
 )
@@ -56,15 +56,14 @@ type (
 // - an address of precompiled contracts
 // - an address of program accounts
 func isProgramAccount(evm *EVM, caller common.Address, addr common.Address, db StateDB) bool {
-	_, exists := evm.GetPrecompiledContractMap(caller)[addr]
+	_, exists := evm.precompile(caller, addr)
 	return exists || db.IsProgramAccount(addr)
 }
 
 // run runs the given contract and takes care of running precompiles with a fallback to the byte code interpreter.
 func run(evm *EVM, contract *Contract, input []byte) ([]byte, error) {
 	if contract.CodeAddr != nil {
-		precompiles := evm.GetPrecompiledContractMap(contract.CallerAddress)
-		if p := precompiles[*contract.CodeAddr]; p != nil {
+		if p, _ := evm.precompile(contract.CallerAddress, *contract.CodeAddr); p != nil {
 			///////////////////////////////////////////////////////
 			// OpcodeComputationCostLimit: The below code is commented and will be usd for debugging purposes.
 			//var startTime time.Time
@@ -151,6 +150,9 @@ type EVM struct {
 
 	// opcodeComputationCostSum is the sum of computation cost of opcodes.
 	opcodeComputationCostSum uint64
+
+	// customPrecompiles contains the custom precompiled contracts activated at the current block.
+	customPrecompiles map[common.Address]PrecompiledContract
 }
 
 // NewEVM returns a new EVM. The returned EVM is not thread safe and should
@@ -163,6 +165,7 @@ func NewEVM(ctx Context, statedb StateDB, chainConfig *params.ChainConfig, vmCon
 		chainConfig: chainConfig,
 		chainRules:  chainConfig.Rules(ctx.BlockNumber),
 	}
+	evm.customPrecompiles = activeCustomPrecompiledContracts(chainConfig, ctx.BlockNumber)
 
 	if vmConfig.RunningEVM != nil {
 		vmConfig.RunningEVM <- evm
@@ -223,9 +226,8 @@ func (evm *EVM) Call(caller types.ContractRef, addr common.Address, input []byte
 	)
 
 	// Filter out invalid precompiled address calls, and create a precompiled contract object if it is not exist.
-	if common.IsPrecompiledContractAddress(addr) {
-		precompiles := evm.GetPrecompiledContractMap(caller.Address())
-		if precompiles[addr] == nil || value.Sign() != 0 {
+	if _, isCustom := evm.customPrecompiles[addr]; isCustom || common.IsPrecompiledContractAddress(addr) {
+		if p, _ := evm.precompile(caller.Address(), addr); p == nil || value.Sign() != 0 {
 			// Return an error if an enabled precompiled address is called or a value is transferred to a precompiled address.
 			if evm.vmConfig.Debug && evm.depth == 0 {
 				evm.vmConfig.Tracer.CaptureStart(caller.Address(), addr, false, input, gas, value)
@@ -576,6 +578,31 @@ func (evm *EVM) GetPrecompiledContractMap(addr common.Address) map[common.Addres
 	}
 }
 
+// precompile returns the precompiled contract at addr which is available for the caller.
+// The custom precompiled contracts are available regardless of the vmVersion of the caller.
+func (evm *EVM) precompile(caller common.Address, addr common.Address) (PrecompiledContract, bool) {
+	if p, ok := evm.GetPrecompiledContractMap(caller)[addr]; ok {
+		return p, true
+	}
+	p, ok := evm.customPrecompiles[addr]
+	return p, ok
+}
+
+// ActivePrecompiles returns the addresses of the precompiled contracts enabled at the
+// current block, including the custom precompiled contracts.
+func (evm *EVM) ActivePrecompiles() []common.Address {
+	precompiles := ActivePrecompiles(evm.chainRules)
+	if len(evm.customPrecompiles) == 0 {
+		return precompiles
+	}
+	addrs := make([]common.Address, 0, len(precompiles)+len(evm.customPrecompiles))
+	addrs = append(addrs, precompiles...)
+	for addr := range evm.customPrecompiles {
+		addrs = append(addrs, addr)
+	}
+	return addrs
+}
+
 // ChainConfig returns the environment's chain configuration
 func (evm *EVM) ChainConfig() *params.ChainConfig { return evm.chainConfig }
 
@@ -376,3 +376,37 @@ func gasSelfdestruct(evm *EVM, contract *Contract, stack *Stack, mem *Memory, me
 	}
 	return gas, nil
 }
+
+func gasCreateEip3860(evm *EVM, contract *Contract, stack *Stack, mem *Memory, memorySize uint64) (uint64, error) {
+	gas, err := memoryGasCost(mem, memorySize)
+	if err != nil {
+		return 0, err
+	}
+	size, overflow := bigUint64(stack.Back(2))
+	if overflow || size > params.MaxInitCodeSize {
+		return 0, ErrMaxInitCodeSizeExceeded
+	}
+	// Since size <= params.MaxInitCodeSize, this multiplication cannot overflow
+	moreGas := params.InitCodeWordGas * toWordSize(size)
+	if gas, overflow = math.SafeAdd(gas, moreGas); overflow {
+		return 0, errGasUintOverflow
+	}
+	return gas, nil
+}
+
+func gasCreate2Eip3860(evm *EVM, contract *Contract, stack *Stack, mem *Memory, memorySize uint64) (uint64, error) {
+	gas, err := memoryGasCost(mem, memorySize)
+	if err != nil {
+		return 0, err
+	}
+	size, overflow := bigUint64(stack.Back(2))
+	if overflow || size > params.MaxInitCodeSize {
+		return 0, ErrMaxInitCodeSizeExceeded
+	}
+	// Since size <= params.MaxInitCodeSize, this multiplication cannot overflow
+	moreGas := (params.InitCodeWordGas + params.Sha3WordGas) * toWordSize(size)
+	if gas, overflow = math.SafeAdd(gas, moreGas); overflow {
+		return 0, errGasUintOverflow
+	}
+	return gas, nil
+}
@@ -653,6 +653,30 @@ func BenchmarkOpSHA3(bench *testing.B) {
 	poolOfIntPools.put(evmInterpreter.intPool)
 }
 
+func TestOpPush0(t *testing.T) {
+	var (
+		env            = NewEVM(Context{}, nil, params.TestChainConfig, &Config{})
+		stack          = newstack()
+		evmInterpreter = NewEVMInterpreter(env, env.vmConfig)
+	)
+
+	env.interpreter = evmInterpreter
+	evmInterpreter.intPool = poolOfIntPools.get()
+	pc := uint64(0)
+	stack.push(big.NewInt(0x1))
+	opPush0(&pc, env, nil, nil, stack)
+	if stack.len() != 2 {
+		t.Fatalf("Push0 fail, stack length got %v, expected %v", stack.len(), 2)
+	}
+	if got := stack.pop(); got.Sign() != 0 {
+		t.Fatalf("Push0 fail, got %v, expected 0", got)
+	}
+	if ShanghaiInstructionSet[PUSH0] == nil || KoreInstructionSet[PUSH0] != nil {
+		t.Fatalf("PUSH0 must be enabled only from the shanghai instruction set")
+	}
+	poolOfIntPools.put(evmInterpreter.intPool)
+}
+
 func TestCreate2Addreses(t *testing.T) {
 	type testcase struct {
 		origin   string
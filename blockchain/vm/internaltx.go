@@ -0,0 +1,66 @@
+// Copyright 2022 The klaytn Authors
+// This file is part of the klaytn library.
+//
+// The klaytn library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The klaytn library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the klaytn library. If not, see <http://www.gnu.org/licenses/>.
+
+package vm
+
+import (
+	"math/big"
+
+	"github.com/klaytn/klaytn/common"
+	"github.com/klaytn/klaytn/common/hexutil"
+)
+
+// InternalTx is a value transfer or a contract creation made by a contract in a transaction.
+type InternalTx struct {
+	Type  string
+	From  common.Address
+	To    common.Address
+	Value *big.Int
+}
+
+// InternalTxsFromTrace returns the internal transactions in the given trace of a transaction
+// in the order of execution. The calls failed or reverted are excluded with their sub-calls,
+// and the calls without value are excluded except contract creations.
+func InternalTxsFromTrace(trace *InternalTxTrace) []*InternalTx {
+	if trace == nil || trace.Error != nil {
+		return nil
+	}
+	var internalTxs []*InternalTx
+	for _, call := range trace.Calls {
+		internalTxs = appendInternalTxs(internalTxs, call)
+	}
+	return internalTxs
+}
+
+func appendInternalTxs(internalTxs []*InternalTx, trace *InternalTxTrace) []*InternalTx {
+	if trace.Error != nil || trace.From == nil || trace.To == nil {
+		return internalTxs
+	}
+	value := new(big.Int)
+	if trace.Value != "" {
+		if v, err := hexutil.DecodeBig(trace.Value); err == nil {
+			value = v
+		}
+	}
+	isCreation := trace.Type == CREATE.String() || trace.Type == CREATE2.String()
+	if isCreation || value.Sign() > 0 {
+		internalTxs = append(internalTxs, &InternalTx{Type: trace.Type, From: *trace.From, To: *trace.To, Value: value})
+	}
+	for _, call := range trace.Calls {
+		internalTxs = appendInternalTxs(internalTxs, call)
+	}
+	return internalTxs
+}
@@ -0,0 +1,67 @@
+// Copyright 2022 The klaytn Authors
+// This file is part of the klaytn library.
+//
+// The klaytn library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The klaytn library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the klaytn library. If not, see <http://www.gnu.org/licenses/>.
+
+package vm
+
+import (
+	"errors"
+	"math/big"
+	"testing"
+
+	"github.com/klaytn/klaytn/common"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestInternalTxsFromTrace(t *testing.T) {
+	var (
+		user     = common.HexToAddress("0x1111")
+		contract = common.HexToAddress("0x2222")
+		created  = common.HexToAddress("0x3333")
+		receiver = common.HexToAddress("0x4444")
+	)
+	trace := &InternalTxTrace{
+		Type: CALL.String(), From: &user, To: &contract, Value: "0x10",
+		Calls: []*InternalTxTrace{
+			{Type: OpCode(STATICCALL).String(), From: &contract, To: &receiver},
+			{Type: CALL.String(), From: &contract, To: &receiver, Value: "0x0"},
+			{
+				Type: CREATE.String(), From: &contract, To: &created, Value: "0x0",
+				Calls: []*InternalTxTrace{{Type: CALL.String(), From: &created, To: &receiver, Value: "0x5"}},
+			},
+			{
+				Type: CALL.String(), From: &contract, To: &receiver, Value: "0x1", Error: errors.New("reverted"),
+				Calls: []*InternalTxTrace{{Type: CALL.String(), From: &receiver, To: &user, Value: "0x1"}},
+			},
+		},
+	}
+
+	expected := []*InternalTx{
+		{Type: CREATE.String(), From: contract, To: created, Value: big.NewInt(0)},
+		{Type: CALL.String(), From: created, To: receiver, Value: big.NewInt(5)},
+	}
+	internalTxs := InternalTxsFromTrace(trace)
+	require.Len(t, internalTxs, len(expected))
+	for i, internalTx := range internalTxs {
+		assert.Equal(t, expected[i].Type, internalTx.Type)
+		assert.Equal(t, expected[i].From, internalTx.From)
+		assert.Equal(t, expected[i].To, internalTx.To)
+		assert.Zero(t, expected[i].Value.Cmp(internalTx.Value))
+	}
+
+	trace.Error = errors.New("reverted")
+	assert.Empty(t, InternalTxsFromTrace(trace))
+}
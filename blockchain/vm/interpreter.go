@@ -89,6 +89,8 @@ func NewEVMInterpreter(evm *EVM, cfg *Config) *Interpreter {
 	if cfg.JumpTable[STOP] == nil {
 		var jt JumpTable
 		switch {
+		case evm.chainRules.IsShanghai:
+			jt = ShanghaiInstructionSet
 		case evm.chainRules.IsKore:
 			jt = KoreInstructionSet
 		case evm.chainRules.IsLondon:
@@ -105,6 +107,9 @@ func NewEVMInterpreter(evm *EVM, cfg *Config) *Interpreter {
 				logger.Error("EIP activation failed", "eip", eip, "error", err)
 			}
 		}
+		if evm.chainConfig.IsOpcodeCostOverridden(evm.Context.BlockNumber) {
+			applyOpcodeCostOverrides(&jt, evm.chainConfig.OpcodeCostOverrides)
+		}
 		cfg.JumpTable = jt
 	}
 
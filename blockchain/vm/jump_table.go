@@ -22,6 +22,7 @@ package vm
 
 import (
 	"errors"
+	"fmt"
 
 	"github.com/klaytn/klaytn/params"
 )
@@ -65,11 +66,60 @@ var (
 	IstanbulInstructionSet       = newIstanbulInstructionSet()
 	LondonInstructionSet         = newLondonInstructionSet()
 	KoreInstructionSet           = newKoreInstructionSet()
+	ShanghaiInstructionSet       = newShanghaiInstructionSet()
 )
 
 // JumpTable contains the EVM opcodes supported at a given fork.
 type JumpTable [256]*operation
 
+// ValidateOpcodeCostOverrides validates the opcode cost overrides of the given chain config.
+// In addition to the bounds checked by params.ChainConfig.CheckOpcodeCostOverrides,
+// it checks that the overridden opcodes are defined.
+func ValidateOpcodeCostOverrides(config *params.ChainConfig) error {
+	if err := config.CheckOpcodeCostOverrides(); err != nil {
+		return err
+	}
+	if config.OpcodeCostOverrides == nil {
+		return nil
+	}
+	for name := range config.OpcodeCostOverrides.Opcodes {
+		if _, ok := stringToOp[name]; !ok {
+			return fmt.Errorf("undefined opcode %q in opcode cost overrides", name)
+		}
+	}
+	return nil
+}
+
+// applyOpcodeCostOverrides overrides the costs of the operations in the given jump table.
+// The overridden operations are copied, so the globally defined jump tables are not polluted.
+// An opcode which is not enabled in the jump table is left undefined.
+func applyOpcodeCostOverrides(jt *JumpTable, overrides *params.OpcodeCostOverrides) {
+	for name, o := range overrides.Opcodes {
+		op, ok := stringToOp[name]
+		if !ok || jt[op] == nil || o == nil {
+			continue
+		}
+		overridden := *jt[op]
+		if o.ConstantGas != nil {
+			overridden.constantGas = *o.ConstantGas
+		}
+		if o.ComputationCost != nil {
+			overridden.computationCost = *o.ComputationCost
+		}
+		jt[op] = &overridden
+	}
+}
+
+// newShanghaiInstructionSet returns the frontier, homestead, byzantium,
+// constantinople, istanbul, petersburg, berlin, london, kore and shanghai instructions.
+func newShanghaiInstructionSet() JumpTable {
+	instructionSet := newKoreInstructionSet()
+
+	enable3855(&instructionSet) // PUSH0 instruction https://eips.ethereum.org/EIPS/eip-3855
+	enable3860(&instructionSet) // Limit and meter initcode https://eips.ethereum.org/EIPS/eip-3860
+	return instructionSet
+}
+
 func newKoreInstructionSet() JumpTable {
 	instructionSet := newLondonInstructionSet()
 
@@ -0,0 +1,63 @@
+// Copyright 2022 The klaytn Authors
+// This file is part of the klaytn library.
+//
+// The klaytn library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The klaytn library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the klaytn library. If not, see <http://www.gnu.org/licenses/>.
+
+package vm
+
+import (
+	"math/big"
+	"testing"
+
+	"github.com/klaytn/klaytn/params"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestOpcodeCostOverrides(t *testing.T) {
+	gas, cost := uint64(5000), uint64(3000)
+	overrides := &params.OpcodeCostOverrides{
+		ActivationBlock: big.NewInt(10),
+		Opcodes: map[string]*params.OpcodeCostOverride{
+			"SLOAD": {ConstantGas: &gas},
+			"ADD":   {ComputationCost: &cost},
+		},
+	}
+	config := &params.ChainConfig{ChainID: big.NewInt(1000), OpcodeCostOverrides: overrides}
+	assert.NoError(t, ValidateOpcodeCostOverrides(config))
+
+	jt := newIstanbulInstructionSet()
+	applyOpcodeCostOverrides(&jt, overrides)
+	assert.Equal(t, gas, jt[SLOAD].constantGas)
+	assert.Equal(t, cost, jt[ADD].computationCost)
+	assert.Equal(t, IstanbulInstructionSet[ADD].constantGas, jt[ADD].constantGas)
+
+	// the global jump table must not be polluted
+	assert.NotEqual(t, gas, IstanbulInstructionSet[SLOAD].constantGas)
+	assert.Equal(t, uint64(params.AddComputationCost), IstanbulInstructionSet[ADD].computationCost)
+
+	// undefined opcode
+	overrides.Opcodes["NOPE"] = &params.OpcodeCostOverride{}
+	assert.Error(t, ValidateOpcodeCostOverrides(config))
+	delete(overrides.Opcodes, "NOPE")
+
+	// out of bound
+	tooExpensive := params.MaxOpcodeConstantGasOverride + 1
+	overrides.Opcodes["SLOAD"] = &params.OpcodeCostOverride{ConstantGas: &tooExpensive}
+	assert.Error(t, ValidateOpcodeCostOverrides(config))
+
+	// public networks
+	overrides.Opcodes["SLOAD"] = &params.OpcodeCostOverride{ConstantGas: &gas}
+	config.ChainID = new(big.Int).SetUint64(params.CypressNetworkId)
+	assert.Error(t, ValidateOpcodeCostOverrides(config))
+}
@@ -63,6 +63,9 @@ func setDefaults(cfg *Config) {
 			LondonCompatibleBlock:    new(big.Int),
 			EthTxTypeCompatibleBlock: new(big.Int),
 			KoreCompatibleBlock:      new(big.Int),
+			ShanghaiCompatibleBlock:  new(big.Int),
+
+			AccessListCompatibleBlock: new(big.Int),
 		}
 	}
 
@@ -116,7 +119,7 @@ func Execute(code, input []byte, cfg *Config) ([]byte, *state.StateDB, error) {
 		rules   = cfg.ChainConfig.Rules(vmenv.BlockNumber)
 	)
 	if rules.IsKore {
-		cfg.State.PrepareAccessList(cfg.Origin, common.Address{}, &address, vm.ActivePrecompiles(rules))
+		cfg.State.PrepareAccessList(cfg.Origin, common.Address{}, &address, vm.ActivePrecompiles(rules), nil)
 	}
 	cfg.State.CreateSmartContractAccount(address, params.CodeFormatEVM, cfg.ChainConfig.Rules(cfg.BlockNumber))
 	// set the receiver's (the executing contract) code for execution.
@@ -150,7 +153,7 @@ func Create(input []byte, cfg *Config) ([]byte, common.Address, uint64, error) {
 		rules  = cfg.ChainConfig.Rules(vmenv.BlockNumber)
 	)
 	if rules.IsKore {
-		cfg.State.PrepareAccessList(cfg.Origin, common.Address{}, nil, vm.ActivePrecompiles(rules))
+		cfg.State.PrepareAccessList(cfg.Origin, common.Address{}, nil, vm.ActivePrecompiles(rules), nil)
 	}
 	// Call the code with the given configuration.
 	code, address, leftOverGas, err := vmenv.Create(
@@ -178,7 +181,7 @@ func Call(address common.Address, input []byte, cfg *Config) ([]byte, uint64, er
 		rules         = cfg.ChainConfig.Rules(vmenv.BlockNumber)
 	)
 	if rules.IsKore {
-		cfg.State.PrepareAccessList(cfg.Origin, common.Address{}, &address, vm.ActivePrecompiles(rules))
+		cfg.State.PrepareAccessList(cfg.Origin, common.Address{}, &address, vm.ActivePrecompiles(rules), nil)
 	}
 	// Call the code with the given configuration.
 	ret, leftOverGas, err := vmenv.Call(
@@ -0,0 +1,136 @@
+// Copyright 2022 The klaytn Authors
+// This file is part of the klaytn library.
+//
+// The klaytn library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The klaytn library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the klaytn library. If not, see <http://www.gnu.org/licenses/>.
+
+package vm
+
+import (
+	"bytes"
+	"math/big"
+	"time"
+
+	"github.com/klaytn/klaytn/common"
+	"github.com/klaytn/klaytn/common/hexutil"
+)
+
+// StorageDiff is the change of a storage slot.
+type StorageDiff struct {
+	Before common.Hash `json:"before"`
+	After  common.Hash `json:"after"`
+}
+
+// AccountDiff is the change of an account. Only the changed fields are set.
+type AccountDiff struct {
+	Created       bool                         `json:"created,omitempty"`
+	Deleted       bool                         `json:"deleted,omitempty"`
+	BalanceBefore *hexutil.Big                 `json:"balanceBefore,omitempty"`
+	BalanceAfter  *hexutil.Big                 `json:"balanceAfter,omitempty"`
+	NonceBefore   *hexutil.Uint64              `json:"nonceBefore,omitempty"`
+	NonceAfter    *hexutil.Uint64              `json:"nonceAfter,omitempty"`
+	CodeAfter     hexutil.Bytes                `json:"codeAfter,omitempty"`
+	Storage       map[common.Hash]*StorageDiff `json:"storage,omitempty"`
+}
+
+// StateDiffTracer is a Tracer which collects the accounts and the storage slots
+// which may be modified by a transaction. Diff compares them between two states
+// to summarize the state changes made by the transaction.
+type StateDiffTracer struct {
+	accounts map[common.Address]map[common.Hash]struct{}
+}
+
+// NewStateDiffTracer returns a new StateDiffTracer.
+func NewStateDiffTracer() *StateDiffTracer {
+	return &StateDiffTracer{accounts: make(map[common.Address]map[common.Hash]struct{})}
+}
+
+func (t *StateDiffTracer) touch(addr common.Address) map[common.Hash]struct{} {
+	slots, ok := t.accounts[addr]
+	if !ok {
+		slots = make(map[common.Hash]struct{})
+		t.accounts[addr] = slots
+	}
+	return slots
+}
+
+// AddAccount adds the given address to the accounts to compare, e.g. the fee payer or the rewardbase.
+func (t *StateDiffTracer) AddAccount(addr common.Address) {
+	t.touch(addr)
+}
+
+func (t *StateDiffTracer) CaptureStart(from common.Address, to common.Address, call bool, input []byte, gas uint64, value *big.Int) error {
+	t.touch(from)
+	t.touch(to)
+	return nil
+}
+
+func (t *StateDiffTracer) CaptureState(env *EVM, pc uint64, op OpCode, gas, cost uint64, memory *Memory, stack *Stack, contract *Contract, depth int, err error) error {
+	slots := t.touch(contract.Address())
+	switch {
+	case op == SSTORE && stack.len() >= 1:
+		slots[common.BigToHash(stack.Back(0))] = struct{}{}
+	case (op == CALL || op == CALLCODE) && stack.len() >= 2:
+		t.touch(common.BigToAddress(stack.Back(1)))
+	case op == SELFDESTRUCT && stack.len() >= 1:
+		t.touch(common.BigToAddress(stack.Back(0)))
+	}
+	return nil
+}
+
+func (t *StateDiffTracer) CaptureFault(env *EVM, pc uint64, op OpCode, gas, cost uint64, memory *Memory, stack *Stack, contract *Contract, depth int, err error) error {
+	return nil
+}
+
+func (t *StateDiffTracer) CaptureEnd(output []byte, gasUsed uint64, d time.Duration, err error) error {
+	return nil
+}
+
+// Diff returns the changes of the collected accounts from pre to post.
+// The accounts without any change are omitted.
+func (t *StateDiffTracer) Diff(pre, post StateDB) map[common.Address]*AccountDiff {
+	diffs := make(map[common.Address]*AccountDiff)
+	for addr, slots := range t.accounts {
+		diff := &AccountDiff{}
+		changed := false
+		if existed, exists := pre.Exist(addr), post.Exist(addr); existed != exists {
+			diff.Created, diff.Deleted = exists, existed
+			changed = true
+		}
+		if before, after := pre.GetBalance(addr), post.GetBalance(addr); before.Cmp(after) != 0 {
+			diff.BalanceBefore, diff.BalanceAfter = (*hexutil.Big)(before), (*hexutil.Big)(after)
+			changed = true
+		}
+		if before, after := pre.GetNonce(addr), post.GetNonce(addr); before != after {
+			diff.NonceBefore, diff.NonceAfter = (*hexutil.Uint64)(&before), (*hexutil.Uint64)(&after)
+			changed = true
+		}
+		if code := post.GetCode(addr); !bytes.Equal(pre.GetCode(addr), code) {
+			diff.CodeAfter = code
+			changed = true
+		}
+		for slot := range slots {
+			if before, after := pre.GetState(addr, slot), post.GetState(addr, slot); before != after {
+				if diff.Storage == nil {
+					diff.Storage = make(map[common.Hash]*StorageDiff)
+				}
+				diff.Storage[slot] = &StorageDiff{Before: before, After: after}
+				changed = true
+			}
+		}
+		if changed {
+			diffs[addr] = diff
+		}
+	}
+	return diffs
+}
@@ -0,0 +1,67 @@
+// Copyright 2022 The klaytn Authors
+// This file is part of the klaytn library.
+//
+// The klaytn library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The klaytn library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the klaytn library. If not, see <http://www.gnu.org/licenses/>.
+
+package vm
+
+import (
+	"math/big"
+	"testing"
+
+	"github.com/klaytn/klaytn/blockchain/state"
+	"github.com/klaytn/klaytn/common"
+	"github.com/klaytn/klaytn/storage/database"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestStateDiffTracer(t *testing.T) {
+	var (
+		from      = common.HexToAddress("0x1111")
+		to        = common.HexToAddress("0x2222")
+		untouched = common.HexToAddress("0x3333")
+		slot      = common.BigToHash(big.NewInt(1))
+		value     = common.BigToHash(big.NewInt(42))
+	)
+	pre, _ := state.New(common.Hash{}, state.NewDatabase(database.NewMemoryDBManager()), nil)
+	pre.AddBalance(from, big.NewInt(100))
+	pre.AddBalance(untouched, big.NewInt(100))
+	post := pre.Copy()
+
+	tracer := NewStateDiffTracer()
+	assert.NoError(t, tracer.CaptureStart(from, to, false, nil, 0, big.NewInt(10)))
+	stack := newstack()
+	stack.push(new(big.Int).Set(value.Big()))
+	stack.push(new(big.Int).Set(slot.Big()))
+	contract := NewContract(AccountRef(from), AccountRef(to), new(big.Int), 0)
+	assert.NoError(t, tracer.CaptureState(nil, 0, SSTORE, 0, 0, nil, stack, contract, 1, nil))
+
+	post.SubBalance(from, big.NewInt(10))
+	post.AddBalance(to, big.NewInt(10))
+	post.SetNonce(from, 1)
+	post.SetState(to, slot, value)
+	post.AddBalance(untouched, big.NewInt(1)) // not captured by the tracer
+
+	diff := tracer.Diff(pre, post)
+	assert.Len(t, diff, 2)
+	assert.Equal(t, big.NewInt(100), diff[from].BalanceBefore.ToInt())
+	assert.Equal(t, big.NewInt(90), diff[from].BalanceAfter.ToInt())
+	assert.Equal(t, uint64(0), uint64(*diff[from].NonceBefore))
+	assert.Equal(t, uint64(1), uint64(*diff[from].NonceAfter))
+	assert.Nil(t, diff[from].Storage)
+	assert.Equal(t, big.NewInt(10), diff[to].BalanceAfter.ToInt())
+	assert.Equal(t, &StorageDiff{Before: common.Hash{}, After: value}, diff[to].Storage[slot])
+	assert.False(t, diff[from].Created)
+	assert.True(t, diff[to].Created)
+}
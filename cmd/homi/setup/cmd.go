@@ -122,6 +122,8 @@ var HomiFlags = []cli.Flag{
 	altsrc.NewInt64Flag(ethTxTypeCompatibleBlockNumberFlag),
 	altsrc.NewInt64Flag(magmaCompatibleBlockNumberFlag),
 	altsrc.NewInt64Flag(koreCompatibleBlockNumberFlag),
+	altsrc.NewInt64Flag(shanghaiCompatibleBlockNumberFlag),
+	altsrc.NewInt64Flag(accessListCompatibleBlockNumberFlag),
 }
 
 var SetupCommand = cli.Command{
@@ -646,6 +648,8 @@ func Gen(ctx *cli.Context) error {
 	genesisJson.Config.EthTxTypeCompatibleBlock = big.NewInt(ctx.Int64(ethTxTypeCompatibleBlockNumberFlag.Name))
 	genesisJson.Config.MagmaCompatibleBlock = big.NewInt(ctx.Int64(magmaCompatibleBlockNumberFlag.Name))
 	genesisJson.Config.KoreCompatibleBlock = big.NewInt(ctx.Int64(koreCompatibleBlockNumberFlag.Name))
+	genesisJson.Config.ShanghaiCompatibleBlock = big.NewInt(ctx.Int64(shanghaiCompatibleBlockNumberFlag.Name))
+	genesisJson.Config.AccessListCompatibleBlock = big.NewInt(ctx.Int64(accessListCompatibleBlockNumberFlag.Name))
 
 	genesisJsonBytes, _ = json.MarshalIndent(genesisJson, "", "    ")
 	genValidatorKeystore(privKeys)
@@ -424,4 +424,16 @@ var (
 		Usage: "koreCompatible blockNumber",
 		Value: 0,
 	}
+
+	shanghaiCompatibleBlockNumberFlag = cli.Int64Flag{
+		Name:  "shanghai-compatible-blocknumber",
+		Usage: "shanghaiCompatible blockNumber",
+		Value: 0,
+	}
+
+	accessListCompatibleBlockNumberFlag = cli.Int64Flag{
+		Name:  "accesslist-compatible-blocknumber",
+		Usage: "accessListCompatible blockNumber",
+		Value: 0,
+	}
 )
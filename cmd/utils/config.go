@@ -36,6 +36,7 @@ import (
 	"github.com/Shopify/sarama"
 	"github.com/klaytn/klaytn/accounts"
 	"github.com/klaytn/klaytn/accounts/keystore"
+	"github.com/klaytn/klaytn/api"
 	"github.com/klaytn/klaytn/api/debug"
 	"github.com/klaytn/klaytn/blockchain"
 	"github.com/klaytn/klaytn/common"
@@ -47,6 +48,7 @@ import (
 	"github.com/klaytn/klaytn/datasync/dbsyncer"
 	"github.com/klaytn/klaytn/datasync/downloader"
 	"github.com/klaytn/klaytn/log"
+	"github.com/klaytn/klaytn/networks/grpc"
 	"github.com/klaytn/klaytn/networks/p2p"
 	"github.com/klaytn/klaytn/networks/p2p/discover"
 	"github.com/klaytn/klaytn/networks/p2p/nat"
@@ -187,6 +189,13 @@ func SetP2PConfig(ctx *cli.Context, cfg *p2p.Config) {
 
 	cfg.NoDiscovery = ctx.GlobalIsSet(NoDiscoverFlag.Name)
 
+	if ctx.GlobalBool(ReadReplicaFlag.Name) {
+		// A read replica neither dials nor accepts peers.
+		cfg.NoDiscovery = true
+		cfg.NoDial = true
+		cfg.NoListen = true
+	}
+
 	cfg.RWTimerConfig = p2p.RWTimerConfig{}
 	cfg.RWTimerConfig.Interval = ctx.GlobalUint64(RWTimerIntervalFlag.Name)
 	cfg.RWTimerConfig.WaitTime = ctx.GlobalDuration(RWTimerWaitTimeFlag.Name)
@@ -332,6 +341,8 @@ func (kCfg *KlayConfig) SetNodeConfig(ctx *cli.Context) {
 	setHTTP(ctx, cfg)
 	setWS(ctx, cfg)
 	setgRPC(ctx, cfg)
+	setGraphQL(ctx, cfg)
+	setAuthRPC(ctx, cfg)
 	setAPIConfig(ctx)
 	setNodeUserIdent(ctx, cfg)
 
@@ -348,9 +359,23 @@ func (kCfg *KlayConfig) SetNodeConfig(ctx *cli.Context) {
 	if ctx.GlobalIsSet(LightKDFFlag.Name) {
 		cfg.UseLightweightKDF = ctx.GlobalBool(LightKDFFlag.Name)
 	}
+	if ctx.GlobalIsSet(WatchOnlyFlag.Name) {
+		for _, addr := range SplitAndTrim(ctx.GlobalString(WatchOnlyFlag.Name)) {
+			if !common.IsHexAddress(addr) {
+				log.Fatalf("Option %q: invalid address %q", WatchOnlyFlag.Name, addr)
+			}
+			cfg.WatchOnlyAccounts = append(cfg.WatchOnlyAccounts, common.HexToAddress(addr))
+		}
+	}
 	if ctx.GlobalIsSet(RPCNonEthCompatibleFlag.Name) {
 		rpc.NonEthCompatible = ctx.GlobalBool(RPCNonEthCompatibleFlag.Name)
 	}
+	if ctx.GlobalIsSet(RPCDisableDeprecatedFlag.Name) {
+		rpc.DisableDeprecatedMethods = ctx.GlobalBool(RPCDisableDeprecatedFlag.Name)
+	}
+	if ctx.GlobalIsSet(RPCDeprecationNoticeFlag.Name) {
+		rpc.DeprecationNotice = ctx.GlobalBool(RPCDeprecationNoticeFlag.Name)
+	}
 }
 
 // setHTTP creates the HTTP RPC listener interface string from the set
@@ -379,6 +404,44 @@ func setHTTP(ctx *cli.Context, cfg *node.Config) {
 		rpc.ConcurrencyLimit = ctx.GlobalInt(RPCConcurrencyLimit.Name)
 		logger.Info("Set the concurrency limit of RPC-HTTP server", "limit", rpc.ConcurrencyLimit)
 	}
+	rpc.BatchRequestLimit = ctx.GlobalInt(RPCBatchRequestLimit.Name)
+	rpc.BatchMaxDuration = ctx.GlobalDuration(RPCBatchMaxDuration.Name)
+	rpc.BatchGasLimit = ctx.GlobalUint64(RPCBatchGasLimit.Name)
+	rpc.BatchConcurrency = ctx.GlobalInt(RPCBatchConcurrency.Name)
+	if ctx.GlobalIsSet(RPCRateLimitFlag.Name) {
+		limits, err := rpc.ParseMethodRateLimits(SplitAndTrim(ctx.GlobalString(RPCRateLimitFlag.Name)))
+		if err != nil {
+			log.Fatalf("Option %q: %v", RPCRateLimitFlag.Name, err)
+		}
+		rpc.MethodRateLimits = limits
+	}
+	rpc.RateLimitKey = ctx.GlobalString(RPCRateLimitKeyFlag.Name)
+	if err := rpc.ValidateRateLimitKey(rpc.RateLimitKey); err != nil {
+		log.Fatalf("Option %q: %v", RPCRateLimitKeyFlag.Name, err)
+	}
+	if ctx.GlobalIsSet(RPCRateLimitAPIKeysFlag.Name) {
+		rpc.RateLimitAPIKeys = make(map[string]bool)
+		for _, key := range SplitAndTrim(ctx.GlobalString(RPCRateLimitAPIKeysFlag.Name)) {
+			rpc.RateLimitAPIKeys[key] = true
+		}
+	}
+	if ctx.GlobalIsSet(RPCMethodTimeoutFlag.Name) {
+		timeouts, err := rpc.ParseMethodTimeouts(SplitAndTrim(ctx.GlobalString(RPCMethodTimeoutFlag.Name)))
+		if err != nil {
+			log.Fatalf("Option %q: %v", RPCMethodTimeoutFlag.Name, err)
+		}
+		rpc.MethodTimeouts = timeouts
+	}
+	rpc.AuditLogSampleRate = ctx.GlobalFloat64(RPCAuditLogSampleRateFlag.Name)
+	if rpc.AuditLogSampleRate < 0 || rpc.AuditLogSampleRate > 1 {
+		log.Fatalf("Option %q: the sample rate must be between 0 and 1", RPCAuditLogSampleRateFlag.Name)
+	}
+	rpc.AuditLogSlowCall = ctx.GlobalDuration(RPCAuditLogSlowCallFlag.Name)
+	if path := ctx.GlobalString(RPCAuditLogFlag.Name); path != "" {
+		if err := rpc.OpenAuditLog(path); err != nil {
+			log.Fatalf("Option %q: %v", RPCAuditLogFlag.Name, err)
+		}
+	}
 	if ctx.GlobalIsSet(RPCReadTimeout.Name) {
 		cfg.HTTPTimeouts.ReadTimeout = time.Duration(ctx.GlobalInt(RPCReadTimeout.Name)) * time.Second
 	}
@@ -416,6 +479,13 @@ func setWS(ctx *cli.Context, cfg *node.Config) {
 	rpc.WebsocketReadDeadline = ctx.GlobalInt64(WSReadDeadLine.Name)
 	rpc.WebsocketWriteDeadline = ctx.GlobalInt64(WSWriteDeadLine.Name)
 	rpc.MaxWebsocketConnections = int32(ctx.GlobalInt(WSMaxConnections.Name))
+	rpc.WebsocketMaxQueuedMessages = ctx.GlobalInt64(WSMaxQueuedMessages.Name)
+	rpc.WebsocketMaxSendLatency = ctx.GlobalInt64(WSMaxSendLatency.Name)
+	rpc.WebsocketNotificationBuffer = ctx.GlobalInt(WSNotificationBuffer.Name)
+	rpc.WebsocketNotificationPolicy = ctx.GlobalString(WSNotificationPolicy.Name)
+	if err := rpc.ValidateNotificationPolicy(rpc.WebsocketNotificationPolicy); err != nil {
+		log.Fatalf("Option %q: %v", WSNotificationPolicy.Name, err)
+	}
 }
 
 // setIPC creates an IPC path configuration from the set command line flags,
@@ -443,12 +513,58 @@ func setgRPC(ctx *cli.Context, cfg *node.Config) {
 	if ctx.GlobalIsSet(GRPCPortFlag.Name) {
 		cfg.GRPCPort = ctx.GlobalInt(GRPCPortFlag.Name)
 	}
+	grpc.StreamTraces = ctx.GlobalBool(GRPCStreamTracesFlag.Name)
+	grpc.StreamMaxBackfill = ctx.GlobalUint64(GRPCStreamMaxBackfillFlag.Name)
+}
+
+// setGraphQL creates the GraphQL listener interface string from the set
+// command line flags, returning empty if the GraphQL endpoint is disabled.
+func setGraphQL(ctx *cli.Context, cfg *node.Config) {
+	if ctx.GlobalBool(GraphQLEnabledFlag.Name) && cfg.GraphQLHost == "" {
+		cfg.GraphQLHost = "127.0.0.1"
+		if ctx.GlobalIsSet(GraphQLListenAddrFlag.Name) {
+			cfg.GraphQLHost = ctx.GlobalString(GraphQLListenAddrFlag.Name)
+		}
+	}
+	if ctx.GlobalIsSet(GraphQLPortFlag.Name) {
+		cfg.GraphQLPort = ctx.GlobalInt(GraphQLPortFlag.Name)
+	}
+	if ctx.GlobalIsSet(GraphQLCORSDomainFlag.Name) {
+		cfg.GraphQLCors = SplitAndTrim(ctx.GlobalString(GraphQLCORSDomainFlag.Name))
+	}
+	if ctx.GlobalIsSet(GraphQLVirtualHostsFlag.Name) {
+		cfg.GraphQLVirtualHosts = SplitAndTrim(ctx.GlobalString(GraphQLVirtualHostsFlag.Name))
+	}
+}
+
+// setAuthRPC creates the authenticated RPC listener interface string from the set
+// command line flags, returning empty if the authenticated endpoint is disabled.
+func setAuthRPC(ctx *cli.Context, cfg *node.Config) {
+	if ctx.GlobalBool(AuthRPCEnabledFlag.Name) && cfg.AuthHost == "" {
+		cfg.AuthHost = "127.0.0.1"
+		if ctx.GlobalIsSet(AuthRPCListenAddrFlag.Name) {
+			cfg.AuthHost = ctx.GlobalString(AuthRPCListenAddrFlag.Name)
+		}
+	}
+	if ctx.GlobalIsSet(AuthRPCPortFlag.Name) {
+		cfg.AuthPort = ctx.GlobalInt(AuthRPCPortFlag.Name)
+	}
+	if ctx.GlobalIsSet(AuthRPCVirtualHostsFlag.Name) {
+		cfg.AuthVirtualHosts = SplitAndTrim(ctx.GlobalString(AuthRPCVirtualHostsFlag.Name))
+	}
+	if ctx.GlobalIsSet(AuthRPCApiFlag.Name) {
+		cfg.AuthModules = SplitAndTrim(ctx.GlobalString(AuthRPCApiFlag.Name))
+	}
+	if ctx.GlobalIsSet(AuthRPCJWTSecretFlag.Name) {
+		cfg.JWTSecret = ctx.GlobalString(AuthRPCJWTSecretFlag.Name)
+	}
 }
 
 // setAPIConfig sets configurations for specific APIs.
 func setAPIConfig(ctx *cli.Context) {
 	filters.GetLogsDeadline = ctx.GlobalDuration(APIFilterGetLogsDeadlineFlag.Name)
 	filters.GetLogsMaxItems = ctx.GlobalInt(APIFilterGetLogsMaxItemsFlag.Name)
+	filters.GetLogsMaxBlockRange = ctx.GlobalUint64(APIFilterGetLogsMaxBlockRangeFlag.Name)
 }
 
 // setNodeUserIdent creates the user identifier from CLI flags.
@@ -472,8 +588,8 @@ func (kCfg *KlayConfig) SetKlayConfig(ctx *cli.Context, stack *node.Node) {
 
 	if ctx.GlobalIsSet(SyncModeFlag.Name) {
 		cfg.SyncMode = *GlobalTextMarshaler(ctx, SyncModeFlag.Name).(*downloader.SyncMode)
-		if cfg.SyncMode != downloader.FullSync && cfg.SyncMode != downloader.SnapSync {
-			log.Fatalf("Full Sync or Snap Sync (prototype) is supported only!")
+		if cfg.SyncMode != downloader.FullSync && cfg.SyncMode != downloader.SnapSync && cfg.SyncMode != downloader.LightSync {
+			log.Fatalf("Full Sync, Snap Sync (prototype) or Light Sync is supported only!")
 		}
 		if cfg.SyncMode == downloader.SnapSync {
 			logger.Info("Snap sync requested, enabling --snapshot")
@@ -482,6 +598,16 @@ func (kCfg *KlayConfig) SetKlayConfig(ctx *cli.Context, stack *node.Node) {
 			cfg.SnapshotCacheSize = 0 // Disabled
 		}
 	}
+	if ctx.GlobalIsSet(CheckpointFlag.Name) {
+		cfg.TrustedCheckpoint = ctx.GlobalString(CheckpointFlag.Name)
+		for _, addr := range SplitAndTrim(ctx.GlobalString(CheckpointSignersFlag.Name)) {
+			if !common.IsHexAddress(addr) {
+				log.Fatalf("Option %q: invalid address %q", CheckpointSignersFlag.Name, addr)
+			}
+			cfg.CheckpointSigners = append(cfg.CheckpointSigners, common.HexToAddress(addr))
+		}
+		cfg.CheckpointThreshold = ctx.GlobalInt(CheckpointThresholdFlag.Name)
+	}
 
 	if ctx.GlobalBool(KESNodeTypeServiceFlag.Name) {
 		cfg.FetcherDisable = true
@@ -513,6 +639,8 @@ func (kCfg *KlayConfig) SetKlayConfig(ctx *cli.Context, stack *node.Node) {
 	cfg.LevelDBBufferPool = !ctx.GlobalIsSet(LevelDBNoBufferPoolFlag.Name)
 	cfg.EnableDBPerfMetrics = !ctx.GlobalIsSet(DBNoPerformanceMetricsFlag.Name)
 	cfg.LevelDBCacheSize = ctx.GlobalInt(LevelDBCacheSizeFlag.Name)
+	cfg.RocksDBBlockCacheSize = ctx.GlobalInt(RocksDBBlockCacheSizeFlag.Name)
+	cfg.RocksDBCompactionStyle = ctx.GlobalString(RocksDBCompactionStyleFlag.Name)
 
 	cfg.DynamoDBConfig.TableName = ctx.GlobalString(DynamoDBTableNameFlag.Name)
 	cfg.DynamoDBConfig.Region = ctx.GlobalString(DynamoDBRegionFlag.Name)
@@ -521,6 +649,14 @@ func (kCfg *KlayConfig) SetKlayConfig(ctx *cli.Context, stack *node.Node) {
 	cfg.DynamoDBConfig.WriteCapacityUnits = ctx.GlobalInt64(DynamoDBWriteCapacityFlag.Name)
 	cfg.DynamoDBConfig.ReadOnly = ctx.GlobalBool(DynamoDBReadOnlyFlag.Name)
 
+	if ctx.GlobalBool(ReadReplicaFlag.Name) {
+		cfg.ReadReplica = true
+		cfg.WorkerDisable = true
+		cfg.DownloaderDisable = true
+		cfg.FetcherDisable = true
+		cfg.DynamoDBConfig.ReadOnly = true
+	}
+
 	if gcmode := ctx.GlobalString(GCModeFlag.Name); gcmode != "full" && gcmode != "archive" {
 		log.Fatalf("--%s must be either 'full' or 'archive'", GCModeFlag.Name)
 	}
@@ -534,6 +670,8 @@ func (kCfg *KlayConfig) SetKlayConfig(ctx *cli.Context, stack *node.Node) {
 	common.DefaultCacheType = common.CacheType(ctx.GlobalInt(CacheTypeFlag.Name))
 	cfg.TrieBlockInterval = ctx.GlobalUint(TrieBlockIntervalFlag.Name)
 	cfg.TriesInMemory = ctx.GlobalUint64(TriesInMemoryFlag.Name)
+	cfg.PeriodicMigrationInterval = ctx.GlobalUint64(PeriodicMigrationIntervalFlag.Name)
+	cfg.StateHistory = ctx.GlobalBool(StateHistoryFlag.Name)
 
 	if ctx.GlobalIsSet(CacheScaleFlag.Name) {
 		common.CacheScale = ctx.GlobalInt(CacheScaleFlag.Name)
@@ -562,12 +700,20 @@ func (kCfg *KlayConfig) SetKlayConfig(ctx *cli.Context, stack *node.Node) {
 	}
 
 	cfg.SenderTxHashIndexing = ctx.GlobalIsSet(SenderTxHashIndexingFlag.Name)
+	cfg.TxLookupLimit = ctx.GlobalUint64(TxLookupLimitFlag.Name)
+	cfg.AccountTxIndexing = ctx.GlobalIsSet(AccountTxIndexingFlag.Name)
+	cfg.InternalTxIndexing = ctx.GlobalIsSet(InternalTxIndexingFlag.Name)
+	cfg.TokenTransferIndexing = ctx.GlobalIsSet(TokenTransferIndexingFlag.Name)
+	cfg.FeeStatsIndexing = ctx.GlobalIsSet(FeeStatsIndexingFlag.Name)
+	cfg.LogIndexBackend = ctx.GlobalString(LogIndexBackendFlag.Name)
+	cfg.LogIndexEndpoint = ctx.GlobalString(LogIndexEndpointFlag.Name)
 	cfg.ParallelDBWrite = !ctx.GlobalIsSet(NoParallelDBWriteFlag.Name)
 	cfg.TrieNodeCacheConfig = statedb.TrieNodeCacheConfig{
 		CacheType: statedb.TrieNodeCacheType(ctx.GlobalString(TrieNodeCacheTypeFlag.
 			Name)).ToValid(),
 		NumFetcherPrefetchWorker:  ctx.GlobalInt(NumFetcherPrefetchWorkerFlag.Name),
 		UseSnapshotForPrefetch:    ctx.GlobalBool(UseSnapshotForPrefetchFlag.Name),
+		PrefetchBlockAccounts:     ctx.GlobalBool(PrefetchBlockAccountsFlag.Name),
 		LocalCacheSizeMiB:         ctx.GlobalInt(TrieNodeCacheLimitFlag.Name),
 		FastCacheFileDir:          ctx.GlobalString(DataDirFlag.Name) + "/fastcache",
 		FastCacheSavePeriod:       ctx.GlobalDuration(TrieNodeCacheSavePeriodFlag.Name),
@@ -587,6 +733,7 @@ func (kCfg *KlayConfig) SetKlayConfig(ctx *cli.Context, stack *node.Node) {
 		}
 	}
 	cfg.EnableInternalTxTracing = ctx.GlobalIsSet(VMTraceInternalTxFlag.Name)
+	cfg.ParallelTxWorkers = ctx.GlobalInt(VMParallelTxWorkersFlag.Name)
 
 	cfg.AutoRestartFlag = ctx.GlobalBool(AutoRestartFlag.Name)
 	cfg.RestartTimeOutFlag = ctx.GlobalDuration(RestartTimeOutFlag.Name)
@@ -598,9 +745,78 @@ func (kCfg *KlayConfig) SetKlayConfig(ctx *cli.Context, stack *node.Node) {
 	if ctx.GlobalIsSet(RPCGlobalEVMTimeoutFlag.Name) {
 		cfg.RPCEVMTimeout = ctx.GlobalDuration(RPCGlobalEVMTimeoutFlag.Name)
 	}
+	if ctx.GlobalIsSet(RPCCallStateReuseWindowFlag.Name) {
+		cfg.RPCCallStateReuseWindow = ctx.GlobalDuration(RPCCallStateReuseWindowFlag.Name)
+	}
+	cfg.RPCStateReexecLimit = ctx.GlobalUint64(RPCStateReexecLimitFlag.Name)
+	if ctx.GlobalIsSet(RPCCustomTracerTimeoutFlag.Name) {
+		cfg.RPCCustomTracerTimeout = ctx.GlobalDuration(RPCCustomTracerTimeoutFlag.Name)
+	}
+	if ctx.GlobalIsSet(RPCCustomTracerMaxResultSizeFlag.Name) {
+		if cfg.RPCCustomTracerMaxResultSize = ctx.GlobalInt(RPCCustomTracerMaxResultSizeFlag.Name); cfg.RPCCustomTracerMaxResultSize < 0 {
+			log.Fatalf("Option %q must not be negative", RPCCustomTracerMaxResultSizeFlag.Name)
+		}
+	}
+	if ctx.GlobalIsSet(RPCStandardTraceDirFlag.Name) {
+		cfg.RPCStandardTraceDir = ctx.GlobalString(RPCStandardTraceDirFlag.Name)
+	}
 	if ctx.GlobalIsSet(RPCGlobalEthTxFeeCapFlag.Name) {
 		cfg.RPCTxFeeCap = ctx.GlobalFloat64(RPCGlobalEthTxFeeCapFlag.Name)
 	}
+	if ctx.GlobalIsSet(GpoBlocksFlag.Name) {
+		if cfg.GPO.Blocks = ctx.GlobalInt(GpoBlocksFlag.Name); cfg.GPO.Blocks < 1 {
+			log.Fatalf("Option %q must be positive", GpoBlocksFlag.Name)
+		}
+	}
+	if ctx.GlobalIsSet(GpoPercentileFlag.Name) {
+		if cfg.GPO.Percentile = ctx.GlobalInt(GpoPercentileFlag.Name); cfg.GPO.Percentile < 0 || cfg.GPO.Percentile > 100 {
+			log.Fatalf("Option %q must be in the range of [0, 100]", GpoPercentileFlag.Name)
+		}
+	}
+	if ctx.GlobalIsSet(GpoMaxHeaderHistoryFlag.Name) {
+		if cfg.GPO.MaxHeaderHistory = ctx.GlobalInt(GpoMaxHeaderHistoryFlag.Name); cfg.GPO.MaxHeaderHistory < 1 {
+			log.Fatalf("Option %q must be positive", GpoMaxHeaderHistoryFlag.Name)
+		}
+	}
+	if ctx.GlobalIsSet(GpoMaxBlockHistoryFlag.Name) {
+		if cfg.GPO.MaxBlockHistory = ctx.GlobalInt(GpoMaxBlockHistoryFlag.Name); cfg.GPO.MaxBlockHistory < 1 {
+			log.Fatalf("Option %q must be positive", GpoMaxBlockHistoryFlag.Name)
+		}
+	}
+	if ctx.GlobalIsSet(RPCEthKlaytnTxModeFlag.Name) {
+		mode, err := api.ParseEthKlaytnTxMode(ctx.GlobalString(RPCEthKlaytnTxModeFlag.Name))
+		if err != nil {
+			log.Fatalf("Option %q: %v", RPCEthKlaytnTxModeFlag.Name, err)
+		}
+		cfg.RPCEthKlaytnTxMode = string(mode)
+	}
+	if ctx.GlobalIsSet(RPCTxPoolEthFormatFlag.Name) {
+		cfg.RPCTxPoolEthFormat = ctx.GlobalBool(RPCTxPoolEthFormatFlag.Name)
+	}
+	if ctx.GlobalIsSet(RPCFeePayerWhitelistFlag.Name) {
+		for _, addr := range SplitAndTrim(ctx.GlobalString(RPCFeePayerWhitelistFlag.Name)) {
+			if addr == "" {
+				continue
+			}
+			if !common.IsHexAddress(addr) {
+				log.Fatalf("Option %q: invalid address %q", RPCFeePayerWhitelistFlag.Name, addr)
+			}
+			cfg.RPCFeePayerWhitelist = append(cfg.RPCFeePayerWhitelist, common.HexToAddress(addr))
+		}
+	}
+	if ctx.GlobalIsSet(RPCReceiptsCacheSizeFlag.Name) {
+		cfg.RPCReceiptsCacheSize = ctx.GlobalInt(RPCReceiptsCacheSizeFlag.Name)
+	}
+	if ctx.GlobalIsSet(RPCEthBlocksCacheSizeFlag.Name) {
+		cfg.RPCEthBlocksCacheSize = ctx.GlobalInt(RPCEthBlocksCacheSizeFlag.Name)
+	}
+	if ctx.GlobalIsSet(RPCEthReceiptsCacheSizeFlag.Name) {
+		cfg.RPCEthReceiptsCacheSize = ctx.GlobalInt(RPCEthReceiptsCacheSizeFlag.Name)
+	}
+	cfg.RPCEthPrecomputeBlocks = ctx.GlobalBool(RPCEthPrecomputeBlocksFlag.Name)
+	if ctx.GlobalIsSet(RPCSendersCacheSizeFlag.Name) {
+		cfg.RPCSendersCacheSize = ctx.GlobalInt(RPCSendersCacheSizeFlag.Name)
+	}
 
 	// Only CNs could set BlockGenerationIntervalFlag and BlockGenerationTimeLimitFlag
 	if ctx.GlobalIsSet(BlockGenerationIntervalFlag.Name) {
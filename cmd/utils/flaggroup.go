@@ -44,8 +44,12 @@ var FlagGroups = []FlagGroup{
 			KeyStoreDirFlag,
 			IdentityFlag,
 			SyncModeFlag,
+			CheckpointFlag,
+			CheckpointSignersFlag,
+			CheckpointThresholdFlag,
 			GCModeFlag,
 			LightKDFFlag,
+			WatchOnlyFlag,
 			SrvTypeFlag,
 			ExtraDataFlag,
 			ConfigFileFlag,
@@ -92,13 +96,23 @@ var FlagGroups = []FlagGroup{
 			NumStateTrieShardsFlag,
 			LevelDBCompressionTypeFlag,
 			LevelDBNoBufferPoolFlag,
+			RocksDBBlockCacheSizeFlag,
+			RocksDBCompactionStyleFlag,
 			DynamoDBTableNameFlag,
 			DynamoDBRegionFlag,
 			DynamoDBIsProvisionedFlag,
 			DynamoDBReadCapacityFlag,
 			DynamoDBWriteCapacityFlag,
+			ReadReplicaFlag,
 			NoParallelDBWriteFlag,
 			SenderTxHashIndexingFlag,
+			TxLookupLimitFlag,
+			AccountTxIndexingFlag,
+			InternalTxIndexingFlag,
+			TokenTransferIndexingFlag,
+			FeeStatsIndexingFlag,
+			LogIndexBackendFlag,
+			LogIndexEndpointFlag,
 			DBNoPerformanceMetricsFlag,
 		},
 	},
@@ -176,6 +190,8 @@ var FlagGroups = []FlagGroup{
 			TrieMemoryCacheSizeFlag,
 			TrieBlockIntervalFlag,
 			TriesInMemoryFlag,
+			PeriodicMigrationIntervalFlag,
+			StateHistoryFlag,
 		},
 	},
 	{
@@ -188,6 +204,7 @@ var FlagGroups = []FlagGroup{
 			TrieNodeCacheTypeFlag,
 			NumFetcherPrefetchWorkerFlag,
 			UseSnapshotForPrefetchFlag,
+			PrefetchBlockAccountsFlag,
 			TrieNodeCacheLimitFlag,
 			TrieNodeCacheSavePeriodFlag,
 			TrieNodeCacheRedisEndpointsFlag,
@@ -239,6 +256,7 @@ var FlagGroups = []FlagGroup{
 			VMEnableDebugFlag,
 			VMLogTargetFlag,
 			VMTraceInternalTxFlag,
+			VMParallelTxWorkersFlag,
 		},
 	},
 	{
@@ -252,9 +270,39 @@ var FlagGroups = []FlagGroup{
 			RPCApiFlag,
 			RPCGlobalGasCap,
 			RPCGlobalEVMTimeoutFlag,
+			RPCCallStateReuseWindowFlag,
+			RPCStateReexecLimitFlag,
+			RPCCustomTracerTimeoutFlag,
+			RPCCustomTracerMaxResultSizeFlag,
+			RPCStandardTraceDirFlag,
 			RPCGlobalEthTxFeeCapFlag,
+			GpoBlocksFlag,
+			GpoPercentileFlag,
+			GpoMaxHeaderHistoryFlag,
+			GpoMaxBlockHistoryFlag,
 			RPCConcurrencyLimit,
+			RPCBatchRequestLimit,
+			RPCBatchMaxDuration,
+			RPCBatchGasLimit,
+			RPCBatchConcurrency,
+			RPCRateLimitFlag,
+			RPCRateLimitKeyFlag,
+			RPCRateLimitAPIKeysFlag,
+			RPCMethodTimeoutFlag,
+			RPCAuditLogFlag,
+			RPCAuditLogSampleRateFlag,
+			RPCAuditLogSlowCallFlag,
 			RPCNonEthCompatibleFlag,
+			RPCDisableDeprecatedFlag,
+			RPCDeprecationNoticeFlag,
+			RPCEthKlaytnTxModeFlag,
+			RPCTxPoolEthFormatFlag,
+			RPCFeePayerWhitelistFlag,
+			RPCReceiptsCacheSizeFlag,
+			RPCEthBlocksCacheSizeFlag,
+			RPCEthReceiptsCacheSizeFlag,
+			RPCEthPrecomputeBlocksFlag,
+			RPCSendersCacheSizeFlag,
 			UnsafeDebugDisableFlag,
 			IPCDisabledFlag,
 			IPCPathFlag,
@@ -266,12 +314,26 @@ var FlagGroups = []FlagGroup{
 			GRPCEnabledFlag,
 			GRPCListenAddrFlag,
 			GRPCPortFlag,
+			GRPCStreamTracesFlag,
+			GRPCStreamMaxBackfillFlag,
+			GraphQLEnabledFlag,
+			GraphQLListenAddrFlag,
+			GraphQLPortFlag,
+			GraphQLCORSDomainFlag,
+			GraphQLVirtualHostsFlag,
+			AuthRPCEnabledFlag,
+			AuthRPCListenAddrFlag,
+			AuthRPCPortFlag,
+			AuthRPCVirtualHostsFlag,
+			AuthRPCApiFlag,
+			AuthRPCJWTSecretFlag,
 			JSpathFlag,
 			ExecFlag,
 			PreloadJSFlag,
 			MaxRequestContentLengthFlag,
 			APIFilterGetLogsDeadlineFlag,
 			APIFilterGetLogsMaxItemsFlag,
+			APIFilterGetLogsMaxBlockRangeFlag,
 		},
 	},
 	{
@@ -317,6 +379,7 @@ var FlagGroups = []FlagGroup{
 			SnapshotFlag,
 			SnapshotCacheSizeFlag,
 			SnapshotAsyncGen,
+			BloomFilterSizeFlag,
 		},
 	},
 }
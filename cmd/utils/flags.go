@@ -28,13 +28,17 @@ import (
 	"strings"
 	"time"
 
+	"github.com/klaytn/klaytn/api"
 	"github.com/klaytn/klaytn/blockchain"
+	"github.com/klaytn/klaytn/blockchain/state/pruner"
 	"github.com/klaytn/klaytn/common"
 	"github.com/klaytn/klaytn/datasync/chaindatafetcher"
 	"github.com/klaytn/klaytn/datasync/chaindatafetcher/kafka"
 	"github.com/klaytn/klaytn/datasync/dbsyncer"
+	"github.com/klaytn/klaytn/graphql"
 	"github.com/klaytn/klaytn/log"
 	metricutils "github.com/klaytn/klaytn/metrics/utils"
+	"github.com/klaytn/klaytn/networks/grpc"
 	"github.com/klaytn/klaytn/networks/rpc"
 	"github.com/klaytn/klaytn/node"
 	"github.com/klaytn/klaytn/node/cn"
@@ -90,7 +94,7 @@ var (
 	}
 	DbTypeFlag = cli.StringFlag{
 		Name:   "dbtype",
-		Usage:  `Blockchain storage database type ("LevelDB", "BadgerDB", "MemoryDB", "DynamoDBS3")`,
+		Usage:  `Blockchain storage database type ("LevelDB", "BadgerDB", "MemoryDB", "DynamoDBS3", "PebbleDB", "RocksDB")`,
 		Value:  "LevelDB",
 		EnvVar: "KLAYTN_DBTYPE",
 	}
@@ -132,10 +136,26 @@ var (
 	defaultSyncMode = cn.GetDefaultConfig().SyncMode
 	SyncModeFlag    = TextMarshalerFlag{
 		Name:   "syncmode",
-		Usage:  `Blockchain sync mode ("full" or "snap")`,
+		Usage:  `Blockchain sync mode ("full", "snap" or "light")`,
 		Value:  &defaultSyncMode,
 		EnvVar: "KLAYTN_SYNCMODE",
 	}
+	CheckpointFlag = cli.StringFlag{
+		Name:   "checkpoint",
+		Usage:  "Path of a signed checkpoint manifest, whose block is taken as the pivot of fast or snap sync while the peers serve its state. The blocks before the checkpoint are still downloaded",
+		EnvVar: "KLAYTN_CHECKPOINT",
+	}
+	CheckpointSignersFlag = cli.StringFlag{
+		Name:   "checkpoint.signers",
+		Usage:  "Comma separated addresses of the trusted signers of the checkpoint manifest",
+		EnvVar: "KLAYTN_CHECKPOINT_SIGNERS",
+	}
+	CheckpointThresholdFlag = cli.IntFlag{
+		Name:   "checkpoint.threshold",
+		Usage:  "Number of the trusted signers required to sign the checkpoint manifest",
+		Value:  cn.GetDefaultConfig().CheckpointThreshold,
+		EnvVar: "KLAYTN_CHECKPOINT_THRESHOLD",
+	}
 	GCModeFlag = cli.StringFlag{
 		Name:   "gcmode",
 		Usage:  `Blockchain garbage collection mode ("full", "archive")`,
@@ -147,6 +167,11 @@ var (
 		Usage:  "Reduce key-derivation RAM & CPU usage at some expense of KDF strength",
 		EnvVar: "KLAYTN_LIGHTKDF",
 	}
+	WatchOnlyFlag = cli.StringFlag{
+		Name:   "watchonly",
+		Usage:  "Comma separated list of addresses to watch without private keys",
+		EnvVar: "KLAYTN_WATCHONLY",
+	}
 	OverwriteGenesisFlag = cli.BoolFlag{
 		Name:   "overwrite-genesis",
 		Usage:  "Overwrites genesis block with the given new genesis block for testing purpose",
@@ -274,6 +299,18 @@ var (
 		Usage:  "Disables using buffer pool for LevelDB's block allocation",
 		EnvVar: "KLAYTN_DB_LEVELDB_NO_BUFFER_POOL",
 	}
+	RocksDBBlockCacheSizeFlag = cli.IntFlag{
+		Name:   "db.rocksdb.block-cache-size",
+		Usage:  "Size of the block caches of the column families in RocksDB (MiB)",
+		Value:  768,
+		EnvVar: "KLAYTN_DB_ROCKSDB_BLOCK_CACHE_SIZE",
+	}
+	RocksDBCompactionStyleFlag = cli.StringFlag{
+		Name:   "db.rocksdb.compaction-style",
+		Usage:  `Compaction style of RocksDB ("level", "universal", "fifo")`,
+		Value:  "level",
+		EnvVar: "KLAYTN_DB_ROCKSDB_COMPACTION_STYLE",
+	}
 	DynamoDBTableNameFlag = cli.StringFlag{
 		Name:   "db.dynamo.tablename",
 		Usage:  "Specifies DynamoDB table name. This is mandatory to use dynamoDB. (Set dbtype to use DynamoDBS3)",
@@ -307,6 +344,11 @@ var (
 		Usage:  "Disables write to DynamoDB. Only read is possible.",
 		EnvVar: "KLAYTN_DB_DYNAMO_READ_ONLY",
 	}
+	ReadReplicaFlag = cli.BoolFlag{
+		Name:   "readreplica",
+		Usage:  "Serve RPC as a read replica of the DynamoDB written by a primary node. Other database types are not supported. P2P networking, consensus, block generation and database writes are disabled. The primary node should run with --gcmode archive to serve the latest states",
+		EnvVar: "KLAYTN_READREPLICA",
+	}
 	NoParallelDBWriteFlag = cli.BoolFlag{
 		Name:   "db.no-parallel-write",
 		Usage:  "Disables parallel writes of block data to persistent database",
@@ -333,6 +375,12 @@ var (
 		Usage:  "Enables snapshot data generation in background",
 		EnvVar: "KLAYTN_SNAPSHOT_BACKGROUND_GENERATION",
 	}
+	BloomFilterSizeFlag = cli.Uint64Flag{
+		Name:   "bloomfilter.size",
+		Usage:  "Megabytes of memory allocated to the bloom filter of the states to keep while pruning",
+		Value:  pruner.DefaultBloomSize,
+		EnvVar: "KLAYTN_BLOOMFILTER_SIZE",
+	}
 	TrieMemoryCacheSizeFlag = cli.IntFlag{
 		Name:   "state.cache-size",
 		Usage:  "Size of in-memory cache of the global state (in MiB) to flush matured singleton trie nodes to disk",
@@ -345,6 +393,17 @@ var (
 		Value:  blockchain.DefaultBlockInterval,
 		EnvVar: "KLAYTN_STATE_BLOCK_INTERVAL",
 	}
+	PeriodicMigrationIntervalFlag = cli.Uint64Flag{
+		Name:   "state.periodic-migration-interval",
+		Usage:  "An interval in terms of block number to migrate the whole live state to a new database and remove the old database with the stale state (0 = disabled, not for archive mode or single database)",
+		Value:  0,
+		EnvVar: "KLAYTN_STATE_PERIODIC_MIGRATION_INTERVAL",
+	}
+	StateHistoryFlag = cli.BoolFlag{
+		Name:   "state.history",
+		Usage:  "Store the reverse state diff of each block in addition to the hash-based state tries, so that a node pruning its state can serve the historical accounts and storage (there is no path-based state scheme, so it does not reduce the disk usage of an archive node)",
+		EnvVar: "KLAYTN_STATE_HISTORY",
+	}
 	TriesInMemoryFlag = cli.Uint64Flag{
 		Name:   "state.tries-in-memory",
 		Usage:  "The number of recent state tries residing in the memory",
@@ -390,6 +449,11 @@ var (
 		Usage:  "Use state snapshot functionality while prefetching",
 		EnvVar: "KLAYTN_STATEDB_CACHE_USE_SNAPSHOT_FOR_PREFETCH",
 	}
+	PrefetchBlockAccountsFlag = cli.BoolFlag{
+		Name:   "statedb.cache.prefetch-block-accounts",
+		Usage:  "Warm the senders, the recipients and the access lists of the transactions of the next block while importing a block",
+		EnvVar: "KLAYTN_STATEDB_CACHE_PREFETCH_BLOCK_ACCOUNTS",
+	}
 	TrieNodeCacheRedisEndpointsFlag = cli.StringSliceFlag{
 		Name:   "statedb.cache.redis.endpoints",
 		Usage:  "Set endpoints of redis trie node cache. More than one endpoints can be set",
@@ -427,6 +491,37 @@ var (
 		Usage:  "Enables storing mapping information of senderTxHash to txHash",
 		EnvVar: "KLAYTN_SENDERTXHASHINDEXING",
 	}
+	TxLookupLimitFlag = cli.Uint64Flag{
+		Name:   "txlookuplimit",
+		Usage:  "Number of recent blocks whose transactions are indexed by hashes, unindexing the older ones (0 = entire chain)",
+		Value:  0,
+		EnvVar: "KLAYTN_TXLOOKUPLIMIT",
+	}
+	AccountTxIndexingFlag = cli.BoolFlag{
+		Name:   "accounttxindexing",
+		Usage:  "Enables storing the transactions by their senders, recipients and fee payers",
+		EnvVar: "KLAYTN_ACCOUNTTXINDEXING",
+	}
+	LogIndexBackendFlag = cli.StringFlag{
+		Name:   "logindex.backend",
+		Usage:  "Name of the log index backend serving getLogs: \"leveldb\" for the local index by addresses and topics (empty = disabled)",
+		EnvVar: "KLAYTN_LOGINDEX_BACKEND",
+	}
+	LogIndexEndpointFlag = cli.StringFlag{
+		Name:   "logindex.endpoint",
+		Usage:  "Endpoint of the log index backend (the database directory for \"leveldb\")",
+		EnvVar: "KLAYTN_LOGINDEX_ENDPOINT",
+	}
+	TokenTransferIndexingFlag = cli.BoolFlag{
+		Name:   "tokentransferindexing",
+		Usage:  "Enables storing the KIP-7 and KIP-17 token transfers by their senders and recipients",
+		EnvVar: "KLAYTN_TOKENTRANSFERINDEXING",
+	}
+	FeeStatsIndexingFlag = cli.BoolFlag{
+		Name:   "feestatsindexing",
+		Usage:  "Enables storing the fee statistics of the blocks for fast fee history queries",
+		EnvVar: "KLAYTN_FEESTATSINDEXING",
+	}
 	ChildChainIndexingFlag = cli.BoolFlag{
 		Name:   "childchainindexing",
 		Usage:  "Enables storing transaction hash of child chain transaction for fast access to child chain data",
@@ -504,6 +599,17 @@ var (
 		Usage:  "Collect internal transaction data while processing a block",
 		EnvVar: "KLAYTN_VM_INTERNALTX",
 	}
+	VMParallelTxWorkersFlag = cli.IntFlag{
+		Name:   "vm.parallel-tx-workers",
+		Usage:  "Number of workers executing the transactions of an imported block in parallel, re-executing the conflicting ones serially (0 = serial execution)",
+		Value:  0,
+		EnvVar: "KLAYTN_VM_PARALLEL_TX_WORKERS",
+	}
+	InternalTxIndexingFlag = cli.BoolFlag{
+		Name:   "internaltxindexing",
+		Usage:  "Enables storing internal transactions collected while processing a block (implies --vm.internaltx)",
+		EnvVar: "KLAYTN_INTERNALTXINDEXING",
+	}
 
 	// Logging and debug settings
 	MetricsEnabledFlag = cli.BoolFlag{
@@ -568,22 +674,193 @@ var (
 		Usage:  "Sets a timeout used for eth_call (0=infinite)",
 		EnvVar: "KLAYTN_RPC_EVMTIMEOUT",
 	}
+	RPCCallStateReuseWindowFlag = cli.DurationFlag{
+		Name:   "rpc.callstatewindow",
+		Usage:  "Reuses the state opened for a call by the following calls against the same block within the window (0=disabled)",
+		EnvVar: "KLAYTN_RPC_CALLSTATEWINDOW",
+	}
+	RPCStateReexecLimitFlag = cli.Uint64Flag{
+		Name:   "rpc.statereexec",
+		Usage:  "Maximum number of blocks re-executed to regenerate a pruned state for calls and tracing (0=pruned states are not regenerated)",
+		Value:  cn.GetDefaultConfig().RPCStateReexecLimit,
+		EnvVar: "KLAYTN_RPC_STATEREEXEC",
+	}
+	RPCCustomTracerTimeoutFlag = cli.DurationFlag{
+		Name:   "rpc.customtracer.timeout",
+		Usage:  "Caps the timeout of the custom Javascript tracers in debug_trace* (0=no cap)",
+		EnvVar: "KLAYTN_RPC_CUSTOMTRACER_TIMEOUT",
+	}
+	RPCCustomTracerMaxResultSizeFlag = cli.IntFlag{
+		Name:   "rpc.customtracer.maxresultsize",
+		Usage:  "Limits the result size in bytes of the custom Javascript tracers in debug_trace* (0=no limit)",
+		EnvVar: "KLAYTN_RPC_CUSTOMTRACER_MAXRESULTSIZE",
+	}
+	RPCStandardTraceDirFlag = DirectoryFlag{
+		Name:   "rpc.standardtracedir",
+		Usage:  "Directory where debug_standardTraceBlockToFile dumps the traces (default = temporary directory of the OS)",
+		EnvVar: "KLAYTN_RPC_STANDARDTRACEDIR",
+	}
 	RPCGlobalEthTxFeeCapFlag = cli.Float64Flag{
 		Name:   "rpc.ethtxfeecap",
 		Usage:  "Sets a cap on transaction fee (in klay) that can be sent via the eth namespace RPC APIs (0 = no cap)",
 		EnvVar: "KLAYTN_RPC_ETHTXFEECAP",
 	}
+	GpoBlocksFlag = cli.IntFlag{
+		Name:   "gpo.blocks",
+		Usage:  "Number of recent blocks to check for gas prices",
+		Value:  cn.GetDefaultConfig().GPO.Blocks,
+		EnvVar: "KLAYTN_GPO_BLOCKS",
+	}
+	GpoPercentileFlag = cli.IntFlag{
+		Name:   "gpo.percentile",
+		Usage:  "Suggested gas price is the given percentile of a set of recent transaction gas prices",
+		Value:  cn.GetDefaultConfig().GPO.Percentile,
+		EnvVar: "KLAYTN_GPO_PERCENTILE",
+	}
+	GpoMaxHeaderHistoryFlag = cli.IntFlag{
+		Name:   "gpo.maxheaderhistory",
+		Usage:  "Maximum number of blocks served by a single fee history request without reward percentiles",
+		Value:  cn.GetDefaultConfig().GPO.MaxHeaderHistory,
+		EnvVar: "KLAYTN_GPO_MAXHEADERHISTORY",
+	}
+	GpoMaxBlockHistoryFlag = cli.IntFlag{
+		Name:   "gpo.maxblockhistory",
+		Usage:  "Maximum number of blocks served by a single fee history request with reward percentiles",
+		Value:  cn.GetDefaultConfig().GPO.MaxBlockHistory,
+		EnvVar: "KLAYTN_GPO_MAXBLOCKHISTORY",
+	}
 	RPCConcurrencyLimit = cli.IntFlag{
 		Name:   "rpc.concurrencylimit",
 		Usage:  "Sets a limit of concurrent connection number of HTTP-RPC server",
 		Value:  rpc.ConcurrencyLimit,
 		EnvVar: "KLAYTN_RPC_CONCURRENCYLIMIT",
 	}
+	RPCBatchRequestLimit = cli.IntFlag{
+		Name:   "rpc.batch.requestlimit",
+		Usage:  "Maximum number of calls in a batch request (0 = no limit)",
+		Value:  rpc.BatchRequestLimit,
+		EnvVar: "KLAYTN_RPC_BATCH_REQUESTLIMIT",
+	}
+	RPCBatchMaxDuration = cli.DurationFlag{
+		Name:   "rpc.batch.maxduration",
+		Usage:  "Maximum time to execute the calls of a batch request. The calls not started within it fail (0 = no limit)",
+		Value:  rpc.BatchMaxDuration,
+		EnvVar: "KLAYTN_RPC_BATCH_MAXDURATION",
+	}
+	RPCBatchGasLimit = cli.Uint64Flag{
+		Name:   "rpc.batch.gaslimit",
+		Usage:  "Maximum gas used by the calls of a batch request in total. The calls started after it is used up fail (0 = no limit)",
+		Value:  rpc.BatchGasLimit,
+		EnvVar: "KLAYTN_RPC_BATCH_GASLIMIT",
+	}
+	RPCBatchConcurrency = cli.IntFlag{
+		Name:   "rpc.batch.concurrency",
+		Usage:  "Maximum number of batch calls executed at the same time, interleaved fairly between the connections (0 = not scheduled)",
+		Value:  rpc.BatchConcurrency,
+		EnvVar: "KLAYTN_RPC_BATCH_CONCURRENCY",
+	}
+	RPCRateLimitFlag = cli.StringFlag{
+		Name:   "rpc.ratelimit",
+		Usage:  `Comma separated calls per second allowed for the methods, as "method=rate[:burst]". A namespace is given as "debug_*"`,
+		Value:  "",
+		EnvVar: "KLAYTN_RPC_RATELIMIT",
+	}
+	RPCRateLimitKeyFlag = cli.StringFlag{
+		Name:   "rpc.ratelimit.key",
+		Usage:  `Applies the rate limits to all calls of a method ("method"), or to the calls of each client IP ("ip") or X-API-Key header ("apikey")`,
+		Value:  rpc.RateLimitKey,
+		EnvVar: "KLAYTN_RPC_RATELIMIT_KEY",
+	}
+	RPCRateLimitAPIKeysFlag = cli.StringFlag{
+		Name:   "rpc.ratelimit.apikeys",
+		Usage:  `Comma separated API keys given their own rate limits with "apikey". The calls with the other keys or without a key share a limit`,
+		Value:  "",
+		EnvVar: "KLAYTN_RPC_RATELIMIT_APIKEYS",
+	}
+	RPCMethodTimeoutFlag = cli.StringFlag{
+		Name:   "rpc.methodtimeout",
+		Usage:  `Comma separated execution time budgets of the methods, as "method=duration". A namespace is given as "debug_*"`,
+		Value:  "",
+		EnvVar: "KLAYTN_RPC_METHODTIMEOUT",
+	}
+	RPCAuditLogFlag = cli.StringFlag{
+		Name:   "rpc.auditlog",
+		Usage:  "File to write the audit log of RPC calls as JSON lines. Parameters, API keys and client addresses are redacted (disabled if empty)",
+		Value:  "",
+		EnvVar: "KLAYTN_RPC_AUDITLOG",
+	}
+	RPCAuditLogSampleRateFlag = cli.Float64Flag{
+		Name:   "rpc.auditlog.samplerate",
+		Usage:  "Fraction of the RPC calls written to the audit log, between 0 and 1",
+		Value:  rpc.AuditLogSampleRate,
+		EnvVar: "KLAYTN_RPC_AUDITLOG_SAMPLERATE",
+	}
+	RPCAuditLogSlowCallFlag = cli.DurationFlag{
+		Name:   "rpc.auditlog.slowcall",
+		Usage:  "Duration over which an RPC call is always written to the audit log regardless of the sample rate (0 = sampled)",
+		Value:  rpc.AuditLogSlowCall,
+		EnvVar: "KLAYTN_RPC_AUDITLOG_SLOWCALL",
+	}
+	RPCEthKlaytnTxModeFlag = cli.StringFlag{
+		Name:   "rpc.eth.klaytntxmode",
+		Usage:  `Sets the representation of Klaytn transactions in the eth namespace APIs ("legacy", "typed" or "feepayer")`,
+		Value:  "legacy",
+		EnvVar: "KLAYTN_RPC_ETH_KLAYTNTXMODE",
+	}
+	RPCTxPoolEthFormatFlag = cli.BoolFlag{
+		Name:   "rpc.txpool.ethformat",
+		Usage:  "Represents the transactions of txpool_content and txpool_inspect in the Ethereum format like the eth namespace APIs",
+		EnvVar: "KLAYTN_RPC_TXPOOL_ETHFORMAT",
+	}
+	RPCFeePayerWhitelistFlag = cli.StringFlag{
+		Name:   "rpc.feepayer.whitelist",
+		Usage:  "Comma separated addresses of the accounts allowed to sign transactions as a fee payer through the RPC APIs (empty = klay_sendRawTransactionAsFeePayer disabled)",
+		EnvVar: "KLAYTN_RPC_FEEPAYER_WHITELIST",
+	}
+	RPCReceiptsCacheSizeFlag = cli.IntFlag{
+		Name:   "rpc.cache.receipts",
+		Usage:  "Number of blocks whose receipts are cached for the RPC APIs (0 = disabled)",
+		Value:  api.DefaultRPCReceiptsCacheSize,
+		EnvVar: "KLAYTN_RPC_CACHE_RECEIPTS",
+	}
+	RPCEthBlocksCacheSizeFlag = cli.IntFlag{
+		Name:   "rpc.cache.ethblocks",
+		Usage:  "Number of Ethereum-format blocks cached for the eth namespace APIs (0 = disabled)",
+		Value:  api.DefaultRPCEthBlocksCacheSize,
+		EnvVar: "KLAYTN_RPC_CACHE_ETHBLOCKS",
+	}
+	RPCEthReceiptsCacheSizeFlag = cli.IntFlag{
+		Name:   "rpc.cache.ethreceipts",
+		Usage:  "Number of Ethereum-format receipts cached for the eth namespace APIs (0 = disabled)",
+		Value:  api.DefaultRPCEthReceiptsCacheSize,
+		EnvVar: "KLAYTN_RPC_CACHE_ETHRECEIPTS",
+	}
+	RPCEthPrecomputeBlocksFlag = cli.BoolFlag{
+		Name:   "rpc.eth.precompute",
+		Usage:  "Marshals the Ethereum-format outputs of each new block and its receipts into the RPC caches at import",
+		EnvVar: "KLAYTN_RPC_ETH_PRECOMPUTE",
+	}
+	RPCSendersCacheSizeFlag = cli.IntFlag{
+		Name:   "rpc.cache.senders",
+		Usage:  "Number of recovered transaction senders cached for the RPC APIs (0 = disabled)",
+		Value:  api.DefaultRPCSendersCacheSize,
+		EnvVar: "KLAYTN_RPC_CACHE_SENDERS",
+	}
 	RPCNonEthCompatibleFlag = cli.BoolFlag{
 		Name:   "rpc.eth.noncompatible",
 		Usage:  "Disables the eth namespace API return formatting for compatibility",
 		EnvVar: "KLAYTN_RPC_ETH_NONCOMPATIBLE",
 	}
+	RPCDisableDeprecatedFlag = cli.BoolFlag{
+		Name:   "rpc.deprecated.disable",
+		Usage:  "Rejects the calls of the deprecated RPC methods (listed by rpc_deprecatedMethods)",
+		EnvVar: "KLAYTN_RPC_DEPRECATED_DISABLE",
+	}
+	RPCDeprecationNoticeFlag = cli.BoolFlag{
+		Name:   "rpc.deprecated.notice",
+		Usage:  "Adds a \"deprecated\" field with the replacement hint to the responses of the deprecated RPC methods",
+		EnvVar: "KLAYTN_RPC_DEPRECATED_NOTICE",
+	}
 	WSEnabledFlag = cli.BoolFlag{
 		Name:   "ws",
 		Usage:  "Enable the WS-RPC server",
@@ -637,6 +914,30 @@ var (
 		Value:  3000,
 		EnvVar: "KLAYTN_WSMAXCONNECTIONS",
 	}
+	WSMaxQueuedMessages = cli.Int64Flag{
+		Name:   "wsmaxqueuedmessages",
+		Usage:  "Maximum number of messages waiting to be sent on a websocket connection. A connection exceeding it is disconnected as a slow consumer. 0 means no limit",
+		Value:  rpc.WebsocketMaxQueuedMessages,
+		EnvVar: "KLAYTN_WSMAXQUEUEDMESSAGES",
+	}
+	WSMaxSendLatency = cli.Int64Flag{
+		Name:   "wsmaxsendlatency",
+		Usage:  "Maximum time in milliseconds to send a message on a websocket connection. A connection exceeding it is disconnected as a slow consumer. 0 means no limit",
+		Value:  rpc.WebsocketMaxSendLatency,
+		EnvVar: "KLAYTN_WSMAXSENDLATENCY",
+	}
+	WSNotificationBuffer = cli.IntFlag{
+		Name:   "wsnotificationbuffer",
+		Usage:  "Maximum number of subscription notifications buffered on a websocket connection to be sent asynchronously. 0 means the notifications are sent synchronously",
+		Value:  rpc.WebsocketNotificationBuffer,
+		EnvVar: "KLAYTN_WSNOTIFICATIONBUFFER",
+	}
+	WSNotificationPolicy = cli.StringFlag{
+		Name:   "wsnotificationpolicy",
+		Usage:  `Policy for the notifications overflowing the buffer of a websocket connection ("drop" drops them, "close" disconnects the connection)`,
+		Value:  rpc.WebsocketNotificationPolicy,
+		EnvVar: "KLAYTN_WSNOTIFICATIONPOLICY",
+	}
 	GRPCEnabledFlag = cli.BoolFlag{
 		Name:   "grpc",
 		Usage:  "Enable the gRPC server",
@@ -654,6 +955,80 @@ var (
 		Value:  node.DefaultGRPCPort,
 		EnvVar: "KLAYTN_GRPCPORT",
 	}
+	GRPCStreamTracesFlag = cli.BoolFlag{
+		Name:   "grpc.stream.traces",
+		Usage:  "Allow the gRPC block stream to include the traces of the transactions (re-executes every streamed block)",
+		EnvVar: "KLAYTN_GRPC_STREAM_TRACES",
+	}
+	GRPCStreamMaxBackfillFlag = cli.Uint64Flag{
+		Name:   "grpc.stream.maxbackfill",
+		Usage:  "Maximum number of blocks behind the current block which the gRPC block stream can backfill from",
+		Value:  grpc.StreamMaxBackfill,
+		EnvVar: "KLAYTN_GRPC_STREAM_MAXBACKFILL",
+	}
+	GraphQLEnabledFlag = cli.BoolFlag{
+		Name:   "graphql",
+		Usage:  "Enable the GraphQL server",
+		EnvVar: "KLAYTN_GRAPHQL",
+	}
+	GraphQLListenAddrFlag = cli.StringFlag{
+		Name:   "graphql.addr",
+		Usage:  "GraphQL server listening interface",
+		Value:  node.DefaultGraphQLHost,
+		EnvVar: "KLAYTN_GRAPHQL_ADDR",
+	}
+	GraphQLPortFlag = cli.IntFlag{
+		Name:   "graphql.port",
+		Usage:  "GraphQL server listening port",
+		Value:  node.DefaultGraphQLPort,
+		EnvVar: "KLAYTN_GRAPHQL_PORT",
+	}
+	GraphQLCORSDomainFlag = cli.StringFlag{
+		Name:   "graphql.corsdomain",
+		Usage:  "Comma separated list of domains from which to accept cross origin requests (browser enforced)",
+		Value:  "",
+		EnvVar: "KLAYTN_GRAPHQL_CORSDOMAIN",
+	}
+	GraphQLVirtualHostsFlag = cli.StringFlag{
+		Name:   "graphql.vhosts",
+		Usage:  "Comma separated list of virtual hostnames from which to accept requests (server enforced). Accepts '*' wildcard.",
+		Value:  strings.Join(node.DefaultConfig.GraphQLVirtualHosts, ","),
+		EnvVar: "KLAYTN_GRAPHQL_VHOSTS",
+	}
+	AuthRPCEnabledFlag = cli.BoolFlag{
+		Name:   "authrpc",
+		Usage:  "Enable the JWT authenticated HTTP and WS-RPC server",
+		EnvVar: "KLAYTN_AUTHRPC",
+	}
+	AuthRPCListenAddrFlag = cli.StringFlag{
+		Name:   "authrpc.addr",
+		Usage:  "Authenticated RPC server listening interface",
+		Value:  node.DefaultAuthHost,
+		EnvVar: "KLAYTN_AUTHRPC_ADDR",
+	}
+	AuthRPCPortFlag = cli.IntFlag{
+		Name:   "authrpc.port",
+		Usage:  "Authenticated RPC server listening port",
+		Value:  node.DefaultAuthPort,
+		EnvVar: "KLAYTN_AUTHRPC_PORT",
+	}
+	AuthRPCVirtualHostsFlag = cli.StringFlag{
+		Name:   "authrpc.vhosts",
+		Usage:  "Comma separated list of virtual hostnames from which to accept requests (server enforced). Accepts '*' wildcard.",
+		Value:  strings.Join(node.DefaultConfig.AuthVirtualHosts, ","),
+		EnvVar: "KLAYTN_AUTHRPC_VHOSTS",
+	}
+	AuthRPCApiFlag = cli.StringFlag{
+		Name:   "authrpc.api",
+		Usage:  "API's offered only over the authenticated RPC interface. They are removed from the other RPC interfaces if the authenticated RPC is enabled.",
+		Value:  strings.Join(node.DefaultConfig.AuthModules, ","),
+		EnvVar: "KLAYTN_AUTHRPC_API",
+	}
+	AuthRPCJWTSecretFlag = cli.StringFlag{
+		Name:   "authrpc.jwtsecret",
+		Usage:  "Path to a hex encoded 32 bytes secret used to verify the JWT tokens of the authenticated RPC (default: generated in the data directory)",
+		EnvVar: "KLAYTN_AUTHRPC_JWTSECRET",
+	}
 	IPCDisabledFlag = cli.BoolFlag{
 		Name:   "ipcdisable",
 		Usage:  "Disable the IPC-RPC server",
@@ -686,6 +1061,12 @@ var (
 		Value:  filters.GetLogsMaxItems,
 		EnvVar: "KLAYTN_API_FILTER_GETLOGS_MAXITEMS",
 	}
+	APIFilterGetLogsMaxBlockRangeFlag = cli.Uint64Flag{
+		Name:   "api.filter.getLogs.maxblockrange",
+		Usage:  "Maximum allowed number of blocks searched by log collecting filter API (0 = unlimited). Paginated queries return a cursor instead of failing",
+		Value:  filters.GetLogsMaxBlockRange,
+		EnvVar: "KLAYTN_API_FILTER_GETLOGS_MAXBLOCKRANGE",
+	}
 	RPCReadTimeout = cli.IntFlag{
 		Name:   "rpcreadtimeout",
 		Usage:  "HTTP-RPC server read timeout (seconds)",
@@ -1234,7 +1615,7 @@ var (
 	// db migration vars
 	DstDbTypeFlag = cli.StringFlag{
 		Name:   "dst.dbtype",
-		Usage:  `Blockchain storage database type ("LevelDB", "BadgerDB", "DynamoDBS3")`,
+		Usage:  `Blockchain storage database type ("LevelDB", "BadgerDB", "DynamoDBS3", "PebbleDB", "RocksDB")`,
 		Value:  "LevelDB",
 		EnvVar: "KLAYTN_DST_DBTYPE",
 	}
@@ -1406,6 +1787,20 @@ func RegisterService(stack *node.Node, cfg *sc.SCConfig) {
 	}
 }
 
+// RegisterGraphQLService adds the GraphQL service to the stack if its endpoint is configured.
+func RegisterGraphQLService(stack *node.Node, cfg *node.Config) {
+	endpoint := cfg.GraphQLEndpoint()
+	if endpoint == "" {
+		return
+	}
+	err := stack.RegisterSubService(func(ctx *node.ServiceContext) (node.Service, error) {
+		return graphql.New(endpoint, cfg.GraphQLCors, cfg.GraphQLVirtualHosts, cfg.HTTPTimeouts)
+	})
+	if err != nil {
+		log.Fatalf("Failed to register the GraphQL service: %v", err)
+	}
+}
+
 // RegisterChainDataFetcherService adds a ChainDataFetcher to the stack
 func RegisterChainDataFetcherService(stack *node.Node, cfg *chaindatafetcher.ChainDataFetcherConfig) {
 	if cfg.EnabledChainDataFetcher {
@@ -71,6 +71,7 @@ func MakeFullNode(ctx *cli.Context) *node.Node {
 	utils.RegisterService(stack, &cfg.ServiceChain)
 	utils.RegisterDBSyncerService(stack, &cfg.DB)
 	utils.RegisterChainDataFetcherService(stack, &cfg.ChainDataFetcher)
+	utils.RegisterGraphQLService(stack, &cfg.Node)
 	return stack
 }
 
@@ -51,7 +51,7 @@ var (
 		Description: `
 The migration command migrates a DB to another DB.
 The type of DBs can be different.
-(e.g. LevelDB -> LevelDB, LevelDB -> BadgerDB, LevelDB -> DynamoDB)
+(e.g. LevelDB -> LevelDB, LevelDB -> BadgerDB, LevelDB -> DynamoDB, LevelDB -> PebbleDB, LevelDB -> RocksDB)
 Note: This feature is only provided when srcDB is single LevelDB.
 Note: Do not use db migration while a node is executing.
 `,
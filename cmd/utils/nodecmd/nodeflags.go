@@ -137,12 +137,18 @@ var CommonNodeFlags = []cli.Flag{
 	altsrc.NewDurationFlag(utils.TxPoolLifetimeFlag),
 	altsrc.NewBoolFlag(utils.TxPoolKeepLocalsFlag),
 	utils.NewWrappedTextMarshalerFlag(utils.SyncModeFlag),
+	altsrc.NewStringFlag(utils.CheckpointFlag),
+	altsrc.NewStringFlag(utils.CheckpointSignersFlag),
+	altsrc.NewIntFlag(utils.CheckpointThresholdFlag),
 	altsrc.NewStringFlag(utils.GCModeFlag),
 	altsrc.NewBoolFlag(utils.LightKDFFlag),
+	altsrc.NewStringFlag(utils.WatchOnlyFlag),
 	altsrc.NewBoolFlag(utils.SingleDBFlag),
 	altsrc.NewUintFlag(utils.NumStateTrieShardsFlag),
 	altsrc.NewIntFlag(utils.LevelDBCompressionTypeFlag),
 	altsrc.NewBoolFlag(utils.LevelDBNoBufferPoolFlag),
+	altsrc.NewIntFlag(utils.RocksDBBlockCacheSizeFlag),
+	altsrc.NewStringFlag(utils.RocksDBCompactionStyleFlag),
 	altsrc.NewBoolFlag(utils.DBNoPerformanceMetricsFlag),
 	altsrc.NewStringFlag(utils.DynamoDBTableNameFlag),
 	altsrc.NewStringFlag(utils.DynamoDBRegionFlag),
@@ -150,12 +156,22 @@ var CommonNodeFlags = []cli.Flag{
 	altsrc.NewInt64Flag(utils.DynamoDBReadCapacityFlag),
 	altsrc.NewInt64Flag(utils.DynamoDBWriteCapacityFlag),
 	altsrc.NewBoolFlag(utils.DynamoDBReadOnlyFlag),
+	altsrc.NewBoolFlag(utils.ReadReplicaFlag),
 	altsrc.NewIntFlag(utils.LevelDBCacheSizeFlag),
 	altsrc.NewBoolFlag(utils.NoParallelDBWriteFlag),
 	altsrc.NewBoolFlag(utils.SenderTxHashIndexingFlag),
+	altsrc.NewUint64Flag(utils.TxLookupLimitFlag),
+	altsrc.NewBoolFlag(utils.AccountTxIndexingFlag),
+	altsrc.NewBoolFlag(utils.InternalTxIndexingFlag),
+	altsrc.NewBoolFlag(utils.TokenTransferIndexingFlag),
+	altsrc.NewBoolFlag(utils.FeeStatsIndexingFlag),
+	altsrc.NewStringFlag(utils.LogIndexBackendFlag),
+	altsrc.NewStringFlag(utils.LogIndexEndpointFlag),
 	altsrc.NewIntFlag(utils.TrieMemoryCacheSizeFlag),
 	altsrc.NewUintFlag(utils.TrieBlockIntervalFlag),
 	altsrc.NewUint64Flag(utils.TriesInMemoryFlag),
+	altsrc.NewUint64Flag(utils.PeriodicMigrationIntervalFlag),
+	altsrc.NewBoolFlag(utils.StateHistoryFlag),
 	altsrc.NewIntFlag(utils.CacheTypeFlag),
 	altsrc.NewIntFlag(utils.CacheScaleFlag),
 	altsrc.NewStringFlag(utils.CacheUsageLevelFlag),
@@ -163,6 +179,7 @@ var CommonNodeFlags = []cli.Flag{
 	altsrc.NewStringFlag(utils.TrieNodeCacheTypeFlag),
 	altsrc.NewIntFlag(utils.NumFetcherPrefetchWorkerFlag),
 	altsrc.NewBoolFlag(utils.UseSnapshotForPrefetchFlag),
+	altsrc.NewBoolFlag(utils.PrefetchBlockAccountsFlag),
 	altsrc.NewIntFlag(utils.TrieNodeCacheLimitFlag),
 	altsrc.NewDurationFlag(utils.TrieNodeCacheSavePeriodFlag),
 	altsrc.NewStringSliceFlag(utils.TrieNodeCacheRedisEndpointsFlag),
@@ -186,10 +203,21 @@ var CommonNodeFlags = []cli.Flag{
 	altsrc.NewBoolFlag(utils.VMEnableDebugFlag),
 	altsrc.NewIntFlag(utils.VMLogTargetFlag),
 	altsrc.NewBoolFlag(utils.VMTraceInternalTxFlag),
+	altsrc.NewIntFlag(utils.VMParallelTxWorkersFlag),
 	altsrc.NewUint64Flag(utils.NetworkIdFlag),
 	altsrc.NewStringFlag(utils.RPCCORSDomainFlag),
 	altsrc.NewStringFlag(utils.RPCVirtualHostsFlag),
 	altsrc.NewBoolFlag(utils.RPCNonEthCompatibleFlag),
+	altsrc.NewBoolFlag(utils.RPCDisableDeprecatedFlag),
+	altsrc.NewBoolFlag(utils.RPCDeprecationNoticeFlag),
+	altsrc.NewStringFlag(utils.RPCEthKlaytnTxModeFlag),
+	altsrc.NewBoolFlag(utils.RPCTxPoolEthFormatFlag),
+	altsrc.NewStringFlag(utils.RPCFeePayerWhitelistFlag),
+	altsrc.NewIntFlag(utils.RPCReceiptsCacheSizeFlag),
+	altsrc.NewIntFlag(utils.RPCEthBlocksCacheSizeFlag),
+	altsrc.NewIntFlag(utils.RPCEthReceiptsCacheSizeFlag),
+	altsrc.NewBoolFlag(utils.RPCEthPrecomputeBlocksFlag),
+	altsrc.NewIntFlag(utils.RPCSendersCacheSizeFlag),
 	altsrc.NewBoolFlag(utils.MetricsEnabledFlag),
 	altsrc.NewBoolFlag(utils.PrometheusExporterFlag),
 	altsrc.NewIntFlag(utils.PrometheusExporterPortFlag),
@@ -200,6 +228,7 @@ var CommonNodeFlags = []cli.Flag{
 	altsrc.NewStringFlag(utils.DaemonPathFlag),
 	altsrc.NewStringFlag(utils.ConfigFileFlag),
 	altsrc.NewIntFlag(utils.APIFilterGetLogsMaxItemsFlag),
+	altsrc.NewUint64Flag(utils.APIFilterGetLogsMaxBlockRangeFlag),
 	altsrc.NewDurationFlag(utils.APIFilterGetLogsDeadlineFlag),
 	altsrc.NewUint64Flag(utils.OpcodeComputationCostLimitFlag),
 	altsrc.NewBoolFlag(utils.SnapshotFlag),
@@ -215,20 +244,57 @@ var CommonRPCFlags = []cli.Flag{
 	altsrc.NewStringFlag(utils.RPCApiFlag),
 	altsrc.NewUint64Flag(utils.RPCGlobalGasCap),
 	altsrc.NewDurationFlag(utils.RPCGlobalEVMTimeoutFlag),
+	altsrc.NewDurationFlag(utils.RPCCallStateReuseWindowFlag),
+	altsrc.NewUint64Flag(utils.RPCStateReexecLimitFlag),
+	altsrc.NewDurationFlag(utils.RPCCustomTracerTimeoutFlag),
+	altsrc.NewIntFlag(utils.RPCCustomTracerMaxResultSizeFlag),
+	utils.NewWrappedDirectoryFlag(utils.RPCStandardTraceDirFlag),
 	altsrc.NewFloat64Flag(utils.RPCGlobalEthTxFeeCapFlag),
+	altsrc.NewIntFlag(utils.GpoBlocksFlag),
+	altsrc.NewIntFlag(utils.GpoPercentileFlag),
+	altsrc.NewIntFlag(utils.GpoMaxHeaderHistoryFlag),
+	altsrc.NewIntFlag(utils.GpoMaxBlockHistoryFlag),
 	altsrc.NewBoolFlag(utils.WSEnabledFlag),
 	altsrc.NewStringFlag(utils.WSListenAddrFlag),
 	altsrc.NewIntFlag(utils.WSPortFlag),
 	altsrc.NewBoolFlag(utils.GRPCEnabledFlag),
 	altsrc.NewStringFlag(utils.GRPCListenAddrFlag),
 	altsrc.NewIntFlag(utils.GRPCPortFlag),
+	altsrc.NewBoolFlag(utils.GRPCStreamTracesFlag),
+	altsrc.NewUint64Flag(utils.GRPCStreamMaxBackfillFlag),
+	altsrc.NewBoolFlag(utils.GraphQLEnabledFlag),
+	altsrc.NewStringFlag(utils.GraphQLListenAddrFlag),
+	altsrc.NewIntFlag(utils.GraphQLPortFlag),
+	altsrc.NewStringFlag(utils.GraphQLCORSDomainFlag),
+	altsrc.NewStringFlag(utils.GraphQLVirtualHostsFlag),
+	altsrc.NewBoolFlag(utils.AuthRPCEnabledFlag),
+	altsrc.NewStringFlag(utils.AuthRPCListenAddrFlag),
+	altsrc.NewIntFlag(utils.AuthRPCPortFlag),
+	altsrc.NewStringFlag(utils.AuthRPCVirtualHostsFlag),
+	altsrc.NewStringFlag(utils.AuthRPCApiFlag),
+	altsrc.NewStringFlag(utils.AuthRPCJWTSecretFlag),
 	altsrc.NewIntFlag(utils.RPCConcurrencyLimit),
+	altsrc.NewIntFlag(utils.RPCBatchRequestLimit),
+	altsrc.NewDurationFlag(utils.RPCBatchMaxDuration),
+	altsrc.NewUint64Flag(utils.RPCBatchGasLimit),
+	altsrc.NewIntFlag(utils.RPCBatchConcurrency),
+	altsrc.NewStringFlag(utils.RPCRateLimitFlag),
+	altsrc.NewStringFlag(utils.RPCRateLimitKeyFlag),
+	altsrc.NewStringFlag(utils.RPCRateLimitAPIKeysFlag),
+	altsrc.NewStringFlag(utils.RPCMethodTimeoutFlag),
+	altsrc.NewStringFlag(utils.RPCAuditLogFlag),
+	altsrc.NewFloat64Flag(utils.RPCAuditLogSampleRateFlag),
+	altsrc.NewDurationFlag(utils.RPCAuditLogSlowCallFlag),
 	altsrc.NewStringFlag(utils.WSApiFlag),
 	altsrc.NewStringFlag(utils.WSAllowedOriginsFlag),
 	altsrc.NewIntFlag(utils.WSMaxSubscriptionPerConn),
 	altsrc.NewInt64Flag(utils.WSReadDeadLine),
 	altsrc.NewInt64Flag(utils.WSWriteDeadLine),
 	altsrc.NewIntFlag(utils.WSMaxConnections),
+	altsrc.NewInt64Flag(utils.WSMaxQueuedMessages),
+	altsrc.NewInt64Flag(utils.WSMaxSendLatency),
+	altsrc.NewIntFlag(utils.WSNotificationBuffer),
+	altsrc.NewStringFlag(utils.WSNotificationPolicy),
 	altsrc.NewBoolFlag(utils.IPCDisabledFlag),
 	utils.NewWrappedDirectoryFlag(utils.IPCPathFlag),
 	altsrc.NewIntFlag(utils.RPCReadTimeout),
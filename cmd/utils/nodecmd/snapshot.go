@@ -28,6 +28,7 @@ import (
 	"time"
 
 	"github.com/klaytn/klaytn/blockchain/state"
+	"github.com/klaytn/klaytn/blockchain/state/pruner"
 	"github.com/klaytn/klaytn/cmd/utils"
 	"github.com/klaytn/klaytn/common"
 	"github.com/klaytn/klaytn/snapshot"
@@ -110,6 +111,33 @@ reading all nodes and logging the missing nodes.
 			Description: `
 klaytn statedb iterate-triedb
 Count the number of nodes in the state-trie db.
+`,
+		},
+		{
+			Name:      "prune-state",
+			Usage:     "Prune stale state data based on the state of the given root",
+			ArgsUsage: "<root>",
+			Action:    utils.MigrateFlags(pruneState),
+			Flags: []cli.Flag{
+				utils.DbTypeFlag,
+				utils.SingleDBFlag,
+				utils.NumStateTrieShardsFlag,
+				utils.DynamoDBTableNameFlag,
+				utils.DynamoDBRegionFlag,
+				utils.DynamoDBIsProvisionedFlag,
+				utils.DynamoDBReadCapacityFlag,
+				utils.DynamoDBWriteCapacityFlag,
+				utils.LevelDBCompressionTypeFlag,
+				utils.DataDirFlag,
+				utils.BloomFilterSizeFlag,
+			},
+			Description: `
+klay snapshot prune-state <state-root>
+will remove all the state trie nodes and contract codes which do not belong to
+the state of the given root and the genesis state. If a root is not given, the
+state of the latest block persisted on disk is kept.
+The node must be stopped while pruning. After pruning, the node restarts from
+the block whose state is kept.
 `,
 		},
 	},
@@ -199,6 +227,38 @@ func verifyState(ctx *cli.Context) error {
 	return nil
 }
 
+// pruneState removes the state data which are not reachable from the state of the given root.
+// If a root hash isn't given, the state of the latest block persisted on disk is kept.
+func pruneState(ctx *cli.Context) error {
+	stack := MakeFullNode(ctx)
+	db := stack.OpenDatabase(getConfig(ctx))
+	defer db.Close()
+
+	if ctx.NArg() > 1 {
+		logger.Error("Too many arguments given")
+		return errors.New("too many arguments")
+	}
+	var root common.Hash
+	if ctx.NArg() == 1 {
+		var err error
+		root, err = parseRoot(ctx.Args().First())
+		if err != nil {
+			logger.Error("Failed to resolve state root", "err", err)
+			return err
+		}
+	}
+	p, err := pruner.NewPruner(db, ctx.GlobalUint64(utils.BloomFilterSizeFlag.Name))
+	if err != nil {
+		logger.Error("Failed to open the state pruner", "err", err)
+		return err
+	}
+	if err := p.Prune(root); err != nil {
+		logger.Error("Failed to prune the state", "err", err)
+		return err
+	}
+	return nil
+}
+
 func traceTrie(ctx *cli.Context) error {
 	var childWait, logWait sync.WaitGroup
 
@@ -157,5 +157,6 @@ type ConsensusInfo struct {
 	Proposer       common.Address
 	OriginProposer common.Address // the proposal of 0 round at the same block number
 	Committee      []common.Address
+	Committers     []common.Address // the validators who signed the committed seals of the block
 	Round          byte
 }
@@ -21,6 +21,7 @@
 package backend
 
 import (
+	"context"
 	"errors"
 	"fmt"
 	"math/big"
@@ -30,6 +31,7 @@ import (
 	"github.com/klaytn/klaytn/blockchain"
 	"github.com/klaytn/klaytn/blockchain/types"
 	"github.com/klaytn/klaytn/common"
+	"github.com/klaytn/klaytn/common/hexutil"
 	"github.com/klaytn/klaytn/consensus"
 	"github.com/klaytn/klaytn/consensus/istanbul"
 	"github.com/klaytn/klaytn/networks/rpc"
@@ -203,11 +205,24 @@ var (
 	errStartNotPositive        = errors.New("start block number should be positive")
 	errEndLargetThanLatest     = errors.New("end block number should be smaller than the latest block number")
 	errStartLargerThanEnd      = errors.New("start should be smaller than end")
-	errRequestedBlocksTooLarge = errors.New("number of requested blocks should be smaller than 50")
+	errRequestedBlocksTooLarge = fmt.Errorf("number of requested blocks should be smaller than %d", maxConsensusInfoBatchSize)
 	errRangeNil                = errors.New("range values should not be nil")
 	errExtractIstanbulExtra    = errors.New("extract Istanbul Extra from block header of the given block number")
 	errNoBlockExist            = errors.New("block with the given block number is not existed")
 	errNoBlockNumber           = errors.New("block number is not assigned")
+	errZeroBatchSize           = errors.New("number of requested blocks should be positive")
+	errBackfillTooLarge        = fmt.Errorf("fromBlock should not be older than %d blocks from the latest block", maxConsensusInfoBackfill)
+)
+
+const (
+	// maxConsensusInfoBatchSize is the maximum number of blocks returned by GetBlocksWithConsensusInfo
+	// and GetBlockWithConsensusInfoByNumberRange.
+	maxConsensusInfoBatchSize = 50
+	// maxConsensusInfoBatchTxs limits the size of a response of GetBlocksWithConsensusInfo.
+	// A batch is cut once it contains this many transactions, but it always contains at least one block.
+	maxConsensusInfoBatchTxs = 10000
+	// maxConsensusInfoBackfill is the maximum number of past blocks sent by NewBlocksWithConsensusInfo.
+	maxConsensusInfoBackfill = 1000
 )
 
 // GetCouncil retrieves the list of authorized validators at the specified block.
@@ -322,6 +337,7 @@ func (api *APIExtension) makeRPCBlockOutput(b *types.Block,
 	}
 
 	r["committee"] = cInfo.Committee
+	r["committers"] = cInfo.Committers
 	r["proposer"] = cInfo.Proposer
 	r["round"] = cInfo.Round
 	r["originProposer"] = cInfo.OriginProposer
@@ -406,8 +422,8 @@ func (api *APIExtension) GetBlockWithConsensusInfoByNumberRange(start *rpc.Block
 		return nil, errStartLargerThanEnd
 	}
 
-	if (e - s) > 50 {
-		logger.Trace("number of requested blocks should be smaller than 50", "start", s, "end", e)
+	if (e - s) > maxConsensusInfoBatchSize {
+		logger.Trace("number of requested blocks is too large", "start", s, "end", e, "max", maxConsensusInfoBatchSize)
 		return nil, errRequestedBlocksTooLarge
 	}
 
@@ -428,6 +444,130 @@ func (api *APIExtension) GetBlockWithConsensusInfoByNumberRange(start *rpc.Block
 	return blocks, nil
 }
 
+// BlocksWithConsensusInfo is a batch of blocks with consensus information.
+type BlocksWithConsensusInfo struct {
+	Blocks []map[string]interface{} `json:"blocks"`
+	Next   hexutil.Uint64           `json:"next"` // the block number to request the next batch from
+}
+
+// GetBlocksWithConsensusInfo returns the blocks with consensus information from start in ascending order.
+// At most count (or maxConsensusInfoBatchSize if not given) blocks are returned, and the batch can be cut
+// earlier if it contains too many transactions. Callers can iterate the chain by requesting from Next;
+// if start is larger than the latest block number, an empty batch is returned.
+func (api *APIExtension) GetBlocksWithConsensusInfo(start rpc.BlockNumber, count *hexutil.Uint64) (*BlocksWithConsensusInfo, error) {
+	if start < 0 {
+		return nil, errStartNotPositive
+	}
+	size := uint64(maxConsensusInfoBatchSize)
+	if count != nil {
+		if *count == 0 {
+			return nil, errZeroBatchSize
+		}
+		if uint64(*count) < size {
+			size = uint64(*count)
+		}
+	}
+
+	var (
+		next   = uint64(start)
+		latest = api.chain.CurrentHeader().Number.Uint64()
+		batch  = &BlocksWithConsensusInfo{Blocks: []map[string]interface{}{}}
+		numTxs = 0
+	)
+	for ; next <= latest && uint64(len(batch.Blocks)) < size && numTxs < maxConsensusInfoBatchTxs; next++ {
+		blockNum := rpc.BlockNumber(next)
+		b, err := api.GetBlockWithConsensusInfoByNumber(&blockNum)
+		if err != nil {
+			return nil, err
+		}
+		if txs, ok := b["transactions"].([]map[string]interface{}); ok {
+			numTxs += len(txs)
+		}
+		batch.Blocks = append(batch.Blocks, b)
+	}
+	batch.Next = hexutil.Uint64(next)
+	return batch, nil
+}
+
+// NewBlocksWithConsensusInfo sends a notification with consensus information for each new block.
+// If fromBlock is given, the blocks from fromBlock to the latest block are sent first,
+// which should not be older than maxConsensusInfoBackfill blocks.
+func (api *APIExtension) NewBlocksWithConsensusInfo(ctx context.Context, fromBlock *rpc.BlockNumber) (*rpc.Subscription, error) {
+	bc, ok := api.chain.(*blockchain.BlockChain)
+	if !ok {
+		logger.Error("chain is not a type of blockchain.BlockChain", "type", reflect.TypeOf(api.chain))
+		return nil, errInternalError
+	}
+	notifier, supported := rpc.NotifierFromContext(ctx)
+	if !supported {
+		return &rpc.Subscription{}, rpc.ErrNotificationsUnsupported
+	}
+
+	latest := bc.CurrentHeader().Number.Uint64()
+	next := latest + 1
+	if fromBlock != nil && *fromBlock >= 0 {
+		from := uint64(*fromBlock)
+		if from+maxConsensusInfoBackfill <= latest {
+			return nil, errBackfillTooLarge
+		}
+		if from < next {
+			next = from
+		}
+	}
+
+	rpcSub := notifier.CreateSubscription()
+
+	go func() {
+		// notifyUpTo sends the blocks from next to the given number, so that no block is
+		// skipped even if the chain head events are coalesced.
+		notifyUpTo := func(number uint64) bool {
+			for ; next <= number; next++ {
+				select {
+				case <-rpcSub.Err():
+					return false
+				case <-notifier.Closed():
+					return false
+				default:
+				}
+				blockNum := rpc.BlockNumber(next)
+				b, err := api.GetBlockWithConsensusInfoByNumber(&blockNum)
+				if err != nil {
+					logger.Debug("Failed to get a block with consensus info for subscription", "number", next, "err", err)
+					continue
+				}
+				if err := notifier.Notify(rpcSub.ID, b); err != nil {
+					return false
+				}
+			}
+			return true
+		}
+		if !notifyUpTo(latest) {
+			return
+		}
+
+		// Subscribe after sending the past blocks not to block the chain head feed.
+		// The blocks inserted meanwhile are sent with the next chain head event.
+		heads := make(chan blockchain.ChainHeadEvent, 10)
+		sub := bc.SubscribeChainHeadEvent(heads)
+		defer sub.Unsubscribe()
+
+		for {
+			select {
+			case ev := <-heads:
+				if !notifyUpTo(ev.Block.NumberU64()) {
+					return
+				}
+			case <-rpcSub.Err():
+				return
+			case <-notifier.Closed():
+				return
+			}
+		}
+	}()
+
+	return rpcSub, nil
+}
+
 func (api *APIExtension) GetBlockWithConsensusInfoByHash(blockHash common.Hash) (map[string]interface{}, error) {
 	b, ok := api.chain.(*blockchain.BlockChain)
 	if !ok {
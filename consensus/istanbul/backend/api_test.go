@@ -0,0 +1,214 @@
+// Copyright 2022 The klaytn Authors
+// This file is part of the klaytn library.
+//
+// The klaytn library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The klaytn library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the klaytn library. If not, see <http://www.gnu.org/licenses/>.
+
+package backend
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/klaytn/klaytn/blockchain"
+	"github.com/klaytn/klaytn/blockchain/types"
+	"github.com/klaytn/klaytn/common"
+	"github.com/klaytn/klaytn/common/hexutil"
+	"github.com/klaytn/klaytn/networks/rpc"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// insertBlocks inserts n sealed blocks on top of the current block of the chain.
+func insertBlocks(t *testing.T, chain *blockchain.BlockChain, engine *backend, n int) {
+	block := chain.CurrentBlock()
+	for i := 0; i < n; i++ {
+		block = makeBlockWithSeal(chain, engine, block)
+		_, err := chain.InsertChain(types.Blocks{block})
+		require.NoError(t, err)
+	}
+}
+
+// blockNumbers returns the numbers of the given blocks with consensus information.
+func blockNumbers(blocks []map[string]interface{}) []uint64 {
+	numbers := make([]uint64, len(blocks))
+	for i, b := range blocks {
+		numbers[i] = b["number"].(*hexutil.Big).ToInt().Uint64()
+	}
+	return numbers
+}
+
+func TestGetBlocksWithConsensusInfo(t *testing.T) {
+	chain, engine := newBlockChain(1, blockPeriod(0))
+	defer engine.Stop()
+
+	insertBlocks(t, chain, engine, maxConsensusInfoBatchSize+5)
+	latest := chain.CurrentBlock().NumberU64()
+	api := &APIExtension{chain: chain, istanbul: engine}
+
+	// The blocks are split into the batches of at most maxConsensusInfoBatchSize blocks.
+	var (
+		next    = rpc.BlockNumber(0)
+		batches = 0
+		numbers []uint64
+	)
+	for uint64(next) <= latest {
+		batch, err := api.GetBlocksWithConsensusInfo(next, nil)
+		require.NoError(t, err)
+		require.NotEmpty(t, batch.Blocks)
+		assert.LessOrEqual(t, len(batch.Blocks), maxConsensusInfoBatchSize)
+		for _, b := range batch.Blocks {
+			assert.Equal(t, engine.address, b["proposer"])
+		}
+		numbers = append(numbers, blockNumbers(batch.Blocks)...)
+		next = rpc.BlockNumber(batch.Next)
+		batches++
+	}
+	assert.Equal(t, 2, batches)
+	require.Len(t, numbers, int(latest)+1)
+	for i, number := range numbers {
+		assert.Equal(t, uint64(i), number)
+	}
+
+	// The batch size is limited by the given count, which cannot exceed maxConsensusInfoBatchSize.
+	count := hexutil.Uint64(3)
+	batch, err := api.GetBlocksWithConsensusInfo(10, &count)
+	require.NoError(t, err)
+	assert.Equal(t, []uint64{10, 11, 12}, blockNumbers(batch.Blocks))
+	assert.Equal(t, hexutil.Uint64(13), batch.Next)
+
+	count = hexutil.Uint64(maxConsensusInfoBatchSize + 1)
+	batch, err = api.GetBlocksWithConsensusInfo(0, &count)
+	require.NoError(t, err)
+	assert.Len(t, batch.Blocks, maxConsensusInfoBatchSize)
+
+	// An empty batch is returned beyond the latest block.
+	batch, err = api.GetBlocksWithConsensusInfo(rpc.BlockNumber(latest+1), nil)
+	require.NoError(t, err)
+	assert.Empty(t, batch.Blocks)
+	assert.Equal(t, hexutil.Uint64(latest+1), batch.Next)
+
+	// Invalid requests are rejected.
+	count = 0
+	_, err = api.GetBlocksWithConsensusInfo(0, &count)
+	assert.Equal(t, errZeroBatchSize, err)
+	_, err = api.GetBlocksWithConsensusInfo(rpc.LatestBlockNumber, nil)
+	assert.Equal(t, errStartNotPositive, err)
+}
+
+// consensusInfoBlock is a part of a block with consensus information sent by a subscription.
+type consensusInfoBlock struct {
+	Number   hexutil.Big    `json:"number"`
+	Proposer common.Address `json:"proposer"`
+}
+
+// subscribeBlocksWithConsensusInfo subscribes the blocks with consensus information from the given block.
+func subscribeBlocksWithConsensusInfo(t *testing.T, api *APIExtension, fromBlock rpc.BlockNumber, ch chan *consensusInfoBlock) (*rpc.ClientSubscription, func(), error) {
+	server := rpc.NewServer()
+	if err := server.RegisterName("klay", api); err != nil {
+		t.Fatal(err)
+	}
+	client := rpc.DialInProc(server)
+	closeFn := func() {
+		client.Close()
+		server.Stop()
+	}
+	sub, err := client.Subscribe(context.Background(), "klay", ch, "newBlocksWithConsensusInfo", fromBlock)
+	if err != nil {
+		closeFn()
+		return nil, nil, err
+	}
+	return sub, closeFn, nil
+}
+
+func TestNewBlocksWithConsensusInfo(t *testing.T) {
+	chain, engine := newBlockChain(1, blockPeriod(0))
+	defer engine.Stop()
+
+	insertBlocks(t, chain, engine, 5)
+	api := &APIExtension{chain: chain, istanbul: engine}
+
+	ch := make(chan *consensusInfoBlock)
+	sub, closeFn, err := subscribeBlocksWithConsensusInfo(t, api, 3, ch)
+	require.NoError(t, err)
+	defer closeFn()
+	defer sub.Unsubscribe()
+
+	receive := func(timeout time.Duration) *consensusInfoBlock {
+		select {
+		case b := <-ch:
+			return b
+		case err := <-sub.Err():
+			t.Fatalf("Subscription failed: %v", err)
+		case <-time.After(timeout):
+		}
+		return nil
+	}
+
+	// The past blocks from fromBlock are sent first.
+	next := uint64(3)
+	for ; next <= 5; next++ {
+		b := receive(5 * time.Second)
+		require.NotNil(t, b, "block %d", next)
+		assert.Equal(t, next, b.Number.ToInt().Uint64())
+		assert.Equal(t, engine.address, b.Proposer)
+	}
+
+	// The new blocks are sent in order as they are inserted. The chain head event can be
+	// emitted before the subscription of the chain head events, so a block is inserted again
+	// if nothing is sent for a while. No block should be skipped in any case.
+	deadline := time.Now().Add(5 * time.Second)
+	for next <= 7 && time.Now().Before(deadline) {
+		if chain.CurrentBlock().NumberU64() < next {
+			insertBlocks(t, chain, engine, 1)
+		}
+		b := receive(100 * time.Millisecond)
+		if b == nil {
+			insertBlocks(t, chain, engine, 1)
+			continue
+		}
+		assert.Equal(t, next, b.Number.ToInt().Uint64())
+		next++
+	}
+	assert.Equal(t, uint64(8), next, "timed out waiting for the new blocks")
+}
+
+func TestNewBlocksWithConsensusInfo_BackfillLimit(t *testing.T) {
+	chain, engine := newBlockChain(1, blockPeriod(0))
+	defer engine.Stop()
+
+	insertBlocks(t, chain, engine, maxConsensusInfoBackfill)
+	latest := chain.CurrentBlock().NumberU64()
+	api := &APIExtension{chain: chain, istanbul: engine}
+
+	// The subscription is rejected if fromBlock is older than maxConsensusInfoBackfill blocks.
+	ch := make(chan *consensusInfoBlock, maxConsensusInfoBackfill)
+	_, _, err := subscribeBlocksWithConsensusInfo(t, api, rpc.BlockNumber(latest-maxConsensusInfoBackfill), ch)
+	assert.EqualError(t, err, errBackfillTooLarge.Error())
+
+	// The oldest block allowed is sent first.
+	sub, closeFn, err := subscribeBlocksWithConsensusInfo(t, api, rpc.BlockNumber(latest-maxConsensusInfoBackfill+1), ch)
+	require.NoError(t, err)
+	defer closeFn()
+	defer sub.Unsubscribe()
+
+	select {
+	case b := <-ch:
+		assert.Equal(t, latest-maxConsensusInfoBackfill+1, b.Number.ToInt().Uint64())
+	case err := <-sub.Err():
+		t.Fatalf("Subscription failed: %v", err)
+	case <-time.After(5 * time.Second):
+		t.Fatal("Timed out waiting for the past blocks")
+	}
+}
@@ -756,34 +756,25 @@ func (sb *backend) GetConsensusInfo(block *types.Block) (consensus.ConsensusInfo
 		committeeAddrs[i] = v.Address()
 	}
 
-	// verify the committee list of the block using istanbul
-	//proposalSeal := istanbulCore.PrepareCommittedSeal(block.Hash())
-	//extra, err := types.ExtractIstanbulExtra(block.Header())
-	//istanbulAddrs := make([]common.Address, len(committeeAddrs))
-	//for i, seal := range extra.CommittedSeal {
-	//	addr, err := istanbul.GetSignatureAddress(proposalSeal, seal)
-	//	istanbulAddrs[i] = addr
-	//	if err != nil {
-	//		return proposer, []common.Address{}, err
-	//	}
-	//
-	//	var found bool = false
-	//	for _, v := range committeeAddrs {
-	//		if addr == v {
-	//			found = true
-	//			break
-	//		}
-	//	}
-	//	if found == false {
-	//		logger.Trace("validator is different!", "snap", committeeAddrs, "istanbul", istanbulAddrs)
-	//		return proposer, committeeAddrs, errors.New("validator set is different from Istanbul engine!!")
-	//	}
-	//}
+	// get the validators who signed the committed seals of this block
+	extra, err := types.ExtractIstanbulExtra(block.Header())
+	if err != nil {
+		return consensus.ConsensusInfo{}, err
+	}
+	proposalSeal := istanbulCore.PrepareCommittedSeal(block.Hash())
+	committers := make([]common.Address, len(extra.CommittedSeal))
+	for i, seal := range extra.CommittedSeal {
+		committers[i], err = cacheSignatureAddresses(proposalSeal, seal)
+		if err != nil {
+			return consensus.ConsensusInfo{}, errInvalidSignature
+		}
+	}
 
 	cInfo := consensus.ConsensusInfo{
 		Proposer:       proposer,
 		OriginProposer: originProposer,
 		Committee:      committeeAddrs,
+		Committers:     committers,
 		Round:          round,
 	}
 
@@ -431,6 +431,23 @@ func TestVerifySeal(t *testing.T) {
 	}
 }
 
+func TestGetConsensusInfo(t *testing.T) {
+	chain, engine := newBlockChain(1)
+	defer engine.Stop()
+
+	// no consensus information for the genesis block
+	cInfo, err := engine.GetConsensusInfo(chain.Genesis())
+	assert.NoError(t, err)
+	assert.Equal(t, consensus.ConsensusInfo{}, cInfo)
+
+	block := makeBlockWithSeal(chain, engine, chain.Genesis())
+	cInfo, err = engine.GetConsensusInfo(block)
+	assert.NoError(t, err)
+	assert.Equal(t, engine.address, cInfo.Proposer)
+	assert.Equal(t, addrs, cInfo.Committee)
+	assert.Equal(t, addrs, cInfo.Committers)
+}
+
 func TestVerifyHeaders(t *testing.T) {
 	chain, engine := newBlockChain(1)
 	defer engine.Stop()
@@ -52,6 +52,15 @@ func NextMagmaBlockBaseFee(parentHeader *types.Header, kip71Config *params.KIP71
 	return makeEvenByFloor(nextFee)
 }
 
+// HeaderBaseFee returns the base fee of the given block. It is the base fee dynamically
+// calculated by KIP-71 after the magma hardfork, and zero before the hardfork.
+func HeaderBaseFee(header *types.Header) *big.Int {
+	if header.BaseFee != nil {
+		return header.BaseFee
+	}
+	return new(big.Int).SetUint64(params.ZeroBaseFee)
+}
+
 func nextBlockBaseFee(parentHeader *types.Header, kip71Config *params.KIP71Config, lowerBoundBaseFee, upperBoundBaseFee *big.Int) *big.Int {
 	// If the parent is the magma disabled block or genesis, then return the lowerBoundBaseFee (default 25ston)
 	if parentHeader.Number.Cmp(new(big.Int).SetUint64(0)) == 0 || parentHeader.BaseFee == nil {
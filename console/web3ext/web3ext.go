@@ -28,6 +28,7 @@ var Modules = map[string]string{
 	"personal":         Personal_JS,
 	"rpc":              RPC_JS,
 	"txpool":           TxPool_JS,
+	"bundle":           Bundle_JS,
 	"istanbul":         Istanbul_JS,
 	"mainbridge":       MainBridge_JS,
 	"subbridge":        SubBridge_JS,
@@ -95,6 +96,12 @@ web3._extend({
 			call: 'eth_getHeaderByHash',
 			params: 1
 		}),
+		new web3._extend.Method({
+			name: 'getHeadersByRange',
+			call: 'eth_getHeadersByRange',
+			params: 3,
+			inputFormatter: [web3._extend.formatters.inputBlockNumberFormatter, web3._extend.utils.fromDecimal, function (val) { return !!val; }]
+		}),
 		new web3._extend.Method({
 			name: 'getBlockByNumber',
 			call: 'eth_getBlockByNumber',
@@ -126,12 +133,24 @@ web3._extend({
 			params: 3,
 			inputFormatter: [web3._extend.formatters.inputAddressFormatter, null, web3._extend.formatters.inputBlockNumberFormatter]
 		}),
+		new web3._extend.Method({
+			name: 'getProofs',
+			call: 'eth_getProofs',
+			params: 2,
+			inputFormatter: [null, web3._extend.formatters.inputBlockNumberFormatter]
+		}),
 		new web3._extend.Method({
 			name: 'createAccessList',
 			call: 'eth_createAccessList',
 			params: 2,
 			inputFormatter: [null, web3._extend.formatters.inputBlockNumberFormatter],
 		}),
+		new web3._extend.Method({
+			name: 'estimateGasWithTrace',
+			call: 'eth_estimateGasWithTrace',
+			params: 2,
+			inputFormatter: [null, web3._extend.formatters.inputBlockNumberFormatter],
+		}),
 		new web3._extend.Method({
 			name: 'feeHistory',
 			call: 'eth_feeHistory',
@@ -445,11 +464,44 @@ web3._extend({
 			name: 'saveTrieNodeCacheToDisk',
 			call: 'admin_saveTrieNodeCacheToDisk',
 		}),
+		new web3._extend.Method({
+			name: 'setTrieNodeCacheSize',
+			call: 'admin_setTrieNodeCacheSize',
+			params: 1,
+		}),
+		new web3._extend.Method({
+			name: 'rpcCacheStats',
+			call: 'admin_rpcCacheStats',
+		}),
+		new web3._extend.Method({
+			name: 'compactDatabase',
+			call: 'admin_compactDatabase',
+			params: 3,
+			inputFormatter: [null, null, null]
+		}),
+		new web3._extend.Method({
+			name: 'dbStats',
+			call: 'admin_dbStats',
+		}),
+		new web3._extend.Method({
+			name: 'setRPCCacheSize',
+			call: 'admin_setRPCCacheSize',
+			params: 2,
+		}),
 		new web3._extend.Method({
 			name: 'setMaxSubscriptionPerWSConn',
 			call: 'admin_setMaxSubscriptionPerWSConn',
 			params: 1
 		}),
+		new web3._extend.Method({
+			name: 'websocketConnections',
+			call: 'admin_websocketConnections',
+		}),
+		new web3._extend.Method({
+			name: 'setWebsocketSlowConsumerLimits',
+			call: 'admin_setWebsocketSlowConsumerLimits',
+			params: 2
+		}),
 		new web3._extend.Method({
 			name: 'startSpamThrottler',
 			call: 'admin_startSpamThrottler',
@@ -477,6 +529,11 @@ web3._extend({
 			name: 'getSpamThrottlerCandidateList',
 			call: 'admin_getSpamThrottlerCandidateList',
 		}),
+		new web3._extend.Method({
+			name: 'setTxPoolLimits',
+			call: 'admin_setTxPoolLimits',
+			params: 1,
+		}),
 	],
 	properties: [
 		new web3._extend.Property({
@@ -499,6 +556,10 @@ web3._extend({
 			name: 'spamThrottlerConfig',
 			getter: 'admin_spamThrottlerConfig'
 		}),
+		new web3._extend.Property({
+			name: 'txPoolLimits',
+			getter: 'admin_txPoolLimits'
+		}),
 	]
 });
 `
@@ -517,6 +578,17 @@ web3._extend({
 			call: 'debug_dumpStateTrie',
 			params: 1
 		}),
+		new web3._extend.Method({
+			name: 'verifyBlockRoots',
+			call: 'debug_verifyBlockRoots',
+			params: 1
+		}),
+		new web3._extend.Method({
+			name: 'simulateBlock',
+			call: 'debug_simulateBlock',
+			params: 3,
+			inputFormatter: [null, null, null]
+		}),
 		new web3._extend.Method({
 			name: 'getBlockRlp',
 			call: 'debug_getBlockRlp',
@@ -757,6 +829,12 @@ web3._extend({
 			params: 2,
 			inputFormatter: [web3._extend.formatters.inputBlockNumberFormatter, null]
 		}),
+		new web3._extend.Method({
+			name: 'replayBlockTransactions',
+			call: 'debug_replayBlockTransactions',
+			params: 2,
+			inputFormatter: [web3._extend.formatters.inputDefaultBlockNumberFormatter, null]
+		}),
 		new web3._extend.Method({
 			name: 'traceBlockByNumberRange',
 			call: 'debug_traceBlockByNumberRange',
@@ -775,6 +853,12 @@ web3._extend({
 			params: 2,
 			inputFormatter: [null, null]
 		}),
+		new web3._extend.Method({
+			name: 'traceCall',
+			call: 'debug_traceCall',
+			params: 3,
+			inputFormatter: [null, null, null]
+		}),
 		new web3._extend.Method({
 			name: 'preimage',
 			call: 'debug_preimage',
@@ -844,12 +928,28 @@ web3._extend({
 			params: 1,
 			inputFormatter: [web3._extend.formatters.inputTransactionFormatter]
 		}),
+		new web3._extend.Method({
+			name: 'buildFeeDelegatedTx',
+			call: 'klay_buildFeeDelegatedTx',
+			params: 1,
+			inputFormatter: [web3._extend.formatters.inputTransactionFormatter]
+		}),
+		new web3._extend.Method({
+			name: 'appendFeePayerSignature',
+			call: 'klay_appendFeePayerSignature',
+			params: 2
+		}),
 		new web3._extend.Method({
 			name: 'sendTransactionAsFeePayer',
 			call: 'klay_sendTransactionAsFeePayer',
 			params: 1,
 			inputFormatter: [web3._extend.formatters.inputTransactionFormatter]
 		}),
+		new web3._extend.Method({
+			name: 'sendRawTransactionAsFeePayer',
+			call: 'klay_sendRawTransactionAsFeePayer',
+			params: 1
+		}),
 		new web3._extend.Method({
 			name: 'getCouncil',
 			call: 'klay_getCouncil',
@@ -913,10 +1013,58 @@ web3._extend({
 		}),
 		new web3._extend.Method({
 			name: 'getAccount',
-			call: 'klay_getAccount'
+			call: 'klay_getAccount',
 			params: 2,
 			inputFormatter: [web3._extend.formatters.inputAddressFormatter, web3._extend.formatters.inputDefaultBlockNumberFormatter],
 		}),
+		new web3._extend.Method({
+			name: 'getDecodedAccount',
+			call: 'klay_getDecodedAccount',
+			params: 2,
+			inputFormatter: [web3._extend.formatters.inputAddressFormatter, web3._extend.formatters.inputDefaultBlockNumberFormatter],
+		}),
+		new web3._extend.Method({
+			name: 'getTransactionsByAccount',
+			call: 'klay_getTransactionsByAccount',
+			params: 4,
+			inputFormatter: [web3._extend.formatters.inputAddressFormatter, web3._extend.formatters.inputBlockNumberFormatter, web3._extend.formatters.inputBlockNumberFormatter, null]
+		}),
+		new web3._extend.Method({
+			name: 'getInternalTransactions',
+			call: 'klay_getInternalTransactions',
+			params: 4,
+			inputFormatter: [null, web3._extend.formatters.inputBlockNumberFormatter, web3._extend.formatters.inputBlockNumberFormatter, null]
+		}),
+		new web3._extend.Method({
+			name: 'getLogsPage',
+			call: 'klay_getLogsPage',
+			params: 2,
+			inputFormatter: [null, web3._extend.utils.fromDecimal]
+		}),
+		new web3._extend.Method({
+			name: 'getBalanceHistory',
+			call: 'klay_getBalanceHistory',
+			params: 4,
+			inputFormatter: [web3._extend.formatters.inputAddressFormatter, web3._extend.formatters.inputBlockNumberFormatter, web3._extend.formatters.inputBlockNumberFormatter, web3._extend.utils.fromDecimal]
+		}),
+		new web3._extend.Method({
+			name: 'getBalancesHistory',
+			call: 'klay_getBalancesHistory',
+			params: 4,
+			inputFormatter: [null, web3._extend.formatters.inputBlockNumberFormatter, web3._extend.formatters.inputBlockNumberFormatter, web3._extend.utils.fromDecimal]
+		}),
+		new web3._extend.Method({
+			name: 'getTokenTransfers',
+			call: 'klay_getTokenTransfers',
+			params: 4,
+			inputFormatter: [web3._extend.formatters.inputAddressFormatter, web3._extend.formatters.inputBlockNumberFormatter, web3._extend.formatters.inputBlockNumberFormatter, null]
+		}),
+		new web3._extend.Method({
+			name: 'getTokenBalances',
+			call: 'klay_getTokenBalances',
+			params: 2,
+			inputFormatter: [web3._extend.formatters.inputAddressFormatter, web3._extend.formatters.inputDefaultBlockNumberFormatter]
+		}),
 		new web3._extend.Method({
 			name: 'getHeaderByNumber',
 			call: 'klay_getHeaderByNumber',
@@ -940,6 +1088,12 @@ web3._extend({
 			params: 2,
 			inputFormatter: [web3._extend.formatters.inputBlockNumberFormatter, web3._extend.formatters.inputBlockNumberFormatter]
 		}),
+		new web3._extend.Method({
+			name: 'getBlocksWithConsensusInfo',
+			call: 'klay_getBlocksWithConsensusInfo',
+			params: 2,
+			inputFormatter: [web3._extend.formatters.inputBlockNumberFormatter, web3._extend.utils.fromDecimal]
+		}),
 		new web3._extend.Method({
 			name: 'isContractAccount',
 			call: 'klay_isContractAccount',
@@ -963,6 +1117,30 @@ web3._extend({
 			params: 2,
 			inputFormatter: [web3._extend.formatters.inputCallFormatter, web3._extend.formatters.inputDefaultBlockNumberFormatter]
 		}),
+		new web3._extend.Method({
+			name: 'multicall',
+			call: 'klay_multicall',
+			params: 3,
+			inputFormatter: [null, web3._extend.formatters.inputDefaultBlockNumberFormatter, null]
+		}),
+		new web3._extend.Method({
+			name: 'callMany',
+			call: 'klay_callMany',
+			params: 2,
+			inputFormatter: [web3._extend.formatters.inputCallFormatter, null]
+		}),
+		new web3._extend.Method({
+			name: 'feeStats',
+			call: 'klay_feeStats',
+			params: 3,
+			inputFormatter: [web3._extend.formatters.inputBlockNumberFormatter, web3._extend.formatters.inputBlockNumberFormatter, null]
+		}),
+		new web3._extend.Method({
+			name: 'estimateGasDetailed',
+			call: 'klay_estimateGasDetailed',
+			params: 1,
+			inputFormatter: [web3._extend.formatters.inputCallFormatter]
+		}),
 		new web3._extend.Method({
 			name: 'getAccountKey',
 			call: 'klay_getAccountKey',
@@ -1049,6 +1227,10 @@ web3._extend({
             name : 'rewardbase',
             getter: 'klay_rewardbase'
         }),
+        new web3._extend.Property({
+            name : 'nodeLag',
+            getter: 'klay_nodeLag'
+        }),
         new web3._extend.Property({
             name : 'gasPrice',
             getter: 'klay_gasPrice',
@@ -1109,6 +1291,30 @@ web3._extend({
 			call: 'personal_importRawKey',
 			params: 2
 		}),
+		new web3._extend.Method({
+			name: 'watchAccount',
+			call: 'personal_watchAccount',
+			params: 1,
+			inputFormatter: [web3._extend.formatters.inputAddressFormatter]
+		}),
+		new web3._extend.Method({
+			name: 'unwatchAccount',
+			call: 'personal_unwatchAccount',
+			params: 1,
+			inputFormatter: [web3._extend.formatters.inputAddressFormatter]
+		}),
+		new web3._extend.Method({
+			name: 'reserveNonce',
+			call: 'personal_reserveNonce',
+			params: 1,
+			inputFormatter: [web3._extend.formatters.inputAddressFormatter]
+		}),
+		new web3._extend.Method({
+			name: 'releaseNonce',
+			call: 'personal_releaseNonce',
+			params: 2,
+			inputFormatter: [web3._extend.formatters.inputAddressFormatter, null]
+		}),
 		new web3._extend.Method({
 			name: 'replaceRawKey',
 			call: 'personal_replaceRawKey',
@@ -1179,7 +1385,13 @@ web3._extend({
 const TxPool_JS = `
 web3._extend({
 	property: 'txpool',
-	methods: [],
+	methods: [
+		new web3._extend.Method({
+			name: 'contentFrom',
+			call: 'txpool_contentFrom',
+			params: 1,
+		}),
+	],
 	properties:
 	[
 		new web3._extend.Property({
@@ -1203,6 +1415,31 @@ web3._extend({
 });
 `
 
+const Bundle_JS = `
+web3._extend({
+	property: 'bundle',
+	methods: [
+		new web3._extend.Method({
+			name: 'send',
+			call: 'bundle_send',
+			params: 1,
+		}),
+		new web3._extend.Method({
+			name: 'cancel',
+			call: 'bundle_cancel',
+			params: 1,
+		}),
+	],
+	properties:
+	[
+		new web3._extend.Property({
+			name: 'pending',
+			getter: 'bundle_pending'
+		}),
+	]
+});
+`
+
 const Istanbul_JS = `
 web3._extend({
 	property: 'istanbul',
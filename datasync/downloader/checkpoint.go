@@ -0,0 +1,220 @@
+// Copyright 2022 The klaytn Authors
+// This file is part of the klaytn library.
+//
+// The klaytn library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The klaytn library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the klaytn library. If not, see <http://www.gnu.org/licenses/>.
+
+package downloader
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"sync/atomic"
+	"time"
+
+	"github.com/klaytn/klaytn/blockchain/types"
+	"github.com/klaytn/klaytn/common"
+	"github.com/klaytn/klaytn/common/hexutil"
+	"github.com/klaytn/klaytn/crypto"
+	"github.com/klaytn/klaytn/rlp"
+)
+
+var (
+	errNoCheckpointSigner         = errors.New("no trusted signer of the checkpoint is given")
+	errInvalidCheckpointThreshold = errors.New("invalid threshold of the checkpoint signatures")
+
+	// checkpointStateTimeout is the time without any progress of the state sync of the checkpoint
+	// after which the state of the checkpoint is regarded as garbage collected by the peers.
+	checkpointStateTimeout = 3 * time.Minute
+)
+
+// TrustedCheckpoint is a manifest of a block and its state root signed by trusted parties.
+// A node syncing in fast or snap mode takes the checkpoint block as the pivot, instead of a
+// block chosen by its peer, so that the state is synced at the block the signers vouch for.
+// The headers after the checkpoint are verified with their committed seals as usual.
+//
+// The checkpoint only pins the pivot: the headers, bodies and receipts are still downloaded
+// from the genesis block, and the state is not bootstrapped from a snapshot. If the peers do
+// not serve the state of the checkpoint anymore, the pivot is moved on as in fast sync, while
+// the downloaded chain is still required to include the checkpoint block.
+type TrustedCheckpoint struct {
+	Number     uint64          `json:"number"`
+	Hash       common.Hash     `json:"hash"`
+	StateRoot  common.Hash     `json:"stateRoot"`
+	Signatures []hexutil.Bytes `json:"signatures"`
+}
+
+// LoadTrustedCheckpoint reads a checkpoint manifest in JSON from the given file.
+func LoadTrustedCheckpoint(path string) (*TrustedCheckpoint, error) {
+	data, err := ioutil.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	cp := new(TrustedCheckpoint)
+	if err := json.Unmarshal(data, cp); err != nil {
+		return nil, fmt.Errorf("invalid checkpoint manifest %s: %v", path, err)
+	}
+	return cp, nil
+}
+
+// SigHash returns the hash signed by the signers of the checkpoint.
+func (cp *TrustedCheckpoint) SigHash() common.Hash {
+	enc, _ := rlp.EncodeToBytes([]interface{}{cp.Number, cp.Hash, cp.StateRoot})
+	return crypto.Keccak256Hash(enc)
+}
+
+// Verify checks that the checkpoint is signed by at least threshold of the trusted signers.
+func (cp *TrustedCheckpoint) Verify(signers []common.Address, threshold int) error {
+	if len(signers) == 0 {
+		return errNoCheckpointSigner
+	}
+	if threshold < 1 || threshold > len(signers) {
+		return fmt.Errorf("%w: %d of %d signers", errInvalidCheckpointThreshold, threshold, len(signers))
+	}
+	trusted := make(map[common.Address]bool, len(signers))
+	for _, signer := range signers {
+		trusted[signer] = true
+	}
+	hash := cp.SigHash()
+	signed := make(map[common.Address]bool)
+	for _, sig := range cp.Signatures {
+		pubkey, err := crypto.SigToPub(hash.Bytes(), sig)
+		if err != nil {
+			return fmt.Errorf("invalid checkpoint signature: %v", err)
+		}
+		if signer := crypto.PubkeyToAddress(*pubkey); trusted[signer] {
+			signed[signer] = true
+		}
+	}
+	if len(signed) < threshold {
+		return fmt.Errorf("checkpoint signed by %d trusted signers, %d required", len(signed), threshold)
+	}
+	return nil
+}
+
+// SetTrustedCheckpoint sets the checkpoint taken as the pivot of fast or snap sync.
+// The checkpoint is expected to be verified by the caller.
+func (d *Downloader) SetTrustedCheckpoint(cp *TrustedCheckpoint) {
+	d.checkpoint = cp
+}
+
+// isCheckpointPivot reports whether the pivot at the given number is the trusted checkpoint,
+// which is not moved while its state is served by the peers.
+func (d *Downloader) isCheckpointPivot(number uint64) bool {
+	return d.checkpoint != nil && d.checkpoint.Number == number && atomic.LoadInt32(&d.checkpointStale) == 0
+}
+
+// checkpointWatch detects that the state sync of the checkpoint makes no progress.
+type checkpointWatch struct {
+	progress uint64    // Number of the state entries synced when the progress was last changed
+	updated  time.Time // Time when the progress was last changed
+}
+
+// stalled reports whether the given progress has not changed for checkpointStateTimeout.
+func (w *checkpointWatch) stalled(progress uint64, now time.Time) bool {
+	if w.updated.IsZero() || progress != w.progress {
+		w.progress, w.updated = progress, now
+		return false
+	}
+	return now.Sub(w.updated) >= checkpointStateTimeout
+}
+
+// checkpointPivotStale reports whether the state of the checkpoint pivot has not been synced for
+// checkpointStateTimeout, which means that the peers have garbage collected it. A stale checkpoint
+// is not taken as the pivot anymore, so that the pivot is moved on to a recent block.
+func (d *Downloader) checkpointPivotStale(now time.Time) bool {
+	if !d.checkpointWatch.stalled(d.stateSyncProgress(), now) {
+		return false
+	}
+	logger.Warn("State of the trusted checkpoint is not served, moving the pivot", "number", d.checkpoint.Number,
+		"elapsed", checkpointStateTimeout)
+	atomic.StoreInt32(&d.checkpointStale, 1)
+	return true
+}
+
+// stateSyncProgress returns the number of the state entries synced by the fast or snap sync.
+func (d *Downloader) stateSyncProgress() uint64 {
+	d.syncStatsLock.RLock()
+	progress := d.syncStatsState.processed
+	d.syncStatsLock.RUnlock()
+
+	if d.snapSync && d.SnapSyncer != nil {
+		if p, _ := d.SnapSyncer.Progress(); p != nil {
+			progress += p.AccountSynced + p.BytecodeSynced + p.StorageSynced + p.TrienodeHealSynced + p.BytecodeHealSynced
+		}
+	}
+	return progress
+}
+
+// verifyCheckpointHeader checks that the header at the number of the checkpoint among the given
+// consecutive headers is the trusted checkpoint.
+func (d *Downloader) verifyCheckpointHeader(headers []*types.Header) error {
+	if d.checkpoint == nil || len(headers) == 0 {
+		return nil
+	}
+	first := headers[0].Number.Uint64()
+	if d.checkpoint.Number < first || d.checkpoint.Number >= first+uint64(len(headers)) {
+		return nil
+	}
+	if header := headers[d.checkpoint.Number-first]; header.Hash() != d.checkpoint.Hash {
+		return fmt.Errorf("%w: checkpoint header %d %x != trusted %x", errInvalidChain, header.Number, header.Hash(), d.checkpoint.Hash)
+	}
+	return nil
+}
+
+// fetchCheckpointHeader retrieves the header of the trusted checkpoint from a remote peer,
+// and checks that it matches the checkpoint.
+func (d *Downloader) fetchCheckpointHeader(p *peerConnection) (*types.Header, error) {
+	p.logger.Debug("Retrieving the checkpoint header", "number", d.checkpoint.Number)
+	go p.peer.RequestHeadersByNumber(d.checkpoint.Number, 1, 0, false)
+
+	ttl := d.requestTTL()
+	timeout := time.After(ttl)
+	for {
+		select {
+		case <-d.cancelCh:
+			return nil, errCanceled
+
+		case packet := <-d.headerCh:
+			// Discard anything not from the origin peer
+			if packet.PeerId() != p.id {
+				logger.Debug("Received headers from incorrect peer", "peer", packet.PeerId())
+				break
+			}
+			headers := packet.(*headerPack).headers
+			if len(headers) != 1 {
+				return nil, fmt.Errorf("%w: returned headers %d != requested 1", errBadPeer, len(headers))
+			}
+			header := headers[0]
+			if header.Number.Uint64() != d.checkpoint.Number || header.Hash() != d.checkpoint.Hash {
+				return nil, fmt.Errorf("%w: checkpoint header %d %x != trusted %d %x", errInvalidChain,
+					header.Number, header.Hash(), d.checkpoint.Number, d.checkpoint.Hash)
+			}
+			if header.Root != d.checkpoint.StateRoot {
+				return nil, fmt.Errorf("%w: checkpoint state root %x != trusted %x", errInvalidChain, header.Root, d.checkpoint.StateRoot)
+			}
+			return header, nil
+
+		case <-timeout:
+			p.logger.Debug("Waiting for the checkpoint header timed out", "elapsed", ttl)
+			return nil, errTimeout
+
+		case <-d.bodyCh:
+		case <-d.receiptCh:
+		case <-d.stakingInfoCh:
+			// Out of bounds delivery, ignore
+		}
+	}
+}
@@ -0,0 +1,113 @@
+// Copyright 2022 The klaytn Authors
+// This file is part of the klaytn library.
+//
+// The klaytn library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The klaytn library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the klaytn library. If not, see <http://www.gnu.org/licenses/>.
+
+package downloader
+
+import (
+	"encoding/json"
+	"io/ioutil"
+	"math/big"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/klaytn/klaytn/blockchain/types"
+	"github.com/klaytn/klaytn/common"
+	"github.com/klaytn/klaytn/crypto"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestTrustedCheckpoint_Verify(t *testing.T) {
+	var (
+		signers = make([]common.Address, 3)
+		cp      = &TrustedCheckpoint{Number: 100, Hash: common.HexToHash("0x01"), StateRoot: common.HexToHash("0x02")}
+	)
+	for i := range signers {
+		key, err := crypto.GenerateKey()
+		require.NoError(t, err)
+		signers[i] = crypto.PubkeyToAddress(key.PublicKey)
+		if i < 2 {
+			sig, err := crypto.Sign(cp.SigHash().Bytes(), key)
+			require.NoError(t, err)
+			cp.Signatures = append(cp.Signatures, sig)
+		}
+	}
+
+	// The manifest is read from a JSON file.
+	dir, err := ioutil.TempDir("", "klaytn-checkpoint")
+	require.NoError(t, err)
+	defer os.RemoveAll(dir)
+	path := filepath.Join(dir, "checkpoint.json")
+	data, err := json.Marshal(cp)
+	require.NoError(t, err)
+	require.NoError(t, ioutil.WriteFile(path, data, 0o600))
+	loaded, err := LoadTrustedCheckpoint(path)
+	require.NoError(t, err)
+	assert.Equal(t, cp, loaded)
+
+	assert.NoError(t, loaded.Verify(signers, 2))
+	assert.Error(t, loaded.Verify(signers, 3))
+	assert.Error(t, loaded.Verify(signers[2:], 1))
+	assert.Equal(t, errNoCheckpointSigner, loaded.Verify(nil, 1))
+	assert.ErrorIs(t, loaded.Verify(signers, 0), errInvalidCheckpointThreshold)
+
+	// A signature of another checkpoint does not count.
+	loaded.Number++
+	assert.Error(t, loaded.Verify(signers, 1))
+}
+
+func TestDownloader_CheckpointPivotStale(t *testing.T) {
+	var (
+		cp  = &TrustedCheckpoint{Number: 100}
+		d   = &Downloader{checkpoint: cp}
+		now = time.Now()
+	)
+	assert.True(t, d.isCheckpointPivot(100))
+	assert.False(t, d.isCheckpointPivot(101))
+
+	// The checkpoint is kept as the pivot while its state is synced.
+	assert.False(t, d.checkpointPivotStale(now))
+	d.syncStatsState.processed = 10
+	assert.False(t, d.checkpointPivotStale(now.Add(checkpointStateTimeout)))
+	assert.False(t, d.checkpointPivotStale(now.Add(checkpointStateTimeout+time.Second)))
+	assert.True(t, d.isCheckpointPivot(100))
+
+	// The checkpoint is not the pivot anymore once its state is not synced for the timeout.
+	assert.True(t, d.checkpointPivotStale(now.Add(2*checkpointStateTimeout)))
+	assert.False(t, d.isCheckpointPivot(100))
+}
+
+func TestDownloader_VerifyCheckpointHeader(t *testing.T) {
+	headers := make([]*types.Header, 10)
+	for i := range headers {
+		headers[i] = &types.Header{Number: big.NewInt(int64(95 + i))}
+	}
+	d := &Downloader{}
+	assert.NoError(t, d.verifyCheckpointHeader(headers))
+
+	d.checkpoint = &TrustedCheckpoint{Number: 100, Hash: headers[5].Hash()}
+	assert.NoError(t, d.verifyCheckpointHeader(headers))
+	assert.NoError(t, d.verifyCheckpointHeader(headers[:5]))
+	assert.NoError(t, d.verifyCheckpointHeader(headers[6:]))
+
+	// The chain not including the checkpoint is rejected even after the pivot is moved on.
+	d.checkpointStale = 1
+	d.checkpoint.Hash = common.HexToHash("0x01")
+	assert.ErrorIs(t, d.verifyCheckpointHeader(headers), errInvalidChain)
+	assert.NoError(t, d.verifyCheckpointHeader(headers[6:]))
+}
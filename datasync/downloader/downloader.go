@@ -145,6 +145,10 @@ type Downloader struct {
 	pivotHeader *types.Header
 	pivotLock   sync.RWMutex
 
+	checkpoint      *TrustedCheckpoint // Trusted block taken as the pivot, nil if the pivot is chosen by the peer
+	checkpointStale int32              // Flag whether the state of the checkpoint is not served by the peers (atomic)
+	checkpointWatch checkpointWatch    // Progress of the state sync of the checkpoint pivot
+
 	stateSyncStart chan *stateSync
 	trackStateReq  chan *stateReq
 	stateCh        chan dataPack // [klay/63] Channel receiving inbound node state data
@@ -493,6 +497,17 @@ func (d *Downloader) syncWithPeer(p *peerConnection, hash common.Hash, td *big.I
 	}
 	height := latest.Number.Uint64()
 
+	// Take the trusted checkpoint as the pivot if the local chain has not reached it yet,
+	// unless the state of the checkpoint has turned out not to be served by the peers
+	if (mode == FastSync || mode == SnapSync) && d.checkpoint != nil && atomic.LoadInt32(&d.checkpointStale) == 0 &&
+		d.checkpoint.Number > d.blockchain.CurrentFastBlock().NumberU64() && d.checkpoint.Number <= height {
+		if pivot, err = d.fetchCheckpointHeader(p); err != nil {
+			return err
+		}
+		d.checkpointWatch = checkpointWatch{}
+		logger.Info("Syncing from the trusted checkpoint", "number", pivot.Number, "hash", pivot.Hash(), "root", pivot.Root)
+	}
+
 	origin, err := d.findAncestor(p, height)
 	if err != nil {
 		return err
@@ -1006,7 +1021,7 @@ func (d *Downloader) fetchHeaders(p *peerConnection, from uint64) error {
 			}
 			// If we're still skeleton filling fast sync, check pivot staleness
 			// before continuing to the next skeleton filling
-			if skeleton && pivot > 0 {
+			if skeleton && pivot > 0 && !d.isCheckpointPivot(pivot) {
 				getNextPivot()
 			} else {
 				getHeaders(from)
@@ -1466,6 +1481,11 @@ func (d *Downloader) processHeaders(origin uint64, td *big.Int) error {
 				}
 				chunk := headers[:limit]
 
+				// Reject the chain not including the trusted checkpoint
+				if err := d.verifyCheckpointHeader(chunk); err != nil {
+					rollbackErr = err
+					return err
+				}
 				// In case of header only syncing, validate the chunk immediately
 				if mode == SnapSync || mode == FastSync || mode == LightSync {
 					// Collect the yet unknown headers to mark them as uncertain
@@ -1487,6 +1507,10 @@ func (d *Downloader) processHeaders(origin uint64, td *big.Int) error {
 					if chunk[len(chunk)-1].Number.Uint64()+uint64(fsHeaderForceVerify) > pivot {
 						frequency = 1
 					}
+					// Light nodes never process the blocks, so the committed seals of every header are verified
+					if mode == LightSync {
+						frequency = 1
+					}
 					if n, err := d.lightchain.InsertHeaderChain(chunk, frequency); err != nil {
 						rollbackErr = err
 						// If some headers were inserted, add them too to the rollback list
@@ -1661,8 +1685,9 @@ func (d *Downloader) processFastSyncContent() error {
 			latest := results[len(results)-1].Header
 			// If the height is above the pivot block by 2 sets, it means the pivot
 			// become stale in the network and it was garbage collected, move to a
-			// new pivot.
-			if height := latest.Number.Uint64(); height >= pivot.Number.Uint64()+2*uint64(fsMinFullBlocks) {
+			// new pivot. The checkpoint pivot is moved only if its state is not served.
+			if height := latest.Number.Uint64(); height >= pivot.Number.Uint64()+2*uint64(fsMinFullBlocks) &&
+				(!d.isCheckpointPivot(pivot.Number.Uint64()) || d.checkpointPivotStale(time.Now())) {
 				logger.Warn("Pivot became stale, moving", "old", pivot.Number.Uint64(), "new", height-uint64(fsMinFullBlocks))
 				pivot = results[len(results)-1-fsMinFullBlocks].Header // must exist as lower old pivot is uncommitted
 
@@ -0,0 +1,40 @@
+// Copyright 2022 The klaytn Authors
+// This file is part of the klaytn library.
+//
+// The klaytn library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The klaytn library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the klaytn library. If not, see <http://www.gnu.org/licenses/>.
+
+package downloader
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSyncModeText(t *testing.T) {
+	for _, mode := range []SyncMode{FullSync, FastSync, SnapSync, LightSync} {
+		text, err := mode.MarshalText()
+		require.NoError(t, err)
+		assert.Equal(t, mode.String(), string(text))
+
+		var decoded SyncMode
+		require.NoError(t, decoded.UnmarshalText(text))
+		assert.Equal(t, mode, decoded)
+	}
+
+	var mode SyncMode
+	err := mode.UnmarshalText([]byte("warp"))
+	assert.EqualError(t, err, `unknown sync mode "warp", want "full", "fast", "snap" or "light"`)
+}
@@ -332,6 +332,8 @@ func chainConfigAt(governance Engine, num *rpc.BlockNumber) *params.ChainConfig
 	config.EthTxTypeCompatibleBlock = latestConfig.EthTxTypeCompatibleBlock
 	config.MagmaCompatibleBlock = latestConfig.MagmaCompatibleBlock
 	config.KoreCompatibleBlock = latestConfig.KoreCompatibleBlock
+	config.ShanghaiCompatibleBlock = latestConfig.ShanghaiCompatibleBlock
+	config.AccessListCompatibleBlock = latestConfig.AccessListCompatibleBlock
 
 	return config
 }
@@ -101,7 +101,7 @@ func (c *contractCaller) makeTx(contractAbi abi.ABI, fn string, args ...interfac
 	)
 
 	tx := types.NewMessage(from, to, nonce, amount, gasLimit, gasPrice, calldata,
-		checkNonce, intrinsicGas)
+		checkNonce, intrinsicGas, nil)
 	return tx, nil
 }
 
@@ -0,0 +1,529 @@
+// Modifications Copyright 2022 The klaytn Authors
+// Copyright 2019 The go-ethereum Authors
+// This file is part of the go-ethereum library.
+//
+// The go-ethereum library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The go-ethereum library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the go-ethereum library. If not, see <http://www.gnu.org/licenses/>.
+//
+// This file is derived from graphql/graphql_test.go (2022/10/01).
+// Modified and improved for the klaytn development.
+
+package graphql
+
+import (
+	"context"
+	"math"
+	"math/big"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/klaytn/klaytn/api"
+	"github.com/klaytn/klaytn/blockchain"
+	"github.com/klaytn/klaytn/blockchain/bloombits"
+	"github.com/klaytn/klaytn/blockchain/state"
+	"github.com/klaytn/klaytn/blockchain/types"
+	"github.com/klaytn/klaytn/blockchain/vm"
+	"github.com/klaytn/klaytn/common"
+	"github.com/klaytn/klaytn/common/hexutil"
+	"github.com/klaytn/klaytn/consensus/gxhash"
+	"github.com/klaytn/klaytn/crypto"
+	"github.com/klaytn/klaytn/event"
+	"github.com/klaytn/klaytn/networks/rpc"
+	"github.com/klaytn/klaytn/node/cn/filters"
+	"github.com/klaytn/klaytn/params"
+	"github.com/klaytn/klaytn/rlp"
+	"github.com/klaytn/klaytn/storage/database"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// testBackend serves the GraphQL resolvers from a blockchain generated in memory.
+// The methods not used by the tested resolvers are left to the embedded nil api.Backend.
+type testBackend struct {
+	api.Backend
+	chain *blockchain.BlockChain
+	db    database.DBManager
+	sent  []*types.Transaction
+}
+
+func (b *testBackend) ChainDB() database.DBManager                                          { return b.db }
+func (b *testBackend) EventMux() *event.TypeMux                                             { return nil }
+func (b *testBackend) ChainConfig() *params.ChainConfig                                     { return b.chain.Config() }
+func (b *testBackend) CurrentBlock() *types.Block                                           { return b.chain.CurrentBlock() }
+func (b *testBackend) BloomStatus() (uint64, uint64)                                        { return params.BloomBitsBlocks, 0 }
+func (b *testBackend) ServiceFilter(ctx context.Context, session *bloombits.MatcherSession) {}
+
+func (b *testBackend) HeaderByNumber(ctx context.Context, number rpc.BlockNumber) (*types.Header, error) {
+	if number == rpc.LatestBlockNumber {
+		return b.chain.CurrentHeader(), nil
+	}
+	return b.chain.GetHeaderByNumber(uint64(number)), nil
+}
+
+func (b *testBackend) HeaderByHash(ctx context.Context, hash common.Hash) (*types.Header, error) {
+	return b.chain.GetHeaderByHash(hash), nil
+}
+
+func (b *testBackend) HeaderByNumberOrHash(ctx context.Context, blockNrOrHash rpc.BlockNumberOrHash) (*types.Header, error) {
+	if hash, ok := blockNrOrHash.Hash(); ok {
+		return b.HeaderByHash(ctx, hash)
+	}
+	number, _ := blockNrOrHash.Number()
+	return b.HeaderByNumber(ctx, number)
+}
+
+func (b *testBackend) BlockByNumberOrHash(ctx context.Context, blockNrOrHash rpc.BlockNumberOrHash) (*types.Block, error) {
+	if hash, ok := blockNrOrHash.Hash(); ok {
+		return b.chain.GetBlockByHash(hash), nil
+	}
+	number, _ := blockNrOrHash.Number()
+	if number == rpc.LatestBlockNumber {
+		return b.chain.CurrentBlock(), nil
+	}
+	return b.chain.GetBlockByNumber(uint64(number)), nil
+}
+
+func (b *testBackend) StateAndHeaderByNumberOrHash(ctx context.Context, blockNrOrHash rpc.BlockNumberOrHash) (*state.StateDB, *types.Header, error) {
+	header, err := b.HeaderByNumberOrHash(ctx, blockNrOrHash)
+	if header == nil || err != nil {
+		return nil, nil, err
+	}
+	st, err := b.chain.StateAt(header.Root)
+	return st, header, err
+}
+
+func (b *testBackend) GetEVM(ctx context.Context, msg blockchain.Message, state *state.StateDB, header *types.Header, vmCfg vm.Config) (*vm.EVM, func() error, error) {
+	context := blockchain.NewEVMContext(msg, header, b.chain, nil)
+	return vm.NewEVM(context, state, b.chain.Config(), &vmCfg), func() error { return nil }, nil
+}
+
+func (b *testBackend) RPCGasCap() *big.Int          { return nil }
+func (b *testBackend) RPCEVMTimeout() time.Duration { return 0 }
+
+func (b *testBackend) SendTx(ctx context.Context, tx *types.Transaction) error {
+	b.sent = append(b.sent, tx)
+	return nil
+}
+
+func (b *testBackend) GetBlockReceipts(ctx context.Context, hash common.Hash) types.Receipts {
+	return b.chain.GetReceiptsByBlockHash(hash)
+}
+
+func (b *testBackend) GetLogs(ctx context.Context, hash common.Hash) ([][]*types.Log, error) {
+	receipts := b.chain.GetReceiptsByBlockHash(hash)
+	logs := make([][]*types.Log, len(receipts))
+	for i, receipt := range receipts {
+		logs[i] = receipt.Logs
+	}
+	return logs, nil
+}
+
+func (b *testBackend) GetTxAndLookupInfo(hash common.Hash) (*types.Transaction, common.Hash, uint64, uint64) {
+	return b.chain.GetTxAndLookupInfo(hash)
+}
+
+func (b *testBackend) GetPoolTransaction(hash common.Hash) *types.Transaction { return nil }
+
+func (b *testBackend) SubscribeDroppedTxsEvent(ch chan<- blockchain.DroppedTxsEvent) event.Subscription {
+	return nil
+}
+
+func (b *testBackend) SubscribeRemovedLogsEvent(ch chan<- blockchain.RemovedLogsEvent) event.Subscription {
+	return nil
+}
+
+func (b *testBackend) SubscribeReorgEvent(ch chan<- blockchain.ReorgEvent) event.Subscription {
+	return nil
+}
+
+func (b *testBackend) SubscribeLogsEvent(ch chan<- []*types.Log) event.Subscription { return nil }
+
+var (
+	testKey, _ = crypto.HexToECDSA("b71c71a67e1177ad4e901695e1b4b9ee17ae16c6668d313eac2f96dbcda3f291")
+	testAddr   = crypto.PubkeyToAddress(testKey.PublicKey)
+
+	// getterContract returns the value of the slot 0, which is 42.
+	getterContract = common.HexToAddress("0x0a")
+	getterCode     = common.FromHex("0x60005460005260206000f3")
+	// revertContract reverts without any data.
+	revertContract = common.HexToAddress("0x0b")
+	revertCode     = common.FromHex("0x60006000fd")
+)
+
+// newTestBackend generates a chain of the given number of blocks, and every block has a
+// contract creation transaction emitting a log.
+func newTestBackend(t *testing.T, numBlocks int) *testBackend {
+	var (
+		gspec = &blockchain.Genesis{
+			Config: params.TestChainConfig,
+			Alloc: blockchain.GenesisAlloc{
+				testAddr:       {Balance: big.NewInt(math.MaxInt64)},
+				getterContract: {Code: getterCode, Storage: map[common.Hash]common.Hash{{}: common.BigToHash(big.NewInt(42))}, Balance: common.Big0},
+				revertContract: {Code: revertCode, Balance: common.Big0},
+			},
+		}
+		db      = database.NewMemoryDBManager()
+		genesis = gspec.MustCommit(db)
+		signer  = types.LatestSignerForChainID(params.TestChainConfig.ChainID)
+	)
+	// PUSH1 0 PUSH1 0 LOG0 STOP
+	code := common.FromHex("0x60006000a000")
+	blocks, _ := blockchain.GenerateChain(gspec.Config, genesis, gxhash.NewFaker(), db, numBlocks, func(i int, b *blockchain.BlockGen) {
+		b.SetRewardbase(testAddr)
+		tx, err := types.SignTx(types.NewContractCreation(b.TxNonce(testAddr), big.NewInt(0), 100000, nil, code), signer, testKey)
+		require.NoError(t, err)
+		b.AddTx(tx)
+	})
+	chain, err := blockchain.NewBlockChain(db, nil, gspec.Config, gxhash.NewFaker(), vm.Config{})
+	require.NoError(t, err)
+	_, err = chain.InsertChain(blocks)
+	require.NoError(t, err)
+	return &testBackend{chain: chain, db: db}
+}
+
+func TestResolverBlock(t *testing.T) {
+	backend := newTestBackend(t, 3)
+	defer backend.chain.Stop()
+	r := &Resolver{backend: backend}
+	ctx := context.Background()
+
+	expected := backend.chain.GetBlockByNumber(2)
+	number := Long(2)
+	byNumber, err := r.Block(ctx, struct {
+		Number *Long
+		Hash   *common.Hash
+	}{Number: &number})
+	require.NoError(t, err)
+	require.NotNil(t, byNumber)
+	hash, err := byNumber.Hash(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, expected.Hash(), hash)
+
+	byHash, err := r.Block(ctx, struct {
+		Number *Long
+		Hash   *common.Hash
+	}{Hash: &hash})
+	require.NoError(t, err)
+	require.NotNil(t, byHash)
+	n, err := byHash.Number(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, Long(2), n)
+
+	txs, err := byHash.Transactions(ctx)
+	require.NoError(t, err)
+	require.NotNil(t, txs)
+	require.Len(t, *txs, 1)
+	assert.Equal(t, expected.Transactions()[0].Hash(), (*txs)[0].Hash(ctx))
+
+	tx, err := r.Transaction(ctx, struct{ Hash common.Hash }{expected.Transactions()[0].Hash()})
+	require.NoError(t, err)
+	require.NotNil(t, tx)
+	nonce, err := tx.Nonce(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, Long(1), nonce)
+
+	// An unknown block is resolved as null.
+	unknown := Long(100)
+	block, err := r.Block(ctx, struct {
+		Number *Long
+		Hash   *common.Hash
+	}{Number: &unknown})
+	assert.NoError(t, err)
+	assert.Nil(t, block)
+}
+
+func TestResolverTransaction(t *testing.T) {
+	backend := newTestBackend(t, 3)
+	defer backend.chain.Stop()
+	r := &Resolver{backend: backend}
+	ctx := context.Background()
+
+	block := backend.chain.GetBlockByNumber(2)
+	expected := block.Transactions()[0]
+	receipt := backend.chain.GetReceiptsByBlockHash(block.Hash())[0]
+
+	tx, err := r.Transaction(ctx, struct{ Hash common.Hash }{expected.Hash()})
+	require.NoError(t, err)
+	require.NotNil(t, tx)
+
+	from, err := tx.From(ctx, BlockNumberArgs{})
+	require.NoError(t, err)
+	fromAddr, _ := from.Address(ctx)
+	assert.Equal(t, testAddr, fromAddr)
+	to, err := tx.To(ctx, BlockNumberArgs{})
+	require.NoError(t, err)
+	assert.Nil(t, to)
+	gas, err := tx.Gas(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, Long(expected.Gas()), gas)
+	txType, err := tx.Type(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, int32(expected.Type()), *txType)
+
+	// The block, the index and the receipt are resolved from the lookup of the transaction.
+	txBlock, err := tx.Block(ctx)
+	require.NoError(t, err)
+	require.NotNil(t, txBlock)
+	number, err := txBlock.Number(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, Long(2), number)
+	index, err := tx.Index(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, int32(0), *index)
+
+	status, err := tx.Status(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, Long(1), *status)
+	gasUsed, err := tx.GasUsed(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, Long(receipt.GasUsed), *gasUsed)
+	cumulativeGasUsed, err := tx.CumulativeGasUsed(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, Long(receipt.GasUsed), *cumulativeGasUsed)
+
+	created, err := tx.CreatedContract(ctx, BlockNumberArgs{})
+	require.NoError(t, err)
+	require.NotNil(t, created)
+	createdAddr, _ := created.Address(ctx)
+	assert.Equal(t, crypto.CreateAddress(testAddr, expected.Nonce()), createdAddr)
+
+	logs, err := tx.Logs(ctx)
+	require.NoError(t, err)
+	require.Len(t, *logs, 1)
+	assert.Equal(t, createdAddr, (*logs)[0].log.Address)
+	assert.Equal(t, expected.Hash(), (*logs)[0].Transaction(ctx).Hash(ctx))
+
+	// An unknown transaction is resolved as null.
+	tx, err = r.Transaction(ctx, struct{ Hash common.Hash }{common.Hash{0x01}})
+	assert.NoError(t, err)
+	assert.Nil(t, tx)
+}
+
+func TestResolverAccount(t *testing.T) {
+	backend := newTestBackend(t, 3)
+	defer backend.chain.Stop()
+	r := &Resolver{backend: backend}
+	ctx := context.Background()
+
+	account := func(number Long, address common.Address) *Account {
+		block, err := r.Block(ctx, struct {
+			Number *Long
+			Hash   *common.Hash
+		}{Number: &number})
+		require.NoError(t, err)
+		require.NotNil(t, block)
+		account, err := block.Account(ctx, struct{ Address common.Address }{address})
+		require.NoError(t, err)
+		return account
+	}
+
+	// The account is resolved against the state of the block.
+	for _, number := range []Long{1, 3} {
+		st, err := backend.chain.StateAt(backend.chain.GetHeaderByNumber(uint64(number)).Root)
+		require.NoError(t, err)
+
+		nonce, err := account(number, testAddr).TransactionCount(ctx)
+		require.NoError(t, err)
+		assert.Equal(t, number, nonce)
+		balance, err := account(number, testAddr).Balance(ctx)
+		require.NoError(t, err)
+		assert.Equal(t, st.GetBalance(testAddr), balance.ToInt())
+	}
+
+	code, err := account(3, getterContract).Code(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, hexutil.Bytes(getterCode), code)
+	value, err := account(3, getterContract).Storage(ctx, struct{ Slot common.Hash }{common.Hash{}})
+	require.NoError(t, err)
+	assert.Equal(t, common.BigToHash(big.NewInt(42)), value)
+}
+
+func TestResolverCallAndEstimateGas(t *testing.T) {
+	backend := newTestBackend(t, 3)
+	defer backend.chain.Stop()
+	r := &Resolver{backend: backend}
+	ctx := context.Background()
+
+	block, err := r.Block(ctx, struct {
+		Number *Long
+		Hash   *common.Hash
+	}{})
+	require.NoError(t, err)
+	require.NotNil(t, block)
+
+	result, err := block.Call(ctx, struct{ Data CallData }{CallData{From: &testAddr, To: &getterContract}})
+	require.NoError(t, err)
+	assert.Equal(t, Long(1), result.Status())
+	assert.Equal(t, common.BigToHash(big.NewInt(42)).Bytes(), []byte(result.Data()))
+	assert.NotZero(t, result.GasUsed())
+
+	// The failed call is reported by the status without an error.
+	result, err = block.Call(ctx, struct{ Data CallData }{CallData{From: &testAddr, To: &revertContract}})
+	require.NoError(t, err)
+	assert.Equal(t, Long(0), result.Status())
+
+	gas, err := block.EstimateGas(ctx, struct{ Data CallData }{CallData{From: &testAddr, To: &testAddr}})
+	require.NoError(t, err)
+	assert.Equal(t, Long(params.TxGas), gas)
+
+	gas, err = block.EstimateGas(ctx, struct{ Data CallData }{CallData{From: &testAddr, To: &getterContract}})
+	require.NoError(t, err)
+	assert.Greater(t, int64(gas), int64(params.TxGas))
+
+	_, err = block.EstimateGas(ctx, struct{ Data CallData }{CallData{From: &testAddr, To: &revertContract}})
+	assert.Error(t, err)
+}
+
+func TestResolverSendRawTransaction(t *testing.T) {
+	backend := newTestBackend(t, 1)
+	defer backend.chain.Stop()
+	r := &Resolver{backend: backend}
+	ctx := context.Background()
+
+	signer := types.LatestSignerForChainID(params.TestChainConfig.ChainID)
+	tx, err := types.SignTx(types.NewTransaction(1, getterContract, big.NewInt(0), params.TxGas, big.NewInt(1), nil), signer, testKey)
+	require.NoError(t, err)
+	data, err := rlp.EncodeToBytes(tx)
+	require.NoError(t, err)
+
+	hash, err := r.SendRawTransaction(ctx, struct{ Data hexutil.Bytes }{data})
+	require.NoError(t, err)
+	assert.Equal(t, tx.Hash(), hash)
+	require.Len(t, backend.sent, 1)
+	assert.Equal(t, tx.Hash(), backend.sent[0].Hash())
+
+	// The transaction which cannot be decoded is not sent.
+	_, err = r.SendRawTransaction(ctx, struct{ Data hexutil.Bytes }{data[:len(data)-1]})
+	assert.Error(t, err)
+	assert.Len(t, backend.sent, 1)
+}
+
+func TestResolverLogs(t *testing.T) {
+	backend := newTestBackend(t, 3)
+	defer backend.chain.Stop()
+	r := &Resolver{backend: backend}
+	ctx := context.Background()
+
+	from, to := Long(1), Long(3)
+	logs, err := r.Logs(ctx, struct{ Filter FilterCriteria }{FilterCriteria{FromBlock: &from, ToBlock: &to}})
+	require.NoError(t, err)
+	assert.Len(t, logs, 3)
+
+	block, err := r.Block(ctx, struct {
+		Number *Long
+		Hash   *common.Hash
+	}{Number: &to})
+	require.NoError(t, err)
+	blockLogs, err := block.Logs(ctx, struct{ Filter BlockFilterCriteria }{})
+	require.NoError(t, err)
+	require.Len(t, blockLogs, 1)
+	assert.Equal(t, backend.chain.GetBlockByNumber(3).Transactions()[0].Hash(), blockLogs[0].Transaction(ctx).Hash(ctx))
+
+	// The logs query is limited like getLogs.
+	defer func(old uint64) { filters.GetLogsMaxBlockRange = old }(filters.GetLogsMaxBlockRange)
+	filters.GetLogsMaxBlockRange = 2
+	_, err = r.Logs(ctx, struct{ Filter FilterCriteria }{FilterCriteria{FromBlock: &from, ToBlock: &to}})
+	assert.Error(t, err)
+
+	defer func(old int) { filters.GetLogsMaxItems = old }(filters.GetLogsMaxItems)
+	filters.GetLogsMaxBlockRange = 0
+	filters.GetLogsMaxItems = 2
+	_, err = r.Logs(ctx, struct{ Filter FilterCriteria }{FilterCriteria{FromBlock: &from, ToBlock: &to}})
+	assert.Error(t, err)
+}
+
+func TestResolverBlocksRange(t *testing.T) {
+	backend := newTestBackend(t, 3)
+	defer backend.chain.Stop()
+	r := &Resolver{backend: backend}
+	ctx := context.Background()
+
+	from, to := Long(1), Long(3)
+	blocks, err := r.Blocks(ctx, struct {
+		From *Long
+		To   *Long
+	}{From: &from, To: &to})
+	require.NoError(t, err)
+	require.Len(t, blocks, 3)
+	for i, block := range blocks {
+		number, err := block.Number(ctx)
+		require.NoError(t, err)
+		assert.Equal(t, Long(i+1), number)
+	}
+
+	// A range beyond the head is cut at the head, so it is checked against a long chain.
+	long := newTestBackend(t, maxBlocksRange+1)
+	defer long.chain.Stop()
+	r = &Resolver{backend: long}
+	from, to = Long(0), Long(maxBlocksRange)
+	_, err = r.Blocks(ctx, struct {
+		From *Long
+		To   *Long
+	}{From: &from, To: &to})
+	assert.Equal(t, errBlocksRange, err)
+
+	from = Long(1)
+	blocks, err = r.Blocks(ctx, struct {
+		From *Long
+		To   *Long
+	}{From: &from, To: &to})
+	require.NoError(t, err)
+	assert.Len(t, blocks, maxBlocksRange)
+}
+
+// TestBuildSchema checks that every field of the schema has a resolver.
+func TestBuildSchema(t *testing.T) {
+	_, err := newHandler(nil)
+	assert.NoError(t, err)
+}
+
+func TestGraphQLHTTPOnSamePath(t *testing.T) {
+	handler, err := newHandler(nil)
+	require.NoError(t, err)
+	server := httptest.NewServer(handler)
+	defer server.Close()
+
+	resp, err := http.Post(server.URL+"/graphql", "application/json", strings.NewReader(`{"query":"{__typename}"}`))
+	require.NoError(t, err)
+	defer resp.Body.Close()
+	assert.Equal(t, http.StatusOK, resp.StatusCode)
+
+	resp, err = http.Post(server.URL+"/other", "application/json", strings.NewReader(`{"query":"{__typename}"}`))
+	require.NoError(t, err)
+	defer resp.Body.Close()
+	assert.Equal(t, http.StatusNotFound, resp.StatusCode)
+}
+
+func TestLongUnmarshalGraphQL(t *testing.T) {
+	tests := []struct {
+		input interface{}
+		want  Long
+	}{
+		{"0x10", 16},
+		{"16", 16},
+		{int32(16), 16},
+		{int64(16), 16},
+		{float64(16), 16},
+	}
+	for _, test := range tests {
+		var l Long
+		require.NoError(t, l.UnmarshalGraphQL(test.input), test.input)
+		assert.Equal(t, test.want, l, test.input)
+	}
+	var l Long
+	assert.Error(t, l.UnmarshalGraphQL(true))
+	assert.Error(t, l.UnmarshalGraphQL("0xzz"))
+}
@@ -0,0 +1,129 @@
+// Modifications Copyright 2022 The klaytn Authors
+// Copyright 2019 The go-ethereum Authors
+// This file is part of the go-ethereum library.
+//
+// The go-ethereum library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The go-ethereum library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the go-ethereum library. If not, see <http://www.gnu.org/licenses/>.
+//
+// This file is derived from graphql/service.go (2022/10/01).
+// Modified and improved for the klaytn development.
+
+package graphql
+
+import (
+	"errors"
+	"fmt"
+	"net"
+	"net/http"
+
+	"github.com/graph-gophers/graphql-go"
+	"github.com/graph-gophers/graphql-go/relay"
+	"github.com/klaytn/klaytn/log"
+	"github.com/klaytn/klaytn/networks/p2p"
+	"github.com/klaytn/klaytn/networks/rpc"
+)
+
+// maxQueryDepth is the maximum nesting depth of a GraphQL query, to reject queries
+// expanding into an excessive amount of work such as nested parents of blocks.
+const maxQueryDepth = 20
+
+var (
+	logger = log.NewModuleLogger(log.GraphQL)
+
+	errNoBackend = errors.New("the GraphQL service requires the CN service")
+)
+
+// Service encapsulates a GraphQL service.
+type Service struct {
+	endpoint string           // The host:port endpoint for this service.
+	cors     []string         // Allowed CORS domains
+	vhosts   []string         // Recognised vhosts
+	timeouts rpc.HTTPTimeouts // Timeout settings for HTTP requests.
+	backend  Backend          // The backend that queries will operate on.
+	handler  http.Handler     // The `http.Handler` used to answer queries.
+	listener net.Listener     // The listening socket.
+}
+
+// New constructs a new GraphQL service instance. The backend is given by the CN
+// service through SetComponents.
+func New(endpoint string, cors, vhosts []string, timeouts rpc.HTTPTimeouts) (*Service, error) {
+	return &Service{
+		endpoint: endpoint,
+		cors:     cors,
+		vhosts:   vhosts,
+		timeouts: timeouts,
+	}, nil
+}
+
+// Protocols returns the list of protocols exported by this service.
+func (s *Service) Protocols() []p2p.Protocol { return nil }
+
+// APIs returns the list of APIs exported by this service.
+func (s *Service) APIs() []rpc.API { return nil }
+
+// Components returns nil, since the service does not provide any component.
+func (s *Service) Components() []interface{} { return nil }
+
+// SetComponents takes the backend of the CN service.
+func (s *Service) SetComponents(components []interface{}) {
+	for _, component := range components {
+		if backend, ok := component.(Backend); ok {
+			s.backend = backend
+		}
+	}
+}
+
+// Start is called after all services have been constructed and the networking
+// layer was also initialized to spawn any goroutines required by the service.
+func (s *Service) Start(server p2p.Server) error {
+	if s.backend == nil {
+		return errNoBackend
+	}
+	handler, err := newHandler(s.backend)
+	if err != nil {
+		return err
+	}
+	s.handler = handler
+
+	if s.listener, err = net.Listen("tcp", s.endpoint); err != nil {
+		return err
+	}
+	go rpc.NewHTTPServer(s.cors, s.vhosts, s.timeouts, s.handler).Serve(s.listener)
+	logger.Info("GraphQL endpoint opened", "url", fmt.Sprintf("http://%s/graphql", s.endpoint))
+	return nil
+}
+
+// Stop terminates all goroutines belonging to the service, blocking until they
+// are all terminated.
+func (s *Service) Stop() error {
+	if s.listener != nil {
+		s.listener.Close()
+		s.listener = nil
+		logger.Info("GraphQL endpoint closed", "url", fmt.Sprintf("http://%s/graphql", s.endpoint))
+	}
+	return nil
+}
+
+// newHandler returns a new `http.Handler` that will answer GraphQL queries at /graphql.
+func newHandler(backend Backend) (http.Handler, error) {
+	q := Resolver{backend}
+
+	s, err := graphql.ParseSchema(schema, &q, graphql.MaxDepth(maxQueryDepth))
+	if err != nil {
+		return nil, err
+	}
+	mux := http.NewServeMux()
+	mux.Handle("/graphql", &relay.Handler{Schema: s})
+	mux.Handle("/graphql/", &relay.Handler{Schema: s})
+	return mux, nil
+}
@@ -41,6 +41,7 @@ var logger = log.NewModuleLogger(log.NetworksGRPC)
 type Listener struct {
 	Addr       string
 	handler    *rpc.Server
+	stream     *streamServer
 	grpcServer *grpc.Server
 }
 
@@ -252,6 +253,12 @@ func (gs *Listener) SetRPCServer(handler *rpc.Server) {
 	gs.handler = handler
 }
 
+// SetStreamBackend sets the backend of the block stream service.
+// The block stream service is not served if the backend is not set.
+func (gs *Listener) SetStreamBackend(backend StreamBackend, tracer StreamTracer) {
+	gs.stream = &streamServer{backend: backend, tracer: tracer}
+}
+
 func (gs *Listener) Start() {
 	lis, err := net.Listen("tcp", gs.Addr)
 	if err != nil {
@@ -261,6 +268,9 @@ func (gs *Listener) Start() {
 	gs.grpcServer = grpc.NewServer()
 
 	RegisterKlaytnNodeServer(gs.grpcServer, &klaytnServer{handler: gs.handler})
+	if gs.stream != nil {
+		RegisterKlaytnStreamServer(gs.grpcServer, gs.stream)
+	}
 
 	// Register reflection service on gRPC server.
 	reflection.Register(gs.grpcServer)
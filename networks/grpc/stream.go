@@ -0,0 +1,217 @@
+// Copyright 2022 The klaytn Authors
+// This file is part of the klaytn library.
+//
+// The klaytn library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The klaytn library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the klaytn library. If not, see <http://www.gnu.org/licenses/>.
+
+package grpc
+
+import (
+	"context"
+	"errors"
+
+	"github.com/klaytn/klaytn/blockchain"
+	"github.com/klaytn/klaytn/blockchain/types"
+	"github.com/klaytn/klaytn/common"
+	"github.com/klaytn/klaytn/event"
+	"github.com/klaytn/klaytn/networks/rpc"
+	"github.com/klaytn/klaytn/rlp"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// chainEventChanSize is the size of channel listening to ChainEvent.
+const chainEventChanSize = 64
+
+var (
+	// StreamTraces enables the traces of the transactions in the block stream.
+	// Tracing re-executes every streamed block, so it is disabled by default.
+	StreamTraces = false
+
+	// StreamMaxBackfill is the maximum number of blocks behind the current block which
+	// the block stream can backfill from.
+	StreamMaxBackfill uint64 = 86400
+)
+
+// StreamBackend provides the blocks and the receipts streamed by KlaytnStream.
+type StreamBackend interface {
+	CurrentBlock() *types.Block
+	BlockByNumber(ctx context.Context, blockNr rpc.BlockNumber) (*types.Block, error)
+	GetBlockReceipts(ctx context.Context, blockHash common.Hash) types.Receipts
+	SubscribeChainEvent(ch chan<- blockchain.ChainEvent) event.Subscription
+}
+
+// StreamTracer traces the transactions of a streamed block with the given tracer.
+// The struct logger is used if the tracer is empty.
+type StreamTracer interface {
+	TraceBlock(ctx context.Context, block *types.Block, tracer string) ([]*TraceMessage, error)
+}
+
+// StreamService is implemented by the node services which provide the block stream.
+type StreamService interface {
+	StreamBackend() (StreamBackend, StreamTracer)
+}
+
+// streamServer is an implementation of KlaytnStreamServer.
+type streamServer struct {
+	backend StreamBackend
+	tracer  StreamTracer
+}
+
+// StreamBlocks sends the blocks from req.FromBlock if req.Backfill is set, and then
+// the new blocks inserted to the chain until the client cancels the stream.
+func (s *streamServer) StreamBlocks(req *StreamRequest, stream KlaytnStream_StreamBlocksServer) error {
+	if req.Traces && (!StreamTraces || s.tracer == nil) {
+		return status.Error(codes.PermissionDenied, "traces are disabled")
+	}
+	ctx := stream.Context()
+
+	current := s.backend.CurrentBlock().NumberU64()
+	next := current + 1
+	if req.Backfill {
+		if req.FromBlock+StreamMaxBackfill < current {
+			return status.Errorf(codes.InvalidArgument, "cannot backfill more than %d blocks", StreamMaxBackfill)
+		}
+		next = req.FromBlock
+	}
+
+	// Subscribe before the backfill, so that no block is missed in between.
+	// The chain events are drained by another goroutine keeping only the latest head,
+	// so that a backfill or a slow client never blocks the chain event feed.
+	chainCh := make(chan blockchain.ChainEvent, chainEventChanSize)
+	sub := s.backend.SubscribeChainEvent(chainCh)
+	defer sub.Unsubscribe()
+
+	heads := make(chan uint64, 1)
+	quit := make(chan struct{})
+	defer close(quit)
+	go func() {
+		for {
+			select {
+			case ev := <-chainCh:
+				if ev.Block == nil {
+					continue
+				}
+				// Replace the head not taken yet. This goroutine is the only sender,
+				// so the channel has room after the replaced head is taken.
+				select {
+				case <-heads:
+				default:
+				}
+				heads <- ev.Block.NumberU64()
+			case <-quit:
+				return
+			}
+		}
+	}()
+
+	// sendUntil sends the blocks from next to the given number which are not sent yet.
+	sendUntil := func(number uint64) error {
+		for ; next <= number; next++ {
+			block, err := s.backend.BlockByNumber(ctx, rpc.BlockNumber(next))
+			if err != nil {
+				return status.Errorf(codes.NotFound, "block #%d: %v", next, err)
+			}
+			if block == nil {
+				return status.Errorf(codes.NotFound, "block #%d not found", next)
+			}
+			if err := s.send(ctx, stream, req, block); err != nil {
+				return err
+			}
+		}
+		return nil
+	}
+	if err := sendUntil(current); err != nil {
+		return err
+	}
+
+	for {
+		select {
+		case number := <-heads:
+			if err := sendUntil(number); err != nil {
+				return err
+			}
+		case err := <-sub.Err():
+			if err == nil {
+				err = errors.New("chain event subscription closed")
+			}
+			return status.Error(codes.Unavailable, err.Error())
+		case <-ctx.Done():
+			return nil
+		}
+	}
+}
+
+// send sends the block with the data selected by req.
+func (s *streamServer) send(ctx context.Context, stream KlaytnStream_StreamBlocksServer, req *StreamRequest, block *types.Block) error {
+	msg, err := newBlockMessage(block)
+	if err != nil {
+		return status.Error(codes.Internal, err.Error())
+	}
+	if req.Receipts {
+		msg.Receipts = newReceiptMessages(block, s.backend.GetBlockReceipts(ctx, block.Hash()))
+	}
+	// The genesis block has no transaction to trace.
+	if req.Traces && block.NumberU64() > 0 {
+		if msg.Traces, err = s.tracer.TraceBlock(ctx, block, req.Tracer); err != nil {
+			return status.Errorf(codes.Internal, "failed to trace block #%d: %v", block.NumberU64(), err)
+		}
+	}
+	return stream.Send(msg)
+}
+
+func newBlockMessage(block *types.Block) (*BlockMessage, error) {
+	enc, err := rlp.EncodeToBytes(block)
+	if err != nil {
+		return nil, err
+	}
+	return &BlockMessage{
+		Number:     block.NumberU64(),
+		Hash:       block.Hash().Bytes(),
+		ParentHash: block.ParentHash().Bytes(),
+		Timestamp:  block.Time().Uint64(),
+		Rlp:        enc,
+	}, nil
+}
+
+func newReceiptMessages(block *types.Block, receipts types.Receipts) []*ReceiptMessage {
+	txs := block.Transactions()
+	msgs := make([]*ReceiptMessage, 0, len(receipts))
+	for i, receipt := range receipts {
+		msg := &ReceiptMessage{
+			Status:  uint64(receipt.Status),
+			GasUsed: receipt.GasUsed,
+			Logs:    make([]*LogMessage, 0, len(receipt.Logs)),
+		}
+		if i < len(txs) {
+			msg.TxHash = txs[i].Hash().Bytes()
+		}
+		if receipt.ContractAddress != (common.Address{}) {
+			msg.ContractAddress = receipt.ContractAddress.Bytes()
+		}
+		for _, log := range receipt.Logs {
+			topics := make([][]byte, len(log.Topics))
+			for j, topic := range log.Topics {
+				topics[j] = topic.Bytes()
+			}
+			msg.Logs = append(msg.Logs, &LogMessage{
+				Address: log.Address.Bytes(),
+				Topics:  topics,
+				Data:    log.Data,
+				Index:   uint32(log.Index),
+			})
+		}
+		msgs = append(msgs, msg)
+	}
+	return msgs
+}
@@ -0,0 +1,227 @@
+// Copyright 2022 The klaytn Authors
+// This file is part of the klaytn library.
+//
+// The klaytn library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The klaytn library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the klaytn library. If not, see <http://www.gnu.org/licenses/>.
+
+package grpc
+
+import (
+	"context"
+	"math/big"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/klaytn/klaytn/blockchain"
+	"github.com/klaytn/klaytn/blockchain/types"
+	"github.com/klaytn/klaytn/common"
+	"github.com/klaytn/klaytn/event"
+	"github.com/klaytn/klaytn/networks/rpc"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+type testStreamBackend struct {
+	blocks   []*types.Block
+	receipts map[common.Hash]types.Receipts
+	current  uint64
+	feed     event.Feed
+}
+
+func newTestStreamBackend(n int) *testStreamBackend {
+	b := &testStreamBackend{receipts: make(map[common.Hash]types.Receipts)}
+	parent := common.Hash{}
+	for i := 0; i < n; i++ {
+		block := types.NewBlockWithHeader(&types.Header{
+			ParentHash: parent,
+			BlockScore: big.NewInt(1),
+			Number:     big.NewInt(int64(i)),
+			Time:       big.NewInt(int64(1000 + i)),
+		})
+		b.blocks = append(b.blocks, block)
+		parent = block.Hash()
+	}
+	return b
+}
+
+func (b *testStreamBackend) CurrentBlock() *types.Block {
+	return b.blocks[atomic.LoadUint64(&b.current)]
+}
+
+func (b *testStreamBackend) BlockByNumber(ctx context.Context, blockNr rpc.BlockNumber) (*types.Block, error) {
+	if int(blockNr) >= len(b.blocks) {
+		return nil, nil
+	}
+	return b.blocks[blockNr], nil
+}
+
+func (b *testStreamBackend) GetBlockReceipts(ctx context.Context, blockHash common.Hash) types.Receipts {
+	return b.receipts[blockHash]
+}
+
+func (b *testStreamBackend) SubscribeChainEvent(ch chan<- blockchain.ChainEvent) event.Subscription {
+	return b.feed.Subscribe(ch)
+}
+
+type testBlocksStream struct {
+	grpc.ServerStream
+	ctx  context.Context
+	sent chan *BlockMessage
+}
+
+func (s *testBlocksStream) Context() context.Context { return s.ctx }
+
+func (s *testBlocksStream) Send(m *BlockMessage) error {
+	s.sent <- m
+	return nil
+}
+
+func TestStreamBlocks_BackfillAndGap(t *testing.T) {
+	backend := newTestStreamBackend(6)
+	atomic.StoreUint64(&backend.current, 3)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	stream := &testBlocksStream{ctx: ctx, sent: make(chan *BlockMessage, 10)}
+	server := &streamServer{backend: backend}
+
+	errCh := make(chan error, 1)
+	go func() { errCh <- server.StreamBlocks(&StreamRequest{Backfill: true, FromBlock: 1}, stream) }()
+
+	recv := func() *BlockMessage {
+		select {
+		case m := <-stream.sent:
+			return m
+		case <-time.After(time.Second):
+			t.Fatal("timeout waiting for a block")
+		}
+		return nil
+	}
+	for i := uint64(1); i <= 3; i++ {
+		m := recv()
+		assert.Equal(t, i, m.Number)
+		assert.Equal(t, backend.blocks[i].Hash().Bytes(), m.Hash)
+		assert.Equal(t, backend.blocks[i].ParentHash().Bytes(), m.ParentHash)
+		assert.Equal(t, 1000+i, m.Timestamp)
+	}
+
+	// Block #4 is not notified, so it should be filled before block #5.
+	atomic.StoreUint64(&backend.current, 5)
+	backend.feed.Send(blockchain.ChainEvent{Block: backend.blocks[5]})
+	assert.Equal(t, uint64(4), recv().Number)
+	assert.Equal(t, uint64(5), recv().Number)
+
+	// An old block should not be sent again.
+	backend.feed.Send(blockchain.ChainEvent{Block: backend.blocks[2]})
+	select {
+	case m := <-stream.sent:
+		t.Fatalf("unexpected block #%d", m.Number)
+	case <-time.After(100 * time.Millisecond):
+	}
+
+	cancel()
+	require.NoError(t, <-errCh)
+}
+
+func TestStreamBlocks_SlowClient(t *testing.T) {
+	backend := newTestStreamBackend(10)
+	atomic.StoreUint64(&backend.current, 9)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	// The stream blocks on the sends until the blocks are received.
+	stream := &testBlocksStream{ctx: ctx, sent: make(chan *BlockMessage)}
+	server := &streamServer{backend: backend}
+
+	errCh := make(chan error, 1)
+	go func() { errCh <- server.StreamBlocks(&StreamRequest{Backfill: true}, stream) }()
+
+	// The chain events are not blocked by the backfill of the slow client.
+	sent := make(chan struct{})
+	go func() {
+		for i := 0; i < 2*chainEventChanSize; i++ {
+			backend.feed.Send(blockchain.ChainEvent{Block: backend.blocks[9]})
+		}
+		close(sent)
+	}()
+	select {
+	case <-sent:
+	case <-time.After(time.Second):
+		t.Fatal("the chain event feed is blocked by the stream")
+	}
+
+	for i := uint64(0); i <= 9; i++ {
+		select {
+		case m := <-stream.sent:
+			assert.Equal(t, i, m.Number)
+		case <-time.After(time.Second):
+			t.Fatal("timeout waiting for a block")
+		}
+	}
+	cancel()
+	require.NoError(t, <-errCh)
+}
+
+func TestStreamBlocks_MaxBackfill(t *testing.T) {
+	defer func(old uint64) { StreamMaxBackfill = old }(StreamMaxBackfill)
+	StreamMaxBackfill = 2
+
+	backend := newTestStreamBackend(6)
+	atomic.StoreUint64(&backend.current, 5)
+	stream := &testBlocksStream{ctx: context.Background(), sent: make(chan *BlockMessage, 10)}
+	server := &streamServer{backend: backend}
+
+	err := server.StreamBlocks(&StreamRequest{Backfill: true, FromBlock: 2}, stream)
+	assert.Equal(t, codes.InvalidArgument, status.Code(err))
+}
+
+func TestStreamBlocks_TracesDisabled(t *testing.T) {
+	defer func(old bool) { StreamTraces = old }(StreamTraces)
+	StreamTraces = false
+
+	stream := &testBlocksStream{ctx: context.Background(), sent: make(chan *BlockMessage, 1)}
+	server := &streamServer{backend: newTestStreamBackend(1)}
+
+	err := server.StreamBlocks(&StreamRequest{Traces: true}, stream)
+	assert.Equal(t, codes.PermissionDenied, status.Code(err))
+}
+
+func TestNewReceiptMessages(t *testing.T) {
+	tx := types.NewTransaction(0, common.Address{1}, big.NewInt(0), 21000, big.NewInt(1), nil)
+	block := types.NewBlockWithHeader(&types.Header{Number: big.NewInt(1)}).WithBody([]*types.Transaction{tx})
+	receipt := &types.Receipt{
+		Status:          types.ReceiptStatusSuccessful,
+		GasUsed:         21000,
+		ContractAddress: common.Address{2},
+		Logs: []*types.Log{{
+			Address: common.Address{3},
+			Topics:  []common.Hash{{4}},
+			Data:    []byte{5},
+			Index:   6,
+		}},
+	}
+
+	msgs := newReceiptMessages(block, types.Receipts{receipt})
+	require.Len(t, msgs, 1)
+	assert.Equal(t, tx.Hash().Bytes(), msgs[0].TxHash)
+	assert.Equal(t, uint64(types.ReceiptStatusSuccessful), msgs[0].Status)
+	assert.Equal(t, uint64(21000), msgs[0].GasUsed)
+	assert.Equal(t, common.Address{2}.Bytes(), msgs[0].ContractAddress)
+	require.Len(t, msgs[0].Logs, 1)
+	assert.Equal(t, common.Address{3}.Bytes(), msgs[0].Logs[0].Address)
+	assert.Equal(t, [][]byte{common.Hash{4}.Bytes()}, msgs[0].Logs[0].Topics)
+	assert.Equal(t, []byte{5}, msgs[0].Logs[0].Data)
+	assert.Equal(t, uint32(6), msgs[0].Logs[0].Index)
+}
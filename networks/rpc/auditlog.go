@@ -0,0 +1,150 @@
+// Copyright 2022 The klaytn Authors
+// This file is part of the klaytn library.
+//
+// The klaytn library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The klaytn library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the klaytn library. If not, see <http://www.gnu.org/licenses/>.
+
+package rpc
+
+import (
+	"context"
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"io"
+	mrand "math/rand"
+	"net"
+	"os"
+	"sync"
+	"time"
+)
+
+var (
+	// AuditLogSampleRate is the fraction of the calls written to the audit log, between 0 and 1.
+	AuditLogSampleRate = 1.0
+
+	// AuditLogSlowCall is the duration over which a call is written to the audit log regardless of
+	// AuditLogSampleRate (0 = sampled like the other calls).
+	AuditLogSlowCall time.Duration
+
+	// auditLog is the audit log of the RPC calls, nil unless OpenAuditLog is called.
+	auditLog *auditLogger
+)
+
+// auditEntry is a line of the audit log. It does not contain the parameters of a call, the full
+// address of a client, or an API key, so that the log can be kept and shared without them.
+type auditEntry struct {
+	Time       time.Time  `json:"time"`
+	Method     string     `json:"method"`
+	ParamsHash string     `json:"paramsHash,omitempty"`
+	Duration   float64    `json:"durationMs"`
+	Error      *jsonError `json:"error,omitempty"`
+	Scheme     string     `json:"scheme,omitempty"`
+	ClientIP   string     `json:"clientIP,omitempty"`
+	UserAgent  string     `json:"userAgent,omitempty"`
+	APIKeyHash string     `json:"apiKeyHash,omitempty"`
+}
+
+// auditLogger writes the audit entries to w as JSON lines.
+type auditLogger struct {
+	mu  sync.Mutex
+	enc *json.Encoder
+
+	// key is the random key to hash the parameters and the API keys. The hashes can be
+	// compared within a run of the node, but cannot be reversed by hashing the guesses.
+	key []byte
+}
+
+// OpenAuditLog starts writing the RPC calls to the file of the path as JSON lines.
+func OpenAuditLog(path string) error {
+	f, err := os.OpenFile(path, os.O_CREATE|os.O_WRONLY|os.O_APPEND, 0o600)
+	if err != nil {
+		return err
+	}
+	l, err := newAuditLogger(f)
+	if err != nil {
+		f.Close()
+		return err
+	}
+	auditLog = l
+	logger.Info("Writing the audit log of RPC calls", "path", path, "sampleRate", AuditLogSampleRate, "slowCall", AuditLogSlowCall)
+	return nil
+}
+
+func newAuditLogger(w io.Writer) (*auditLogger, error) {
+	key := make([]byte, 32)
+	if _, err := rand.Read(key); err != nil {
+		return nil, err
+	}
+	return &auditLogger{enc: json.NewEncoder(w), key: key}, nil
+}
+
+// sampled reports whether a call taking the duration is written to the audit log.
+func (l *auditLogger) sampled(duration time.Duration) bool {
+	if AuditLogSlowCall > 0 && duration >= AuditLogSlowCall {
+		return true
+	}
+	return AuditLogSampleRate >= 1 || mrand.Float64() < AuditLogSampleRate
+}
+
+// record writes the call of the message if it is sampled.
+func (l *auditLogger) record(ctx context.Context, conn jsonWriter, msg, resp *jsonrpcMessage, start time.Time) {
+	duration := time.Since(start)
+	if !l.sampled(duration) {
+		return
+	}
+	entry := &auditEntry{
+		Time:     start.UTC(),
+		Method:   msg.Method,
+		Duration: float64(duration) / float64(time.Millisecond),
+		ClientIP: redactIP(clientIP(ctx, conn)),
+	}
+	if len(msg.Params) > 0 {
+		entry.ParamsHash = l.hash(msg.Params)
+	}
+	if resp != nil {
+		entry.Error = resp.Error
+	}
+	entry.Scheme, _ = ctx.Value("scheme").(string)
+	entry.UserAgent, _ = ctx.Value("User-Agent").(string)
+	if apiKey, _ := ctx.Value(APIKeyHeader).(string); apiKey != "" {
+		entry.APIKeyHash = l.hash([]byte(apiKey))
+	}
+
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	if err := l.enc.Encode(entry); err != nil {
+		logger.Warn("Failed to write the audit log of an RPC call", "method", msg.Method, "err", err)
+	}
+}
+
+func (l *auditLogger) hash(data []byte) string {
+	mac := hmac.New(sha256.New, l.key)
+	mac.Write(data)
+	return hex.EncodeToString(mac.Sum(nil)[:8])
+}
+
+// redactIP masks the host part of an IP address: the last byte of an IPv4 address,
+// and the last 80 bits of an IPv6 address.
+func redactIP(addr string) string {
+	ip := net.ParseIP(addr)
+	if ip == nil {
+		return ""
+	}
+	if ip4 := ip.To4(); ip4 != nil {
+		return ip4.Mask(net.CIDRMask(24, 32)).String()
+	}
+	return ip.Mask(net.CIDRMask(48, 128)).String()
+}
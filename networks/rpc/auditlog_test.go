@@ -0,0 +1,113 @@
+// Copyright 2022 The klaytn Authors
+// This file is part of the klaytn library.
+//
+// The klaytn library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The klaytn library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the klaytn library. If not, see <http://www.gnu.org/licenses/>.
+
+package rpc
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func setAuditLog(t *testing.T, sampleRate float64, slowCall time.Duration) (*bytes.Buffer, func()) {
+	oldLog, oldRate, oldSlowCall := auditLog, AuditLogSampleRate, AuditLogSlowCall
+	buf := new(bytes.Buffer)
+	l, err := newAuditLogger(buf)
+	require.NoError(t, err)
+	auditLog, AuditLogSampleRate, AuditLogSlowCall = l, sampleRate, slowCall
+	return buf, func() { auditLog, AuditLogSampleRate, AuditLogSlowCall = oldLog, oldRate, oldSlowCall }
+}
+
+func readAuditEntries(t *testing.T, buf *bytes.Buffer) []auditEntry {
+	var entries []auditEntry
+	dec := json.NewDecoder(buf)
+	for dec.More() {
+		var entry auditEntry
+		require.NoError(t, dec.Decode(&entry))
+		entries = append(entries, entry)
+	}
+	return entries
+}
+
+func TestAuditLog(t *testing.T) {
+	buf, restore := setAuditLog(t, 1, 0)
+	defer restore()
+
+	server := newTestServer("service", new(Service))
+	defer server.Stop()
+	httpsrv := httptest.NewServer(server)
+	defer httpsrv.Close()
+
+	post := func(body string) {
+		req, err := http.NewRequest(http.MethodPost, httpsrv.URL, strings.NewReader(body))
+		require.NoError(t, err)
+		req.Header.Set("Content-Type", "application/json")
+		req.Header.Set(APIKeyHeader, "secret-key")
+		resp, err := http.DefaultClient.Do(req)
+		require.NoError(t, err)
+		resp.Body.Close()
+	}
+	post(`{"jsonrpc":"2.0","id":1,"method":"service_echo","params":["0xsecret",1,null]}`)
+	post(`{"jsonrpc":"2.0","id":2,"method":"service_echo","params":["0xsecret",1,null]}`)
+	post(`{"jsonrpc":"2.0","id":3,"method":"service_unknown"}`)
+
+	log := buf.String()
+	assert.NotContains(t, log, "secret")
+
+	entries := readAuditEntries(t, bytes.NewBufferString(log))
+	require.Len(t, entries, 3)
+	assert.Equal(t, "service_echo", entries[0].Method)
+	assert.NotEmpty(t, entries[0].ParamsHash)
+	assert.Equal(t, entries[0].ParamsHash, entries[1].ParamsHash)
+	assert.NotEmpty(t, entries[0].APIKeyHash)
+	assert.Equal(t, "127.0.0.0", entries[0].ClientIP)
+	assert.Nil(t, entries[0].Error)
+
+	assert.Equal(t, "service_unknown", entries[2].Method)
+	require.NotNil(t, entries[2].Error)
+	assert.Equal(t, -32601, entries[2].Error.Code)
+}
+
+func TestAuditLog_Sampling(t *testing.T) {
+	buf, restore := setAuditLog(t, 0, 10*time.Millisecond)
+	defer restore()
+
+	server := newTestServer("service", new(Service))
+	defer server.Stop()
+	client := DialInProc(server)
+	defer client.Close()
+
+	require.NoError(t, client.Call(nil, "service_sleep", time.Duration(0)))
+	require.NoError(t, client.Call(nil, "service_sleep", 20*time.Millisecond))
+
+	// Only the slow call is written when nothing is sampled.
+	entries := readAuditEntries(t, buf)
+	require.Len(t, entries, 1)
+	assert.GreaterOrEqual(t, entries[0].Duration, float64(10))
+}
+
+func TestRedactIP(t *testing.T) {
+	assert.Equal(t, "192.168.1.0", redactIP("192.168.1.77"))
+	assert.Equal(t, "2001:db8:1::", redactIP("2001:db8:1:2:3:4:5:6"))
+	assert.Equal(t, "", redactIP("pipe"))
+}
@@ -0,0 +1,181 @@
+// Copyright 2022 The klaytn Authors
+// This file is part of the klaytn library.
+//
+// The klaytn library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The klaytn library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the klaytn library. If not, see <http://www.gnu.org/licenses/>.
+
+package rpc
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net"
+	"net/http"
+	"strings"
+	"time"
+)
+
+const (
+	// JWTSecretLength is the length of the shared secret of the authenticated endpoint.
+	JWTSecretLength = 32
+
+	// jwtExpiryTimeout is the maximum difference between the issued-at claim of a token
+	// and the local time. Tokens are not reusable after it, which limits replay attacks.
+	jwtExpiryTimeout = 60 * time.Second
+)
+
+var (
+	errMissingToken    = errors.New("missing token")
+	errMalformedToken  = errors.New("malformed token")
+	errUnsupportedAlg  = errors.New("unsupported signing algorithm")
+	errInvalidSig      = errors.New("invalid signature")
+	errMissingIssuedAt = errors.New("missing issued-at")
+	errStaleToken      = errors.New("stale token")
+	errTokenExpired    = errors.New("token is expired")
+)
+
+// jwtHandler rejects the requests which do not carry a valid HS256 bearer token
+// signed by the shared secret, as the engine API of Ethereum does.
+type jwtHandler struct {
+	secret []byte
+	next   http.Handler
+}
+
+// NewJWTHandler returns a handler which passes the requests authenticated by the
+// given secret to next.
+func NewJWTHandler(secret []byte, next http.Handler) http.Handler {
+	return &jwtHandler{secret: secret, next: next}
+}
+
+func (h *jwtHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	auth := r.Header.Get("Authorization")
+	if !strings.HasPrefix(auth, "Bearer ") {
+		http.Error(w, errMissingToken.Error(), http.StatusUnauthorized)
+		return
+	}
+	if err := verifyJWT(h.secret, strings.TrimPrefix(auth, "Bearer "), time.Now()); err != nil {
+		logger.Debug("Rejected unauthenticated request", "remote", r.RemoteAddr, "err", err)
+		http.Error(w, err.Error(), http.StatusUnauthorized)
+		return
+	}
+	h.next.ServeHTTP(w, r)
+}
+
+// verifyJWT checks the signature and the issued-at claim of the token.
+func verifyJWT(secret []byte, token string, now time.Time) error {
+	parts := strings.Split(token, ".")
+	if len(parts) != 3 {
+		return errMalformedToken
+	}
+	var header struct {
+		Alg string `json:"alg"`
+	}
+	if err := decodeJWTSegment(parts[0], &header); err != nil {
+		return err
+	}
+	if header.Alg != "HS256" {
+		return errUnsupportedAlg
+	}
+	sig, err := base64.RawURLEncoding.DecodeString(parts[2])
+	if err != nil {
+		return errMalformedToken
+	}
+	mac := hmac.New(sha256.New, secret)
+	mac.Write([]byte(parts[0] + "." + parts[1]))
+	if !hmac.Equal(sig, mac.Sum(nil)) {
+		return errInvalidSig
+	}
+	var claims struct {
+		IssuedAt  *int64 `json:"iat"`
+		ExpiresAt *int64 `json:"exp"`
+	}
+	if err := decodeJWTSegment(parts[1], &claims); err != nil {
+		return err
+	}
+	if claims.IssuedAt == nil {
+		return errMissingIssuedAt
+	}
+	issuedAt := time.Unix(*claims.IssuedAt, 0)
+	if diff := now.Sub(issuedAt); diff > jwtExpiryTimeout || diff < -jwtExpiryTimeout {
+		return errStaleToken
+	}
+	if claims.ExpiresAt != nil && !now.Before(time.Unix(*claims.ExpiresAt, 0)) {
+		return errTokenExpired
+	}
+	return nil
+}
+
+func decodeJWTSegment(segment string, v interface{}) error {
+	data, err := base64.RawURLEncoding.DecodeString(segment)
+	if err != nil {
+		return errMalformedToken
+	}
+	if err := json.Unmarshal(data, v); err != nil {
+		return fmt.Errorf("%v: %v", errMalformedToken, err)
+	}
+	return nil
+}
+
+// newAuthHandler serves both HTTP and websocket requests authenticated by the secret.
+func newAuthHandler(srv *Server, vhosts []string, secret []byte) http.Handler {
+	// The websocket requests are authenticated by the token, so any origin is allowed.
+	ws := srv.WebsocketHandler([]string{"*"})
+	handler := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if strings.EqualFold(r.Header.Get("Upgrade"), "websocket") {
+			ws.ServeHTTP(w, r)
+			return
+		}
+		srv.ServeHTTP(w, r)
+	})
+	return newVHostHandler(vhosts, NewJWTHandler(secret, handler))
+}
+
+// StartAuthEndpoint starts the authenticated HTTP and websocket RPC endpoint, configured
+// with vhosts/modules. All public APIs and the APIs of the given modules are exposed.
+func StartAuthEndpoint(endpoint string, apis []API, modules []string, vhosts []string, timeouts HTTPTimeouts, secret []byte) (net.Listener, *Server, error) {
+	if len(secret) != JWTSecretLength {
+		return nil, nil, fmt.Errorf("invalid JWT secret length %d, want %d", len(secret), JWTSecretLength)
+	}
+	// Generate the whitelist based on the allowed modules
+	whitelist := make(map[string]bool)
+	for _, module := range modules {
+		whitelist[module] = true
+	}
+	// Register all the APIs exposed by the services
+	handler := NewServer()
+	for _, api := range apis {
+		if whitelist[api.Namespace] || api.Public {
+			if err := handler.RegisterAPI(api); err != nil {
+				return nil, nil, err
+			}
+			logger.Debug("Authenticated RPC registered", "namespace", api.Namespace)
+		}
+	}
+	// All APIs registered, start the HTTP listener
+	listener, err := net.Listen("tcp", endpoint)
+	if err != nil {
+		return nil, nil, err
+	}
+	timeouts = sanitizeTimeouts(timeouts)
+	server := &http.Server{
+		Handler:     newAuthHandler(handler, vhosts, secret),
+		ReadTimeout: timeouts.ReadTimeout,
+		IdleTimeout: timeouts.IdleTimeout,
+	}
+	go server.Serve(listener)
+	return listener, handler, nil
+}
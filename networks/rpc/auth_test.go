@@ -0,0 +1,127 @@
+// Copyright 2022 The klaytn Authors
+// This file is part of the klaytn library.
+//
+// The klaytn library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The klaytn library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the klaytn library. If not, see <http://www.gnu.org/licenses/>.
+
+package rpc
+
+import (
+	"bytes"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/base64"
+	"io/ioutil"
+	"net/http"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/gorilla/websocket"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+var testJWTSecret = bytes.Repeat([]byte{0x42}, JWTSecretLength)
+
+func newTestJWT(secret []byte, alg string, claims string) string {
+	enc := base64.RawURLEncoding
+	unsigned := enc.EncodeToString([]byte(`{"alg":"`+alg+`","typ":"JWT"}`)) + "." + enc.EncodeToString([]byte(claims))
+	mac := hmac.New(sha256.New, secret)
+	mac.Write([]byte(unsigned))
+	return unsigned + "." + enc.EncodeToString(mac.Sum(nil))
+}
+
+func TestVerifyJWT(t *testing.T) {
+	now := time.Unix(1000000, 0)
+	tests := []struct {
+		name  string
+		token string
+		err   error
+	}{
+		{"valid", newTestJWT(testJWTSecret, "HS256", `{"iat":1000000}`), nil},
+		{"valid within the timeout", newTestJWT(testJWTSecret, "HS256", `{"iat":999950}`), nil},
+		{"stale", newTestJWT(testJWTSecret, "HS256", `{"iat":999900}`), errStaleToken},
+		{"future", newTestJWT(testJWTSecret, "HS256", `{"iat":1000100}`), errStaleToken},
+		{"expired", newTestJWT(testJWTSecret, "HS256", `{"iat":1000000,"exp":1000000}`), errTokenExpired},
+		{"missing iat", newTestJWT(testJWTSecret, "HS256", `{}`), errMissingIssuedAt},
+		{"wrong secret", newTestJWT([]byte("wrong"), "HS256", `{"iat":1000000}`), errInvalidSig},
+		{"none algorithm", newTestJWT(testJWTSecret, "none", `{"iat":1000000}`), errUnsupportedAlg},
+		{"malformed", "abc.def", errMalformedToken},
+	}
+	for _, tt := range tests {
+		assert.Equal(t, tt.err, verifyJWT(testJWTSecret, tt.token, now), tt.name)
+	}
+}
+
+func TestStartAuthEndpoint(t *testing.T) {
+	apis := []API{
+		{Namespace: "service", Version: "1.0", Service: new(Service), Public: false},
+		{Namespace: "hidden", Version: "1.0", Service: new(Service), Public: false},
+	}
+	_, _, err := StartAuthEndpoint("127.0.0.1:0", apis, []string{"service"}, []string{"*"}, DefaultHTTPTimeouts, []byte("short"))
+	assert.Error(t, err)
+
+	listener, handler, err := StartAuthEndpoint("127.0.0.1:0", apis, []string{"service"}, []string{"*"}, DefaultHTTPTimeouts, testJWTSecret)
+	require.NoError(t, err)
+	defer listener.Close()
+	defer handler.Stop()
+	addr := listener.Addr().String()
+
+	call := func(method, token string) (int, string) {
+		body := `{"jsonrpc":"2.0","id":1,"method":"` + method + `","params":[]}`
+		req, err := http.NewRequest("POST", "http://"+addr, strings.NewReader(body))
+		require.NoError(t, err)
+		req.Header.Set("Content-Type", "application/json")
+		if token != "" {
+			req.Header.Set("Authorization", "Bearer "+token)
+		}
+		resp, err := http.DefaultClient.Do(req)
+		require.NoError(t, err)
+		defer resp.Body.Close()
+		data, err := ioutil.ReadAll(resp.Body)
+		require.NoError(t, err)
+		return resp.StatusCode, string(data)
+	}
+	token := func() string {
+		return newTestJWT(testJWTSecret, "HS256", `{"iat":`+strconv.FormatInt(time.Now().Unix(), 10)+`}`)
+	}
+
+	status, _ := call("service_rets", "")
+	assert.Equal(t, http.StatusUnauthorized, status)
+	status, _ = call("service_rets", newTestJWT([]byte("wrong"), "HS256", `{"iat":`+strconv.FormatInt(time.Now().Unix(), 10)+`}`))
+	assert.Equal(t, http.StatusUnauthorized, status)
+
+	status, body := call("service_rets", token())
+	assert.Equal(t, http.StatusOK, status)
+	assert.Contains(t, body, `"result"`)
+
+	// Private APIs not in the modules are not exposed.
+	status, body = call("hidden_rets", token())
+	assert.Equal(t, http.StatusOK, status)
+	assert.Contains(t, body, `"error"`)
+
+	// Websocket connections are authenticated by the handshake.
+	_, resp, err := websocket.DefaultDialer.Dial("ws://"+addr, nil)
+	require.Error(t, err)
+	assert.Equal(t, http.StatusUnauthorized, resp.StatusCode)
+
+	conn, _, err := websocket.DefaultDialer.Dial("ws://"+addr, http.Header{"Authorization": {"Bearer " + token()}})
+	require.NoError(t, err)
+	defer conn.Close()
+	require.NoError(t, conn.WriteMessage(websocket.TextMessage, []byte(`{"jsonrpc":"2.0","id":1,"method":"service_rets","params":[]}`)))
+	_, msg, err := conn.ReadMessage()
+	require.NoError(t, err)
+	assert.Contains(t, string(msg), `"result"`)
+}
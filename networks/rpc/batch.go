@@ -0,0 +1,205 @@
+// Copyright 2022 The klaytn Authors
+// This file is part of the klaytn library.
+//
+// The klaytn library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The klaytn library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the klaytn library. If not, see <http://www.gnu.org/licenses/>.
+
+package rpc
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"sync/atomic"
+	"time"
+)
+
+var (
+	// BatchRequestLimit is the maximum number of calls in a batch request. 0 means no limit.
+	BatchRequestLimit = 0
+
+	// BatchMaxDuration is the maximum time to execute the calls of a batch request. The calls not
+	// started within it are answered with an error. 0 means no limit.
+	BatchMaxDuration time.Duration = 0
+
+	// BatchGasLimit is the maximum gas the calls of a batch request can use in total, as reported by
+	// the API methods through ConsumeBatchGas. The calls started after it is used up are answered with
+	// an error. 0 means no limit.
+	BatchGasLimit uint64 = 0
+
+	// BatchConcurrency is the maximum number of batch calls executed at the same time across the
+	// connections. The calls waiting for their turn are interleaved between the connections, so that
+	// a large batch cannot monopolize the execution. 0 means the batch calls are not scheduled.
+	BatchConcurrency = 0
+
+	batchCalls = &batchScheduler{queues: make(map[interface{}][]chan struct{})}
+)
+
+// batchLimitError is returned for the calls of a batch request exceeding the limits.
+type batchLimitError struct{ message string }
+
+func (e *batchLimitError) ErrorCode() int { return -32005 }
+
+func (e *batchLimitError) Error() string { return e.message }
+
+type batchGasKey struct{}
+
+// batchGas is the gas used by the calls of a batch request.
+type batchGas struct {
+	used  uint64
+	limit uint64
+}
+
+// ConsumeBatchGas adds the gas used by a call to the gas used by its batch request.
+// It does nothing if the call is not a part of a batch request limited by BatchGasLimit.
+func ConsumeBatchGas(ctx context.Context, gas uint64) {
+	if g, ok := ctx.Value(batchGasKey{}).(*batchGas); ok {
+		atomic.AddUint64(&g.used, gas)
+	}
+}
+
+// CheckBatchGas returns an error if the gas used by the batch request of the call has reached
+// BatchGasLimit, so that a method executing several calls can stop between them.
+// It returns nil if the call is not a part of a batch request limited by BatchGasLimit.
+func CheckBatchGas(ctx context.Context) error {
+	if g, ok := ctx.Value(batchGasKey{}).(*batchGas); ok {
+		return g.check()
+	}
+	return nil
+}
+
+// check returns an error if the gas used has reached the limit.
+func (g *batchGas) check() error {
+	if used := atomic.LoadUint64(&g.used); used >= g.limit {
+		return &batchLimitError{fmt.Sprintf("batch gas usage %d reaches the limit %d", used, g.limit)}
+	}
+	return nil
+}
+
+// batchLimits tracks the limits of a batch request.
+type batchLimits struct {
+	start       time.Time
+	maxDuration time.Duration
+	gas         *batchGas
+}
+
+// newBatchLimits returns the limits of a batch request starting now, and the context of its calls.
+func newBatchLimits(ctx context.Context) (*batchLimits, context.Context) {
+	l := &batchLimits{start: time.Now(), maxDuration: BatchMaxDuration}
+	if BatchGasLimit > 0 {
+		l.gas = &batchGas{limit: BatchGasLimit}
+		ctx = context.WithValue(ctx, batchGasKey{}, l.gas)
+	}
+	return l, ctx
+}
+
+// check returns an error if the batch request has exceeded its limits.
+func (l *batchLimits) check() error {
+	if l.maxDuration > 0 {
+		if elapsed := time.Since(l.start); elapsed > l.maxDuration {
+			return &batchLimitError{fmt.Sprintf("batch execution time exceeds the limit %v", l.maxDuration)}
+		}
+	}
+	if l.gas != nil {
+		return l.gas.check()
+	}
+	return nil
+}
+
+// batchScheduler limits the number of the batch calls executed at the same time and hands the
+// turns to the waiting connections in round-robin order.
+type batchScheduler struct {
+	mu      sync.Mutex
+	running int
+	queues  map[interface{}][]chan struct{} // waiting calls of each connection
+	order   []interface{}                   // connections with waiting calls in their turn order
+}
+
+// acquire waits for the turn of a batch call of the given connection. It returns false if the
+// context is canceled before the turn comes.
+func (s *batchScheduler) acquire(ctx context.Context, conn interface{}, limit int) bool {
+	s.mu.Lock()
+	if s.running < limit && len(s.order) == 0 {
+		s.running++
+		s.mu.Unlock()
+		return true
+	}
+	turn := make(chan struct{})
+	if len(s.queues[conn]) == 0 {
+		s.order = append(s.order, conn)
+	}
+	s.queues[conn] = append(s.queues[conn], turn)
+	s.mu.Unlock()
+
+	select {
+	case <-turn:
+		return true
+	case <-ctx.Done():
+		s.mu.Lock()
+		defer s.mu.Unlock()
+		select {
+		case <-turn:
+			// The turn came while canceling; hand it to the next call.
+			s.next()
+		default:
+			s.remove(conn, turn)
+		}
+		return false
+	}
+}
+
+// release ends a batch call and hands its turn to the next waiting call.
+func (s *batchScheduler) release() {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.next()
+}
+
+// next hands the turn of a finished call to the first waiting call of the next connection.
+func (s *batchScheduler) next() {
+	if len(s.order) == 0 {
+		s.running--
+		return
+	}
+	conn := s.order[0]
+	s.order = s.order[1:]
+	queue := s.queues[conn]
+	close(queue[0])
+	if len(queue) > 1 {
+		s.queues[conn] = queue[1:]
+		s.order = append(s.order, conn)
+	} else {
+		delete(s.queues, conn)
+	}
+}
+
+func (s *batchScheduler) remove(conn interface{}, turn chan struct{}) {
+	queue := s.queues[conn]
+	for i, t := range queue {
+		if t == turn {
+			queue = append(queue[:i:i], queue[i+1:]...)
+			break
+		}
+	}
+	if len(queue) > 0 {
+		s.queues[conn] = queue
+		return
+	}
+	delete(s.queues, conn)
+	for i, c := range s.order {
+		if c == conn {
+			s.order = append(s.order[:i:i], s.order[i+1:]...)
+			break
+		}
+	}
+}
@@ -0,0 +1,195 @@
+// Copyright 2022 The klaytn Authors
+// This file is part of the klaytn library.
+//
+// The klaytn library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The klaytn library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the klaytn library. If not, see <http://www.gnu.org/licenses/>.
+
+package rpc
+
+import (
+	"context"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type batchGasService struct{}
+
+func (s *batchGasService) Use(ctx context.Context, gas uint64) uint64 {
+	ConsumeBatchGas(ctx, gas)
+	return gas
+}
+
+// UseEach uses the gas for each of n calls, and stops once the gas of the batch is used up.
+func (s *batchGasService) UseEach(ctx context.Context, gas uint64, n int) (int, error) {
+	for i := 0; i < n; i++ {
+		if err := CheckBatchGas(ctx); err != nil {
+			return i, err
+		}
+		ConsumeBatchGas(ctx, gas)
+	}
+	return n, nil
+}
+
+func TestBatchRequestLimit(t *testing.T) {
+	oldLimit := BatchRequestLimit
+	BatchRequestLimit = 2
+	defer func() { BatchRequestLimit = oldLimit }()
+
+	server := newTestServer("service", new(Service))
+	defer server.Stop()
+	httpsrv := httptest.NewServer(server)
+	defer httpsrv.Close()
+
+	post := func(body string) string {
+		resp, err := http.Post(httpsrv.URL, "application/json", strings.NewReader(body))
+		require.NoError(t, err)
+		defer resp.Body.Close()
+		data, err := ioutil.ReadAll(resp.Body)
+		require.NoError(t, err)
+		return string(data)
+	}
+	call := `{"jsonrpc":"2.0","id":1,"method":"service_noArgsRets"}`
+	assert.NotContains(t, post("["+call+","+call+"]"), "batch too large")
+	assert.Contains(t, post("["+call+","+call+","+call+"]"), "batch too large: 3 calls, limit 2")
+}
+
+func TestBatchMaxDuration(t *testing.T) {
+	oldDuration := BatchMaxDuration
+	BatchMaxDuration = 20 * time.Millisecond
+	defer func() { BatchMaxDuration = oldDuration }()
+
+	server := newTestServer("service", new(Service))
+	defer server.Stop()
+	client := DialInProc(server)
+	defer client.Close()
+
+	batch := []BatchElem{
+		{Method: "service_sleep", Args: []interface{}{50 * time.Millisecond}, Result: new(interface{})},
+		{Method: "service_rets", Result: new(string)},
+	}
+	require.NoError(t, client.BatchCall(batch))
+	assert.NoError(t, batch[0].Error)
+	require.Error(t, batch[1].Error)
+	assert.Equal(t, -32005, batch[1].Error.(Error).ErrorCode())
+	assert.Contains(t, batch[1].Error.Error(), "batch execution time exceeds the limit")
+}
+
+func TestBatchGasLimit(t *testing.T) {
+	oldLimit := BatchGasLimit
+	BatchGasLimit = 100
+	defer func() { BatchGasLimit = oldLimit }()
+
+	server := newTestServer("gas", new(batchGasService))
+	defer server.Stop()
+	client := DialInProc(server)
+	defer client.Close()
+
+	batch := make([]BatchElem, 4)
+	for i := range batch {
+		batch[i] = BatchElem{Method: "gas_use", Args: []interface{}{60}, Result: new(uint64)}
+	}
+	require.NoError(t, client.BatchCall(batch))
+	assert.NoError(t, batch[0].Error)
+	assert.NoError(t, batch[1].Error)
+	for _, elem := range batch[2:] {
+		require.Error(t, elem.Error)
+		assert.Contains(t, elem.Error.Error(), "batch gas usage 120 reaches the limit 100")
+	}
+
+	// The gas is accounted per batch request.
+	var used uint64
+	require.NoError(t, client.Call(&used, "gas_use", 200))
+	assert.Equal(t, uint64(200), used)
+
+	// A call executing several calls stops in the middle once the gas of the batch is used up.
+	batch = []BatchElem{
+		{Method: "gas_useEach", Args: []interface{}{30, 10}, Result: new(int)},
+		{Method: "gas_use", Args: []interface{}{10}, Result: new(uint64)},
+	}
+	require.NoError(t, client.BatchCall(batch))
+	require.Error(t, batch[0].Error)
+	assert.Contains(t, batch[0].Error.Error(), "batch gas usage 120 reaches the limit 100")
+	require.Error(t, batch[1].Error)
+
+	// The gas is not checked outside of a batch request.
+	var executed int
+	require.NoError(t, client.Call(&executed, "gas_useEach", 30, 10))
+	assert.Equal(t, 10, executed)
+}
+
+func TestBatchScheduler_RoundRobin(t *testing.T) {
+	var (
+		s       = &batchScheduler{queues: make(map[interface{}][]chan struct{})}
+		connA   = new(int)
+		connB   = new(int)
+		mu      sync.Mutex
+		order   []string
+		wg      sync.WaitGroup
+		waiting = func(n int) func() bool {
+			return func() bool {
+				s.mu.Lock()
+				defer s.mu.Unlock()
+				count := 0
+				for _, queue := range s.queues {
+					count += len(queue)
+				}
+				return count == n
+			}
+		}
+	)
+	// The only slot is taken by a call of connection A.
+	require.True(t, s.acquire(context.Background(), connA, 1))
+
+	// Connection A queues two calls before connection B queues one.
+	for i, call := range []struct {
+		conn interface{}
+		name string
+	}{{connA, "A1"}, {connA, "A2"}, {connB, "B1"}} {
+		wg.Add(1)
+		go func(conn interface{}, name string) {
+			defer wg.Done()
+			require.True(t, s.acquire(context.Background(), conn, 1))
+			mu.Lock()
+			order = append(order, name)
+			mu.Unlock()
+			s.release()
+		}(call.conn, call.name)
+		require.Eventually(t, waiting(i+1), time.Second, time.Millisecond)
+	}
+
+	// The turns are interleaved between the connections.
+	s.release()
+	wg.Wait()
+	assert.Equal(t, []string{"A1", "B1", "A2"}, order)
+	assert.Equal(t, 0, s.running)
+
+	// A canceled call leaves the queue.
+	require.True(t, s.acquire(context.Background(), connA, 1))
+	ctx, cancel := context.WithCancel(context.Background())
+	done := make(chan bool)
+	go func() { done <- s.acquire(ctx, connB, 1) }()
+	require.Eventually(t, waiting(1), time.Second, time.Millisecond)
+	cancel()
+	assert.False(t, <-done)
+	assert.Empty(t, s.order)
+	s.release()
+	assert.Equal(t, 0, s.running)
+}
@@ -682,3 +682,38 @@ func TestClientCloseUnsubscribeRace(t *testing.T) {
 		}
 	}
 }
+
+type dataError struct{}
+
+func (e *dataError) Error() string          { return "execution reverted" }
+func (e *dataError) ErrorCode() int         { return 3 }
+func (e *dataError) ErrorData() interface{} { return "0x1234" }
+
+type dataErrorService struct{}
+
+func (s *dataErrorService) Fail() error {
+	return &dataError{}
+}
+
+func TestClientErrorData(t *testing.T) {
+	server := newTestServer("service", new(dataErrorService))
+	defer server.Stop()
+	client := DialInProc(server)
+	defer client.Close()
+
+	var resp interface{}
+	err := client.Call(&resp, "service_fail")
+	if err == nil {
+		t.Fatal("expected an error")
+	}
+	// The code and the data of the error are returned to the client.
+	if ec, ok := err.(Error); !ok || ec.ErrorCode() != 3 {
+		t.Errorf("wrong error code %v", err)
+	}
+	if de, ok := err.(DataError); !ok || de.ErrorData() != "0x1234" {
+		t.Errorf("wrong error data %v", err)
+	}
+	if err.Error() != "execution reverted" {
+		t.Errorf("wrong error message %q", err.Error())
+	}
+}
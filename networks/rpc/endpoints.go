@@ -35,7 +35,7 @@ func StartHTTPEndpoint(endpoint string, apis []API, modules []string, cors []str
 	handler := NewServer()
 	for _, api := range apis {
 		if whitelist[api.Namespace] || (len(whitelist) == 0 && api.Public) {
-			if err := handler.RegisterName(api.Namespace, api.Service); err != nil {
+			if err := handler.RegisterAPI(api); err != nil {
 				return nil, nil, err
 			}
 			logger.Debug("HTTP registered", "namespace", api.Namespace)
@@ -64,7 +64,7 @@ func StartFastHTTPEndpoint(endpoint string, apis []API, modules []string, cors [
 	handler := NewServer()
 	for _, api := range apis {
 		if whitelist[api.Namespace] || (len(whitelist) == 0 && api.Public) {
-			if err := handler.RegisterName(api.Namespace, api.Service); err != nil {
+			if err := handler.RegisterAPI(api); err != nil {
 				return nil, nil, err
 			}
 			logger.Debug("FastHTTP registered", "namespace", api.Namespace)
@@ -93,7 +93,7 @@ func StartWSEndpoint(endpoint string, apis []API, modules []string, wsOrigins []
 	handler := NewServer()
 	for _, api := range apis {
 		if exposeAll || whitelist[api.Namespace] || (len(whitelist) == 0 && api.Public) {
-			if err := handler.RegisterName(api.Namespace, api.Service); err != nil {
+			if err := handler.RegisterAPI(api); err != nil {
 				return nil, nil, err
 			}
 			logger.Debug("WebSocket registered", "service", api.Service, "namespace", api.Namespace)
@@ -121,7 +121,7 @@ func StartFastWSEndpoint(endpoint string, apis []API, modules []string, wsOrigin
 	handler := NewServer()
 	for _, api := range apis {
 		if exposeAll || whitelist[api.Namespace] || (len(whitelist) == 0 && api.Public) {
-			if err := handler.RegisterName(api.Namespace, api.Service); err != nil {
+			if err := handler.RegisterAPI(api); err != nil {
 				return nil, nil, err
 			}
 			logger.Debug("FastWebSocket registered", "service", api.Service, "namespace", api.Namespace)
@@ -144,7 +144,7 @@ func StartIPCEndpoint(ipcEndpoint string, apis []API) (net.Listener, *Server, er
 	// Register all the APIs exposed by the services.
 	handler := NewServer()
 	for _, api := range apis {
-		if err := handler.RegisterName(api.Namespace, api.Service); err != nil {
+		if err := handler.RegisterAPI(api); err != nil {
 			return nil, nil, err
 		}
 		logger.Debug("IPC registered", "namespace", api.Namespace)
@@ -28,6 +28,14 @@ func (e *methodNotFoundError) Error() string {
 	return fmt.Sprintf("the method %s does not exist/is not available", e.method)
 }
 
+type deprecatedMethodError struct{ method, replacement string }
+
+func (e *deprecatedMethodError) ErrorCode() int { return -32601 }
+
+func (e *deprecatedMethodError) Error() string {
+	return fmt.Sprintf("the method %s is deprecated and disabled, use %s instead", e.method, e.replacement)
+}
+
 type subscriptionNotFoundError struct{ namespace, subscription string }
 
 func (e *subscriptionNotFoundError) ErrorCode() int { return -32601 }
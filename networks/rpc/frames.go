@@ -0,0 +1,208 @@
+// Copyright 2022 The klaytn Authors
+// This file is part of the klaytn library.
+//
+// The klaytn library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The klaytn library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the klaytn library. If not, see <http://www.gnu.org/licenses/>.
+
+package rpc
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/binary"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"time"
+)
+
+// The frame encoding is a binary encoding of the JSON-RPC messages, which an IPC or a websocket
+// client negotiates with the server when it connects. A message is sent as a sequence of chunks.
+// Each chunk is a 4-byte big-endian header followed by the chunk data. The lower 31 bits of the
+// header are the length of the data, and the highest bit is set if more chunks of the message
+// follow. The messages are read without scanning the JSON text for their boundaries, and a large
+// result is written chunk by chunk while it is encoded, without knowing its total length in advance.
+const (
+	// framesMagic is sent by an IPC client to request the frame encoding, and echoed by the server to accept it.
+	// It starts with a zero byte, which never starts a JSON message.
+	framesMagic = "\x00klay-frames/1\n"
+
+	// wsFramesProtocol is the websocket subprotocol of the frame encoding.
+	// Each websocket message carries the chunks of a single JSON-RPC message.
+	wsFramesProtocol = "klay-frames"
+
+	frameChunkSize  = 64 * 1024
+	frameHeaderSize = 4
+	frameMoreFlag   = 1 << 31
+
+	// framesHandshakeTimeout is the time to wait for the server to accept the frame encoding.
+	framesHandshakeTimeout = 5 * time.Second
+)
+
+var errFramesRejected = errors.New("the server does not support the frame encoding")
+
+// frameWriter splits the written data into chunks. Close writes the last chunk of the message.
+type frameWriter struct {
+	w   io.Writer
+	buf []byte
+}
+
+func newFrameWriter(w io.Writer) *frameWriter {
+	return &frameWriter{w: w, buf: make([]byte, frameHeaderSize, frameHeaderSize+frameChunkSize)}
+}
+
+func (fw *frameWriter) Write(p []byte) (int, error) {
+	n := 0
+	for len(p) > 0 {
+		if len(fw.buf) == cap(fw.buf) {
+			if err := fw.flush(true); err != nil {
+				return n, err
+			}
+		}
+		c := copy(fw.buf[len(fw.buf):cap(fw.buf)], p)
+		fw.buf = fw.buf[:len(fw.buf)+c]
+		p = p[c:]
+		n += c
+	}
+	return n, nil
+}
+
+func (fw *frameWriter) flush(more bool) error {
+	header := uint32(len(fw.buf) - frameHeaderSize)
+	if more {
+		header |= frameMoreFlag
+	}
+	binary.BigEndian.PutUint32(fw.buf, header)
+	_, err := fw.w.Write(fw.buf)
+	fw.buf = fw.buf[:frameHeaderSize]
+	return err
+}
+
+func (fw *frameWriter) Close() error {
+	return fw.flush(false)
+}
+
+// readFrameMessage reads the chunks of a message and returns the concatenated data.
+// If limit is positive, a message longer than limit is rejected.
+func readFrameMessage(r io.Reader, limit int) ([]byte, error) {
+	var (
+		header [frameHeaderSize]byte
+		msg    []byte
+	)
+	for {
+		if _, err := io.ReadFull(r, header[:]); err != nil {
+			return nil, err
+		}
+		h := binary.BigEndian.Uint32(header[:])
+		size := int(h &^ frameMoreFlag)
+		if limit > 0 && len(msg)+size > limit {
+			return nil, fmt.Errorf("frame message too large (max %d bytes)", limit)
+		}
+		start := len(msg)
+		msg = append(msg, make([]byte, size)...)
+		if _, err := io.ReadFull(r, msg[start:]); err != nil {
+			return nil, err
+		}
+		if h&frameMoreFlag == 0 {
+			return msg, nil
+		}
+	}
+}
+
+// encodeFrames encodes v in JSON as a frame message written to w.
+func encodeFrames(w io.Writer, v interface{}) error {
+	fw := newFrameWriter(w)
+	if err := json.NewEncoder(fw).Encode(v); err != nil {
+		return err
+	}
+	return fw.Close()
+}
+
+// decodeFrames decodes a frame message read from r into v.
+func decodeFrames(r io.Reader, limit int, v interface{}) error {
+	msg, err := readFrameMessage(r, limit)
+	if err != nil {
+		return err
+	}
+	dec := json.NewDecoder(bytes.NewReader(msg))
+	dec.UseNumber()
+	return dec.Decode(v)
+}
+
+// newFramesCodec creates a codec reading and writing frame messages on the given connection.
+func newFramesCodec(conn Conn, r io.Reader, limit int) ServerCodec {
+	w := bufio.NewWriterSize(conn, frameHeaderSize+frameChunkSize)
+	encode := func(v interface{}) error {
+		if err := encodeFrames(w, v); err != nil {
+			return err
+		}
+		return w.Flush()
+	}
+	decode := func(v interface{}) error {
+		return decodeFrames(r, limit, v)
+	}
+	return NewFuncCodec(conn, encode, decode)
+}
+
+// peekedConn is a connection whose first bytes were peeked by a buffered reader.
+type peekedConn struct {
+	net.Conn
+	r *bufio.Reader
+}
+
+func (c *peekedConn) Read(p []byte) (int, error) {
+	return c.r.Read(p)
+}
+
+// newServerConnCodec creates the server codec of an IPC connection. If the client starts with
+// framesMagic, the frame encoding is accepted. Otherwise, the connection serves plain JSON.
+func newServerConnCodec(conn net.Conn) (ServerCodec, error) {
+	r := bufio.NewReader(conn)
+	first, err := r.Peek(1)
+	if err != nil {
+		return nil, err
+	}
+	if first[0] != framesMagic[0] {
+		return NewCodec(&peekedConn{conn, r}), nil
+	}
+	magic := make([]byte, len(framesMagic))
+	if _, err := io.ReadFull(r, magic); err != nil {
+		return nil, err
+	}
+	if string(magic) != framesMagic {
+		return nil, fmt.Errorf("invalid frame encoding request %q", magic)
+	}
+	if _, err := io.WriteString(conn, framesMagic); err != nil {
+		return nil, err
+	}
+	return newFramesCodec(conn, r, 0), nil
+}
+
+// newClientFramesCodec requests the frame encoding on a connection to the server,
+// and creates the client codec if the server accepts it.
+func newClientFramesCodec(conn net.Conn) (ServerCodec, error) {
+	conn.SetDeadline(time.Now().Add(framesHandshakeTimeout))
+	defer conn.SetDeadline(time.Time{})
+
+	if _, err := io.WriteString(conn, framesMagic); err != nil {
+		return nil, err
+	}
+	r := bufio.NewReader(conn)
+	ack := make([]byte, len(framesMagic))
+	if _, err := io.ReadFull(r, ack); err != nil || string(ack) != framesMagic {
+		return nil, errFramesRejected
+	}
+	return newFramesCodec(conn, r, 0), nil
+}
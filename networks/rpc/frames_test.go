@@ -0,0 +1,102 @@
+// Copyright 2022 The klaytn Authors
+// This file is part of the klaytn library.
+//
+// The klaytn library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The klaytn library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the klaytn library. If not, see <http://www.gnu.org/licenses/>.
+
+package rpc
+
+import (
+	"bytes"
+	"context"
+	"net"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestFrameEncoding(t *testing.T) {
+	var (
+		buf bytes.Buffer
+		msg = strings.Repeat("x", 3*frameChunkSize+10)
+		out string
+	)
+	require.NoError(t, encodeFrames(&buf, msg))
+	// The message is split into 4 chunks.
+	assert.Equal(t, len(msg)+len(`""`+"\n")+4*frameHeaderSize, buf.Len())
+
+	enc := buf.Bytes()
+	require.NoError(t, decodeFrames(bytes.NewReader(enc), 0, &out))
+	assert.Equal(t, msg, out)
+
+	assert.Error(t, decodeFrames(bytes.NewReader(enc), frameChunkSize, &out))
+	assert.Error(t, decodeFrames(bytes.NewReader(enc[:len(enc)-1]), 0, &out))
+}
+
+func TestIPCFrames(t *testing.T) {
+	server := newTestServer("service", new(Service))
+	defer server.Stop()
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	// A client requesting the frame encoding.
+	clientConn, serverConn := net.Pipe()
+	go server.serveConn(serverConn)
+	client, err := NewClient(ctx, func(context.Context) (ServerCodec, error) {
+		return newClientFramesCodec(clientConn)
+	})
+	require.NoError(t, err)
+	defer client.Close()
+
+	var result Result
+	arg := strings.Repeat("x", 2*frameChunkSize)
+	require.NoError(t, client.Call(&result, "service_echo", arg, 1))
+	assert.Equal(t, arg, result.String)
+
+	// A client using plain JSON on the same kind of connection.
+	clientConn, serverConn = net.Pipe()
+	go server.serveConn(serverConn)
+	client, err = NewClient(ctx, func(context.Context) (ServerCodec, error) {
+		return NewCodec(clientConn), nil
+	})
+	require.NoError(t, err)
+	defer client.Close()
+
+	require.NoError(t, client.Call(&result, "service_echo", "plain", 1))
+	assert.Equal(t, "plain", result.String)
+}
+
+func TestWebsocketFrames(t *testing.T) {
+	var (
+		srv     = newTestServer("service", new(Service))
+		httpsrv = httptest.NewServer(srv.WebsocketHandler([]string{"*"}))
+		wsAddr  = "ws:" + strings.TrimPrefix(httpsrv.URL, "http:")
+	)
+	defer srv.Stop()
+	defer httpsrv.Close()
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+	client, err := DialWebsocketFrames(ctx, wsAddr, "")
+	require.NoError(t, err)
+	defer client.Close()
+
+	var result Result
+	arg := strings.Repeat("x", 2*frameChunkSize)
+	require.NoError(t, client.Call(&result, "service_echo", arg, 1))
+	assert.Equal(t, arg, result.String)
+}
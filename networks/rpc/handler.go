@@ -116,6 +116,15 @@ func (h *handler) handleBatch(msgs []*jsonrpcMessage) {
 		return
 	}
 
+	if limit := BatchRequestLimit; limit > 0 && len(calls) > limit {
+		rpcErrorResponsesCounter.Inc(int64(len(calls)))
+		err := &invalidRequestError{fmt.Sprintf("batch too large: %d calls, limit %d", len(calls), limit)}
+		h.startCallProc(func(cp *callProc) {
+			h.conn.writeJSON(cp.ctx, errorMessage(err))
+		})
+		return
+	}
+
 	if atomic.LoadInt64(&pendingRequestCount) > pendingRequestLimit {
 		rpcErrorResponsesCounter.Inc(int64(len(calls)))
 		err := &invalidRequestError{"server requests exceed the limit"}
@@ -128,9 +137,12 @@ func (h *handler) handleBatch(msgs []*jsonrpcMessage) {
 
 	// Process calls on a goroutine because they may block indefinitely:
 	h.startCallProc(func(cp *callProc) {
+		var limits *batchLimits
+		limits, cp.ctx = newBatchLimits(cp.ctx)
+
 		answers := make([]*jsonrpcMessage, 0, len(msgs))
 		for _, msg := range calls {
-			if answer := h.handleCallMsg(cp, msg); answer != nil {
+			if answer := h.handleBatchCallMsg(cp, msg, limits); answer != nil {
 				answers = append(answers, answer)
 			}
 		}
@@ -144,6 +156,25 @@ func (h *handler) handleBatch(msgs []*jsonrpcMessage) {
 	})
 }
 
+// handleBatchCallMsg executes a call of a batch request within the limits of the batch,
+// waiting for its turn if the batch calls are scheduled.
+func (h *handler) handleBatchCallMsg(cp *callProc, msg *jsonrpcMessage, limits *batchLimits) *jsonrpcMessage {
+	if limit := BatchConcurrency; limit > 0 {
+		if !batchCalls.acquire(cp.ctx, h, limit) {
+			return msg.errorResponse(&shutdownError{})
+		}
+		defer batchCalls.release()
+	}
+	if err := limits.check(); err != nil {
+		rpcErrorResponsesCounter.Inc(1)
+		if msg.isCall() {
+			return msg.errorResponse(err)
+		}
+		return nil
+	}
+	return h.handleCallMsg(cp, msg)
+}
+
 // handleMsg handles a single message.
 func (h *handler) handleMsg(msg *jsonrpcMessage) {
 	rpcTotalRequestsCounter.Inc(1)
@@ -228,6 +259,7 @@ func (h *handler) addSubscriptions(nn []*Notifier) {
 			h.serverSubs[sub.ID] = sub
 		}
 	}
+	h.reportSubscriptionCount()
 }
 
 // cancelServerSubscriptions removes all subscriptions and closes their error channels.
@@ -240,6 +272,20 @@ func (h *handler) cancelServerSubscriptions(err error) {
 		close(s.err)
 		delete(h.serverSubs, id)
 	}
+	h.reportSubscriptionCount()
+}
+
+// subscriptionCounter is implemented by the connections tracking the number of their subscriptions.
+type subscriptionCounter interface {
+	setSubscriptionCount(n int)
+}
+
+// reportSubscriptionCount reports the number of the subscriptions to the connection.
+// It must be called with h.subLock held.
+func (h *handler) reportSubscriptionCount() {
+	if c, ok := h.conn.(subscriptionCounter); ok {
+		c.setSubscriptionCount(len(h.serverSubs))
+	}
 }
 
 // startCallProc runs fn in a new goroutine and starts tracking it in the h.calls wait group.
@@ -326,11 +372,17 @@ func (h *handler) handleCallMsg(ctx *callProc, msg *jsonrpcMessage) *jsonrpcMess
 	start := time.Now()
 	switch {
 	case msg.isNotification():
-		h.handleCall(ctx, msg)
+		resp := h.handleCall(ctx, msg)
+		if auditLog != nil {
+			auditLog.record(ctx.ctx, h.conn, msg, resp, start)
+		}
 		logger.Trace("Served "+msg.Method, "duration", time.Since(start))
 		return nil
 	case msg.isCall():
 		resp := h.handleCall(ctx, msg)
+		if auditLog != nil {
+			auditLog.record(ctx.ctx, h.conn, msg, resp, start)
+		}
 		if resp.Error != nil {
 			logger.Debug("Served "+msg.Method, "reqid", idForLog{msg.ID}, "duration", time.Since(start), "err", resp.Error.Message)
 		} else {
@@ -362,12 +414,34 @@ func (h *handler) handleCall(cp *callProc, msg *jsonrpcMessage) *jsonrpcMessage
 		rpcErrorResponsesCounter.Inc(1)
 		return msg.errorResponse(&methodNotFoundError{method: msg.Method})
 	}
+	replacement, deprecated := h.reg.deprecation(msg.Method)
+	if deprecated {
+		rpcDeprecatedRequestsCounter.Inc(1)
+		if DisableDeprecatedMethods {
+			rpcErrorResponsesCounter.Inc(1)
+			return msg.errorResponse(&deprecatedMethodError{method: msg.Method, replacement: replacement})
+		}
+	}
 	args, err := parsePositionalArguments(msg.Params, callb.argTypes)
 	if err != nil {
 		rpcErrorResponsesCounter.Inc(1)
 		return msg.errorResponse(&invalidParamsError{err.Error()})
 	}
-	return h.runMethod(cp.ctx, msg, callb, args)
+	if err := checkMethodRateLimit(cp.ctx, h.conn, msg.Method); err != nil {
+		rpcErrorResponsesCounter.Inc(1)
+		return msg.errorResponse(err)
+	}
+	ctx := cp.ctx
+	if timeout, ok := methodTimeout(msg.Method); ok {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, timeout)
+		defer cancel()
+	}
+	resp := h.runMethod(ctx, msg, callb, args)
+	if deprecated && DeprecationNotice {
+		resp.Deprecated = &deprecationNotice{Replacement: replacement}
+	}
+	return resp
 }
 
 // handleSubscribe processes *_subscribe method calls.
@@ -440,6 +514,7 @@ func (h *handler) unsubscribe(ctx context.Context, id ID) (bool, error) {
 	}
 	close(s.err)
 	delete(h.serverSubs, id)
+	h.reportSubscriptionCount()
 	return true, nil
 }
 
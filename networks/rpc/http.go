@@ -348,6 +348,9 @@ func (s *Server) ServeHTTP(w http.ResponseWriter, r *http.Request) {
 	if origin := r.Header.Get("Origin"); origin != "" {
 		ctx = context.WithValue(ctx, "Origin", origin)
 	}
+	if apiKey := r.Header.Get(APIKeyHeader); apiKey != "" {
+		ctx = context.WithValue(ctx, APIKeyHeader, apiKey)
+	}
 
 	w.Header().Set("content-type", contentType)
 	codec := newHTTPServerConn(r, w)
@@ -378,6 +381,9 @@ func (srv *Server) HandleFastHTTP(requestCtx *fasthttp.RequestCtx) {
 	ctx = context.WithValue(ctx, "remote", requestCtx.RemoteAddr().String())
 	ctx = context.WithValue(ctx, "scheme", string(requestCtx.URI().Scheme()))
 	ctx = context.WithValue(ctx, "local", requestCtx.LocalAddr().String())
+	if apiKey := r.Header.Peek(APIKeyHeader); len(apiKey) > 0 {
+		ctx = context.WithValue(ctx, APIKeyHeader, string(apiKey))
+	}
 
 	reader := bufio.NewReaderSize(bytes.NewReader(r.Body()), common.MaxRequestContentLength)
 	codec := NewCodec(&httpReadWriteNopCloser{reader, w.BodyWriter()})
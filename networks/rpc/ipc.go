@@ -38,10 +38,21 @@ func (s *Server) ServeListener(l net.Listener) error {
 			return err
 		}
 		logger.Trace("Accepted connection", "addr", conn.RemoteAddr())
-		go s.ServeCodec(NewCodec(conn), 0)
+		go s.serveConn(conn)
 	}
 }
 
+// serveConn serves JSON-RPC on the connection, in the frame encoding if the client requests it.
+func (s *Server) serveConn(conn net.Conn) {
+	codec, err := newServerConnCodec(conn)
+	if err != nil {
+		logger.Debug("Failed to start serving a connection", "addr", conn.RemoteAddr(), "err", err)
+		conn.Close()
+		return
+	}
+	s.ServeCodec(codec, 0)
+}
+
 // DialIPC create a new IPC client that connects to the given endpoint. On Unix it assumes
 // the endpoint is the full path to a unix socket, and Windows the endpoint is an
 // identifier for a named pipe.
@@ -57,3 +68,21 @@ func DialIPC(ctx context.Context, endpoint string) (*Client, error) {
 		return NewCodec(conn), err
 	})
 }
+
+// DialIPCFrames is like DialIPC, but the messages are exchanged in the frame encoding,
+// which a high-throughput client can use to cut the cost of reading large results.
+// It fails if the server does not support the frame encoding.
+func DialIPCFrames(ctx context.Context, endpoint string) (*Client, error) {
+	return NewClient(ctx, func(ctx context.Context) (ServerCodec, error) {
+		conn, err := newIPCConnection(ctx, endpoint)
+		if err != nil {
+			return nil, err
+		}
+		codec, err := newClientFramesCodec(conn)
+		if err != nil {
+			conn.Close()
+			return nil, err
+		}
+		return codec, nil
+	})
+}
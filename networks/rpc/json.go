@@ -56,6 +56,14 @@ type jsonrpcMessage struct {
 	Params  json.RawMessage `json:"params,omitempty"`
 	Error   *jsonError      `json:"error,omitempty"`
 	Result  json.RawMessage `json:"result,omitempty"`
+
+	// Deprecated is the notice added to the responses of the deprecated methods if DeprecationNotice is set.
+	Deprecated *deprecationNotice `json:"deprecated,omitempty"`
+}
+
+// deprecationNotice marks the response of a deprecated method with the hint of its replacement.
+type deprecationNotice struct {
+	Replacement string `json:"replacement"`
 }
 
 type jsonSuccessResponse struct {
@@ -139,6 +147,10 @@ func errorMessage(err error) *jsonrpcMessage {
 	if ok {
 		msg.Error.Code = ec.ErrorCode()
 	}
+	de, ok := err.(DataError)
+	if ok {
+		msg.Error.Data = de.ErrorData()
+	}
 	return msg
 }
 
@@ -159,6 +171,10 @@ func (err *jsonError) ErrorCode() int {
 	return err.Code
 }
 
+func (err *jsonError) ErrorData() interface{} {
+	return err.Data
+}
+
 // Conn is a subset of the methods of net.Conn which are sufficient for ServerCodec.
 type Conn interface {
 	io.ReadWriteCloser
@@ -8,7 +8,15 @@ var (
 	rpcErrorResponsesCounter   = metrics.NewRegisteredCounter("rpc/counts/errors", nil)
 	rpcPendingRequestsCount    = metrics.NewRegisteredCounter("rpc/counts/pending", nil)
 
+	rpcDeprecatedRequestsCounter = metrics.NewRegisteredCounter("rpc/counts/deprecated", nil)
+	rpcRateLimitedCounter        = metrics.NewRegisteredCounter("rpc/counts/ratelimited", nil)
+
 	wsSubscriptionReqCounter   = metrics.NewRegisteredCounter("ws/counts/subscription/request", nil)
 	wsUnsubscriptionReqCounter = metrics.NewRegisteredCounter("ws/counts/unsubscription/request", nil)
 	wsConnCounter              = metrics.NewRegisteredCounter("ws/counts/connections/total", nil)
+	wsEvictedConnCounter       = metrics.NewRegisteredCounter("ws/counts/connections/evicted", nil)
+	wsSendLatencyTimer         = metrics.NewRegisteredTimer("ws/latency/send", nil)
+
+	wsDroppedNotificationCounter = metrics.NewRegisteredCounter("ws/counts/notifications/dropped", nil)
+	wsNotificationLagTimer       = metrics.NewRegisteredTimer("ws/latency/notification", nil)
 )
@@ -0,0 +1,273 @@
+// Copyright 2022 The klaytn Authors
+// This file is part of the klaytn library.
+//
+// The klaytn library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The klaytn library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the klaytn library. If not, see <http://www.gnu.org/licenses/>.
+
+package rpc
+
+import (
+	"context"
+	"fmt"
+	"net"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+const (
+	// RateLimitByMethod shares the rate limit of a method between all clients.
+	RateLimitByMethod = "method"
+	// RateLimitByIP applies the rate limit of a method to each client IP.
+	RateLimitByIP = "ip"
+	// RateLimitByAPIKey applies the rate limit of a method to each API key of RateLimitAPIKeys given by the
+	// APIKeyHeader header of the HTTP requests. The calls with the other keys or without a key share a limit.
+	RateLimitByAPIKey = "apikey"
+
+	// APIKeyHeader is the HTTP header identifying the client for RateLimitByAPIKey.
+	APIKeyHeader = "X-API-Key"
+
+	// rateLimitCleanupInterval is the interval to remove the idle token buckets of the clients.
+	rateLimitCleanupInterval = time.Minute
+)
+
+// MethodRateLimit is the number of calls per second allowed for a method, and the
+// number of calls allowed in a burst.
+type MethodRateLimit struct {
+	Rate  float64
+	Burst int
+}
+
+var (
+	// MethodRateLimits is the rate limits of the methods keyed by the method name, like "debug_traceBlockByNumber",
+	// or by the namespace with a wildcard, like "debug_*". The methods not in it are not limited.
+	MethodRateLimits map[string]MethodRateLimit
+
+	// RateLimitKey determines to whom a rate limit is applied: RateLimitByMethod, RateLimitByIP or RateLimitByAPIKey.
+	RateLimitKey = RateLimitByMethod
+
+	// RateLimitAPIKeys is the API keys having their own rate limits with RateLimitByAPIKey.
+	// The keys are chosen by the clients, so the unknown keys are not given their own limits.
+	RateLimitAPIKeys map[string]bool
+
+	// maxRateLimitBuckets is the maximum number of the token buckets of the clients. The clients
+	// seen after it is reached share the limit of the method until the idle buckets are removed.
+	maxRateLimitBuckets = 10000
+
+	// MethodTimeouts is the execution time budgets of the methods keyed like MethodRateLimits.
+	// The context of a call is canceled when its budget is spent.
+	MethodTimeouts map[string]time.Duration
+
+	methodLimiter = &rateLimiter{buckets: make(map[string]*tokenBucket)}
+)
+
+// rateLimitError is returned for the calls exceeding the rate limit of their method.
+type rateLimitError struct{ method string }
+
+func (e *rateLimitError) ErrorCode() int { return -32005 }
+
+func (e *rateLimitError) Error() string {
+	return fmt.Sprintf("rate limit of the method %s exceeded", e.method)
+}
+
+// ValidateRateLimitKey returns an error if the key is not one of the RateLimitBy constants.
+func ValidateRateLimitKey(key string) error {
+	switch key {
+	case RateLimitByMethod, RateLimitByIP, RateLimitByAPIKey:
+		return nil
+	}
+	return fmt.Errorf("unknown rate limit key %q, want %q, %q or %q", key, RateLimitByMethod, RateLimitByIP, RateLimitByAPIKey)
+}
+
+// methodRateLimit returns the rate limit of the method. The limit of a namespace
+// applies to each method of it separately.
+func methodRateLimit(method string) (MethodRateLimit, bool) {
+	if limit, ok := MethodRateLimits[method]; ok {
+		return limit, true
+	}
+	if i := strings.Index(method, serviceMethodSeparator); i >= 0 {
+		limit, ok := MethodRateLimits[method[:i+len(serviceMethodSeparator)]+"*"]
+		return limit, ok
+	}
+	return MethodRateLimit{}, false
+}
+
+// methodTimeout returns the execution time budget of the method.
+func methodTimeout(method string) (time.Duration, bool) {
+	if timeout, ok := MethodTimeouts[method]; ok {
+		return timeout, true
+	}
+	if i := strings.Index(method, serviceMethodSeparator); i >= 0 {
+		timeout, ok := MethodTimeouts[method[:i+len(serviceMethodSeparator)]+"*"]
+		return timeout, ok
+	}
+	return 0, false
+}
+
+// checkMethodRateLimit returns an error if the call of the method exceeds its rate limit.
+func checkMethodRateLimit(ctx context.Context, conn jsonWriter, method string) error {
+	limit, ok := methodRateLimit(method)
+	if !ok {
+		return nil
+	}
+	var client string
+	switch RateLimitKey {
+	case RateLimitByIP:
+		client = clientIP(ctx, conn)
+	case RateLimitByAPIKey:
+		if apiKey, _ := ctx.Value(APIKeyHeader).(string); RateLimitAPIKeys[apiKey] {
+			client = apiKey
+		}
+	}
+	if !methodLimiter.allow(method, client, limit, time.Now()) {
+		rpcRateLimitedCounter.Inc(1)
+		return &rateLimitError{method}
+	}
+	return nil
+}
+
+// clientIP returns the IP address of the client of the connection.
+func clientIP(ctx context.Context, conn jsonWriter) string {
+	remote := conn.remoteAddr()
+	if remote == "" {
+		remote, _ = ctx.Value("remote").(string)
+	}
+	if host, _, err := net.SplitHostPort(remote); err == nil {
+		return host
+	}
+	return remote
+}
+
+// tokenBucket holds the calls allowed for a rate limit key.
+type tokenBucket struct {
+	tokens float64
+	last   time.Time
+}
+
+// rateLimiter keeps the token buckets of the rate limit keys.
+type rateLimiter struct {
+	mu          sync.Mutex
+	buckets     map[string]*tokenBucket
+	lastCleanup time.Time
+}
+
+// allow takes a token from the bucket of the method for the client, refilled at the rate of the limit
+// since it was last used. The calls without a client, or from a new client while the number of the
+// buckets is at maxRateLimitBuckets, take a token from the bucket shared by the method.
+func (l *rateLimiter) allow(method, client string, limit MethodRateLimit, now time.Time) bool {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	if now.Sub(l.lastCleanup) > rateLimitCleanupInterval {
+		l.removeIdle(now)
+	}
+	key := method
+	if client != "" {
+		key += "|" + client
+	}
+	if _, ok := l.buckets[key]; !ok && client != "" && len(l.buckets) >= maxRateLimitBuckets {
+		l.removeIdle(now)
+		if len(l.buckets) >= maxRateLimitBuckets {
+			key = method
+		}
+	}
+
+	burst := float64(limit.Burst)
+	if burst < 1 {
+		burst = 1
+	}
+	b, ok := l.buckets[key]
+	if !ok {
+		b = &tokenBucket{tokens: burst, last: now}
+		l.buckets[key] = b
+	}
+	b.tokens += now.Sub(b.last).Seconds() * limit.Rate
+	if b.tokens > burst {
+		b.tokens = burst
+	}
+	b.last = now
+
+	if b.tokens < 1 {
+		return false
+	}
+	b.tokens--
+	return true
+}
+
+// removeIdle removes the buckets of the clients not seen for rateLimitCleanupInterval. They would be
+// full again, so they can be dropped. The caller should hold mu.
+func (l *rateLimiter) removeIdle(now time.Time) {
+	for k, bucket := range l.buckets {
+		if now.Sub(bucket.last) > rateLimitCleanupInterval {
+			delete(l.buckets, k)
+		}
+	}
+	l.lastCleanup = now
+}
+
+// ParseMethodRateLimits parses the rate limits given as "method=rate" or "method=rate:burst".
+// The burst is the rate rounded up if omitted.
+func ParseMethodRateLimits(specs []string) (map[string]MethodRateLimit, error) {
+	limits := make(map[string]MethodRateLimit, len(specs))
+	for _, spec := range specs {
+		method, value, err := splitMethodSpec(spec)
+		if err != nil {
+			return nil, err
+		}
+		rateStr, burstStr := value, ""
+		if i := strings.Index(value, ":"); i >= 0 {
+			rateStr, burstStr = value[:i], value[i+1:]
+		}
+		rate, err := strconv.ParseFloat(rateStr, 64)
+		if err != nil || rate <= 0 {
+			return nil, fmt.Errorf("invalid rate limit %q: the rate must be a positive number", spec)
+		}
+		burst := int(rate)
+		if float64(burst) < rate {
+			burst++
+		}
+		if burstStr != "" {
+			if burst, err = strconv.Atoi(burstStr); err != nil || burst <= 0 {
+				return nil, fmt.Errorf("invalid rate limit %q: the burst must be a positive integer", spec)
+			}
+		}
+		limits[method] = MethodRateLimit{Rate: rate, Burst: burst}
+	}
+	return limits, nil
+}
+
+// ParseMethodTimeouts parses the execution time budgets given as "method=duration", like "debug_*=30s".
+func ParseMethodTimeouts(specs []string) (map[string]time.Duration, error) {
+	timeouts := make(map[string]time.Duration, len(specs))
+	for _, spec := range specs {
+		method, value, err := splitMethodSpec(spec)
+		if err != nil {
+			return nil, err
+		}
+		timeout, err := time.ParseDuration(value)
+		if err != nil || timeout <= 0 {
+			return nil, fmt.Errorf("invalid method timeout %q: the timeout must be a positive duration", spec)
+		}
+		timeouts[method] = timeout
+	}
+	return timeouts, nil
+}
+
+func splitMethodSpec(spec string) (string, string, error) {
+	i := strings.Index(spec, "=")
+	if i <= 0 || i == len(spec)-1 {
+		return "", "", fmt.Errorf("invalid method configuration %q: want method=value", spec)
+	}
+	return strings.TrimSpace(spec[:i]), strings.TrimSpace(spec[i+1:]), nil
+}
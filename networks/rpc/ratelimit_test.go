@@ -0,0 +1,196 @@
+// Copyright 2022 The klaytn Authors
+// This file is part of the klaytn library.
+//
+// The klaytn library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The klaytn library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the klaytn library. If not, see <http://www.gnu.org/licenses/>.
+
+package rpc
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func setMethodRateLimits(limits map[string]MethodRateLimit, key string) func() {
+	oldLimits, oldKey, oldLimiter := MethodRateLimits, RateLimitKey, methodLimiter
+	MethodRateLimits, RateLimitKey = limits, key
+	methodLimiter = &rateLimiter{buckets: make(map[string]*tokenBucket)}
+	return func() { MethodRateLimits, RateLimitKey, methodLimiter = oldLimits, oldKey, oldLimiter }
+}
+
+func setRateLimitAPIKeys(keys ...string) func() {
+	oldKeys := RateLimitAPIKeys
+	RateLimitAPIKeys = make(map[string]bool, len(keys))
+	for _, key := range keys {
+		RateLimitAPIKeys[key] = true
+	}
+	return func() { RateLimitAPIKeys = oldKeys }
+}
+
+func TestMethodRateLimit(t *testing.T) {
+	defer setMethodRateLimits(map[string]MethodRateLimit{"service_rets": {Rate: 0.001, Burst: 2}}, RateLimitByMethod)()
+
+	server := newTestServer("service", new(Service))
+	defer server.Stop()
+	client := DialInProc(server)
+	defer client.Close()
+
+	var result string
+	require.NoError(t, client.Call(&result, "service_rets"))
+	require.NoError(t, client.Call(&result, "service_rets"))
+	err := client.Call(&result, "service_rets")
+	require.Error(t, err)
+	assert.Equal(t, -32005, err.(Error).ErrorCode())
+
+	// The methods without a limit are not affected.
+	assert.NoError(t, client.Call(&result, "service_noArgsRets"))
+}
+
+func TestMethodRateLimit_Namespace(t *testing.T) {
+	defer setMethodRateLimits(map[string]MethodRateLimit{"service_*": {Rate: 0.001, Burst: 1}}, RateLimitByMethod)()
+
+	server := newTestServer("service", new(Service))
+	defer server.Stop()
+	client := DialInProc(server)
+	defer client.Close()
+
+	var result string
+	require.NoError(t, client.Call(&result, "service_rets"))
+	assert.Error(t, client.Call(&result, "service_rets"))
+	// The methods of a namespace have their own buckets.
+	assert.NoError(t, client.Call(&result, "service_noArgsRets"))
+}
+
+func TestMethodRateLimit_APIKey(t *testing.T) {
+	defer setMethodRateLimits(map[string]MethodRateLimit{"service_rets": {Rate: 0.001, Burst: 1}}, RateLimitByAPIKey)()
+	defer setRateLimitAPIKeys("a", "b")()
+
+	server := newTestServer("service", new(Service))
+	defer server.Stop()
+	httpsrv := httptest.NewServer(server)
+	defer httpsrv.Close()
+
+	post := func(apiKey string) int {
+		req, err := http.NewRequest(http.MethodPost, httpsrv.URL,
+			strings.NewReader(`{"jsonrpc":"2.0","id":1,"method":"service_rets"}`))
+		require.NoError(t, err)
+		req.Header.Set("Content-Type", "application/json")
+		req.Header.Set(APIKeyHeader, apiKey)
+		resp, err := http.DefaultClient.Do(req)
+		require.NoError(t, err)
+		defer resp.Body.Close()
+		var msg jsonrpcMessage
+		require.NoError(t, json.NewDecoder(resp.Body).Decode(&msg))
+		if msg.Error != nil {
+			return msg.Error.Code
+		}
+		return 0
+	}
+	assert.Equal(t, 0, post("a"))
+	assert.Equal(t, -32005, post("a"))
+	assert.Equal(t, 0, post("b"))
+
+	// The unknown keys share a limit, so it cannot be bypassed by changing the key.
+	assert.Equal(t, 0, post("unknown"))
+	assert.Equal(t, -32005, post("rotated"))
+	assert.Equal(t, -32005, post(""))
+	assert.NotContains(t, methodLimiter.buckets, "service_rets|unknown")
+}
+
+func TestRateLimiter_Refill(t *testing.T) {
+	l := &rateLimiter{buckets: make(map[string]*tokenBucket)}
+	limit := MethodRateLimit{Rate: 10, Burst: 1}
+	now := time.Now()
+
+	assert.True(t, l.allow("m", "", limit, now))
+	assert.False(t, l.allow("m", "", limit, now))
+	assert.True(t, l.allow("m", "", limit, now.Add(100*time.Millisecond)))
+
+	// The idle buckets are removed.
+	assert.True(t, l.allow("other", "", limit, now.Add(2*rateLimitCleanupInterval)))
+	assert.NotContains(t, l.buckets, "m")
+}
+
+func TestRateLimiter_MaxBuckets(t *testing.T) {
+	oldMax := maxRateLimitBuckets
+	maxRateLimitBuckets = 2
+	defer func() { maxRateLimitBuckets = oldMax }()
+
+	l := &rateLimiter{buckets: make(map[string]*tokenBucket), lastCleanup: time.Now()}
+	limit := MethodRateLimit{Rate: 0.001, Burst: 1}
+	now := time.Now()
+
+	assert.True(t, l.allow("m", "a", limit, now))
+	assert.True(t, l.allow("m", "b", limit, now))
+
+	// The new clients share the bucket of the method once the buckets are at the maximum.
+	assert.True(t, l.allow("m", "c", limit, now))
+	assert.False(t, l.allow("m", "d", limit, now))
+	assert.False(t, l.allow("m", "", limit, now))
+	assert.Len(t, l.buckets, 3)
+	assert.NotContains(t, l.buckets, "m|c")
+
+	// The known clients keep their own buckets.
+	assert.False(t, l.allow("m", "a", limit, now))
+
+	// The idle buckets are removed for the new clients.
+	later := now.Add(2 * rateLimitCleanupInterval)
+	assert.True(t, l.allow("m", "e", limit, later))
+	assert.Contains(t, l.buckets, "m|e")
+	assert.Len(t, l.buckets, 1)
+}
+
+func TestMethodTimeout(t *testing.T) {
+	oldTimeouts := MethodTimeouts
+	MethodTimeouts = map[string]time.Duration{"service_sleep": 20 * time.Millisecond}
+	defer func() { MethodTimeouts = oldTimeouts }()
+
+	server := newTestServer("service", new(Service))
+	defer server.Stop()
+	client := DialInProc(server)
+	defer client.Close()
+
+	start := time.Now()
+	require.NoError(t, client.Call(nil, "service_sleep", time.Second))
+	assert.Less(t, int64(time.Since(start)), int64(500*time.Millisecond))
+}
+
+func TestParseMethodRateLimits(t *testing.T) {
+	limits, err := ParseMethodRateLimits([]string{"debug_*=0.5", "eth_call=10:20"})
+	require.NoError(t, err)
+	assert.Equal(t, map[string]MethodRateLimit{
+		"debug_*":  {Rate: 0.5, Burst: 1},
+		"eth_call": {Rate: 10, Burst: 20},
+	}, limits)
+
+	for _, spec := range []string{"eth_call", "=1", "eth_call=", "eth_call=-1", "eth_call=1:0", "eth_call=a"} {
+		_, err := ParseMethodRateLimits([]string{spec})
+		assert.Error(t, err, spec)
+	}
+}
+
+func TestParseMethodTimeouts(t *testing.T) {
+	timeouts, err := ParseMethodTimeouts([]string{"debug_*=30s"})
+	require.NoError(t, err)
+	assert.Equal(t, map[string]time.Duration{"debug_*": 30 * time.Second}, timeouts)
+
+	_, err = ParseMethodTimeouts([]string{"debug_*=30"})
+	assert.Error(t, err)
+}
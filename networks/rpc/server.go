@@ -23,6 +23,7 @@ package rpc
 import (
 	"context"
 	"io"
+	"sync"
 	"sync/atomic"
 
 	mapset "github.com/deckarep/golang-set"
@@ -70,6 +71,14 @@ var (
 	// NonEthCompatible is a bool value that determines whether to use return formatting of the eth namespace API  provided for compatibility.
 	// It can be overwritten by rpc.eth.noncompatible flag
 	NonEthCompatible = false
+
+	// DisableDeprecatedMethods is a bool value that determines whether to reject the calls of the deprecated methods.
+	// It can be overwritten by rpc.deprecated.disable flag
+	DisableDeprecatedMethods = false
+
+	// DeprecationNotice is a bool value that determines whether to add the deprecation notice to the responses of the
+	// deprecated methods. It can be overwritten by rpc.deprecated.notice flag
+	DeprecationNotice = false
 )
 
 // Server is an RPC server.
@@ -79,6 +88,9 @@ type Server struct {
 	codecs      mapset.Set
 	run         int32
 	wsConnCount int32
+
+	wsConns      sync.Map // *wsConnCodec by the connection id
+	lastWSConnID uint64
 }
 
 // NewServer creates a new server instance with no registered handlers.
@@ -103,12 +115,29 @@ func (s *RPCService) Modules() map[string]string {
 	defer s.server.services.mu.Unlock()
 
 	modules := make(map[string]string)
-	for name := range s.server.services.services {
-		modules[name] = "1.0"
+	for name, svc := range s.server.services.services {
+		modules[name] = svc.version
+		if modules[name] == "" {
+			modules[name] = "1.0"
+		}
 	}
 	return modules
 }
 
+// DeprecatedMethods returns the deprecated methods of the RPC services with the methods replacing them.
+func (s *RPCService) DeprecatedMethods() map[string]string {
+	s.server.services.mu.Lock()
+	defer s.server.services.mu.Unlock()
+
+	methods := make(map[string]string)
+	for name, svc := range s.server.services.services {
+		for method, replacement := range svc.deprecated {
+			methods[name+serviceMethodSeparator+method] = replacement
+		}
+	}
+	return methods
+}
+
 func (s *Server) GetServices() map[string]service {
 	return s.services.services
 }
@@ -121,6 +150,16 @@ func (s *Server) RegisterName(name string, rcvr interface{}) error {
 	return s.services.registerName(name, rcvr)
 }
 
+// RegisterAPI registers the service of the API under its namespace like RegisterName,
+// and records the version and the deprecated methods of the namespace.
+func (s *Server) RegisterAPI(api API) error {
+	if err := s.services.registerName(api.Namespace, api.Service); err != nil {
+		return err
+	}
+	s.services.setAPIInfo(api.Namespace, api.Version, api.Deprecated)
+	return nil
+}
+
 func GetNullServices() service {
 	return service{}
 }
@@ -23,6 +23,8 @@ import (
 	"net"
 	"testing"
 	"time"
+
+	"github.com/stretchr/testify/assert"
 )
 
 type Service struct{}
@@ -190,3 +192,58 @@ func TestServerShortLivedConn(t *testing.T) {
 		}
 	}
 }
+
+func TestServerDeprecatedMethods(t *testing.T) {
+	server := NewServer()
+	defer server.Stop()
+	if err := server.RegisterAPI(API{
+		Namespace:  "test",
+		Version:    "2.0",
+		Service:    new(Service),
+		Deprecated: map[string]string{"rets": "test_echo"},
+	}); err != nil {
+		t.Fatal(err)
+	}
+
+	clientConn, serverConn := net.Pipe()
+	defer clientConn.Close()
+	go server.ServeCodec(NewCodec(serverConn), 0)
+
+	out := json.NewEncoder(clientConn)
+	in := json.NewDecoder(clientConn)
+	call := func(method string) *jsonrpcMessage {
+		if err := out.Encode(map[string]interface{}{"jsonrpc": "2.0", "id": 1, "method": method}); err != nil {
+			t.Fatal(err)
+		}
+		var msg jsonrpcMessage
+		if err := in.Decode(&msg); err != nil {
+			t.Fatal(err)
+		}
+		return &msg
+	}
+
+	// The version and the deprecated methods are listed by the rpc namespace.
+	assert.JSONEq(t, `{"rpc":"1.0","test":"2.0"}`, string(call("rpc_modules").Result))
+	assert.JSONEq(t, `{"test_rets":"test_echo"}`, string(call("rpc_deprecatedMethods").Result))
+
+	// Deprecated methods are served without the notice by default.
+	resp := call("test_rets")
+	assert.Nil(t, resp.Error)
+	assert.Nil(t, resp.Deprecated)
+
+	defer func(notice, disable bool) {
+		DeprecationNotice, DisableDeprecatedMethods = notice, disable
+	}(DeprecationNotice, DisableDeprecatedMethods)
+
+	DeprecationNotice = true
+	resp = call("test_rets")
+	assert.Nil(t, resp.Error)
+	assert.Equal(t, &deprecationNotice{Replacement: "test_echo"}, resp.Deprecated)
+	assert.Nil(t, call("test_noArgsRets").Deprecated)
+
+	DisableDeprecatedMethods = true
+	resp = call("test_rets")
+	if assert.NotNil(t, resp.Error) {
+		assert.Contains(t, resp.Error.Message, "use test_echo instead")
+	}
+}
@@ -47,6 +47,8 @@ type serviceRegistry struct {
 // service represents a registered object.
 type service struct {
 	name          string               // name for service
+	version       string               // api version of the namespace
+	deprecated    map[string]string    // replacement hints of the deprecated methods
 	callbacks     map[string]*callback // registered handlers
 	subscriptions map[string]*callback // available subscriptions/notifications
 }
@@ -95,6 +97,40 @@ func (r *serviceRegistry) registerName(name string, rcvr interface{}) error {
 	return nil
 }
 
+// setAPIInfo records the version and the deprecated methods of a registered service.
+func (r *serviceRegistry) setAPIInfo(name, version string, deprecated map[string]string) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	svc, ok := r.services[name]
+	if !ok {
+		return
+	}
+	if version != "" {
+		svc.version = version
+	}
+	for method, replacement := range deprecated {
+		if svc.deprecated == nil {
+			svc.deprecated = make(map[string]string)
+		}
+		svc.deprecated[method] = replacement
+	}
+	r.services[name] = svc
+}
+
+// deprecation returns the replacement hint of the given RPC method name if it is deprecated.
+func (r *serviceRegistry) deprecation(method string) (string, bool) {
+	elem := strings.SplitN(method, serviceMethodSeparator, 2)
+	if len(elem) != 2 {
+		return "", false
+	}
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	replacement, ok := r.services[elem[0]].deprecated[elem[1]]
+	return replacement, ok
+}
+
 // callback returns the callback corresponding to the given RPC method name.
 func (r *serviceRegistry) callback(method string) *callback {
 	elem := strings.SplitN(method, serviceMethodSeparator, 2)
@@ -172,11 +172,15 @@ func (n *Notifier) activate() error {
 func (n *Notifier) send(sub *Subscription, data json.RawMessage) error {
 	params, _ := json.Marshal(&subscriptionResult{ID: string(sub.ID), Result: data})
 	ctx := context.Background()
-	return n.h.conn.writeJSON(ctx, &jsonrpcMessage{
+	msg := &jsonrpcMessage{
 		Version: vsn,
 		Method:  n.namespace + notificationMethodSuffix,
 		Params:  params,
-	})
+	}
+	if sender, ok := n.h.conn.(notificationSender); ok {
+		return sender.notify(ctx, msg)
+	}
+	return n.h.conn.writeJSON(ctx, msg)
 }
 
 // A Subscription is created by a notifier and tight to that notifier. The client can use
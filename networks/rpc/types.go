@@ -37,6 +37,10 @@ type API struct {
 	Version   string      // api version for DApp's
 	Service   interface{} // receiver instance which holds the methods
 	Public    bool        // indication if the methods must be considered safe for public use
+
+	// Deprecated maps the deprecated methods of the namespace to the hints of their replacements,
+	// e.g. "startRPC" to "admin_startHTTP".
+	Deprecated map[string]string
 }
 
 // Error wraps RPC errors, which contain an error code in addition to the message.
@@ -57,9 +57,36 @@ func newWebsocketCodec(conn *websocket.Conn) ServerCodec {
 	if WebsocketWriteDeadline != 0 {
 		conn.SetWriteDeadline(time.Now().Add(time.Duration(WebsocketWriteDeadline) * time.Second))
 	}
+	if conn.Subprotocol() == wsFramesProtocol {
+		return newWebsocketFramesCodec(conn)
+	}
 	return NewFuncCodec(conn, conn.WriteJSON, conn.ReadJSON)
 }
 
+// newWebsocketFramesCodec creates a codec sending each message in the frame encoding
+// as a binary websocket message.
+func newWebsocketFramesCodec(conn *websocket.Conn) ServerCodec {
+	encode := func(v interface{}) error {
+		w, err := conn.NextWriter(websocket.BinaryMessage)
+		if err != nil {
+			return err
+		}
+		if err := encodeFrames(w, v); err != nil {
+			w.Close()
+			return err
+		}
+		return w.Close()
+	}
+	decode := func(v interface{}) error {
+		_, r, err := conn.NextReader()
+		if err != nil {
+			return err
+		}
+		return decodeFrames(r, common.MaxRequestContentLength, v)
+	}
+	return NewFuncCodec(conn, encode, decode)
+}
+
 // WebsocketHandler returns a handler that serves JSON-RPC to WebSocket connections.
 //
 // allowedOrigins should be a comma-separated list of allowed origin URLs.
@@ -70,6 +97,7 @@ func (srv *Server) WebsocketHandler(allowedOrigins []string) http.Handler {
 		WriteBufferSize: wsWriteBuffer,
 		WriteBufferPool: wsBufferPool,
 		CheckOrigin:     wsHandshakeValidator(allowedOrigins),
+		Subprotocols:    []string{wsFramesProtocol},
 	}
 	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
 		if atomic.LoadInt32(&srv.wsConnCount) >= MaxWebsocketConnections {
@@ -86,7 +114,9 @@ func (srv *Server) WebsocketHandler(allowedOrigins []string) http.Handler {
 			return
 		}
 		codec := newWebsocketCodec(conn)
-		srv.ServeCodec(codec, 0)
+		srv.serveWebsocketCodec(codec, r.RemoteAddr, func(code int, reason string) {
+			conn.WriteControl(websocket.CloseMessage, formatCloseReason(code, reason), time.Now().Add(wsCloseTimeout))
+		})
 	})
 }
 
@@ -139,9 +169,28 @@ func (srv *Server) FastWebsocketHandler(ctx *fasthttp.RequestCtx) {
 			dec.UseNumber()
 			return dec.Decode(v)
 		}
+		if string(protocol) == wsFramesProtocol {
+			encoder = func(v interface{}) error {
+				var buf bytes.Buffer
+				if err := encodeFrames(&buf, v); err != nil {
+					return err
+				}
+				return conn.WriteMessage(websocket.BinaryMessage, buf.Bytes())
+			}
+			decoder = func(v interface{}) error {
+				_, data, err := conn.ReadMessage()
+				if err != nil {
+					return err
+				}
+				return decodeFrames(bytes.NewReader(data), common.MaxRequestContentLength, v)
+			}
+		}
 
 		reader := bufio.NewReaderSize(bytes.NewReader(ctx.Request.Body()), common.MaxRequestContentLength)
-		srv.ServeCodec(NewFuncCodec(&httpReadWriteNopCloser{reader, ctx.Response.BodyWriter()}, encoder, decoder), 0)
+		codec := NewFuncCodec(&httpReadWriteNopCloser{reader, ctx.Response.BodyWriter()}, encoder, decoder)
+		srv.serveWebsocketCodec(codec, conn.RemoteAddr().String(), func(code int, reason string) {
+			conn.WriteControl(websocket.CloseMessage, formatCloseReason(code, reason), time.Now().Add(wsCloseTimeout))
+		})
 	})
 	if err != nil {
 		logger.Error("FastWebsocketHandler fail to upgrade message", "err", err)
@@ -271,6 +320,17 @@ func (e wsHandshakeError) Error() string {
 // The context is used for the initial connection establishment. It does not
 // affect subsequent interactions with the client.
 func DialWebsocket(ctx context.Context, endpoint, origin string) (*Client, error) {
+	return dialWebsocket(ctx, endpoint, origin, false)
+}
+
+// DialWebsocketFrames is like DialWebsocket, but the messages are exchanged in the frame encoding,
+// which a high-throughput client can use to cut the cost of reading large results.
+// It fails if the server does not support the frame encoding.
+func DialWebsocketFrames(ctx context.Context, endpoint, origin string) (*Client, error) {
+	return dialWebsocket(ctx, endpoint, origin, true)
+}
+
+func dialWebsocket(ctx context.Context, endpoint, origin string, frames bool) (*Client, error) {
 	endpoint, header, err := wsClientHeaders(endpoint, origin)
 	if err != nil {
 		return nil, err
@@ -281,6 +341,9 @@ func DialWebsocket(ctx context.Context, endpoint, origin string) (*Client, error
 		WriteBufferSize: wsWriteBuffer,
 		WriteBufferPool: wsBufferPool,
 	}
+	if frames {
+		dialer.Subprotocols = []string{wsFramesProtocol}
+	}
 
 	return NewClient(ctx, func(ctx context.Context) (ServerCodec, error) {
 		conn, resp, err := dialer.DialContext(ctx, endpoint, header)
@@ -295,6 +358,10 @@ func DialWebsocket(ctx context.Context, endpoint, origin string) (*Client, error
 			}
 			return nil, hErr
 		}
+		if frames && conn.Subprotocol() != wsFramesProtocol {
+			conn.Close()
+			return nil, errFramesRejected
+		}
 		return newWebsocketCodec(conn), nil
 	})
 }
@@ -0,0 +1,262 @@
+// Copyright 2022 The klaytn Authors
+// This file is part of the klaytn library.
+//
+// The klaytn library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The klaytn library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the klaytn library. If not, see <http://www.gnu.org/licenses/>.
+
+package rpc
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/gorilla/websocket"
+)
+
+var (
+	// WebsocketMaxQueuedMessages is the maximum number of messages waiting to be sent on a websocket connection.
+	// A connection exceeding it is disconnected as a slow consumer. 0 means no limit.
+	WebsocketMaxQueuedMessages int64 = 0
+
+	// WebsocketMaxSendLatency is the maximum time in milliseconds to send a message on a websocket connection.
+	// A connection exceeding it is disconnected as a slow consumer. 0 means no limit.
+	WebsocketMaxSendLatency int64 = 0
+
+	// WebsocketNotificationBuffer is the maximum number of subscription notifications buffered on a websocket
+	// connection to be sent asynchronously. 0 means the notifications are sent synchronously, blocking
+	// the publisher until they are written. It applies to the connections established afterwards.
+	WebsocketNotificationBuffer = 0
+
+	// WebsocketNotificationPolicy is the policy applied to a notification overflowing the buffer of a connection.
+	WebsocketNotificationPolicy = NotificationPolicyClose
+
+	errWebsocketSlowConsumer     = errors.New("websocket connection disconnected as a slow consumer")
+	errUnknownNotificationPolicy = errors.New("unknown websocket notification policy")
+)
+
+const (
+	// NotificationPolicyDrop drops the notifications overflowing the buffer of a connection.
+	NotificationPolicyDrop = "drop"
+	// NotificationPolicyClose disconnects the connection whose buffer overflows as a slow consumer.
+	NotificationPolicyClose = "close"
+)
+
+// ValidateNotificationPolicy returns an error if the given websocket notification policy is unknown.
+func ValidateNotificationPolicy(policy string) error {
+	switch policy {
+	case NotificationPolicyDrop, NotificationPolicyClose:
+		return nil
+	}
+	return fmt.Errorf("%w: %q", errUnknownNotificationPolicy, policy)
+}
+
+// wsCloseTimeout is the timeout of sending the close frame to an evicted websocket connection.
+const wsCloseTimeout = time.Second
+
+// WSConnInfo is the statistics of a websocket connection.
+type WSConnInfo struct {
+	ID               uint64    `json:"id"`
+	RemoteAddr       string    `json:"remoteAddr"`
+	ConnectedAt      time.Time `json:"connectedAt"`
+	Subscriptions    int32     `json:"subscriptions"`
+	QueuedMessages   int64     `json:"queuedMessages"` // messages waiting to be sent
+	SentMessages     uint64    `json:"sentMessages"`
+	AvgSendLatencyMs float64   `json:"avgSendLatencyMs"`
+	MaxSendLatencyMs float64   `json:"maxSendLatencyMs"`
+
+	BufferedNotifications int    `json:"bufferedNotifications"` // notifications waiting in the buffer
+	DroppedNotifications  uint64 `json:"droppedNotifications"`
+}
+
+// notificationSender is implemented by the connections sending the subscription notifications
+// through their own buffer.
+type notificationSender interface {
+	notify(ctx context.Context, msg *jsonrpcMessage) error
+}
+
+// queuedNotification is a subscription notification waiting in the buffer of a connection.
+type queuedNotification struct {
+	msg      *jsonrpcMessage
+	queuedAt time.Time
+}
+
+// wsConnCodec is the server codec of a websocket connection. It tracks the statistics of the
+// connection, and disconnects the connection if it cannot keep up with the messages sent to it.
+type wsConnCodec struct {
+	ServerCodec
+	id              uint64
+	remote          string
+	connectedAt     time.Time
+	closeWithReason func(code int, reason string) // sends the close frame with the reason
+
+	subscriptions int32
+	queued        int64
+	sent          uint64
+	totalLatency  int64 // sum of the send latencies in nanoseconds
+	maxLatency    int64 // maximum send latency in nanoseconds
+
+	notifications chan queuedNotification // nil if the notifications are sent synchronously
+	dropped       uint64
+
+	evictOnce sync.Once
+}
+
+// startNotificationSender makes the subscription notifications be buffered up to the given size
+// and sent by a separate goroutine, so that a slow consumer does not block their publisher.
+func (c *wsConnCodec) startNotificationSender(size int) {
+	c.notifications = make(chan queuedNotification, size)
+	go func() {
+		for {
+			select {
+			case n := <-c.notifications:
+				if err := c.writeJSON(context.Background(), n.msg); err != nil {
+					logger.Debug("Failed to send a websocket notification", "id", c.id, "remote", c.remote, "err", err)
+				}
+				wsNotificationLagTimer.UpdateSince(n.queuedAt)
+			case <-c.closed():
+				return
+			}
+		}
+	}()
+}
+
+// notify implements notificationSender. When the buffer is full, the notification is dropped or
+// the connection is disconnected according to WebsocketNotificationPolicy.
+func (c *wsConnCodec) notify(ctx context.Context, msg *jsonrpcMessage) error {
+	if c.notifications == nil {
+		start := time.Now()
+		err := c.writeJSON(ctx, msg)
+		wsNotificationLagTimer.UpdateSince(start)
+		return err
+	}
+	select {
+	case c.notifications <- queuedNotification{msg: msg, queuedAt: time.Now()}:
+		return nil
+	default:
+	}
+	if WebsocketNotificationPolicy == NotificationPolicyDrop {
+		atomic.AddUint64(&c.dropped, 1)
+		wsDroppedNotificationCounter.Inc(1)
+		return nil
+	}
+	c.evict(fmt.Sprintf("slow consumer: %d notifications buffered", cap(c.notifications)))
+	return errWebsocketSlowConsumer
+}
+
+func (c *wsConnCodec) writeJSON(ctx context.Context, v interface{}) error {
+	queued := atomic.AddInt64(&c.queued, 1)
+	defer atomic.AddInt64(&c.queued, -1)
+
+	if limit := WebsocketMaxQueuedMessages; limit > 0 && queued > limit {
+		c.evict(fmt.Sprintf("slow consumer: %d messages queued, limit %d", queued, limit))
+		return errWebsocketSlowConsumer
+	}
+
+	start := time.Now()
+	err := c.ServerCodec.writeJSON(ctx, v)
+	latency := time.Since(start)
+
+	wsSendLatencyTimer.Update(latency)
+	atomic.AddUint64(&c.sent, 1)
+	atomic.AddInt64(&c.totalLatency, int64(latency))
+	for {
+		max := atomic.LoadInt64(&c.maxLatency)
+		if int64(latency) <= max || atomic.CompareAndSwapInt64(&c.maxLatency, max, int64(latency)) {
+			break
+		}
+	}
+
+	if limit := time.Duration(WebsocketMaxSendLatency) * time.Millisecond; limit > 0 && latency > limit {
+		c.evict(fmt.Sprintf("slow consumer: sending took %v, limit %v", latency.Round(time.Millisecond), limit))
+	}
+	return err
+}
+
+// setSubscriptionCount implements subscriptionCounter.
+func (c *wsConnCodec) setSubscriptionCount(n int) {
+	atomic.StoreInt32(&c.subscriptions, int32(n))
+}
+
+// evict sends the close frame with the reason and closes the connection.
+func (c *wsConnCodec) evict(reason string) {
+	c.evictOnce.Do(func() {
+		logger.Warn("Disconnecting a slow websocket consumer", "id", c.id, "remote", c.remote, "reason", reason)
+		wsEvictedConnCounter.Inc(1)
+		if c.closeWithReason != nil {
+			c.closeWithReason(websocket.ClosePolicyViolation, reason)
+		}
+		c.close()
+	})
+}
+
+func (c *wsConnCodec) info() *WSConnInfo {
+	info := &WSConnInfo{
+		ID:               c.id,
+		RemoteAddr:       c.remote,
+		ConnectedAt:      c.connectedAt,
+		Subscriptions:    atomic.LoadInt32(&c.subscriptions),
+		QueuedMessages:   atomic.LoadInt64(&c.queued),
+		SentMessages:     atomic.LoadUint64(&c.sent),
+		MaxSendLatencyMs: float64(atomic.LoadInt64(&c.maxLatency)) / float64(time.Millisecond),
+
+		BufferedNotifications: len(c.notifications),
+		DroppedNotifications:  atomic.LoadUint64(&c.dropped),
+	}
+	if info.SentMessages > 0 {
+		info.AvgSendLatencyMs = float64(atomic.LoadInt64(&c.totalLatency)) / float64(info.SentMessages) / float64(time.Millisecond)
+	}
+	return info
+}
+
+// serveWebsocketCodec serves the codec of a websocket connection while tracking its statistics.
+func (s *Server) serveWebsocketCodec(codec ServerCodec, remote string, closeWithReason func(code int, reason string)) {
+	c := &wsConnCodec{
+		ServerCodec:     codec,
+		id:              atomic.AddUint64(&s.lastWSConnID, 1),
+		remote:          remote,
+		connectedAt:     time.Now(),
+		closeWithReason: closeWithReason,
+	}
+	if WebsocketNotificationBuffer > 0 {
+		c.startNotificationSender(WebsocketNotificationBuffer)
+	}
+	s.wsConns.Store(c.id, c)
+	defer s.wsConns.Delete(c.id)
+
+	s.ServeCodec(c, 0)
+}
+
+// WebsocketConnections returns the statistics of the websocket connections served by the server.
+func (s *Server) WebsocketConnections() []*WSConnInfo {
+	var conns []*WSConnInfo
+	s.wsConns.Range(func(_, c interface{}) bool {
+		conns = append(conns, c.(*wsConnCodec).info())
+		return true
+	})
+	sort.Slice(conns, func(i, j int) bool { return conns[i].ID < conns[j].ID })
+	return conns
+}
+
+// formatCloseReason formats the close frame with the reason truncated to fit in a control frame.
+func formatCloseReason(code int, reason string) []byte {
+	// The payload of a control frame is up to 125 bytes including the 2 bytes close code.
+	if len(reason) > 123 {
+		reason = reason[:123]
+	}
+	return websocket.FormatCloseMessage(code, reason)
+}
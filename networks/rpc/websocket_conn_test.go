@@ -0,0 +1,202 @@
+// Copyright 2022 The klaytn Authors
+// This file is part of the klaytn library.
+//
+// The klaytn library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The klaytn library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the klaytn library. If not, see <http://www.gnu.org/licenses/>.
+
+package rpc
+
+import (
+	"context"
+	"net"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/gorilla/websocket"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// newTestWSConnCodec returns a wsConnCodec whose writes are done by the given function,
+// and a channel receiving the close code and reason sent on eviction.
+func newTestWSConnCodec(t *testing.T, write func(v interface{}) error) (*wsConnCodec, chan string) {
+	_, serverConn := net.Pipe()
+	closeReasons := make(chan string, 1)
+	codec := &wsConnCodec{
+		ServerCodec: NewFuncCodec(serverConn, write, func(v interface{}) error { return nil }),
+		closeWithReason: func(code int, reason string) {
+			assert.Equal(t, websocket.ClosePolicyViolation, code)
+			closeReasons <- reason
+		},
+	}
+	return codec, closeReasons
+}
+
+func TestWSConnCodec_MaxQueuedMessages(t *testing.T) {
+	oldLimit := WebsocketMaxQueuedMessages
+	WebsocketMaxQueuedMessages = 2
+	defer func() { WebsocketMaxQueuedMessages = oldLimit }()
+
+	var (
+		unblock = make(chan struct{})
+		writing = make(chan struct{}, 2)
+	)
+	codec, closeReasons := newTestWSConnCodec(t, func(v interface{}) error {
+		writing <- struct{}{}
+		<-unblock
+		return nil
+	})
+
+	// Two messages are queued while the consumer does not read them.
+	errs := make(chan error, 2)
+	for i := 0; i < 2; i++ {
+		go func() { errs <- codec.writeJSON(context.Background(), "msg") }()
+	}
+	<-writing
+	for codec.info().QueuedMessages < 2 {
+		time.Sleep(time.Millisecond)
+	}
+
+	// The third message exceeds the limit and the connection is evicted.
+	assert.Equal(t, errWebsocketSlowConsumer, codec.writeJSON(context.Background(), "msg"))
+	assert.Contains(t, <-closeReasons, "3 messages queued, limit 2")
+	select {
+	case <-codec.closed():
+	default:
+		t.Fatal("the evicted connection is not closed")
+	}
+
+	close(unblock)
+	for i := 0; i < 2; i++ {
+		<-errs
+	}
+	assert.Equal(t, int64(0), codec.info().QueuedMessages)
+}
+
+func TestWSConnCodec_MaxSendLatency(t *testing.T) {
+	oldLimit := WebsocketMaxSendLatency
+	WebsocketMaxSendLatency = 10
+	defer func() { WebsocketMaxSendLatency = oldLimit }()
+
+	delay := time.Duration(0)
+	codec, closeReasons := newTestWSConnCodec(t, func(v interface{}) error {
+		time.Sleep(delay)
+		return nil
+	})
+
+	// A fast send does not evict the connection.
+	assert.NoError(t, codec.writeJSON(context.Background(), "msg"))
+	assert.Len(t, closeReasons, 0)
+
+	// A send slower than the limit evicts the connection.
+	delay = 50 * time.Millisecond
+	assert.NoError(t, codec.writeJSON(context.Background(), "msg"))
+	assert.Contains(t, <-closeReasons, "slow consumer: sending took")
+
+	info := codec.info()
+	assert.Equal(t, uint64(2), info.SentMessages)
+	assert.True(t, info.MaxSendLatencyMs >= 50)
+	assert.True(t, info.AvgSendLatencyMs >= 25)
+}
+
+func TestWSConnCodec_NotificationBuffer(t *testing.T) {
+	oldPolicy := WebsocketNotificationPolicy
+	defer func() { WebsocketNotificationPolicy = oldPolicy }()
+
+	for _, policy := range []string{NotificationPolicyDrop, NotificationPolicyClose} {
+		WebsocketNotificationPolicy = policy
+
+		var (
+			unblock = make(chan struct{})
+			written = make(chan interface{}, 4)
+		)
+		codec, closeReasons := newTestWSConnCodec(t, func(v interface{}) error {
+			<-unblock
+			written <- v
+			return nil
+		})
+		codec.startNotificationSender(2)
+
+		// The first notification is being sent and two more are buffered without blocking the publisher.
+		msg := &jsonrpcMessage{Version: vsn, Method: "test" + notificationMethodSuffix}
+		require.NoError(t, codec.notify(context.Background(), msg))
+		for codec.info().QueuedMessages < 1 {
+			time.Sleep(time.Millisecond)
+		}
+		require.NoError(t, codec.notify(context.Background(), msg))
+		require.NoError(t, codec.notify(context.Background(), msg))
+		assert.Equal(t, 2, codec.info().BufferedNotifications)
+
+		// The notification overflowing the buffer is handled according to the policy.
+		err := codec.notify(context.Background(), msg)
+		switch policy {
+		case NotificationPolicyDrop:
+			assert.NoError(t, err)
+			assert.Equal(t, uint64(1), codec.info().DroppedNotifications)
+			assert.Len(t, closeReasons, 0)
+
+			close(unblock)
+			for i := 0; i < 3; i++ {
+				assert.Equal(t, msg, <-written)
+			}
+		case NotificationPolicyClose:
+			assert.Equal(t, errWebsocketSlowConsumer, err)
+			assert.Contains(t, <-closeReasons, "2 notifications buffered")
+			close(unblock)
+		}
+		codec.close()
+	}
+
+	assert.NoError(t, ValidateNotificationPolicy(NotificationPolicyDrop))
+	assert.ErrorIs(t, ValidateNotificationPolicy("block"), errUnknownNotificationPolicy)
+}
+
+func TestServer_WebsocketConnections(t *testing.T) {
+	var (
+		srv     = newTestServer("nftest", new(NotificationTestService))
+		httpsrv = httptest.NewServer(srv.WebsocketHandler([]string{"*"}))
+		wsAddr  = "ws:" + strings.TrimPrefix(httpsrv.URL, "http:")
+	)
+	defer srv.Stop()
+	defer httpsrv.Close()
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+	client, err := DialWebsocket(ctx, wsAddr, "")
+	require.NoError(t, err)
+	defer client.Close()
+
+	ch := make(chan int)
+	sub, err := client.Subscribe(ctx, "nftest", ch, "someSubscription", 2, 0)
+	require.NoError(t, err)
+	for i := 0; i < 2; i++ {
+		<-ch
+	}
+
+	conns := srv.WebsocketConnections()
+	require.Len(t, conns, 1)
+	assert.Equal(t, int32(1), conns[0].Subscriptions)
+	assert.NotEmpty(t, conns[0].RemoteAddr)
+	// the subscription id and two notifications are sent
+	assert.Eventually(t, func() bool {
+		return srv.WebsocketConnections()[0].SentMessages == 3
+	}, time.Second, 10*time.Millisecond)
+
+	sub.Unsubscribe()
+	client.Close()
+	assert.Eventually(t, func() bool {
+		return len(srv.WebsocketConnections()) == 0
+	}, time.Second, 10*time.Millisecond)
+}
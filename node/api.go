@@ -283,6 +283,29 @@ func (api *PrivateAdminAPI) SetMaxSubscriptionPerWSConn(num int32) {
 	rpc.MaxSubscriptionPerWSConn = num
 }
 
+// WebsocketConnections returns the subscription count, queued messages and send latency
+// of each websocket connection.
+func (api *PrivateAdminAPI) WebsocketConnections() ([]*rpc.WSConnInfo, error) {
+	api.node.lock.RLock()
+	defer api.node.lock.RUnlock()
+
+	if api.node.wsHandler == nil {
+		return nil, fmt.Errorf("WebSocket RPC not running")
+	}
+	return api.node.wsHandler.WebsocketConnections(), nil
+}
+
+// SetWebsocketSlowConsumerLimits sets the maximum number of the queued messages and the maximum
+// send latency in milliseconds of a websocket connection. The connections exceeding them are
+// disconnected. 0 means no limit.
+func (api *PrivateAdminAPI) SetWebsocketSlowConsumerLimits(maxQueuedMessages int64, maxSendLatency int64) {
+	logger.Info("Change the slow consumer limits for websocket connections",
+		"oldMaxQueuedMessages", rpc.WebsocketMaxQueuedMessages, "newMaxQueuedMessages", maxQueuedMessages,
+		"oldMaxSendLatency", rpc.WebsocketMaxSendLatency, "newMaxSendLatency", maxSendLatency)
+	rpc.WebsocketMaxQueuedMessages = maxQueuedMessages
+	rpc.WebsocketMaxSendLatency = maxSendLatency
+}
+
 // PublicAdminAPI is the collection of administrative API methods exposed over
 // both secure and unsecure RPC channels.
 type PublicAdminAPI struct {
@@ -31,14 +31,17 @@ import (
 	"strings"
 	"time"
 
+	klaytnapi "github.com/klaytn/klaytn/api"
 	"github.com/klaytn/klaytn/blockchain"
 	"github.com/klaytn/klaytn/blockchain/state"
 	"github.com/klaytn/klaytn/blockchain/types"
 	"github.com/klaytn/klaytn/common"
 	"github.com/klaytn/klaytn/common/hexutil"
 	"github.com/klaytn/klaytn/networks/rpc"
+	"github.com/klaytn/klaytn/node/cn/gasprice"
 	"github.com/klaytn/klaytn/params"
 	"github.com/klaytn/klaytn/rlp"
+	"github.com/klaytn/klaytn/storage/database"
 	"github.com/klaytn/klaytn/storage/statedb"
 	"github.com/klaytn/klaytn/work"
 )
@@ -59,6 +62,19 @@ func (api *PublicKlayAPI) Rewardbase() (common.Address, error) {
 	return api.cn.Rewardbase()
 }
 
+// NodeLag returns how far the local chain is behind the heads advertised by the peers,
+// which reveals a stalled node even when it is not syncing.
+func (api *PublicKlayAPI) NodeLag() *NodeLag {
+	return api.cn.protocolManager.NodeLag()
+}
+
+// FeeStats returns the gas used ratio, the base fee and the rewards at the given percentiles
+// of each block from fromBlock to toBlock. The statistics stored at import are used
+// if the fee statistics indexing is enabled.
+func (api *PublicKlayAPI) FeeStats(ctx context.Context, fromBlock, toBlock rpc.BlockNumber, rewardPercentiles []float64) ([]*gasprice.FeeStatsResult, error) {
+	return api.cn.APIBackend.gpo.FeeStats(ctx, fromBlock, toBlock, rewardPercentiles)
+}
+
 // PrivateAdminAPI is the collection of CN full node-related APIs
 // exposed over the private admin endpoint.
 type PrivateAdminAPI struct {
@@ -197,10 +213,56 @@ func (api *PrivateAdminAPI) StateMigrationStatus() map[string]interface{} {
 	}
 }
 
+// RpcCacheStats returns the capacities, the numbers of entries and the hit rates of the RPC caches.
+func (api *PrivateAdminAPI) RpcCacheStats() map[string]klaytnapi.RPCCacheStats {
+	return klaytnapi.RPCCachesStats()
+}
+
+// SetRPCCacheSize changes the capacity of the RPC cache of the given name.
+// The available names are "receipts", "ethblocks", "ethreceipts" and "senders", and the size 0 disables the cache.
+func (api *PrivateAdminAPI) SetRPCCacheSize(name string, size int) error {
+	return klaytnapi.ResizeRPCCache(name, size)
+}
+
 func (api *PrivateAdminAPI) SaveTrieNodeCacheToDisk() error {
 	return api.cn.BlockChain().SaveTrieNodeCacheToDisk()
 }
 
+// SetTrieNodeCacheSize changes the size of the trie node cache in MiB at runtime.
+// The cached trie nodes are dropped when the cache is resized.
+func (api *PrivateAdminAPI) SetTrieNodeCacheSize(sizeMiB int) error {
+	return api.cn.blockchain.ResizeTrieNodeCache(sizeMiB)
+}
+
+// CompactDatabase compacts the given key range of the database of the given name, such as "header" or "statetrie".
+// All the databases are compacted if the name is empty, and the whole key range if start and limit are empty.
+func (api *PrivateAdminAPI) CompactDatabase(name string, start, limit hexutil.Bytes) error {
+	if len(start) == 0 {
+		start = nil
+	}
+	if len(limit) == 0 {
+		limit = nil
+	}
+	return api.cn.ChainDB().CompactDatabase(name, start, limit)
+}
+
+// DbStats returns the size, the read and write amplifications and the cache usage of each database.
+func (api *PrivateAdminAPI) DbStats() ([]*database.DBStats, error) {
+	return api.cn.ChainDB().DatabaseStats()
+}
+
+// TxPoolLimits returns the current slot limits and lifetime of the transaction pool.
+func (api *PrivateAdminAPI) TxPoolLimits() blockchain.TxPoolLimits {
+	return api.cn.txPool.Limits()
+}
+
+// SetTxPoolLimits updates the slot limits and lifetime of the transaction pool without restarting the node.
+// The fields omitted or given as zero are left unchanged. It returns the limits after the update.
+func (api *PrivateAdminAPI) SetTxPoolLimits(limits blockchain.TxPoolLimits) blockchain.TxPoolLimits {
+	api.cn.txPool.SetLimits(limits)
+	return api.cn.txPool.Limits()
+}
+
 func (api *PrivateAdminAPI) SpamThrottlerConfig(ctx context.Context) (*blockchain.ThrottlerConfig, error) {
 	throttler := blockchain.GetSpamThrottler()
 	if throttler == nil {
@@ -343,6 +405,65 @@ func (api *PublicDebugAPI) DumpStateTrie(ctx context.Context, blockNrOrHash rpc.
 	return result, nil
 }
 
+// BlockRootMismatch is a field of a header which does not match the value recomputed from the stored data.
+type BlockRootMismatch struct {
+	Field    string `json:"field"`
+	Header   string `json:"header"`
+	Computed string `json:"computed"`
+}
+
+// VerifyBlockRootsResult is the result of debug_verifyBlockRoots.
+type VerifyBlockRootsResult struct {
+	Number     hexutil.Uint64      `json:"number"`
+	Hash       common.Hash         `json:"hash"`
+	Valid      bool                `json:"valid"`
+	Mismatches []BlockRootMismatch `json:"mismatches"`
+}
+
+// VerifyBlockRoots recomputes the transaction root, the receipt root, the bloom and the gas used
+// of the given block from its stored body and receipts, and reports the fields mismatching the header.
+func (api *PublicDebugAPI) VerifyBlockRoots(ctx context.Context, blockNrOrHash rpc.BlockNumberOrHash) (*VerifyBlockRootsResult, error) {
+	block, err := api.cn.APIBackend.BlockByNumberOrHash(ctx, blockNrOrHash)
+	if block == nil || err != nil {
+		blockNrOrHashString, _ := blockNrOrHash.NumberOrHashString()
+		return nil, fmt.Errorf("block %v not found", blockNrOrHashString)
+	}
+	header := block.Header()
+	result := &VerifyBlockRootsResult{
+		Number:     hexutil.Uint64(block.NumberU64()),
+		Hash:       block.Hash(),
+		Mismatches: []BlockRootMismatch{},
+	}
+	mismatch := func(field string, inHeader, computed interface{}) {
+		result.Mismatches = append(result.Mismatches, BlockRootMismatch{field, fmt.Sprint(inHeader), fmt.Sprint(computed)})
+	}
+
+	if txHash := types.DeriveSha(block.Transactions(), block.Number()); txHash != header.TxHash {
+		mismatch("transactionsRoot", header.TxHash.Hex(), txHash.Hex())
+	}
+	receipts := api.cn.blockchain.GetReceiptsByBlockHash(block.Hash())
+	if len(receipts) != len(block.Transactions()) {
+		// The roots cannot be recomputed without all the receipts.
+		mismatch("receipts", len(block.Transactions()), len(receipts))
+	} else {
+		if receiptHash := types.DeriveSha(receipts, block.Number()); receiptHash != header.ReceiptHash {
+			mismatch("receiptsRoot", header.ReceiptHash.Hex(), receiptHash.Hex())
+		}
+		if bloom := types.CreateBloom(receipts); bloom != header.Bloom {
+			mismatch("logsBloom", hexutil.Encode(header.Bloom[:]), hexutil.Encode(bloom[:]))
+		}
+		gasUsed := uint64(0)
+		for _, receipt := range receipts {
+			gasUsed += receipt.GasUsed
+		}
+		if gasUsed != header.GasUsed {
+			mismatch("gasUsed", header.GasUsed, gasUsed)
+		}
+	}
+	result.Valid = len(result.Mismatches) == 0
+	return result, nil
+}
+
 // TODO-klaytn: Rearrange PublicDebugAPI and PrivateDebugAPI receivers
 // StartWarmUp retrieves all state/storage tries of the latest committed state root and caches the tries.
 func (api *PrivateDebugAPI) StartWarmUp() error {
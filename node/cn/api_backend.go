@@ -22,6 +22,7 @@ package cn
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"math/big"
 	"time"
@@ -32,9 +33,11 @@ import (
 	"github.com/klaytn/klaytn/blockchain/bloombits"
 	"github.com/klaytn/klaytn/blockchain/state"
 	"github.com/klaytn/klaytn/blockchain/types"
+	"github.com/klaytn/klaytn/blockchain/types/account"
 	"github.com/klaytn/klaytn/blockchain/vm"
 	"github.com/klaytn/klaytn/common"
 	"github.com/klaytn/klaytn/consensus"
+	"github.com/klaytn/klaytn/datasync/downloader"
 	"github.com/klaytn/klaytn/event"
 	"github.com/klaytn/klaytn/networks/rpc"
 	"github.com/klaytn/klaytn/node/cn/gasprice"
@@ -42,10 +45,21 @@ import (
 	"github.com/klaytn/klaytn/storage/database"
 )
 
+// errNotCanonical is returned when a block requested with requireCanonical is not in the canonical chain.
+var errNotCanonical = errors.New("hash is not currently canonical")
+
 // CNAPIBackend implements api.Backend for full nodes
 type CNAPIBackend struct {
 	cn  *CN
 	gpo *gasprice.Oracle
+
+	pending pendingStateCache // pending state built from the tx pool if not mining
+}
+
+// isCanonical returns true if the block of the given number and hash is in the canonical chain.
+func (b *CNAPIBackend) isCanonical(number uint64, hash common.Hash) bool {
+	canonical := b.cn.blockchain.GetHeaderByNumber(number)
+	return canonical != nil && canonical.Hash() == hash
 }
 
 // GetTxLookupInfoAndReceipt retrieves a tx and lookup info and receipt for a given transaction hash.
@@ -109,6 +123,9 @@ func (b *CNAPIBackend) HeaderByNumberOrHash(ctx context.Context, blockNrOrHash r
 		if err != nil {
 			return nil, err
 		}
+		if blockNrOrHash.RequireCanonical && !b.isCanonical(header.Number.Uint64(), hash) {
+			return nil, errNotCanonical
+		}
 		return header, nil
 	}
 	return nil, fmt.Errorf("invalid arguments; neither block nor hash specified")
@@ -147,14 +164,20 @@ func (b *CNAPIBackend) BlockByNumberOrHash(ctx context.Context, blockNrOrHash rp
 		if err != nil {
 			return nil, err
 		}
+		if blockNrOrHash.RequireCanonical && !b.isCanonical(block.NumberU64(), hash) {
+			return nil, errNotCanonical
+		}
 		return block, nil
 	}
 	return nil, fmt.Errorf("invalid arguments; neither block nor hash specified")
 }
 
 func (b *CNAPIBackend) StateAndHeaderByNumber(ctx context.Context, blockNr rpc.BlockNumber) (*state.StateDB, *types.Header, error) {
-	// Pending state is only known by the miner
+	// Pending state is only known by the miner, or built from the tx pool if not mining
 	if blockNr == rpc.PendingBlockNumber {
+		if !b.cn.miner.Mining() {
+			return b.pendingStateAndHeader(ctx)
+		}
 		block, state := b.cn.miner.Pending()
 		return state, block.Header(), nil
 	}
@@ -163,7 +186,7 @@ func (b *CNAPIBackend) StateAndHeaderByNumber(ctx context.Context, blockNr rpc.B
 	if header == nil || err != nil {
 		return nil, nil, err
 	}
-	stateDb, err := b.cn.BlockChain().StateAt(header.Root)
+	stateDb, err := b.stateAtHeader(header)
 	return stateDb, header, err
 }
 
@@ -176,12 +199,68 @@ func (b *CNAPIBackend) StateAndHeaderByNumberOrHash(ctx context.Context, blockNr
 		if header == nil {
 			return nil, nil, fmt.Errorf("header for hash not found")
 		}
-		stateDb, err := b.cn.BlockChain().StateAt(header.Root)
+		if blockNrOrHash.RequireCanonical && !b.isCanonical(header.Number.Uint64(), hash) {
+			return nil, nil, errNotCanonical
+		}
+		stateDb, err := b.stateAtHeader(header)
 		return stateDb, header, err
 	}
 	return nil, nil, fmt.Errorf("invalid arguments; neither block nor hash specified")
 }
 
+// stateAtHeader returns the state of the given header for the klay/eth namespace APIs.
+// If the state has been pruned, it is regenerated by re-executing at most RPCStateReexecLimit
+// blocks from the nearest available state, and an error is returned if no state is available
+// within the limit. The pruned state is not regenerated if RPCStateReexecLimit is 0.
+func (b *CNAPIBackend) stateAtHeader(header *types.Header) (*state.StateDB, error) {
+	stateDb, err := b.cn.BlockChain().StateAt(header.Root)
+	if err == nil || b.cn.config.RPCStateReexecLimit == 0 {
+		return stateDb, err
+	}
+	block := b.cn.blockchain.GetBlock(header.Hash(), header.Number.Uint64())
+	if block == nil {
+		return nil, err
+	}
+	stateDb, err = b.cn.stateAtBlock(block, b.cn.config.RPCStateReexecLimit, nil, false, false)
+	if err != nil {
+		return nil, b.reexecLimitError(block, err)
+	}
+	return stateDb, nil
+}
+
+// limitReexec bounds the number of blocks re-executed for a tracing request by RPCStateReexecLimit.
+// It returns true if the requested number is limited.
+func (b *CNAPIBackend) limitReexec(reexec uint64) (uint64, bool) {
+	if limit := b.cn.config.RPCStateReexecLimit; reexec > limit {
+		return limit, true
+	}
+	return reexec, false
+}
+
+// reexecLimitError reports that the state of the block is not regenerated within RPCStateReexecLimit.
+func (b *CNAPIBackend) reexecLimitError(block *types.Block, err error) error {
+	return fmt.Errorf("state of block %d is not regenerated within the limit of %d blocks: %w",
+		block.NumberU64(), b.cn.config.RPCStateReexecLimit, err)
+}
+
+// HistoricalAccount returns the account of the given address at the given canonical header,
+// reconstructed from the state history if the state of the header has been pruned.
+func (b *CNAPIBackend) HistoricalAccount(ctx context.Context, address common.Address, header *types.Header) (account.Account, error) {
+	if !b.isCanonical(header.Number.Uint64(), header.Hash()) {
+		return nil, errNotCanonical
+	}
+	return b.cn.blockchain.HistoricalAccount(address, header.Number.Uint64())
+}
+
+// HistoricalStorage returns the storage value of the given address at the given canonical header,
+// reconstructed from the state history if the state of the header has been pruned.
+func (b *CNAPIBackend) HistoricalStorage(ctx context.Context, address common.Address, key common.Hash, header *types.Header) (common.Hash, error) {
+	if !b.isCanonical(header.Number.Uint64(), header.Hash()) {
+		return common.Hash{}, errNotCanonical
+	}
+	return b.cn.blockchain.HistoricalStorage(address, key, header.Number.Uint64())
+}
+
 func (b *CNAPIBackend) BlockByHash(ctx context.Context, hash common.Hash) (*types.Block, error) {
 	block := b.cn.blockchain.GetBlockByHash(hash)
 	if block == nil {
@@ -219,6 +298,10 @@ func (b *CNAPIBackend) SubscribeRemovedLogsEvent(ch chan<- blockchain.RemovedLog
 	return b.cn.BlockChain().SubscribeRemovedLogsEvent(ch)
 }
 
+func (b *CNAPIBackend) SubscribeReorgEvent(ch chan<- blockchain.ReorgEvent) event.Subscription {
+	return b.cn.BlockChain().SubscribeReorgEvent(ch)
+}
+
 func (b *CNAPIBackend) SubscribeChainEvent(ch chan<- blockchain.ChainEvent) event.Subscription {
 	return b.cn.BlockChain().SubscribeChainEvent(ch)
 }
@@ -236,6 +319,9 @@ func (b *CNAPIBackend) SubscribeLogsEvent(ch chan<- []*types.Log) event.Subscrip
 }
 
 func (b *CNAPIBackend) SendTx(ctx context.Context, signedTx *types.Transaction) error {
+	if b.cn.config.ReadReplica {
+		return errReadReplica
+	}
 	return b.cn.txPool.AddLocal(signedTx)
 }
 
@@ -267,10 +353,18 @@ func (b *CNAPIBackend) TxPoolContent() (map[common.Address]types.Transactions, m
 	return b.cn.TxPool().Content()
 }
 
+func (b *CNAPIBackend) TxPoolContentFrom(addr common.Address) (types.Transactions, types.Transactions) {
+	return b.cn.TxPool().ContentFrom(addr)
+}
+
 func (b *CNAPIBackend) SubscribeNewTxsEvent(ch chan<- blockchain.NewTxsEvent) event.Subscription {
 	return b.cn.TxPool().SubscribeNewTxsEvent(ch)
 }
 
+func (b *CNAPIBackend) SubscribeDroppedTxsEvent(ch chan<- blockchain.DroppedTxsEvent) event.Subscription {
+	return b.cn.TxPool().SubscribeDroppedTxsEvent(ch)
+}
+
 func (b *CNAPIBackend) Progress() klaytn.SyncProgress {
 	return b.cn.Progress()
 }
@@ -285,6 +379,12 @@ func (b *CNAPIBackend) SuggestPrice(ctx context.Context) (*big.Int, error) {
 	return b.gpo.SuggestPrice(ctx)
 }
 
+// SuggestTipCap returns the gas tip cap sampled from the recent blocks.
+// If there are no transactions in the recent blocks, it returns the unitPrice.
+func (b *CNAPIBackend) SuggestTipCap(ctx context.Context) (*big.Int, error) {
+	return b.gpo.SuggestTipCap(ctx)
+}
+
 func (b *CNAPIBackend) UpperBoundGasPrice(ctx context.Context) *big.Int {
 	if b.cn.chainConfig.IsMagmaForkEnabled(b.CurrentBlock().Number()) {
 		return new(big.Int).SetUint64(b.cn.governance.Params().UpperBoundBaseFee())
@@ -332,6 +432,15 @@ func (b *CNAPIBackend) IsSenderTxHashIndexingEnabled() bool {
 	return b.cn.BlockChain().IsSenderTxHashIndexingEnabled()
 }
 
+func (b *CNAPIBackend) TxIndexDone() bool {
+	return b.cn.BlockChain().TxIndexDone()
+}
+
+// IsTxLookupSupported returns false for a light node, which does not index the transactions by hash.
+func (b *CNAPIBackend) IsTxLookupSupported() bool {
+	return b.cn.config.SyncMode != downloader.LightSync
+}
+
 func (b *CNAPIBackend) RPCGasCap() *big.Int {
 	return b.cn.config.RPCGasCap
 }
@@ -344,16 +453,30 @@ func (b *CNAPIBackend) RPCTxFeeCap() float64 {
 	return b.cn.config.RPCTxFeeCap
 }
 
+func (b *CNAPIBackend) RPCFeePayerWhitelist() []common.Address {
+	return b.cn.config.RPCFeePayerWhitelist
+}
+
 func (b *CNAPIBackend) Engine() consensus.Engine {
 	return b.cn.engine
 }
 
 func (b *CNAPIBackend) StateAtBlock(ctx context.Context, block *types.Block, reexec uint64, base *state.StateDB, checkLive bool, preferDisk bool) (*state.StateDB, error) {
-	return b.cn.stateAtBlock(block, reexec, base, checkLive, preferDisk)
+	reexec, limited := b.limitReexec(reexec)
+	stateDb, err := b.cn.stateAtBlock(block, reexec, base, checkLive, preferDisk)
+	if err != nil && limited {
+		return nil, b.reexecLimitError(block, err)
+	}
+	return stateDb, err
 }
 
 func (b *CNAPIBackend) StateAtTransaction(ctx context.Context, block *types.Block, txIndex int, reexec uint64) (blockchain.Message, vm.Context, *state.StateDB, error) {
-	return b.cn.stateAtTransaction(block, txIndex, reexec)
+	reexec, limited := b.limitReexec(reexec)
+	msg, vmctx, stateDb, err := b.cn.stateAtTransaction(block, txIndex, reexec)
+	if err != nil && limited {
+		return nil, vm.Context{}, nil, b.reexecLimitError(block, err)
+	}
+	return msg, vmctx, stateDb, err
 }
 
 func (b *CNAPIBackend) FeeHistory(ctx context.Context, blockCount int, lastBlock rpc.BlockNumber, rewardPercentiles []float64) (*big.Int, [][]*big.Int, []*big.Int, []float64, error) {
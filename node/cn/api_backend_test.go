@@ -17,6 +17,7 @@
 package cn
 
 import (
+	"errors"
 	"math/big"
 	"testing"
 
@@ -24,6 +25,7 @@ import (
 	"github.com/klaytn/klaytn/blockchain"
 	"github.com/klaytn/klaytn/blockchain/state"
 	"github.com/klaytn/klaytn/blockchain/types"
+	"github.com/klaytn/klaytn/blockchain/vm"
 	"github.com/klaytn/klaytn/common"
 	mocks3 "github.com/klaytn/klaytn/event/mocks"
 	"github.com/klaytn/klaytn/networks/rpc"
@@ -32,6 +34,7 @@ import (
 	"github.com/klaytn/klaytn/storage/database"
 	"github.com/klaytn/klaytn/work/mocks"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 	"golang.org/x/net/context"
 )
 
@@ -41,7 +44,7 @@ func newCNAPIBackend(t *testing.T) (*gomock.Controller, *mocks.MockBlockChain, *
 	mockBlockChain := mocks.NewMockBlockChain(mockCtrl)
 	mockMiner := mocks2.NewMockMiner(mockCtrl)
 
-	cn := &CN{blockchain: mockBlockChain, miner: mockMiner}
+	cn := &CN{config: &Config{}, blockchain: mockBlockChain, miner: mockMiner}
 
 	return mockCtrl, mockBlockChain, mockMiner, &CNAPIBackend{cn: cn}
 }
@@ -396,6 +399,7 @@ func TestCNAPIBackend_StateAndHeaderByNumber(t *testing.T) {
 	expectedHeader := block.Header()
 	{
 		mockCtrl, _, mockMiner, api := newCNAPIBackend(t)
+		mockMiner.EXPECT().Mining().Return(true).Times(1)
 		mockMiner.EXPECT().Pending().Return(block, stateDB).Times(1)
 
 		returnedStateDB, header, err := api.StateAndHeaderByNumber(context.Background(), rpc.PendingBlockNumber)
@@ -429,6 +433,207 @@ func TestCNAPIBackend_StateAndHeaderByNumber(t *testing.T) {
 		assert.Equal(t, expectedHeader, header)
 		assert.NoError(t, err)
 
+		mockCtrl.Finish()
+	}
+	{
+		// The pruned state can't be regenerated without the block.
+		mockCtrl, mockBlockChain, _, api := newCNAPIBackend(t)
+		api.cn.config.RPCStateReexecLimit = 128
+
+		expectedErr := errors.New("missing trie node")
+		mockBlockChain.EXPECT().GetHeaderByNumber(blockNum).Return(expectedHeader).Times(1)
+		mockBlockChain.EXPECT().StateAt(expectedHeader.Root).Return(nil, expectedErr).Times(1)
+		mockBlockChain.EXPECT().GetBlock(expectedHeader.Hash(), blockNum).Return(nil).Times(1)
+		returnedStateDB, _, err := api.StateAndHeaderByNumber(context.Background(), rpc.BlockNumber(blockNum))
+
+		assert.Nil(t, returnedStateDB)
+		assert.Equal(t, expectedErr, err)
+
+		mockCtrl.Finish()
+	}
+	{
+		// The pruned state is not regenerated if no state is available within the limit.
+		mockCtrl, mockBlockChain, _, api := newCNAPIBackend(t)
+		db := database.NewMemoryDBManager()
+		api.cn.chainDB = db
+		api.cn.config.RPCStateReexecLimit = 1
+
+		parent := types.NewBlockWithHeader(&types.Header{Number: big.NewInt(int64(blockNum - 1)), Root: common.HexToHash("0x1"), BlockScore: big.NewInt(1)})
+		header := &types.Header{Number: big.NewInt(int64(blockNum)), ParentHash: parent.Hash(), Root: common.HexToHash("0x2"), BlockScore: big.NewInt(1)}
+		mockBlockChain.EXPECT().GetHeaderByNumber(blockNum).Return(header).Times(1)
+		mockBlockChain.EXPECT().StateAt(header.Root).Return(nil, errors.New("missing trie node")).Times(1)
+		mockBlockChain.EXPECT().GetBlock(header.Hash(), blockNum).Return(types.NewBlockWithHeader(header)).Times(1)
+		mockBlockChain.EXPECT().StateCache().Return(state.NewDatabase(db)).Times(1)
+		mockBlockChain.EXPECT().GetBlock(parent.Hash(), blockNum-1).Return(parent).Times(1)
+		returnedStateDB, _, err := api.StateAndHeaderByNumber(context.Background(), rpc.BlockNumber(blockNum))
+
+		assert.Nil(t, returnedStateDB)
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "not regenerated within the limit of 1 blocks")
+
+		mockCtrl.Finish()
+	}
+}
+
+func TestCNAPIBackend_LimitReexec(t *testing.T) {
+	mockCtrl, _, _, api := newCNAPIBackend(t)
+	defer mockCtrl.Finish()
+
+	api.cn.config.RPCStateReexecLimit = 128
+	reexec, limited := api.limitReexec(64)
+	assert.Equal(t, uint64(64), reexec)
+	assert.False(t, limited)
+
+	reexec, limited = api.limitReexec(1024)
+	assert.Equal(t, uint64(128), reexec)
+	assert.True(t, limited)
+}
+
+func TestCNAPIBackend_StateAtBlock(t *testing.T) {
+	mockCtrl, mockBlockChain, _, api := newCNAPIBackend(t)
+	defer mockCtrl.Finish()
+
+	blockNum := uint64(123)
+	db := database.NewMemoryDBManager()
+	api.cn.chainDB = db
+	api.cn.config.RPCStateReexecLimit = 1
+
+	// The reexec of the tracing request is lowered to the limit, which is reported on failure.
+	parent := types.NewBlockWithHeader(&types.Header{Number: big.NewInt(int64(blockNum - 1)), Root: common.HexToHash("0x1"), BlockScore: big.NewInt(1)})
+	block := types.NewBlockWithHeader(&types.Header{Number: big.NewInt(int64(blockNum)), ParentHash: parent.Hash(), Root: common.HexToHash("0x2"), BlockScore: big.NewInt(1)})
+	mockBlockChain.EXPECT().StateCache().Return(state.NewDatabase(db)).Times(1)
+	mockBlockChain.EXPECT().GetBlock(parent.Hash(), blockNum-1).Return(parent).Times(1)
+	returnedStateDB, err := api.StateAtBlock(context.Background(), block, 128, nil, false, false)
+
+	assert.Nil(t, returnedStateDB)
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "not regenerated within the limit of 1 blocks")
+}
+
+func TestCNAPIBackend_PendingStateAndHeader(t *testing.T) {
+	mockCtrl, mockBlockChain, mockMiner, api := newCNAPIBackend(t)
+	defer mockCtrl.Finish()
+	mockTxPool := mocks.NewMockTxPool(mockCtrl)
+	api.cn.txPool = mockTxPool
+
+	parent := types.NewBlockWithHeader(&types.Header{Number: big.NewInt(123), Time: big.NewInt(1000), BlockScore: common.Big1})
+	stateDB, err := state.New(common.Hash{}, state.NewDatabase(database.NewMemoryDBManager()), nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	config := params.TestChainConfig
+	txSigner := types.MakeSigner(config, parent.Number())
+	newTx := func(key int, nonce uint64) *types.Transaction {
+		tx, err := types.SignTx(types.NewTransaction(nonce, addrs[5], big.NewInt(1), 21000, big.NewInt(1), nil), txSigner, keys[key])
+		if err != nil {
+			t.Fatal(err)
+		}
+		return tx
+	}
+	// The transaction of addrs[1] with nonce 0 fails, so the following one is skipped too.
+	applied := types.Transactions{newTx(0, 0), newTx(0, 1)}
+	failed := types.Transactions{newTx(1, 0), newTx(1, 1)}
+	pending := map[common.Address]types.Transactions{addrs[0]: applied, addrs[1]: failed}
+
+	mockMiner.EXPECT().Mining().Return(false).Times(2)
+	mockBlockChain.EXPECT().CurrentBlock().Return(parent).Times(2)
+	mockBlockChain.EXPECT().StateAt(parent.Root()).Return(stateDB, nil).Times(1)
+	mockBlockChain.EXPECT().Config().Return(config).Times(1)
+	mockTxPool.EXPECT().Pending().Return(pending, nil).Times(1)
+	mockBlockChain.EXPECT().ApplyTransaction(config, gomock.Any(), stateDB, gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).DoAndReturn(
+		func(_ *params.ChainConfig, _ *common.Address, st *state.StateDB, header *types.Header, tx *types.Transaction, usedGas *uint64, _ *vm.Config) (*types.Receipt, *vm.InternalTxTrace, error) {
+			assert.Equal(t, uint64(124), header.Number.Uint64())
+			if tx == failed[0] {
+				st.SetNonce(addrs[1], 1)
+				return nil, nil, blockchain.ErrNonceTooHigh
+			}
+			assert.NotEqual(t, failed[1], tx)
+			st.SetNonce(addrs[0], tx.Nonce()+1)
+			*usedGas += tx.Gas()
+			return types.NewReceipt(types.ReceiptStatusSuccessful, tx.Hash(), tx.Gas()), nil, nil
+		}).Times(3)
+
+	returnedStateDB, header, err := api.StateAndHeaderByNumber(context.Background(), rpc.PendingBlockNumber)
+	assert.NoError(t, err)
+	assert.Equal(t, uint64(124), header.Number.Uint64())
+	assert.Equal(t, parent.Hash(), header.ParentHash)
+	assert.Equal(t, uint64(2*21000), header.GasUsed)
+	assert.Equal(t, uint64(2), returnedStateDB.GetNonce(addrs[0]))
+	assert.Equal(t, uint64(0), returnedStateDB.GetNonce(addrs[1]))
+
+	// The pending state is reused on top of the same head.
+	returnedStateDB.SetNonce(addrs[0], 100)
+	returnedStateDB, cachedHeader, err := api.StateAndHeaderByNumber(context.Background(), rpc.PendingBlockNumber)
+	assert.NoError(t, err)
+	assert.Equal(t, header, cachedHeader)
+	assert.Equal(t, uint64(2), returnedStateDB.GetNonce(addrs[0]))
+}
+
+func TestCNAPIBackend_RequireCanonical(t *testing.T) {
+	blockNum := uint64(123)
+	block := newBlock(int(blockNum))
+	sideBlock := newBlockWithParentHash(int(blockNum), hash1)
+
+	stateDB, err := state.New(common.Hash{}, state.NewDatabase(database.NewMemoryDBManager()), nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	{
+		// The canonical block is returned.
+		mockCtrl, mockBlockChain, _, api := newCNAPIBackend(t)
+		mockBlockChain.EXPECT().GetHeaderByHash(block.Hash()).Return(block.Header()).Times(2)
+		mockBlockChain.EXPECT().GetBlockByHash(block.Hash()).Return(block).Times(1)
+		mockBlockChain.EXPECT().GetHeaderByNumber(blockNum).Return(block.Header()).Times(3)
+		mockBlockChain.EXPECT().StateAt(block.Root()).Return(stateDB, nil).Times(1)
+		blockNrOrHash := rpc.NewBlockNumberOrHashWithHash(block.Hash(), true)
+
+		header, err := api.HeaderByNumberOrHash(context.Background(), blockNrOrHash)
+		assert.Equal(t, block.Header(), header)
+		assert.NoError(t, err)
+
+		returnedBlock, err := api.BlockByNumberOrHash(context.Background(), blockNrOrHash)
+		assert.Equal(t, block, returnedBlock)
+		assert.NoError(t, err)
+
+		returnedStateDB, header, err := api.StateAndHeaderByNumberOrHash(context.Background(), blockNrOrHash)
+		assert.Equal(t, stateDB, returnedStateDB)
+		assert.Equal(t, block.Header(), header)
+		assert.NoError(t, err)
+
+		mockCtrl.Finish()
+	}
+	{
+		// The block which is not in the canonical chain is rejected.
+		mockCtrl, mockBlockChain, _, api := newCNAPIBackend(t)
+		mockBlockChain.EXPECT().GetHeaderByHash(sideBlock.Hash()).Return(sideBlock.Header()).Times(2)
+		mockBlockChain.EXPECT().GetBlockByHash(sideBlock.Hash()).Return(sideBlock).Times(1)
+		mockBlockChain.EXPECT().GetHeaderByNumber(blockNum).Return(block.Header()).Times(3)
+		blockNrOrHash := rpc.NewBlockNumberOrHashWithHash(sideBlock.Hash(), true)
+
+		header, err := api.HeaderByNumberOrHash(context.Background(), blockNrOrHash)
+		assert.Nil(t, header)
+		assert.Equal(t, errNotCanonical, err)
+
+		returnedBlock, err := api.BlockByNumberOrHash(context.Background(), blockNrOrHash)
+		assert.Nil(t, returnedBlock)
+		assert.Equal(t, errNotCanonical, err)
+
+		returnedStateDB, header, err := api.StateAndHeaderByNumberOrHash(context.Background(), blockNrOrHash)
+		assert.Nil(t, returnedStateDB)
+		assert.Nil(t, header)
+		assert.Equal(t, errNotCanonical, err)
+
+		mockCtrl.Finish()
+	}
+	{
+		// The block which is not in the canonical chain is returned if requireCanonical is not set.
+		mockCtrl, mockBlockChain, _, api := newCNAPIBackend(t)
+		mockBlockChain.EXPECT().GetHeaderByHash(sideBlock.Hash()).Return(sideBlock.Header()).Times(1)
+
+		header, err := api.HeaderByNumberOrHash(context.Background(), rpc.NewBlockNumberOrHashWithHash(sideBlock.Hash(), false))
+		assert.Equal(t, sideBlock.Header(), header)
+		assert.NoError(t, err)
+
 		mockCtrl.Finish()
 	}
 }
@@ -555,6 +760,10 @@ func TestCNAPIBackend_SendTx(t *testing.T) {
 	defer mockCtrl.Finish()
 
 	assert.Equal(t, expectedErr, api.SendTx(context.Background(), tx1))
+
+	// A read replica does not accept transactions.
+	api.cn.config.ReadReplica = true
+	assert.Equal(t, errReadReplica, api.SendTx(context.Background(), tx1))
 }
 
 func TestCNAPIBackend_GetPoolTransactions(t *testing.T) {
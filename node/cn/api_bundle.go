@@ -0,0 +1,89 @@
+// Copyright 2022 The klaytn Authors
+// This file is part of the klaytn library.
+//
+// The klaytn library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The klaytn library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the klaytn library. If not, see <http://www.gnu.org/licenses/>.
+
+package cn
+
+import (
+	"fmt"
+
+	"github.com/klaytn/klaytn/blockchain/types"
+	"github.com/klaytn/klaytn/common"
+	"github.com/klaytn/klaytn/common/hexutil"
+	"github.com/klaytn/klaytn/rlp"
+	"github.com/klaytn/klaytn/work"
+)
+
+// PrivateBundleAPI provides an API to submit the bundles of transactions to the block proposer.
+// A bundle is included contiguously at the top of a block proposed by this node, or not at all.
+type PrivateBundleAPI struct {
+	cn *CN
+}
+
+// NewPrivateBundleAPI creates a new bundle API for consensus nodes.
+func NewPrivateBundleAPI(cn *CN) *PrivateBundleAPI {
+	return &PrivateBundleAPI{cn}
+}
+
+// BundleArgs represents the arguments to submit a bundle.
+type BundleArgs struct {
+	Txs            []hexutil.Bytes `json:"txs"`            // RLP-encoded signed transactions in order
+	MaxBlockNumber hexutil.Uint64  `json:"maxBlockNumber"` // last block to include the bundle, optional
+}
+
+// RPCBundle represents a bundle waiting for inclusion.
+type RPCBundle struct {
+	Hash           common.Hash    `json:"hash"`
+	Txs            []common.Hash  `json:"txs"`
+	MaxBlockNumber hexutil.Uint64 `json:"maxBlockNumber"`
+}
+
+// Send submits the transactions as a bundle, and returns the hash of the bundle.
+func (api *PrivateBundleAPI) Send(args BundleArgs) (common.Hash, error) {
+	bundle := &work.Bundle{
+		Txs:            make(types.Transactions, len(args.Txs)),
+		MaxBlockNumber: uint64(args.MaxBlockNumber),
+	}
+	for i, encoded := range args.Txs {
+		tx := new(types.Transaction)
+		if err := rlp.DecodeBytes(encoded, tx); err != nil {
+			return common.Hash{}, fmt.Errorf("invalid transaction at %d: %v", i, err)
+		}
+		bundle.Txs[i] = tx
+	}
+	if err := api.cn.miner.AddBundle(bundle); err != nil {
+		return common.Hash{}, err
+	}
+	return bundle.Hash(), nil
+}
+
+// Cancel cancels the bundle of the given hash, returning whether the bundle was waiting for inclusion.
+func (api *PrivateBundleAPI) Cancel(hash common.Hash) bool {
+	return api.cn.miner.RemoveBundle(hash)
+}
+
+// Pending returns the bundles waiting for inclusion.
+func (api *PrivateBundleAPI) Pending() []*RPCBundle {
+	bundles := api.cn.miner.Bundles()
+	result := make([]*RPCBundle, len(bundles))
+	for i, bundle := range bundles {
+		txs := make([]common.Hash, len(bundle.Txs))
+		for j, tx := range bundle.Txs {
+			txs[j] = tx.Hash()
+		}
+		result[i] = &RPCBundle{Hash: bundle.Hash(), Txs: txs, MaxBlockNumber: hexutil.Uint64(bundle.MaxBlockNumber)}
+	}
+	return result
+}
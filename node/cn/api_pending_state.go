@@ -0,0 +1,117 @@
+// Copyright 2022 The klaytn Authors
+// This file is part of the klaytn library.
+//
+// The klaytn library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The klaytn library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the klaytn library. If not, see <http://www.gnu.org/licenses/>.
+
+package cn
+
+import (
+	"context"
+	"math/big"
+	"sync"
+	"time"
+
+	"github.com/klaytn/klaytn/blockchain/state"
+	"github.com/klaytn/klaytn/blockchain/types"
+	"github.com/klaytn/klaytn/blockchain/vm"
+	"github.com/klaytn/klaytn/common"
+	"github.com/klaytn/klaytn/consensus/misc"
+	"github.com/klaytn/klaytn/params"
+)
+
+const (
+	// maxPendingStateTxs is the maximum number of pending transactions applied to the pending state.
+	maxPendingStateTxs = 1000
+
+	// pendingStateLifetime is how long a built pending state is reused on top of the same head.
+	pendingStateLifetime = time.Second
+)
+
+// pendingStateCache keeps the last pending state built by pendingStateAndHeader.
+type pendingStateCache struct {
+	mu      sync.Mutex
+	head    common.Hash
+	builtAt time.Time
+	state   *state.StateDB
+	header  *types.Header
+}
+
+// pendingStateAndHeader returns the state and the header of a hypothetical next block, which has
+// the pending transactions of the tx pool applied on top of the latest block. It is used by the nodes
+// not mining blocks, whose miner does not execute the pending transactions.
+//
+// The transactions are ordered by time and nonce and applied like a proposer does, so the same pool
+// always results in the same state. A transaction failing to be applied is skipped together with
+// the following transactions of the same sender. The block is not finalized, so the block rewards
+// are not included in the state.
+func (b *CNAPIBackend) pendingStateAndHeader(ctx context.Context) (*state.StateDB, *types.Header, error) {
+	bc := b.cn.BlockChain()
+	parent := bc.CurrentBlock()
+
+	cache := &b.pending
+	cache.mu.Lock()
+	defer cache.mu.Unlock()
+	if cache.state != nil && cache.head == parent.Hash() && time.Since(cache.builtAt) < pendingStateLifetime {
+		return cache.state.Copy(), types.CopyHeader(cache.header), nil
+	}
+
+	statedb, err := bc.StateAt(parent.Root())
+	if err != nil {
+		return nil, nil, err
+	}
+	header := &types.Header{
+		ParentHash: parent.Hash(),
+		Number:     new(big.Int).Add(parent.Number(), common.Big1),
+		Rewardbase: b.cn.rewardbase,
+		BlockScore: common.Big1,
+		Time:       new(big.Int).Add(parent.Time(), big.NewInt(params.BlockGenerationInterval)),
+	}
+	config := bc.Config()
+	pending, err := b.cn.TxPool().Pending()
+	if err != nil {
+		return nil, nil, err
+	}
+	if config.IsMagmaForkEnabled(header.Number) {
+		header.BaseFee = misc.NextMagmaBlockBaseFee(parent.Header(), config.Governance.KIP71)
+		pending = types.FilterTransactionWithBaseFee(pending, header.BaseFee)
+	}
+
+	var (
+		txs      = types.NewTransactionsByTimeAndNonce(types.MakeSigner(config, header.Number), pending)
+		vmConfig = vm.Config{UseOpcodeComputationCost: true}
+		applied  int
+	)
+	for tx := txs.Peek(); tx != nil && applied < maxPendingStateTxs; tx = txs.Peek() {
+		if err := ctx.Err(); err != nil {
+			return nil, nil, err
+		}
+		snapshot := statedb.Snapshot()
+		statedb.Prepare(tx.Hash(), common.Hash{}, applied)
+		if _, _, err := bc.ApplyTransaction(config, &header.Rewardbase, statedb, header, tx, &header.GasUsed, &vmConfig); err != nil {
+			// The following transactions of the sender cannot be applied without this one.
+			statedb.RevertToSnapshot(snapshot)
+			txs.Pop()
+			continue
+		}
+		applied++
+		txs.Shift()
+	}
+	statedb.Finalise(true, true)
+
+	cache.head = parent.Hash()
+	cache.builtAt = time.Now()
+	cache.state = statedb
+	cache.header = header
+	return statedb.Copy(), types.CopyHeader(header), nil
+}
@@ -0,0 +1,190 @@
+// Copyright 2022 The klaytn Authors
+// This file is part of the klaytn library.
+//
+// The klaytn library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The klaytn library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the klaytn library. If not, see <http://www.gnu.org/licenses/>.
+
+package cn
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math/big"
+	"time"
+
+	klaytnapi "github.com/klaytn/klaytn/api"
+	"github.com/klaytn/klaytn/blockchain/types"
+	"github.com/klaytn/klaytn/blockchain/vm"
+	"github.com/klaytn/klaytn/common"
+	"github.com/klaytn/klaytn/common/hexutil"
+	"github.com/klaytn/klaytn/consensus/misc"
+	"github.com/klaytn/klaytn/networks/rpc"
+	"github.com/klaytn/klaytn/params"
+)
+
+// maxSimulateBlockTxs is the maximum number of transactions in a block simulated by SimulateBlock.
+const maxSimulateBlockTxs = 1000
+
+var errEmptyBundle = errors.New("empty bundle: no transaction is given")
+
+// SimulateBlockOptions overrides the fields of a block simulated by SimulateBlock.
+type SimulateBlockOptions struct {
+	Timestamp  *hexutil.Uint64 `json:"timestamp"`  // defaults to the parent timestamp plus the block generation interval
+	Rewardbase *common.Address `json:"rewardbase"` // defaults to the rewardbase of this node
+}
+
+// SimulatedTxResult is the result of a transaction in a simulated block.
+// A transaction failing the validation is not included in the block, and has only the error.
+type SimulatedTxResult struct {
+	Hash    common.Hash            `json:"hash"`
+	Receipt map[string]interface{} `json:"receipt,omitempty"`
+	Error   string                 `json:"error,omitempty"`
+}
+
+// SimulatedBlockResult is the result of SimulateBlock.
+type SimulatedBlockResult struct {
+	Number           hexutil.Uint64       `json:"number"`
+	Hash             common.Hash          `json:"hash"`
+	ParentHash       common.Hash          `json:"parentHash"`
+	Timestamp        hexutil.Uint64       `json:"timestamp"`
+	Rewardbase       common.Address       `json:"rewardbase"`
+	BaseFeePerGas    *hexutil.Big         `json:"baseFeePerGas,omitempty"`
+	GasUsed          hexutil.Uint64       `json:"gasUsed"`
+	StateRoot        common.Hash          `json:"stateRoot"`
+	TransactionsRoot common.Hash          `json:"transactionsRoot"`
+	ReceiptsRoot     common.Hash          `json:"receiptsRoot"`
+	LogsBloom        types.Bloom          `json:"logsBloom"`
+	Transactions     []*SimulatedTxResult `json:"transactions"`
+}
+
+// SimulateBlock executes the given ordered bundle of signed raw transactions as a hypothetical next block
+// on top of the given parent block, which defaults to the latest block. The transactions are validated
+// and applied as a proposer does, and the block is finalized by the consensus engine with its rewards.
+// It returns the receipts, the gas used and the roots of the block. Nothing is written to the chain.
+func (api *PublicDebugAPI) SimulateBlock(ctx context.Context, rawTxs []hexutil.Bytes, parentNrOrHash *rpc.BlockNumberOrHash, opts *SimulateBlockOptions) (*SimulatedBlockResult, error) {
+	if len(rawTxs) == 0 {
+		return nil, errEmptyBundle
+	}
+	if len(rawTxs) > maxSimulateBlockTxs {
+		return nil, fmt.Errorf("too many transactions (have %d, max %d)", len(rawTxs), maxSimulateBlockTxs)
+	}
+	txs := make([]*types.Transaction, len(rawTxs))
+	for i, raw := range rawTxs {
+		tx := new(types.Transaction)
+		if err := tx.UnmarshalBinary(raw); err != nil {
+			return nil, fmt.Errorf("transaction %d: %w", i, err)
+		}
+		txs[i] = tx
+	}
+
+	blockNrOrHash := rpc.NewBlockNumberOrHashWithNumber(rpc.LatestBlockNumber)
+	if parentNrOrHash != nil {
+		blockNrOrHash = *parentNrOrHash
+	}
+	parent, err := api.cn.APIBackend.BlockByNumberOrHash(ctx, blockNrOrHash)
+	if parent == nil || err != nil {
+		blockNrOrHashString, _ := blockNrOrHash.NumberOrHashString()
+		return nil, fmt.Errorf("block %v not found", blockNrOrHashString)
+	}
+	bc := api.cn.BlockChain()
+	statedb, err := bc.StateAt(parent.Root())
+	if err != nil {
+		return nil, err
+	}
+
+	header := &types.Header{
+		ParentHash: parent.Hash(),
+		Number:     new(big.Int).Add(parent.Number(), common.Big1),
+		Rewardbase: api.cn.rewardbase,
+		BlockScore: common.Big1,
+		Time:       new(big.Int).Add(parent.Time(), big.NewInt(params.BlockGenerationInterval)),
+	}
+	if opts != nil && opts.Timestamp != nil {
+		if uint64(*opts.Timestamp) <= parent.Time().Uint64() {
+			return nil, fmt.Errorf("block timestamp %d must be greater than the parent timestamp %v", uint64(*opts.Timestamp), parent.Time())
+		}
+		header.Time = new(big.Int).SetUint64(uint64(*opts.Timestamp))
+	}
+	if opts != nil && opts.Rewardbase != nil {
+		header.Rewardbase = *opts.Rewardbase
+	}
+	config := bc.Config()
+	if config.IsMagmaForkEnabled(header.Number) {
+		header.BaseFee = misc.NextMagmaBlockBaseFee(parent.Header(), config.Governance.KIP71)
+	}
+
+	var (
+		results  = make([]*SimulatedTxResult, len(txs))
+		included []*types.Transaction
+		receipts []*types.Receipt
+		vmConfig = vm.Config{UseOpcodeComputationCost: true}
+		deadline = time.Now().Add(params.BlockGenerationTimeLimit)
+	)
+	for i, tx := range txs {
+		if err := ctx.Err(); err != nil {
+			return nil, err
+		}
+		results[i] = &SimulatedTxResult{Hash: tx.Hash()}
+		if time.Now().After(deadline) {
+			results[i].Error = "block generation time limit exceeded"
+			continue
+		}
+		snapshot := statedb.Snapshot()
+		statedb.Prepare(tx.Hash(), common.Hash{}, len(included))
+		receipt, _, err := bc.ApplyTransaction(config, &header.Rewardbase, statedb, header, tx, &header.GasUsed, &vmConfig)
+		if err != nil {
+			// Like a proposer, the transaction failing the validation is left out of the block.
+			statedb.RevertToSnapshot(snapshot)
+			results[i].Error = err.Error()
+			continue
+		}
+		included = append(included, tx)
+		receipts = append(receipts, receipt)
+	}
+
+	block, err := api.cn.engine.Finalize(bc, header, statedb, included, receipts)
+	if err != nil {
+		return nil, err
+	}
+	header = block.Header()
+	result := &SimulatedBlockResult{
+		Number:           hexutil.Uint64(block.NumberU64()),
+		Hash:             block.Hash(),
+		ParentHash:       header.ParentHash,
+		Timestamp:        hexutil.Uint64(header.Time.Uint64()),
+		Rewardbase:       header.Rewardbase,
+		GasUsed:          hexutil.Uint64(header.GasUsed),
+		StateRoot:        header.Root,
+		TransactionsRoot: header.TxHash,
+		ReceiptsRoot:     header.ReceiptHash,
+		LogsBloom:        header.Bloom,
+		Transactions:     results,
+	}
+	if header.BaseFee != nil {
+		result.BaseFeePerGas = (*hexutil.Big)(header.BaseFee)
+	}
+	index := 0
+	for _, res := range results {
+		if res.Error != "" {
+			continue
+		}
+		receipt := receipts[index]
+		for _, log := range receipt.Logs {
+			log.BlockHash = block.Hash()
+		}
+		res.Receipt = klaytnapi.RpcOutputReceipt(header, included[index], block.Hash(), block.NumberU64(), uint64(index), receipt)
+		index++
+	}
+	return result, nil
+}
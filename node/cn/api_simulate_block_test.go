@@ -0,0 +1,39 @@
+// Copyright 2022 The klaytn Authors
+// This file is part of the klaytn library.
+//
+// The klaytn library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The klaytn library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the klaytn library. If not, see <http://www.gnu.org/licenses/>.
+
+package cn
+
+import (
+	"context"
+	"testing"
+
+	"github.com/klaytn/klaytn/common/hexutil"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestPublicDebugAPI_SimulateBlockBundle(t *testing.T) {
+	api := NewPublicDebugAPI(&CN{})
+	ctx := context.Background()
+
+	_, err := api.SimulateBlock(ctx, nil, nil, nil)
+	assert.Equal(t, errEmptyBundle, err)
+
+	_, err = api.SimulateBlock(ctx, make([]hexutil.Bytes, maxSimulateBlockTxs+1), nil, nil)
+	assert.Error(t, err)
+
+	_, err = api.SimulateBlock(ctx, []hexutil.Bytes{{0x01, 0x02}}, nil, nil)
+	assert.Error(t, err)
+}
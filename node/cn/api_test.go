@@ -20,13 +20,22 @@
 package cn
 
 import (
+	"context"
+	"math/big"
 	"reflect"
 	"testing"
 
 	"github.com/davecgh/go-spew/spew"
+	"github.com/klaytn/klaytn/blockchain"
 	"github.com/klaytn/klaytn/blockchain/state"
+	"github.com/klaytn/klaytn/blockchain/types"
 	"github.com/klaytn/klaytn/common"
+	"github.com/klaytn/klaytn/common/hexutil"
+	"github.com/klaytn/klaytn/networks/rpc"
+	"github.com/klaytn/klaytn/params"
 	"github.com/klaytn/klaytn/storage/database"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 )
 
 var dumper = spew.ConfigState{Indent: "    "}
@@ -91,3 +100,105 @@ func TestStorageRangeAt(t *testing.T) {
 		}
 	}
 }
+
+func TestPublicDebugAPI_VerifyBlockRoots(t *testing.T) {
+	blockchain.InitDeriveSha(params.TestChainConfig)
+
+	// The block is made of two transactions, and the first one emits a log.
+	txs := types.Transactions{
+		types.NewTransaction(0, addrs[0], big.NewInt(1), 21000, big.NewInt(1), nil),
+		types.NewTransaction(1, addrs[1], big.NewInt(1), 21000, big.NewInt(1), nil),
+	}
+	receipts := types.Receipts{
+		types.NewReceipt(types.ReceiptStatusSuccessful, txs[0].Hash(), 30000),
+		types.NewReceipt(types.ReceiptStatusSuccessful, txs[1].Hash(), 21000),
+	}
+	receipts[0].Logs = []*types.Log{{Address: addrs[0], Topics: []common.Hash{{0x01}}}}
+	receipts[0].Bloom = types.CreateBloom(receipts[:1])
+	block := types.NewBlock(&types.Header{Number: big.NewInt(1), BlockScore: big.NewInt(1), GasUsed: 51000}, txs, receipts)
+	header := block.Header()
+
+	// tamperReceipt returns a copy of the receipts with the receipt at the given index modified.
+	tamperReceipt := func(index int, modify func(*types.Receipt)) types.Receipts {
+		tampered := make(types.Receipts, len(receipts))
+		copy(tampered, receipts)
+		receipt := *receipts[index]
+		modify(&receipt)
+		tampered[index] = &receipt
+		return tampered
+	}
+	mismatchedFields := func(result *VerifyBlockRootsResult) []string {
+		fields := make([]string, len(result.Mismatches))
+		for i, m := range result.Mismatches {
+			fields[i] = m.Field
+		}
+		return fields
+	}
+
+	testCases := []struct {
+		name     string
+		block    *types.Block
+		receipts types.Receipts
+		fields   []string
+	}{
+		{"valid", block, receipts, []string{}},
+		{
+			"tampered gas used of receipt", block,
+			tamperReceipt(1, func(r *types.Receipt) { r.GasUsed = 20000 }),
+			[]string{"receiptsRoot", "gasUsed"},
+		},
+		{
+			"tampered logs of receipt", block,
+			tamperReceipt(0, func(r *types.Receipt) { r.Logs, r.Bloom = nil, types.Bloom{} }),
+			[]string{"receiptsRoot", "logsBloom"},
+		},
+		{
+			"tampered body", types.NewBlockWithHeader(header).WithBody(types.Transactions{txs[1], txs[0]}), receipts,
+			[]string{"transactionsRoot"},
+		},
+		{"missing receipt", block, receipts[:1], []string{"receipts"}},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			mockCtrl, mockBlockChain, _, backend := newCNAPIBackend(t)
+			defer mockCtrl.Finish()
+			backend.cn.APIBackend = backend
+			api := NewPublicDebugAPI(backend.cn)
+
+			hash := tc.block.Hash()
+			mockBlockChain.EXPECT().GetBlockByHash(hash).Return(tc.block).Times(1)
+			mockBlockChain.EXPECT().GetReceiptsByBlockHash(hash).Return(tc.receipts).Times(1)
+
+			result, err := api.VerifyBlockRoots(context.Background(), rpc.NewBlockNumberOrHashWithHash(hash, false))
+			require.NoError(t, err)
+			assert.Equal(t, hexutil.Uint64(1), result.Number)
+			assert.Equal(t, hash, result.Hash)
+			assert.Equal(t, len(tc.fields) == 0, result.Valid)
+			assert.Equal(t, tc.fields, mismatchedFields(result))
+		})
+	}
+
+	// The mismatched roots are reported with the values in the header and the recomputed values.
+	// The bloom still matches, as the dropped transaction does not emit any log.
+	mockCtrl, mockBlockChain, _, backend := newCNAPIBackend(t)
+	defer mockCtrl.Finish()
+	backend.cn.APIBackend = backend
+	api := NewPublicDebugAPI(backend.cn)
+
+	tampered := types.NewBlockWithHeader(header).WithBody(txs[:1])
+	mockBlockChain.EXPECT().GetBlockByHash(tampered.Hash()).Return(tampered).Times(1)
+	mockBlockChain.EXPECT().GetReceiptsByBlockHash(tampered.Hash()).Return(receipts[:1]).Times(1)
+
+	result, err := api.VerifyBlockRoots(context.Background(), rpc.NewBlockNumberOrHashWithHash(tampered.Hash(), false))
+	require.NoError(t, err)
+	assert.False(t, result.Valid)
+	require.Len(t, result.Mismatches, 3)
+	assert.Equal(t, BlockRootMismatch{"transactionsRoot", header.TxHash.Hex(), types.DeriveSha(txs[:1], header.Number).Hex()}, result.Mismatches[0])
+	assert.Equal(t, BlockRootMismatch{"receiptsRoot", header.ReceiptHash.Hex(), types.DeriveSha(receipts[:1], header.Number).Hex()}, result.Mismatches[1])
+	assert.Equal(t, BlockRootMismatch{"gasUsed", "51000", "30000"}, result.Mismatches[2])
+
+	// A missing block is reported as an error.
+	mockBlockChain.EXPECT().GetBlockByHash(common.Hash{}).Return(nil).Times(1)
+	_, err = api.VerifyBlockRoots(context.Background(), rpc.NewBlockNumberOrHashWithHash(common.Hash{}, false))
+	assert.Error(t, err)
+}
@@ -31,11 +31,13 @@ import (
 
 	"github.com/klaytn/klaytn"
 	"github.com/klaytn/klaytn/accounts"
+	"github.com/klaytn/klaytn/accounts/watchonly"
 	"github.com/klaytn/klaytn/api"
 	"github.com/klaytn/klaytn/blockchain"
 	"github.com/klaytn/klaytn/blockchain/bloombits"
 	"github.com/klaytn/klaytn/blockchain/state"
 	"github.com/klaytn/klaytn/blockchain/types"
+	"github.com/klaytn/klaytn/blockchain/vm"
 	"github.com/klaytn/klaytn/common"
 	"github.com/klaytn/klaytn/common/hexutil"
 	"github.com/klaytn/klaytn/consensus"
@@ -58,7 +60,19 @@ import (
 	"github.com/klaytn/klaytn/work"
 )
 
-var errCNLightSync = errors.New("can't run cn.CN in light sync mode")
+var (
+	errReadReplica = errors.New("transactions cannot be sent to a read replica")
+
+	errCheckpointSyncMode = errors.New("a trusted checkpoint is used only in fast or snap sync mode")
+
+	errMissingInternalTxTraces = errors.New("internal tx traces of the block are missing")
+)
+
+// readReplicaFollowInterval is the interval of a read replica polling the head block of the primary node.
+const readReplicaFollowInterval = time.Second
+
+// lightFollowInterval is the interval of a light node moving its current block to its current header.
+const lightFollowInterval = time.Second
 
 //go:generate mockgen -destination=node/cn/mocks/lesserver_mock.go -package=mocks github.com/klaytn/klaytn/node/cn LesServer
 type LesServer interface {
@@ -80,6 +94,9 @@ type Miner interface {
 	SetExtra(extra []byte) error
 	Pending() (*types.Block, *state.StateDB)
 	PendingBlock() *types.Block
+	AddBundle(bundle *work.Bundle) error
+	RemoveBundle(hash common.Hash) bool
+	Bundles() []*work.Bundle
 }
 
 // BackendProtocolManager is an interface of cn.ProtocolManager used from cn.CN and cn.ServiceChain.
@@ -98,6 +115,7 @@ type BackendProtocolManager interface {
 	Start(maxPeers int)
 	Stop()
 	SetSyncStop(flag bool)
+	NodeLag() *NodeLag
 }
 
 // CN implements the Klaytn consensus node service.
@@ -122,7 +140,15 @@ type CN struct {
 	bloomIndexer      *blockchain.ChainIndexer       // Bloom indexer operating during block imports
 	closeBloomHandler chan struct{}
 
+	closeWatchOnlyNotifier chan struct{} // Channel closed to stop notifying the watch-only backend
+
+	logIndex       filters.LogIndex        // External log index serving getLogs if configured
+	logIndexSyncer *filters.LogIndexSyncer // Syncer keeping logIndex up to date
+
+	ethBlockPrecomputer *api.EthBlockPrecomputer // Precomputer of the Ethereum-format blocks if configured
+
 	APIBackend *CNAPIBackend
+	tracerAPI  *tracers.API // Tracer of the gRPC block stream
 
 	miner    Miner
 	gasPrice *big.Int
@@ -181,16 +207,345 @@ func senderTxHashIndexer(db database.DBManager, chainEvent <-chan blockchain.Cha
 	}
 }
 
+// feeStatsIndexer stores the fee statistics of the imported blocks,
+// which are read by the gas price oracle instead of the bodies and the receipts.
+// The receipts are read from the database if the chain event does not carry them,
+// as the events of the blocks mined by the node and the KES events have no receipts.
+func feeStatsIndexer(db database.DBManager, config *params.ChainConfig, chainEvent <-chan blockchain.ChainEvent, subscription event.Subscription) {
+	defer subscription.Unsubscribe()
+
+	for {
+		select {
+		case event := <-chainEvent:
+			receipts := event.Receipts
+			if len(receipts) != len(event.Block.Transactions()) {
+				receipts = db.ReadReceipts(event.Block.Hash(), event.Block.NumberU64())
+			}
+			if len(receipts) != len(event.Block.Transactions()) {
+				logger.Error("Failed to store fee statistics due to the missing receipts", "blockNum", event.Block.NumberU64(),
+					"txs", len(event.Block.Transactions()), "receipts", len(receipts))
+				continue
+			}
+			stats := gasprice.NewFeeStats(config, event.Block, receipts)
+			if err := gasprice.WriteFeeStats(db, event.Block.NumberU64(), stats); err != nil {
+				logger.Error("Failed to store fee statistics", "blockNum", event.Block.NumberU64(), "err", err)
+			}
+
+		case <-subscription.Err():
+			return
+		}
+	}
+}
+
+// chainIndex is an index of the canonical blocks which runChainIndexer builds from the chain events.
+// The index covers the blocks from its tail to its head, which is stored with the index of each block.
+type chainIndex struct {
+	name      string
+	readTail  func() (uint64, bool)
+	writeTail func(blockNum uint64) error
+	readHead  func() (uint64, bool)
+	putHead   func(batch database.Batch, blockNum uint64) error
+	newBatch  func() database.Batch
+	put       func(batch database.Batch, event blockchain.ChainEvent) error // logs the failure in detail
+}
+
+// runChainIndexer stores the index of the block of each chain event until the subscription is closed.
+// The tail is moved to the block if the index has not been written or the blocks before it have
+// not been indexed, as the indexing was disabled for a while or failed to store a block.
+func runChainIndexer(index *chainIndex, chainEvent <-chan blockchain.ChainEvent, subscription event.Subscription) {
+	defer subscription.Unsubscribe()
+
+	for {
+		select {
+		case event := <-chainEvent:
+			blockNum := event.Block.NumberU64()
+			_, hasTail := index.readTail()
+			if head, ok := index.readHead(); !hasTail || !ok || blockNum > head+1 {
+				if hasTail {
+					logger.Warn("Restart index after unindexed blocks", "index", index.name, "head", head, "blockNum", blockNum)
+				}
+				if err := index.writeTail(blockNum); err != nil {
+					logger.Error("Failed to store the tail of index", "index", index.name, "blockNum", blockNum, "err", err)
+					continue
+				}
+			}
+
+			batch := index.newBatch()
+			if err := index.put(batch, event); err != nil {
+				continue
+			}
+			if err := index.putHead(batch, blockNum); err != nil {
+				logger.Error("Failed to store the head of index", "index", index.name, "blockNum", blockNum, "err", err)
+				continue
+			}
+			if err := batch.Write(); err != nil {
+				logger.Error("Failed to write index to database", "index", index.name, "blockNum", blockNum, "err", err)
+			}
+
+		case <-subscription.Err():
+			return
+		}
+	}
+}
+
+// accountTxIndexer stores the positions of the transactions by their senders, recipients and fee payers.
+func accountTxIndexer(db database.DBManager, signer types.Signer, chainEvent <-chan blockchain.ChainEvent, subscription event.Subscription) {
+	runChainIndexer(&chainIndex{
+		name:      "account tx",
+		readTail:  db.ReadAccountTxIndexTail,
+		writeTail: db.WriteAccountTxIndexTail,
+		readHead:  db.ReadAccountTxIndexHead,
+		putHead:   db.PutAccountTxIndexHeadToBatch,
+		newBatch:  db.NewAccountTxIndexBatch,
+		put: func(batch database.Batch, event blockchain.ChainEvent) error {
+			blockNum := event.Block.NumberU64()
+			for i, tx := range event.Block.Transactions() {
+				for _, addr := range txAccounts(signer, tx) {
+					if err := db.PutAccountTxIndexToBatch(batch, addr, blockNum, uint64(i), tx.Hash()); err != nil {
+						logger.Error("Failed to store account tx index to database",
+							"blockNum", blockNum, "address", addr, "txHash", tx.Hash(), "err", err)
+						return err
+					}
+				}
+			}
+			return nil
+		},
+	}, chainEvent, subscription)
+}
+
+// internalTxIndexer stores the internal transactions collected while processing blocks,
+// and the positions of their transactions by the accounts of the internal transactions.
+func internalTxIndexer(db database.DBManager, chainEvent <-chan blockchain.ChainEvent, subscription event.Subscription) {
+	runChainIndexer(&chainIndex{
+		name:      "internal tx",
+		readTail:  db.ReadInternalTxIndexTail,
+		writeTail: db.WriteInternalTxIndexTail,
+		readHead:  db.ReadInternalTxIndexHead,
+		putHead:   db.PutInternalTxIndexHeadToBatch,
+		newBatch:  db.NewInternalTxIndexBatch,
+		put: func(batch database.Batch, event blockchain.ChainEvent) error {
+			blockNum := event.Block.NumberU64()
+			txs := event.Block.Transactions()
+			if len(event.InternalTxTraces) != len(txs) {
+				logger.Error("Failed to index internal txs due to the missing traces",
+					"blockNum", blockNum, "txs", len(txs), "traces", len(event.InternalTxTraces))
+				return errMissingInternalTxTraces
+			}
+			for i, trace := range event.InternalTxTraces {
+				if err := putInternalTxsToBatch(db, batch, blockNum, uint64(i), txs[i].Hash(), trace); err != nil {
+					logger.Error("Failed to store internal txs to database",
+						"blockNum", blockNum, "txHash", txs[i].Hash(), "err", err)
+					return err
+				}
+			}
+			return nil
+		},
+	}, chainEvent, subscription)
+}
+
+// tokenTransferIndexer stores the KIP-7 and KIP-17 token transfers by their senders and recipients,
+// and the tokens which the accounts have ever sent or received.
+func tokenTransferIndexer(db database.DBManager, chainEvent <-chan blockchain.ChainEvent, subscription event.Subscription) {
+	runChainIndexer(&chainIndex{
+		name:      "token transfer",
+		readTail:  db.ReadTokenTransferIndexTail,
+		writeTail: db.WriteTokenTransferIndexTail,
+		readHead:  db.ReadTokenTransferIndexHead,
+		putHead:   db.PutTokenTransferIndexHeadToBatch,
+		newBatch:  db.NewTokenTransferIndexBatch,
+		put: func(batch database.Batch, event blockchain.ChainEvent) error {
+			blockNum := event.Block.NumberU64()
+			for _, log := range event.Logs {
+				transfer, ok := api.ParseTokenTransfer(log)
+				if !ok {
+					continue
+				}
+				if err := putTokenTransferToBatch(db, batch, blockNum, transfer); err != nil {
+					logger.Error("Failed to store token transfer to database",
+						"blockNum", blockNum, "txHash", log.TxHash, "logIndex", log.Index, "err", err)
+					return err
+				}
+			}
+			return nil
+		},
+	}, chainEvent, subscription)
+}
+
+// watchOnlyNotifier notifies the watch-only backend of the transactions entering the transaction
+// pool and the transactions included in the new blocks with their receipts, until quit is closed.
+// The events are skipped without recovering their senders while no address is watched.
+func watchOnlyNotifier(backend *watchonly.Backend, signer types.Signer, txsCh <-chan blockchain.NewTxsEvent, txsSub event.Subscription,
+	chainEvent <-chan blockchain.ChainEvent, chainSub event.Subscription, quit <-chan struct{},
+) {
+	defer txsSub.Unsubscribe()
+	defer chainSub.Unsubscribe()
+
+	for {
+		select {
+		case ev := <-txsCh:
+			if backend.Len() == 0 {
+				continue
+			}
+			for _, tx := range ev.Txs {
+				backend.NotifyTx(tx, nil, txAccounts(signer, tx))
+			}
+
+		case ev := <-chainEvent:
+			if backend.Len() == 0 {
+				continue
+			}
+			for i, tx := range ev.Block.Transactions() {
+				var receipt *types.Receipt
+				if i < len(ev.Receipts) {
+					receipt = ev.Receipts[i]
+				}
+				backend.NotifyTx(tx, receipt, txAccounts(signer, tx))
+			}
+
+		case <-txsSub.Err():
+			return
+		case <-chainSub.Err():
+			return
+		case <-quit:
+			return
+		}
+	}
+}
+
+// putTokenTransferToBatch stores the token transfer for its sender and recipient.
+// The zero address is skipped since it is the sender of minting and the recipient of burning.
+func putTokenTransferToBatch(db database.DBManager, batch database.Batch, blockNum uint64, transfer *api.TokenTransfer) error {
+	data, err := rlp.EncodeToBytes(transfer)
+	if err != nil {
+		return err
+	}
+	accounts := []common.Address{transfer.From}
+	if transfer.To != transfer.From {
+		accounts = append(accounts, transfer.To)
+	}
+	for _, addr := range accounts {
+		if addr == (common.Address{}) {
+			continue
+		}
+		if err := db.PutTokenTransferToBatch(batch, addr, blockNum, transfer.LogIndex, data); err != nil {
+			return err
+		}
+		if err := db.PutTokenHoldingToBatch(batch, addr, transfer.Token, []byte(transfer.Standard)); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func putInternalTxsToBatch(db database.DBManager, batch database.Batch, blockNum, txIndex uint64, txHash common.Hash, trace *vm.InternalTxTrace) error {
+	internalTxs := vm.InternalTxsFromTrace(trace)
+	if len(internalTxs) == 0 {
+		return nil
+	}
+	data, err := rlp.EncodeToBytes(internalTxs)
+	if err != nil {
+		return err
+	}
+	if err := db.PutInternalTxsToBatch(batch, txHash, data); err != nil {
+		return err
+	}
+
+	indexed := make(map[common.Address]bool)
+	for _, internalTx := range internalTxs {
+		for _, addr := range []common.Address{internalTx.From, internalTx.To} {
+			if indexed[addr] {
+				continue
+			}
+			indexed[addr] = true
+			if err := db.PutInternalTxAccountIndexToBatch(batch, addr, blockNum, txIndex, txHash); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+// txAccounts returns the distinct sender, recipient and fee payer of the transaction.
+func txAccounts(signer types.Signer, tx *types.Transaction) []common.Address {
+	var from common.Address
+	if tx.IsEthereumTransaction() {
+		from, _ = types.Sender(signer, tx)
+	} else {
+		from, _ = tx.From()
+	}
+	accounts := []common.Address{from}
+	if to := tx.To(); to != nil && *to != from {
+		accounts = append(accounts, *to)
+	}
+	if tx.IsFeeDelegatedTransaction() {
+		if feePayer, err := tx.FeePayer(); err == nil && feePayer != from && (tx.To() == nil || feePayer != *tx.To()) {
+			accounts = append(accounts, feePayer)
+		}
+	}
+	return accounts
+}
+
 func checkSyncMode(config *Config) error {
 	if !config.SyncMode.IsValid() {
 		return fmt.Errorf("invalid sync mode %d", config.SyncMode)
 	}
-	if config.SyncMode == downloader.LightSync {
-		return errCNLightSync
+	return nil
+}
+
+// checkReadReplica rejects the options which cannot be used by a read replica. The replica only
+// reads the DynamoDB written by the primary node, and the options writing the database are rejected.
+func checkReadReplica(config *Config) error {
+	if !config.ReadReplica {
+		return nil
+	}
+	switch {
+	case config.DBType != database.DynamoDB:
+		return fmt.Errorf("read replica supports only %s, but %s is given", database.DynamoDB, config.DBType)
+	case config.SyncMode == downloader.LightSync:
+		return errors.New("read replica cannot run in light sync mode")
+	case config.StartBlockNumber != 0:
+		return errors.New("read replica cannot be started with the start block number")
+	case config.TxLookupLimit != 0:
+		return errors.New("read replica cannot unindex the transactions with the tx lookup limit")
+	case config.SnapshotCacheSize != 0:
+		return errors.New("read replica cannot generate the state snapshot")
+	case config.PeriodicMigrationInterval != 0:
+		return errors.New("read replica cannot migrate the state periodically")
+	case config.StateHistory:
+		return errors.New("read replica cannot record the state history")
 	}
 	return nil
 }
 
+// checkEthKlaytnTxMode validates the representation of Klaytn transactions in the eth namespace APIs,
+// which may be given by the TOML config without passing the command line flag check.
+// The legacy mode is used if it is not set.
+func checkEthKlaytnTxMode(config *Config) error {
+	if config.RPCEthKlaytnTxMode == "" {
+		config.RPCEthKlaytnTxMode = string(api.EthKlaytnTxModeLegacy)
+	}
+	if _, err := api.ParseEthKlaytnTxMode(config.RPCEthKlaytnTxMode); err != nil {
+		return fmt.Errorf("invalid RPCEthKlaytnTxMode: %w", err)
+	}
+	return nil
+}
+
+// loadTrustedCheckpoint loads the checkpoint manifest and verifies that it is signed by the trusted signers.
+func loadTrustedCheckpoint(config *Config) (*downloader.TrustedCheckpoint, error) {
+	if config.SyncMode != downloader.FastSync && config.SyncMode != downloader.SnapSync {
+		return nil, errCheckpointSyncMode
+	}
+	cp, err := downloader.LoadTrustedCheckpoint(config.TrustedCheckpoint)
+	if err != nil {
+		return nil, err
+	}
+	if err := cp.Verify(config.CheckpointSigners, config.CheckpointThreshold); err != nil {
+		return nil, err
+	}
+	logger.Info("Loaded the trusted checkpoint", "number", cp.Number, "hash", cp.Hash, "root", cp.StateRoot)
+	return cp, nil
+}
+
 func setEngineType(chainConfig *params.ChainConfig) {
 	if chainConfig.Clique != nil {
 		types.EngineType = types.Engine_Clique
@@ -206,13 +561,32 @@ func New(ctx *node.ServiceContext, config *Config) (*CN, error) {
 	if err := checkSyncMode(config); err != nil {
 		return nil, err
 	}
+	if err := checkEthKlaytnTxMode(config); err != nil {
+		return nil, err
+	}
+	if err := checkReadReplica(config); err != nil {
+		return nil, err
+	}
 
 	chainDB := CreateDB(ctx, config, "chaindata")
 
+	// A light node syncs only the headers, and retrieves the bodies, the receipts and the state
+	// from its peers when they are read from the database.
+	var retriever *lightRetriever
+	if config.SyncMode == downloader.LightSync {
+		retriever = newLightRetriever()
+		chainDB = newLightDatabase(chainDB, retriever)
+		// The announced blocks cannot be imported without the state of their parents.
+		config.FetcherDisable = true
+	}
+
 	chainConfig, genesisHash, genesisErr := blockchain.SetupGenesisBlock(chainDB, config.Genesis, config.NetworkId, config.IsPrivate, false)
 	if _, ok := genesisErr.(*params.ConfigCompatError); genesisErr != nil && !ok {
 		return nil, genesisErr
 	}
+	if genesisErr != nil && config.ReadReplica {
+		return nil, fmt.Errorf("read replica cannot rewind the chain to upgrade configuration: %w", genesisErr)
+	}
 
 	setEngineType(chainConfig)
 
@@ -238,6 +612,8 @@ func New(ctx *node.ServiceContext, config *Config) (*CN, error) {
 		bloomIndexer:      NewBloomIndexer(chainDB, params.BloomBitsBlocks),
 		closeBloomHandler: make(chan struct{}),
 		governance:        governance,
+
+		closeWatchOnlyNotifier: make(chan struct{}),
 	}
 
 	// istanbul BFT. Derive and set node's address using nodekey
@@ -256,8 +632,8 @@ func New(ctx *node.ServiceContext, config *Config) (*CN, error) {
 		vmConfig    = config.getVMConfig()
 		cacheConfig = &blockchain.CacheConfig{
 			ArchiveMode: config.NoPruning, CacheSize: config.TrieCacheSize,
-			BlockInterval: config.TrieBlockInterval, TriesInMemory: config.TriesInMemory,
-			TrieNodeCacheConfig: &config.TrieNodeCacheConfig, SenderTxHashIndexing: config.SenderTxHashIndexing, SnapshotCacheSize: config.SnapshotCacheSize, SnapshotAsyncGen: config.SnapshotAsyncGen,
+			BlockInterval: config.TrieBlockInterval, TriesInMemory: config.TriesInMemory, PeriodicMigrationInterval: config.PeriodicMigrationInterval, StateHistory: config.StateHistory,
+			TrieNodeCacheConfig: &config.TrieNodeCacheConfig, SenderTxHashIndexing: config.SenderTxHashIndexing, TxLookupLimit: config.TxLookupLimit, ParallelTxWorkers: config.ParallelTxWorkers, SnapshotCacheSize: config.SnapshotCacheSize, SnapshotAsyncGen: config.SnapshotAsyncGen,
 		}
 	)
 
@@ -282,19 +658,54 @@ func New(ctx *node.ServiceContext, config *Config) (*CN, error) {
 		cn.blockchain.Config().Governance.Reward.UseGiniCoeff = governance.Params().UseGiniCoeff()
 	}
 
-	if config.SenderTxHashIndexing {
+	// The indexes of a read replica are written by the primary node.
+	if config.SenderTxHashIndexing && !config.ReadReplica {
 		ch := make(chan blockchain.ChainEvent, 255)
 		chainEventSubscription := cn.blockchain.SubscribeChainEvent(ch)
 		go senderTxHashIndexer(chainDB, ch, chainEventSubscription)
 	}
 
+	if config.AccountTxIndexing && !config.ReadReplica {
+		ch := make(chan blockchain.ChainEvent, 255)
+		chainEventSubscription := cn.blockchain.SubscribeChainEvent(ch)
+		go accountTxIndexer(chainDB, types.LatestSignerForChainID(cn.chainConfig.ChainID), ch, chainEventSubscription)
+	}
+
+	if config.InternalTxIndexing && !config.ReadReplica {
+		ch := make(chan blockchain.ChainEvent, 255)
+		chainEventSubscription := cn.blockchain.SubscribeChainEvent(ch)
+		go internalTxIndexer(chainDB, ch, chainEventSubscription)
+	}
+
+	if config.TokenTransferIndexing && !config.ReadReplica {
+		ch := make(chan blockchain.ChainEvent, 255)
+		chainEventSubscription := cn.blockchain.SubscribeChainEvent(ch)
+		go tokenTransferIndexer(chainDB, ch, chainEventSubscription)
+	}
+
+	if config.FeeStatsIndexing && !config.ReadReplica {
+		ch := make(chan blockchain.ChainEvent, 255)
+		chainEventSubscription := cn.blockchain.SubscribeChainEvent(ch)
+		go feeStatsIndexer(chainDB, cn.chainConfig, ch, chainEventSubscription)
+	}
+
 	// Rewind the chain in case of an incompatible config upgrade.
 	if compat, ok := genesisErr.(*params.ConfigCompatError); ok {
 		logger.Error("Rewinding chain to upgrade configuration", "err", compat)
 		cn.blockchain.SetHead(compat.RewindTo)
 		chainDB.WriteChainConfig(genesisHash, cn.chainConfig)
 	}
-	cn.bloomIndexer.Start(cn.blockchain)
+	if config.ReadReplica {
+		// The bloom bits are indexed by the primary node, and the replica follows its head.
+		logger.Info("Running as a read replica; p2p networking, consensus and database writes are disabled")
+		go bc.FollowHeadLoop(readReplicaFollowInterval)
+	} else {
+		cn.bloomIndexer.Start(cn.blockchain)
+	}
+	if retriever != nil {
+		logger.Info("Running as a light node; only the headers are synced, and the other data is retrieved on demand")
+		go bc.FollowHeaderLoop(lightFollowInterval)
+	}
 
 	if config.TxPool.Journal != "" {
 		config.TxPool.Journal = ctx.ResolvePath(config.TxPool.Journal)
@@ -304,11 +715,25 @@ func New(ctx *node.ServiceContext, config *Config) (*CN, error) {
 	cn.txPool = blockchain.NewTxPool(config.TxPool, cn.chainConfig, bc)
 	governance.SetTxPool(cn.txPool)
 
+	if ctx.AccountManager != nil {
+		if backends := ctx.AccountManager.Backends(watchonly.BackendType); len(backends) > 0 {
+			txsCh := make(chan blockchain.NewTxsEvent, 255)
+			chainCh := make(chan blockchain.ChainEvent, 255)
+			go watchOnlyNotifier(backends[0].(*watchonly.Backend), types.LatestSignerForChainID(cn.chainConfig.ChainID),
+				txsCh, cn.txPool.SubscribeNewTxsEvent(txsCh), chainCh, cn.blockchain.SubscribeChainEvent(chainCh), cn.closeWatchOnlyNotifier)
+		}
+	}
+
 	// Permit the downloader to use the trie cache allowance during fast sync
 	cacheLimit := cacheConfig.TrieNodeCacheConfig.LocalCacheSizeMiB
-	if cn.protocolManager, err = NewProtocolManager(cn.chainConfig, config.SyncMode, config.NetworkId, cn.eventMux, cn.txPool, cn.engine, cn.blockchain, chainDB, cacheLimit, ctx.NodeType(), config); err != nil {
+	pm, err := NewProtocolManager(cn.chainConfig, config.SyncMode, config.NetworkId, cn.eventMux, cn.txPool, cn.engine, cn.blockchain, chainDB, cacheLimit, ctx.NodeType(), config)
+	if err != nil {
 		return nil, err
 	}
+	if retriever != nil {
+		pm.setLightRetriever(retriever)
+	}
+	cn.protocolManager = pm
 
 	if err := cn.setAcceptTxs(); err != nil {
 		logger.Error("Failed to decode IstanbulExtra", "err", err)
@@ -326,7 +751,7 @@ func New(ctx *node.ServiceContext, config *Config) (*CN, error) {
 	}
 
 	// set worker
-	if config.WorkerDisable {
+	if config.WorkerDisable || config.ReadReplica || retriever != nil {
 		cn.miner = work.NewFakeWorker()
 		// Istanbul backend can be accessed by APIs to call its methods even though the core of the
 		// consensus engine doesn't run.
@@ -342,7 +767,7 @@ func New(ctx *node.ServiceContext, config *Config) (*CN, error) {
 	// istanbul BFT
 	cn.miner.SetExtra(makeExtraData(config.ExtraData))
 
-	cn.APIBackend = &CNAPIBackend{cn, nil}
+	cn.APIBackend = &CNAPIBackend{cn: cn}
 
 	gpoParams := config.GPO
 
@@ -351,12 +776,24 @@ func New(ctx *node.ServiceContext, config *Config) (*CN, error) {
 	gpoParams.Default = config.GasPrice
 
 	cn.APIBackend.gpo = gasprice.NewOracle(cn.APIBackend, gpoParams, cn.txPool)
+	if config.FeeStatsIndexing {
+		cn.APIBackend.gpo.SetFeeStatsDB(chainDB)
+	}
+
+	if config.LogIndexBackend != "" {
+		if cn.logIndex, err = filters.NewLogIndex(config.LogIndexBackend, config.LogIndexEndpoint); err != nil {
+			return nil, err
+		}
+		cn.logIndexSyncer = filters.NewLogIndexSyncer(cn.APIBackend, cn.logIndex)
+		logger.Info("Serving getLogs by the external log index", "backend", config.LogIndexBackend)
+	}
 	//@TODO Klaytn add core component
 	cn.addComponent(cn.blockchain)
 	cn.addComponent(cn.txPool)
 	cn.addComponent(cn.APIs())
 	cn.addComponent(cn.ChainDB())
 	cn.addComponent(cn.engine)
+	cn.addComponent(cn.APIBackend)
 
 	if config.AutoRestartFlag {
 		daemonPath := config.DaemonPathFlag
@@ -462,6 +899,8 @@ func CreateDB(ctx *node.ServiceContext, config *Config, name string) database.DB
 		Dir: name, DBType: config.DBType, ParallelDBWrite: config.ParallelDBWrite, SingleDB: config.SingleDB, NumStateTrieShards: config.NumStateTrieShards,
 		LevelDBCacheSize: config.LevelDBCacheSize, OpenFilesLimit: database.GetOpenFilesLimit(), LevelDBCompression: config.LevelDBCompression,
 		LevelDBBufferPool: config.LevelDBBufferPool, EnableDBPerfMetrics: config.EnableDBPerfMetrics, DynamoDBConfig: &config.DynamoDBConfig,
+		RocksDBBlockCacheSize: config.RocksDBBlockCacheSize, RocksDBCompactionStyle: config.RocksDBCompactionStyle,
+		ReadOnly: config.ReadReplica,
 	}
 	return ctx.OpenDatabase(dbc)
 }
@@ -484,6 +923,9 @@ func (s *CN) APIs() []rpc.API {
 	apis = append(apis, s.engine.APIs(s.BlockChain())...)
 
 	publicFilterAPI := filters.NewPublicFilterAPI(s.APIBackend, false)
+	if s.logIndex != nil {
+		publicFilterAPI.SetLogIndex(s.logIndex)
+	}
 	governanceKlayAPI := governance.NewGovernanceKlayAPI(s.governance, s.blockchain)
 	publicGovernanceAPI := governance.NewGovernanceAPI(s.governance)
 	publicDownloaderAPI := downloader.NewPublicDownloaderAPI(s.protocolManager.Downloader(), s.eventMux)
@@ -491,7 +933,28 @@ func (s *CN) APIs() []rpc.API {
 	ethAPI.SetPublicFilterAPI(publicFilterAPI)
 	ethAPI.SetGovernanceKlayAPI(governanceKlayAPI)
 	ethAPI.SetPublicGovernanceAPI(publicGovernanceAPI)
+	ethAPI.SetKlaytnTxMode(api.EthKlaytnTxMode(s.config.RPCEthKlaytnTxMode))
+
+	for name, size := range map[string]int{
+		api.RPCCacheReceipts:    s.config.RPCReceiptsCacheSize,
+		api.RPCCacheEthBlocks:   s.config.RPCEthBlocksCacheSize,
+		api.RPCCacheEthReceipts: s.config.RPCEthReceiptsCacheSize,
+		api.RPCCacheSenders:     s.config.RPCSendersCacheSize,
+	} {
+		if err := api.ResizeRPCCache(name, size); err != nil {
+			logger.Error("Failed to set the size of RPC cache", "name", name, "size", size, "err", err)
+		}
+	}
+	api.SetCallStateReuseWindow(s.config.RPCCallStateReuseWindow)
+	if s.config.RPCEthPrecomputeBlocks && s.ethBlockPrecomputer == nil {
+		if s.config.RPCEthBlocksCacheSize == 0 && s.config.RPCEthReceiptsCacheSize == 0 {
+			logger.Warn("Ethereum-format blocks are precomputed, but the RPC caches are disabled")
+		}
+		s.ethBlockPrecomputer = api.NewEthBlockPrecomputer(ethAPI, s.APIBackend)
+	}
 
+	tracers.SetCustomTracerLimits(s.config.RPCCustomTracerTimeout, s.config.RPCCustomTracerMaxResultSize)
+	tracers.SetStandardTraceDir(s.config.RPCStandardTraceDir)
 	var tracerAPI *tracers.API
 	if s.config.DisableUnsafeDebug {
 		tracerAPI = tracers.NewAPIUnsafeDisabled(s.APIBackend)
@@ -506,6 +969,16 @@ func (s *CN) APIs() []rpc.API {
 			},
 		}...)
 	}
+	s.tracerAPI = tracerAPI
+	if s.config.RPCTxPoolEthFormat {
+		// Registered after api.PublicTxPoolAPI to override its methods
+		apis = append(apis, rpc.API{
+			Namespace: "txpool",
+			Version:   "1.0",
+			Service:   api.NewEthTxPoolAPI(s.APIBackend, ethAPI),
+			Public:    true,
+		})
+	}
 
 	// Append all the local APIs and return
 	return append(apis, []rpc.API{
@@ -533,6 +1006,10 @@ func (s *CN) APIs() []rpc.API {
 			Namespace: "admin",
 			Version:   "1.0",
 			Service:   NewPrivateAdminAPI(s),
+		}, {
+			Namespace: "bundle",
+			Version:   "1.0",
+			Service:   NewPrivateBundleAPI(s),
 		}, {
 			Namespace: "debug",
 			Version:   "1.0",
@@ -697,9 +1174,16 @@ func (s *CN) Stop() error {
 	// Then stop everything else.
 	s.bloomIndexer.Close()
 	close(s.closeBloomHandler)
+	close(s.closeWatchOnlyNotifier)
 	s.txPool.Stop()
 	s.miner.Stop()
 	reward.StakingManagerUnsubscribe()
+	if s.logIndexSyncer != nil {
+		s.logIndexSyncer.Stop()
+	}
+	if s.ethBlockPrecomputer != nil {
+		s.ethBlockPrecomputer.Stop()
+	}
 	s.blockchain.Stop()
 	s.chainDB.Close()
 	s.eventMux.Stop()
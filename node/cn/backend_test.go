@@ -17,14 +17,24 @@
 package cn
 
 import (
+	"math/big"
 	"testing"
 	"time"
 
 	"github.com/golang/mock/gomock"
+	"github.com/klaytn/klaytn/accounts/watchonly"
+	"github.com/klaytn/klaytn/api"
+	"github.com/klaytn/klaytn/blockchain"
 	"github.com/klaytn/klaytn/blockchain/types"
+	"github.com/klaytn/klaytn/blockchain/vm"
+	"github.com/klaytn/klaytn/common"
+	"github.com/klaytn/klaytn/crypto"
 	"github.com/klaytn/klaytn/datasync/downloader"
+	"github.com/klaytn/klaytn/event"
+	"github.com/klaytn/klaytn/node/cn/gasprice"
 	"github.com/klaytn/klaytn/node/cn/mocks"
 	"github.com/klaytn/klaytn/params"
+	"github.com/klaytn/klaytn/storage/database"
 	mocks2 "github.com/klaytn/klaytn/work/mocks"
 	"github.com/stretchr/testify/assert"
 )
@@ -54,7 +64,36 @@ func TestCN_CheckSyncMode(t *testing.T) {
 	assert.NoError(t, checkSyncMode(c))
 
 	c.SyncMode = downloader.LightSync
-	assert.Equal(t, errCNLightSync, checkSyncMode(c))
+	assert.NoError(t, checkSyncMode(c))
+}
+
+func TestCN_CheckReadReplica(t *testing.T) {
+	c := &Config{DBType: database.LevelDB, StateHistory: true}
+	assert.NoError(t, checkReadReplica(c))
+
+	c.ReadReplica = true
+	assert.EqualError(t, checkReadReplica(c), "read replica supports only DynamoDBS3, but LevelDB is given")
+
+	c.DBType = database.DynamoDB
+	assert.EqualError(t, checkReadReplica(c), "read replica cannot record the state history")
+
+	c.StateHistory = false
+	assert.NoError(t, checkReadReplica(c))
+
+	c.TxLookupLimit = 128
+	assert.Error(t, checkReadReplica(c))
+}
+
+func TestCN_CheckEthKlaytnTxMode(t *testing.T) {
+	c := &Config{}
+	assert.NoError(t, checkEthKlaytnTxMode(c))
+	assert.Equal(t, string(api.EthKlaytnTxModeLegacy), c.RPCEthKlaytnTxMode)
+
+	c.RPCEthKlaytnTxMode = string(api.EthKlaytnTxModeTyped)
+	assert.NoError(t, checkEthKlaytnTxMode(c))
+
+	c.RPCEthKlaytnTxMode = "Typed"
+	assert.Error(t, checkEthKlaytnTxMode(c))
 }
 
 func TestCN_SetEngineType(t *testing.T) {
@@ -145,3 +184,208 @@ func TestCN_ReBroadcastTxs(t *testing.T) {
 	mockPM.EXPECT().ReBroadcastTxs(txs).Times(1)
 	cn.ReBroadcastTxs(txs)
 }
+
+func TestAccountTxIndexer_RestartAfterGap(t *testing.T) {
+	db := database.NewMemoryDBManager()
+	defer db.Close()
+
+	var feed event.Feed
+	ch := make(chan blockchain.ChainEvent)
+	sub := feed.Subscribe(ch)
+	done := make(chan struct{})
+	go func() {
+		accountTxIndexer(db, types.LatestSignerForChainID(params.TestChainConfig.ChainID), ch, sub)
+		close(done)
+	}()
+
+	// The indexer receives the next event after it has stored the previous block.
+	index := func(nums ...int64) (uint64, uint64) {
+		for _, num := range nums {
+			feed.Send(blockchain.ChainEvent{Block: types.NewBlockWithHeader(&types.Header{Number: big.NewInt(num)})})
+		}
+		feed.Send(blockchain.ChainEvent{Block: types.NewBlockWithHeader(&types.Header{Number: big.NewInt(nums[len(nums)-1])})})
+		tail, _ := db.ReadAccountTxIndexTail()
+		head, _ := db.ReadAccountTxIndexHead()
+		return tail, head
+	}
+
+	tail, head := index(1, 2, 3)
+	assert.Equal(t, uint64(1), tail)
+	assert.Equal(t, uint64(3), head)
+
+	// A block indexed again by a reorganization does not move the tail.
+	tail, head = index(3)
+	assert.Equal(t, uint64(1), tail)
+	assert.Equal(t, uint64(3), head)
+
+	// The tail is moved to the first block after the unindexed blocks.
+	tail, head = index(6, 7)
+	assert.Equal(t, uint64(6), tail)
+	assert.Equal(t, uint64(7), head)
+
+	sub.Unsubscribe()
+	<-done
+}
+
+func TestInternalTxIndexer_MissingTraces(t *testing.T) {
+	db := database.NewMemoryDBManager()
+	defer db.Close()
+
+	var feed event.Feed
+	ch := make(chan blockchain.ChainEvent)
+	sub := feed.Subscribe(ch)
+	done := make(chan struct{})
+	go func() {
+		internalTxIndexer(db, ch, sub)
+		close(done)
+	}()
+
+	var (
+		contract = common.HexToAddress("0x1111")
+		receiver = common.HexToAddress("0x2222")
+	)
+	newTx := func(nonce uint64) *types.Transaction {
+		return types.NewTransaction(nonce, contract, common.Big0, 100000, common.Big1, nil)
+	}
+	trace := &vm.InternalTxTrace{
+		Type:  "CALL",
+		To:    &contract,
+		Calls: []*vm.InternalTxTrace{{Type: "CALL", From: &contract, To: &receiver, Value: "0x1"}},
+	}
+	newBlock := func(num int64, tx *types.Transaction) *types.Block {
+		return types.NewBlockWithHeader(&types.Header{Number: big.NewInt(num)}).WithBody(types.Transactions{tx})
+	}
+	// The indexer receives the next event after it has stored the previous block.
+	// The next event has no traces for an indexed block, so that it changes nothing.
+	index := func(num int64, tx *types.Transaction, traces []*vm.InternalTxTrace) (uint64, uint64) {
+		block := newBlock(num, tx)
+		feed.Send(blockchain.ChainEvent{Block: block, Hash: block.Hash(), InternalTxTraces: traces})
+		feed.Send(blockchain.ChainEvent{Block: newBlock(1, tx)})
+		tail, _ := db.ReadInternalTxIndexTail()
+		head, _ := db.ReadInternalTxIndexHead()
+		return tail, head
+	}
+
+	tx1, tx2, tx3 := newTx(0), newTx(1), newTx(2)
+	tail, head := index(1, tx1, []*vm.InternalTxTrace{trace})
+	assert.Equal(t, uint64(1), tail)
+	assert.Equal(t, uint64(1), head)
+	assert.NotEmpty(t, db.ReadInternalTxs(tx1.Hash()))
+
+	// A chain event without the traces is not indexed.
+	tail, head = index(2, tx2, nil)
+	assert.Equal(t, uint64(1), tail)
+	assert.Equal(t, uint64(1), head)
+	assert.Empty(t, db.ReadInternalTxs(tx2.Hash()))
+
+	// The tail is moved to the first block after the unindexed block.
+	tail, head = index(3, tx3, []*vm.InternalTxTrace{trace})
+	assert.Equal(t, uint64(3), tail)
+	assert.Equal(t, uint64(3), head)
+	entries := db.ReadInternalTxAccountIndex(receiver, 0, 0, 3, 10)
+	if assert.Len(t, entries, 2) {
+		assert.ElementsMatch(t, []common.Hash{tx1.Hash(), tx3.Hash()}, []common.Hash{entries[0].TxHash, entries[1].TxHash})
+	}
+
+	sub.Unsubscribe()
+	<-done
+}
+
+func TestFeeStatsIndexer_MissingReceipts(t *testing.T) {
+	db := database.NewMemoryDBManager()
+	defer db.Close()
+
+	var feed event.Feed
+	ch := make(chan blockchain.ChainEvent)
+	sub := feed.Subscribe(ch)
+	done := make(chan struct{})
+	go func() {
+		feeStatsIndexer(db, params.TestChainConfig, ch, sub)
+		close(done)
+	}()
+
+	tx := types.NewTransaction(0, common.HexToAddress("0x1111"), common.Big0, 21000, common.Big1, nil)
+	newBlock := func(num int64) *types.Block {
+		return types.NewBlockWithHeader(&types.Header{Number: big.NewInt(num), GasUsed: 21000}).WithBody(types.Transactions{tx})
+	}
+	// The indexer receives the next event after it has stored the previous block.
+	index := func(block *types.Block) {
+		feed.Send(blockchain.ChainEvent{Block: block, Hash: block.Hash()})
+		feed.Send(blockchain.ChainEvent{Block: types.NewBlockWithHeader(&types.Header{Number: big.NewInt(0)})})
+	}
+
+	// The receipts of a mined block are read from the database.
+	mined := newBlock(1)
+	db.WriteReceipts(mined.Hash(), mined.NumberU64(), types.Receipts{{Status: types.ReceiptStatusSuccessful, GasUsed: 21000}})
+	index(mined)
+	stats := gasprice.ReadFeeStats(db, mined.Header())
+	if assert.NotNil(t, stats) {
+		assert.Equal(t, uint64(1), stats.TxCount)
+		assert.Equal(t, []gasprice.RewardGas{{Reward: new(big.Int).SetUint64(params.TestChainConfig.UnitPrice), GasUsed: 21000}}, stats.Rewards)
+	}
+
+	// A block without the receipts is skipped.
+	missing := newBlock(2)
+	index(missing)
+	assert.Nil(t, gasprice.ReadFeeStats(db, missing.Header()))
+
+	sub.Unsubscribe()
+	<-done
+}
+
+func TestWatchOnlyNotifier(t *testing.T) {
+	key, _ := crypto.GenerateKey()
+	var (
+		watched = common.HexToAddress("0x1111")
+		signer  = types.LatestSignerForChainID(params.TestChainConfig.ChainID)
+	)
+	tx, err := types.SignTx(types.NewTransaction(0, watched, common.Big0, 21000, common.Big1, nil), signer, key)
+	assert.NoError(t, err)
+	receipt := &types.Receipt{Status: types.ReceiptStatusSuccessful, TxHash: tx.Hash()}
+
+	backend := watchonly.NewBackend(watched)
+	activities := make(chan watchonly.ActivityEvent, 2)
+	activitySub := backend.SubscribeActivity(activities)
+	defer activitySub.Unsubscribe()
+
+	var txsFeed, chainFeed event.Feed
+	txsCh := make(chan blockchain.NewTxsEvent)
+	chainCh := make(chan blockchain.ChainEvent)
+	txsSub, chainSub := txsFeed.Subscribe(txsCh), chainFeed.Subscribe(chainCh)
+	quit, done := make(chan struct{}), make(chan struct{})
+	go func() {
+		watchOnlyNotifier(backend, signer, txsCh, txsSub, chainCh, chainSub, quit)
+		close(done)
+	}()
+	block := types.NewBlockWithHeader(&types.Header{Number: big.NewInt(1)}).WithBody(types.Transactions{tx})
+
+	// Nothing is notified while no address is watched.
+	assert.True(t, backend.Remove(watched))
+	txsFeed.Send(blockchain.NewTxsEvent{Txs: []*types.Transaction{tx}})
+	chainFeed.Send(blockchain.ChainEvent{Block: block, Receipts: types.Receipts{receipt}})
+	txsFeed.Send(blockchain.NewTxsEvent{}) // received after the previous events are handled
+	assert.True(t, backend.Add(watched))
+
+	// The transaction to the watched account is notified when it is pending and when it is included.
+	txsFeed.Send(blockchain.NewTxsEvent{Txs: []*types.Transaction{tx}})
+	chainFeed.Send(blockchain.ChainEvent{Block: block, Receipts: types.Receipts{receipt}})
+
+	for _, expected := range []*types.Receipt{nil, receipt} {
+		select {
+		case ev := <-activities:
+			assert.Equal(t, watched, ev.Address)
+			assert.Equal(t, tx.Hash(), ev.Tx.Hash())
+			assert.Equal(t, expected, ev.Receipt)
+		case <-time.After(time.Second):
+			t.Fatal("activity of the watch-only account is not notified")
+		}
+	}
+
+	// The notifier is stopped by closing quit.
+	close(quit)
+	select {
+	case <-done:
+	case <-time.After(time.Second):
+		t.Fatal("watch-only notifier is not stopped")
+	}
+}
@@ -0,0 +1,70 @@
+// Copyright 2022 The klaytn Authors
+// This file is part of the klaytn library.
+//
+// The klaytn library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The klaytn library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the klaytn library. If not, see <http://www.gnu.org/licenses/>.
+
+package cn
+
+import (
+	"math/big"
+	"testing"
+
+	"github.com/klaytn/klaytn/blockchain/types"
+	"github.com/klaytn/klaytn/common"
+	"github.com/klaytn/klaytn/common/bitutil"
+	"github.com/klaytn/klaytn/storage/database"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// TestBloomIndexer tests that the bloom indexer stores the rotated bloom bits of a section,
+// so that a bit vector tells which blocks of the section have the bit set in their bloom.
+func TestBloomIndexer(t *testing.T) {
+	const (
+		size    = 16
+		section = 2
+		matched = 3 // the index of the block having the logs in the section
+	)
+	db := database.NewMemoryDBManager()
+	indexer := &BloomIndexer{db: db, size: size}
+
+	var bloom types.Bloom
+	bloom.Add(new(big.Int).SetBytes(common.HexToAddress("0x1111").Bytes()))
+	bloom.Add(new(big.Int).SetBytes(common.HexToHash("0x2222").Bytes()))
+
+	require.NoError(t, indexer.Reset(section, common.Hash{}))
+	var head common.Hash
+	for i := uint64(0); i < size; i++ {
+		header := &types.Header{Number: new(big.Int).SetUint64(section*size + i)}
+		if i == matched {
+			header.Bloom = bloom
+		}
+		indexer.Process(header)
+		head = header.Hash()
+	}
+	require.NoError(t, indexer.Commit())
+
+	for bit := 0; bit < types.BloomBitLength; bit++ {
+		compressed, err := db.ReadBloomBits(database.BloomBitsKey(uint(bit), section, head))
+		require.NoError(t, err)
+		bits, err := bitutil.DecompressBytes(compressed, size/8)
+		require.NoError(t, err)
+
+		expected := make([]byte, size/8)
+		if bloom[types.BloomByteLength-1-bit/8]&(1<<uint(bit%8)) != 0 {
+			expected[matched/8] = 1 << (7 - matched%8)
+		}
+		assert.Equal(t, expected, bits, "bit %d", bit)
+	}
+}
@@ -28,6 +28,7 @@ import (
 
 	"github.com/klaytn/klaytn/storage/statedb"
 
+	"github.com/klaytn/klaytn/api"
 	"github.com/klaytn/klaytn/blockchain"
 	"github.com/klaytn/klaytn/blockchain/vm"
 	"github.com/klaytn/klaytn/common"
@@ -45,15 +46,18 @@ var logger = log.NewModuleLogger(log.NodeCN)
 // GetDefaultConfig returns default settings for use on the Klaytn main net.
 func GetDefaultConfig() *Config {
 	return &Config{
-		SyncMode:            downloader.FullSync,
-		NetworkId:           params.CypressNetworkId,
-		LevelDBCacheSize:    768,
-		TrieCacheSize:       512,
-		TrieTimeout:         5 * time.Minute,
-		TrieBlockInterval:   blockchain.DefaultBlockInterval,
-		TrieNodeCacheConfig: *statedb.GetEmptyTrieNodeCacheConfig(),
-		TriesInMemory:       blockchain.DefaultTriesInMemory,
-		GasPrice:            big.NewInt(18 * params.Ston),
+		SyncMode:               downloader.FullSync,
+		NetworkId:              params.CypressNetworkId,
+		LevelDBCacheSize:       768,
+		RocksDBBlockCacheSize:  768,
+		RocksDBCompactionStyle: "level",
+		TrieCacheSize:          512,
+		TrieTimeout:            5 * time.Minute,
+		TrieBlockInterval:      blockchain.DefaultBlockInterval,
+		TrieNodeCacheConfig:    *statedb.GetEmptyTrieNodeCacheConfig(),
+		TriesInMemory:          blockchain.DefaultTriesInMemory,
+		GasPrice:               big.NewInt(18 * params.Ston),
+		CheckpointThreshold:    1,
 
 		TxPool: blockchain.DefaultTxPoolConfig,
 		GPO: gasprice.Config{
@@ -64,8 +68,16 @@ func GetDefaultConfig() *Config {
 		},
 		WsEndpoint: "localhost:8546",
 
-		Istanbul:      *istanbul.DefaultConfig,
-		RPCEVMTimeout: 5 * time.Second,
+		Istanbul:            *istanbul.DefaultConfig,
+		RPCEVMTimeout:       5 * time.Second,
+		RPCStateReexecLimit: 128,
+
+		RPCEthKlaytnTxMode: "legacy",
+
+		RPCReceiptsCacheSize:    api.DefaultRPCReceiptsCacheSize,
+		RPCEthBlocksCacheSize:   api.DefaultRPCEthBlocksCacheSize,
+		RPCEthReceiptsCacheSize: api.DefaultRPCEthReceiptsCacheSize,
+		RPCSendersCacheSize:     api.DefaultRPCSendersCacheSize,
 	}
 }
 
@@ -95,6 +107,14 @@ type Config struct {
 	DownloaderDisable bool
 	FetcherDisable    bool
 
+	// Read replica options
+	ReadReplica bool // serves RPC from the database written by a primary node without p2p, consensus and writes
+
+	// Trusted checkpoint options
+	TrustedCheckpoint   string           `toml:",omitempty"` // path of the signed checkpoint manifest taken as the pivot of snap sync
+	CheckpointSigners   []common.Address `toml:",omitempty"` // trusted signers of the checkpoint manifest
+	CheckpointThreshold int              // number of the trusted signers required to sign the checkpoint
+
 	// Service chain options
 	ParentOperatorAddr *common.Address `toml:",omitempty"` // A hex account address in the parent chain used to sign a child chain transaction.
 	AnchoringPeriod    uint64          // Period when child chain sends an anchoring transaction to the parent chain. Default value is 1.
@@ -108,24 +128,33 @@ type Config struct {
 	StartBlockNumber uint64
 
 	// Database options
-	DBType               database.DBType
-	SkipBcVersionCheck   bool `toml:"-"`
-	SingleDB             bool
-	NumStateTrieShards   uint
-	EnableDBPerfMetrics  bool
-	LevelDBCompression   database.LevelDBCompressionType
-	LevelDBBufferPool    bool
-	LevelDBCacheSize     int
-	DynamoDBConfig       database.DynamoDBConfig
-	TrieCacheSize        int
-	TrieTimeout          time.Duration
-	TrieBlockInterval    uint
-	TriesInMemory        uint64
-	SenderTxHashIndexing bool
-	ParallelDBWrite      bool
-	TrieNodeCacheConfig  statedb.TrieNodeCacheConfig
-	SnapshotCacheSize    int
-	SnapshotAsyncGen     bool
+	DBType                    database.DBType
+	SkipBcVersionCheck        bool `toml:"-"`
+	SingleDB                  bool
+	NumStateTrieShards        uint
+	EnableDBPerfMetrics       bool
+	LevelDBCompression        database.LevelDBCompressionType
+	LevelDBBufferPool         bool
+	LevelDBCacheSize          int
+	RocksDBBlockCacheSize     int
+	RocksDBCompactionStyle    string
+	DynamoDBConfig            database.DynamoDBConfig
+	TrieCacheSize             int
+	TrieTimeout               time.Duration
+	TrieBlockInterval         uint
+	TriesInMemory             uint64
+	PeriodicMigrationInterval uint64
+	StateHistory              bool // stores reverse state diffs on top of the hash-based state tries to serve the pruned states
+	SenderTxHashIndexing      bool
+	TxLookupLimit             uint64
+	ParallelTxWorkers         int
+	AccountTxIndexing         bool
+	TokenTransferIndexing     bool
+	FeeStatsIndexing          bool
+	ParallelDBWrite           bool
+	TrieNodeCacheConfig       statedb.TrieNodeCacheConfig
+	SnapshotCacheSize         int
+	SnapshotAsyncGen          bool
 
 	// Mining-related options
 	ServiceChainSigner common.Address `toml:",omitempty"`
@@ -143,8 +172,15 @@ type Config struct {
 
 	// Enables tracking of SHA3 preimages in the VM
 	EnablePreimageRecording bool
+	// Name and endpoint of the external log index serving getLogs. Refer to filters.RegisterLogIndex.
+	LogIndexBackend  string `toml:",omitempty"`
+	LogIndexEndpoint string `toml:",omitempty"`
+
 	// Enables collecting internal transaction data during processing a block
 	EnableInternalTxTracing bool
+	// Enables storing internal transactions collected during processing a block.
+	// It implies EnableInternalTxTracing.
+	InternalTxIndexing bool
 	// Istanbul options
 	Istanbul istanbul.Config
 
@@ -175,11 +211,51 @@ type Config struct {
 	// RPCEVMTimeout is the global timeout for klay/eth-call.
 	RPCEVMTimeout time.Duration
 
+	// RPCCallStateReuseWindow is how long the state opened for a call is reused by the following
+	// calls against the same block. The reuse is disabled if it is 0.
+	RPCCallStateReuseWindow time.Duration `toml:",omitempty"`
+
+	// RPCStateReexecLimit is the maximum number of blocks re-executed to regenerate a pruned state
+	// for the klay/eth namespace APIs and tracing, which fail if no state is available within the limit.
+	// The reexec given by a tracing request is lowered to it.
+	// If it is 0, the pruned states are not regenerated and the APIs fail on them.
+	RPCStateReexecLimit uint64
+
+	// RPCCustomTracerTimeout caps the timeout of the custom Javascript tracers given by users.
+	// RPCCustomTracerMaxResultSize limits the size of their results in bytes. 0 means no limit.
+	RPCCustomTracerTimeout       time.Duration `toml:",omitempty"`
+	RPCCustomTracerMaxResultSize int           `toml:",omitempty"`
+
+	// RPCStandardTraceDir is the directory where debug_standardTraceBlockToFile dumps the traces.
+	// The temporary directory of the OS is used if it is empty.
+	RPCStandardTraceDir string `toml:",omitempty"`
+
 	// RPCTxFeeCap is the global transaction fee(price * gaslimit) cap for
 	// send-transction variants. The unit is klay.
 	// This is used by eth namespace RPC APIs
 	RPCTxFeeCap float64
 
+	// RPCEthKlaytnTxMode is the representation of Klaytn transactions in the eth namespace APIs.
+	// Refer to api.EthKlaytnTxMode for the available modes.
+	RPCEthKlaytnTxMode string
+
+	// RPCTxPoolEthFormat represents the transactions of the txpool namespace APIs in the Ethereum format.
+	RPCTxPoolEthFormat bool `toml:",omitempty"`
+
+	// RPCFeePayerWhitelist restricts the accounts of this node signing transactions as a fee payer
+	// through the RPC APIs. klay_sendRawTransactionAsFeePayer is disabled if it is empty.
+	RPCFeePayerWhitelist []common.Address `toml:",omitempty"`
+
+	// Capacities of the caches of the RPC outputs. They can be changed by admin_setRPCCacheSize at runtime.
+	RPCReceiptsCacheSize    int // number of blocks whose receipts are cached
+	RPCEthBlocksCacheSize   int // number of Ethereum-format blocks cached
+	RPCEthReceiptsCacheSize int // number of Ethereum-format receipts cached
+	RPCSendersCacheSize     int // number of recovered transaction senders cached
+
+	// RPCEthPrecomputeBlocks stores the Ethereum-format outputs of each new block and its receipts
+	// in the RPC caches at import, so that the eth namespace APIs serve the new blocks by cache reads.
+	RPCEthPrecomputeBlocks bool
+
 	// Disable option for unsafe debug APIs
 	DisableUnsafeDebug bool `toml:",omitempty"`
 }
@@ -191,6 +267,6 @@ type configMarshaling struct {
 func (c *Config) getVMConfig() vm.Config {
 	return vm.Config{
 		EnablePreimageRecording: c.EnablePreimageRecording,
-		EnableInternalTxTracing: c.EnableInternalTxTracing,
+		EnableInternalTxTracing: c.EnableInternalTxTracing || c.InternalTxIndexing,
 	}
 }
@@ -29,12 +29,12 @@ import (
 	"sync"
 	"time"
 
-	"github.com/klaytn/klaytn/params"
-
 	"github.com/klaytn/klaytn"
+	"github.com/klaytn/klaytn/blockchain"
 	"github.com/klaytn/klaytn/blockchain/types"
 	"github.com/klaytn/klaytn/common"
 	"github.com/klaytn/klaytn/common/hexutil"
+	"github.com/klaytn/klaytn/consensus/misc"
 	"github.com/klaytn/klaytn/event"
 	"github.com/klaytn/klaytn/networks/rpc"
 	"github.com/klaytn/klaytn/storage/database"
@@ -46,6 +46,7 @@ var (
 	getLogsCxtKeyMaxItems = "maxItems"       // the value of the context key should have the type of GetLogsMaxItems
 	GetLogsDeadline       = 10 * time.Second // execution deadlines for getLogs and getFilterLogs APIs
 	GetLogsMaxItems       = int(10000)       // maximum allowed number of return items for getLogs and getFilterLogs APIs
+	GetLogsMaxBlockRange  = uint64(0)        // maximum allowed number of blocks searched by getLogs and getFilterLogs APIs, 0 if not limited
 )
 
 // filter is a helper struct that holds meta information over the filter type
@@ -69,6 +70,7 @@ type PublicFilterAPI struct {
 	events    *EventSystem
 	filtersMu sync.Mutex
 	filters   map[rpc.ID]*filter
+	logIndex  LogIndex
 }
 
 // NewPublicFilterAPI returns a new PublicFilterAPI instance.
@@ -85,6 +87,12 @@ func NewPublicFilterAPI(backend Backend, lightMode bool) *PublicFilterAPI {
 	return api
 }
 
+// SetLogIndex makes the range queries of logs served by the given external log index.
+// The logs not served by the log index are searched by the bloombits.
+func (api *PublicFilterAPI) SetLogIndex(index LogIndex) {
+	api.logIndex = index
+}
+
 // timeoutLoop runs every 5 minutes and deletes filters that have not been recently used.
 // Tt is started when the api is created.
 func (api *PublicFilterAPI) timeoutLoop() {
@@ -143,7 +151,21 @@ func (api *PublicFilterAPI) NewPendingTransactionFilter() rpc.ID {
 
 // NewPendingTransactions creates a subscription that is triggered each time a transaction
 // enters the transaction pool and was signed from one of the transactions this nodes manages.
-func (api *PublicFilterAPI) NewPendingTransactions(ctx context.Context) (*rpc.Subscription, error) {
+// If fullTx is true, the complete transactions are notified instead of their hashes.
+// Only the transactions matched by the optional criteria are notified.
+func (api *PublicFilterAPI) NewPendingTransactions(ctx context.Context, fullTx *bool, crit *PendingTxCriteria) (*rpc.Subscription, error) {
+	if fullTx == nil && crit == nil {
+		return api.newPendingTransactionHashes(ctx)
+	}
+	marshal := func(tx *types.Transaction) interface{} { return tx.Hash() }
+	if fullTx != nil && *fullTx {
+		marshal = rpcMarshalPendingTx
+	}
+	return SubscribePendingTransactions(ctx, api, crit, marshal)
+}
+
+// newPendingTransactionHashes creates a subscription notifying the hashes of all the pending transactions.
+func (api *PublicFilterAPI) newPendingTransactionHashes(ctx context.Context) (*rpc.Subscription, error) {
 	notifier, supported := rpc.NotifierFromContext(ctx)
 	if !supported {
 		return &rpc.Subscription{}, rpc.ErrNotificationsUnsupported
@@ -230,11 +252,7 @@ func RPCMarshalHeader(head *types.Header, isEnabledEthTxTypeFork bool) map[strin
 	}
 
 	if isEnabledEthTxTypeFork {
-		if head.BaseFee == nil {
-			result["baseFeePerGas"] = (*hexutil.Big)(new(big.Int).SetUint64(params.ZeroBaseFee))
-		} else {
-			result["baseFeePerGas"] = (*hexutil.Big)(head.BaseFee)
-		}
+		result["baseFeePerGas"] = (*hexutil.Big)(misc.HeaderBaseFee(head))
 	}
 
 	return result
@@ -271,6 +289,73 @@ func (api *PublicFilterAPI) NewHeads(ctx context.Context) (*rpc.Subscription, er
 	return rpcSub, nil
 }
 
+// RPCReorg is the notification of a reorganization of the canonical chain.
+type RPCReorg struct {
+	OldHead                common.Hash    `json:"oldHead"`
+	OldHeadNumber          hexutil.Uint64 `json:"oldHeadNumber"`
+	NewHead                common.Hash    `json:"newHead"`
+	NewHeadNumber          hexutil.Uint64 `json:"newHeadNumber"`
+	CommonAncestor         common.Hash    `json:"commonAncestor"`
+	CommonAncestorNumber   hexutil.Uint64 `json:"commonAncestorNumber"`
+	Depth                  hexutil.Uint64 `json:"depth"`
+	RemovedTransactions    []common.Hash  `json:"removedTransactions"`
+	ReincludedTransactions []common.Hash  `json:"reincludedTransactions"`
+}
+
+// newRPCReorg converts the given ReorgEvent to its RPC representation.
+func newRPCReorg(ev blockchain.ReorgEvent) *RPCReorg {
+	txHashes := func(txs types.Transactions) []common.Hash {
+		hashes := make([]common.Hash, len(txs))
+		for i, tx := range txs {
+			hashes[i] = tx.Hash()
+		}
+		return hashes
+	}
+	return &RPCReorg{
+		OldHead:                ev.OldHead.Hash(),
+		OldHeadNumber:          hexutil.Uint64(ev.OldHead.Number.Uint64()),
+		NewHead:                ev.NewHead.Hash(),
+		NewHeadNumber:          hexutil.Uint64(ev.NewHead.Number.Uint64()),
+		CommonAncestor:         ev.CommonAncestor.Hash(),
+		CommonAncestorNumber:   hexutil.Uint64(ev.CommonAncestor.Number.Uint64()),
+		Depth:                  hexutil.Uint64(ev.Depth),
+		RemovedTransactions:    txHashes(ev.RemovedTxs),
+		ReincludedTransactions: txHashes(ev.ReincludedTxs),
+	}
+}
+
+// Reorg sends a notification each time the canonical chain is reorganized, with the old and the new heads,
+// the common ancestor, the number of the removed blocks, and the transactions removed or included again.
+func (api *PublicFilterAPI) Reorg(ctx context.Context) (*rpc.Subscription, error) {
+	notifier, supported := rpc.NotifierFromContext(ctx)
+	if !supported {
+		return &rpc.Subscription{}, rpc.ErrNotificationsUnsupported
+	}
+
+	rpcSub := notifier.CreateSubscription()
+
+	go func() {
+		reorgs := make(chan blockchain.ReorgEvent, rmLogsChanSize)
+		reorgsSub := api.backend.SubscribeReorgEvent(reorgs)
+		defer reorgsSub.Unsubscribe()
+
+		for {
+			select {
+			case ev := <-reorgs:
+				notifier.Notify(rpcSub.ID, newRPCReorg(ev))
+			case <-reorgsSub.Err():
+				return
+			case <-rpcSub.Err():
+				return
+			case <-notifier.Closed():
+				return
+			}
+		}
+	}()
+
+	return rpcSub, nil
+}
+
 // Logs creates a subscription that fires for all new log that match the given filter criteria.
 func (api *PublicFilterAPI) Logs(ctx context.Context, crit FilterCriteria) (*rpc.Subscription, error) {
 	notifier, supported := rpc.NotifierFromContext(ctx)
@@ -355,38 +440,75 @@ func (api *PublicFilterAPI) NewFilter(crit FilterCriteria) (rpc.ID, error) {
 	return logsSub.ID, nil
 }
 
+// WithGetLogsLimits returns a context carrying the execution deadline and the maximum number of
+// returned items of getLogs, so that other log query interfaces are limited the same as getLogs.
+func WithGetLogsLimits(ctx context.Context) (context.Context, context.CancelFunc) {
+	ctx = context.WithValue(ctx, getLogsCxtKeyMaxItems, GetLogsMaxItems)
+	return context.WithTimeout(ctx, GetLogsDeadline)
+}
+
 // GetLogs returns logs matching the given argument that are stored within the state.
 func (api *PublicFilterAPI) GetLogs(ctx context.Context, crit FilterCriteria) ([]*types.Log, error) {
-	ctx = context.WithValue(ctx, getLogsCxtKeyMaxItems, GetLogsMaxItems)
-	ctx, cancelFnc := context.WithTimeout(ctx, GetLogsDeadline)
+	ctx, cancelFnc := WithGetLogsLimits(ctx)
 	defer cancelFnc()
 
-	var filter *Filter
-	if crit.BlockHash != nil {
-		// Block filter requested, construct a single-shot filter
-		filter = NewBlockFilter(api.backend, *crit.BlockHash, crit.Addresses, crit.Topics)
-	} else {
-		// Convert the RPC block numbers into internal representations
-		begin := rpc.LatestBlockNumber.Int64()
-		if crit.FromBlock != nil {
-			begin = crit.FromBlock.Int64()
-		}
-		end := rpc.LatestBlockNumber.Int64()
-		if crit.ToBlock != nil {
-			end = crit.ToBlock.Int64()
-		}
-		// Construct the range filter
-		filter = NewRangeFilter(api.backend, begin, end, crit.Addresses, crit.Topics)
-	}
-
 	// Run the filter and return all the logs
-	logs, err := filter.Logs(ctx)
+	logs, err := api.newCriteriaFilter(crit).Logs(ctx)
 	if err != nil {
 		return nil, err
 	}
 	return returnLogs(logs), err
 }
 
+// LogsPage is a page of logs returned by GetLogsPage.
+// Cursor is the block number to continue the query from, and it is nil if the query is completed.
+type LogsPage struct {
+	Logs   []*types.Log    `json:"logs"`
+	Cursor *hexutil.Uint64 `json:"cursor"`
+}
+
+// GetLogsPage returns logs matching the given argument like GetLogs, but returns the logs found so far
+// instead of failing when maxResults logs are found or the query deadline is exceeded.
+// The query stops at a block boundary, so it is continued by querying again with fromBlock set to the cursor.
+// maxResults is limited to the maximum number of logs returned by GetLogs, and a page covers at most
+// the maximum block range of GetLogs.
+func (api *PublicFilterAPI) GetLogsPage(ctx context.Context, crit FilterCriteria, maxResults *hexutil.Uint) (*LogsPage, error) {
+	ctx, cancelFnc := context.WithTimeout(ctx, GetLogsDeadline)
+	defer cancelFnc()
+
+	limit := GetLogsMaxItems
+	if maxResults != nil && *maxResults > 0 && int(*maxResults) < limit {
+		limit = int(*maxResults)
+	}
+	logs, next, err := api.newCriteriaFilter(crit).LogsPage(ctx, limit)
+	if err != nil {
+		return nil, err
+	}
+	return &LogsPage{Logs: returnLogs(logs), Cursor: (*hexutil.Uint64)(next)}, nil
+}
+
+// newCriteriaFilter returns a block filter if the block hash is given in the criteria, or a range filter otherwise.
+func (api *PublicFilterAPI) newCriteriaFilter(crit FilterCriteria) *Filter {
+	if crit.BlockHash != nil {
+		// Block filter requested, construct a single-shot filter
+		return NewBlockFilter(api.backend, *crit.BlockHash, crit.Addresses, crit.Topics)
+	}
+	// Convert the RPC block numbers into internal representations
+	begin := rpc.LatestBlockNumber.Int64()
+	if crit.FromBlock != nil {
+		begin = crit.FromBlock.Int64()
+	}
+	end := rpc.LatestBlockNumber.Int64()
+	if crit.ToBlock != nil {
+		end = crit.ToBlock.Int64()
+	}
+	// Construct the range filter
+	filter := NewRangeFilter(api.backend, begin, end, crit.Addresses, crit.Topics)
+	filter.logIndex = api.logIndex
+	filter.maxBlockRange = GetLogsMaxBlockRange
+	return filter
+}
+
 // UninstallFilter removes the filter with the given filter id.
 func (api *PublicFilterAPI) UninstallFilter(id rpc.ID) bool {
 	api.filtersMu.Lock()
@@ -405,8 +527,7 @@ func (api *PublicFilterAPI) UninstallFilter(id rpc.ID) bool {
 // GetFilterLogs returns the logs for the filter with the given id.
 // If the filter could not be found an empty array of logs is returned.
 func (api *PublicFilterAPI) GetFilterLogs(ctx context.Context, id rpc.ID) ([]*types.Log, error) {
-	ctx = context.WithValue(ctx, getLogsCxtKeyMaxItems, GetLogsMaxItems)
-	ctx, cancelFnc := context.WithTimeout(ctx, GetLogsDeadline)
+	ctx, cancelFnc := WithGetLogsLimits(ctx)
 	defer cancelFnc()
 
 	api.filtersMu.Lock()
@@ -427,6 +548,8 @@ func (api *PublicFilterAPI) GetFilterLogs(ctx context.Context, id rpc.ID) ([]*ty
 	}
 	// Create and run the filter to get all the logs
 	filter := NewRangeFilter(api.backend, begin, end, f.crit.Addresses, f.crit.Topics)
+	filter.logIndex = api.logIndex
+	filter.maxBlockRange = GetLogsMaxBlockRange
 
 	logs, err := filter.Logs(ctx)
 	if err != nil {
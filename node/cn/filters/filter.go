@@ -25,7 +25,9 @@ import (
 	"errors"
 	"math"
 	"math/big"
+	"runtime"
 	"strconv"
+	"sync"
 
 	"github.com/klaytn/klaytn/params"
 
@@ -48,8 +50,10 @@ type Backend interface {
 	GetLogs(ctx context.Context, blockHash common.Hash) ([][]*types.Log, error)
 
 	SubscribeNewTxsEvent(chan<- blockchain.NewTxsEvent) event.Subscription
+	SubscribeDroppedTxsEvent(chan<- blockchain.DroppedTxsEvent) event.Subscription
 	SubscribeChainEvent(ch chan<- blockchain.ChainEvent) event.Subscription
 	SubscribeRemovedLogsEvent(ch chan<- blockchain.RemovedLogsEvent) event.Subscription
+	SubscribeReorgEvent(ch chan<- blockchain.ReorgEvent) event.Subscription
 	SubscribeLogsEvent(ch chan<- []*types.Log) event.Subscription
 
 	BloomStatus() (uint64, uint64)
@@ -67,9 +71,21 @@ type Filter struct {
 	addresses  []common.Address
 	topics     [][]common.Hash
 
-	matcher *bloombits.Matcher
+	matcher      *bloombits.Matcher
+	bloomFilters [][][]byte // the flattened bloombits filter to create the matchers of the sections
+	logIndex     LogIndex   // the external log index serving range queries if not nil
+
+	pageLimit     int    // the number of logs to stop at a block boundary, 0 if the logs are not paginated
+	maxBlockRange uint64 // the maximum number of blocks searched by a range query, 0 if not limited
+	truncated     bool   // whether the range of a paginated query is cut at the maximum block range
 }
 
+// logSearchWorkers is the maximum number of bloombits sections searched concurrently by a range filter.
+var logSearchWorkers = runtime.NumCPU()
+
+// errPageFilled is returned internally to stop searching logs at a block boundary when a page is filled.
+var errPageFilled = errors.New("page of logs is filled")
+
 // NewBlockFilter creates a new filter which directly inspects the contents of
 // a block to figure out whether it is interesting or not.
 func NewBlockFilter(backend Backend, block common.Hash, addresses []common.Address, topics [][]common.Hash) *Filter {
@@ -107,12 +123,18 @@ func NewRangeFilter(backend Backend, begin, end int64, addresses []common.Addres
 	filter := newFilter(backend, addresses, topics)
 
 	filter.matcher = bloombits.NewMatcher(size, filters)
+	filter.bloomFilters = filters
 	filter.begin = begin
 	filter.end = end
 
 	return filter
 }
 
+// SetMaxBlockRange limits the number of blocks searched by the range filter, 0 if not limited.
+func (f *Filter) SetMaxBlockRange(maxBlockRange uint64) {
+	f.maxBlockRange = maxBlockRange
+}
+
 // newFilter creates a generic filter that can either filter based on a block hash,
 // or based on range queries. The search criteria needs to be explicitly set.
 func newFilter(backend Backend, addresses []common.Address, topics [][]common.Hash) *Filter {
@@ -152,27 +174,78 @@ func (f *Filter) Logs(ctx context.Context) ([]*types.Log, error) {
 	if f.end == -1 {
 		end = head
 	}
+	// A paginated query searches up to the maximum block range, and the rest is left for the next pages.
+	if f.maxBlockRange > 0 && f.begin <= int64(end) && end-uint64(f.begin) >= f.maxBlockRange {
+		if f.pageLimit == 0 {
+			return nil, errors.New("query exceeds the maximum block range of " + strconv.FormatUint(f.maxBlockRange, 10) + " blocks")
+		}
+		end = uint64(f.begin) + f.maxBlockRange - 1
+		f.truncated = true
+	}
+	// Gather the logs served by the external log index first if available.
+	// The paginated queries are not served by it since it returns all the logs at once.
+	var logs []*types.Log
+	if f.logIndex != nil && f.pageLimit == 0 {
+		logs = f.logIndexLogs(ctx, end)
+		if maxItems := getMaxItems(ctx); len(logs) > maxItems {
+			return logs, errors.New("query returned more than " + strconv.Itoa(maxItems) + " results")
+		}
+		if f.begin > int64(end) {
+			return logs, nil
+		}
+	}
 	// Gather all indexed logs, and finish with non indexed ones
-	var (
-		logs []*types.Log
-		err  error
-	)
 	size, sections := f.backend.BloomStatus()
 	if indexed := sections * size; indexed > uint64(f.begin) {
-		if indexed > end {
-			logs, err = f.indexedLogs(ctx, end)
+		var (
+			found []*types.Log
+			err   error
+		)
+		last := end
+		if indexed <= end {
+			last = indexed - 1
+		}
+		// The paginated queries are searched sequentially to stop at the first filled block.
+		if f.pageLimit == 0 && logSearchWorkers > 1 && uint64(f.begin)/size < last/size {
+			found, err = f.parallelIndexedLogs(ctx, size, last)
 		} else {
-			logs, err = f.indexedLogs(ctx, indexed-1)
+			found, err = f.indexedLogs(ctx, last)
 		}
+		logs = append(logs, found...)
 		if err != nil {
 			return logs, err
 		}
 	}
+	if f.pageLimit > 0 {
+		f.pageLimit -= len(logs)
+	}
 	rest, err := f.unindexedLogs(ctx, end)
 	logs = append(logs, rest...)
 	return logs, err
 }
 
+// LogsPage searches the blockchain for matching log entries like Logs, but stops at a block boundary
+// when at least limit logs are found or the deadline of the context is exceeded, instead of failing.
+// It returns the number of the first block not searched yet if the search is stopped, or nil otherwise.
+// All the logs of a block are returned together, so more than limit logs can be returned.
+// The search also stops after the maximum block range of the filter.
+func (f *Filter) LogsPage(ctx context.Context, limit int) ([]*types.Log, *uint64, error) {
+	f.pageLimit = limit
+	logs, err := f.Logs(ctx)
+	if err == errPageFilled || (err != nil && f.block == (common.Hash{}) && ctx.Err() == context.DeadlineExceeded) {
+		next := uint64(f.begin)
+		return logs, &next, nil
+	}
+	if err != nil {
+		return nil, nil, err
+	}
+	if f.truncated {
+		next := uint64(f.begin)
+		return logs, &next, nil
+	}
+	return logs, nil, nil
+}
+
 // blockLogs returns the logs matching the filter criteria within a single block.
 func (f *Filter) blockLogs(ctx context.Context, header *types.Header) (logs []*types.Log, err error) {
 	if bloomFilter(header.Bloom, f.addresses, f.topics) {
@@ -215,7 +288,6 @@ func (f *Filter) indexedLogs(ctx context.Context, end uint64) ([]*types.Log, err
 				}
 				return logs, err
 			}
-			f.begin = int64(number) + 1
 
 			// Retrieve the suggested block and pull any truly matching logs
 			header, err := f.backend.HeaderByNumber(ctx, rpc.BlockNumber(number))
@@ -227,7 +299,12 @@ func (f *Filter) indexedLogs(ctx context.Context, end uint64) ([]*types.Log, err
 				return logs, err
 			}
 			logs = append(logs, found...)
-			if len(logs) > maxItems {
+			f.begin = int64(number) + 1
+			if f.pageLimit > 0 {
+				if len(logs) >= f.pageLimit {
+					return logs, errPageFilled
+				}
+			} else if len(logs) > maxItems {
 				return logs, errors.New("query returned more than " + strconv.Itoa(maxItems) + " results")
 			}
 		case <-ctx.Done():
@@ -239,6 +316,96 @@ func (f *Filter) indexedLogs(ctx context.Context, end uint64) ([]*types.Log, err
 	}
 }
 
+// parallelIndexedLogs returns the logs matching the filter criteria like indexedLogs, but searches
+// the bloombits sections concurrently by at most logSearchWorkers workers. The search is aborted as soon
+// as a section fails or the context is canceled.
+func (f *Filter) parallelIndexedLogs(ctx context.Context, size, end uint64) ([]*types.Log, error) {
+	searchCtx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	var (
+		first, last = uint64(f.begin) / size, end / size
+		results     = make([][]*types.Log, last-first+1)
+		sections    = make(chan uint64)
+		maxItems    = getMaxItems(ctx)
+
+		mu       sync.Mutex
+		found    int
+		firstErr error
+		wg       sync.WaitGroup
+	)
+	// fail records the first error and aborts the other workers.
+	fail := func(err error) {
+		mu.Lock()
+		if firstErr == nil {
+			firstErr = err
+		}
+		mu.Unlock()
+		cancel()
+	}
+	workers := logSearchWorkers
+	if n := int(last - first + 1); n < workers {
+		workers = n
+	}
+	for i := 0; i < workers; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for section := range sections {
+				begin, stop := section*size, (section+1)*size-1
+				if begin < uint64(f.begin) {
+					begin = uint64(f.begin)
+				}
+				if stop > end {
+					stop = end
+				}
+				sub := newFilter(f.backend, f.addresses, f.topics)
+				sub.matcher = bloombits.NewMatcher(size, f.bloomFilters)
+				sub.begin = int64(begin)
+
+				logs, err := sub.indexedLogs(searchCtx, stop)
+				results[section-first] = logs
+				if err != nil {
+					fail(err)
+					continue
+				}
+				mu.Lock()
+				found += len(logs)
+				exceeded := found > maxItems
+				mu.Unlock()
+				if exceeded {
+					fail(errors.New("query returned more than " + strconv.Itoa(maxItems) + " results"))
+				}
+			}
+		}()
+	}
+feed:
+	for section := first; section <= last; section++ {
+		select {
+		case sections <- section:
+		case <-searchCtx.Done():
+			break feed
+		}
+	}
+	close(sections)
+	wg.Wait()
+
+	var logs []*types.Log
+	for _, result := range results {
+		logs = append(logs, result...)
+	}
+	if firstErr != nil {
+		return logs, firstErr
+	}
+	if ctx.Err() == context.DeadlineExceeded {
+		return logs, errors.New("query timeout exceeded")
+	} else if ctx.Err() != nil {
+		return logs, errors.New("query is canceled. " + ctx.Err().Error())
+	}
+	f.begin = int64(end) + 1
+	return logs, nil
+}
+
 // indexedLogs returns the logs matching the filter criteria based on raw block
 // iteration and bloom matching.
 func (f *Filter) unindexedLogs(ctx context.Context, end uint64) ([]*types.Log, error) {
@@ -247,6 +414,15 @@ func (f *Filter) unindexedLogs(ctx context.Context, end uint64) ([]*types.Log, e
 	maxItems := getMaxItems(ctx)
 
 	for ; f.begin <= int64(end); f.begin++ {
+		// Check the context before searching a block, so that f.begin is the first block not searched yet.
+		select {
+		case <-ctx.Done():
+			if ctx.Err() == context.DeadlineExceeded {
+				return logs, errors.New("query timeout exceeded")
+			}
+			return logs, errors.New("query is canceled. " + ctx.Err().Error())
+		default:
+		}
 		header, err := f.backend.HeaderByNumber(ctx, rpc.BlockNumber(f.begin))
 		if header == nil || err != nil {
 			return logs, err
@@ -257,18 +433,15 @@ func (f *Filter) unindexedLogs(ctx context.Context, end uint64) ([]*types.Log, e
 				return logs, err
 			}
 			logs = append(logs, found...)
-			if len(logs) > maxItems {
+			if f.pageLimit > 0 {
+				if len(logs) >= f.pageLimit {
+					f.begin++
+					return logs, errPageFilled
+				}
+			} else if len(logs) > maxItems {
 				return logs, errors.New("query returned more than " + strconv.Itoa(maxItems) + " results")
 			}
 		}
-		select {
-		case <-ctx.Done():
-			if ctx.Err() == context.DeadlineExceeded {
-				return logs, errors.New("query timeout exceeded")
-			}
-			return logs, errors.New("query is canceled. " + ctx.Err().Error())
-		default:
-		}
 	}
 	return logs, nil
 }
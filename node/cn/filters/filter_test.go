@@ -23,10 +23,13 @@ package filters
 import (
 	"context"
 	"math/big"
+	"runtime"
 	"testing"
+	"time"
 
 	"github.com/golang/mock/gomock"
 	"github.com/klaytn/klaytn/blockchain"
+	"github.com/klaytn/klaytn/blockchain/bloombits"
 	"github.com/klaytn/klaytn/blockchain/types"
 	"github.com/klaytn/klaytn/common"
 	"github.com/klaytn/klaytn/consensus/gxhash"
@@ -356,4 +359,239 @@ func TestFilters(t *testing.T) {
 	if len(logs) != 0 {
 		t.Error("expected 0 log, got", len(logs))
 	}
+
+	// A page stops at the block boundary after the limit is reached, and is continued from the cursor.
+	filter = NewRangeFilter(backend, 0, -1, []common.Address{addr}, [][]common.Hash{{hash1, hash2, hash3, hash4}})
+	logs, next, err := filter.LogsPage(context.Background(), 2)
+	assert.NoError(t, err)
+	assert.Len(t, logs, 2)
+	if assert.NotNil(t, next) {
+		assert.Equal(t, uint64(4), *next)
+	}
+
+	filter = NewRangeFilter(backend, int64(*next), -1, []common.Address{addr}, [][]common.Hash{{hash1, hash2, hash3, hash4}})
+	logs, next, err = filter.LogsPage(context.Background(), 10)
+	assert.NoError(t, err)
+	assert.Len(t, logs, 2)
+	assert.Nil(t, next)
+
+	// A query over the maximum block range fails, but a page stops at the maximum block range.
+	crit := [][]common.Hash{{hash1, hash2, hash3, hash4}}
+	filter = NewRangeFilter(backend, 0, -1, []common.Address{addr}, crit)
+	filter.maxBlockRange = 500
+	_, err = filter.Logs(context.Background())
+	assert.Error(t, err)
+
+	for _, page := range []struct {
+		from    int64
+		numLogs int
+		next    *uint64
+	}{
+		{0, 2, func() *uint64 { n := uint64(500); return &n }()},
+		{500, 1, func() *uint64 { n := uint64(1000); return &n }()},
+		{1000, 1, nil},
+	} {
+		filter = NewRangeFilter(backend, page.from, -1, []common.Address{addr}, crit)
+		filter.maxBlockRange = 500
+		logs, next, err = filter.LogsPage(context.Background(), 10)
+		assert.NoError(t, err)
+		assert.Len(t, logs, page.numLogs)
+		assert.Equal(t, page.next, next)
+	}
+
+	// The logs indexed by the log index are served by it, and the rest are searched by the bloombits.
+	index := &testLogIndex{first: 0, last: 500, logs: []*types.Log{{Address: addr, Topics: []common.Hash{hash1}}}}
+	filter = NewRangeFilter(backend, 0, -1, []common.Address{addr}, [][]common.Hash{{hash1, hash2, hash3, hash4}})
+	filter.logIndex = index
+	logs, err = filter.Logs(context.Background())
+	assert.NoError(t, err)
+	assert.Len(t, logs, 3)
+
+	// The bloombits are used if the log index fails.
+	index.err = someErr
+	filter = NewRangeFilter(backend, 0, -1, []common.Address{addr}, [][]common.Hash{{hash1, hash2, hash3, hash4}})
+	filter.logIndex = index
+	logs, err = filter.Logs(context.Background())
+	assert.NoError(t, err)
+	assert.Len(t, logs, 4)
+}
+
+type testLogIndex struct {
+	first, last uint64
+	logs        []*types.Log
+	err         error
+}
+
+func (i *testLogIndex) IndexLogs(block *types.Block, logs []*types.Log) error { return nil }
+func (i *testLogIndex) RemoveLogs(logs []*types.Log) error                    { return nil }
+func (i *testLogIndex) IndexedRange() (uint64, uint64, bool)                  { return i.first, i.last, true }
+func (i *testLogIndex) Close() error                                          { return nil }
+
+func (i *testLogIndex) FilterLogs(ctx context.Context, begin, end uint64, addresses []common.Address, topics [][]common.Hash) ([]*types.Log, error) {
+	return i.logs, i.err
+}
+
+// sectionTestBackend serves the bloombits of the sections of the given size from memory.
+type sectionTestBackend struct {
+	*testBackend
+	size    uint64
+	bitsets [][][]byte // the bitsets of each bloom bit of each section
+
+	failSection uint64 // the section whose retrieval fails with err if err is not nil
+	err         error
+}
+
+func (b *sectionTestBackend) BloomStatus() (uint64, uint64) {
+	return b.size, uint64(len(b.bitsets))
+}
+
+func (b *sectionTestBackend) ServiceFilter(ctx context.Context, session *bloombits.MatcherSession) {
+	requests := make(chan chan *bloombits.Retrieval)
+
+	go session.Multiplex(16, 0, requests)
+	go func() {
+		for {
+			select {
+			case <-ctx.Done():
+				return
+
+			case request := <-requests:
+				task := <-request
+
+				task.Bitsets = make([][]byte, len(task.Sections))
+				for i, section := range task.Sections {
+					if b.err != nil && section == b.failSection {
+						task.Error = b.err
+					}
+					task.Bitsets[i] = b.bitsets[section][task.Bit]
+				}
+				request <- task
+			}
+		}
+	}()
+}
+
+// newSectionTestBackend returns a backend serving the chain of the given number of blocks
+// with a log of addr at each of logBlocks, and the bloombits of all the full sections of the given size.
+func newSectionTestBackend(t *testing.T, numBlocks int, size uint64, addr common.Address, logBlocks []int) *sectionTestBackend {
+	db := database.NewMemoryDBManager()
+	genesis := blockchain.GenesisBlockForTesting(db, addr, big.NewInt(1000000))
+	hasLog := make(map[int]bool)
+	for _, number := range logBlocks {
+		hasLog[number] = true
+	}
+	chain, receipts := blockchain.GenerateChain(params.TestChainConfig, genesis, gxhash.NewFaker(), db, numBlocks, func(i int, gen *blockchain.BlockGen) {
+		if number := i + 1; hasLog[number] {
+			receipt := genReceipt(false, 0)
+			receipt.Logs = []*types.Log{{Address: addr, Topics: []common.Hash{common.BigToHash(big.NewInt(int64(number)))}, BlockNumber: uint64(number)}}
+			gen.AddUncheckedReceipt(receipt)
+			gen.AddUncheckedTx(types.NewTransaction(uint64(number), common.HexToAddress("0x1"), big.NewInt(1), 1, big.NewInt(1), nil))
+		}
+	})
+	headers := []*types.Header{genesis.Header()}
+	for i, block := range chain {
+		db.WriteBlock(block)
+		db.WriteCanonicalHash(block.Hash(), block.NumberU64())
+		db.WriteHeadBlockHash(block.Hash())
+		db.WriteReceipts(block.Hash(), block.NumberU64(), receipts[i])
+		headers = append(headers, block.Header())
+	}
+
+	var bitsets [][][]byte
+	for section := uint64(0); (section+1)*size <= uint64(len(headers)); section++ {
+		gen, err := bloombits.NewGenerator(uint(size))
+		if err != nil {
+			t.Fatal(err)
+		}
+		for i := uint64(0); i < size; i++ {
+			if err := gen.AddBloom(uint(i), headers[section*size+i].Bloom); err != nil {
+				t.Fatal(err)
+			}
+		}
+		bits := make([][]byte, types.BloomBitLength)
+		for bit := range bits {
+			bits[bit], _ = gen.Bitset(uint(bit))
+		}
+		bitsets = append(bitsets, bits)
+	}
+	return &sectionTestBackend{
+		testBackend: &testBackend{new(event.TypeMux), db, 0, new(event.Feed), new(event.Feed), new(event.Feed), new(event.Feed), params.TestChainConfig},
+		size:        size,
+		bitsets:     bitsets,
+	}
+}
+
+// logBlockNumbers returns the block numbers of the given logs.
+func logBlockNumbers(logs []*types.Log) []uint64 {
+	numbers := make([]uint64, len(logs))
+	for i, log := range logs {
+		numbers[i] = log.BlockNumber
+	}
+	return numbers
+}
+
+func TestFilter_parallelIndexedLogs(t *testing.T) {
+	defer func(workers int) { logSearchWorkers = workers }(logSearchWorkers)
+	logSearchWorkers = 4
+
+	var (
+		addr    = common.HexToAddress("0x1234")
+		size    = uint64(16)
+		backend = newSectionTestBackend(t, 100, size, addr, []int{3, 17, 18, 40, 41, 70, 95, 98})
+	)
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	// The logs of the sections searched concurrently are returned in the same order as the sequential search.
+	sequential := NewRangeFilter(backend, 1, 95, []common.Address{addr}, nil)
+	expected, err := sequential.indexedLogs(ctx, 95)
+	assert.NoError(t, err)
+	assert.Equal(t, []uint64{3, 17, 18, 40, 41, 70, 95}, logBlockNumbers(expected))
+
+	parallel := NewRangeFilter(backend, 1, 95, []common.Address{addr}, nil)
+	logs, err := parallel.parallelIndexedLogs(ctx, size, 95)
+	assert.NoError(t, err)
+	assert.Equal(t, expected, logs)
+	assert.Equal(t, sequential.begin, parallel.begin)
+
+	// The logs of the blocks which are not indexed yet follow the indexed ones.
+	filter := NewRangeFilter(backend, 0, -1, []common.Address{addr}, nil)
+	logs, err = filter.Logs(ctx)
+	assert.NoError(t, err)
+	assert.Equal(t, []uint64{3, 17, 18, 40, 41, 70, 95, 98}, logBlockNumbers(logs))
+}
+
+func TestFilter_parallelIndexedLogsAborted(t *testing.T) {
+	defer func(workers int) { logSearchWorkers = workers }(logSearchWorkers)
+	logSearchWorkers = 4
+
+	var (
+		addr    = common.HexToAddress("0x1234")
+		size    = uint64(16)
+		backend = newSectionTestBackend(t, 100, size, addr, []int{3, 17, 40, 70, 95})
+		before  = runtime.NumGoroutine()
+	)
+
+	// The search fails if a section fails.
+	backend.failSection, backend.err = 3, someErr
+	filter := NewRangeFilter(backend, 1, 95, []common.Address{addr}, nil)
+	_, err := filter.parallelIndexedLogs(context.Background(), size, 95)
+	assert.Equal(t, someErr, err)
+	assert.Equal(t, int64(1), filter.begin)
+	backend.err = nil
+
+	// The search fails if the context is canceled.
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	filter = NewRangeFilter(backend, 1, 95, []common.Address{addr}, nil)
+	_, err = filter.parallelIndexedLogs(ctx, size, 95)
+	if assert.Error(t, err) {
+		assert.Contains(t, err.Error(), "canceled")
+	}
+	assert.Equal(t, int64(1), filter.begin)
+
+	// All the workers and the retrievals of the sections are stopped.
+	assert.Eventually(t, func() bool {
+		return runtime.NumGoroutine() <= before
+	}, 5*time.Second, 10*time.Millisecond)
 }
@@ -0,0 +1,173 @@
+// Copyright 2022 The klaytn Authors
+// This file is part of the klaytn library.
+//
+// The klaytn library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The klaytn library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the klaytn library. If not, see <http://www.gnu.org/licenses/>.
+
+package filters
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"sync"
+
+	"github.com/klaytn/klaytn/blockchain"
+	"github.com/klaytn/klaytn/blockchain/types"
+	"github.com/klaytn/klaytn/common"
+	"github.com/klaytn/klaytn/event"
+)
+
+// LogIndex is an external index of logs, such as Elasticsearch or ClickHouse, which serves range
+// queries of logs instead of the bloombits. It is kept in sync with the chain by LogIndexSyncer.
+// The methods can be called concurrently.
+type LogIndex interface {
+	// IndexLogs stores the logs of the given block, which is inserted into the canonical chain.
+	IndexLogs(block *types.Block, logs []*types.Log) error
+	// RemoveLogs removes the logs which are not in the canonical chain anymore due to a reorganization.
+	RemoveLogs(logs []*types.Log) error
+	// IndexedRange returns the range of the blocks whose logs are all indexed.
+	// It returns false if the index is not available.
+	IndexedRange() (first, last uint64, ok bool)
+	// FilterLogs returns the logs in the given range of blocks matching the given criteria
+	// in the order of the block numbers and the log indexes.
+	FilterLogs(ctx context.Context, begin, end uint64, addresses []common.Address, topics [][]common.Hash) ([]*types.Log, error)
+	// Close releases the resources of the index.
+	Close() error
+}
+
+// LogIndexFactory creates a LogIndex connected to the given endpoint.
+type LogIndexFactory func(endpoint string) (LogIndex, error)
+
+var (
+	logIndexFactoriesMu sync.RWMutex
+	logIndexFactories   = make(map[string]LogIndexFactory)
+)
+
+// RegisterLogIndex makes a LogIndex backend available by the given name.
+// It is intended to be called from the init function of the package implementing the backend.
+func RegisterLogIndex(name string, factory LogIndexFactory) {
+	logIndexFactoriesMu.Lock()
+	defer logIndexFactoriesMu.Unlock()
+
+	if _, exists := logIndexFactories[name]; exists {
+		logger.Crit("Log index backend is registered twice", "name", name)
+	}
+	logIndexFactories[name] = factory
+}
+
+// LogIndexBackends returns the names of the registered LogIndex backends.
+func LogIndexBackends() []string {
+	logIndexFactoriesMu.RLock()
+	defer logIndexFactoriesMu.RUnlock()
+
+	names := make([]string, 0, len(logIndexFactories))
+	for name := range logIndexFactories {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// NewLogIndex creates a LogIndex of the registered backend of the given name.
+func NewLogIndex(name, endpoint string) (LogIndex, error) {
+	logIndexFactoriesMu.RLock()
+	factory, ok := logIndexFactories[name]
+	logIndexFactoriesMu.RUnlock()
+
+	if !ok {
+		return nil, fmt.Errorf("unknown log index backend %q (available: %v)", name, LogIndexBackends())
+	}
+	return factory(endpoint)
+}
+
+// LogIndexSyncer keeps a LogIndex in sync with the chain by the chain events and the removed logs events.
+type LogIndexSyncer struct {
+	index     LogIndex
+	chainCh   chan blockchain.ChainEvent
+	rmLogsCh  chan blockchain.RemovedLogsEvent
+	chainSub  event.Subscription
+	rmLogsSub event.Subscription
+	quit      chan struct{}
+	wg        sync.WaitGroup
+}
+
+// NewLogIndexSyncer subscribes the chain events of the backend and starts indexing the logs to the given index.
+func NewLogIndexSyncer(backend Backend, index LogIndex) *LogIndexSyncer {
+	s := &LogIndexSyncer{
+		index:    index,
+		chainCh:  make(chan blockchain.ChainEvent, chainEvChanSize),
+		rmLogsCh: make(chan blockchain.RemovedLogsEvent, rmLogsChanSize),
+		quit:     make(chan struct{}),
+	}
+	s.chainSub = backend.SubscribeChainEvent(s.chainCh)
+	s.rmLogsSub = backend.SubscribeRemovedLogsEvent(s.rmLogsCh)
+
+	s.wg.Add(1)
+	go s.loop()
+	return s
+}
+
+func (s *LogIndexSyncer) loop() {
+	defer s.wg.Done()
+	defer s.chainSub.Unsubscribe()
+	defer s.rmLogsSub.Unsubscribe()
+
+	for {
+		select {
+		case ev := <-s.chainCh:
+			if err := s.index.IndexLogs(ev.Block, ev.Logs); err != nil {
+				logger.Error("Failed to index logs to the log index", "blockNum", ev.Block.NumberU64(), "err", err)
+			}
+		case ev := <-s.rmLogsCh:
+			if err := s.index.RemoveLogs(ev.Logs); err != nil {
+				logger.Error("Failed to remove logs from the log index", "logs", len(ev.Logs), "err", err)
+			}
+		case <-s.chainSub.Err():
+			return
+		case <-s.rmLogsSub.Err():
+			return
+		case <-s.quit:
+			return
+		}
+	}
+}
+
+// Stop stops syncing and closes the index.
+func (s *LogIndexSyncer) Stop() {
+	close(s.quit)
+	s.wg.Wait()
+	if err := s.index.Close(); err != nil {
+		logger.Error("Failed to close the log index", "err", err)
+	}
+}
+
+// logIndexLogs returns the logs from f.begin to end served by the log index, and advances f.begin to the
+// first block not served. It returns nothing if the log index is not available or fails, so that
+// the logs are searched by the bloombits instead.
+func (f *Filter) logIndexLogs(ctx context.Context, end uint64) []*types.Log {
+	first, last, ok := f.logIndex.IndexedRange()
+	if !ok || uint64(f.begin) < first || uint64(f.begin) > last {
+		return nil
+	}
+	if last > end {
+		last = end
+	}
+	logs, err := f.logIndex.FilterLogs(ctx, uint64(f.begin), last, f.addresses, f.topics)
+	if err != nil {
+		logger.Warn("Failed to filter logs by the log index, falling back to bloombits", "begin", f.begin, "end", last, "err", err)
+		return nil
+	}
+	f.begin = int64(last) + 1
+	return logs
+}
@@ -0,0 +1,243 @@
+// Copyright 2022 The klaytn Authors
+// This file is part of the klaytn library.
+//
+// The klaytn library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The klaytn library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the klaytn library. If not, see <http://www.gnu.org/licenses/>.
+
+package filters
+
+import (
+	"context"
+	"encoding/binary"
+	"encoding/json"
+	"sort"
+	"sync"
+
+	"github.com/klaytn/klaytn/blockchain/types"
+	"github.com/klaytn/klaytn/common"
+	"github.com/klaytn/klaytn/storage/database"
+)
+
+// DBLogIndexBackend is the name of the built-in LogIndex backend storing the index in a local LevelDB.
+// Its endpoint is the path of the database directory.
+const DBLogIndexBackend = "leveldb"
+
+var (
+	logIndexAddressPrefix = []byte("la") // logIndexAddressPrefix + address + num (uint64 big endian) -> empty
+	logIndexTopicPrefix   = []byte("lt") // logIndexTopicPrefix + topic0 + num (uint64 big endian) -> empty
+	logIndexBlockPrefix   = []byte("lb") // logIndexBlockPrefix + num (uint64 big endian) -> logs of the block
+	logIndexRangeKey      = []byte("LogIndexRange")
+)
+
+func init() {
+	RegisterLogIndex(DBLogIndexBackend, func(endpoint string) (LogIndex, error) {
+		db, err := database.NewLevelDBWithOption(endpoint, database.GetDefaultLevelDBOption())
+		if err != nil {
+			return nil, err
+		}
+		return NewDBLogIndex(db), nil
+	})
+}
+
+func logIndexAddressKey(address common.Address, number uint64) []byte {
+	return append(append(append([]byte{}, logIndexAddressPrefix...), address.Bytes()...), encodeLogIndexNumber(number)...)
+}
+
+func logIndexTopicKey(topic common.Hash, number uint64) []byte {
+	return append(append(append([]byte{}, logIndexTopicPrefix...), topic.Bytes()...), encodeLogIndexNumber(number)...)
+}
+
+func logIndexBlockKey(number uint64) []byte {
+	return append(append([]byte{}, logIndexBlockPrefix...), encodeLogIndexNumber(number)...)
+}
+
+func encodeLogIndexNumber(number uint64) []byte {
+	enc := make([]byte, 8)
+	binary.BigEndian.PutUint64(enc, number)
+	return enc
+}
+
+// DBLogIndex is a LogIndex kept in a key-value database. It stores the logs of each block, and the inverted
+// indexes from the addresses and the first topics of the logs to the numbers of the blocks having them,
+// so that the logs of an address or an event are found without scanning the blocks.
+type DBLogIndex struct {
+	db database.Database
+	mu sync.RWMutex // Protects the indexed range and the entries of a block being updated
+}
+
+// NewDBLogIndex returns a DBLogIndex stored in the given database.
+func NewDBLogIndex(db database.Database) *DBLogIndex {
+	return &DBLogIndex{db: db}
+}
+
+// IndexLogs stores the logs of the given block, replacing the logs previously stored at the block number.
+func (idx *DBLogIndex) IndexLogs(block *types.Block, logs []*types.Log) error {
+	number := block.NumberU64()
+
+	idx.mu.Lock()
+	defer idx.mu.Unlock()
+
+	batch := idx.db.NewBatch()
+	if len(logs) == 0 {
+		batch.Delete(logIndexBlockKey(number))
+	} else {
+		enc, err := json.Marshal(logs)
+		if err != nil {
+			return err
+		}
+		batch.Put(logIndexBlockKey(number), enc)
+		for _, log := range logs {
+			batch.Put(logIndexAddressKey(log.Address, number), nil)
+			if len(log.Topics) > 0 {
+				batch.Put(logIndexTopicKey(log.Topics[0], number), nil)
+			}
+		}
+	}
+
+	// The indexed range is restarted from the block if any block is missing in between.
+	first, last, ok := idx.indexedRange()
+	switch {
+	case !ok || number > last+1 || number < first:
+		first, last = number, number
+	case number == last+1:
+		last = number
+	}
+	batch.Put(logIndexRangeKey, append(encodeLogIndexNumber(first), encodeLogIndexNumber(last)...))
+	return batch.Write()
+}
+
+// RemoveLogs removes the logs of the blocks which have the given logs. The logs of a block are
+// not removed if they have been replaced by the logs of another block at the same number.
+func (idx *DBLogIndex) RemoveLogs(logs []*types.Log) error {
+	blockHashes := make(map[uint64]common.Hash)
+	for _, log := range logs {
+		blockHashes[log.BlockNumber] = log.BlockHash
+	}
+
+	idx.mu.Lock()
+	defer idx.mu.Unlock()
+
+	batch := idx.db.NewBatch()
+	for number, hash := range blockHashes {
+		stored, err := idx.blockLogs(number)
+		if err != nil {
+			return err
+		}
+		if len(stored) == 0 || stored[0].BlockHash != hash {
+			continue
+		}
+		batch.Delete(logIndexBlockKey(number))
+		for _, log := range stored {
+			batch.Delete(logIndexAddressKey(log.Address, number))
+			if len(log.Topics) > 0 {
+				batch.Delete(logIndexTopicKey(log.Topics[0], number))
+			}
+		}
+	}
+	return batch.Write()
+}
+
+// IndexedRange returns the range of the blocks whose logs are all indexed.
+func (idx *DBLogIndex) IndexedRange() (first, last uint64, ok bool) {
+	idx.mu.RLock()
+	defer idx.mu.RUnlock()
+
+	return idx.indexedRange()
+}
+
+func (idx *DBLogIndex) indexedRange() (first, last uint64, ok bool) {
+	enc, err := idx.db.Get(logIndexRangeKey)
+	if err != nil || len(enc) != 16 {
+		return 0, 0, false
+	}
+	return binary.BigEndian.Uint64(enc[:8]), binary.BigEndian.Uint64(enc[8:]), true
+}
+
+// FilterLogs returns the logs in the given range of blocks matching the given criteria. The blocks are
+// looked up by the addresses if given, by the first topics if given, and are all scanned otherwise.
+func (idx *DBLogIndex) FilterLogs(ctx context.Context, begin, end uint64, addresses []common.Address, topics [][]common.Hash) ([]*types.Log, error) {
+	idx.mu.RLock()
+	defer idx.mu.RUnlock()
+
+	var numbers []uint64
+	switch {
+	case len(addresses) > 0:
+		for _, address := range addresses {
+			numbers = append(numbers, idx.blockNumbers(append(append([]byte{}, logIndexAddressPrefix...), address.Bytes()...), begin, end)...)
+		}
+	case len(topics) > 0 && len(topics[0]) > 0:
+		for _, topic := range topics[0] {
+			numbers = append(numbers, idx.blockNumbers(append(append([]byte{}, logIndexTopicPrefix...), topic.Bytes()...), begin, end)...)
+		}
+	default:
+		numbers = idx.blockNumbers(logIndexBlockPrefix, begin, end)
+	}
+	sort.Slice(numbers, func(i, j int) bool { return numbers[i] < numbers[j] })
+
+	var ret []*types.Log
+	for i, number := range numbers {
+		if i > 0 && numbers[i-1] == number {
+			continue
+		}
+		select {
+		case <-ctx.Done():
+			return nil, ctx.Err()
+		default:
+		}
+		logs, err := idx.blockLogs(number)
+		if err != nil {
+			return nil, err
+		}
+		ret = append(ret, filterLogs(logs, nil, nil, addresses, topics)...)
+	}
+	return ret, nil
+}
+
+// blockNumbers returns the block numbers from begin to end of the keys having the given prefix.
+func (idx *DBLogIndex) blockNumbers(prefix []byte, begin, end uint64) []uint64 {
+	it := idx.db.NewIterator(prefix, encodeLogIndexNumber(begin))
+	defer it.Release()
+
+	var numbers []uint64
+	for it.Next() {
+		key := it.Key()
+		if len(key) != len(prefix)+8 {
+			continue
+		}
+		number := binary.BigEndian.Uint64(key[len(prefix):])
+		if number > end {
+			break
+		}
+		numbers = append(numbers, number)
+	}
+	return numbers
+}
+
+// blockLogs returns the logs stored at the given block number.
+func (idx *DBLogIndex) blockLogs(number uint64) ([]*types.Log, error) {
+	enc, err := idx.db.Get(logIndexBlockKey(number))
+	if err != nil || len(enc) == 0 {
+		return nil, nil
+	}
+	var logs []*types.Log
+	if err := json.Unmarshal(enc, &logs); err != nil {
+		return nil, err
+	}
+	return logs, nil
+}
+
+// Close closes the database of the index.
+func (idx *DBLogIndex) Close() error {
+	idx.db.Close()
+	return nil
+}
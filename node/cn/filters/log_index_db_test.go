@@ -0,0 +1,100 @@
+// Copyright 2022 The klaytn Authors
+// This file is part of the klaytn library.
+//
+// The klaytn library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The klaytn library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the klaytn library. If not, see <http://www.gnu.org/licenses/>.
+
+package filters
+
+import (
+	"context"
+	"math/big"
+	"testing"
+
+	"github.com/klaytn/klaytn/blockchain/types"
+	"github.com/klaytn/klaytn/common"
+	"github.com/klaytn/klaytn/storage/database"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDBLogIndex(t *testing.T) {
+	var (
+		addr1  = common.HexToAddress("0x1111")
+		addr2  = common.HexToAddress("0x2222")
+		topic1 = common.HexToHash("0x3333")
+		topic2 = common.HexToHash("0x4444")
+		ctx    = context.Background()
+	)
+	newBlock := func(number int64) *types.Block {
+		return types.NewBlockWithHeader(&types.Header{Number: big.NewInt(number)})
+	}
+	newLog := func(block *types.Block, address common.Address, topics ...common.Hash) *types.Log {
+		return &types.Log{Address: address, Topics: append([]common.Hash{}, topics...), Data: []byte{}, BlockNumber: block.NumberU64(), BlockHash: block.Hash()}
+	}
+
+	idx := NewDBLogIndex(database.NewMemDB())
+	_, _, ok := idx.IndexedRange()
+	assert.False(t, ok)
+
+	blocks := make([]*types.Block, 5)
+	logs := make([][]*types.Log, 5)
+	for i := range blocks {
+		blocks[i] = newBlock(int64(10 + i))
+	}
+	logs[0] = []*types.Log{newLog(blocks[0], addr1, topic1)}
+	logs[2] = []*types.Log{newLog(blocks[2], addr2, topic2), newLog(blocks[2], addr1, topic2)}
+	logs[4] = []*types.Log{newLog(blocks[4], addr2, topic1)}
+	for i := range blocks {
+		require.NoError(t, idx.IndexLogs(blocks[i], logs[i]))
+	}
+
+	first, last, ok := idx.IndexedRange()
+	assert.True(t, ok)
+	assert.Equal(t, uint64(10), first)
+	assert.Equal(t, uint64(14), last)
+
+	found, err := idx.FilterLogs(ctx, 10, 14, []common.Address{addr1}, nil)
+	require.NoError(t, err)
+	assert.Equal(t, []*types.Log{logs[0][0], logs[2][1]}, found)
+
+	found, err = idx.FilterLogs(ctx, 11, 14, nil, [][]common.Hash{{topic1}})
+	require.NoError(t, err)
+	assert.Equal(t, []*types.Log{logs[4][0]}, found)
+
+	found, err = idx.FilterLogs(ctx, 10, 13, []common.Address{addr2}, [][]common.Hash{{topic2}})
+	require.NoError(t, err)
+	assert.Equal(t, []*types.Log{logs[2][0]}, found)
+
+	found, err = idx.FilterLogs(ctx, 10, 14, nil, nil)
+	require.NoError(t, err)
+	assert.Len(t, found, 4)
+
+	// The logs replaced by a reorganization are kept, while the logs of the removed block are removed
+	reorged := types.NewBlockWithHeader(&types.Header{Number: big.NewInt(14), Extra: []byte{1}})
+	require.NoError(t, idx.IndexLogs(reorged, []*types.Log{newLog(reorged, addr1)}))
+	require.NoError(t, idx.RemoveLogs(logs[4]))
+	require.NoError(t, idx.RemoveLogs(logs[2]))
+
+	found, err = idx.FilterLogs(ctx, 10, 14, nil, nil)
+	require.NoError(t, err)
+	assert.Len(t, found, 2)
+	assert.Equal(t, reorged.Hash(), found[1].BlockHash)
+
+	// The indexed range is restarted if a block is missing
+	require.NoError(t, idx.IndexLogs(newBlock(20), nil))
+	first, last, ok = idx.IndexedRange()
+	assert.True(t, ok)
+	assert.Equal(t, uint64(20), first)
+	assert.Equal(t, uint64(20), last)
+}
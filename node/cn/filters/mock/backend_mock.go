@@ -198,6 +198,20 @@ func (mr *MockBackendMockRecorder) SubscribeLogsEvent(ch interface{}) *gomock.Ca
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SubscribeLogsEvent", reflect.TypeOf((*MockBackend)(nil).SubscribeLogsEvent), ch)
 }
 
+// SubscribeDroppedTxsEvent mocks base method.
+func (m *MockBackend) SubscribeDroppedTxsEvent(arg0 chan<- blockchain.DroppedTxsEvent) event.Subscription {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SubscribeDroppedTxsEvent", arg0)
+	ret0, _ := ret[0].(event.Subscription)
+	return ret0
+}
+
+// SubscribeDroppedTxsEvent indicates an expected call of SubscribeDroppedTxsEvent.
+func (mr *MockBackendMockRecorder) SubscribeDroppedTxsEvent(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SubscribeDroppedTxsEvent", reflect.TypeOf((*MockBackend)(nil).SubscribeDroppedTxsEvent), arg0)
+}
+
 // SubscribeNewTxsEvent mocks base method.
 func (m *MockBackend) SubscribeNewTxsEvent(arg0 chan<- blockchain.NewTxsEvent) event.Subscription {
 	m.ctrl.T.Helper()
@@ -225,3 +239,17 @@ func (mr *MockBackendMockRecorder) SubscribeRemovedLogsEvent(ch interface{}) *go
 	mr.mock.ctrl.T.Helper()
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SubscribeRemovedLogsEvent", reflect.TypeOf((*MockBackend)(nil).SubscribeRemovedLogsEvent), ch)
 }
+
+// SubscribeReorgEvent mocks base method.
+func (m *MockBackend) SubscribeReorgEvent(ch chan<- blockchain.ReorgEvent) event.Subscription {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SubscribeReorgEvent", ch)
+	ret0, _ := ret[0].(event.Subscription)
+	return ret0
+}
+
+// SubscribeReorgEvent indicates an expected call of SubscribeReorgEvent.
+func (mr *MockBackendMockRecorder) SubscribeReorgEvent(ch interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SubscribeReorgEvent", reflect.TypeOf((*MockBackend)(nil).SubscribeReorgEvent), ch)
+}
@@ -0,0 +1,124 @@
+// Copyright 2022 The klaytn Authors
+// This file is part of the klaytn library.
+//
+// The klaytn library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The klaytn library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the klaytn library. If not, see <http://www.gnu.org/licenses/>.
+
+package filters
+
+import (
+	"bytes"
+	"context"
+	"math/big"
+
+	"github.com/klaytn/klaytn/blockchain/types"
+	"github.com/klaytn/klaytn/common"
+	"github.com/klaytn/klaytn/common/hexutil"
+	"github.com/klaytn/klaytn/networks/rpc"
+)
+
+// PendingTxCriteria selects the pending transactions notified by a newPendingTransactions subscription.
+// An empty field matches any transaction.
+type PendingTxCriteria struct {
+	ToAddresses     []common.Address `json:"toAddresses"`     // recipients of the transaction
+	MethodSelectors []hexutil.Bytes  `json:"methodSelectors"` // prefixes of the input data, usually 4-byte selectors
+	MinGasPrice     *hexutil.Big     `json:"minGasPrice"`     // lower bound of the gas price, or the fee cap of a dynamic fee transaction
+}
+
+// Match reports whether the transaction satisfies all the criteria.
+func (crit *PendingTxCriteria) Match(tx *types.Transaction) bool {
+	if crit == nil {
+		return true
+	}
+	if len(crit.ToAddresses) > 0 {
+		to := tx.To()
+		if to == nil || !includes(crit.ToAddresses, *to) {
+			return false
+		}
+	}
+	if len(crit.MethodSelectors) > 0 {
+		data, matched := tx.Data(), false
+		for _, selector := range crit.MethodSelectors {
+			if bytes.HasPrefix(data, selector) {
+				matched = true
+				break
+			}
+		}
+		if !matched {
+			return false
+		}
+	}
+	if crit.MinGasPrice != nil && tx.GasFeeCap().Cmp((*big.Int)(crit.MinGasPrice)) < 0 {
+		return false
+	}
+	return true
+}
+
+// SubscribePendingTransactions creates a subscription notifying the pending transactions matched
+// by crit. Each transaction is converted by marshal to the value sent in a notification,
+// and skipped if marshal returns nil.
+// It lets the APIs of other namespaces serve the transactions in their own representation.
+func SubscribePendingTransactions(ctx context.Context, api *PublicFilterAPI, crit *PendingTxCriteria, marshal func(tx *types.Transaction) interface{}) (*rpc.Subscription, error) {
+	notifier, supported := rpc.NotifierFromContext(ctx)
+	if !supported {
+		return &rpc.Subscription{}, rpc.ErrNotificationsUnsupported
+	}
+
+	rpcSub := notifier.CreateSubscription()
+
+	go func() {
+		txs := make(chan []*types.Transaction, 128)
+		pendingTxSub := api.events.SubscribeFullPendingTxs(txs)
+
+		for {
+			select {
+			case pending := <-txs:
+				for _, tx := range pending {
+					if !crit.Match(tx) {
+						continue
+					}
+					if v := marshal(tx); v != nil {
+						notifier.Notify(rpcSub.ID, v)
+					}
+				}
+			case <-rpcSub.Err():
+				pendingTxSub.Unsubscribe()
+				return
+			case <-notifier.Closed():
+				pendingTxSub.Unsubscribe()
+				return
+			}
+		}
+	}()
+
+	return rpcSub, nil
+}
+
+// rpcMarshalPendingTx converts the pending transaction to the RPC output of the klay namespace.
+func rpcMarshalPendingTx(tx *types.Transaction) interface{} {
+	output := tx.MakeRPCOutput()
+	output["senderTxHash"] = tx.SenderTxHashAll()
+	output["blockHash"] = common.Hash{}
+	output["blockNumber"] = (*hexutil.Big)(new(big.Int))
+	output["hash"] = tx.Hash()
+	output["transactionIndex"] = hexutil.Uint(0)
+	if tx.IsEthereumTransaction() {
+		output["from"], _ = types.Sender(types.LatestSignerForChainID(tx.ChainId()), tx)
+	} else {
+		output["from"], _ = tx.From()
+	}
+	if tx.Type() == types.TxTypeEthereumDynamicFee {
+		output["gasPrice"] = (*hexutil.Big)(tx.EffectiveGasPrice(nil))
+	}
+	return output
+}
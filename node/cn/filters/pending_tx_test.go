@@ -0,0 +1,95 @@
+// Copyright 2022 The klaytn Authors
+// This file is part of the klaytn library.
+//
+// The klaytn library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The klaytn library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the klaytn library. If not, see <http://www.gnu.org/licenses/>.
+
+package filters
+
+import (
+	"math/big"
+	"testing"
+	"time"
+
+	"github.com/klaytn/klaytn/blockchain"
+	"github.com/klaytn/klaytn/blockchain/types"
+	"github.com/klaytn/klaytn/common"
+	"github.com/klaytn/klaytn/common/hexutil"
+	"github.com/klaytn/klaytn/event"
+	"github.com/klaytn/klaytn/params"
+	"github.com/klaytn/klaytn/storage/database"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestPendingTxCriteria_Match(t *testing.T) {
+	var (
+		to1 = common.HexToAddress("0x1111")
+		to2 = common.HexToAddress("0x2222")
+		tx  = types.NewTransaction(0, to1, new(big.Int), 0, big.NewInt(25), common.FromHex("0xa9059cbb0000"))
+	)
+	var nilCrit *PendingTxCriteria
+	assert.True(t, nilCrit.Match(tx))
+	assert.True(t, (&PendingTxCriteria{}).Match(tx))
+
+	assert.True(t, (&PendingTxCriteria{ToAddresses: []common.Address{to2, to1}}).Match(tx))
+	assert.False(t, (&PendingTxCriteria{ToAddresses: []common.Address{to2}}).Match(tx))
+
+	assert.True(t, (&PendingTxCriteria{MethodSelectors: []hexutil.Bytes{common.FromHex("0x095ea7b3"), common.FromHex("0xa9059cbb")}}).Match(tx))
+	assert.False(t, (&PendingTxCriteria{MethodSelectors: []hexutil.Bytes{common.FromHex("0x095ea7b3")}}).Match(tx))
+
+	assert.True(t, (&PendingTxCriteria{MinGasPrice: (*hexutil.Big)(big.NewInt(25))}).Match(tx))
+	assert.False(t, (&PendingTxCriteria{MinGasPrice: (*hexutil.Big)(big.NewInt(26))}).Match(tx))
+
+	assert.False(t, (&PendingTxCriteria{ToAddresses: []common.Address{to1}, MinGasPrice: (*hexutil.Big)(big.NewInt(26))}).Match(tx))
+}
+
+func TestSubscribeFullPendingTxs(t *testing.T) {
+	t.Parallel()
+
+	var (
+		mux        = new(event.TypeMux)
+		db         = database.NewMemoryDBManager()
+		txFeed     = new(event.Feed)
+		rmLogsFeed = new(event.Feed)
+		logsFeed   = new(event.Feed)
+		chainFeed  = new(event.Feed)
+		backend    = &testBackend{mux, db, 0, txFeed, rmLogsFeed, logsFeed, chainFeed, params.TestChainConfig}
+		api        = NewPublicFilterAPI(backend, false)
+
+		transactions = []*types.Transaction{
+			types.NewTransaction(0, common.HexToAddress("0x1111"), new(big.Int), 0, new(big.Int), nil),
+			types.NewTransaction(1, common.HexToAddress("0x2222"), new(big.Int), 0, new(big.Int), nil),
+		}
+	)
+
+	txs := make(chan []*types.Transaction, 1)
+	sub := api.events.SubscribeFullPendingTxs(txs)
+	defer sub.Unsubscribe()
+	hashes := make(chan []common.Hash, 1)
+	hashSub := api.events.SubscribePendingTxs(hashes)
+	defer hashSub.Unsubscribe()
+
+	txFeed.Send(blockchain.NewTxsEvent{Txs: transactions})
+	select {
+	case received := <-txs:
+		assert.Equal(t, transactions, received)
+	case <-time.After(time.Second):
+		t.Fatal("full pending transactions not received")
+	}
+	select {
+	case received := <-hashes:
+		assert.Equal(t, []common.Hash{transactions[0].Hash(), transactions[1].Hash()}, received)
+	case <-time.After(time.Second):
+		t.Fatal("pending transaction hashes not received")
+	}
+}
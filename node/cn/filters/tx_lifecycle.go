@@ -0,0 +1,172 @@
+// Copyright 2022 The klaytn Authors
+// This file is part of the klaytn library.
+//
+// The klaytn library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The klaytn library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the klaytn library. If not, see <http://www.gnu.org/licenses/>.
+
+package filters
+
+import (
+	"context"
+
+	"github.com/klaytn/klaytn/blockchain"
+	"github.com/klaytn/klaytn/blockchain/types"
+	"github.com/klaytn/klaytn/common"
+	"github.com/klaytn/klaytn/common/hexutil"
+	"github.com/klaytn/klaytn/networks/rpc"
+)
+
+// The statuses of a transaction notified by the TransactionLifecycle subscription.
+const (
+	TxStatusPending  = "pending"  // entered the transaction pool
+	TxStatusReplaced = "replaced" // replaced by a transaction with the same nonce
+	TxStatusDropped  = "dropped"  // removed from the transaction pool without being included
+	TxStatusIncluded = "included" // included in a block of the canonical chain
+)
+
+// TxLifecycleCriteria selects the transactions notified by the TransactionLifecycle subscription.
+// A transaction is selected if its hash is one of Hashes, or its sender or recipient is one of Addresses.
+// All the transactions are selected if both are empty.
+type TxLifecycleCriteria struct {
+	Hashes    []common.Hash    `json:"hashes"`
+	Addresses []common.Address `json:"addresses"`
+}
+
+// Match reports whether the transaction is selected by the criteria.
+func (crit *TxLifecycleCriteria) Match(tx *types.Transaction) bool {
+	if crit == nil || (len(crit.Hashes) == 0 && len(crit.Addresses) == 0) {
+		return true
+	}
+	hash := tx.Hash()
+	for _, h := range crit.Hashes {
+		if h == hash {
+			return true
+		}
+	}
+	if len(crit.Addresses) == 0 {
+		return false
+	}
+	var to common.Address
+	if tx.To() != nil {
+		to = *tx.To()
+	}
+	from, _ := txSender(tx)
+	for _, addr := range crit.Addresses {
+		if addr == to || addr == from {
+			return true
+		}
+	}
+	return false
+}
+
+// txSender returns the sender of the transaction in the same way as the pending transactions are marshaled.
+func txSender(tx *types.Transaction) (common.Address, error) {
+	if tx.IsEthereumTransaction() {
+		return types.Sender(types.LatestSignerForChainID(tx.ChainId()), tx)
+	}
+	return tx.From()
+}
+
+// RPCTxLifecycle is a notification of the TransactionLifecycle subscription.
+type RPCTxLifecycle struct {
+	Hash        common.Hash  `json:"hash"`
+	Status      string       `json:"status"`
+	Reason      string       `json:"reason,omitempty"`
+	ReplacedBy  *common.Hash `json:"replacedBy,omitempty"`
+	BlockHash   *common.Hash `json:"blockHash,omitempty"`
+	BlockNumber *hexutil.Big `json:"blockNumber,omitempty"`
+}
+
+// newRPCTxsDropped converts the event into the notifications of the dropped or replaced transactions.
+func newRPCTxsDropped(ev blockchain.DroppedTxsEvent) []*RPCTxLifecycle {
+	result := make([]*RPCTxLifecycle, 0, len(ev.Txs))
+	for _, tx := range ev.Txs {
+		lifecycle := &RPCTxLifecycle{Hash: tx.Hash(), Status: TxStatusDropped, Reason: ev.Reason}
+		if ev.Replacement != nil {
+			replacedBy := ev.Replacement.Hash()
+			lifecycle.Status, lifecycle.ReplacedBy = TxStatusReplaced, &replacedBy
+		}
+		result = append(result, lifecycle)
+	}
+	return result
+}
+
+// newRPCTxIncluded returns the notification of the transaction included in the block.
+func newRPCTxIncluded(tx *types.Transaction, block *types.Block) *RPCTxLifecycle {
+	blockHash := block.Hash()
+	return &RPCTxLifecycle{
+		Hash:        tx.Hash(),
+		Status:      TxStatusIncluded,
+		BlockHash:   &blockHash,
+		BlockNumber: (*hexutil.Big)(block.Number()),
+	}
+}
+
+// TransactionLifecycle sends a notification each time a transaction matched by the optional criteria
+// enters the transaction pool, is replaced or dropped from it, or is included in a block.
+func (api *PublicFilterAPI) TransactionLifecycle(ctx context.Context, crit *TxLifecycleCriteria) (*rpc.Subscription, error) {
+	notifier, supported := rpc.NotifierFromContext(ctx)
+	if !supported {
+		return &rpc.Subscription{}, rpc.ErrNotificationsUnsupported
+	}
+
+	rpcSub := notifier.CreateSubscription()
+
+	go func() {
+		newTxs := make(chan blockchain.NewTxsEvent, txChanSize)
+		newTxsSub := api.backend.SubscribeNewTxsEvent(newTxs)
+		defer newTxsSub.Unsubscribe()
+		droppedTxs := make(chan blockchain.DroppedTxsEvent, txChanSize)
+		droppedTxsSub := api.backend.SubscribeDroppedTxsEvent(droppedTxs)
+		defer droppedTxsSub.Unsubscribe()
+		chainEvs := make(chan blockchain.ChainEvent, chainEvChanSize)
+		chainEvsSub := api.backend.SubscribeChainEvent(chainEvs)
+		defer chainEvsSub.Unsubscribe()
+
+		for {
+			select {
+			case ev := <-newTxs:
+				for _, tx := range ev.Txs {
+					if crit.Match(tx) {
+						notifier.Notify(rpcSub.ID, &RPCTxLifecycle{Hash: tx.Hash(), Status: TxStatusPending})
+					}
+				}
+			case ev := <-droppedTxs:
+				lifecycles := newRPCTxsDropped(ev)
+				for i, tx := range ev.Txs {
+					if crit.Match(tx) {
+						notifier.Notify(rpcSub.ID, lifecycles[i])
+					}
+				}
+			case ev := <-chainEvs:
+				for _, tx := range ev.Block.Transactions() {
+					if crit.Match(tx) {
+						notifier.Notify(rpcSub.ID, newRPCTxIncluded(tx, ev.Block))
+					}
+				}
+			case <-newTxsSub.Err():
+				return
+			case <-droppedTxsSub.Err():
+				return
+			case <-chainEvsSub.Err():
+				return
+			case <-rpcSub.Err():
+				return
+			case <-notifier.Closed():
+				return
+			}
+		}
+	}()
+
+	return rpcSub, nil
+}
@@ -0,0 +1,74 @@
+// Copyright 2022 The klaytn Authors
+// This file is part of the klaytn library.
+//
+// The klaytn library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The klaytn library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the klaytn library. If not, see <http://www.gnu.org/licenses/>.
+
+package filters
+
+import (
+	"math/big"
+	"testing"
+
+	"github.com/klaytn/klaytn/blockchain"
+	"github.com/klaytn/klaytn/blockchain/types"
+	"github.com/klaytn/klaytn/common"
+	"github.com/klaytn/klaytn/crypto"
+	"github.com/klaytn/klaytn/params"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestTxLifecycleCriteria_Match(t *testing.T) {
+	key, _ := crypto.GenerateKey()
+	var (
+		from  = crypto.PubkeyToAddress(key.PublicKey)
+		to    = common.HexToAddress("0x1111")
+		other = common.HexToAddress("0x2222")
+	)
+	tx, err := types.SignTx(types.NewTransaction(0, to, new(big.Int), 21000, big.NewInt(25), nil),
+		types.LatestSignerForChainID(params.TestChainConfig.ChainID), key)
+	assert.NoError(t, err)
+
+	var nilCrit *TxLifecycleCriteria
+	assert.True(t, nilCrit.Match(tx))
+	assert.True(t, (&TxLifecycleCriteria{}).Match(tx))
+
+	assert.True(t, (&TxLifecycleCriteria{Hashes: []common.Hash{tx.Hash()}}).Match(tx))
+	assert.False(t, (&TxLifecycleCriteria{Hashes: []common.Hash{{0x1}}}).Match(tx))
+
+	assert.True(t, (&TxLifecycleCriteria{Addresses: []common.Address{to}}).Match(tx))
+	assert.True(t, (&TxLifecycleCriteria{Addresses: []common.Address{from}}).Match(tx))
+	assert.False(t, (&TxLifecycleCriteria{Addresses: []common.Address{other}}).Match(tx))
+
+	assert.True(t, (&TxLifecycleCriteria{Hashes: []common.Hash{{0x1}}, Addresses: []common.Address{to}}).Match(tx))
+}
+
+func TestNewRPCTxsDropped(t *testing.T) {
+	var (
+		tx1 = types.NewTransaction(0, common.HexToAddress("0x1111"), new(big.Int), 21000, big.NewInt(25), nil)
+		tx2 = types.NewTransaction(1, common.HexToAddress("0x1111"), new(big.Int), 21000, big.NewInt(25), nil)
+		tx3 = types.NewTransaction(0, common.HexToAddress("0x1111"), new(big.Int), 21000, big.NewInt(50), nil)
+	)
+
+	dropped := newRPCTxsDropped(blockchain.DroppedTxsEvent{Txs: []*types.Transaction{tx1, tx2}, Reason: blockchain.DropReasonExpired})
+	assert.Equal(t, []*RPCTxLifecycle{
+		{Hash: tx1.Hash(), Status: TxStatusDropped, Reason: blockchain.DropReasonExpired},
+		{Hash: tx2.Hash(), Status: TxStatusDropped, Reason: blockchain.DropReasonExpired},
+	}, dropped)
+
+	replacedBy := tx3.Hash()
+	replaced := newRPCTxsDropped(blockchain.DroppedTxsEvent{Txs: []*types.Transaction{tx1}, Reason: blockchain.DropReasonReplaced, Replacement: tx3})
+	assert.Equal(t, []*RPCTxLifecycle{
+		{Hash: tx1.Hash(), Status: TxStatusReplaced, Reason: blockchain.DropReasonReplaced, ReplacedBy: &replacedBy},
+	}, replaced)
+}
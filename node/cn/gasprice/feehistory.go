@@ -25,7 +25,6 @@ import (
 	"errors"
 	"fmt"
 	"math/big"
-	"sort"
 	"sync/atomic"
 
 	"github.com/klaytn/klaytn/blockchain/types"
@@ -53,8 +52,9 @@ type blockFees struct {
 	// set by the caller
 	blockNumber uint64
 	header      *types.Header
-	block       *types.Block // only set if reward percentiles are requested
+	block       *types.Block // only set if reward percentiles are requested and stats are not stored
 	receipts    types.Receipts
+	stats       *FeeStats // set if reward percentiles are requested and stats are stored
 	// filled by processBlock
 	results processedFees
 	err     error
@@ -90,11 +90,7 @@ func (s sortGasAndReward) Less(i, j int) bool {
 // fills in the rest of the fields.
 func (oracle *Oracle) processBlock(bf *blockFees, percentiles []float64) {
 	chainconfig := oracle.backend.ChainConfig()
-	// TODO-Klaytn: If we implement baseFee feature like Ethereum does, we should set it from header, not constant.
-	if bf.results.baseFee = bf.header.BaseFee; bf.results.baseFee == nil {
-		bf.results.baseFee = new(big.Int).SetUint64(params.ZeroBaseFee)
-	}
-	// TODO-Klaytn: If we implement baseFee feature like Ethereum does, we should calculate nextBaseFee from parent block header.
+	bf.results.baseFee = misc.HeaderBaseFee(bf.header)
 	if chainconfig.IsMagmaForkEnabled(big.NewInt(int64(bf.blockNumber + 1))) {
 		bf.results.nextBaseFee = misc.NextMagmaBlockBaseFee(bf.header, chainconfig.Governance.KIP71)
 	} else {
@@ -107,42 +103,69 @@ func (oracle *Oracle) processBlock(bf *blockFees, percentiles []float64) {
 		// rewards were not requested, return null
 		return
 	}
-	if bf.block == nil || (bf.receipts == nil && len(bf.block.Transactions()) != 0) {
-		logger.Error("Block or receipts are missing while reward percentiles are requested")
-		return
+	if bf.stats == nil {
+		if bf.block == nil || (bf.receipts == nil && len(bf.block.Transactions()) != 0) {
+			logger.Error("Block or receipts are missing while reward percentiles are requested")
+			return
+		}
+		bf.stats = NewFeeStats(chainconfig, bf.block, bf.receipts)
 	}
+	bf.results.reward = bf.stats.RewardPercentiles(percentiles)
+}
 
-	bf.results.reward = make([]*big.Int, len(percentiles))
-	if len(bf.block.Transactions()) == 0 {
-		// return an all zero row if there are no transactions to gather data from
-		for i := range bf.results.reward {
-			bf.results.reward[i] = new(big.Int)
-		}
-		return
+// txCount returns the number of transactions in the block, which is known only if
+// reward percentiles are requested.
+func (bf *blockFees) txCount() uint64 {
+	if bf.stats != nil {
+		return bf.stats.TxCount
 	}
+	if bf.block != nil {
+		return uint64(len(bf.block.Transactions()))
+	}
+	return 0
+}
 
-	sorter := make(sortGasAndReward, len(bf.block.Transactions()))
-	for i := range bf.block.Transactions() {
-		// TODO-Klaytn: If we change the fixed unit price policy and add baseFee feature, we should re-calculate reward.
-		reward := bf.block.Header().BaseFee
-		if reward == nil {
-			reward = new(big.Int).SetUint64(chainconfig.UnitPrice)
+// fetchBlockFees retrieves the header of the given block, and the fee statistics if reward
+// percentiles are requested. The stored statistics are used if available, otherwise the block
+// and its receipts are retrieved. The header is left nil if the block is not found.
+func (oracle *Oracle) fetchBlockFees(ctx context.Context, blockNumber uint64, percentiles []float64) (*blockFees, error) {
+	fees := &blockFees{blockNumber: blockNumber}
+	if len(percentiles) != 0 && oracle.feeStatsDB != nil {
+		header, err := oracle.backend.HeaderByNumber(ctx, rpc.BlockNumber(blockNumber))
+		if err != nil || header == nil {
+			return fees, err
+		}
+		if stats := ReadFeeStats(oracle.feeStatsDB, header); stats != nil {
+			fees.header, fees.stats = header, stats
+			return fees, nil
 		}
-		sorter[i] = txGasAndReward{gasUsed: bf.receipts[i].GasUsed, reward: reward}
 	}
-	sort.Sort(sorter)
-
-	var txIndex int
-	sumGasUsed := sorter[0].gasUsed
+	if len(percentiles) != 0 {
+		block, err := oracle.backend.BlockByNumber(ctx, rpc.BlockNumber(blockNumber))
+		if err != nil || block == nil {
+			return fees, err
+		}
+		fees.block = block
+		fees.receipts = oracle.backend.GetBlockReceipts(ctx, block.Hash())
+		fees.header = block.Header()
+		return fees, nil
+	}
+	header, err := oracle.backend.HeaderByNumber(ctx, rpc.BlockNumber(blockNumber))
+	fees.header = header
+	return fees, err
+}
 
+// checkPercentiles checks that the percentiles are in the range of [0, 100] and in ascending order.
+func checkPercentiles(percentiles []float64) error {
 	for i, p := range percentiles {
-		thresholdGasUsed := uint64(float64(bf.block.GasUsed()) * p / 100)
-		for sumGasUsed < thresholdGasUsed && txIndex < len(bf.block.Transactions())-1 {
-			txIndex++
-			sumGasUsed += sorter[txIndex].gasUsed
+		if p < 0 || p > 100 {
+			return fmt.Errorf("%w: %f", errInvalidPercentile, p)
+		}
+		if i > 0 && p < percentiles[i-1] {
+			return fmt.Errorf("%w: #%d:%f > #%d:%f", errInvalidPercentile, i-1, percentiles[i-1], i, p)
 		}
-		bf.results.reward[i] = sorter[txIndex].reward
 	}
+	return nil
 }
 
 // resolveBlockRange resolves the specified block range to absolute block numbers while also
@@ -202,20 +225,16 @@ func (oracle *Oracle) FeeHistory(
 		return common.Big0, nil, nil, nil, nil // returning with no data and no error means there are no retrievable blocks
 	}
 	maxFeeHistory := oracle.maxHeaderHistory
-	if len(rewardPercentiles) != 0 {
+	if len(rewardPercentiles) != 0 && oracle.feeStatsDB == nil {
+		// The stored fee statistics are as cheap as the headers, so only the block retrievals are limited.
 		maxFeeHistory = oracle.maxBlockHistory
 	}
 	if blocks > maxFeeHistory {
 		logger.Warn("Sanitizing fee history length", "requested", blocks, "truncated", maxFeeHistory)
 		blocks = maxFeeHistory
 	}
-	for i, p := range rewardPercentiles {
-		if p < 0 || p > 100 {
-			return common.Big0, nil, nil, nil, fmt.Errorf("%w: %f", errInvalidPercentile, p)
-		}
-		if i > 0 && p < rewardPercentiles[i-1] {
-			return common.Big0, nil, nil, nil, fmt.Errorf("%w: #%d:%f > #%d:%f", errInvalidPercentile, i-1, rewardPercentiles[i-1], i, p)
-		}
+	if err := checkPercentiles(rewardPercentiles); err != nil {
+		return common.Big0, nil, nil, nil, err
 	}
 	var err error
 	lastBlock, blocks, err := oracle.resolveBlockRange(ctx, unresolvedLastBlock, blocks)
@@ -237,16 +256,8 @@ func (oracle *Oracle) FeeHistory(
 					return
 				}
 
-				fees := &blockFees{blockNumber: blockNumber}
-				if len(rewardPercentiles) != 0 {
-					fees.block, fees.err = oracle.backend.BlockByNumber(ctx, rpc.BlockNumber(blockNumber))
-					if fees.block != nil && fees.err == nil {
-						fees.receipts = oracle.backend.GetBlockReceipts(ctx, fees.block.Hash())
-						fees.header = fees.block.Header()
-					}
-				} else {
-					fees.header, fees.err = oracle.backend.HeaderByNumber(ctx, rpc.BlockNumber(blockNumber))
-				}
+				fees, err := oracle.fetchBlockFees(ctx, blockNumber, rewardPercentiles)
+				fees.err = err
 				if fees.header != nil && fees.err == nil {
 					oracle.processBlock(fees, rewardPercentiles)
 				}
@@ -23,9 +23,14 @@ package gasprice
 import (
 	"context"
 	"errors"
+	"math/big"
 	"testing"
 
+	"github.com/klaytn/klaytn/common"
+	"github.com/klaytn/klaytn/consensus/misc"
 	"github.com/klaytn/klaytn/networks/rpc"
+	"github.com/klaytn/klaytn/params"
+	"github.com/stretchr/testify/assert"
 )
 
 func TestFeeHistory(t *testing.T) {
@@ -87,3 +92,38 @@ func TestFeeHistory(t *testing.T) {
 		}
 	}
 }
+
+// TestFeeHistory_MagmaBoundary tests the base fees of the blocks before and after the magma hardfork.
+func TestFeeHistory_MagmaBoundary(t *testing.T) {
+	const magmaBlock = 16
+
+	config := params.TestChainConfig.Copy()
+	config.IstanbulCompatibleBlock = common.Big0
+	config.LondonCompatibleBlock = common.Big0
+	config.EthTxTypeCompatibleBlock = common.Big0
+	config.MagmaCompatibleBlock = big.NewInt(magmaBlock)
+	config.Governance = &params.GovernanceConfig{KIP71: params.GetDefaultKIP71Config()}
+
+	backend := newTestBackendWithConfig(t, config)
+	oracle := NewOracle(backend, Config{MaxHeaderHistory: 1000, MaxBlockHistory: 1000}, nil)
+
+	first, _, baseFee, _, err := oracle.FeeHistory(context.Background(), 10, magmaBlock+4, nil)
+	assert.NoError(t, err)
+	assert.Equal(t, uint64(magmaBlock-5), first.Uint64())
+	assert.Len(t, baseFee, 11)
+
+	for i, fee := range baseFee {
+		number := first.Uint64() + uint64(i)
+		if number < magmaBlock {
+			assert.Zero(t, fee.Sign(), "block %d", number)
+			continue
+		}
+		// The base fee of the next block is derived from the newest block of the range.
+		parent := backend.chain.GetHeaderByNumber(number - 1)
+		expected := misc.NextMagmaBlockBaseFee(parent, config.Governance.KIP71)
+		if header := backend.chain.GetHeaderByNumber(number); header != nil {
+			assert.Equal(t, header.BaseFee, expected, "block %d", number)
+		}
+		assert.Equal(t, expected, fee, "block %d", number)
+	}
+}
@@ -0,0 +1,213 @@
+// Copyright 2022 The klaytn Authors
+// This file is part of the klaytn library.
+//
+// The klaytn library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The klaytn library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the klaytn library. If not, see <http://www.gnu.org/licenses/>.
+
+package gasprice
+
+import (
+	"context"
+	"fmt"
+	"math/big"
+	"sort"
+
+	"github.com/klaytn/klaytn/blockchain/types"
+	"github.com/klaytn/klaytn/common"
+	"github.com/klaytn/klaytn/common/hexutil"
+	"github.com/klaytn/klaytn/networks/rpc"
+	"github.com/klaytn/klaytn/params"
+	"github.com/klaytn/klaytn/rlp"
+)
+
+// maxFeeStatsBlocks is the maximum number of blocks queried by a single FeeStats.
+const maxFeeStatsBlocks = 10000
+
+// FeeStatsDB is the database storing the fee statistics of the blocks.
+type FeeStatsDB interface {
+	ReadFeeStats(blockNum uint64) []byte
+	WriteFeeStats(blockNum uint64, stats []byte) error
+}
+
+// RewardGas is the gas used by the transactions paying the same reward in a block.
+type RewardGas struct {
+	Reward  *big.Int
+	GasUsed uint64
+}
+
+// FeeStats is the fee statistics of a block, which is computed and stored when the block is imported.
+// The rewards are kept as a gas-weighted distribution, so that any percentiles can be answered
+// without reading the body and the receipts of the block.
+type FeeStats struct {
+	Hash    common.Hash
+	GasUsed uint64
+	TxCount uint64
+	Rewards []RewardGas // rewards aggregated by their values in ascending order
+}
+
+// NewFeeStats computes the fee statistics of the given block from its receipts.
+func NewFeeStats(config *params.ChainConfig, block *types.Block, receipts types.Receipts) *FeeStats {
+	stats := &FeeStats{
+		Hash:    block.Hash(),
+		GasUsed: block.GasUsed(),
+		TxCount: uint64(len(block.Transactions())),
+	}
+	if len(block.Transactions()) == 0 {
+		return stats
+	}
+	sorter := make(sortGasAndReward, len(block.Transactions()))
+	for i := range block.Transactions() {
+		// TODO-Klaytn: If we change the fixed unit price policy and add baseFee feature, we should re-calculate reward.
+		reward := block.Header().BaseFee
+		if reward == nil {
+			reward = new(big.Int).SetUint64(config.UnitPrice)
+		}
+		sorter[i] = txGasAndReward{gasUsed: receipts[i].GasUsed, reward: reward}
+	}
+	sort.Sort(sorter)
+
+	for _, tx := range sorter {
+		if last := len(stats.Rewards) - 1; last >= 0 && stats.Rewards[last].Reward.Cmp(tx.reward) == 0 {
+			stats.Rewards[last].GasUsed += tx.gasUsed
+			continue
+		}
+		stats.Rewards = append(stats.Rewards, RewardGas{Reward: new(big.Int).Set(tx.reward), GasUsed: tx.gasUsed})
+	}
+	return stats
+}
+
+// RewardPercentiles returns the rewards at the given percentiles of the gas used in the block.
+// The percentiles should be sorted in ascending order.
+func (stats *FeeStats) RewardPercentiles(percentiles []float64) []*big.Int {
+	rewards := make([]*big.Int, len(percentiles))
+	if len(stats.Rewards) == 0 {
+		// return an all zero row if there are no transactions to gather data from
+		for i := range rewards {
+			rewards[i] = new(big.Int)
+		}
+		return rewards
+	}
+
+	var index int
+	sumGasUsed := stats.Rewards[0].GasUsed
+	for i, p := range percentiles {
+		thresholdGasUsed := uint64(float64(stats.GasUsed) * p / 100)
+		for sumGasUsed < thresholdGasUsed && index < len(stats.Rewards)-1 {
+			index++
+			sumGasUsed += stats.Rewards[index].GasUsed
+		}
+		rewards[i] = stats.Rewards[index].Reward
+	}
+	return rewards
+}
+
+// WriteFeeStats stores the fee statistics of the block with the given number.
+func WriteFeeStats(db FeeStatsDB, blockNum uint64, stats *FeeStats) error {
+	data, err := rlp.EncodeToBytes(stats)
+	if err != nil {
+		return err
+	}
+	return db.WriteFeeStats(blockNum, data)
+}
+
+// ReadFeeStats retrieves the stored fee statistics of the given header.
+// It returns nil if the statistics are not stored, or stored for another block of the same number.
+func ReadFeeStats(db FeeStatsDB, header *types.Header) *FeeStats {
+	data := db.ReadFeeStats(header.Number.Uint64())
+	if len(data) == 0 {
+		return nil
+	}
+	stats := new(FeeStats)
+	if err := rlp.DecodeBytes(data, stats); err != nil {
+		logger.Error("Invalid fee statistics", "number", header.Number, "err", err)
+		return nil
+	}
+	if stats.Hash != header.Hash() {
+		return nil
+	}
+	return stats
+}
+
+// SetFeeStatsDB makes the oracle read the fee statistics stored in the given database,
+// instead of reading the bodies and the receipts of the blocks.
+func (oracle *Oracle) SetFeeStatsDB(db FeeStatsDB) {
+	oracle.feeStatsDB = db
+}
+
+// FeeStatsResult is the fee statistics of a block returned by FeeStats.
+type FeeStatsResult struct {
+	Number        hexutil.Uint64 `json:"number"`
+	BaseFeePerGas *hexutil.Big   `json:"baseFeePerGas"`
+	GasUsed       hexutil.Uint64 `json:"gasUsed"`
+	GasUsedRatio  float64        `json:"gasUsedRatio"`
+	TxCount       hexutil.Uint64 `json:"txCount"`
+	Reward        []*hexutil.Big `json:"reward,omitempty"`
+}
+
+// FeeStats returns the fee statistics of the blocks from fromBlock to toBlock with the rewards
+// at the given percentiles. The statistics stored at import are used if available, and the
+// statistics of the other blocks are computed from their bodies and receipts.
+func (oracle *Oracle) FeeStats(ctx context.Context, fromBlock, toBlock rpc.BlockNumber, rewardPercentiles []float64) ([]*FeeStatsResult, error) {
+	if err := checkPercentiles(rewardPercentiles); err != nil {
+		return nil, err
+	}
+	head := oracle.backend.CurrentBlock().NumberU64()
+	resolve := func(n rpc.BlockNumber) uint64 {
+		if n < 0 || uint64(n) > head {
+			return head
+		}
+		return uint64(n)
+	}
+	from, to := resolve(fromBlock), resolve(toBlock)
+	if from > to {
+		return nil, fmt.Errorf("fromBlock %d is later than toBlock %d", from, to)
+	}
+	if to-from+1 > maxFeeStatsBlocks {
+		return nil, fmt.Errorf("too many blocks to query (%d > %d)", to-from+1, maxFeeStatsBlocks)
+	}
+
+	results := make([]*FeeStatsResult, 0, to-from+1)
+	for num := from; num <= to; num++ {
+		if err := ctx.Err(); err != nil {
+			return nil, err
+		}
+		fees, err := oracle.fetchBlockFees(ctx, num, rewardPercentiles)
+		if err != nil {
+			return nil, err
+		}
+		if fees.header == nil {
+			return nil, fmt.Errorf("block %d not found", num)
+		}
+		oracle.processBlock(fees, rewardPercentiles)
+		results = append(results, &FeeStatsResult{
+			Number:        hexutil.Uint64(num),
+			BaseFeePerGas: (*hexutil.Big)(fees.results.baseFee),
+			GasUsed:       hexutil.Uint64(fees.header.GasUsed),
+			GasUsedRatio:  fees.results.gasUsedRatio,
+			TxCount:       hexutil.Uint64(fees.txCount()),
+			Reward:        toHexBigs(fees.results.reward),
+		})
+	}
+	return results, nil
+}
+
+func toHexBigs(values []*big.Int) []*hexutil.Big {
+	if values == nil {
+		return nil
+	}
+	res := make([]*hexutil.Big, len(values))
+	for i, v := range values {
+		res[i] = (*hexutil.Big)(v)
+	}
+	return res
+}
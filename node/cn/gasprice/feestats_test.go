@@ -0,0 +1,113 @@
+// Copyright 2022 The klaytn Authors
+// This file is part of the klaytn library.
+//
+// The klaytn library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The klaytn library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the klaytn library. If not, see <http://www.gnu.org/licenses/>.
+
+package gasprice
+
+import (
+	"context"
+	"math/big"
+	"testing"
+
+	"github.com/klaytn/klaytn/common"
+	"github.com/klaytn/klaytn/networks/rpc"
+	"github.com/klaytn/klaytn/storage/database"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestFeeStats_RewardPercentiles(t *testing.T) {
+	stats := &FeeStats{
+		GasUsed: 100,
+		Rewards: []RewardGas{
+			{Reward: big.NewInt(1), GasUsed: 20},
+			{Reward: big.NewInt(2), GasUsed: 50},
+			{Reward: big.NewInt(3), GasUsed: 30},
+		},
+	}
+	assert.Equal(t, []*big.Int{big.NewInt(1), big.NewInt(1), big.NewInt(2), big.NewInt(2), big.NewInt(3), big.NewInt(3)},
+		stats.RewardPercentiles([]float64{0, 20, 21, 70, 71, 100}))
+
+	empty := &FeeStats{}
+	assert.Equal(t, []*big.Int{new(big.Int), new(big.Int)}, empty.RewardPercentiles([]float64{10, 90}))
+}
+
+func TestFeeStats_Stored(t *testing.T) {
+	var (
+		backend     = newTestBackend(t)
+		oracle      = NewOracle(backend, Config{MaxHeaderHistory: 1000, MaxBlockHistory: 1000}, nil)
+		db          = database.NewMemoryDBManager()
+		percentiles = []float64{0, 50, 100}
+	)
+	_, expReward, expBaseFee, expRatio, err := oracle.FeeHistory(context.Background(), 10, rpc.LatestBlockNumber, percentiles)
+	require.NoError(t, err)
+
+	// The statistics computed at import give the same results as the blocks.
+	for num := uint64(0); num <= testHead; num++ {
+		block := backend.chain.GetBlockByNumber(num)
+		stats := NewFeeStats(backend.ChainConfig(), block, backend.chain.GetReceiptsByBlockHash(block.Hash()))
+		require.NoError(t, WriteFeeStats(db, num, stats))
+	}
+	oracle.SetFeeStatsDB(db)
+	_, reward, baseFee, ratio, err := oracle.FeeHistory(context.Background(), 10, rpc.LatestBlockNumber, percentiles)
+	require.NoError(t, err)
+	assert.Equal(t, expReward, reward)
+	assert.Equal(t, expBaseFee, baseFee)
+	assert.Equal(t, expRatio, ratio)
+
+	// The stored statistics are used instead of the block.
+	header := backend.chain.GetHeaderByNumber(testHead)
+	require.NoError(t, WriteFeeStats(db, testHead, &FeeStats{
+		Hash:    header.Hash(),
+		GasUsed: header.GasUsed,
+		TxCount: 1,
+		Rewards: []RewardGas{{Reward: big.NewInt(7), GasUsed: header.GasUsed}},
+	}))
+	_, reward, _, _, err = oracle.FeeHistory(context.Background(), 1, rpc.LatestBlockNumber, percentiles)
+	require.NoError(t, err)
+	assert.Equal(t, [][]*big.Int{{big.NewInt(7), big.NewInt(7), big.NewInt(7)}}, reward)
+
+	// The statistics stored for another block of the same number are ignored.
+	require.NoError(t, WriteFeeStats(db, testHead, &FeeStats{
+		Hash:    common.HexToHash("0x1234"),
+		GasUsed: header.GasUsed,
+		Rewards: []RewardGas{{Reward: big.NewInt(7), GasUsed: header.GasUsed}},
+	}))
+	_, reward, _, _, err = oracle.FeeHistory(context.Background(), 1, rpc.LatestBlockNumber, percentiles)
+	require.NoError(t, err)
+	assert.Equal(t, expReward[len(expReward)-1:], reward)
+}
+
+func TestFeeStats_Range(t *testing.T) {
+	var (
+		backend = newTestBackend(t)
+		oracle  = NewOracle(backend, Config{}, nil)
+	)
+	results, err := oracle.FeeStats(context.Background(), 10, rpc.LatestBlockNumber, []float64{50})
+	require.NoError(t, err)
+	require.Len(t, results, testHead-10+1)
+	for i, res := range results {
+		block := backend.chain.GetBlockByNumber(uint64(10 + i))
+		assert.Equal(t, block.NumberU64(), uint64(res.Number))
+		assert.Equal(t, block.GasUsed(), uint64(res.GasUsed))
+		assert.Equal(t, uint64(len(block.Transactions())), uint64(res.TxCount))
+		assert.Len(t, res.Reward, 1)
+	}
+
+	_, err = oracle.FeeStats(context.Background(), 20, 10, nil)
+	assert.Error(t, err)
+	_, err = oracle.FeeStats(context.Background(), 0, 10, []float64{50, 10})
+	assert.ErrorIs(t, err, errInvalidPercentile)
+}
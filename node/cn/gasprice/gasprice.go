@@ -23,6 +23,7 @@ package gasprice
 import (
 	"context"
 	"math/big"
+	"sort"
 	"sync"
 
 	"github.com/klaytn/klaytn/blockchain/types"
@@ -68,6 +69,8 @@ type Oracle struct {
 	checkBlocks, maxEmpty, maxBlocks  int
 	percentile                        int
 	maxHeaderHistory, maxBlockHistory int
+
+	feeStatsDB FeeStatsDB // set if the fee statistics of the blocks are stored at import
 }
 
 // NewOracle returns a new oracle.
@@ -183,6 +186,71 @@ func (gpo *Oracle) SuggestPrice(ctx context.Context) (*big.Int, error) {
 	*/
 }
 
+// SuggestTipCap returns the recommended gas tip cap for dynamic fee transactions.
+// It samples the lowest effective tips of the transactions in the recent blocks, and returns
+// the tip at the configured percentile of the samples. Since Klaytn has no tip on top of the
+// base fee, the effective gas price which is used as the reward of FeeHistory is sampled.
+// If there are no transactions in the recent blocks, the unit price of the governance is returned.
+func (gpo *Oracle) SuggestTipCap(ctx context.Context) (*big.Int, error) {
+	head, err := gpo.backend.HeaderByNumber(ctx, rpc.LatestBlockNumber)
+	if head == nil || err != nil {
+		return nil, err
+	}
+	headHash := head.Hash()
+
+	gpo.cacheLock.RLock()
+	lastHead, lastPrice := gpo.lastHead, gpo.lastPrice
+	gpo.cacheLock.RUnlock()
+	if headHash == lastHead && lastPrice != nil {
+		return new(big.Int).Set(lastPrice), nil
+	}
+
+	gpo.fetchLock.Lock()
+	defer gpo.fetchLock.Unlock()
+
+	// Try checking the cache again, maybe the last fetch fetched what we need
+	gpo.cacheLock.RLock()
+	lastHead, lastPrice = gpo.lastHead, gpo.lastPrice
+	gpo.cacheLock.RUnlock()
+	if headHash == lastHead && lastPrice != nil {
+		return new(big.Int).Set(lastPrice), nil
+	}
+
+	var samples []*big.Int
+	for i, num := 0, head.Number.Int64(); i < gpo.checkBlocks && num >= 0; i, num = i+1, num-1 {
+		fees, err := gpo.fetchBlockFees(ctx, uint64(num), []float64{0})
+		if err != nil {
+			return nil, err
+		}
+		if fees.header == nil {
+			break
+		}
+		gpo.processBlock(fees, []float64{0})
+		if fees.txCount() != 0 && len(fees.results.reward) != 0 {
+			samples = append(samples, fees.results.reward[0])
+		}
+	}
+
+	price := common.Big0
+	if gpo.txPool != nil {
+		price = gpo.txPool.GasPrice()
+	}
+	if len(samples) > 0 {
+		sort.Slice(samples, func(i, j int) bool { return samples[i].Cmp(samples[j]) < 0 })
+		price = samples[(len(samples)-1)*gpo.percentile/100]
+	}
+	if price.Cmp(maxPrice) > 0 {
+		price = maxPrice
+	}
+	price = new(big.Int).Set(price)
+
+	gpo.cacheLock.Lock()
+	gpo.lastHead = headHash
+	gpo.lastPrice = price
+	gpo.cacheLock.Unlock()
+	return new(big.Int).Set(price), nil
+}
+
 // TODO-Klaytn-RemoveLater Later remove below obsolete code if we don't need them anymore.
 //type getBlockPricesResult struct {
 //	price *big.Int
@@ -76,12 +76,16 @@ func (b *testBackend) CurrentBlock() *types.Block {
 }
 
 func newTestBackend(t *testing.T) *testBackend {
+	return newTestBackendWithConfig(t, params.TestChainConfig)
+}
+
+func newTestBackendWithConfig(t *testing.T, config *params.ChainConfig) *testBackend {
 	var (
 		key, _ = crypto.HexToECDSA("b71c71a67e1177ad4e901695e1b4b9ee17ae16c6668d313eac2f96dbcda3f291")
 		addr   = crypto.PubkeyToAddress(key.PublicKey)
 
 		gspec = &blockchain.Genesis{
-			Config: params.TestChainConfig,
+			Config: config,
 			Alloc:  blockchain.GenesisAlloc{addr: {Balance: big.NewInt(math.MaxInt64)}},
 		}
 		db      = database.NewMemoryDBManager()
@@ -93,8 +97,8 @@ func newTestBackend(t *testing.T) *testBackend {
 
 		toaddr := common.Address{}
 		data := make([]byte, 1)
-		gas, _ := types.IntrinsicGas(data, nil, false, params.TestChainConfig.Rules(big.NewInt(0)))
-		signer := types.NewEIP155Signer(params.TestChainConfig.ChainID)
+		gas, _ := types.IntrinsicGas(data, nil, false, config.Rules(big.NewInt(0)))
+		signer := types.NewEIP155Signer(config.ChainID)
 		tx, _ := types.SignTx(types.NewTransaction(b.TxNonce(addr), toaddr, big.NewInt(1), gas, nil, data), signer, key)
 		b.AddTx(tx)
 	})
@@ -185,3 +189,45 @@ func TestGasPrice_SuggestPrice(t *testing.T) {
 	assert.Equal(t, big.NewInt(25), price)
 	assert.Nil(t, err)
 }
+
+type testTxPool struct {
+	gasPrice *big.Int
+}
+
+func (pool *testTxPool) GasPrice() *big.Int {
+	return pool.gasPrice
+}
+
+func TestGasPrice_SuggestTipCap(t *testing.T) {
+	// The effective tip of the transactions in the recent blocks is suggested.
+	backend := newTestBackend(t)
+	oracle := NewOracle(backend, Config{Blocks: 5, Percentile: 60}, &testTxPool{big.NewInt(123)})
+
+	head := backend.CurrentBlock()
+	expected := NewFeeStats(backend.ChainConfig(), head, backend.GetBlockReceipts(context.Background(), head.Hash())).Rewards[0].Reward
+	tipCap, err := oracle.SuggestTipCap(context.Background())
+	assert.NoError(t, err)
+	assert.Equal(t, expected, tipCap)
+	assert.Equal(t, head.Hash(), oracle.lastHead)
+
+	// The unit price is suggested if there are no transactions in the recent blocks.
+	mockCtrl := gomock.NewController(t)
+	defer mockCtrl.Finish()
+	mockBackend := mock_api.NewMockBackend(mockCtrl)
+	oracle = NewOracle(mockBackend, Config{Blocks: 5, Percentile: 60}, &testTxPool{big.NewInt(123)})
+
+	emptyBlock := types.NewBlockWithHeader(&types.Header{Number: big.NewInt(1)})
+	mockBackend.EXPECT().ChainConfig().Return(params.TestChainConfig).AnyTimes()
+	mockBackend.EXPECT().HeaderByNumber(gomock.Any(), rpc.LatestBlockNumber).Return(emptyBlock.Header(), nil).Times(2)
+	mockBackend.EXPECT().BlockByNumber(gomock.Any(), gomock.Any()).Return(emptyBlock, nil).Times(2)
+	mockBackend.EXPECT().GetBlockReceipts(gomock.Any(), emptyBlock.Hash()).Return(types.Receipts{}).Times(2)
+
+	tipCap, err = oracle.SuggestTipCap(context.Background())
+	assert.NoError(t, err)
+	assert.Equal(t, big.NewInt(123), tipCap)
+
+	// The suggestion is cached until a new block is imported.
+	tipCap, err = oracle.SuggestTipCap(context.Background())
+	assert.NoError(t, err)
+	assert.Equal(t, big.NewInt(123), tipCap)
+}
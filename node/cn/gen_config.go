@@ -18,58 +18,87 @@ import (
 // MarshalTOML marshals as TOML.
 func (c Config) MarshalTOML() (interface{}, error) {
 	type Config struct {
-		Genesis                 *blockchain.Genesis `toml:",omitempty"`
-		NetworkId               uint64
-		SyncMode                downloader.SyncMode
-		NoPruning               bool
-		WorkerDisable           bool
-		DownloaderDisable       bool
-		FetcherDisable          bool
-		ParentOperatorAddr      *common.Address `toml:",omitempty"`
-		AnchoringPeriod         uint64
-		SentChainTxsLimit       uint64
-		OverwriteGenesis        bool
-		StartBlockNumber        uint64
-		DBType                  database.DBType
-		SkipBcVersionCheck      bool `toml:"-"`
-		SingleDB                bool
-		NumStateTrieShards      uint
-		EnableDBPerfMetrics     bool
-		LevelDBCompression      database.LevelDBCompressionType
-		LevelDBBufferPool       bool
-		LevelDBCacheSize        int
-		DynamoDBConfig          database.DynamoDBConfig
-		TrieCacheSize           int
-		TrieTimeout             time.Duration
-		TrieBlockInterval       uint
-		TriesInMemory           uint64
-		SenderTxHashIndexing    bool
-		ParallelDBWrite         bool
-		TrieNodeCacheConfig     statedb.TrieNodeCacheConfig
-		SnapshotCacheSize       int
-		SnapshotAsyncGen        bool
-		ServiceChainSigner      common.Address `toml:",omitempty"`
-		ExtraData               []byte         `toml:",omitempty"`
-		GasPrice                *big.Int
-		Rewardbase              common.Address `toml:",omitempty"`
-		TxPool                  blockchain.TxPoolConfig
-		GPO                     gasprice.Config
-		EnablePreimageRecording bool
-		EnableInternalTxTracing bool
-		Istanbul                istanbul.Config
-		DocRoot                 string `toml:"-"`
-		WsEndpoint              string `toml:",omitempty"`
-		TxResendInterval        uint64
-		TxResendCount           int
-		TxResendUseLegacy       bool
-		NoAccountCreation       bool
-		IsPrivate               bool
-		AutoRestartFlag         bool
-		RestartTimeOutFlag      time.Duration
-		DaemonPathFlag          string
-		RPCGasCap               *big.Int `toml:",omitempty"`
-		RPCEVMTimeout           time.Duration
-		RPCTxFeeCap             float64
+		Genesis                      *blockchain.Genesis `toml:",omitempty"`
+		NetworkId                    uint64
+		SyncMode                     downloader.SyncMode
+		NoPruning                    bool
+		WorkerDisable                bool
+		DownloaderDisable            bool
+		FetcherDisable               bool
+		ReadReplica                  bool
+		TrustedCheckpoint            string           `toml:",omitempty"`
+		CheckpointSigners            []common.Address `toml:",omitempty"`
+		CheckpointThreshold          int
+		ParentOperatorAddr           *common.Address `toml:",omitempty"`
+		AnchoringPeriod              uint64
+		SentChainTxsLimit            uint64
+		OverwriteGenesis             bool
+		StartBlockNumber             uint64
+		DBType                       database.DBType
+		SkipBcVersionCheck           bool `toml:"-"`
+		SingleDB                     bool
+		NumStateTrieShards           uint
+		EnableDBPerfMetrics          bool
+		LevelDBCompression           database.LevelDBCompressionType
+		LevelDBBufferPool            bool
+		LevelDBCacheSize             int
+		RocksDBBlockCacheSize        int
+		RocksDBCompactionStyle       string
+		DynamoDBConfig               database.DynamoDBConfig
+		TrieCacheSize                int
+		TrieTimeout                  time.Duration
+		TrieBlockInterval            uint
+		TriesInMemory                uint64
+		PeriodicMigrationInterval    uint64
+		StateHistory                 bool
+		SenderTxHashIndexing         bool
+		TxLookupLimit                uint64
+		ParallelTxWorkers            int
+		AccountTxIndexing            bool
+		TokenTransferIndexing        bool
+		FeeStatsIndexing             bool
+		ParallelDBWrite              bool
+		TrieNodeCacheConfig          statedb.TrieNodeCacheConfig
+		SnapshotCacheSize            int
+		SnapshotAsyncGen             bool
+		ServiceChainSigner           common.Address `toml:",omitempty"`
+		ExtraData                    []byte         `toml:",omitempty"`
+		GasPrice                     *big.Int
+		Rewardbase                   common.Address `toml:",omitempty"`
+		TxPool                       blockchain.TxPoolConfig
+		GPO                          gasprice.Config
+		EnablePreimageRecording      bool
+		EnableInternalTxTracing      bool
+		LogIndexBackend              string `toml:",omitempty"`
+		LogIndexEndpoint             string `toml:",omitempty"`
+		InternalTxIndexing           bool
+		Istanbul                     istanbul.Config
+		DocRoot                      string `toml:"-"`
+		WsEndpoint                   string `toml:",omitempty"`
+		TxResendInterval             uint64
+		TxResendCount                int
+		TxResendUseLegacy            bool
+		NoAccountCreation            bool
+		IsPrivate                    bool
+		AutoRestartFlag              bool
+		RestartTimeOutFlag           time.Duration
+		DaemonPathFlag               string
+		RPCGasCap                    *big.Int `toml:",omitempty"`
+		RPCEVMTimeout                time.Duration
+		RPCCallStateReuseWindow      time.Duration `toml:",omitempty"`
+		RPCStateReexecLimit          uint64
+		RPCCustomTracerTimeout       time.Duration `toml:",omitempty"`
+		RPCCustomTracerMaxResultSize int           `toml:",omitempty"`
+		RPCStandardTraceDir          string        `toml:",omitempty"`
+		RPCTxFeeCap                  float64
+		RPCEthKlaytnTxMode           string
+		RPCTxPoolEthFormat           bool             `toml:",omitempty"`
+		RPCFeePayerWhitelist         []common.Address `toml:",omitempty"`
+		RPCReceiptsCacheSize         int
+		RPCEthBlocksCacheSize        int
+		RPCEthReceiptsCacheSize      int
+		RPCEthPrecomputeBlocks       bool
+		RPCSendersCacheSize          int
 	}
 	var enc Config
 	enc.Genesis = c.Genesis
@@ -79,6 +108,10 @@ func (c Config) MarshalTOML() (interface{}, error) {
 	enc.WorkerDisable = c.WorkerDisable
 	enc.DownloaderDisable = c.DownloaderDisable
 	enc.FetcherDisable = c.FetcherDisable
+	enc.ReadReplica = c.ReadReplica
+	enc.TrustedCheckpoint = c.TrustedCheckpoint
+	enc.CheckpointSigners = c.CheckpointSigners
+	enc.CheckpointThreshold = c.CheckpointThreshold
 	enc.ParentOperatorAddr = c.ParentOperatorAddr
 	enc.AnchoringPeriod = c.AnchoringPeriod
 	enc.SentChainTxsLimit = c.SentChainTxsLimit
@@ -92,12 +125,21 @@ func (c Config) MarshalTOML() (interface{}, error) {
 	enc.LevelDBCompression = c.LevelDBCompression
 	enc.LevelDBBufferPool = c.LevelDBBufferPool
 	enc.LevelDBCacheSize = c.LevelDBCacheSize
+	enc.RocksDBBlockCacheSize = c.RocksDBBlockCacheSize
+	enc.RocksDBCompactionStyle = c.RocksDBCompactionStyle
 	enc.DynamoDBConfig = c.DynamoDBConfig
 	enc.TrieCacheSize = c.TrieCacheSize
 	enc.TrieTimeout = c.TrieTimeout
 	enc.TrieBlockInterval = c.TrieBlockInterval
 	enc.TriesInMemory = c.TriesInMemory
+	enc.PeriodicMigrationInterval = c.PeriodicMigrationInterval
+	enc.StateHistory = c.StateHistory
 	enc.SenderTxHashIndexing = c.SenderTxHashIndexing
+	enc.TxLookupLimit = c.TxLookupLimit
+	enc.ParallelTxWorkers = c.ParallelTxWorkers
+	enc.AccountTxIndexing = c.AccountTxIndexing
+	enc.TokenTransferIndexing = c.TokenTransferIndexing
+	enc.FeeStatsIndexing = c.FeeStatsIndexing
 	enc.ParallelDBWrite = c.ParallelDBWrite
 	enc.TrieNodeCacheConfig = c.TrieNodeCacheConfig
 	enc.SnapshotCacheSize = c.SnapshotCacheSize
@@ -110,6 +152,9 @@ func (c Config) MarshalTOML() (interface{}, error) {
 	enc.GPO = c.GPO
 	enc.EnablePreimageRecording = c.EnablePreimageRecording
 	enc.EnableInternalTxTracing = c.EnableInternalTxTracing
+	enc.LogIndexBackend = c.LogIndexBackend
+	enc.LogIndexEndpoint = c.LogIndexEndpoint
+	enc.InternalTxIndexing = c.InternalTxIndexing
 	enc.Istanbul = c.Istanbul
 	enc.DocRoot = c.DocRoot
 	enc.WsEndpoint = c.WsEndpoint
@@ -123,65 +168,107 @@ func (c Config) MarshalTOML() (interface{}, error) {
 	enc.DaemonPathFlag = c.DaemonPathFlag
 	enc.RPCGasCap = c.RPCGasCap
 	enc.RPCEVMTimeout = c.RPCEVMTimeout
+	enc.RPCCallStateReuseWindow = c.RPCCallStateReuseWindow
+	enc.RPCStateReexecLimit = c.RPCStateReexecLimit
+	enc.RPCCustomTracerTimeout = c.RPCCustomTracerTimeout
+	enc.RPCCustomTracerMaxResultSize = c.RPCCustomTracerMaxResultSize
+	enc.RPCStandardTraceDir = c.RPCStandardTraceDir
 	enc.RPCTxFeeCap = c.RPCTxFeeCap
+	enc.RPCEthKlaytnTxMode = c.RPCEthKlaytnTxMode
+	enc.RPCTxPoolEthFormat = c.RPCTxPoolEthFormat
+	enc.RPCFeePayerWhitelist = c.RPCFeePayerWhitelist
+	enc.RPCReceiptsCacheSize = c.RPCReceiptsCacheSize
+	enc.RPCEthBlocksCacheSize = c.RPCEthBlocksCacheSize
+	enc.RPCEthReceiptsCacheSize = c.RPCEthReceiptsCacheSize
+	enc.RPCEthPrecomputeBlocks = c.RPCEthPrecomputeBlocks
+	enc.RPCSendersCacheSize = c.RPCSendersCacheSize
 	return &enc, nil
 }
 
 // UnmarshalTOML unmarshals from TOML.
 func (c *Config) UnmarshalTOML(unmarshal func(interface{}) error) error {
 	type Config struct {
-		Genesis                 *blockchain.Genesis `toml:",omitempty"`
-		NetworkId               *uint64
-		SyncMode                *downloader.SyncMode
-		NoPruning               *bool
-		WorkerDisable           *bool
-		DownloaderDisable       *bool
-		FetcherDisable          *bool
-		ParentOperatorAddr      *common.Address `toml:",omitempty"`
-		AnchoringPeriod         *uint64
-		SentChainTxsLimit       *uint64
-		OverwriteGenesis        *bool
-		StartBlockNumber        *uint64
-		DBType                  *database.DBType
-		SkipBcVersionCheck      *bool `toml:"-"`
-		SingleDB                *bool
-		NumStateTrieShards      *uint
-		EnableDBPerfMetrics     *bool
-		LevelDBCompression      *database.LevelDBCompressionType
-		LevelDBBufferPool       *bool
-		LevelDBCacheSize        *int
-		DynamoDBConfig          *database.DynamoDBConfig
-		TrieCacheSize           *int
-		TrieTimeout             *time.Duration
-		TrieBlockInterval       *uint
-		TriesInMemory           *uint64
-		SenderTxHashIndexing    *bool
-		ParallelDBWrite         *bool
-		TrieNodeCacheConfig     *statedb.TrieNodeCacheConfig
-		SnapshotCacheSize       *int
-		SnapshotAsyncGen        *bool
-		ServiceChainSigner      *common.Address `toml:",omitempty"`
-		ExtraData               []byte          `toml:",omitempty"`
-		GasPrice                *big.Int
-		Rewardbase              *common.Address `toml:",omitempty"`
-		TxPool                  *blockchain.TxPoolConfig
-		GPO                     *gasprice.Config
-		EnablePreimageRecording *bool
-		EnableInternalTxTracing *bool
-		Istanbul                *istanbul.Config
-		DocRoot                 *string `toml:"-"`
-		WsEndpoint              *string `toml:",omitempty"`
-		TxResendInterval        *uint64
-		TxResendCount           *int
-		TxResendUseLegacy       *bool
-		NoAccountCreation       *bool
-		IsPrivate               *bool
-		AutoRestartFlag         *bool
-		RestartTimeOutFlag      *time.Duration
-		DaemonPathFlag          *string
-		RPCGasCap               *big.Int `toml:",omitempty"`
-		RPCEVMTimeout           *time.Duration
-		RPCTxFeeCap             *float64
+		Genesis                      *blockchain.Genesis `toml:",omitempty"`
+		NetworkId                    *uint64
+		SyncMode                     *downloader.SyncMode
+		NoPruning                    *bool
+		WorkerDisable                *bool
+		DownloaderDisable            *bool
+		FetcherDisable               *bool
+		ReadReplica                  *bool
+		TrustedCheckpoint            *string          `toml:",omitempty"`
+		CheckpointSigners            []common.Address `toml:",omitempty"`
+		CheckpointThreshold          *int
+		ParentOperatorAddr           *common.Address `toml:",omitempty"`
+		AnchoringPeriod              *uint64
+		SentChainTxsLimit            *uint64
+		OverwriteGenesis             *bool
+		StartBlockNumber             *uint64
+		DBType                       *database.DBType
+		SkipBcVersionCheck           *bool `toml:"-"`
+		SingleDB                     *bool
+		NumStateTrieShards           *uint
+		EnableDBPerfMetrics          *bool
+		LevelDBCompression           *database.LevelDBCompressionType
+		LevelDBBufferPool            *bool
+		LevelDBCacheSize             *int
+		RocksDBBlockCacheSize        *int
+		RocksDBCompactionStyle       *string
+		DynamoDBConfig               *database.DynamoDBConfig
+		TrieCacheSize                *int
+		TrieTimeout                  *time.Duration
+		TrieBlockInterval            *uint
+		TriesInMemory                *uint64
+		PeriodicMigrationInterval    *uint64
+		StateHistory                 *bool
+		SenderTxHashIndexing         *bool
+		TxLookupLimit                *uint64
+		ParallelTxWorkers            *int
+		AccountTxIndexing            *bool
+		TokenTransferIndexing        *bool
+		FeeStatsIndexing             *bool
+		ParallelDBWrite              *bool
+		TrieNodeCacheConfig          *statedb.TrieNodeCacheConfig
+		SnapshotCacheSize            *int
+		SnapshotAsyncGen             *bool
+		ServiceChainSigner           *common.Address `toml:",omitempty"`
+		ExtraData                    []byte          `toml:",omitempty"`
+		GasPrice                     *big.Int
+		Rewardbase                   *common.Address `toml:",omitempty"`
+		TxPool                       *blockchain.TxPoolConfig
+		GPO                          *gasprice.Config
+		EnablePreimageRecording      *bool
+		EnableInternalTxTracing      *bool
+		LogIndexBackend              *string `toml:",omitempty"`
+		LogIndexEndpoint             *string `toml:",omitempty"`
+		InternalTxIndexing           *bool
+		Istanbul                     *istanbul.Config
+		DocRoot                      *string `toml:"-"`
+		WsEndpoint                   *string `toml:",omitempty"`
+		TxResendInterval             *uint64
+		TxResendCount                *int
+		TxResendUseLegacy            *bool
+		NoAccountCreation            *bool
+		IsPrivate                    *bool
+		AutoRestartFlag              *bool
+		RestartTimeOutFlag           *time.Duration
+		DaemonPathFlag               *string
+		RPCGasCap                    *big.Int `toml:",omitempty"`
+		RPCEVMTimeout                *time.Duration
+		RPCCallStateReuseWindow      *time.Duration `toml:",omitempty"`
+		RPCStateReexecLimit          *uint64
+		RPCCustomTracerTimeout       *time.Duration `toml:",omitempty"`
+		RPCCustomTracerMaxResultSize *int           `toml:",omitempty"`
+		RPCStandardTraceDir          *string        `toml:",omitempty"`
+		RPCTxFeeCap                  *float64
+		RPCEthKlaytnTxMode           *string
+		RPCTxPoolEthFormat           *bool            `toml:",omitempty"`
+		RPCFeePayerWhitelist         []common.Address `toml:",omitempty"`
+		RPCReceiptsCacheSize         *int
+		RPCEthBlocksCacheSize        *int
+		RPCEthReceiptsCacheSize      *int
+		RPCEthPrecomputeBlocks       *bool
+		RPCSendersCacheSize          *int
 	}
 	var dec Config
 	if err := unmarshal(&dec); err != nil {
@@ -208,6 +295,18 @@ func (c *Config) UnmarshalTOML(unmarshal func(interface{}) error) error {
 	if dec.FetcherDisable != nil {
 		c.FetcherDisable = *dec.FetcherDisable
 	}
+	if dec.ReadReplica != nil {
+		c.ReadReplica = *dec.ReadReplica
+	}
+	if dec.TrustedCheckpoint != nil {
+		c.TrustedCheckpoint = *dec.TrustedCheckpoint
+	}
+	if dec.CheckpointSigners != nil {
+		c.CheckpointSigners = dec.CheckpointSigners
+	}
+	if dec.CheckpointThreshold != nil {
+		c.CheckpointThreshold = *dec.CheckpointThreshold
+	}
 	if dec.ParentOperatorAddr != nil {
 		c.ParentOperatorAddr = dec.ParentOperatorAddr
 	}
@@ -247,6 +346,12 @@ func (c *Config) UnmarshalTOML(unmarshal func(interface{}) error) error {
 	if dec.LevelDBCacheSize != nil {
 		c.LevelDBCacheSize = *dec.LevelDBCacheSize
 	}
+	if dec.RocksDBBlockCacheSize != nil {
+		c.RocksDBBlockCacheSize = *dec.RocksDBBlockCacheSize
+	}
+	if dec.RocksDBCompactionStyle != nil {
+		c.RocksDBCompactionStyle = *dec.RocksDBCompactionStyle
+	}
 	if dec.DynamoDBConfig != nil {
 		c.DynamoDBConfig = *dec.DynamoDBConfig
 	}
@@ -262,9 +367,30 @@ func (c *Config) UnmarshalTOML(unmarshal func(interface{}) error) error {
 	if dec.TriesInMemory != nil {
 		c.TriesInMemory = *dec.TriesInMemory
 	}
+	if dec.PeriodicMigrationInterval != nil {
+		c.PeriodicMigrationInterval = *dec.PeriodicMigrationInterval
+	}
+	if dec.StateHistory != nil {
+		c.StateHistory = *dec.StateHistory
+	}
 	if dec.SenderTxHashIndexing != nil {
 		c.SenderTxHashIndexing = *dec.SenderTxHashIndexing
 	}
+	if dec.TxLookupLimit != nil {
+		c.TxLookupLimit = *dec.TxLookupLimit
+	}
+	if dec.ParallelTxWorkers != nil {
+		c.ParallelTxWorkers = *dec.ParallelTxWorkers
+	}
+	if dec.AccountTxIndexing != nil {
+		c.AccountTxIndexing = *dec.AccountTxIndexing
+	}
+	if dec.TokenTransferIndexing != nil {
+		c.TokenTransferIndexing = *dec.TokenTransferIndexing
+	}
+	if dec.FeeStatsIndexing != nil {
+		c.FeeStatsIndexing = *dec.FeeStatsIndexing
+	}
 	if dec.ParallelDBWrite != nil {
 		c.ParallelDBWrite = *dec.ParallelDBWrite
 	}
@@ -301,6 +427,15 @@ func (c *Config) UnmarshalTOML(unmarshal func(interface{}) error) error {
 	if dec.EnableInternalTxTracing != nil {
 		c.EnableInternalTxTracing = *dec.EnableInternalTxTracing
 	}
+	if dec.LogIndexBackend != nil {
+		c.LogIndexBackend = *dec.LogIndexBackend
+	}
+	if dec.LogIndexEndpoint != nil {
+		c.LogIndexEndpoint = *dec.LogIndexEndpoint
+	}
+	if dec.InternalTxIndexing != nil {
+		c.InternalTxIndexing = *dec.InternalTxIndexing
+	}
 	if dec.Istanbul != nil {
 		c.Istanbul = *dec.Istanbul
 	}
@@ -340,8 +475,47 @@ func (c *Config) UnmarshalTOML(unmarshal func(interface{}) error) error {
 	if dec.RPCEVMTimeout != nil {
 		c.RPCEVMTimeout = *dec.RPCEVMTimeout
 	}
+	if dec.RPCCallStateReuseWindow != nil {
+		c.RPCCallStateReuseWindow = *dec.RPCCallStateReuseWindow
+	}
+	if dec.RPCStateReexecLimit != nil {
+		c.RPCStateReexecLimit = *dec.RPCStateReexecLimit
+	}
+	if dec.RPCCustomTracerTimeout != nil {
+		c.RPCCustomTracerTimeout = *dec.RPCCustomTracerTimeout
+	}
+	if dec.RPCCustomTracerMaxResultSize != nil {
+		c.RPCCustomTracerMaxResultSize = *dec.RPCCustomTracerMaxResultSize
+	}
+	if dec.RPCStandardTraceDir != nil {
+		c.RPCStandardTraceDir = *dec.RPCStandardTraceDir
+	}
 	if dec.RPCTxFeeCap != nil {
 		c.RPCTxFeeCap = *dec.RPCTxFeeCap
 	}
+	if dec.RPCEthKlaytnTxMode != nil {
+		c.RPCEthKlaytnTxMode = *dec.RPCEthKlaytnTxMode
+	}
+	if dec.RPCTxPoolEthFormat != nil {
+		c.RPCTxPoolEthFormat = *dec.RPCTxPoolEthFormat
+	}
+	if dec.RPCFeePayerWhitelist != nil {
+		c.RPCFeePayerWhitelist = dec.RPCFeePayerWhitelist
+	}
+	if dec.RPCReceiptsCacheSize != nil {
+		c.RPCReceiptsCacheSize = *dec.RPCReceiptsCacheSize
+	}
+	if dec.RPCEthBlocksCacheSize != nil {
+		c.RPCEthBlocksCacheSize = *dec.RPCEthBlocksCacheSize
+	}
+	if dec.RPCEthReceiptsCacheSize != nil {
+		c.RPCEthReceiptsCacheSize = *dec.RPCEthReceiptsCacheSize
+	}
+	if dec.RPCEthPrecomputeBlocks != nil {
+		c.RPCEthPrecomputeBlocks = *dec.RPCEthPrecomputeBlocks
+	}
+	if dec.RPCSendersCacheSize != nil {
+		c.RPCSendersCacheSize = *dec.RPCSendersCacheSize
+	}
 	return nil
 }
@@ -138,6 +138,10 @@ type ProtocolManager struct {
 
 	// syncStop is a flag to stop peer sync
 	syncStop int32
+
+	// retriever retrieves the bodies, the receipts and the state on demand in a light node.
+	// It is nil if the node is not a light node.
+	retriever *lightRetriever
 }
 
 // NewProtocolManager returns a new Klaytn sub protocol manager. The Klaytn sub protocol manages peers capable
@@ -313,7 +317,15 @@ func NewProtocolManager(config *params.ChainConfig, mode downloader.SyncMode, ne
 		if config.Istanbul != nil {
 			proposerPolicy = config.Istanbul.ProposerPolicy
 		}
-		manager.downloader = downloader.New(mode, chainDB, stateBloom, manager.eventMux, blockchain, nil, manager.removePeer, proposerPolicy)
+		dl := downloader.New(mode, chainDB, stateBloom, manager.eventMux, blockchain, nil, manager.removePeer, proposerPolicy)
+		if cnconfig.TrustedCheckpoint != "" {
+			checkpoint, err := loadTrustedCheckpoint(cnconfig)
+			if err != nil {
+				return nil, err
+			}
+			dl.SetTrustedCheckpoint(checkpoint)
+		}
+		manager.downloader = dl
 	}
 
 	// Create and set fetcher
@@ -383,6 +395,13 @@ func (pm *ProtocolManager) removePeer(id string) {
 	}
 }
 
+// setLightRetriever makes the protocol manager run as a light node, which syncs only the headers
+// and retrieves the other data on demand with the given retriever.
+func (pm *ProtocolManager) setLightRetriever(retriever *lightRetriever) {
+	retriever.peers = pm.peers
+	pm.retriever = retriever
+}
+
 // getChainID returns the current chain id.
 func (pm *ProtocolManager) getChainID() *big.Int {
 	return pm.blockchain.Config().ChainID
@@ -403,6 +422,7 @@ func (pm *ProtocolManager) Start(maxPeers int) {
 	// start sync handlers
 	go pm.syncer()
 	go pm.txsyncLoop()
+	go pm.nodeLagLoop()
 }
 
 func (pm *ProtocolManager) Stop() {
@@ -917,6 +937,10 @@ func handleBlockBodiesMsg(pm *ProtocolManager, p Peer, msg p2p.Msg) error {
 	for i, body := range request {
 		transactions[i] = body.Transactions
 	}
+	if pm.retriever != nil {
+		pm.retriever.deliverBodies(transactions)
+		return nil
+	}
 
 	err := pm.downloader.DeliverBodies(p.GetID(), transactions)
 	if err != nil {
@@ -939,6 +963,10 @@ func handleNodeDataRequestMsg(pm *ProtocolManager, p Peer, msg p2p.Msg) error {
 		bytes int
 		data  [][]byte
 	)
+	// A light node does not serve the state, which it retrieves from its peers.
+	if pm.retriever != nil {
+		return p.SendNodeData(data)
+	}
 	for bytes < softResponseLimit && len(data) < downloader.MaxStateFetch {
 		// Retrieve the hash of the next state entry
 		if err := msgStream.Decode(&hash); err == rlp.EOL {
@@ -968,6 +996,10 @@ func handleNodeDataMsg(pm *ProtocolManager, p Peer, msg p2p.Msg) error {
 	if err := msg.Decode(&data); err != nil {
 		return errResp(ErrDecode, "msg %v: %v", msg, err)
 	}
+	if pm.retriever != nil {
+		pm.retriever.deliverNodeData(data)
+		return nil
+	}
 	// Deliver all to the downloader
 	if err := pm.downloader.DeliverNodeData(p.GetID(), data); err != nil {
 		logger.Debug("Failed to deliver node state data", "err", err)
@@ -1020,6 +1052,10 @@ func handleReceiptsMsg(pm *ProtocolManager, p Peer, msg p2p.Msg) error {
 	if err := msg.Decode(&receipts); err != nil {
 		return errResp(ErrDecode, "msg %v: %v", msg, err)
 	}
+	if pm.retriever != nil {
+		pm.retriever.deliverReceipts(receipts)
+		return nil
+	}
 	// Deliver all to the downloader
 	if err := pm.downloader.DeliverReceipts(p.GetID(), receipts); err != nil {
 		logger.Debug("Failed to deliver receipts", "err", err)
@@ -29,6 +29,7 @@ import (
 	"github.com/klaytn/klaytn/blockchain"
 	"github.com/klaytn/klaytn/blockchain/types"
 	"github.com/klaytn/klaytn/common"
+	"github.com/klaytn/klaytn/common/hexutil"
 	"github.com/klaytn/klaytn/consensus"
 	consensusmocks "github.com/klaytn/klaytn/consensus/mocks"
 	"github.com/klaytn/klaytn/crypto"
@@ -1173,3 +1174,34 @@ func createAndRegisterPeers(mockCtrl *gomock.Controller, peers *peerSet) (*MockP
 
 	return cnPeer, pnPeer, enPeer
 }
+
+func TestNodeLag(t *testing.T) {
+	pm := &ProtocolManager{}
+	mockCtrl, _, mockBlockChain, _ := newMocks(t)
+	defer mockCtrl.Finish()
+	pm.blockchain = mockBlockChain
+
+	peers := newPeerSet()
+	pm.peers = peers
+	cnPeer, pnPeer, enPeer := createAndRegisterPeers(mockCtrl, peers)
+
+	current := types.NewBlockWithHeader(&types.Header{
+		Number:     big.NewInt(100),
+		BlockScore: big.NewInt(1),
+		Time:       big.NewInt(time.Now().Unix() - 30),
+	})
+	mockBlockChain.EXPECT().CurrentBlock().Return(current).Times(1)
+	mockBlockChain.EXPECT().GetTd(current.Hash(), current.NumberU64()).Return(big.NewInt(101)).Times(1)
+
+	cnPeer.EXPECT().Head().Return(common.Hash{}, big.NewInt(101)).Times(1)
+	pnPeer.EXPECT().Head().Return(common.Hash{}, big.NewInt(105)).Times(1)
+	enPeer.EXPECT().Head().Return(common.Hash{}, big.NewInt(111)).Times(1)
+
+	lag := pm.NodeLag()
+	assert.Equal(t, hexutil.Uint64(100), lag.LocalHead)
+	assert.Equal(t, hexutil.Uint64(110), lag.BestPeerHead)
+	assert.Equal(t, hexutil.Uint64(10), lag.BlocksBehind)
+	assert.True(t, lag.SecondsBehind >= 30)
+	assert.Equal(t, 2, lag.PeersAhead)
+	assert.Equal(t, 3, lag.Peers)
+}
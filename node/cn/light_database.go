@@ -0,0 +1,158 @@
+// Copyright 2022 The klaytn Authors
+// This file is part of the klaytn library.
+//
+// The klaytn library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The klaytn library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the klaytn library. If not, see <http://www.gnu.org/licenses/>.
+
+package cn
+
+import (
+	"context"
+
+	"github.com/klaytn/klaytn/blockchain/types"
+	"github.com/klaytn/klaytn/common"
+	"github.com/klaytn/klaytn/storage/database"
+)
+
+// lightDatabase is the database of a light node, which has only the headers of the blocks.
+// The bodies, the receipts and the state missing in the database are retrieved on demand from
+// the peers, validated against the headers and the trie node hashes, and stored for later reads.
+type lightDatabase struct {
+	database.DBManager
+	retriever *lightRetriever
+}
+
+func newLightDatabase(db database.DBManager, retriever *lightRetriever) *lightDatabase {
+	return &lightDatabase{DBManager: db, retriever: retriever}
+}
+
+// ReadCachedTrieNode retrieves the state trie node from the peers if it is not in the database.
+func (db *lightDatabase) ReadCachedTrieNode(hash common.Hash) ([]byte, error) {
+	if enc, err := db.DBManager.ReadCachedTrieNode(hash); err == nil && len(enc) > 0 {
+		return enc, nil
+	}
+	enc, err := db.retriever.retrieveNode(context.Background(), hash)
+	if err != nil {
+		logger.Debug("Failed to retrieve a trie node", "hash", hash, "err", err)
+		return nil, err
+	}
+	if err := db.GetStateTrieDB().Put(hash[:], enc); err != nil {
+		logger.Error("Failed to store a retrieved trie node", "hash", hash, "err", err)
+	}
+	return enc, nil
+}
+
+// ReadCode retrieves the contract code from the peers if it is not in the database.
+func (db *lightDatabase) ReadCode(hash common.Hash) []byte {
+	if code := db.DBManager.ReadCode(hash); len(code) > 0 {
+		return code
+	}
+	return db.retrieveCode(hash)
+}
+
+// ReadCodeWithPrefix retrieves the contract code from the peers if it is not in the database.
+func (db *lightDatabase) ReadCodeWithPrefix(hash common.Hash) []byte {
+	if code := db.DBManager.ReadCodeWithPrefix(hash); len(code) > 0 {
+		return code
+	}
+	return db.retrieveCode(hash)
+}
+
+func (db *lightDatabase) retrieveCode(hash common.Hash) []byte {
+	code, err := db.retriever.retrieveNode(context.Background(), hash)
+	if err != nil {
+		logger.Debug("Failed to retrieve a contract code", "hash", hash, "err", err)
+		return nil
+	}
+	db.WriteCode(hash, code)
+	return code
+}
+
+// ReadBody retrieves the block body from the peers if it is not in the database.
+func (db *lightDatabase) ReadBody(hash common.Hash, number uint64) *types.Body {
+	if body := db.DBManager.ReadBody(hash, number); body != nil {
+		return body
+	}
+	header := db.ReadHeader(hash, number)
+	if header == nil {
+		return nil
+	}
+	body, err := db.retriever.retrieveBody(context.Background(), header)
+	if err != nil {
+		logger.Debug("Failed to retrieve a block body", "number", number, "hash", hash, "err", err)
+		return nil
+	}
+	db.WriteBody(hash, number, body)
+	return body
+}
+
+// ReadBlock assembles the block with the body retrieved from the peers if it is not in the database.
+func (db *lightDatabase) ReadBlock(hash common.Hash, number uint64) *types.Block {
+	if block := db.DBManager.ReadBlock(hash, number); block != nil {
+		return block
+	}
+	header := db.ReadHeader(hash, number)
+	if header == nil {
+		return nil
+	}
+	body := db.ReadBody(hash, number)
+	if body == nil {
+		return nil
+	}
+	return types.NewBlockWithHeader(header).WithBody(body.Transactions)
+}
+
+func (db *lightDatabase) ReadBlockByHash(hash common.Hash) *types.Block {
+	number := db.ReadHeaderNumber(hash)
+	if number == nil {
+		return nil
+	}
+	return db.ReadBlock(hash, *number)
+}
+
+func (db *lightDatabase) ReadBlockByNumber(number uint64) *types.Block {
+	hash := db.ReadCanonicalHash(number)
+	if hash == (common.Hash{}) {
+		return nil
+	}
+	return db.ReadBlock(hash, number)
+}
+
+// ReadReceipts retrieves the receipts of the block from the peers if they are not in the database.
+func (db *lightDatabase) ReadReceipts(hash common.Hash, number uint64) types.Receipts {
+	if receipts := db.DBManager.ReadReceipts(hash, number); receipts != nil {
+		return receipts
+	}
+	header := db.ReadHeader(hash, number)
+	if header == nil {
+		return nil
+	}
+	receipts, err := db.retriever.retrieveReceipts(context.Background(), header)
+	if err != nil {
+		logger.Debug("Failed to retrieve block receipts", "number", number, "hash", hash, "err", err)
+		return nil
+	}
+	db.WriteReceipts(hash, number, receipts)
+	return receipts
+}
+
+func (db *lightDatabase) ReadReceiptsByBlockHash(hash common.Hash) types.Receipts {
+	if receipts := db.ReadBlockReceiptsInCache(hash); receipts != nil {
+		return receipts
+	}
+	number := db.ReadHeaderNumber(hash)
+	if number == nil {
+		return nil
+	}
+	return db.ReadReceipts(hash, *number)
+}
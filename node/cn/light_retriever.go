@@ -0,0 +1,207 @@
+// Copyright 2022 The klaytn Authors
+// This file is part of the klaytn library.
+//
+// The klaytn library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The klaytn library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the klaytn library. If not, see <http://www.gnu.org/licenses/>.
+
+package cn
+
+import (
+	"context"
+	"errors"
+	"math/big"
+	"sync"
+	"time"
+
+	"github.com/klaytn/klaytn/blockchain/types"
+	"github.com/klaytn/klaytn/common"
+	"github.com/klaytn/klaytn/crypto"
+)
+
+const (
+	// lightRetrieveTimeout is the time waiting for the peers to answer an on-demand request.
+	lightRetrieveTimeout = 5 * time.Second
+
+	// lightPeerTimeout is the time waiting for a peer before the request is sent to another peer.
+	lightPeerTimeout = time.Second
+)
+
+var (
+	errNoLightPeer       = errors.New("no peer to retrieve the data from")
+	errLightRetrieveFail = errors.New("the data was not retrieved from the peers")
+)
+
+// lightRequestKind is the kind of the data requested on demand.
+type lightRequestKind int
+
+const (
+	lightNodeData lightRequestKind = iota
+	lightBody
+	lightReceipts
+)
+
+// lightRequest is an on-demand request waiting for its response.
+type lightRequest struct {
+	kind   lightRequestKind
+	root   common.Hash      // hash of the requested data
+	number *big.Int         // block number used to derive the root of a body or receipts
+	result chan interface{} // receives the matched data
+}
+
+// lightRetriever retrieves the data missing in a light node on demand from its peers.
+// The requests are sent with the existing messages of the klay protocol. The responses are
+// matched to the requests by their content, so that a peer cannot answer with data which
+// does not hash to the header fields or the trie node hashes trusted by the light node.
+type lightRetriever struct {
+	peers PeerSet
+
+	mu      sync.Mutex
+	pending map[*lightRequest]struct{}
+}
+
+func newLightRetriever() *lightRetriever {
+	return &lightRetriever{pending: make(map[*lightRequest]struct{})}
+}
+
+// retrieveNode retrieves a state trie node or a contract code by its hash.
+func (r *lightRetriever) retrieveNode(ctx context.Context, hash common.Hash) ([]byte, error) {
+	res, err := r.retrieve(ctx, &lightRequest{kind: lightNodeData, root: hash}, func(p Peer) error {
+		return p.RequestNodeData([]common.Hash{hash})
+	})
+	if err != nil {
+		return nil, err
+	}
+	return res.([]byte), nil
+}
+
+// retrieveBody retrieves the body of the block of the given header.
+func (r *lightRetriever) retrieveBody(ctx context.Context, header *types.Header) (*types.Body, error) {
+	if header.EmptyBody() {
+		return &types.Body{}, nil
+	}
+	hash := header.Hash()
+	res, err := r.retrieve(ctx, &lightRequest{kind: lightBody, root: header.TxHash, number: header.Number}, func(p Peer) error {
+		return p.RequestBodies([]common.Hash{hash})
+	})
+	if err != nil {
+		return nil, err
+	}
+	return &types.Body{Transactions: res.([]*types.Transaction)}, nil
+}
+
+// retrieveReceipts retrieves the receipts of the block of the given header.
+func (r *lightRetriever) retrieveReceipts(ctx context.Context, header *types.Header) (types.Receipts, error) {
+	if header.EmptyReceipts() {
+		return types.Receipts{}, nil
+	}
+	hash := header.Hash()
+	res, err := r.retrieve(ctx, &lightRequest{kind: lightReceipts, root: header.ReceiptHash, number: header.Number}, func(p Peer) error {
+		return p.RequestReceipts([]common.Hash{hash})
+	})
+	if err != nil {
+		return nil, err
+	}
+	return res.(types.Receipts), nil
+}
+
+// retrieve sends the request to a peer, and to another peer each time a peer does not answer
+// in lightPeerTimeout, until the response is delivered or the context is done.
+func (r *lightRetriever) retrieve(ctx context.Context, req *lightRequest, send func(p Peer) error) (interface{}, error) {
+	if r.peers == nil || r.peers.Len() == 0 {
+		return nil, errNoLightPeer
+	}
+	ctx, cancel := context.WithTimeout(ctx, lightRetrieveTimeout)
+	defer cancel()
+
+	req.result = make(chan interface{}, 1)
+	r.mu.Lock()
+	r.pending[req] = struct{}{}
+	r.mu.Unlock()
+	defer func() {
+		r.mu.Lock()
+		delete(r.pending, req)
+		r.mu.Unlock()
+	}()
+
+	tried := make(map[string]bool)
+	for {
+		for id, p := range r.peers.Peers() {
+			if tried[id] {
+				continue
+			}
+			tried[id] = true
+			if err := send(p); err != nil {
+				logger.Debug("Failed to send an on-demand request", "peer", id, "err", err)
+				continue
+			}
+			break
+		}
+		timer := time.NewTimer(lightPeerTimeout)
+		select {
+		case res := <-req.result:
+			timer.Stop()
+			return res, nil
+		case <-ctx.Done():
+			timer.Stop()
+			return nil, errLightRetrieveFail
+		case <-timer.C:
+			if len(tried) >= r.peers.Len() {
+				// All the peers were tried, start over from the first peer.
+				tried = make(map[string]bool)
+			}
+		}
+	}
+}
+
+// deliver passes the delivered data to the pending requests of the kind whose roots are matched by the data.
+func (r *lightRetriever) deliver(kind lightRequestKind, data interface{}, root func(number *big.Int) common.Hash) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	for req := range r.pending {
+		if req.kind == kind && root(req.number) == req.root {
+			select {
+			case req.result <- data:
+			default:
+			}
+		}
+	}
+}
+
+// deliverNodeData passes the node data received from a peer to the pending requests.
+func (r *lightRetriever) deliverNodeData(data [][]byte) {
+	for _, node := range data {
+		hash := crypto.Keccak256Hash(node)
+		r.deliver(lightNodeData, node, func(*big.Int) common.Hash { return hash })
+	}
+}
+
+// deliverBodies passes the block bodies received from a peer to the pending requests.
+func (r *lightRetriever) deliverBodies(bodies [][]*types.Transaction) {
+	for _, txs := range bodies {
+		txs := txs
+		r.deliver(lightBody, txs, func(number *big.Int) common.Hash {
+			return types.DeriveSha(types.Transactions(txs), number)
+		})
+	}
+}
+
+// deliverReceipts passes the receipts received from a peer to the pending requests.
+func (r *lightRetriever) deliverReceipts(receipts [][]*types.Receipt) {
+	for _, list := range receipts {
+		list := types.Receipts(list)
+		r.deliver(lightReceipts, list, func(number *big.Int) common.Hash {
+			return types.DeriveSha(list, number)
+		})
+	}
+}
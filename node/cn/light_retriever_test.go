@@ -0,0 +1,68 @@
+// Copyright 2022 The klaytn Authors
+// This file is part of the klaytn library.
+//
+// The klaytn library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The klaytn library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the klaytn library. If not, see <http://www.gnu.org/licenses/>.
+
+package cn
+
+import (
+	"context"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/klaytn/klaytn/common"
+	"github.com/klaytn/klaytn/crypto"
+	"github.com/klaytn/klaytn/storage/database"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestLightRetriever_RetrieveNode(t *testing.T) {
+	mockCtrl := gomock.NewController(t)
+	defer mockCtrl.Finish()
+
+	var (
+		node = []byte("trie node")
+		hash = crypto.Keccak256Hash(node)
+	)
+
+	retriever := newLightRetriever()
+	peers := NewMockPeerSet(mockCtrl)
+	retriever.peers = peers
+
+	// Nothing is retrieved without peers.
+	peers.EXPECT().Len().Return(0).Times(1)
+	_, err := retriever.retrieveNode(context.Background(), hash)
+	assert.Equal(t, errNoLightPeer, err)
+
+	// The data which does not hash to the requested hash is ignored.
+	peer := NewMockPeer(mockCtrl)
+	peer.EXPECT().RequestNodeData([]common.Hash{hash}).DoAndReturn(func([]common.Hash) error {
+		go retriever.deliverNodeData([][]byte{[]byte("invalid node"), node})
+		return nil
+	}).Times(1)
+	peers.EXPECT().Len().Return(1).AnyTimes()
+	peers.EXPECT().Peers().Return(map[string]Peer{"peer": peer}).AnyTimes()
+
+	// The retrieved node is stored in the light database.
+	db := newLightDatabase(database.NewMemoryDBManager(), retriever)
+	enc, err := db.ReadCachedTrieNode(hash)
+	require.NoError(t, err)
+	assert.Equal(t, node, enc)
+	assert.Len(t, retriever.pending, 0)
+
+	enc, err = db.DBManager.ReadCachedTrieNode(hash)
+	require.NoError(t, err)
+	assert.Equal(t, node, enc)
+}
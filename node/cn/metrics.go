@@ -68,6 +68,9 @@ var (
 	cnPeerCountGauge                     = metrics.NewRegisteredGauge("p2p/CNPeerCountGauge", nil)
 	pnPeerCountGauge                     = metrics.NewRegisteredGauge("p2p/PNPeerCountGauge", nil)
 	enPeerCountGauge                     = metrics.NewRegisteredGauge("p2p/ENPeerCountGauge", nil)
+	nodeLagBlocksGauge                   = metrics.NewRegisteredGauge("klay/lag/blocks", nil)
+	nodeLagSecondsGauge                  = metrics.NewRegisteredGauge("klay/lag/seconds", nil)
+	nodeLagPeersAheadGauge               = metrics.NewRegisteredGauge("klay/lag/peersahead", nil)
 	propConsensusIstanbulInPacketsMeter  = metrics.NewRegisteredMeter("klay/prop/consensus/istanbul/in/packets", nil)
 	propConsensusIstanbulInTrafficMeter  = metrics.NewRegisteredMeter("klay/prop/consensus/istanbul/in/traffic", nil)
 	propConsensusIstanbulOutPacketsMeter = metrics.NewRegisteredMeter("klay/prop/consensus/istanbul/out/packets", nil)
@@ -10,6 +10,7 @@ import (
 	gomock "github.com/golang/mock/gomock"
 	state "github.com/klaytn/klaytn/blockchain/state"
 	types "github.com/klaytn/klaytn/blockchain/types"
+	common "github.com/klaytn/klaytn/common"
 	work "github.com/klaytn/klaytn/work"
 )
 
@@ -36,6 +37,34 @@ func (m *MockMiner) EXPECT() *MockMinerMockRecorder {
 	return m.recorder
 }
 
+// AddBundle mocks base method
+func (m *MockMiner) AddBundle(arg0 *work.Bundle) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "AddBundle", arg0)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// AddBundle indicates an expected call of AddBundle
+func (mr *MockMinerMockRecorder) AddBundle(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AddBundle", reflect.TypeOf((*MockMiner)(nil).AddBundle), arg0)
+}
+
+// Bundles mocks base method
+func (m *MockMiner) Bundles() []*work.Bundle {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Bundles")
+	ret0, _ := ret[0].([]*work.Bundle)
+	return ret0
+}
+
+// Bundles indicates an expected call of Bundles
+func (mr *MockMinerMockRecorder) Bundles() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Bundles", reflect.TypeOf((*MockMiner)(nil).Bundles))
+}
+
 // HashRate mocks base method
 func (m *MockMiner) HashRate() int64 {
 	m.ctrl.T.Helper()
@@ -105,6 +134,20 @@ func (mr *MockMinerMockRecorder) Register(arg0 interface{}) *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Register", reflect.TypeOf((*MockMiner)(nil).Register), arg0)
 }
 
+// RemoveBundle mocks base method
+func (m *MockMiner) RemoveBundle(arg0 common.Hash) bool {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RemoveBundle", arg0)
+	ret0, _ := ret[0].(bool)
+	return ret0
+}
+
+// RemoveBundle indicates an expected call of RemoveBundle
+func (mr *MockMinerMockRecorder) RemoveBundle(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RemoveBundle", reflect.TypeOf((*MockMiner)(nil).RemoveBundle), arg0)
+}
+
 // SetExtra mocks base method
 func (m *MockMiner) SetExtra(arg0 []byte) error {
 	m.ctrl.T.Helper()
@@ -0,0 +1,85 @@
+// Copyright 2022 The klaytn Authors
+// This file is part of the klaytn library.
+//
+// The klaytn library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The klaytn library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the klaytn library. If not, see <http://www.gnu.org/licenses/>.
+
+package cn
+
+import (
+	"math/big"
+	"time"
+
+	"github.com/klaytn/klaytn/common/hexutil"
+)
+
+// nodeLagReportInterval is the interval of updating the node lag metrics.
+const nodeLagReportInterval = 10 * time.Second
+
+// NodeLag is how far the local chain is behind the heads advertised by the peers.
+// Since the blockscore of every block is 1 in Klaytn, the difference of the total blockscores
+// is used as the number of the blocks behind.
+type NodeLag struct {
+	LocalHead     hexutil.Uint64 `json:"localHead"`
+	BestPeerHead  hexutil.Uint64 `json:"bestPeerHead"`
+	BlocksBehind  hexutil.Uint64 `json:"blocksBehind"`
+	SecondsBehind uint64         `json:"secondsBehind"` // seconds elapsed since the timestamp of the local head
+	PeersAhead    int            `json:"peersAhead"`    // number of the peers advertising a better head
+	Peers         int            `json:"peers"`
+}
+
+// NodeLag compares the local head with the heads advertised by the peers.
+func (pm *ProtocolManager) NodeLag() *NodeLag {
+	current := pm.blockchain.CurrentBlock()
+	localTd := pm.blockchain.GetTd(current.Hash(), current.NumberU64())
+	if localTd == nil {
+		localTd = new(big.Int)
+	}
+
+	lag := &NodeLag{LocalHead: hexutil.Uint64(current.NumberU64())}
+	bestTd := localTd
+	for _, p := range pm.peers.Peers() {
+		lag.Peers++
+		if _, td := p.Head(); td.Cmp(localTd) > 0 {
+			lag.PeersAhead++
+			if td.Cmp(bestTd) > 0 {
+				bestTd = td
+			}
+		}
+	}
+	lag.BlocksBehind = hexutil.Uint64(new(big.Int).Sub(bestTd, localTd).Uint64())
+	lag.BestPeerHead = lag.LocalHead + lag.BlocksBehind
+	if now, headTime := uint64(time.Now().Unix()), current.Time().Uint64(); now > headTime {
+		lag.SecondsBehind = now - headTime
+	}
+	return lag
+}
+
+// nodeLagLoop updates the node lag metrics periodically, so that monitoring can alert on
+// a stalled node even when it is not syncing.
+func (pm *ProtocolManager) nodeLagLoop() {
+	ticker := time.NewTicker(nodeLagReportInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ticker.C:
+			lag := pm.NodeLag()
+			nodeLagBlocksGauge.Update(int64(lag.BlocksBehind))
+			nodeLagSecondsGauge.Update(int64(lag.SecondsBehind))
+			nodeLagPeersAheadGauge.Update(int64(lag.PeersAhead))
+		case <-pm.quitSync:
+			return
+		}
+	}
+}
@@ -79,6 +79,20 @@ func (mr *MockBackendProtocolManagerMockRecorder) NodeType() *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "NodeType", reflect.TypeOf((*MockBackendProtocolManager)(nil).NodeType))
 }
 
+// NodeLag mocks base method.
+func (m *MockBackendProtocolManager) NodeLag() *NodeLag {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "NodeLag")
+	ret0, _ := ret[0].(*NodeLag)
+	return ret0
+}
+
+// NodeLag indicates an expected call of NodeLag.
+func (mr *MockBackendProtocolManagerMockRecorder) NodeLag() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "NodeLag", reflect.TypeOf((*MockBackendProtocolManager)(nil).NodeLag))
+}
+
 // ProtocolVersion mocks base method.
 func (m *MockBackendProtocolManager) ProtocolVersion() int {
 	m.ctrl.T.Helper()
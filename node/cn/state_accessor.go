@@ -83,6 +83,14 @@ func (cn *CN) stateAtBlock(block *types.Block, reexec uint64, base *state.StateD
 		// the internal junks created by tracing will be persisted into the disk.
 		database = state.NewDatabaseWithExistingCache(cn.ChainDB(), cn.blockchain.StateCache().TrieDB().TrieNodeCache())
 
+		// If we didn't check the live database, do check state over ephemeral database,
+		// so that no block is re-executed if the state is available, even if reexec is 0.
+		if !checkLive {
+			statedb, err = state.New(current.Root(), database, nil)
+			if err == nil {
+				return statedb, nil
+			}
+		}
 		for i := uint64(0); i < reexec; i++ {
 			if current.NumberU64() == 0 {
 				return nil, errors.New("genesis state is missing")
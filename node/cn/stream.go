@@ -0,0 +1,61 @@
+// Copyright 2022 The klaytn Authors
+// This file is part of the klaytn library.
+//
+// The klaytn library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The klaytn library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the klaytn library. If not, see <http://www.gnu.org/licenses/>.
+
+package cn
+
+import (
+	"context"
+	"encoding/json"
+
+	"github.com/klaytn/klaytn/blockchain/types"
+	"github.com/klaytn/klaytn/networks/grpc"
+	"github.com/klaytn/klaytn/node/cn/tracers"
+)
+
+// StreamBackend returns the backend and the tracer of the gRPC block stream.
+// The tracer is nil if the traces of the block stream are disabled.
+func (s *CN) StreamBackend() (grpc.StreamBackend, grpc.StreamTracer) {
+	if !grpc.StreamTraces || s.tracerAPI == nil {
+		return s.APIBackend, nil
+	}
+	return s.APIBackend, &streamTracer{api: s.tracerAPI}
+}
+
+// streamTracer traces the blocks of the gRPC block stream with the debug tracing API.
+type streamTracer struct {
+	api *tracers.API
+}
+
+func (st *streamTracer) TraceBlock(ctx context.Context, block *types.Block, tracer string) ([]*grpc.TraceMessage, error) {
+	config := &tracers.TraceConfig{}
+	if tracer != "" {
+		config.Tracer = &tracer
+	}
+	results, err := st.api.TraceBlockByHash(ctx, block.Hash(), config)
+	if err != nil {
+		return nil, err
+	}
+	msgs := make([]*grpc.TraceMessage, len(results))
+	for i, result := range results {
+		msgs[i] = &grpc.TraceMessage{TxHash: result.TxHash.Bytes(), Error: result.Error}
+		if result.Result != nil {
+			if msgs[i].Result, err = json.Marshal(result.Result); err != nil {
+				return nil, err
+			}
+		}
+	}
+	return msgs, nil
+}
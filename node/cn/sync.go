@@ -168,7 +168,10 @@ func (pm *ProtocolManager) syncer() {
 
 // getSyncMode returns SyncMode based on currentBlockNumber.
 func (pm *ProtocolManager) getSyncMode(currentBlock *types.Block) downloader.SyncMode {
-	if atomic.LoadUint32(&pm.snapSync) == 1 {
+	if pm.retriever != nil {
+		// A light node syncs only the headers
+		return downloader.LightSync
+	} else if atomic.LoadUint32(&pm.snapSync) == 1 {
 		// Snap sync was explicitly requested, and explicitly granted
 		return downloader.SnapSync
 	} else if atomic.LoadUint32(&pm.fastSync) == 1 {
@@ -195,6 +198,11 @@ func (pm *ProtocolManager) synchronise(peer Peer) {
 	// Make sure the peer's TD is higher than our own
 	currentBlock := pm.blockchain.CurrentBlock()
 	td := pm.blockchain.GetTd(currentBlock.Hash(), currentBlock.NumberU64())
+	if pm.retriever != nil {
+		// The current block of a light node follows its current header.
+		currentHeader := pm.blockchain.CurrentHeader()
+		td = pm.blockchain.GetTd(currentHeader.Hash(), currentHeader.Number.Uint64())
+	}
 
 	pHead, pTd := peer.Head()
 	if pTd.Cmp(td) <= 0 {
@@ -222,7 +230,7 @@ func (pm *ProtocolManager) synchronise(peer Peer) {
 		atomic.StoreUint32(&pm.snapSync, 0)
 	}
 	atomic.StoreUint32(&pm.acceptTxs, 1) // Mark initial sync done
-	if head := pm.blockchain.CurrentBlock(); head.NumberU64() > 0 {
+	if head := pm.blockchain.CurrentBlock(); head.NumberU64() > 0 && pm.retriever == nil {
 		// We've completed a sync cycle, notify all peers of new state. This path is
 		// essential in star-topology networks where a gateway node needs to notify
 		// all its out-of-date peers of the availability of a new block. This failure
@@ -23,6 +23,7 @@ package tracers
 import (
 	"bufio"
 	"bytes"
+	"compress/gzip"
 	"context"
 	"errors"
 	"fmt"
@@ -42,6 +43,7 @@ import (
 	"github.com/klaytn/klaytn/common"
 	"github.com/klaytn/klaytn/common/hexutil"
 	"github.com/klaytn/klaytn/consensus"
+	"github.com/klaytn/klaytn/consensus/misc"
 	"github.com/klaytn/klaytn/log"
 	"github.com/klaytn/klaytn/networks/rpc"
 	"github.com/klaytn/klaytn/params"
@@ -72,6 +74,15 @@ const (
 	fastCallTracer = "fastCallTracer"
 )
 
+// standardTraceDir is the directory where the standard traces are dumped. os.TempDir() is used if empty.
+var standardTraceDir string
+
+// SetStandardTraceDir sets the directory where debug_standardTraceBlockToFile dumps the traces.
+// It should be called before the tracing APIs are served.
+func SetStandardTraceDir(dir string) {
+	standardTraceDir = dir
+}
+
 // Backend interface provides the common API services with access to necessary functions.
 type Backend interface {
 	HeaderByHash(ctx context.Context, hash common.Hash) (*types.Header, error)
@@ -163,6 +174,30 @@ func (api *API) blockByNumberAndHash(ctx context.Context, number rpc.BlockNumber
 	return api.blockByHash(ctx, hash)
 }
 
+// blockByNumberOrHash is the wrapper of the chain access function offered by
+// the backend. It will return an error if the block is not found.
+func (api *API) blockByNumberOrHash(ctx context.Context, blockNrOrHash rpc.BlockNumberOrHash) (*types.Block, error) {
+	var (
+		err   error
+		block *types.Block
+	)
+	if hash, ok := blockNrOrHash.Hash(); ok {
+		block, err = api.blockByHash(ctx, hash)
+	} else if number, ok := blockNrOrHash.Number(); ok {
+		block, err = api.blockByNumber(ctx, number)
+	} else {
+		return nil, errors.New("invalid arguments; neither block nor hash specified")
+	}
+	if err != nil {
+		return nil, err
+	}
+	if block == nil {
+		blockNrOrHashString, _ := blockNrOrHash.NumberOrHashString()
+		return nil, fmt.Errorf("block %v not found", blockNrOrHashString)
+	}
+	return block, nil
+}
+
 // TraceConfig holds extra parameters to trace functions.
 type TraceConfig struct {
 	*vm.LogConfig
@@ -171,11 +206,19 @@ type TraceConfig struct {
 	Reexec  *uint64
 }
 
+// TraceCallConfig is the config for traceCall API. It holds one more
+// field to override the state for tracing.
+type TraceCallConfig struct {
+	TraceConfig
+	StateOverrides *klaytnapi.EthStateOverride
+}
+
 // StdTraceConfig holds extra parameters to standard-json trace functions.
 type StdTraceConfig struct {
 	*vm.LogConfig
-	Reexec *uint64
-	TxHash common.Hash
+	Reexec   *uint64
+	TxHash   common.Hash
+	Compress bool // gzip the trace files
 }
 
 // txTraceResult is the result of a single transaction trace.
@@ -462,7 +505,7 @@ func (api *API) TraceBlockByNumber(ctx context.Context, number rpc.BlockNumber,
 	if err != nil {
 		return nil, err
 	}
-	return api.traceBlock(ctx, block, config)
+	return api.traceBlock(ctx, block, config, nil)
 }
 
 // TraceBlockByNumberRange returns the ranged blocks tracing results
@@ -484,7 +527,43 @@ func (api *API) TraceBlockByHash(ctx context.Context, hash common.Hash, config *
 	if err != nil {
 		return nil, err
 	}
-	return api.traceBlock(ctx, block, config)
+	return api.traceBlock(ctx, block, config, nil)
+}
+
+// TraceBlockTransactions traces the transactions of the given block like TraceBlockByNumber,
+// but streams the result of each transaction to the subscriber as soon as it is traced,
+// instead of returning the results of all transactions at once. The results are sent
+// in the order they are traced, which may differ from the order of the transactions.
+func (api *API) TraceBlockTransactions(ctx context.Context, blockNrOrHash rpc.BlockNumberOrHash, config *TraceConfig) (*rpc.Subscription, error) {
+	notifier, supported := rpc.NotifierFromContext(ctx)
+	if !supported {
+		return &rpc.Subscription{}, rpc.ErrNotificationsUnsupported
+	}
+	block, err := api.blockByNumberOrHash(ctx, blockNrOrHash)
+	if err != nil {
+		return nil, err
+	}
+	sub := notifier.CreateSubscription()
+
+	// The tracing outlives the request, so it is aborted when the subscription is closed.
+	localctx, cancel := context.WithCancel(context.Background())
+	go func() {
+		select {
+		case <-notifier.Closed():
+		case <-sub.Err():
+		case <-localctx.Done():
+		}
+		cancel()
+	}()
+	go func() {
+		defer cancel()
+		if _, err := api.traceBlock(localctx, block, config, func(result *txTraceResult) {
+			notifier.Notify(sub.ID, result)
+		}); err != nil {
+			logger.Warn("Tracing block failed", "number", block.NumberU64(), "hash", block.Hash(), "err", err)
+		}
+	}()
+	return sub, nil
 }
 
 // TraceBlock returns the structured logs created during the execution of EVM
@@ -494,7 +573,7 @@ func (api *API) TraceBlock(ctx context.Context, blob hexutil.Bytes, config *Trac
 	if err := rlp.Decode(bytes.NewReader(blob), block); err != nil {
 		return nil, fmt.Errorf("could not decode block: %v", err)
 	}
-	return api.traceBlock(ctx, block, config)
+	return api.traceBlock(ctx, block, config, nil)
 }
 
 // TraceBlockFromFile returns the structured logs created during the execution of
@@ -520,7 +599,7 @@ func (api *API) TraceBadBlock(ctx context.Context, hash common.Hash, config *Tra
 	}
 	for _, block := range blocks {
 		if block.Hash() == hash {
-			return api.traceBlock(ctx, block, config)
+			return api.traceBlock(ctx, block, config, nil)
 		}
 	}
 	return nil, fmt.Errorf("bad block %#x not found", hash)
@@ -562,7 +641,8 @@ func (api *API) StandardTraceBadBlockToFile(ctx context.Context, hash common.Has
 // traceBlock configures a new tracer according to the provided configuration, and
 // executes all the transactions contained within. The return value will be one item
 // per transaction, dependent on the requestd tracer.
-func (api *API) traceBlock(ctx context.Context, block *types.Block, config *TraceConfig) ([]*txTraceResult, error) {
+// If notify is not nil, it is called with the result of each transaction as soon as it is traced.
+func (api *API) traceBlock(ctx context.Context, block *types.Block, config *TraceConfig, notify func(*txTraceResult)) ([]*txTraceResult, error) {
 	if block.NumberU64() == 0 {
 		return nil, errors.New("genesis is not traceable")
 	}
@@ -600,26 +680,20 @@ func (api *API) traceBlock(ctx context.Context, block *types.Block, config *Trac
 
 			// Fetch and execute the next transaction trace tasks
 			for task := range jobs {
-				msg, err := txs[task.index].AsMessageWithAccountKeyPicker(signer, task.statedb, block.NumberU64())
-				if err != nil {
-					logger.Warn("Tracing failed", "tx idx", task.index, "block", block.NumberU64(), "err", err)
-					results[task.index] = &txTraceResult{TxHash: txs[task.index].Hash(), Error: err.Error()}
-					continue
-				}
-
-				vmctx := blockchain.NewEVMContext(msg, block.Header(), newChainContext(ctx, api.backend), nil)
-				res, err := api.traceTx(ctx, msg, vmctx, task.statedb, config)
-				if err != nil {
-					results[task.index] = &txTraceResult{TxHash: txs[task.index].Hash(), Error: err.Error()}
-					continue
+				results[task.index] = api.traceBlockTx(ctx, block, txs[task.index], signer, task.statedb, config)
+				if notify != nil {
+					notify(results[task.index])
 				}
-				results[task.index] = &txTraceResult{TxHash: txs[task.index].Hash(), Result: res}
 			}
 		}()
 	}
 	// Feed the transactions into the tracers and return
 	var failed error
 	for i, tx := range txs {
+		if err := ctx.Err(); err != nil {
+			failed = err
+			break
+		}
 		// Send the trace task over for execution
 		jobs <- &txTraceTask{statedb: statedb.Copy(), index: i}
 
@@ -650,6 +724,22 @@ func (api *API) traceBlock(ctx context.Context, block *types.Block, config *Trac
 	return results, nil
 }
 
+// traceBlockTx traces the given transaction of the block on top of the given state.
+func (api *API) traceBlockTx(ctx context.Context, block *types.Block, tx *types.Transaction, signer types.Signer, statedb *state.StateDB, config *TraceConfig) *txTraceResult {
+	msg, err := tx.AsMessageWithAccountKeyPicker(signer, statedb, block.NumberU64())
+	if err != nil {
+		logger.Warn("Tracing failed", "hash", tx.Hash(), "block", block.NumberU64(), "err", err)
+		return &txTraceResult{TxHash: tx.Hash(), Error: err.Error()}
+	}
+
+	vmctx := blockchain.NewEVMContext(msg, block.Header(), newChainContext(ctx, api.backend), nil)
+	res, err := api.traceTx(ctx, msg, vmctx, statedb, config)
+	if err != nil {
+		return &txTraceResult{TxHash: tx.Hash(), Error: err.Error()}
+	}
+	return &txTraceResult{TxHash: tx.Hash(), Result: res}
+}
+
 // standardTraceBlockToFile configures a new tracer which uses standard JSON output,
 // and traces either a full block or an individual transaction. The return value will
 // be one filename per transaction traced.
@@ -679,12 +769,14 @@ func (api *API) standardTraceBlockToFile(ctx context.Context, block *types.Block
 	var (
 		logConfig vm.LogConfig
 		txHash    common.Hash
+		compress  bool
 	)
 	if config != nil {
 		if config.LogConfig != nil {
 			logConfig = *config.LogConfig
 		}
 		txHash = config.TxHash
+		compress = config.Compress
 	}
 	logConfig.Debug = true
 
@@ -705,7 +797,7 @@ func (api *API) standardTraceBlockToFile(ctx context.Context, block *types.Block
 			vmctx = blockchain.NewEVMContext(msg, block.Header(), newChainContext(ctx, api.backend), nil)
 
 			vmConf vm.Config
-			dump   *os.File
+			dump   *traceFile
 		)
 
 		// If the transaction needs tracing, swap out the configs
@@ -713,16 +805,16 @@ func (api *API) standardTraceBlockToFile(ctx context.Context, block *types.Block
 			// Generate a unique temporary file to dump it into
 			prefix := fmt.Sprintf("block_%#x-%d-%#x-", block.Hash().Bytes()[:4], i, tx.Hash().Bytes()[:4])
 
-			dump, err = ioutil.TempFile(os.TempDir(), prefix)
+			dump, err = createTraceFile(prefix, compress)
 			if err != nil {
-				return nil, err
+				return dumps, err
 			}
 			dumps = append(dumps, dump.Name())
 
 			// Swap out the noop logger to the standard tracer
 			vmConf = vm.Config{
 				Debug:                    true,
-				Tracer:                   vm.NewJSONLogger(&logConfig, bufio.NewWriter(dump)),
+				Tracer:                   vm.NewJSONLogger(&logConfig, dump),
 				EnablePreimageRecording:  true,
 				UseOpcodeComputationCost: true,
 			}
@@ -732,7 +824,9 @@ func (api *API) standardTraceBlockToFile(ctx context.Context, block *types.Block
 		_, _, kerr := blockchain.ApplyMessage(vmenv, msg)
 
 		if dump != nil {
-			dump.Close()
+			if err := dump.Close(); err != nil {
+				return dumps, err
+			}
 			logger.Info("Wrote standard trace", "file", dump.Name())
 		}
 		if kerr.ErrTxInvalid != nil {
@@ -749,6 +843,56 @@ func (api *API) standardTraceBlockToFile(ctx context.Context, block *types.Block
 	return dumps, nil
 }
 
+// traceFile is a buffered file, optionally gzip-compressed, where a standard trace is dumped.
+type traceFile struct {
+	*bufio.Writer
+	file *os.File
+	gz   *gzip.Writer
+}
+
+// createTraceFile creates a unique trace file in the standard trace directory.
+// Each line of the file is a JSON object, and the file name ends with ".jsonl" or ".jsonl.gz".
+func createTraceFile(prefix string, compress bool) (*traceFile, error) {
+	dir, pattern := standardTraceDir, prefix+"*.jsonl"
+	if dir == "" {
+		dir = os.TempDir()
+	}
+	if compress {
+		pattern += ".gz"
+	}
+	file, err := ioutil.TempFile(dir, pattern)
+	if err != nil {
+		return nil, err
+	}
+	f := &traceFile{file: file}
+	if compress {
+		f.gz = gzip.NewWriter(file)
+		f.Writer = bufio.NewWriter(f.gz)
+	} else {
+		f.Writer = bufio.NewWriter(file)
+	}
+	return f, nil
+}
+
+// Name returns the path of the trace file.
+func (f *traceFile) Name() string {
+	return f.file.Name()
+}
+
+// Close flushes the buffered trace to the disk and closes the file.
+func (f *traceFile) Close() error {
+	err := f.Flush()
+	if f.gz != nil {
+		if gzErr := f.gz.Close(); err == nil {
+			err = gzErr
+		}
+	}
+	if closeErr := f.file.Close(); err == nil {
+		err = closeErr
+	}
+	return err
+}
+
 // containsTx reports whether the transaction with a certain hash
 // is contained within the specified block.
 func containsTx(block *types.Block, hash common.Hash) bool {
@@ -788,6 +932,59 @@ func (api *API) TraceTransaction(ctx context.Context, hash common.Hash, config *
 	return api.traceTx(ctx, msg, vmctx, statedb, config)
 }
 
+// TraceCall lets you trace a given klay_call. It collects the structured logs
+// created during the execution of EVM if the given transaction was added on
+// top of the provided block and returns them as a JSON object.
+// You can provide -2 as a block number to trace on top of the pending block.
+func (api *API) TraceCall(ctx context.Context, args klaytnapi.CallArgs, blockNrOrHash rpc.BlockNumberOrHash, config *TraceCallConfig) (interface{}, error) {
+	// Try to retrieve the specified block
+	block, err := api.blockByNumberOrHash(ctx, blockNrOrHash)
+	if err != nil {
+		return nil, err
+	}
+	// try to recompute the state
+	reexec := defaultTraceReexec
+	if config != nil && config.Reexec != nil {
+		reexec = *config.Reexec
+	}
+	statedb, err := api.backend.StateAtBlock(ctx, block, reexec, nil, true, false)
+	if err != nil {
+		return nil, err
+	}
+	// Apply the customized state rules if required.
+	if config != nil {
+		if err := config.StateOverrides.Apply(statedb); err != nil {
+			return nil, err
+		}
+	}
+
+	// Execute the trace
+	data := args.Input
+	if data == nil {
+		data = args.Data
+	}
+	intrinsicGas, err := types.IntrinsicGas(data, nil, args.To == nil, api.backend.ChainConfig().Rules(block.Number()))
+	if err != nil {
+		return nil, err
+	}
+	baseFee := misc.HeaderBaseFee(block.Header())
+	var gasCap uint64
+	if rpcGasCap := api.backend.RPCGasCap(); rpcGasCap != nil {
+		gasCap = rpcGasCap.Uint64()
+	}
+	msg, err := args.ToMessage(gasCap, baseFee, intrinsicGas)
+	if err != nil {
+		return nil, err
+	}
+	vmctx := blockchain.NewEVMContext(msg, block.Header(), newChainContext(ctx, api.backend), nil)
+
+	var traceConfig *TraceConfig
+	if config != nil {
+		traceConfig = &config.TraceConfig
+	}
+	return api.traceTx(ctx, msg, vmctx, statedb, traceConfig)
+}
+
 // traceTx configures a new tracer according to the provided configuration, and
 // executes the given message in the provided environment. The return value will
 // be tracer dependent.
@@ -806,14 +1003,23 @@ func (api *API) traceTx(ctx context.Context, message blockchain.Message, vmctx v
 				return nil, err
 			}
 		}
+		custom := isCustomTracer(*config.Tracer)
+		if custom && customTracerTimeout > 0 && timeout > customTracerTimeout {
+			timeout = customTracerTimeout
+		}
 
 		if *config.Tracer == fastCallTracer {
 			tracer = vm.NewInternalTxTracer()
 		} else {
 			// Construct the JavaScript tracer to execute with
-			if tracer, err = New(*config.Tracer, api.unsafeTrace); err != nil {
+			jsTracer, err := New(*config.Tracer, api.unsafeTrace)
+			if err != nil {
 				return nil, err
 			}
+			if custom {
+				jsTracer.maxResultSize = customTracerMaxResultSize
+			}
+			tracer = jsTracer
 		}
 		// Handle timeouts and RPC cancellations
 		deadlineCtx, cancel := context.WithTimeout(ctx, timeout)
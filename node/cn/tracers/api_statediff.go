@@ -0,0 +1,134 @@
+// Copyright 2022 The klaytn Authors
+// This file is part of the klaytn library.
+//
+// The klaytn library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The klaytn library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the klaytn library. If not, see <http://www.gnu.org/licenses/>.
+
+package tracers
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	lru "github.com/hashicorp/golang-lru"
+	"github.com/klaytn/klaytn/blockchain"
+	"github.com/klaytn/klaytn/blockchain/types"
+	"github.com/klaytn/klaytn/blockchain/vm"
+	"github.com/klaytn/klaytn/common"
+	"github.com/klaytn/klaytn/networks/rpc"
+)
+
+// stateDiffCacheSize is the number of blocks whose state diffs are cached.
+const stateDiffCacheSize = 64
+
+// stateDiffCache caches the state diffs of the replayed blocks by the block hash.
+// The cached results are shared by the callers, so they must not be modified.
+var stateDiffCache, _ = lru.New(stateDiffCacheSize)
+
+// ReplayConfig holds extra parameters to ReplayBlockTransactions.
+type ReplayConfig struct {
+	Reexec  *uint64
+	NoCache bool // if true, the block is replayed even if its result is cached
+}
+
+// TxStateDiff is the state changes made by a transaction.
+type TxStateDiff struct {
+	TxHash    common.Hash                        `json:"txHash"`
+	StateDiff map[common.Address]*vm.AccountDiff `json:"stateDiff"`
+}
+
+// ReplayBlockTransactions replays the transactions of the given block, and returns the accounts
+// created or deleted and every balance, nonce, code and storage change made by each transaction.
+// The results are cached by the block hash unless config.NoCache is set.
+func (api *API) ReplayBlockTransactions(ctx context.Context, blockNrOrHash rpc.BlockNumberOrHash, config *ReplayConfig) ([]*TxStateDiff, error) {
+	var (
+		block *types.Block
+		err   error
+	)
+	if hash, ok := blockNrOrHash.Hash(); ok {
+		block, err = api.blockByHash(ctx, hash)
+	} else if number, ok := blockNrOrHash.Number(); ok {
+		block, err = api.blockByNumber(ctx, number)
+	} else {
+		return nil, errors.New("invalid arguments; neither block nor hash specified")
+	}
+	if err != nil {
+		return nil, err
+	}
+	if config == nil {
+		config = &ReplayConfig{}
+	}
+	if !config.NoCache {
+		if cached, ok := stateDiffCache.Get(block.Hash()); ok {
+			return cached.([]*TxStateDiff), nil
+		}
+	}
+	results, err := api.replayBlockStateDiffs(ctx, block, config)
+	if err != nil {
+		return nil, err
+	}
+	stateDiffCache.Add(block.Hash(), results)
+	return results, nil
+}
+
+// replayBlockStateDiffs executes the transactions of the block one by one on top of its parent state,
+// and compares the states before and after each transaction.
+func (api *API) replayBlockStateDiffs(ctx context.Context, block *types.Block, config *ReplayConfig) ([]*TxStateDiff, error) {
+	if block.NumberU64() == 0 {
+		return nil, errors.New("genesis is not traceable")
+	}
+	parent, err := api.blockByNumberAndHash(ctx, rpc.BlockNumber(block.NumberU64()-1), block.ParentHash())
+	if err != nil {
+		return nil, err
+	}
+	reexec := defaultTraceReexec
+	if config.Reexec != nil {
+		reexec = *config.Reexec
+	}
+	statedb, err := api.backend.StateAtBlock(ctx, parent, reexec, nil, true, false)
+	if err != nil {
+		return nil, err
+	}
+
+	var (
+		signer  = types.MakeSigner(api.backend.ChainConfig(), block.Number())
+		txs     = block.Transactions()
+		results = make([]*TxStateDiff, len(txs))
+	)
+	for i, tx := range txs {
+		if err := ctx.Err(); err != nil {
+			return nil, err
+		}
+		msg, err := tx.AsMessageWithAccountKeyPicker(signer, statedb, block.NumberU64())
+		if err != nil {
+			return nil, fmt.Errorf("replaying failed at tx %d: %v", i, err)
+		}
+		pre := statedb.Copy()
+		statedb.Prepare(tx.Hash(), block.Hash(), i)
+
+		vmctx := blockchain.NewEVMContext(msg, block.Header(), newChainContext(ctx, api.backend), nil)
+		tracer := vm.NewStateDiffTracer()
+		tracer.AddAccount(vmctx.Coinbase)
+		tracer.AddAccount(msg.ValidatedFeePayer())
+
+		vmenv := vm.NewEVM(vmctx, statedb, api.backend.ChainConfig(), &vm.Config{Debug: true, Tracer: tracer, UseOpcodeComputationCost: true})
+		if _, _, kerr := blockchain.ApplyMessage(vmenv, msg); kerr.ErrTxInvalid != nil {
+			return nil, fmt.Errorf("replaying failed at tx %d: %v", i, kerr.ErrTxInvalid)
+		}
+		statedb.Finalise(true, true)
+
+		results[i] = &TxStateDiff{TxHash: tx.Hash(), StateDiff: tracer.Diff(pre, statedb)}
+	}
+	return results, nil
+}
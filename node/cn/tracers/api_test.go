@@ -21,14 +21,21 @@ package tracers
 
 import (
 	"bytes"
+	"compress/gzip"
 	"context"
 	"crypto/ecdsa"
+	"encoding/json"
 	"errors"
 	"fmt"
+	"io"
+	"io/ioutil"
 	"math/big"
+	"os"
+	"path/filepath"
 	"reflect"
 	"sort"
 	"testing"
+	"time"
 
 	klaytnapi "github.com/klaytn/klaytn/api"
 	"github.com/klaytn/klaytn/blockchain"
@@ -36,6 +43,7 @@ import (
 	"github.com/klaytn/klaytn/blockchain/types"
 	"github.com/klaytn/klaytn/blockchain/vm"
 	"github.com/klaytn/klaytn/common"
+	"github.com/klaytn/klaytn/common/hexutil"
 	"github.com/klaytn/klaytn/consensus"
 	"github.com/klaytn/klaytn/consensus/gxhash"
 	"github.com/klaytn/klaytn/crypto"
@@ -179,136 +187,157 @@ func (b *testBackend) StateAtTransaction(ctx context.Context, block *types.Block
 	return nil, vm.Context{}, nil, fmt.Errorf("transaction index %d out of range for block %#x", txIndex, block.Hash())
 }
 
-// TODO-tracer: implement TraceCall
-//func TestTraceCall(t *testing.T) {
-//	t.Parallel()
-//
-//	// Initialize test accounts
-//	accounts := newAccounts(3)
-//	genesis := &blockchain.Genesis{Alloc: blockchain.GenesisAlloc{
-//		accounts[0].addr: {Balance: big.NewInt(params.KLAY)},
-//		accounts[1].addr: {Balance: big.NewInt(params.KLAY)},
-//		accounts[2].addr: {Balance: big.NewInt(params.KLAY)},
-//	}}
-//	genBlocks := 10
-//	signer := types.HomesteadSigner{}
-//	api := NewAPI(newTestBackend(t, genBlocks, genesis, func(i int, b *blockchain.BlockGen) {
-//		// Transfer from account[0] to account[1]
-//		//    value: 1000 peb
-//		//    fee:   0 peb
-//		tx, _ := types.SignTx(types.NewTransaction(uint64(i), accounts[1].addr, big.NewInt(1000), params.TxGas, big.NewInt(0), nil), signer, accounts[0].key)
-//		b.AddTx(tx)
-//	}))
-//
-//	var testSuite = []struct {
-//		blockNumber rpc.BlockNumber
-//		call        klaytnapi.CallArgs
-//		config      *TraceConfig
-//		expectErr   error
-//		expect      interface{}
-//	}{
-//		// Standard JSON trace upon the genesis, plain transfer.
-//		{
-//			blockNumber: rpc.BlockNumber(0),
-//			call: klaytnapi.CallArgs{
-//				From:  accounts[0].addr,
-//				To:    &accounts[1].addr,
-//				Value: (hexutil.Big)(*big.NewInt(1000)),
-//			},
-//			config:    nil,
-//			expectErr: nil,
-//			expect: &klaytnapi.ExecutionResult{
-//				Gas:         params.TxGas,
-//				Failed:      false,
-//				ReturnValue: "",
-//				StructLogs:  []klaytnapi.StructLogRes{},
-//			},
-//		},
-//		// Standard JSON trace upon the head, plain transfer.
-//		{
-//			blockNumber: rpc.BlockNumber(genBlocks),
-//			call: klaytnapi.CallArgs{
-//				From:  accounts[0].addr,
-//				To:    &accounts[1].addr,
-//				Value: (hexutil.Big)(*big.NewInt(1000)),
-//			},
-//			config:    nil,
-//			expectErr: nil,
-//			expect: &klaytnapi.ExecutionResult{
-//				Gas:         params.TxGas,
-//				Failed:      false,
-//				ReturnValue: "",
-//				StructLogs:  []klaytnapi.StructLogRes{},
-//			},
-//		},
-//		// Standard JSON trace upon the non-existent block, error expects
-//		{
-//			blockNumber: rpc.BlockNumber(genBlocks + 1),
-//			call: klaytnapi.CallArgs{
-//				From:  accounts[0].addr,
-//				To:    &accounts[1].addr,
-//				Value: (hexutil.Big)(*big.NewInt(1000)),
-//			},
-//			config:    nil,
-//			expectErr: fmt.Errorf("the block does not exist (block number: %d)", genBlocks+1),
-//			expect:    nil,
-//		},
-//		// Standard JSON trace upon the latest block
-//		{
-//			blockNumber: rpc.LatestBlockNumber,
-//			call: klaytnapi.CallArgs{
-//				From:  accounts[0].addr,
-//				To:    &accounts[1].addr,
-//				Value: (hexutil.Big)(*big.NewInt(1000)),
-//			},
-//			config:    nil,
-//			expectErr: nil,
-//			expect: &klaytnapi.ExecutionResult{
-//				Gas:         params.TxGas,
-//				Failed:      false,
-//				ReturnValue: "",
-//				StructLogs:  []klaytnapi.StructLogRes{},
-//			},
-//		},
-//		// Standard JSON trace upon the pending block
-//		{
-//			blockNumber: rpc.PendingBlockNumber,
-//			call: klaytnapi.CallArgs{
-//				From:  accounts[0].addr,
-//				To:    &accounts[1].addr,
-//				Value: (hexutil.Big)(*big.NewInt(1000)),
-//			},
-//			config:    nil,
-//			expectErr: nil,
-//			expect: &klaytnapi.ExecutionResult{
-//				Gas:         params.TxGas,
-//				Failed:      false,
-//				ReturnValue: "",
-//				StructLogs:  []klaytnapi.StructLogRes{},
-//			},
-//		},
-//	}
-//	for _, testspec := range testSuite {
-//		result, err := api.TraceCall(context.Background(), testspec.call, rpc.BlockNumberOrHash{BlockNumber: &testspec.blockNumber}, testspec.config)
-//		if testspec.expectErr != nil {
-//			if err == nil {
-//				t.Errorf("Expect error %v, get nothing", testspec.expectErr)
-//				continue
-//			}
-//			if !reflect.DeepEqual(err, testspec.expectErr) {
-//				t.Errorf("Error mismatch, want %v, get %v", testspec.expectErr, err)
-//			}
-//		} else {
-//			if err != nil {
-//				t.Errorf("Expect no error, get %v", err)
-//				continue
-//			}
-//			if !reflect.DeepEqual(result, testspec.expect) {
-//				t.Errorf("Result mismatch, want %v, get %v", testspec.expect, result)
-//			}
-//		}
-//	}
-//}
+func TestTraceCall(t *testing.T) {
+	t.Parallel()
+
+	// Initialize test accounts
+	accounts := newAccounts(3)
+	genesis := &blockchain.Genesis{Alloc: blockchain.GenesisAlloc{
+		accounts[0].addr: {Balance: big.NewInt(params.KLAY)},
+		accounts[1].addr: {Balance: big.NewInt(params.KLAY)},
+		accounts[2].addr: {Balance: big.NewInt(params.KLAY)},
+	}}
+	genBlocks := 10
+	signer := types.LatestSignerForChainID(params.TestChainConfig.ChainID)
+	api := NewAPI(newTestBackend(t, genBlocks, genesis, func(i int, b *blockchain.BlockGen) {
+		// Transfer from account[0] to account[1]
+		//    value: 1000 peb
+		//    fee:   0 peb
+		tx, _ := types.SignTx(types.NewTransaction(uint64(i), accounts[1].addr, big.NewInt(1000), params.TxGas, big.NewInt(0), nil), signer, accounts[0].key)
+		b.AddTx(tx)
+	}))
+
+	overriddenBalance := (*hexutil.Big)(new(big.Int).Mul(big.NewInt(10), big.NewInt(params.KLAY)))
+	testSuite := []struct {
+		blockNumber rpc.BlockNumber
+		call        klaytnapi.CallArgs
+		config      *TraceCallConfig
+		expectErr   error
+		expect      interface{}
+	}{
+		// Standard JSON trace upon the genesis, plain transfer.
+		{
+			blockNumber: rpc.BlockNumber(0),
+			call: klaytnapi.CallArgs{
+				From:  accounts[0].addr,
+				To:    &accounts[1].addr,
+				Value: (hexutil.Big)(*big.NewInt(1000)),
+			},
+			config:    nil,
+			expectErr: nil,
+			expect: &klaytnapi.ExecutionResult{
+				Gas:         params.TxGas,
+				Failed:      false,
+				ReturnValue: "",
+				StructLogs:  []klaytnapi.StructLogRes{},
+			},
+		},
+		// Standard JSON trace upon the head, plain transfer.
+		{
+			blockNumber: rpc.BlockNumber(genBlocks),
+			call: klaytnapi.CallArgs{
+				From:  accounts[0].addr,
+				To:    &accounts[1].addr,
+				Value: (hexutil.Big)(*big.NewInt(1000)),
+			},
+			config:    nil,
+			expectErr: nil,
+			expect: &klaytnapi.ExecutionResult{
+				Gas:         params.TxGas,
+				Failed:      false,
+				ReturnValue: "",
+				StructLogs:  []klaytnapi.StructLogRes{},
+			},
+		},
+		// Standard JSON trace upon the non-existent block, error expects
+		{
+			blockNumber: rpc.BlockNumber(genBlocks + 1),
+			call: klaytnapi.CallArgs{
+				From:  accounts[0].addr,
+				To:    &accounts[1].addr,
+				Value: (hexutil.Big)(*big.NewInt(1000)),
+			},
+			config:    nil,
+			expectErr: fmt.Errorf("the block does not exist (block number: %d)", genBlocks+1),
+			expect:    nil,
+		},
+		// Standard JSON trace upon the latest block
+		{
+			blockNumber: rpc.LatestBlockNumber,
+			call: klaytnapi.CallArgs{
+				From:  accounts[0].addr,
+				To:    &accounts[1].addr,
+				Value: (hexutil.Big)(*big.NewInt(1000)),
+			},
+			config:    nil,
+			expectErr: nil,
+			expect: &klaytnapi.ExecutionResult{
+				Gas:         params.TxGas,
+				Failed:      false,
+				ReturnValue: "",
+				StructLogs:  []klaytnapi.StructLogRes{},
+			},
+		},
+		// Standard JSON trace with the overridden balance of the sender
+		{
+			blockNumber: rpc.LatestBlockNumber,
+			call: klaytnapi.CallArgs{
+				From:  accounts[2].addr,
+				To:    &accounts[1].addr,
+				Value: (hexutil.Big)(*big.NewInt(2 * params.KLAY)),
+			},
+			config: &TraceCallConfig{
+				StateOverrides: &klaytnapi.EthStateOverride{
+					accounts[2].addr: klaytnapi.EthOverrideAccount{Balance: &overriddenBalance},
+				},
+			},
+			expectErr: nil,
+			expect: &klaytnapi.ExecutionResult{
+				Gas:         params.TxGas,
+				Failed:      false,
+				ReturnValue: "",
+				StructLogs:  []klaytnapi.StructLogRes{},
+			},
+		},
+		// Standard JSON trace upon the pending block
+		{
+			blockNumber: rpc.PendingBlockNumber,
+			call: klaytnapi.CallArgs{
+				From:  accounts[0].addr,
+				To:    &accounts[1].addr,
+				Value: (hexutil.Big)(*big.NewInt(1000)),
+			},
+			config:    nil,
+			expectErr: nil,
+			expect: &klaytnapi.ExecutionResult{
+				Gas:         params.TxGas,
+				Failed:      false,
+				ReturnValue: "",
+				StructLogs:  []klaytnapi.StructLogRes{},
+			},
+		},
+	}
+	for _, testspec := range testSuite {
+		result, err := api.TraceCall(context.Background(), testspec.call, rpc.BlockNumberOrHash{BlockNumber: &testspec.blockNumber}, testspec.config)
+		if testspec.expectErr != nil {
+			if err == nil {
+				t.Errorf("Expect error %v, get nothing", testspec.expectErr)
+				continue
+			}
+			if !reflect.DeepEqual(err, testspec.expectErr) {
+				t.Errorf("Error mismatch, want %v, get %v", testspec.expectErr, err)
+			}
+		} else {
+			if err != nil {
+				t.Errorf("Expect no error, get %v", err)
+				continue
+			}
+			if !reflect.DeepEqual(result, testspec.expect) {
+				t.Errorf("Result mismatch, want %v, get %v", testspec.expect, result)
+			}
+		}
+	}
+}
 
 func TestTraceTransaction(t *testing.T) {
 	t.Parallel()
@@ -343,6 +372,38 @@ func TestTraceTransaction(t *testing.T) {
 	}
 }
 
+func TestReplayBlockTransactions(t *testing.T) {
+	t.Parallel()
+
+	// Initialize test accounts
+	accounts := newAccounts(2)
+	genesis := &blockchain.Genesis{Alloc: blockchain.GenesisAlloc{
+		accounts[0].addr: {Balance: big.NewInt(params.KLAY)},
+	}}
+	target := common.Hash{}
+	signer := types.LatestSignerForChainID(params.TestChainConfig.ChainID)
+	api := NewAPI(newTestBackend(t, 1, genesis, func(i int, b *blockchain.BlockGen) {
+		// Transfer from account[0] to the new account[1]
+		tx, _ := types.SignTx(types.NewTransaction(uint64(i), accounts[1].addr, big.NewInt(1000), params.TxGas, big.NewInt(0), nil), signer, accounts[0].key)
+		b.AddTx(tx)
+		target = tx.Hash()
+	}))
+	results, err := api.ReplayBlockTransactions(context.Background(), rpc.NewBlockNumberOrHashWithNumber(1), nil)
+	if err != nil {
+		t.Fatalf("Failed to replay block %v", err)
+	}
+	if len(results) != 1 || results[0].TxHash != target {
+		t.Fatalf("Replay result is different: %v", results)
+	}
+	sender, recipient := results[0].StateDiff[accounts[0].addr], results[0].StateDiff[accounts[1].addr]
+	if sender == nil || uint64(*sender.NonceAfter) != 1 {
+		t.Errorf("Sender diff is different: %v", sender)
+	}
+	if recipient == nil || !recipient.Created || recipient.BalanceAfter.ToInt().Cmp(big.NewInt(1000)) != 0 {
+		t.Errorf("Recipient diff is different: %v", recipient)
+	}
+}
+
 func TestTraceBlock(t *testing.T) {
 	t.Parallel()
 
@@ -459,6 +520,137 @@ func TestTraceBlock(t *testing.T) {
 	}
 }
 
+func TestStandardTraceBlockToFile(t *testing.T) {
+	// Not parallel, as the trace directory is a package-level setting
+	dir, err := ioutil.TempDir("", "standard-trace")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+	SetStandardTraceDir(dir)
+	defer SetStandardTraceDir("")
+
+	// Initialize test accounts
+	accounts := newAccounts(2)
+	genesis := &blockchain.Genesis{Alloc: blockchain.GenesisAlloc{
+		accounts[0].addr: {Balance: big.NewInt(params.KLAY)},
+	}}
+	signer := types.LatestSignerForChainID(params.TestChainConfig.ChainID)
+	var target common.Hash
+	backend := newTestBackend(t, 1, genesis, func(i int, b *blockchain.BlockGen) {
+		// Calling a non-existing account pings the tracer without executing any code
+		for nonce := uint64(0); nonce < 2; nonce++ {
+			tx, _ := types.SignTx(types.NewTransaction(nonce, accounts[1].addr, big.NewInt(0), params.TxGas, big.NewInt(0), nil), signer, accounts[0].key)
+			b.AddTx(tx)
+			target = tx.Hash()
+		}
+	})
+	block, _ := backend.BlockByNumber(context.Background(), 1)
+	api := NewAPI(backend)
+
+	var testSuite = []struct {
+		config  *StdTraceConfig
+		expectN int
+	}{
+		{config: nil, expectN: 2},
+		{config: &StdTraceConfig{TxHash: target}, expectN: 1},
+		{config: &StdTraceConfig{Compress: true}, expectN: 2},
+	}
+	for i, testspec := range testSuite {
+		files, err := api.StandardTraceBlockToFile(context.Background(), block.Hash(), testspec.config)
+		if err != nil {
+			t.Fatalf("test %d: failed to trace the block: %v", i, err)
+		}
+		if len(files) != testspec.expectN {
+			t.Fatalf("test %d: trace file count mismatch: have %d, want %d", i, len(files), testspec.expectN)
+		}
+		for _, file := range files {
+			if filepath.Dir(file) != dir {
+				t.Errorf("test %d: trace file %s is not in %s", i, file, dir)
+			}
+			f, err := os.Open(file)
+			if err != nil {
+				t.Fatal(err)
+			}
+			var r io.Reader = f
+			if testspec.config != nil && testspec.config.Compress {
+				if r, err = gzip.NewReader(f); err != nil {
+					t.Fatalf("test %d: invalid gzip file %s: %v", i, file, err)
+				}
+			}
+			content, err := ioutil.ReadAll(r)
+			f.Close()
+			if err != nil {
+				t.Fatal(err)
+			}
+			var end struct {
+				Output  string `json:"output"`
+				GasUsed string `json:"gasUsed"`
+			}
+			if err := json.Unmarshal(content, &end); err != nil {
+				t.Errorf("test %d: invalid trace %q: %v", i, content, err)
+			}
+		}
+	}
+}
+
+func TestTraceBlockTransactions(t *testing.T) {
+	t.Parallel()
+
+	// Initialize test accounts
+	accounts := newAccounts(2)
+	genesis := &blockchain.Genesis{Alloc: blockchain.GenesisAlloc{
+		accounts[0].addr: {Balance: big.NewInt(params.KLAY)},
+	}}
+	signer := types.LatestSignerForChainID(params.TestChainConfig.ChainID)
+	targets := make(map[common.Hash]bool)
+	api := NewAPI(newTestBackend(t, 1, genesis, func(i int, b *blockchain.BlockGen) {
+		for nonce := uint64(0); nonce < 3; nonce++ {
+			tx, _ := types.SignTx(types.NewTransaction(nonce, accounts[1].addr, big.NewInt(1000), params.TxGas, big.NewInt(0), nil), signer, accounts[0].key)
+			b.AddTx(tx)
+			targets[tx.Hash()] = true
+		}
+	}))
+
+	server := rpc.NewServer()
+	defer server.Stop()
+	if err := server.RegisterName("debug", api); err != nil {
+		t.Fatal(err)
+	}
+	client := rpc.DialInProc(server)
+	defer client.Close()
+
+	type streamedResult struct {
+		TxHash common.Hash                `json:"txHash"`
+		Result *klaytnapi.ExecutionResult `json:"result"`
+		Error  string                     `json:"error"`
+	}
+	ch := make(chan *streamedResult)
+	sub, err := client.Subscribe(context.Background(), "debug", ch, "traceBlockTransactions", "0x1", nil)
+	if err != nil {
+		t.Fatalf("Failed to subscribe: %v", err)
+	}
+	defer sub.Unsubscribe()
+
+	// The result of each transaction is streamed.
+	for i := 0; i < 3; i++ {
+		select {
+		case res := <-ch:
+			if !targets[res.TxHash] {
+				t.Fatalf("Unexpected transaction %v", res.TxHash)
+			}
+			delete(targets, res.TxHash)
+			if res.Error != "" || res.Result == nil || res.Result.Gas != params.TxGas || res.Result.Failed {
+				t.Errorf("Transaction tracing result is different: %v", res)
+			}
+		case err := <-sub.Err():
+			t.Fatalf("Subscription failed: %v", err)
+		case <-time.After(5 * time.Second):
+			t.Fatal("Timed out waiting for the trace results")
+		}
+	}
+}
+
 type Account struct {
 	key  *ecdsa.PrivateKey
 	addr common.Address
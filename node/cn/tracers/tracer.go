@@ -315,6 +315,8 @@ type Tracer struct {
 
 	interrupt uint32 // Atomic flag to signal execution interruption
 	reason    error  // Textual reason for the interruption
+
+	maxResultSize int // Maximum size of the result in bytes, 0 if not limited
 }
 
 // New instantiates a new tracer instance. code specifies either a predefined
@@ -668,6 +670,8 @@ func (jst *Tracer) GetResult() (json.RawMessage, error) {
 	result, err := jst.call(false, "result", "ctx", "db")
 	if err != nil {
 		jst.err = wrapError("result", err)
+	} else if jst.maxResultSize > 0 && len(result) > jst.maxResultSize {
+		result, jst.err = nil, fmt.Errorf("tracer result size %d exceeds the limit %d", len(result), jst.maxResultSize)
 	}
 	// Clean up the JavaScript environment
 	jst.vm.DestroyHeap()
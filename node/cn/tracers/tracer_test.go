@@ -131,6 +131,20 @@ func TestTracing(t *testing.T) {
 	}
 }
 
+// TestTracingMaxResultSize tests if it returns an error when the result is larger than the limit.
+func TestTracingMaxResultSize(t *testing.T) {
+	tracer, err := New("{step: function() {}, fault: function() {}, result: function() { return 'abcdefgh'; }}", true)
+	if err != nil {
+		t.Fatal(err)
+	}
+	tracer.maxResultSize = 8
+
+	_, err = runTrace(tracer)
+	if err == nil || !strings.Contains(err.Error(), "exceeds the limit 8") {
+		t.Errorf("Expected a result size error, got %v", err)
+	}
+}
+
 func TestUnsafeTracingDisabled(t *testing.T) {
 	_, err := New("{count: 0, step: function() { this.count += 1; }, fault: function() {}, result: function() { return this.count; }}", false)
 	if err == nil || err.Error() != "Only predefined tracers are supported" {
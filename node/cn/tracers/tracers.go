@@ -23,6 +23,7 @@ package tracers
 
 import (
 	"strings"
+	"time"
 	"unicode"
 
 	"github.com/klaytn/klaytn/node/cn/tracers/internal/tracers"
@@ -31,6 +32,19 @@ import (
 // all contains all the built in JavaScript tracers by name.
 var all = make(map[string]string)
 
+// Limits of the custom Javascript tracers given by users. They are not applied to the built-in tracers.
+var (
+	customTracerTimeout       time.Duration // maximum timeout of a trace, 0 if not limited
+	customTracerMaxResultSize int           // maximum size of a trace result in bytes, 0 if not limited
+)
+
+// SetCustomTracerLimits limits the timeout and the result size of the custom Javascript tracers.
+// It should be called before the tracing APIs are served.
+func SetCustomTracerLimits(timeout time.Duration, maxResultSize int) {
+	customTracerTimeout = timeout
+	customTracerMaxResultSize = maxResultSize
+}
+
 // camel converts a snake cased input string into a camel cased output.
 func camel(str string) string {
 	pieces := strings.Split(str, "_")
@@ -48,6 +62,12 @@ func init() {
 	}
 }
 
+// isCustomTracer returns true if the given code is not the name of a built-in tracer.
+func isCustomTracer(code string) bool {
+	_, ok := all[code]
+	return !ok && code != fastCallTracer
+}
+
 // tracer retrieves a specific JavaScript tracer by name.
 func tracer(name string) (string, bool) {
 	if tracer, ok := all[name]; ok {
@@ -22,6 +22,8 @@ package node
 
 import (
 	"crypto/ecdsa"
+	"crypto/rand"
+	"errors"
 	"fmt"
 	"io/ioutil"
 	"os"
@@ -31,7 +33,9 @@ import (
 
 	"github.com/klaytn/klaytn/accounts"
 	"github.com/klaytn/klaytn/accounts/keystore"
+	"github.com/klaytn/klaytn/accounts/watchonly"
 	"github.com/klaytn/klaytn/common"
+	"github.com/klaytn/klaytn/common/hexutil"
 	"github.com/klaytn/klaytn/crypto"
 	"github.com/klaytn/klaytn/log"
 	"github.com/klaytn/klaytn/networks/p2p"
@@ -46,6 +50,7 @@ const (
 	datadirStaticNodes     = "static-nodes.json"  // Path within the datadir to the static node list
 	datadirTrustedNodes    = "trusted-nodes.json" // Path within the datadir to the trusted node list
 	datadirNodeDatabase    = "nodes"              // Path within the datadir to store the node infos
+	datadirJWTSecret       = "jwtsecret"          // Path within the datadir to the secret of the authenticated RPC
 )
 
 // Config represents a small collection of configuration values to fine tune the
@@ -90,6 +95,10 @@ type Config struct {
 	// scrypt KDF at the expense of security.
 	UseLightweightKDF bool `toml:",omitempty"`
 
+	// WatchOnlyAccounts are the addresses listed as accounts without private keys.
+	// They cannot be used for signing.
+	WatchOnlyAccounts []common.Address `toml:",omitempty"`
+
 	// IPCPath is the requested location to place the IPC endpoint. If the path is
 	// a simple file name, it is placed inside the data directory (or on the root
 	// pipe path on Windows), whereas if it's a resolvable path name (absolute or
@@ -166,6 +175,50 @@ type Config struct {
 	// ephemeral nodes).
 	GRPCPort int `toml:",omitempty"`
 
+	// GraphQLHost is the host interface on which to start the GraphQL server. If this
+	// field is empty, no GraphQL API endpoint will be started.
+	GraphQLHost string `toml:",omitempty"`
+
+	// GraphQLPort is the TCP port number on which to start the GraphQL server. The
+	// default zero value is valid and will pick a port number randomly (useful
+	// for ephemeral nodes).
+	GraphQLPort int `toml:",omitempty"`
+
+	// GraphQLCors is the Cross-Origin Resource Sharing header to send to requesting
+	// clients. Please be aware that CORS is a browser enforced security, it's fully
+	// useless for custom HTTP clients.
+	GraphQLCors []string `toml:",omitempty"`
+
+	// GraphQLVirtualHosts is the list of virtual hostnames which are allowed on incoming requests.
+	// This is by default {'localhost'}. Using this prevents attacks like
+	// DNS rebinding, which bypasses SOP by simply masquerading as being within the same
+	// origin. These attacks do not utilize CORS, since they are not cross-domain.
+	// By explicitly checking the Host-header, the server will not allow requests
+	// made against the server with a malicious host domain.
+	// Requests using ip address directly are not affected
+	GraphQLVirtualHosts []string `toml:",omitempty"`
+
+	// AuthHost is the host interface on which to start the authenticated HTTP and
+	// websocket RPC server. If this field is empty, no authenticated endpoint will be started.
+	AuthHost string `toml:",omitempty"`
+
+	// AuthPort is the TCP port number on which to start the authenticated RPC server.
+	AuthPort int `toml:",omitempty"`
+
+	// AuthVirtualHosts is the list of virtual hostnames which are allowed on incoming
+	// requests to the authenticated RPC server.
+	AuthVirtualHosts []string `toml:",omitempty"`
+
+	// AuthModules is a list of API modules to expose only via the authenticated RPC
+	// interface. If the authenticated endpoint is enabled, these modules are removed
+	// from the HTTP, websocket and gRPC interfaces.
+	AuthModules []string `toml:",omitempty"`
+
+	// JWTSecret is the path to the hex encoded secret shared with the clients of the
+	// authenticated RPC server. A random secret is generated at "jwtsecret" in the
+	// instance directory if the path is empty and the file does not exist.
+	JWTSecret string `toml:",omitempty"`
+
 	// Ntp server:port to check the synchronization when booting the node
 	NtpRemoteServer string `toml:",omitempty"`
 
@@ -265,6 +318,24 @@ func DefaultGRPCEndpoint() string {
 	return config.GRPCEndpoint()
 }
 
+// GraphQLEndpoint resolves a GraphQL endpoint based on the configured host interface
+// and port parameters.
+func (c *Config) GraphQLEndpoint() string {
+	if c.GraphQLHost == "" {
+		return ""
+	}
+	return fmt.Sprintf("%s:%d", c.GraphQLHost, c.GraphQLPort)
+}
+
+// AuthEndpoint resolves an authenticated RPC endpoint based on the configured host
+// interface and port parameters.
+func (c *Config) AuthEndpoint() string {
+	if c.AuthHost == "" {
+		return ""
+	}
+	return fmt.Sprintf("%s:%d", c.AuthHost, c.AuthPort)
+}
+
 // NodeName returns the devp2p node identifier.
 func (c *Config) NodeName() string {
 	name := c.name()
@@ -370,6 +441,44 @@ func (c *Config) NodeKey() *ecdsa.PrivateKey {
 	return key
 }
 
+// AuthSecret retrieves the secret of the authenticated RPC endpoint from the configured
+// file, falling back to the one found in the instance directory. If no secret can be
+// found in the instance directory, a new one is generated.
+func (c *Config) AuthSecret() ([]byte, error) {
+	path := c.JWTSecret
+	if path == "" {
+		path = c.ResolvePath(datadirJWTSecret)
+	}
+	if path == "" {
+		return nil, errors.New("no data directory to store the JWT secret")
+	}
+	if data, err := ioutil.ReadFile(path); err == nil {
+		secret, err := hexutil.Decode("0x" + strings.TrimPrefix(strings.TrimSpace(string(data)), "0x"))
+		if err != nil {
+			return nil, fmt.Errorf("invalid JWT secret %s: %v", path, err)
+		}
+		if len(secret) != rpc.JWTSecretLength {
+			return nil, fmt.Errorf("invalid JWT secret %s: length %d, want %d", path, len(secret), rpc.JWTSecretLength)
+		}
+		return secret, nil
+	} else if c.JWTSecret != "" {
+		return nil, err
+	}
+	// No persistent secret found, generate and store a new one.
+	secret := make([]byte, rpc.JWTSecretLength)
+	if _, err := rand.Read(secret); err != nil {
+		return nil, err
+	}
+	if err := os.MkdirAll(filepath.Dir(path), 0o700); err != nil {
+		return nil, err
+	}
+	if err := ioutil.WriteFile(path, []byte(hexutil.Encode(secret)), 0o600); err != nil {
+		return nil, err
+	}
+	logger.Info("Generated JWT secret", "path", path)
+	return secret, nil
+}
+
 // StaticNodes returns a list of node enode URLs configured as static nodes.
 func (c *Config) StaticNodes() []*discover.Node {
 	return c.parsePersistentNodes(c.ResolvePath(datadirStaticNodes))
@@ -458,6 +567,7 @@ func makeAccountManager(conf *Config) (*accounts.Manager, string, error) {
 	// Assemble the account manager and supported backends
 	backends := []accounts.Backend{
 		keystore.NewKeyStore(keydir, scryptN, scryptP),
+		watchonly.NewBackend(conf.WatchOnlyAccounts...),
 	}
 	return accounts.NewManager(backends...), ephemeral, nil
 }
@@ -43,6 +43,10 @@ const (
 	DefaultWSPort                 = 8552        // Default TCP port for the websocket RPC server
 	DefaultGRPCHost               = "localhost" // Default host interface for the gRPC server
 	DefaultGRPCPort               = 8553        // Default TCP port for the gRPC server
+	DefaultGraphQLHost            = "localhost" // Default host interface for the GraphQL server
+	DefaultGraphQLPort            = 8554        // Default TCP port for the GraphQL server
+	DefaultAuthHost               = "localhost" // Default host interface for the authenticated RPC server
+	DefaultAuthPort               = 8555        // Default TCP port for the authenticated RPC server
 	DefaultP2PPort                = 32323
 	DefaultP2PSubPort             = 32324
 	DefaultMaxPhysicalConnections = 10 // Default the max number of node's physical connections
@@ -50,15 +54,20 @@ const (
 
 // DefaultConfig contains reasonable default settings.
 var DefaultConfig = Config{
-	DBType:           DefaultDBType(),
-	DataDir:          DefaultDataDir(),
-	HTTPPort:         DefaultHTTPPort,
-	HTTPModules:      []string{"net", "web3"},
-	HTTPVirtualHosts: []string{"localhost"},
-	HTTPTimeouts:     rpc.DefaultHTTPTimeouts,
-	WSPort:           DefaultWSPort,
-	WSModules:        []string{"net", "web3"},
-	GRPCPort:         DefaultGRPCPort,
+	DBType:              DefaultDBType(),
+	DataDir:             DefaultDataDir(),
+	HTTPPort:            DefaultHTTPPort,
+	HTTPModules:         []string{"net", "web3"},
+	HTTPVirtualHosts:    []string{"localhost"},
+	HTTPTimeouts:        rpc.DefaultHTTPTimeouts,
+	WSPort:              DefaultWSPort,
+	WSModules:           []string{"net", "web3"},
+	GRPCPort:            DefaultGRPCPort,
+	GraphQLPort:         DefaultGraphQLPort,
+	GraphQLVirtualHosts: []string{"localhost"},
+	AuthPort:            DefaultAuthPort,
+	AuthVirtualHosts:    []string{"localhost"},
+	AuthModules:         []string{"admin", "debug", "personal"},
 	P2P: p2p.Config{
 		ListenAddr:             fmt.Sprintf(":%d", DefaultP2PPort),
 		MaxPhysicalConnections: DefaultMaxPhysicalConnections,
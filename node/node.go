@@ -87,6 +87,10 @@ type Node struct {
 	grpcListener *grpc.Listener // gRPC listener socket to server API requests
 	grpcHandler  *rpc.Server    // gRPC request handler to process the API requests
 
+	authEndpoint string       // Authenticated RPC endpoint (interface + port) to listen at (empty = disabled)
+	authListener net.Listener // Authenticated RPC listener socket to server API requests
+	authHandler  *rpc.Server  // Authenticated RPC request handler to process the API requests
+
 	stop chan struct{} // Channel to wait for termination notifications
 	lock sync.RWMutex
 
@@ -140,6 +144,7 @@ func New(conf *Config) (*Node, error) {
 		httpEndpoint:      conf.HTTPEndpoint(),
 		wsEndpoint:        conf.WSEndpoint(),
 		grpcEndpoint:      conf.GRPCEndpoint(),
+		authEndpoint:      conf.AuthEndpoint(),
 		eventmux:          new(event.TypeMux),
 		logger:            conf.Logger,
 	}, nil
@@ -345,25 +350,30 @@ func (n *Node) startRPC(services map[reflect.Type]Service) error {
 		n.stopInProc()
 		return err
 	}
+	// The modules served by the authenticated endpoint are not exposed to the others
+	rpcAPIs := apis
+	if n.authEndpoint != "" {
+		rpcAPIs = excludeModules(apis, n.config.AuthModules)
+	}
 	if n.config.IsFastHTTP() {
-		if err := n.startFastHTTP(n.httpEndpoint, apis, n.config.HTTPModules, n.config.HTTPCors, n.config.HTTPVirtualHosts, n.config.HTTPTimeouts); err != nil {
+		if err := n.startFastHTTP(n.httpEndpoint, rpcAPIs, n.config.HTTPModules, n.config.HTTPCors, n.config.HTTPVirtualHosts, n.config.HTTPTimeouts); err != nil {
 			n.stopIPC()
 			n.stopInProc()
 			return err
 		}
-		if err := n.startFastWS(n.wsEndpoint, apis, n.config.WSModules, n.config.WSOrigins, n.config.WSExposeAll); err != nil {
+		if err := n.startFastWS(n.wsEndpoint, rpcAPIs, n.config.WSModules, n.config.WSOrigins, n.config.WSExposeAll); err != nil {
 			n.stopHTTP()
 			n.stopIPC()
 			n.stopInProc()
 			return err
 		}
 	} else {
-		if err := n.startHTTP(n.httpEndpoint, apis, n.config.HTTPModules, n.config.HTTPCors, n.config.HTTPVirtualHosts, n.config.HTTPTimeouts); err != nil {
+		if err := n.startHTTP(n.httpEndpoint, rpcAPIs, n.config.HTTPModules, n.config.HTTPCors, n.config.HTTPVirtualHosts, n.config.HTTPTimeouts); err != nil {
 			n.stopIPC()
 			n.stopInProc()
 			return err
 		}
-		if err := n.startWS(n.wsEndpoint, apis, n.config.WSModules, n.config.WSOrigins, n.config.WSExposeAll); err != nil {
+		if err := n.startWS(n.wsEndpoint, rpcAPIs, n.config.WSModules, n.config.WSOrigins, n.config.WSExposeAll); err != nil {
 			n.stopHTTP()
 			n.stopIPC()
 			n.stopInProc()
@@ -371,7 +381,15 @@ func (n *Node) startRPC(services map[reflect.Type]Service) error {
 		}
 	}
 	// start gRPC server
-	if err := n.startgRPC(apis); err != nil {
+	if err := n.startgRPC(rpcAPIs, services); err != nil {
+		n.stopHTTP()
+		n.stopIPC()
+		n.stopInProc()
+		return err
+	}
+	if err := n.startAuth(n.authEndpoint, apis, n.config.AuthModules, n.config.AuthVirtualHosts, n.config.HTTPTimeouts); err != nil {
+		n.stopgRPC()
+		n.stopWS()
 		n.stopHTTP()
 		n.stopIPC()
 		n.stopInProc()
@@ -388,7 +406,7 @@ func (n *Node) startInProc(apis []rpc.API) error {
 	// Register all the APIs exposed by the services
 	handler := rpc.NewServer()
 	for _, api := range apis {
-		if err := handler.RegisterName(api.Namespace, api.Service); err != nil {
+		if err := handler.RegisterAPI(api); err != nil {
 			return err
 		}
 		n.logger.Debug("InProc registered", "service", api.Service, "namespace", api.Namespace)
@@ -435,7 +453,7 @@ func (n *Node) stopIPC() {
 }
 
 // startgRPC initializes and starts the gRPC endpoint.
-func (n *Node) startgRPC(apis []rpc.API) error {
+func (n *Node) startgRPC(apis []rpc.API, services map[reflect.Type]Service) error {
 	if n.grpcEndpoint == "" {
 		return nil
 	}
@@ -443,7 +461,7 @@ func (n *Node) startgRPC(apis []rpc.API) error {
 	handler := rpc.NewServer()
 	for _, api := range apis {
 		if api.Public {
-			if err := handler.RegisterName(api.Namespace, api.Service); err != nil {
+			if err := handler.RegisterAPI(api); err != nil {
 				return err
 			}
 			n.logger.Debug("gRPC registered", "namespace", api.Namespace)
@@ -454,6 +472,13 @@ func (n *Node) startgRPC(apis []rpc.API) error {
 	n.grpcHandler = handler
 	n.grpcListener = listener
 	listener.SetRPCServer(handler)
+	for _, service := range services {
+		if streamService, ok := service.(grpc.StreamService); ok {
+			listener.SetStreamBackend(streamService.StreamBackend())
+			n.logger.Debug("gRPC block stream registered", "traces", grpc.StreamTraces)
+			break
+		}
+	}
 
 	go listener.Start()
 	n.logger.Info("gRPC endpoint opened", "url", n.grpcEndpoint)
@@ -564,6 +589,56 @@ func (n *Node) stopWS() {
 	}
 }
 
+// startAuth initializes and starts the authenticated HTTP and websocket RPC endpoint.
+func (n *Node) startAuth(endpoint string, apis []rpc.API, modules []string, vhosts []string, timeouts rpc.HTTPTimeouts) error {
+	// Short circuit if the authenticated endpoint isn't being exposed
+	if endpoint == "" {
+		return nil
+	}
+	secret, err := n.config.AuthSecret()
+	if err != nil {
+		return err
+	}
+	listener, handler, err := rpc.StartAuthEndpoint(endpoint, apis, modules, vhosts, timeouts, secret)
+	if err != nil {
+		return err
+	}
+	n.logger.Info("Authenticated RPC endpoint opened", "url", fmt.Sprintf("http://%s", endpoint), "modules", strings.Join(modules, ","), "vhosts", strings.Join(vhosts, ","))
+	n.authListener = listener
+	n.authHandler = handler
+
+	return nil
+}
+
+// stopAuth terminates the authenticated RPC endpoint.
+func (n *Node) stopAuth() {
+	if n.authListener != nil {
+		n.authListener.Close()
+		n.authListener = nil
+
+		n.logger.Info("Authenticated RPC endpoint closed", "url", fmt.Sprintf("http://%s", n.authEndpoint))
+	}
+	if n.authHandler != nil {
+		n.authHandler.Stop()
+		n.authHandler = nil
+	}
+}
+
+// excludeModules returns the APIs which do not belong to the given modules.
+func excludeModules(apis []rpc.API, modules []string) []rpc.API {
+	excluded := make(map[string]bool)
+	for _, module := range modules {
+		excluded[module] = true
+	}
+	filtered := make([]rpc.API, 0, len(apis))
+	for _, api := range apis {
+		if !excluded[api.Namespace] {
+			filtered = append(filtered, api)
+		}
+	}
+	return filtered
+}
+
 func (n *Node) stopgRPC() {
 	if n.grpcListener != nil {
 		n.grpcListener.Stop()
@@ -590,6 +665,7 @@ func (n *Node) Stop() error {
 	}
 
 	// Terminate the API, services and the p2p server.
+	n.stopAuth()
 	n.stopWS()
 	n.stopHTTP()
 	n.stopIPC()
@@ -776,6 +852,10 @@ func (n *Node) apis() []rpc.API {
 			Namespace: "admin",
 			Version:   "1.0",
 			Service:   NewPrivateAdminAPI(n),
+			Deprecated: map[string]string{
+				"startRPC": "admin_startHTTP",
+				"stopRPC":  "admin_stopHTTP",
+			},
 		}, {
 			Namespace: "admin",
 			Version:   "1.0",
@@ -791,6 +871,10 @@ func (n *Node) apis() []rpc.API {
 			Version:   "1.0",
 			Service:   NewPublicKlayAPI(n),
 			Public:    true,
+			Deprecated: map[string]string{
+				"clientVersion": "klay_clientVersion",
+				"sha3":          "klay_sha3",
+			},
 		}, {
 			Namespace: "klay",
 			Version:   "1.0",
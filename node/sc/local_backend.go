@@ -133,7 +133,7 @@ func (b *LocalBackend) callContract(ctx context.Context, call klaytn.CallMsg, bl
 	}
 
 	// Create new call message
-	msg := types.NewMessage(call.From, call.To, 0, call.Value, gas, gasPrice, call.Data, false, intrinsicGas)
+	msg := types.NewMessage(call.From, call.To, 0, call.Value, gas, gasPrice, call.Data, false, intrinsicGas, nil)
 
 	// Setup context so it may be cancelled the call has completed
 	// or, in case of unmetered gas, setup a context with a timeout.
@@ -391,6 +391,10 @@ func (fb *filterLocalBackend) SubscribeNewTxsEvent(ch chan<- blockchain.NewTxsEv
 	return fb.subbridge.txPool.SubscribeNewTxsEvent(ch)
 }
 
+func (fb *filterLocalBackend) SubscribeDroppedTxsEvent(ch chan<- blockchain.DroppedTxsEvent) event.Subscription {
+	return fb.subbridge.txPool.SubscribeDroppedTxsEvent(ch)
+}
+
 func (fb *filterLocalBackend) SubscribeChainEvent(ch chan<- blockchain.ChainEvent) event.Subscription {
 	return fb.subbridge.blockchain.SubscribeChainEvent(ch)
 }
@@ -399,6 +403,10 @@ func (fb *filterLocalBackend) SubscribeRemovedLogsEvent(ch chan<- blockchain.Rem
 	return fb.subbridge.blockchain.SubscribeRemovedLogsEvent(ch)
 }
 
+func (fb *filterLocalBackend) SubscribeReorgEvent(ch chan<- blockchain.ReorgEvent) event.Subscription {
+	return fb.subbridge.blockchain.SubscribeReorgEvent(ch)
+}
+
 func (fb *filterLocalBackend) SubscribeLogsEvent(ch chan<- []*types.Log) event.Subscription {
 	return fb.subbridge.blockchain.SubscribeLogsEvent(ch)
 }
@@ -171,4 +171,7 @@ const (
 
 	// computation costs for opcode added at koreCompatible Protocol Upgrade
 	RandomComputationCost = 1498
+
+	// computation costs for opcode added at shanghaiCompatible Protocol Upgrade
+	Push0ComputationCost = 80
 )
@@ -176,6 +176,11 @@ type ChainConfig struct {
 	EthTxTypeCompatibleBlock *big.Int `json:"ethTxTypeCompatibleBlock,omitempty"` // EthTxTypeCompatibleBlock switch block (nil = no fork, 0 = already on ethTxType)
 	MagmaCompatibleBlock     *big.Int `json:"magmaCompatibleBlock,omitempty"`     // MagmaCompatible switch block (nil = no fork, 0 already on Magma)
 	KoreCompatibleBlock      *big.Int `json:"koreCompatibleBlock,omitempty"`      // KoreCompatible switch block (nil = no fork, 0 already on Kore)
+	ShanghaiCompatibleBlock  *big.Int `json:"shanghaiCompatibleBlock,omitempty"`  // ShanghaiCompatible switch block (nil = no fork, 0 already on Shanghai)
+
+	// AccessListCompatibleBlock switch block (nil = no fork, 0 already on AccessListCompatible).
+	// From this block, the EIP-2930 access lists of transactions are warmed before the execution.
+	AccessListCompatibleBlock *big.Int `json:"accessListCompatibleBlock,omitempty"`
 
 	// Various consensus engines
 	Gxhash   *GxhashConfig   `json:"gxhash,omitempty"` // (deprecated) not supported engine
@@ -185,6 +190,13 @@ type ChainConfig struct {
 	UnitPrice     uint64            `json:"unitPrice"`
 	DeriveShaImpl int               `json:"deriveShaImpl"`
 	Governance    *GovernanceConfig `json:"governance"`
+
+	// CustomPrecompiles are the precompiled contracts registered by a service chain
+	// in addition to the built-in precompiled contracts.
+	CustomPrecompiles []*CustomPrecompileConfig `json:"customPrecompiles,omitempty"`
+
+	// OpcodeCostOverrides overrides the costs of opcodes on a private network.
+	OpcodeCostOverrides *OpcodeCostOverrides `json:"opcodeCostOverrides,omitempty"`
 }
 
 // GovernanceConfig stores governance information for a network
@@ -267,26 +279,30 @@ func (c *ChainConfig) String() string {
 		engine = "unknown"
 	}
 	if c.Istanbul != nil {
-		return fmt.Sprintf("{ChainID: %v IstanbulCompatibleBlock: %v LondonCompatibleBlock: %v EthTxTypeCompatibleBlock: %v MagmaCompatibleBlock: %v KoreCompatibleBlock: %v SubGroupSize: %d UnitPrice: %d DeriveShaImpl: %d Engine: %v}",
+		return fmt.Sprintf("{ChainID: %v IstanbulCompatibleBlock: %v LondonCompatibleBlock: %v EthTxTypeCompatibleBlock: %v MagmaCompatibleBlock: %v KoreCompatibleBlock: %v ShanghaiCompatibleBlock: %v AccessListCompatibleBlock: %v SubGroupSize: %d UnitPrice: %d DeriveShaImpl: %d Engine: %v}",
 			c.ChainID,
 			c.IstanbulCompatibleBlock,
 			c.LondonCompatibleBlock,
 			c.EthTxTypeCompatibleBlock,
 			c.MagmaCompatibleBlock,
 			c.KoreCompatibleBlock,
+			c.ShanghaiCompatibleBlock,
+			c.AccessListCompatibleBlock,
 			c.Istanbul.SubGroupSize,
 			c.UnitPrice,
 			c.DeriveShaImpl,
 			engine,
 		)
 	} else {
-		return fmt.Sprintf("{ChainID: %v IstanbulCompatibleBlock: %v LondonCompatibleBlock: %v EthTxTypeCompatibleBlock: %v MagmaCompatibleBlock: %v KoreCompatibleBlock: %v UnitPrice: %d DeriveShaImpl: %d Engine: %v }",
+		return fmt.Sprintf("{ChainID: %v IstanbulCompatibleBlock: %v LondonCompatibleBlock: %v EthTxTypeCompatibleBlock: %v MagmaCompatibleBlock: %v KoreCompatibleBlock: %v ShanghaiCompatibleBlock: %v AccessListCompatibleBlock: %v UnitPrice: %d DeriveShaImpl: %d Engine: %v }",
 			c.ChainID,
 			c.IstanbulCompatibleBlock,
 			c.LondonCompatibleBlock,
 			c.EthTxTypeCompatibleBlock,
 			c.MagmaCompatibleBlock,
 			c.KoreCompatibleBlock,
+			c.ShanghaiCompatibleBlock,
+			c.AccessListCompatibleBlock,
 			c.UnitPrice,
 			c.DeriveShaImpl,
 			engine,
@@ -326,6 +342,16 @@ func (c *ChainConfig) IsKoreForkEnabled(num *big.Int) bool {
 	return isForked(c.KoreCompatibleBlock, num)
 }
 
+// IsShanghaiForkEnabled returns whether num is either equal to the shanghai block or greater.
+func (c *ChainConfig) IsShanghaiForkEnabled(num *big.Int) bool {
+	return isForked(c.ShanghaiCompatibleBlock, num)
+}
+
+// IsAccessListForkEnabled returns whether num is either equal to the access list block or greater.
+func (c *ChainConfig) IsAccessListForkEnabled(num *big.Int) bool {
+	return isForked(c.AccessListCompatibleBlock, num)
+}
+
 // CheckCompatible checks whether scheduled fork transitions have been imported
 // with a mismatching chain configuration.
 func (c *ChainConfig) CheckCompatible(newcfg *ChainConfig, height uint64) *ConfigCompatError {
@@ -359,6 +385,8 @@ func (c *ChainConfig) CheckConfigForkOrder() error {
 		{name: "ethTxTypeBlock", block: c.EthTxTypeCompatibleBlock},
 		{name: "magmaBlock", block: c.MagmaCompatibleBlock},
 		{name: "koreBlock", block: c.KoreCompatibleBlock},
+		{name: "shanghaiBlock", block: c.ShanghaiCompatibleBlock},
+		{name: "accessListBlock", block: c.AccessListCompatibleBlock, optional: true},
 	} {
 		if lastFork.name != "" {
 			// Next one must be higher number
@@ -397,6 +425,18 @@ func (c *ChainConfig) checkCompatible(newcfg *ChainConfig, head *big.Int) *Confi
 	if isForkIncompatible(c.KoreCompatibleBlock, newcfg.KoreCompatibleBlock, head) {
 		return newCompatError("Kore Block", c.KoreCompatibleBlock, newcfg.KoreCompatibleBlock)
 	}
+	if isForkIncompatible(c.ShanghaiCompatibleBlock, newcfg.ShanghaiCompatibleBlock, head) {
+		return newCompatError("Shanghai Block", c.ShanghaiCompatibleBlock, newcfg.ShanghaiCompatibleBlock)
+	}
+	if isForkIncompatible(c.AccessListCompatibleBlock, newcfg.AccessListCompatibleBlock, head) {
+		return newCompatError("AccessList Block", c.AccessListCompatibleBlock, newcfg.AccessListCompatibleBlock)
+	}
+	if err := c.checkCustomPrecompilesCompatible(newcfg, head); err != nil {
+		return err
+	}
+	if err := c.checkOpcodeCostOverridesCompatible(newcfg, head); err != nil {
+		return err
+	}
 	return nil
 }
 
@@ -514,6 +554,9 @@ type Rules struct {
 	IsLondon   bool
 	IsMagma    bool
 	IsKore     bool
+	IsShanghai bool
+
+	IsAccessList bool
 }
 
 // Rules ensures c's ChainID is not nil.
@@ -528,6 +571,9 @@ func (c *ChainConfig) Rules(num *big.Int) Rules {
 		IsLondon:   c.IsLondonForkEnabled(num),
 		IsMagma:    c.IsMagmaForkEnabled(num),
 		IsKore:     c.IsKoreForkEnabled(num),
+		IsShanghai: c.IsShanghaiForkEnabled(num),
+
+		IsAccessList: c.IsAccessListForkEnabled(num),
 	}
 }
 
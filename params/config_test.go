@@ -20,6 +20,7 @@ import (
 	"math/big"
 	"testing"
 
+	"github.com/klaytn/klaytn/common"
 	"github.com/stretchr/testify/assert"
 )
 
@@ -70,6 +71,73 @@ func TestChainConfig_Copy(t *testing.T) {
 	assert.NotEqual(t, a.Governance.Reward.Ratio, b.Governance.Reward.Ratio)
 }
 
+func TestChainConfig_CheckCustomPrecompiles(t *testing.T) {
+	valid := &CustomPrecompileConfig{
+		Name:            "blsVerify",
+		Address:         FirstCustomPrecompileAddress,
+		ActivationBlock: big.NewInt(10),
+		BaseGas:         45000,
+	}
+	c := &ChainConfig{CustomPrecompiles: []*CustomPrecompileConfig{valid}}
+	assert.Nil(t, c.CheckCustomPrecompiles())
+	assert.Len(t, c.ActiveCustomPrecompiles(big.NewInt(9)), 0)
+	assert.Len(t, c.ActiveCustomPrecompiles(big.NewInt(10)), 1)
+
+	// the implementation is not registered
+	oldRegistered := IsCustomPrecompileRegistered
+	IsCustomPrecompileRegistered = func(name string) bool { return name == "blsVerify" }
+	assert.Nil(t, c.CheckCustomPrecompiles())
+	unregistered := *valid
+	unregistered.Name = "zkVerify"
+	c.CustomPrecompiles = []*CustomPrecompileConfig{&unregistered}
+	assert.NotNil(t, c.CheckCustomPrecompiles())
+	IsCustomPrecompileRegistered = oldRegistered
+
+	// the activation block is not set
+	noActivation := *valid
+	noActivation.ActivationBlock = nil
+	c.CustomPrecompiles = []*CustomPrecompileConfig{&noActivation}
+	assert.NotNil(t, c.CheckCustomPrecompiles())
+
+	// address in the reserved range
+	reserved := *valid
+	reserved.Address = common.HexToAddress("0x3ff")
+	c.CustomPrecompiles = []*CustomPrecompileConfig{&reserved}
+	assert.NotNil(t, c.CheckCustomPrecompiles())
+
+	// duplicated address
+	dup := *valid
+	dup.Name = "zkVerify"
+	c.CustomPrecompiles = []*CustomPrecompileConfig{valid, &dup}
+	assert.NotNil(t, c.CheckCustomPrecompiles())
+
+	// changing the activation of an already activated precompile is incompatible
+	moved := *valid
+	moved.ActivationBlock = big.NewInt(20)
+	stored := &ChainConfig{CustomPrecompiles: []*CustomPrecompileConfig{valid}}
+	newcfg := &ChainConfig{CustomPrecompiles: []*CustomPrecompileConfig{&moved}}
+	assert.NotNil(t, stored.CheckCompatible(newcfg, 15))
+	assert.Nil(t, stored.CheckCompatible(newcfg, 5))
+
+	// changing the schedule of an already activated precompile is incompatible
+	for _, change := range []func(p *CustomPrecompileConfig){
+		func(p *CustomPrecompileConfig) { p.Name = "other" },
+		func(p *CustomPrecompileConfig) { p.BaseGas++ },
+		func(p *CustomPrecompileConfig) { p.PerWordGas++ },
+		func(p *CustomPrecompileConfig) { p.BaseComputationCost++ },
+		func(p *CustomPrecompileConfig) { p.PerWordComputationCost++ },
+	} {
+		changed := *valid
+		change(&changed)
+		newcfg = &ChainConfig{CustomPrecompiles: []*CustomPrecompileConfig{&changed}}
+		err := stored.CheckCompatible(newcfg, 15)
+		if assert.NotNil(t, err) {
+			assert.Equal(t, uint64(9), err.RewindTo)
+		}
+		assert.Nil(t, stored.CheckCompatible(newcfg, 5))
+	}
+}
+
 func BenchmarkChainConfig_Copy(b *testing.B) {
 	a := CypressChainConfig
 	for i := 0; i < b.N; i++ {
@@ -0,0 +1,175 @@
+// Copyright 2022 The klaytn Authors
+// This file is part of the klaytn library.
+//
+// The klaytn library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The klaytn library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the klaytn library. If not, see <http://www.gnu.org/licenses/>.
+
+package params
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"math/big"
+
+	"github.com/klaytn/klaytn/common"
+)
+
+var (
+	// FirstCustomPrecompileAddress and LastCustomPrecompileAddress define the address range
+	// reserved for custom precompiled contracts. The range is placed right above
+	// the range of the built-in precompiled contracts (0x01 ~ 0x3FF).
+	FirstCustomPrecompileAddress = common.HexToAddress("0x0000000000000000000000000000000000000400")
+	LastCustomPrecompileAddress  = common.HexToAddress("0x0000000000000000000000000000000000000FFF")
+
+	errCustomPrecompileNoName          = errors.New("custom precompile name is empty")
+	errCustomPrecompileNoActivation    = errors.New("custom precompile activation block is not set")
+	errCustomPrecompileAddressOutRange = fmt.Errorf("custom precompile address must be in range [%v, %v]",
+		FirstCustomPrecompileAddress.Hex(), LastCustomPrecompileAddress.Hex())
+	errCustomPrecompileNotRegistered = errors.New("custom precompile is not registered in this binary")
+
+	// IsCustomPrecompileRegistered reports whether the implementation of the custom precompiled
+	// contract of the given name is registered. It is set by the vm package, which keeps the
+	// implementations, so the registration is not checked if the vm package is not linked.
+	IsCustomPrecompileRegistered func(name string) bool
+)
+
+// CustomPrecompileConfig describes a precompiled contract implemented in Go which is
+// registered by a service chain in addition to the built-in precompiled contracts.
+// The implementation is looked up by Name, and its gas and computation cost are
+// charged as Base + PerWord * (number of 32-byte words of the input).
+type CustomPrecompileConfig struct {
+	Name            string         `json:"name"`
+	Address         common.Address `json:"address"`
+	ActivationBlock *big.Int       `json:"activationBlock"` // Activation block (0 = activated from the genesis block)
+
+	BaseGas                uint64 `json:"baseGas"`
+	PerWordGas             uint64 `json:"perWordGas"`
+	BaseComputationCost    uint64 `json:"baseComputationCost"`
+	PerWordComputationCost uint64 `json:"perWordComputationCost"`
+}
+
+// IsActive returns whether the custom precompiled contract is activated at the given block number.
+func (p *CustomPrecompileConfig) IsActive(num *big.Int) bool {
+	return isForked(p.ActivationBlock, num)
+}
+
+func (p *CustomPrecompileConfig) validate() error {
+	if p.Name == "" {
+		return errCustomPrecompileNoName
+	}
+	if p.ActivationBlock == nil {
+		return errCustomPrecompileNoActivation
+	}
+	if bytes.Compare(p.Address.Bytes(), FirstCustomPrecompileAddress.Bytes()) < 0 ||
+		bytes.Compare(p.Address.Bytes(), LastCustomPrecompileAddress.Bytes()) > 0 {
+		return errCustomPrecompileAddressOutRange
+	}
+	// The computation cost of a precompiled contract must fit in the computation cost limit
+	// of a transaction even with a minimal input.
+	if p.BaseComputationCost > OpcodeComputationCostLimit {
+		return fmt.Errorf("custom precompile base computation cost %d exceeds the limit %d",
+			p.BaseComputationCost, OpcodeComputationCostLimit)
+	}
+	// A node without the implementation would process the calls to the contract differently
+	// from the other nodes once it is activated, so it must not start at all.
+	if IsCustomPrecompileRegistered != nil && !IsCustomPrecompileRegistered(p.Name) {
+		return errCustomPrecompileNotRegistered
+	}
+	return nil
+}
+
+// CheckCustomPrecompiles validates the custom precompiled contracts in the chain config.
+// It checks the address range, duplicated names and addresses, the gas schedule and
+// whether the implementations are registered.
+func (c *ChainConfig) CheckCustomPrecompiles() error {
+	names := make(map[string]struct{}, len(c.CustomPrecompiles))
+	addrs := make(map[common.Address]struct{}, len(c.CustomPrecompiles))
+	for _, p := range c.CustomPrecompiles {
+		if p == nil {
+			return errors.New("custom precompile config is nil")
+		}
+		if err := p.validate(); err != nil {
+			return fmt.Errorf("invalid custom precompile %q at %v: %w", p.Name, p.Address.Hex(), err)
+		}
+		if _, ok := names[p.Name]; ok {
+			return fmt.Errorf("duplicated custom precompile name %q", p.Name)
+		}
+		if _, ok := addrs[p.Address]; ok {
+			return fmt.Errorf("duplicated custom precompile address %v", p.Address.Hex())
+		}
+		names[p.Name] = struct{}{}
+		addrs[p.Address] = struct{}{}
+	}
+	return nil
+}
+
+// ActiveCustomPrecompiles returns the custom precompiled contracts activated at the given block number.
+func (c *ChainConfig) ActiveCustomPrecompiles(num *big.Int) []*CustomPrecompileConfig {
+	if len(c.CustomPrecompiles) == 0 {
+		return nil
+	}
+	active := make([]*CustomPrecompileConfig, 0, len(c.CustomPrecompiles))
+	for _, p := range c.CustomPrecompiles {
+		if p != nil && p.IsActive(num) {
+			active = append(active, p)
+		}
+	}
+	return active
+}
+
+// sameSchedule returns whether the custom precompiled contracts run the same implementation
+// with the same gas and computation cost.
+func (p *CustomPrecompileConfig) sameSchedule(other *CustomPrecompileConfig) bool {
+	return p.Name == other.Name &&
+		p.BaseGas == other.BaseGas &&
+		p.PerWordGas == other.PerWordGas &&
+		p.BaseComputationCost == other.BaseComputationCost &&
+		p.PerWordComputationCost == other.PerWordComputationCost
+}
+
+// checkCustomPrecompilesCompatible returns an error if the activation block of a custom
+// precompiled contract is changed for a block which has already been processed, or if the
+// implementation, the gas or the computation cost of an activated one is changed.
+func (c *ChainConfig) checkCustomPrecompilesCompatible(newcfg *ChainConfig, head *big.Int) *ConfigCompatError {
+	byAddress := func(cfg *ChainConfig) map[common.Address]*CustomPrecompileConfig {
+		m := make(map[common.Address]*CustomPrecompileConfig, len(cfg.CustomPrecompiles))
+		for _, p := range cfg.CustomPrecompiles {
+			if p != nil {
+				m[p.Address] = p
+			}
+		}
+		return m
+	}
+	stored, next := byAddress(c), byAddress(newcfg)
+	for addr, p := range stored {
+		var nextBlock *big.Int
+		if n, ok := next[addr]; ok {
+			nextBlock = n.ActivationBlock
+		}
+		if isForkIncompatible(p.ActivationBlock, nextBlock, head) {
+			return newCompatError("Custom Precompile "+addr.Hex(), p.ActivationBlock, nextBlock)
+		}
+		// The activation block is not changed here if the contract has been activated,
+		// so the blocks since the activation should be processed again with the new schedule.
+		if n, ok := next[addr]; ok && isForked(p.ActivationBlock, head) && !p.sameSchedule(n) {
+			return newCompatError("Custom Precompile "+addr.Hex()+" schedule", p.ActivationBlock, n.ActivationBlock)
+		}
+	}
+	for addr, p := range next {
+		if _, ok := stored[addr]; !ok && isForkIncompatible(nil, p.ActivationBlock, head) {
+			return newCompatError("Custom Precompile "+addr.Hex(), nil, p.ActivationBlock)
+		}
+	}
+	return nil
+}
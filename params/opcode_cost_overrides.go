@@ -0,0 +1,92 @@
+// Copyright 2022 The klaytn Authors
+// This file is part of the klaytn library.
+//
+// The klaytn library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The klaytn library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the klaytn library. If not, see <http://www.gnu.org/licenses/>.
+
+package params
+
+import (
+	"errors"
+	"fmt"
+	"math/big"
+	"reflect"
+)
+
+// MaxOpcodeConstantGasOverride is the upper bound of the constant gas of an opcode
+// which can be set by OpcodeCostOverrides.
+const MaxOpcodeConstantGasOverride uint64 = 1000000
+
+var errOpcodeCostOverridesOnPublicNetwork = errors.New("opcode cost overrides are not allowed on cypress and baobab")
+
+// OpcodeCostOverrides overrides the constant gas and the computation cost of opcodes
+// from the activation block. It is only allowed on private networks, to evaluate
+// pricing changes before proposing them as a protocol upgrade.
+type OpcodeCostOverrides struct {
+	ActivationBlock *big.Int                       `json:"activationBlock"` // Activation block (nil = never activated, 0 = already activated)
+	Opcodes         map[string]*OpcodeCostOverride `json:"opcodes"`         // Overrides keyed by the opcode name, e.g. "SLOAD"
+}
+
+// OpcodeCostOverride contains the overridden costs of an opcode.
+// A nil field leaves the corresponding cost of the active hardfork unchanged.
+type OpcodeCostOverride struct {
+	ConstantGas     *uint64 `json:"constantGas,omitempty"`
+	ComputationCost *uint64 `json:"computationCost,omitempty"`
+}
+
+// IsOpcodeCostOverridden returns whether the opcode cost overrides are activated at the given block number.
+func (c *ChainConfig) IsOpcodeCostOverridden(num *big.Int) bool {
+	return c.OpcodeCostOverrides != nil && isForked(c.OpcodeCostOverrides.ActivationBlock, num)
+}
+
+// CheckOpcodeCostOverrides validates the network and the bounds of the opcode cost overrides.
+// The opcode names are validated by the vm package which defines them.
+func (c *ChainConfig) CheckOpcodeCostOverrides() error {
+	if c.OpcodeCostOverrides == nil {
+		return nil
+	}
+	if c.ChainID != nil && (c.ChainID.Uint64() == CypressNetworkId || c.ChainID.Uint64() == BaobabNetworkId) {
+		return errOpcodeCostOverridesOnPublicNetwork
+	}
+	for name, o := range c.OpcodeCostOverrides.Opcodes {
+		if o == nil {
+			return fmt.Errorf("opcode cost override of %s is nil", name)
+		}
+		if o.ConstantGas != nil && *o.ConstantGas > MaxOpcodeConstantGasOverride {
+			return fmt.Errorf("constant gas override of %s (%d) exceeds the limit %d", name, *o.ConstantGas, MaxOpcodeConstantGasOverride)
+		}
+		if o.ComputationCost != nil && *o.ComputationCost > OpcodeComputationCostLimit {
+			return fmt.Errorf("computation cost override of %s (%d) exceeds the limit %d", name, *o.ComputationCost, OpcodeComputationCostLimit)
+		}
+	}
+	return nil
+}
+
+// checkOpcodeCostOverridesCompatible returns an error if the opcode cost overrides are
+// changed for a block which has already been processed.
+func (c *ChainConfig) checkOpcodeCostOverridesCompatible(newcfg *ChainConfig, head *big.Int) *ConfigCompatError {
+	var storedBlock, newBlock *big.Int
+	if c.OpcodeCostOverrides != nil {
+		storedBlock = c.OpcodeCostOverrides.ActivationBlock
+	}
+	if newcfg.OpcodeCostOverrides != nil {
+		newBlock = newcfg.OpcodeCostOverrides.ActivationBlock
+	}
+	if isForkIncompatible(storedBlock, newBlock, head) {
+		return newCompatError("Opcode Cost Overrides Block", storedBlock, newBlock)
+	}
+	if isForked(storedBlock, head) && !reflect.DeepEqual(c.OpcodeCostOverrides.Opcodes, newcfg.OpcodeCostOverrides.Opcodes) {
+		return newCompatError("Opcode Cost Overrides", storedBlock, newBlock)
+	}
+	return nil
+}
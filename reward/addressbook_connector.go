@@ -90,7 +90,7 @@ func (ac *addressBookConnector) makeMsgToAddressBook(r params.Rules) (*types.Tra
 		checkNonce = false
 	)
 	msg := types.NewMessage(from, to, nonce, amount, gasLimit, gasPrice,
-		data, checkNonce, intrinsicGas)
+		data, checkNonce, intrinsicGas, nil)
 	return msg, nil
 }
 
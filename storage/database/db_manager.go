@@ -50,6 +50,7 @@ var (
 type DBManager interface {
 	IsParallelDBWrite() bool
 	IsSingle() bool
+	IsReadOnly() bool
 	InMigration() bool
 	MigrationBlockNumber() uint64
 	getStateTrieMigrationInfo() uint64
@@ -64,11 +65,16 @@ type DBManager interface {
 	getDatabase(DBEntryType) Database
 	CreateMigrationDBAndSetStatus(blockNum uint64) error
 	FinishStateMigration(succeed bool) chan struct{}
+	ReadPeriodicMigrationBlock() (uint64, bool)
+	WritePeriodicMigrationBlock(blockNum uint64) error
 	GetStateTrieDB() Database
 	GetStateTrieMigrationDB() Database
 	GetMiscDB() Database
 	GetSnapshotDB() Database
 
+	CompactDatabase(name string, start, limit []byte) error
+	DatabaseStats() ([]*DBStats, error)
+
 	// from accessors_chain.go
 	ReadCanonicalHash(number uint64) common.Hash
 	WriteCanonicalHash(hash common.Hash, number uint64)
@@ -175,6 +181,9 @@ type DBManager interface {
 	WriteAndCacheTxLookupEntries(block *types.Block) error
 	PutTxLookupEntriesToBatch(batch Batch, block *types.Block)
 	DeleteTxLookupEntry(hash common.Hash)
+	DeleteTxLookupEntries(block *types.Block)
+	ReadTxIndexTail() *uint64
+	WriteTxIndexTail(number uint64)
 
 	ReadTxAndLookupInfo(hash common.Hash) (*types.Transaction, common.Hash, uint64, uint64)
 
@@ -283,6 +292,49 @@ type DBManager interface {
 	ReadStakingInfo(blockNum uint64) ([]byte, error)
 	WriteStakingInfo(blockNum uint64, stakingInfo []byte) error
 
+	// Account transaction index related functions
+	NewAccountTxIndexBatch() Batch
+	PutAccountTxIndexToBatch(batch Batch, address common.Address, blockNum, txIndex uint64, txHash common.Hash) error
+	ReadAccountTxIndex(address common.Address, fromBlock, fromIndex, toBlock uint64, limit int) []AccountTxIndexEntry
+	ReadAccountTxIndexTail() (uint64, bool)
+	WriteAccountTxIndexTail(blockNum uint64) error
+	ReadAccountTxIndexHead() (uint64, bool)
+	PutAccountTxIndexHeadToBatch(batch Batch, blockNum uint64) error
+
+	// Internal transaction index related functions
+	NewInternalTxIndexBatch() Batch
+	PutInternalTxsToBatch(batch Batch, txHash common.Hash, internalTxs []byte) error
+	ReadInternalTxs(txHash common.Hash) []byte
+	PutInternalTxAccountIndexToBatch(batch Batch, address common.Address, blockNum, txIndex uint64, txHash common.Hash) error
+	ReadInternalTxAccountIndex(address common.Address, fromBlock, fromIndex, toBlock uint64, limit int) []AccountTxIndexEntry
+	ReadInternalTxIndexTail() (uint64, bool)
+	WriteInternalTxIndexTail(blockNum uint64) error
+	ReadInternalTxIndexHead() (uint64, bool)
+	PutInternalTxIndexHeadToBatch(batch Batch, blockNum uint64) error
+
+	// Token transfer index related functions
+	NewTokenTransferIndexBatch() Batch
+	PutTokenTransferToBatch(batch Batch, address common.Address, blockNum, logIndex uint64, transfer []byte) error
+	ReadTokenTransfers(address common.Address, fromBlock, fromLogIndex, toBlock uint64, limit int) []TokenTransferIndexEntry
+	PutTokenHoldingToBatch(batch Batch, address, token common.Address, standard []byte) error
+	ReadTokenHoldings(address common.Address) map[common.Address][]byte
+	ReadTokenTransferIndexTail() (uint64, bool)
+	WriteTokenTransferIndexTail(blockNum uint64) error
+	ReadTokenTransferIndexHead() (uint64, bool)
+	PutTokenTransferIndexHeadToBatch(batch Batch, blockNum uint64) error
+
+	// Fee statistics related functions
+	ReadFeeStats(blockNum uint64) []byte
+	WriteFeeStats(blockNum uint64, stats []byte) error
+
+	// State history related functions
+	ReadStateHistory(hash common.Hash, blockNum uint64) []byte
+	HasStateHistory(hash common.Hash, blockNum uint64) bool
+	WriteStateHistory(hash common.Hash, blockNum uint64, history []byte, addresses []common.Address, tail bool) error
+	ReadAccountStateHistoryIndex(address common.Address, fromBlock, toBlock uint64, fn func(blockNum uint64, hash common.Hash) bool)
+	ReadStateHistoryTail() (uint64, bool)
+	WriteStateHistoryTail(blockNum uint64) error
+
 	// DB migration related function
 	StartDBMigration(DBManager) error
 
@@ -428,12 +480,17 @@ type DBConfig struct {
 	ParallelDBWrite     bool
 	OpenFilesLimit      int
 	EnableDBPerfMetrics bool // If true, read and write performance will be logged
+	ReadOnly            bool // If true, writes are rejected. It is used by the read replicas sharing a DynamoDB.
 
 	// LevelDB related configurations.
 	LevelDBCacheSize   int // LevelDBCacheSize = BlockCacheCapacity + WriteBuffer
 	LevelDBCompression LevelDBCompressionType
 	LevelDBBufferPool  bool
 
+	// RocksDB related configurations.
+	RocksDBBlockCacheSize  int    // Total size of the block caches of the column families (MiB)
+	RocksDBCompactionStyle string // One of "level", "universal" and "fifo"
+
 	// DynamoDB related configurations
 	DynamoDBConfig *DynamoDBConfig
 }
@@ -515,6 +572,19 @@ func databaseDBManager(dbc *DBConfig) (*databaseManager, error) {
 
 // newDatabase returns Database interface with given DBConfig.
 func newDatabase(dbc *DBConfig, entryType DBEntryType) (Database, error) {
+	// The local databases cannot be opened while a primary node holds them,
+	// and they are not updated by the primary node once opened.
+	if dbc.ReadOnly && dbc.DBType != DynamoDB {
+		return nil, errors.Errorf("read-only mode is not supported by %s, but only by %s", dbc.DBType, DynamoDB)
+	}
+	db, err := openDatabase(dbc, entryType)
+	if err != nil || !dbc.ReadOnly {
+		return db, err
+	}
+	return newReadOnlyDB(db), nil
+}
+
+func openDatabase(dbc *DBConfig, entryType DBEntryType) (Database, error) {
 	switch dbc.DBType {
 	case LevelDB:
 		return NewLevelDB(dbc, entryType)
@@ -524,6 +594,10 @@ func newDatabase(dbc *DBConfig, entryType DBEntryType) (Database, error) {
 		return NewMemDB(), nil
 	case DynamoDB:
 		return NewDynamoDB(dbc.DynamoDBConfig)
+	case PebbleDB:
+		return NewPebbleDB(dbc, entryType)
+	case RocksDB:
+		return NewRocksDB(dbc, entryType)
 	default:
 		logger.Info("database type is not set, fall back to default LevelDB")
 		return NewLevelDB(dbc, 0)
@@ -580,6 +654,10 @@ func (dbm *databaseManager) IsSingle() bool {
 	return dbm.config.SingleDB
 }
 
+func (dbm *databaseManager) IsReadOnly() bool {
+	return dbm.config.ReadOnly
+}
+
 func (dbm *databaseManager) InMigration() bool {
 	dbm.lockInMigration.RLock()
 	defer dbm.lockInMigration.RUnlock()
@@ -714,6 +792,17 @@ func (dbm *databaseManager) setStateTrieMigrationStatus(blockNum uint64) {
 	dbm.inMigration, dbm.migrationBlockNumber = true, blockNum
 }
 
+// ReadPeriodicMigrationBlock returns the number of the block at which the last periodic state migration
+// has started. It returns false if no periodic state migration has started.
+func (dbm *databaseManager) ReadPeriodicMigrationBlock() (uint64, bool) {
+	return dbm.readBlockNumber(periodicMigrationKey)
+}
+
+// WritePeriodicMigrationBlock stores the number of the block at which a periodic state migration starts.
+func (dbm *databaseManager) WritePeriodicMigrationBlock(blockNum uint64) error {
+	return dbm.getDatabase(MiscDB).Put(periodicMigrationKey, common.Int64ToByteBigEndian(blockNum))
+}
+
 func newStateTrieMigrationDB(dbc *DBConfig, blockNum uint64) (Database, string) {
 	dbDir := dbBaseDirs[StateTrieMigrationDB] + "_" + strconv.FormatUint(blockNum, 10)
 	newDBConfig := getDBEntryConfig(dbc, StateTrieMigrationDB, dbDir)
@@ -1976,6 +2065,41 @@ func (dbm *databaseManager) DeleteTxLookupEntry(hash common.Hash) {
 	db.Delete(TxLookupKey(hash))
 }
 
+// DeleteTxLookupEntries removes the positional metadata of every transaction of a block.
+func (dbm *databaseManager) DeleteTxLookupEntries(block *types.Block) {
+	batch := dbm.NewBatch(TxLookUpEntryDB)
+	for _, tx := range block.Transactions() {
+		if err := batch.Delete(TxLookupKey(tx.Hash())); err != nil {
+			logger.Crit("Failed to delete transaction lookup entry", "err", err)
+		}
+	}
+	if err := batch.Write(); err != nil {
+		logger.Crit("Failed to delete TxLookupEntries in batch", "err", err, "blockNumber", block.Number())
+	}
+}
+
+// ReadTxIndexTail retrieves the number of the oldest block whose transactions are indexed.
+// It returns nil if the transactions have never been unindexed.
+func (dbm *databaseManager) ReadTxIndexTail() *uint64 {
+	db := dbm.getDatabase(TxLookUpEntryDB)
+	data, _ := db.Get(txIndexTailKey)
+	if len(data) != 8 {
+		return nil
+	}
+	number := binary.BigEndian.Uint64(data)
+	return &number
+}
+
+// WriteTxIndexTail stores the number of the oldest block whose transactions are indexed.
+func (dbm *databaseManager) WriteTxIndexTail(number uint64) {
+	db := dbm.getDatabase(TxLookUpEntryDB)
+	var buf [8]byte
+	binary.BigEndian.PutUint64(buf[:], number)
+	if err := db.Put(txIndexTailKey, buf[:]); err != nil {
+		logger.Crit("Failed to store the transaction index tail", "err", err)
+	}
+}
+
 // ReadTxAndLookupInfo retrieves a specific transaction from the database, along with
 // its added positional metadata.
 func (dbm *databaseManager) ReadTxAndLookupInfo(hash common.Hash) (*types.Transaction, common.Hash, uint64, uint64) {
@@ -0,0 +1,102 @@
+// Copyright 2022 The klaytn Authors
+// This file is part of the klaytn library.
+//
+// The klaytn library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The klaytn library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the klaytn library. If not, see <http://www.gnu.org/licenses/>.
+
+package database
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/klaytn/klaytn/common"
+)
+
+// singleDBName is the name of the database reported when all the entries share one database.
+const singleDBName = "single"
+
+// compactDB compacts the given key range of the database if it supports the manual compaction.
+func compactDB(db Database, start, limit []byte) error {
+	c, ok := db.(Compacter)
+	if !ok {
+		return fmt.Errorf("%s does not support compaction", db.Type())
+	}
+	return c.Compact(start, limit)
+}
+
+// statDB returns the statistics of the database if it reports them.
+func statDB(db Database) (*DBStats, error) {
+	s, ok := db.(StatsReporter)
+	if !ok {
+		return nil, fmt.Errorf("%s does not report statistics", db.Type())
+	}
+	return s.Stats()
+}
+
+// namedDatabases returns the opened databases by their names.
+func (dbm *databaseManager) namedDatabases() ([]string, []Database) {
+	if dbm.config.SingleDB || dbm.config.DBType == MemoryDB {
+		return []string{singleDBName}, []Database{dbm.dbs[0]}
+	}
+	var (
+		names []string
+		dbs   []Database
+	)
+	for et, db := range dbm.dbs {
+		if db != nil {
+			names = append(names, dbBaseDirs[et])
+			dbs = append(dbs, db)
+		}
+	}
+	return names, dbs
+}
+
+// CompactDatabase compacts the given key range of the database of the given name,
+// or of all the databases if the name is empty.
+func (dbm *databaseManager) CompactDatabase(name string, start, limit []byte) error {
+	names, dbs := dbm.namedDatabases()
+	found := false
+	for i, db := range dbs {
+		if name != "" && name != names[i] && names[i] != singleDBName {
+			continue
+		}
+		found = true
+		logger.Info("Compacting database", "name", names[i], "start", common.Bytes2Hex(start), "limit", common.Bytes2Hex(limit))
+		begin := time.Now()
+		if err := compactDB(db, start, limit); err != nil {
+			logger.Error("Database compaction failed", "name", names[i], "err", err)
+			return err
+		}
+		logger.Info("Compacted database", "name", names[i], "elapsed", common.PrettyDuration(time.Since(begin)))
+	}
+	if !found {
+		return fmt.Errorf("unknown database %q", name)
+	}
+	return nil
+}
+
+// DatabaseStats returns the statistics of all the databases.
+func (dbm *databaseManager) DatabaseStats() ([]*DBStats, error) {
+	names, dbs := dbm.namedDatabases()
+	stats := make([]*DBStats, 0, len(dbs))
+	for i, db := range dbs {
+		s, err := statDB(db)
+		if err != nil {
+			return nil, fmt.Errorf("failed to get the statistics of %s: %v", names[i], err)
+		}
+		s.Name = names[i]
+		stats = append(stats, s)
+	}
+	return stats, nil
+}
@@ -0,0 +1,65 @@
+// Copyright 2022 The klaytn Authors
+// This file is part of the klaytn library.
+//
+// The klaytn library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The klaytn library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the klaytn library. If not, see <http://www.gnu.org/licenses/>.
+
+package database
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDatabaseManager_CompactionAndStats(t *testing.T) {
+	for _, dbm := range dbManagers {
+		dbc := dbm.GetDBConfig()
+		if dbc.DBType == BadgerDB {
+			assert.Error(t, dbm.CompactDatabase("", nil, nil))
+			continue
+		}
+		for i := 0; i < 100; i++ {
+			dbm.WriteCanonicalHash(hash1, uint64(i))
+		}
+		// The database managers are shared by the tests, so the written entries are removed afterwards.
+		defer func(dbm DBManager) {
+			for i := 0; i < 100; i++ {
+				dbm.DeleteCanonicalHash(uint64(i))
+			}
+		}(dbm)
+
+		assert.NoError(t, dbm.CompactDatabase("", nil, nil))
+		assert.NoError(t, dbm.CompactDatabase("misc", []byte{0x00}, []byte{0xff}))
+		if !dbc.SingleDB && dbc.DBType != MemoryDB {
+			assert.Error(t, dbm.CompactDatabase("unknown", nil, nil))
+		}
+
+		stats, err := dbm.DatabaseStats()
+		require.NoError(t, err)
+		if dbc.SingleDB || dbc.DBType == MemoryDB {
+			require.Len(t, stats, 1)
+			assert.Equal(t, singleDBName, stats[0].Name)
+		} else {
+			assert.Equal(t, dbBaseDirs[MiscDB], stats[0].Name)
+			assert.Equal(t, dbBaseDirs[headerDB], stats[1].Name)
+		}
+		var size int64
+		for _, s := range stats {
+			assert.Equal(t, dbc.DBType, s.Type)
+			size += s.Size
+		}
+		assert.True(t, size > 0)
+	}
+}
@@ -0,0 +1,30 @@
+// Copyright 2022 The klaytn Authors
+// This file is part of the klaytn library.
+//
+// The klaytn library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The klaytn library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the klaytn library. If not, see <http://www.gnu.org/licenses/>.
+
+package database
+
+// ReadFeeStats retrieves the encoded fee statistics of the given block from MiscDB.
+// It returns nil if the statistics of the block have not been stored.
+func (dbm *databaseManager) ReadFeeStats(blockNum uint64) []byte {
+	stats, _ := dbm.getDatabase(MiscDB).Get(makeKey(feeStatsPrefix, blockNum))
+	return stats
+}
+
+// WriteFeeStats stores the encoded fee statistics of the given block to MiscDB.
+// The statistics of a block replaced by a reorganization are overwritten by the new block.
+func (dbm *databaseManager) WriteFeeStats(blockNum uint64, stats []byte) error {
+	return dbm.getDatabase(MiscDB).Put(makeKey(feeStatsPrefix, blockNum), stats)
+}
@@ -0,0 +1,36 @@
+// Copyright 2022 The klaytn Authors
+// This file is part of the klaytn library.
+//
+// The klaytn library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The klaytn library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the klaytn library. If not, see <http://www.gnu.org/licenses/>.
+
+package database
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestDatabaseManager_FeeStats(t *testing.T) {
+	for _, dbm := range dbManagers {
+		assert.Nil(t, dbm.ReadFeeStats(1234))
+
+		assert.NoError(t, dbm.WriteFeeStats(1234, []byte("stats")))
+		assert.Equal(t, []byte("stats"), dbm.ReadFeeStats(1234))
+		assert.Nil(t, dbm.ReadFeeStats(1235))
+
+		assert.NoError(t, dbm.WriteFeeStats(1234, []byte("replaced")))
+		assert.Equal(t, []byte("replaced"), dbm.ReadFeeStats(1234))
+	}
+}
@@ -0,0 +1,90 @@
+// Copyright 2022 The klaytn Authors
+// This file is part of the klaytn library.
+//
+// The klaytn library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The klaytn library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the klaytn library. If not, see <http://www.gnu.org/licenses/>.
+
+package database
+
+import (
+	"encoding/binary"
+
+	"github.com/klaytn/klaytn/common"
+)
+
+// ReadStateHistory retrieves the encoded reverse state diff of the given block from MiscDB.
+// It returns nil if the history of the block has not been stored.
+func (dbm *databaseManager) ReadStateHistory(hash common.Hash, blockNum uint64) []byte {
+	history, _ := dbm.getDatabase(MiscDB).Get(stateHistoryKey(blockNum, hash))
+	return history
+}
+
+// HasStateHistory returns true if the reverse state diff of the given block is stored in MiscDB.
+func (dbm *databaseManager) HasStateHistory(hash common.Hash, blockNum uint64) bool {
+	ok, _ := dbm.getDatabase(MiscDB).Has(stateHistoryKey(blockNum, hash))
+	return ok
+}
+
+// WriteStateHistory stores the encoded reverse state diff of the given block to MiscDB and
+// indexes the block by the given addresses, which are the accounts modified by the block.
+// If tail is true, the block is stored as the tail of the state history in the same batch.
+func (dbm *databaseManager) WriteStateHistory(hash common.Hash, blockNum uint64, history []byte, addresses []common.Address, tail bool) error {
+	batch := dbm.NewBatch(MiscDB)
+	if err := batch.Put(stateHistoryKey(blockNum, hash), history); err != nil {
+		return err
+	}
+	if tail {
+		if err := batch.Put(stateHistoryTailKey, common.Int64ToByteBigEndian(blockNum)); err != nil {
+			return err
+		}
+	}
+	for _, addr := range addresses {
+		if err := putToBatch(batch, accountStateHistoryIndexKey(addr, blockNum, hash), []byte{}); err != nil {
+			return err
+		}
+	}
+	return batch.Write()
+}
+
+// ReadAccountStateHistoryIndex calls fn for the blocks from fromBlock up to toBlock which
+// modified the given address, in ascending order of the block number, until fn returns false.
+// The blocks are not removed on reorganizations, so the caller should check if each block
+// is still canonical.
+func (dbm *databaseManager) ReadAccountStateHistoryIndex(address common.Address, fromBlock, toBlock uint64, fn func(blockNum uint64, hash common.Hash) bool) {
+	prefix := append(append([]byte{}, accountStateHistoryIndexPrefix...), address.Bytes()...)
+
+	it := dbm.getDatabase(MiscDB).NewIterator(prefix, common.Int64ToByteBigEndian(fromBlock))
+	defer it.Release()
+
+	for it.Next() {
+		key := it.Key()
+		if len(key) != len(prefix)+8+common.HashLength {
+			continue
+		}
+		blockNum := binary.BigEndian.Uint64(key[len(prefix):])
+		if blockNum > toBlock || !fn(blockNum, common.BytesToHash(key[len(prefix)+8:])) {
+			return
+		}
+	}
+}
+
+// ReadStateHistoryTail returns the number of the first block of which the state history is stored.
+// It returns false if the state history has never been written.
+func (dbm *databaseManager) ReadStateHistoryTail() (uint64, bool) {
+	return dbm.readBlockNumber(stateHistoryTailKey)
+}
+
+// WriteStateHistoryTail stores the number of the first block of which the state history is stored.
+func (dbm *databaseManager) WriteStateHistoryTail(blockNum uint64) error {
+	return dbm.getDatabase(MiscDB).Put(stateHistoryTailKey, common.Int64ToByteBigEndian(blockNum))
+}
@@ -0,0 +1,89 @@
+// Copyright 2022 The klaytn Authors
+// This file is part of the klaytn library.
+//
+// The klaytn library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The klaytn library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the klaytn library. If not, see <http://www.gnu.org/licenses/>.
+
+package database
+
+import (
+	"testing"
+
+	"github.com/klaytn/klaytn/common"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestDatabaseManager_StateHistory(t *testing.T) {
+	var (
+		hash1 = common.HexToHash("0x1")
+		hash2 = common.HexToHash("0x2")
+		addr1 = common.HexToAddress("0xa1")
+		addr2 = common.HexToAddress("0xa2")
+	)
+	dbm := NewMemoryDBManager()
+	defer dbm.Close()
+
+	assert.Nil(t, dbm.ReadStateHistory(hash1, 1234))
+	_, ok := dbm.ReadStateHistoryTail()
+	assert.False(t, ok)
+
+	// The history is stored with the tail in a batch.
+	assert.NoError(t, dbm.WriteStateHistory(hash1, 1234, []byte("history"), []common.Address{addr1, addr2}, true))
+	tail, ok := dbm.ReadStateHistoryTail()
+	assert.True(t, ok)
+	assert.Equal(t, uint64(1234), tail)
+	assert.Equal(t, []byte("history"), dbm.ReadStateHistory(hash1, 1234))
+	assert.True(t, dbm.HasStateHistory(hash1, 1234))
+	assert.Nil(t, dbm.ReadStateHistory(hash2, 1234))
+	assert.False(t, dbm.HasStateHistory(hash2, 1234))
+	assert.Nil(t, dbm.ReadStateHistory(hash1, 1235))
+
+	// A block of a side chain does not replace the history of the other block.
+	assert.NoError(t, dbm.WriteStateHistory(hash2, 1234, []byte("side"), []common.Address{addr1}, false))
+	assert.NoError(t, dbm.WriteStateHistory(hash1, 1240, []byte("later"), []common.Address{addr1}, false))
+	assert.Equal(t, []byte("history"), dbm.ReadStateHistory(hash1, 1234))
+	assert.Equal(t, []byte("side"), dbm.ReadStateHistory(hash2, 1234))
+
+	type entry struct {
+		num  uint64
+		hash common.Hash
+	}
+	read := func(addr common.Address, from, to uint64) []entry {
+		var entries []entry
+		dbm.ReadAccountStateHistoryIndex(addr, from, to, func(num uint64, hash common.Hash) bool {
+			entries = append(entries, entry{num, hash})
+			return true
+		})
+		return entries
+	}
+	assert.Equal(t, []entry{{1234, hash1}, {1234, hash2}, {1240, hash1}}, read(addr1, 0, 2000))
+	assert.Equal(t, []entry{{1240, hash1}}, read(addr1, 1235, 2000))
+	assert.Equal(t, []entry{{1234, hash1}, {1234, hash2}}, read(addr1, 1000, 1239))
+	assert.Equal(t, []entry{{1234, hash1}}, read(addr2, 0, 2000))
+	assert.Nil(t, read(common.HexToAddress("0xa3"), 0, 2000))
+
+	var count int
+	dbm.ReadAccountStateHistoryIndex(addr1, 0, 2000, func(uint64, common.Hash) bool {
+		count++
+		return false
+	})
+	assert.Equal(t, 1, count)
+
+	// The tail is not moved by the histories which are not stored as the tail.
+	tail, _ = dbm.ReadStateHistoryTail()
+	assert.Equal(t, uint64(1234), tail)
+	assert.NoError(t, dbm.WriteStateHistoryTail(1240))
+	tail, ok = dbm.ReadStateHistoryTail()
+	assert.True(t, ok)
+	assert.Equal(t, uint64(1240), tail)
+}
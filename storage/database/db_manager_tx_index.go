@@ -0,0 +1,247 @@
+// Copyright 2022 The klaytn Authors
+// This file is part of the klaytn library.
+//
+// The klaytn library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The klaytn library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the klaytn library. If not, see <http://www.gnu.org/licenses/>.
+
+package database
+
+import (
+	"encoding/binary"
+
+	"github.com/klaytn/klaytn/common"
+)
+
+// AccountTxIndexEntry is the position of a transaction related to an account.
+type AccountTxIndexEntry struct {
+	BlockNumber uint64
+	Index       uint64
+	TxHash      common.Hash
+}
+
+// NewAccountTxIndexBatch returns a batch to write the account transaction index.
+func (dbm *databaseManager) NewAccountTxIndexBatch() Batch {
+	return dbm.NewBatch(MiscDB)
+}
+
+// PutAccountTxIndexToBatch puts the position of a transaction related to the given address to the given batch.
+func (dbm *databaseManager) PutAccountTxIndexToBatch(batch Batch, address common.Address, blockNum, txIndex uint64, txHash common.Hash) error {
+	return putToBatch(batch, accountTxIndexKey(address, blockNum, txIndex), txHash.Bytes())
+}
+
+// ReadAccountTxIndex retrieves at most limit positions of the transactions related to the given
+// address, starting from the position (fromBlock, fromIndex) up to the block toBlock.
+// The positions are sorted in ascending order. They are not removed on reorganizations,
+// so the caller should check if the transaction at each position is still canonical.
+func (dbm *databaseManager) ReadAccountTxIndex(address common.Address, fromBlock, fromIndex, toBlock uint64, limit int) []AccountTxIndexEntry {
+	return dbm.readTxPositions(accountTxIndexPrefix, address, fromBlock, fromIndex, toBlock, limit)
+}
+
+// ReadAccountTxIndexTail returns the number of the first block of the account transaction index.
+// It returns false if the account transaction index has never been written.
+func (dbm *databaseManager) ReadAccountTxIndexTail() (uint64, bool) {
+	return dbm.readBlockNumber(accountTxIndexTailKey)
+}
+
+// WriteAccountTxIndexTail stores the number of the first block of the account transaction index.
+func (dbm *databaseManager) WriteAccountTxIndexTail(blockNum uint64) error {
+	return dbm.getDatabase(MiscDB).Put(accountTxIndexTailKey, common.Int64ToByteBigEndian(blockNum))
+}
+
+// ReadAccountTxIndexHead returns the number of the last block of the account transaction index.
+// It returns false if no block has been indexed.
+func (dbm *databaseManager) ReadAccountTxIndexHead() (uint64, bool) {
+	return dbm.readBlockNumber(accountTxIndexHeadKey)
+}
+
+// PutAccountTxIndexHeadToBatch puts the number of the last block of the account transaction index
+// to the given batch, so that it is stored with the index of the block.
+func (dbm *databaseManager) PutAccountTxIndexHeadToBatch(batch Batch, blockNum uint64) error {
+	return batch.Put(accountTxIndexHeadKey, common.Int64ToByteBigEndian(blockNum))
+}
+
+// NewInternalTxIndexBatch returns a batch to write the internal transactions and their account index.
+func (dbm *databaseManager) NewInternalTxIndexBatch() Batch {
+	return dbm.NewBatch(MiscDB)
+}
+
+// PutInternalTxsToBatch puts the encoded internal transactions of the given transaction to the given batch.
+func (dbm *databaseManager) PutInternalTxsToBatch(batch Batch, txHash common.Hash, internalTxs []byte) error {
+	return putToBatch(batch, internalTxsKey(txHash), internalTxs)
+}
+
+// ReadInternalTxs retrieves the encoded internal transactions of the given transaction.
+func (dbm *databaseManager) ReadInternalTxs(txHash common.Hash) []byte {
+	data, _ := dbm.getDatabase(MiscDB).Get(internalTxsKey(txHash))
+	return data
+}
+
+// PutInternalTxAccountIndexToBatch puts the position of a transaction whose internal transactions
+// are related to the given address to the given batch.
+func (dbm *databaseManager) PutInternalTxAccountIndexToBatch(batch Batch, address common.Address, blockNum, txIndex uint64, txHash common.Hash) error {
+	return putToBatch(batch, internalTxAccountIndexKey(address, blockNum, txIndex), txHash.Bytes())
+}
+
+// ReadInternalTxAccountIndex is the same as ReadAccountTxIndex, but for the transactions
+// whose internal transactions are related to the given address.
+func (dbm *databaseManager) ReadInternalTxAccountIndex(address common.Address, fromBlock, fromIndex, toBlock uint64, limit int) []AccountTxIndexEntry {
+	return dbm.readTxPositions(internalTxAccountIndexPrefix, address, fromBlock, fromIndex, toBlock, limit)
+}
+
+// ReadInternalTxIndexTail returns the number of the first block of the internal transaction index.
+// It returns false if the internal transaction index has never been written.
+func (dbm *databaseManager) ReadInternalTxIndexTail() (uint64, bool) {
+	return dbm.readBlockNumber(internalTxAccountIndexTailKey)
+}
+
+// WriteInternalTxIndexTail stores the number of the first block of the internal transaction index.
+func (dbm *databaseManager) WriteInternalTxIndexTail(blockNum uint64) error {
+	return dbm.getDatabase(MiscDB).Put(internalTxAccountIndexTailKey, common.Int64ToByteBigEndian(blockNum))
+}
+
+// ReadInternalTxIndexHead returns the number of the last block of the internal transaction index.
+// It returns false if no block has been indexed.
+func (dbm *databaseManager) ReadInternalTxIndexHead() (uint64, bool) {
+	return dbm.readBlockNumber(internalTxAccountIndexHeadKey)
+}
+
+// PutInternalTxIndexHeadToBatch puts the number of the last block of the internal transaction index
+// to the given batch, so that it is stored with the index of the block.
+func (dbm *databaseManager) PutInternalTxIndexHeadToBatch(batch Batch, blockNum uint64) error {
+	return batch.Put(internalTxAccountIndexHeadKey, common.Int64ToByteBigEndian(blockNum))
+}
+
+// TokenTransferIndexEntry is an encoded token transfer related to an account with the position of its log.
+type TokenTransferIndexEntry struct {
+	BlockNumber uint64
+	LogIndex    uint64
+	Transfer    []byte
+}
+
+// NewTokenTransferIndexBatch returns a batch to write the token transfer index.
+func (dbm *databaseManager) NewTokenTransferIndexBatch() Batch {
+	return dbm.NewBatch(MiscDB)
+}
+
+// PutTokenTransferToBatch puts the encoded token transfer related to the given address to the given batch.
+func (dbm *databaseManager) PutTokenTransferToBatch(batch Batch, address common.Address, blockNum, logIndex uint64, transfer []byte) error {
+	return putToBatch(batch, tokenTransferIndexKey(address, blockNum, logIndex), transfer)
+}
+
+// ReadTokenTransfers retrieves at most limit encoded token transfers related to the given address,
+// starting from the position (fromBlock, fromLogIndex) up to the block toBlock.
+// The token transfers are sorted in ascending order. They are not removed on reorganizations,
+// so the caller should check if the transaction of each token transfer is still canonical.
+func (dbm *databaseManager) ReadTokenTransfers(address common.Address, fromBlock, fromLogIndex, toBlock uint64, limit int) []TokenTransferIndexEntry {
+	var entries []TokenTransferIndexEntry
+	dbm.iteratePositions(tokenTransferIndexPrefix, address, fromBlock, fromLogIndex, toBlock, limit, func(blockNum, logIndex uint64, value []byte) {
+		entries = append(entries, TokenTransferIndexEntry{BlockNumber: blockNum, LogIndex: logIndex, Transfer: common.CopyBytes(value)})
+	})
+	return entries
+}
+
+// PutTokenHoldingToBatch puts the token which the given address has ever received or sent to the given batch.
+func (dbm *databaseManager) PutTokenHoldingToBatch(batch Batch, address, token common.Address, standard []byte) error {
+	return putToBatch(batch, tokenHoldingKey(address, token), standard)
+}
+
+// ReadTokenHoldings retrieves the tokens which the given address has ever received or sent with their standards.
+func (dbm *databaseManager) ReadTokenHoldings(address common.Address) map[common.Address][]byte {
+	prefix := tokenHoldingKey(address, common.Address{})[:len(tokenHoldingPrefix)+common.AddressLength]
+
+	it := dbm.getDatabase(MiscDB).NewIterator(prefix, nil)
+	defer it.Release()
+
+	holdings := make(map[common.Address][]byte)
+	for it.Next() {
+		if key := it.Key(); len(key) == len(prefix)+common.AddressLength {
+			holdings[common.BytesToAddress(key[len(prefix):])] = common.CopyBytes(it.Value())
+		}
+	}
+	return holdings
+}
+
+// ReadTokenTransferIndexTail returns the number of the first block of the token transfer index.
+// It returns false if the token transfer index has never been written.
+func (dbm *databaseManager) ReadTokenTransferIndexTail() (uint64, bool) {
+	return dbm.readBlockNumber(tokenTransferIndexTailKey)
+}
+
+// WriteTokenTransferIndexTail stores the number of the first block of the token transfer index.
+func (dbm *databaseManager) WriteTokenTransferIndexTail(blockNum uint64) error {
+	return dbm.getDatabase(MiscDB).Put(tokenTransferIndexTailKey, common.Int64ToByteBigEndian(blockNum))
+}
+
+// ReadTokenTransferIndexHead returns the number of the last block of the token transfer index.
+// It returns false if no block has been indexed.
+func (dbm *databaseManager) ReadTokenTransferIndexHead() (uint64, bool) {
+	return dbm.readBlockNumber(tokenTransferIndexHeadKey)
+}
+
+// PutTokenTransferIndexHeadToBatch puts the number of the last block of the token transfer index
+// to the given batch, so that it is stored with the index of the block.
+func (dbm *databaseManager) PutTokenTransferIndexHeadToBatch(batch Batch, blockNum uint64) error {
+	return batch.Put(tokenTransferIndexHeadKey, common.Int64ToByteBigEndian(blockNum))
+}
+
+func putToBatch(batch Batch, key, value []byte) error {
+	if err := batch.Put(key, value); err != nil {
+		return err
+	}
+
+	if batch.ValueSize() > IdealBatchSize {
+		batch.Write()
+		batch.Reset()
+	}
+
+	return nil
+}
+
+func (dbm *databaseManager) readBlockNumber(key []byte) (uint64, bool) {
+	data, _ := dbm.getDatabase(MiscDB).Get(key)
+	if len(data) != 8 {
+		return 0, false
+	}
+	return binary.BigEndian.Uint64(data), true
+}
+
+func (dbm *databaseManager) readTxPositions(indexPrefix []byte, address common.Address, fromBlock, fromIndex, toBlock uint64, limit int) []AccountTxIndexEntry {
+	var entries []AccountTxIndexEntry
+	dbm.iteratePositions(indexPrefix, address, fromBlock, fromIndex, toBlock, limit, func(blockNum, index uint64, value []byte) {
+		entries = append(entries, AccountTxIndexEntry{BlockNumber: blockNum, Index: index, TxHash: common.BytesToHash(value)})
+	})
+	return entries
+}
+
+// iteratePositions calls fn for at most limit entries of the given address in a position index,
+// starting from the position (fromBlock, fromIndex) up to the block toBlock.
+func (dbm *databaseManager) iteratePositions(indexPrefix []byte, address common.Address, fromBlock, fromIndex, toBlock uint64, limit int, fn func(blockNum, index uint64, value []byte)) {
+	prefix := append(append([]byte{}, indexPrefix...), address.Bytes()...)
+	start := txPositionKey(indexPrefix, address, fromBlock, fromIndex)[len(prefix):]
+
+	it := dbm.getDatabase(MiscDB).NewIterator(prefix, start)
+	defer it.Release()
+
+	for count := 0; count < limit && it.Next(); {
+		key := it.Key()
+		if len(key) != len(prefix)+16 {
+			continue
+		}
+		blockNum := binary.BigEndian.Uint64(key[len(prefix):])
+		if blockNum > toBlock {
+			break
+		}
+		fn(blockNum, binary.BigEndian.Uint64(key[len(prefix)+8:]), it.Value())
+		count++
+	}
+}
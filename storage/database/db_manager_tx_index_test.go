@@ -0,0 +1,100 @@
+// Copyright 2022 The klaytn Authors
+// This file is part of the klaytn library.
+//
+// The klaytn library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The klaytn library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the klaytn library. If not, see <http://www.gnu.org/licenses/>.
+
+package database
+
+import (
+	"testing"
+
+	"github.com/klaytn/klaytn/common"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestDatabaseManager_AccountTxIndex(t *testing.T) {
+	dbm := NewMemoryDBManager()
+	defer dbm.Close()
+
+	var (
+		addr  = common.HexToAddress("0x1111")
+		other = common.HexToAddress("0x2222")
+	)
+	_, ok := dbm.ReadAccountTxIndexTail()
+	assert.False(t, ok)
+	assert.NoError(t, dbm.WriteAccountTxIndexTail(3))
+	tail, ok := dbm.ReadAccountTxIndexTail()
+	assert.True(t, ok)
+	assert.Equal(t, uint64(3), tail)
+
+	batch := dbm.NewAccountTxIndexBatch()
+	positions := [][2]uint64{{3, 0}, {3, 2}, {256, 1}, {300, 0}}
+	for i, pos := range positions {
+		assert.NoError(t, dbm.PutAccountTxIndexToBatch(batch, addr, pos[0], pos[1], common.BytesToHash([]byte{byte(i)})))
+	}
+	assert.NoError(t, dbm.PutAccountTxIndexToBatch(batch, other, 3, 1, common.Hash{}))
+	assert.NoError(t, dbm.PutAccountTxIndexHeadToBatch(batch, 300))
+	_, ok = dbm.ReadAccountTxIndexHead()
+	assert.False(t, ok)
+	assert.NoError(t, batch.Write())
+	head, ok := dbm.ReadAccountTxIndexHead()
+	assert.True(t, ok)
+	assert.Equal(t, uint64(300), head)
+
+	entries := dbm.ReadAccountTxIndex(addr, 0, 0, 1000, 10)
+	assert.Len(t, entries, len(positions))
+	for i, pos := range positions {
+		assert.Equal(t, AccountTxIndexEntry{pos[0], pos[1], common.BytesToHash([]byte{byte(i)})}, entries[i])
+	}
+
+	// Starting position is inclusive, and the block range and the limit are applied.
+	entries = dbm.ReadAccountTxIndex(addr, 3, 2, 299, 10)
+	assert.Len(t, entries, 2)
+	assert.Equal(t, uint64(256), entries[1].BlockNumber)
+
+	entries = dbm.ReadAccountTxIndex(addr, 0, 0, 1000, 1)
+	assert.Len(t, entries, 1)
+}
+
+func TestDatabaseManager_TokenTransferIndex(t *testing.T) {
+	dbm := NewMemoryDBManager()
+	defer dbm.Close()
+
+	var (
+		addr   = common.HexToAddress("0x1111")
+		tokenA = common.HexToAddress("0xaaaa")
+		tokenB = common.HexToAddress("0xbbbb")
+	)
+	_, ok := dbm.ReadTokenTransferIndexTail()
+	assert.False(t, ok)
+	assert.NoError(t, dbm.WriteTokenTransferIndexTail(5))
+	tail, ok := dbm.ReadTokenTransferIndexTail()
+	assert.True(t, ok)
+	assert.Equal(t, uint64(5), tail)
+
+	batch := dbm.NewTokenTransferIndexBatch()
+	assert.NoError(t, dbm.PutTokenTransferToBatch(batch, addr, 5, 0, []byte("first")))
+	assert.NoError(t, dbm.PutTokenTransferToBatch(batch, addr, 7, 3, []byte("second")))
+	assert.NoError(t, dbm.PutTokenHoldingToBatch(batch, addr, tokenA, []byte("KIP-7")))
+	assert.NoError(t, dbm.PutTokenHoldingToBatch(batch, addr, tokenB, []byte("KIP-17")))
+	assert.NoError(t, batch.Write())
+
+	entries := dbm.ReadTokenTransfers(addr, 0, 0, 1000, 10)
+	assert.Equal(t, []TokenTransferIndexEntry{{5, 0, []byte("first")}, {7, 3, []byte("second")}}, entries)
+	assert.Len(t, dbm.ReadTokenTransfers(addr, 5, 1, 1000, 10), 1)
+	assert.Len(t, dbm.ReadTokenTransfers(addr, 0, 0, 6, 10), 1)
+
+	assert.Equal(t, map[common.Address][]byte{tokenA: []byte("KIP-7"), tokenB: []byte("KIP-17")}, dbm.ReadTokenHoldings(addr))
+	assert.Empty(t, dbm.ReadTokenHoldings(tokenA))
+}
@@ -32,13 +32,15 @@ const (
 	BadgerDB         = "BadgerDB"
 	MemoryDB         = "MemoryDB"
 	DynamoDB         = "DynamoDBS3"
+	PebbleDB         = "PebbleDB"
+	RocksDB          = "RocksDB"
 	ShardedDB        = "ShardedDB"
 )
 
 // ToValid converts DBType to a valid one.
 // If it is unable to convert, "" is returned.
 func (db DBType) ToValid() DBType {
-	validDBType := []DBType{LevelDB, BadgerDB, MemoryDB, DynamoDB}
+	validDBType := []DBType{LevelDB, BadgerDB, MemoryDB, DynamoDB, PebbleDB, RocksDB}
 
 	for _, vdb := range validDBType {
 		if strings.ToLower(string(vdb)) == strings.ToLower(string(db)) {
@@ -79,6 +81,33 @@ type Database interface {
 	Iteratee
 }
 
+// Compacter wraps the Compact method of a database which supports the manual compaction.
+type Compacter interface {
+	// Compact flattens the underlying data store for the given key range. In essence,
+	// deleted and overwritten versions are discarded, and the data is rearranged to
+	// reduce the cost of operations needed to access them.
+	//
+	// A nil start is treated as a key before all keys in the data store; a nil limit
+	// is treated as a key after all keys in the data store.
+	Compact(start []byte, limit []byte) error
+}
+
+// DBStats is the statistics of a database reported to the operators.
+type DBStats struct {
+	Name               string   `json:"name"`
+	Type               DBType   `json:"type"`
+	Size               int64    `json:"size"`                   // Size of the data on disk in bytes
+	ReadAmplification  float64  `json:"readAmplification"`      // Number of tables which a point lookup may read
+	WriteAmplification float64  `json:"writeAmplification"`     // Bytes written by the compactions per byte flushed
+	CacheSize          int64    `json:"cacheSize"`              // Size of the block cache in use in bytes
+	CacheHitRate       *float64 `json:"cacheHitRate,omitempty"` // Nil if the database does not count the cache hits
+}
+
+// StatsReporter wraps the Stats method of a database which reports its statistics.
+type StatsReporter interface {
+	Stats() (*DBStats, error)
+}
+
 func WriteBatches(batches ...Batch) (int, error) {
 	bytes := 0
 	for _, batch := range batches {
@@ -24,7 +24,7 @@ import (
 )
 
 func TestDBType_ToValid(t *testing.T) {
-	validDBType := []DBType{LevelDB, BadgerDB, MemoryDB, DynamoDB}
+	validDBType := []DBType{LevelDB, BadgerDB, MemoryDB, DynamoDB, PebbleDB, RocksDB}
 
 	// check acceptable dbtype values
 	acceptableDBTypes := []DBType{
@@ -32,6 +32,8 @@ func TestDBType_ToValid(t *testing.T) {
 		"BadgerDB", "badgerdb", "Badgerdb", "badgerDB",
 		"MemoryDB", "memorydb", "Memorydb", "memoryDB",
 		"DynamoDBS3", "dynamodbs3", "Dynamodbs3", "DynamodbS3", "dynamodbS3",
+		"PebbleDB", "pebbledb", "Pebbledb", "pebbleDB",
+		"RocksDB", "rocksdb", "Rocksdb", "rocksDB",
 	}
 
 	for _, dbtype := range acceptableDBTypes {
@@ -42,7 +44,7 @@ func TestDBType_ToValid(t *testing.T) {
 	// check not acceptable dbtype values
 	notAcceptableDBTypes := []DBType{
 		"level", "ldb", "badger", "memory",
-		"dynamo", "dynamodb", "dynamos3", "ddb", "ShardedDB",
+		"dynamo", "dynamodb", "dynamos3", "ddb", "ShardedDB", "pebble", "rocks",
 	}
 
 	for _, dbtype := range notAcceptableDBTypes {
@@ -344,6 +344,36 @@ func (db *levelDB) LDB() *leveldb.DB {
 	return db.db
 }
 
+// Compact flattens the underlying data store for the given key range.
+func (db *levelDB) Compact(start []byte, limit []byte) error {
+	return db.db.CompactRange(util.Range{Start: start, Limit: limit})
+}
+
+// Stats returns the size, the amplifications and the block cache usage of the database.
+// LevelDB does not count the cache hits, so the cache hit rate is not reported.
+func (db *levelDB) Stats() (*DBStats, error) {
+	s := new(leveldb.DBStats)
+	if err := db.db.Stats(s); err != nil {
+		return nil, err
+	}
+	stats := &DBStats{Type: LevelDB, CacheSize: int64(s.BlockCacheSize)}
+	var written int64
+	for lv := range s.LevelSizes {
+		stats.Size += s.LevelSizes[lv]
+		written += s.LevelWrite[lv]
+		// Every table of level 0 may overlap, while only one table per level is read in the other levels
+		if lv == 0 {
+			stats.ReadAmplification += float64(s.LevelTablesCounts[lv])
+		} else if s.LevelTablesCounts[lv] > 0 {
+			stats.ReadAmplification++
+		}
+	}
+	if len(s.LevelWrite) > 0 && s.LevelWrite[0] > 0 {
+		stats.WriteAmplification = float64(written) / float64(s.LevelWrite[0])
+	}
+	return stats, nil
+}
+
 // Meter configures the database metrics collectors and
 func (db *levelDB) Meter(prefix string) {
 	db.prefix = prefix
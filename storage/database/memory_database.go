@@ -179,6 +179,18 @@ func (db *MemDB) Compact(start []byte, limit []byte) error {
 	return nil
 }
 
+// Stats returns the size of the data in the memory database.
+func (db *MemDB) Stats() (*DBStats, error) {
+	db.lock.RLock()
+	defer db.lock.RUnlock()
+
+	stats := &DBStats{Type: MemoryDB}
+	for key, value := range db.db {
+		stats.Size += int64(len(key) + len(value))
+	}
+	return stats, nil
+}
+
 // Len returns the number of entries currently present in the memory database.
 //
 // Note, this method is only used for testing (i.e. not public in general) and
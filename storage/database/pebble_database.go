@@ -0,0 +1,298 @@
+// Copyright 2022 The klaytn Authors
+// This file is part of the klaytn library.
+//
+// The klaytn library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The klaytn library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the klaytn library. If not, see <http://www.gnu.org/licenses/>.
+
+//go:build pebble
+// +build pebble
+
+package database
+
+import (
+	"bytes"
+	"sync"
+
+	"github.com/cockroachdb/pebble"
+	"github.com/cockroachdb/pebble/bloom"
+	"github.com/klaytn/klaytn/log"
+)
+
+const minPebbleDBCacheSize = 16 // MiB
+
+// pebbleDB is a Database backed by Pebble, a LevelDB-compatible key-value store whose
+// compactions do not stall the writes as LevelDB does on large databases.
+type pebbleDB struct {
+	fn string // filename for reporting
+	db *pebble.DB
+
+	quitLock sync.Mutex // Mutex protecting the database from being used after closed
+	closed   bool
+
+	logger log.Logger // Contextual logger tracking the database path
+}
+
+func getPebbleDBOptions(dbc *DBConfig) *pebble.Options {
+	cacheSize := int64(dbc.LevelDBCacheSize) * 1024 * 1024
+	opts := &pebble.Options{
+		Cache:                       pebble.NewCache(cacheSize / 2),
+		MaxOpenFiles:                dbc.OpenFilesLimit,
+		MemTableSize:                int(cacheSize / 4),
+		MemTableStopWritesThreshold: 2,
+		MaxConcurrentCompactions:    4,
+		Levels:                      make([]pebble.LevelOptions, 7),
+	}
+	for i := range opts.Levels {
+		opts.Levels[i].TargetFileSize = int64(2*1024*1024) << i
+		opts.Levels[i].FilterPolicy = bloom.FilterPolicy(10)
+	}
+	opts.EnsureDefaults()
+	return opts
+}
+
+// NewPebbleDB returns a Database backed by Pebble in the directory of the given config.
+func NewPebbleDB(dbc *DBConfig, entryType DBEntryType) (*pebbleDB, error) {
+	localLogger := logger.NewWith("path", dbc.Dir)
+
+	if dbc.LevelDBCacheSize < minPebbleDBCacheSize {
+		dbc.LevelDBCacheSize = minPebbleDBCacheSize
+	}
+	if dbc.OpenFilesLimit < minFileDescriptorsForLevelDB {
+		dbc.OpenFilesLimit = minFileDescriptorsForLevelDB
+	}
+	opts := getPebbleDBOptions(dbc)
+
+	localLogger.Info("PebbleDB configurations", "cacheSize", dbc.LevelDBCacheSize, "openFilesLimit", opts.MaxOpenFiles,
+		"memTableSize(MB)", opts.MemTableSize/1024/1024, "entryType", entryType)
+
+	db, err := pebble.Open(dbc.Dir, opts)
+	if err != nil {
+		return nil, err
+	}
+	return &pebbleDB{
+		fn:     dbc.Dir,
+		db:     db,
+		logger: localLogger,
+	}, nil
+}
+
+func (db *pebbleDB) Type() DBType {
+	return PebbleDB
+}
+
+// Path returns the path to the database directory.
+func (db *pebbleDB) Path() string {
+	return db.fn
+}
+
+// Put inserts the given key and value pair to the database.
+func (db *pebbleDB) Put(key []byte, value []byte) error {
+	return db.db.Set(key, value, pebble.NoSync)
+}
+
+// Has returns true if the corresponding value to the given key exists.
+func (db *pebbleDB) Has(key []byte) (bool, error) {
+	_, closer, err := db.db.Get(key)
+	if err == pebble.ErrNotFound {
+		return false, nil
+	} else if err != nil {
+		return false, err
+	}
+	closer.Close()
+	return true, nil
+}
+
+// Get returns the given key if it's present.
+func (db *pebbleDB) Get(key []byte) ([]byte, error) {
+	dat, closer, err := db.db.Get(key)
+	if err != nil {
+		if err == pebble.ErrNotFound {
+			return nil, dataNotFoundErr
+		}
+		return nil, err
+	}
+	ret := make([]byte, len(dat))
+	copy(ret, dat)
+	closer.Close()
+	return ret, nil
+}
+
+// Delete deletes the key from the database.
+func (db *pebbleDB) Delete(key []byte) error {
+	return db.db.Delete(key, pebble.NoSync)
+}
+
+// NewIterator creates a binary-alphabetical iterator over a subset
+// of database content with a particular key prefix, starting at a particular
+// initial key (or after, if it does not exist).
+func (db *pebbleDB) NewIterator(prefix []byte, start []byte) Iterator {
+	r := bytesPrefixRange(prefix, start)
+	iter := db.db.NewIter(&pebble.IterOptions{LowerBound: r.Start, UpperBound: r.Limit})
+	iter.First()
+	return &pebbleIterator{iter: iter, moved: true}
+}
+
+func (db *pebbleDB) Close() {
+	db.quitLock.Lock()
+	defer db.quitLock.Unlock()
+
+	if db.closed {
+		return
+	}
+	db.closed = true
+	if err := db.db.Close(); err != nil {
+		db.logger.Error("Failed to close database", "err", err)
+	} else {
+		db.logger.Info("Database closed")
+	}
+}
+
+// Compact flattens the underlying data store for the given key range.
+func (db *pebbleDB) Compact(start []byte, limit []byte) error {
+	// Pebble has no key to represent the end of the key range like nil in LevelDB, so a key
+	// larger than any hash-prefixed key is used instead.
+	if limit == nil {
+		limit = bytes.Repeat([]byte{0xff}, 32)
+	}
+	return db.db.Compact(start, limit, true)
+}
+
+// Stats returns the size, the amplifications and the block cache usage and hit rate of the database.
+func (db *pebbleDB) Stats() (*DBStats, error) {
+	m := db.db.Metrics()
+	stats := &DBStats{
+		Type:               PebbleDB,
+		Size:               int64(m.DiskSpaceUsage()),
+		ReadAmplification:  float64(m.ReadAmp()),
+		WriteAmplification: m.Total().WriteAmp(),
+		CacheSize:          m.BlockCache.Size,
+	}
+	if lookups := m.BlockCache.Hits + m.BlockCache.Misses; lookups > 0 {
+		rate := float64(m.BlockCache.Hits) / float64(lookups)
+		stats.CacheHitRate = &rate
+	}
+	return stats, nil
+}
+
+func (db *pebbleDB) Meter(prefix string) {
+	logger.Warn("pebbleDB does not support metrics!")
+}
+
+func (db *pebbleDB) NewBatch() Batch {
+	return &pebbleBatch{b: db.db.NewBatch()}
+}
+
+// pebbleBatch is a write-only batch that commits changes to its host database when Write is called.
+type pebbleBatch struct {
+	b    *pebble.Batch
+	size int
+}
+
+// Put inserts the given value into the batch for later committing.
+func (b *pebbleBatch) Put(key, value []byte) error {
+	b.b.Set(key, value, nil)
+	b.size += len(value)
+	return nil
+}
+
+// Delete inserts the a key removal into the batch for later committing.
+func (b *pebbleBatch) Delete(key []byte) error {
+	b.b.Delete(key, nil)
+	b.size++
+	return nil
+}
+
+// ValueSize retrieves the amount of data queued up for writing.
+func (b *pebbleBatch) ValueSize() int {
+	return b.size
+}
+
+// Write flushes any accumulated data to disk.
+func (b *pebbleBatch) Write() error {
+	return b.b.Commit(pebble.NoSync)
+}
+
+// Reset resets the batch for reuse.
+func (b *pebbleBatch) Reset() {
+	b.b.Reset()
+	b.size = 0
+}
+
+// Replay replays the batch contents.
+func (b *pebbleBatch) Replay(w KeyValueWriter) error {
+	reader := b.b.Reader()
+	for {
+		kind, k, v, ok := reader.Next()
+		if !ok {
+			break
+		}
+		switch kind {
+		case pebble.InternalKeyKindSet:
+			if err := w.Put(k, v); err != nil {
+				return err
+			}
+		case pebble.InternalKeyKindDelete:
+			if err := w.Delete(k); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+// pebbleIterator wraps the Pebble iterator to satisfy the Iterator interface,
+// whose Next moves to the first entry on the first call.
+type pebbleIterator struct {
+	iter     *pebble.Iterator
+	moved    bool
+	released bool
+}
+
+// Next moves the iterator to the next key/value pair. It returns whether the
+// iterator is exhausted.
+func (it *pebbleIterator) Next() bool {
+	if it.moved {
+		it.moved = false
+		return it.iter.Valid()
+	}
+	return it.iter.Next()
+}
+
+// Error returns any accumulated error. Exhausting all the key/value pairs
+// is not considered to be an error.
+func (it *pebbleIterator) Error() error {
+	return it.iter.Error()
+}
+
+// Key returns the key of the current key/value pair, or nil if done. The caller
+// should not modify the contents of the returned slice, and its contents may
+// change on the next call to Next.
+func (it *pebbleIterator) Key() []byte {
+	return it.iter.Key()
+}
+
+// Value returns the value of the current key/value pair, or nil if done. The
+// caller should not modify the contents of the returned slice, and its contents
+// may change on the next call to Next.
+func (it *pebbleIterator) Value() []byte {
+	return it.iter.Value()
+}
+
+// Release releases associated resources. Release should always succeed and can
+// be called multiple times without causing error.
+func (it *pebbleIterator) Release() {
+	if !it.released {
+		it.iter.Close()
+		it.released = true
+	}
+}
@@ -0,0 +1,50 @@
+// Copyright 2022 The klaytn Authors
+// This file is part of the klaytn library.
+//
+// The klaytn library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The klaytn library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the klaytn library. If not, see <http://www.gnu.org/licenses/>.
+
+//go:build pebble
+// +build pebble
+
+package database
+
+import (
+	"io/ioutil"
+	"os"
+	"testing"
+
+	"github.com/stretchr/testify/suite"
+)
+
+func newTestPebbleDB() (Database, func()) {
+	dirName, err := ioutil.TempDir(os.TempDir(), "klay_pebbledb_test_")
+	if err != nil {
+		panic("failed to create test file: " + err.Error())
+	}
+	db, err := NewPebbleDB(&DBConfig{Dir: dirName, DBType: PebbleDB}, MiscDB)
+	if err != nil {
+		panic("failed to create test database: " + err.Error())
+	}
+
+	return db, func() {
+		db.Close()
+		os.RemoveAll(dirName)
+	}
+}
+
+func TestPebbleDBTestSuite(t *testing.T) {
+	db, remove := newTestPebbleDB()
+	defer remove()
+	suite.Run(t, &commonDatabaseTestSuite{database: db})
+}
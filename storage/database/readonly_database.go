@@ -0,0 +1,88 @@
+// Copyright 2022 The klaytn Authors
+// This file is part of the klaytn library.
+//
+// The klaytn library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The klaytn library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the klaytn library. If not, see <http://www.gnu.org/licenses/>.
+
+package database
+
+import "errors"
+
+var errReadOnlyDatabase = errors.New("database is read-only")
+
+// readOnlyDB wraps a Database opened by a read replica, which shares the database with
+// a primary node. Calling put, delete, batch put and batch write returns errReadOnlyDatabase,
+// so that the replica never modifies the data written by the primary node.
+type readOnlyDB struct {
+	Database
+}
+
+func newReadOnlyDB(db Database) *readOnlyDB {
+	return &readOnlyDB{db}
+}
+
+func (db *readOnlyDB) Put(key []byte, value []byte) error {
+	return errReadOnlyDatabase
+}
+
+func (db *readOnlyDB) Delete(key []byte) error {
+	return errReadOnlyDatabase
+}
+
+func (db *readOnlyDB) NewBatch() Batch {
+	return &readOnlyBatch{}
+}
+
+// Compact does nothing since the database is managed by the primary node.
+func (db *readOnlyDB) Compact(start []byte, limit []byte) error {
+	return nil
+}
+
+func (db *readOnlyDB) Stats() (*DBStats, error) {
+	return statDB(db.Database)
+}
+
+// readOnlyBatch is the batch of readOnlyDB. Writing the batch returns errReadOnlyDatabase
+// if any put or delete has been requested, since the requests are not written.
+type readOnlyBatch struct {
+	requested bool
+}
+
+func (batch *readOnlyBatch) Put(key, val []byte) error {
+	batch.requested = true
+	return errReadOnlyDatabase
+}
+
+func (batch *readOnlyBatch) Delete(key []byte) error {
+	batch.requested = true
+	return errReadOnlyDatabase
+}
+
+func (batch *readOnlyBatch) Write() error {
+	if batch.requested {
+		return errReadOnlyDatabase
+	}
+	return nil
+}
+
+func (batch *readOnlyBatch) ValueSize() int {
+	return 0
+}
+
+func (batch *readOnlyBatch) Reset() {
+	batch.requested = false
+}
+
+func (batch *readOnlyBatch) Replay(w KeyValueWriter) error {
+	return nil
+}
@@ -0,0 +1,58 @@
+// Copyright 2022 The klaytn Authors
+// This file is part of the klaytn library.
+//
+// The klaytn library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The klaytn library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the klaytn library. If not, see <http://www.gnu.org/licenses/>.
+
+package database
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestReadOnlyDB_Write(t *testing.T) {
+	memDB := NewMemDB()
+	require.NoError(t, memDB.Put([]byte("key"), []byte("val")))
+
+	db := newReadOnlyDB(memDB)
+	val, err := db.Get([]byte("key"))
+	require.NoError(t, err)
+	assert.Equal(t, []byte("val"), val)
+
+	assert.Equal(t, errReadOnlyDatabase, db.Put([]byte("key"), []byte("new")))
+	assert.Equal(t, errReadOnlyDatabase, db.Delete([]byte("key")))
+
+	// Writing an empty batch writes nothing.
+	batch := db.NewBatch()
+	assert.NoError(t, batch.Write())
+
+	assert.Equal(t, errReadOnlyDatabase, batch.Put([]byte("key"), []byte("new")))
+	assert.Equal(t, errReadOnlyDatabase, batch.Delete([]byte("key")))
+	assert.Equal(t, errReadOnlyDatabase, batch.Write())
+
+	// The data written before is kept.
+	val, err = memDB.Get([]byte("key"))
+	require.NoError(t, err)
+	assert.Equal(t, []byte("val"), val)
+}
+
+func TestNewDatabase_ReadOnly(t *testing.T) {
+	for _, dbType := range []DBType{LevelDB, BadgerDB, MemoryDB, PebbleDB, RocksDB} {
+		db, err := newDatabase(&DBConfig{DBType: dbType, ReadOnly: true}, 0)
+		assert.Nil(t, db, dbType)
+		assert.EqualError(t, err, "read-only mode is not supported by "+string(dbType)+", but only by "+string(DynamoDB), dbType)
+	}
+}
@@ -0,0 +1,476 @@
+// Copyright 2022 The klaytn Authors
+// This file is part of the klaytn library.
+//
+// The klaytn library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The klaytn library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the klaytn library. If not, see <http://www.gnu.org/licenses/>.
+
+//go:build rocksdb
+// +build rocksdb
+
+package database
+
+import (
+	"fmt"
+	"path/filepath"
+	"strconv"
+	"sync"
+
+	"github.com/klaytn/klaytn/common"
+	"github.com/klaytn/klaytn/log"
+	"github.com/linxGnu/grocksdb"
+)
+
+const (
+	minRocksDBCacheSize = 8 // MiB
+
+	// rocksDBChainDir is the directory of the RocksDB instance shared by the chain data,
+	// whose entry types are stored as the column families of the instance.
+	rocksDBChainDir = "chaindata"
+
+	rocksDBDefaultCF = "default"
+	rocksDBStateCF   = "state"
+)
+
+var (
+	rocksDBInstancesMu sync.Mutex
+	rocksDBInstances   = make(map[string]*rocksDBInstance)
+)
+
+// rocksDBInstance is a RocksDB database opened in a directory, which is shared by the
+// Databases of the column families in it.
+type rocksDBInstance struct {
+	dir  string
+	db   *grocksdb.DB
+	cfs  map[string]*grocksdb.ColumnFamilyHandle
+	refs int
+}
+
+// rocksDB is a Database backed by a column family of a RocksDB instance.
+// It is built with cgo only when the rocksdb build tag is given, and requires librocksdb.
+type rocksDB struct {
+	fn   string // filename for reporting
+	inst *rocksDBInstance
+	cf   *grocksdb.ColumnFamilyHandle
+	ro   *grocksdb.ReadOptions
+	wo   *grocksdb.WriteOptions
+
+	quitLock sync.Mutex // Mutex protecting the database from being used after closed
+	closed   bool
+
+	logger log.Logger // Contextual logger tracking the database path
+}
+
+// rocksDBLocation returns the directory of the RocksDB instance and the column family for the given entry type.
+// Headers, bodies and receipts are column families of one instance so that they share the write-ahead log.
+// The state trie keeps its own instance since the state migration removes the whole directory of the old state.
+func rocksDBLocation(dbc *DBConfig, entryType DBEntryType) (string, string) {
+	if dbc.SingleDB {
+		return dbc.Dir, rocksDBDefaultCF
+	}
+	switch entryType {
+	case headerDB, BodyDB, ReceiptsDB:
+		return filepath.Join(filepath.Dir(dbc.Dir), rocksDBChainDir), dbBaseDirs[entryType]
+	case StateTrieDB, StateTrieMigrationDB:
+		return dbc.Dir, rocksDBStateCF
+	default:
+		return dbc.Dir, rocksDBDefaultCF
+	}
+}
+
+// rocksDBCacheSize returns the block cache size in MiB of the column family of the given name.
+func rocksDBCacheSize(dbc *DBConfig, name string) int {
+	size := minRocksDBCacheSize
+	if dbc.SingleDB {
+		size = dbc.RocksDBBlockCacheSize
+	} else if name == rocksDBStateCF {
+		size = dbc.RocksDBBlockCacheSize * dbConfigRatio[StateTrieDB] / 100
+	} else {
+		for _, et := range []DBEntryType{headerDB, BodyDB, ReceiptsDB} {
+			if dbBaseDirs[et] == name {
+				size = dbc.RocksDBBlockCacheSize * dbConfigRatio[et] / 100
+			}
+		}
+	}
+	if size < minRocksDBCacheSize {
+		size = minRocksDBCacheSize
+	}
+	return size
+}
+
+func getRocksDBCompactionStyle(style string) (grocksdb.CompactionStyle, error) {
+	switch style {
+	case "", "level":
+		return grocksdb.LevelCompactionStyle, nil
+	case "universal":
+		return grocksdb.UniversalCompactionStyle, nil
+	case "fifo":
+		return grocksdb.FIFOCompactionStyle, nil
+	default:
+		return 0, fmt.Errorf("unknown RocksDB compaction style %q (level, universal or fifo)", style)
+	}
+}
+
+// getRocksDBOptions returns the options of the column family of the given name.
+// The state is looked up by random hashes, so a bloom filter is used for it
+// while the chain data, read mostly by sequential keys, is compressed instead.
+func getRocksDBOptions(dbc *DBConfig, name string) (*grocksdb.Options, error) {
+	style, err := getRocksDBCompactionStyle(dbc.RocksDBCompactionStyle)
+	if err != nil {
+		return nil, err
+	}
+	cacheSize := rocksDBCacheSize(dbc, name)
+
+	bbto := grocksdb.NewDefaultBlockBasedTableOptions()
+	bbto.SetBlockCache(grocksdb.NewLRUCache(uint64(cacheSize) * 1024 * 1024))
+	bbto.SetBlockSize(16 * 1024)
+
+	opts := grocksdb.NewDefaultOptions()
+	opts.SetCreateIfMissing(true)
+	opts.SetCreateIfMissingColumnFamilies(true)
+	opts.SetMaxOpenFiles(dbc.OpenFilesLimit)
+	opts.IncreaseParallelism(4)
+	opts.SetCompactionStyle(style)
+	opts.SetWriteBufferSize(uint64(cacheSize) * 1024 * 1024 / 4)
+	if name == rocksDBStateCF {
+		bbto.SetFilterPolicy(grocksdb.NewBloomFilter(10))
+		bbto.SetCacheIndexAndFilterBlocks(true)
+		opts.SetCompression(grocksdb.NoCompression)
+	} else {
+		opts.SetCompression(grocksdb.LZ4Compression)
+	}
+	opts.SetBlockBasedTableFactory(bbto)
+	return opts, nil
+}
+
+// openRocksDBInstance opens the RocksDB instance in the given directory with the column family
+// of the given name, or returns the already opened one.
+func openRocksDBInstance(dbc *DBConfig, dir, name string) (*rocksDBInstance, *grocksdb.ColumnFamilyHandle, error) {
+	rocksDBInstancesMu.Lock()
+	defer rocksDBInstancesMu.Unlock()
+
+	inst, ok := rocksDBInstances[dir]
+	if !ok {
+		opts, err := getRocksDBOptions(dbc, rocksDBDefaultCF)
+		if err != nil {
+			return nil, nil, err
+		}
+		names, err := grocksdb.ListColumnFamilies(opts, dir)
+		if err != nil || len(names) == 0 {
+			names = []string{rocksDBDefaultCF} // a new database
+		}
+		cfOpts := make([]*grocksdb.Options, len(names))
+		for i, cfName := range names {
+			if cfOpts[i], err = getRocksDBOptions(dbc, cfName); err != nil {
+				return nil, nil, err
+			}
+		}
+		db, handles, err := grocksdb.OpenDbColumnFamilies(opts, dir, names, cfOpts)
+		if err != nil {
+			return nil, nil, err
+		}
+		inst = &rocksDBInstance{dir: dir, db: db, cfs: make(map[string]*grocksdb.ColumnFamilyHandle)}
+		for i, cfName := range names {
+			inst.cfs[cfName] = handles[i]
+		}
+		rocksDBInstances[dir] = inst
+	}
+
+	cf, ok := inst.cfs[name]
+	if !ok {
+		opts, err := getRocksDBOptions(dbc, name)
+		if err != nil {
+			return nil, nil, err
+		}
+		if cf, err = inst.db.CreateColumnFamily(opts, name); err != nil {
+			return nil, nil, err
+		}
+		inst.cfs[name] = cf
+	}
+	inst.refs++
+	return inst, cf, nil
+}
+
+// release closes the instance if no Database uses it anymore.
+func (inst *rocksDBInstance) release() {
+	rocksDBInstancesMu.Lock()
+	defer rocksDBInstancesMu.Unlock()
+
+	if inst.refs--; inst.refs > 0 {
+		return
+	}
+	for _, cf := range inst.cfs {
+		cf.Destroy()
+	}
+	inst.db.Close()
+	delete(rocksDBInstances, inst.dir)
+}
+
+// NewRocksDB returns a Database backed by a column family of the RocksDB instance for the given entry type.
+func NewRocksDB(dbc *DBConfig, entryType DBEntryType) (*rocksDB, error) {
+	dir, name := rocksDBLocation(dbc, entryType)
+	localLogger := logger.NewWith("path", dir, "columnFamily", name)
+
+	if dbc.OpenFilesLimit < minFileDescriptorsForLevelDB {
+		dbc.OpenFilesLimit = minFileDescriptorsForLevelDB
+	}
+
+	localLogger.Info("RocksDB configurations", "blockCacheSize", rocksDBCacheSize(dbc, name),
+		"compactionStyle", dbc.RocksDBCompactionStyle, "openFilesLimit", dbc.OpenFilesLimit, "entryType", entryType)
+
+	inst, cf, err := openRocksDBInstance(dbc, dir, name)
+	if err != nil {
+		return nil, err
+	}
+	return &rocksDB{
+		fn:     dbc.Dir,
+		inst:   inst,
+		cf:     cf,
+		ro:     grocksdb.NewDefaultReadOptions(),
+		wo:     grocksdb.NewDefaultWriteOptions(),
+		logger: localLogger,
+	}, nil
+}
+
+func (db *rocksDB) Type() DBType {
+	return RocksDB
+}
+
+// Path returns the path to the database directory.
+func (db *rocksDB) Path() string {
+	return db.fn
+}
+
+// Put inserts the given key and value pair to the database.
+func (db *rocksDB) Put(key []byte, value []byte) error {
+	return db.inst.db.PutCF(db.wo, db.cf, key, value)
+}
+
+// Has returns true if the corresponding value to the given key exists.
+func (db *rocksDB) Has(key []byte) (bool, error) {
+	dat, err := db.inst.db.GetCF(db.ro, db.cf, key)
+	if err != nil {
+		return false, err
+	}
+	defer dat.Free()
+	return dat.Exists(), nil
+}
+
+// Get returns the given key if it's present.
+func (db *rocksDB) Get(key []byte) ([]byte, error) {
+	dat, err := db.inst.db.GetCF(db.ro, db.cf, key)
+	if err != nil {
+		return nil, err
+	}
+	defer dat.Free()
+	if !dat.Exists() {
+		return nil, dataNotFoundErr
+	}
+	return common.CopyBytes(dat.Data()), nil
+}
+
+// Delete deletes the key from the database.
+func (db *rocksDB) Delete(key []byte) error {
+	return db.inst.db.DeleteCF(db.wo, db.cf, key)
+}
+
+// NewIterator creates a binary-alphabetical iterator over a subset
+// of database content with a particular key prefix, starting at a particular
+// initial key (or after, if it does not exist).
+func (db *rocksDB) NewIterator(prefix []byte, start []byte) Iterator {
+	r := bytesPrefixRange(prefix, start)
+	ro := grocksdb.NewDefaultReadOptions()
+	if r.Limit != nil {
+		ro.SetIterateUpperBound(r.Limit)
+	}
+	iter := db.inst.db.NewIteratorCF(ro, db.cf)
+	iter.Seek(r.Start)
+	return &rocksDBIterator{iter: iter, ro: ro, moved: true}
+}
+
+func (db *rocksDB) Close() {
+	db.quitLock.Lock()
+	defer db.quitLock.Unlock()
+
+	if db.closed {
+		return
+	}
+	db.closed = true
+	db.ro.Destroy()
+	db.wo.Destroy()
+	db.inst.release()
+	db.logger.Info("Database closed")
+}
+
+// Compact flattens the column family for the given key range.
+func (db *rocksDB) Compact(start []byte, limit []byte) error {
+	db.inst.db.CompactRangeCF(db.cf, grocksdb.Range{Start: start, Limit: limit})
+	return nil
+}
+
+// Stats returns the size, the read amplification and the block cache usage of the column family.
+// The statistics of RocksDB are not enabled, so the cache hit rate is not reported.
+func (db *rocksDB) Stats() (*DBStats, error) {
+	property := func(name string) int64 {
+		v, _ := strconv.ParseInt(db.inst.db.GetPropertyCF(name, db.cf), 10, 64)
+		return v
+	}
+	stats := &DBStats{
+		Type:      RocksDB,
+		Size:      property("rocksdb.total-sst-files-size"),
+		CacheSize: property("rocksdb.block-cache-usage"),
+	}
+	// Every file of level 0 may overlap, while only one file per level is read in the other levels
+	stats.ReadAmplification = float64(property("rocksdb.num-files-at-level0"))
+	for lv := 1; lv < 7; lv++ {
+		if property(fmt.Sprintf("rocksdb.num-files-at-level%d", lv)) > 0 {
+			stats.ReadAmplification++
+		}
+	}
+	return stats, nil
+}
+
+func (db *rocksDB) Meter(prefix string) {
+	logger.Warn("rocksDB does not support metrics!")
+}
+
+func (db *rocksDB) NewBatch() Batch {
+	return &rocksDBBatch{db: db}
+}
+
+// rocksDBOp is a write operation queued up in a rocksDBBatch.
+type rocksDBOp struct {
+	key    []byte
+	value  []byte
+	delete bool
+}
+
+// rocksDBBatch is a write-only batch that commits changes to its host database when Write is called.
+type rocksDBBatch struct {
+	db   *rocksDB
+	ops  []rocksDBOp
+	size int
+}
+
+// Put inserts the given value into the batch for later committing.
+func (b *rocksDBBatch) Put(key, value []byte) error {
+	b.ops = append(b.ops, rocksDBOp{key: common.CopyBytes(key), value: common.CopyBytes(value)})
+	b.size += len(value)
+	return nil
+}
+
+// Delete inserts the a key removal into the batch for later committing.
+func (b *rocksDBBatch) Delete(key []byte) error {
+	b.ops = append(b.ops, rocksDBOp{key: common.CopyBytes(key), delete: true})
+	b.size++
+	return nil
+}
+
+// ValueSize retrieves the amount of data queued up for writing.
+func (b *rocksDBBatch) ValueSize() int {
+	return b.size
+}
+
+// Write flushes any accumulated data to disk.
+func (b *rocksDBBatch) Write() error {
+	wb := grocksdb.NewWriteBatch()
+	defer wb.Destroy()
+
+	for _, op := range b.ops {
+		if op.delete {
+			wb.DeleteCF(b.db.cf, op.key)
+		} else {
+			wb.PutCF(b.db.cf, op.key, op.value)
+		}
+	}
+	return b.db.inst.db.Write(b.db.wo, wb)
+}
+
+// Reset resets the batch for reuse.
+func (b *rocksDBBatch) Reset() {
+	b.ops = b.ops[:0]
+	b.size = 0
+}
+
+// Replay replays the batch contents.
+func (b *rocksDBBatch) Replay(w KeyValueWriter) error {
+	for _, op := range b.ops {
+		if op.delete {
+			if err := w.Delete(op.key); err != nil {
+				return err
+			}
+		} else if err := w.Put(op.key, op.value); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// rocksDBIterator wraps the RocksDB iterator to satisfy the Iterator interface,
+// whose Next moves to the first entry on the first call.
+type rocksDBIterator struct {
+	iter     *grocksdb.Iterator
+	ro       *grocksdb.ReadOptions
+	key      []byte
+	value    []byte
+	moved    bool
+	released bool
+}
+
+// Next moves the iterator to the next key/value pair. It returns whether the
+// iterator is exhausted.
+func (it *rocksDBIterator) Next() bool {
+	if it.moved {
+		it.moved = false
+	} else {
+		it.iter.Next()
+	}
+	if !it.iter.Valid() {
+		it.key, it.value = nil, nil
+		return false
+	}
+	key, value := it.iter.Key(), it.iter.Value()
+	it.key, it.value = common.CopyBytes(key.Data()), common.CopyBytes(value.Data())
+	key.Free()
+	value.Free()
+	return true
+}
+
+// Error returns any accumulated error. Exhausting all the key/value pairs
+// is not considered to be an error.
+func (it *rocksDBIterator) Error() error {
+	return it.iter.Err()
+}
+
+// Key returns the key of the current key/value pair, or nil if done. The caller
+// should not modify the contents of the returned slice, and its contents may
+// change on the next call to Next.
+func (it *rocksDBIterator) Key() []byte {
+	return it.key
+}
+
+// Value returns the value of the current key/value pair, or nil if done. The
+// caller should not modify the contents of the returned slice, and its contents
+// may change on the next call to Next.
+func (it *rocksDBIterator) Value() []byte {
+	return it.value
+}
+
+// Release releases associated resources. Release should always succeed and can
+// be called multiple times without causing error.
+func (it *rocksDBIterator) Release() {
+	if !it.released {
+		it.iter.Close()
+		it.ro.Destroy()
+		it.released = true
+	}
+}
@@ -0,0 +1,29 @@
+// Copyright 2022 The klaytn Authors
+// This file is part of the klaytn library.
+//
+// The klaytn library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The klaytn library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the klaytn library. If not, see <http://www.gnu.org/licenses/>.
+
+//go:build !rocksdb
+// +build !rocksdb
+
+package database
+
+import "errors"
+
+var errRocksDBNotSupported = errors.New("RocksDB is not supported by this binary, build it with the rocksdb tag and build/rocksdb.go.mod")
+
+// NewRocksDB returns an error since the binary is built without RocksDB.
+func NewRocksDB(dbc *DBConfig, entryType DBEntryType) (Database, error) {
+	return nil, errRocksDBNotSupported
+}
@@ -0,0 +1,87 @@
+// Copyright 2022 The klaytn Authors
+// This file is part of the klaytn library.
+//
+// The klaytn library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The klaytn library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the klaytn library. If not, see <http://www.gnu.org/licenses/>.
+
+//go:build rocksdb
+// +build rocksdb
+
+package database
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/stretchr/testify/suite"
+)
+
+func newTestRocksDB() (Database, func()) {
+	dirName, err := ioutil.TempDir(os.TempDir(), "klay_rocksdb_test_")
+	if err != nil {
+		panic("failed to create test file: " + err.Error())
+	}
+	db, err := NewRocksDB(&DBConfig{Dir: dirName, DBType: RocksDB}, MiscDB)
+	if err != nil {
+		panic("failed to create test database: " + err.Error())
+	}
+
+	return db, func() {
+		db.Close()
+		os.RemoveAll(dirName)
+	}
+}
+
+func TestRocksDBTestSuite(t *testing.T) {
+	db, remove := newTestRocksDB()
+	defer remove()
+	suite.Run(t, &commonDatabaseTestSuite{database: db})
+}
+
+func TestRocksDBColumnFamilies(t *testing.T) {
+	dirName, err := ioutil.TempDir(os.TempDir(), "klay_rocksdb_test_")
+	require.NoError(t, err)
+	defer os.RemoveAll(dirName)
+
+	dbc := &DBConfig{Dir: dirName, DBType: RocksDB}
+	headers, err := NewRocksDB(getDBEntryConfig(dbc, headerDB, dbBaseDirs[headerDB]), headerDB)
+	require.NoError(t, err)
+	bodies, err := NewRocksDB(getDBEntryConfig(dbc, BodyDB, dbBaseDirs[BodyDB]), BodyDB)
+	require.NoError(t, err)
+
+	// The chain data shares one instance, but the column families are separated
+	assert.Equal(t, headers.inst, bodies.inst)
+	require.NoError(t, headers.Put([]byte("key"), []byte("header")))
+	ok, err := bodies.Has([]byte("key"))
+	require.NoError(t, err)
+	assert.False(t, ok)
+
+	// The instance is reopened with the existing column families
+	headers.Close()
+	bodies.Close()
+	headers, err = NewRocksDB(getDBEntryConfig(dbc, headerDB, dbBaseDirs[headerDB]), headerDB)
+	require.NoError(t, err)
+	defer headers.Close()
+	val, err := headers.Get([]byte("key"))
+	require.NoError(t, err)
+	assert.Equal(t, []byte("header"), val)
+	assert.DirExists(t, filepath.Join(dirName, rocksDBChainDir))
+
+	// Unknown compaction style is rejected
+	_, err = NewRocksDB(&DBConfig{Dir: filepath.Join(dirName, "misc"), RocksDBCompactionStyle: "none"}, MiscDB)
+	assert.Error(t, err)
+}
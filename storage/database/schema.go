@@ -72,6 +72,9 @@ var (
 	// snapshotRootKey tracks the hash of the last snapshot.
 	snapshotRootKey = []byte("SnapshotRoot")
 
+	// txIndexTailKey tracks the oldest block whose transactions have been indexed.
+	txIndexTailKey = []byte("TransactionIndexTail")
+
 	// badBlockKey tracks the list of bad blocks seen by local
 	badBlockKey = []byte("InvalidBlock")
 
@@ -117,11 +120,32 @@ var (
 	governanceHistoryKey = []byte("governanceIdxHistory")
 	governanceStateKey   = []byte("governanceState")
 
-	databaseDirPrefix  = []byte("databaseDirectory")
-	migrationStatusKey = []byte("migrationStatus")
+	databaseDirPrefix    = []byte("databaseDirectory")
+	migrationStatusKey   = []byte("migrationStatus")
+	periodicMigrationKey = []byte("periodicMigration")
 
 	stakingInfoPrefix = []byte("stakingInfo")
 
+	accountTxIndexPrefix  = []byte("accountTxIndex") // accountTxIndexPrefix + address + num (uint64 big endian) + index (uint64 big endian) -> tx hash
+	accountTxIndexTailKey = []byte("accountTxIndexTail")
+	accountTxIndexHeadKey = []byte("accountTxIndexHead")
+
+	internalTxsPrefix             = []byte("internalTxs")            // internalTxsPrefix + tx hash -> internal transactions
+	internalTxAccountIndexPrefix  = []byte("internalTxAccountIndex") // internalTxAccountIndexPrefix + address + num (uint64 big endian) + index (uint64 big endian) -> tx hash
+	internalTxAccountIndexTailKey = []byte("internalTxAccountIndexTail")
+	internalTxAccountIndexHeadKey = []byte("internalTxAccountIndexHead")
+
+	tokenTransferIndexPrefix  = []byte("tokenTransferIndex") // tokenTransferIndexPrefix + address + num (uint64 big endian) + log index (uint64 big endian) -> token transfer
+	tokenHoldingPrefix        = []byte("tokenHolding")       // tokenHoldingPrefix + address + token address -> token standard
+	tokenTransferIndexTailKey = []byte("tokenTransferIndexTail")
+	tokenTransferIndexHeadKey = []byte("tokenTransferIndexHead")
+
+	feeStatsPrefix = []byte("feeStats") // feeStatsPrefix + num (uint64 little endian) -> fee statistics of the block
+
+	stateHistoryPrefix             = []byte("stateHistory")             // stateHistoryPrefix + num (uint64 big endian) + hash -> reverse state diff of the block
+	accountStateHistoryIndexPrefix = []byte("accountStateHistoryIndex") // accountStateHistoryIndexPrefix + address + num (uint64 big endian) + hash -> empty
+	stateHistoryTailKey            = []byte("stateHistoryTail")
+
 	chaindatafetcherCheckpointKey = []byte("chaindatafetcherCheckpoint")
 )
 
@@ -253,6 +277,52 @@ func BloomBitsKey(bit uint, section uint64, hash common.Hash) []byte {
 	return key
 }
 
+// accountTxIndexKey = accountTxIndexPrefix + address + num (uint64 big endian) + index (uint64 big endian)
+func accountTxIndexKey(address common.Address, number, index uint64) []byte {
+	return txPositionKey(accountTxIndexPrefix, address, number, index)
+}
+
+// internalTxAccountIndexKey = internalTxAccountIndexPrefix + address + num (uint64 big endian) + index (uint64 big endian)
+func internalTxAccountIndexKey(address common.Address, number, index uint64) []byte {
+	return txPositionKey(internalTxAccountIndexPrefix, address, number, index)
+}
+
+// tokenTransferIndexKey = tokenTransferIndexPrefix + address + num (uint64 big endian) + log index (uint64 big endian)
+func tokenTransferIndexKey(address common.Address, number, logIndex uint64) []byte {
+	return txPositionKey(tokenTransferIndexPrefix, address, number, logIndex)
+}
+
+// tokenHoldingKey = tokenHoldingPrefix + address + token address
+func tokenHoldingKey(address, token common.Address) []byte {
+	return append(append(append([]byte{}, tokenHoldingPrefix...), address.Bytes()...), token.Bytes()...)
+}
+
+// stateHistoryKey = stateHistoryPrefix + num (uint64 big endian) + hash
+func stateHistoryKey(number uint64, hash common.Hash) []byte {
+	return append(append(append([]byte{}, stateHistoryPrefix...), common.Int64ToByteBigEndian(number)...), hash.Bytes()...)
+}
+
+// accountStateHistoryIndexKey = accountStateHistoryIndexPrefix + address + num (uint64 big endian) + hash
+func accountStateHistoryIndexKey(address common.Address, number uint64, hash common.Hash) []byte {
+	key := make([]byte, 0, len(accountStateHistoryIndexPrefix)+common.AddressLength+8+common.HashLength)
+	key = append(append(key, accountStateHistoryIndexPrefix...), address.Bytes()...)
+	return append(append(key, common.Int64ToByteBigEndian(number)...), hash.Bytes()...)
+}
+
+func internalTxsKey(txHash common.Hash) []byte {
+	return append(internalTxsPrefix, txHash.Bytes()...)
+}
+
+func txPositionKey(prefix []byte, address common.Address, number, index uint64) []byte {
+	key := make([]byte, 0, len(prefix)+common.AddressLength+16)
+	key = append(append(append(key, prefix...), address.Bytes()...), make([]byte, 16)...)
+
+	binary.BigEndian.PutUint64(key[len(key)-16:], number)
+	binary.BigEndian.PutUint64(key[len(key)-8:], index)
+
+	return key
+}
+
 func makeKey(prefix []byte, num uint64) []byte {
 	byteKey := common.Int64ToByteLittleEndian(num)
 	return append(prefix, byteKey...)
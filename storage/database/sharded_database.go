@@ -162,6 +162,37 @@ func (db *shardedDB) Close() {
 	}
 }
 
+// Compact compacts the given key range of all the shards.
+func (db *shardedDB) Compact(start []byte, limit []byte) error {
+	for _, shard := range db.shards {
+		if err := compactDB(shard, start, limit); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// Stats returns the total size and cache usage, and the largest amplifications of the shards.
+func (db *shardedDB) Stats() (*DBStats, error) {
+	stats := &DBStats{}
+	for _, shard := range db.shards {
+		s, err := statDB(shard)
+		if err != nil {
+			return nil, err
+		}
+		stats.Type = s.Type
+		stats.Size += s.Size
+		stats.CacheSize += s.CacheSize
+		if s.ReadAmplification > stats.ReadAmplification {
+			stats.ReadAmplification = s.ReadAmplification
+		}
+		if s.WriteAmplification > stats.WriteAmplification {
+			stats.WriteAmplification = s.WriteAmplification
+		}
+	}
+	return stats, nil
+}
+
 // Not enough size of channel slows down the iterator
 const shardedDBCombineChanSize = 1024 // Size of resultCh
 const shardedDBSubChannelSize = 128   // Size of each sub-channel of resultChs
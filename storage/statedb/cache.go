@@ -31,6 +31,7 @@ type TrieNodeCacheConfig struct {
 	CacheType                 TrieNodeCacheType
 	NumFetcherPrefetchWorker  int           // Number of workers used to prefetch a block when fetcher works
 	UseSnapshotForPrefetch    bool          // Enable snapshot functionality while prefetching
+	PrefetchBlockAccounts     bool          // Warm the accounts listed in the transactions of the next block while importing a block
 	LocalCacheSizeMiB         int           // Memory allowance (MiB) to use for caching trie nodes in fast cache
 	FastCacheFileDir          string        // Directory where the persistent fastcache data is stored
 	FastCacheSavePeriod       time.Duration // Period of saving in memory trie cache to file if fastcache is used
@@ -58,6 +59,11 @@ type TrieNodeCache interface {
 	Close() error
 }
 
+// resizableCache is a TrieNodeCache whose size can be changed at runtime.
+type resizableCache interface {
+	Resize(sizeMiB int) error
+}
+
 type BlockPubSub interface {
 	PublishBlock(msg string) error
 	SubscribeBlockCh() <-chan *redis.Message
@@ -74,6 +80,7 @@ const (
 var (
 	errNotSupportedCacheType  = errors.New("not supported stateDB TrieNodeCache type")
 	errNilTrieNodeCacheConfig = errors.New("TrieNodeCacheConfig is nil")
+	errNotResizableCache      = errors.New("the trie node cache is not resizable")
 )
 
 func (cacheType TrieNodeCacheType) ToValid() TrieNodeCacheType {
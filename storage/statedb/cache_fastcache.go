@@ -17,6 +17,8 @@
 package statedb
 
 import (
+	"errors"
+	"sync/atomic"
 	"time"
 
 	"github.com/VictoriaMetrics/fastcache"
@@ -26,6 +28,7 @@ import (
 
 var (
 	// metrics
+	memcacheFastHits                   = metrics.NewRegisteredGauge("trie/memcache/fast/hits", nil)
 	memcacheFastMisses                 = metrics.NewRegisteredGauge("trie/memcache/fast/misses", nil)
 	memcacheFastCollisions             = metrics.NewRegisteredGauge("trie/memcache/fast/collisions", nil)
 	memcacheFastCorruptions            = metrics.NewRegisteredGauge("trie/memcache/fast/corruptions", nil)
@@ -39,8 +42,21 @@ var (
 	memcacheFastInvalidValueHashErrors = metrics.NewRegisteredGauge("trie/memcache/fast/error/invalid/hash", nil)
 )
 
+var errInvalidCacheSize = errors.New("the size of the trie node cache should be greater than 0")
+
+// FastCache is a local trie node cache whose size can be changed at runtime.
 type FastCache struct {
-	fast *fastcache.Cache
+	fast atomic.Value // *fastcache.Cache, replaced when the cache is resized
+}
+
+func newFastCacheWith(fast *fastcache.Cache) *FastCache {
+	fc := &FastCache{}
+	fc.fast.Store(fast)
+	return fc
+}
+
+func (cache *FastCache) cache() *fastcache.Cache {
+	return cache.fast.Load().(*fastcache.Cache)
 }
 
 // newFastCache creates a FastCache with given cache size.
@@ -59,7 +75,7 @@ func newFastCache(config *TrieNodeCacheConfig) TrieNodeCache {
 		"MaxMiB", config.LocalCacheSizeMiB, "FilePath", config.FastCacheFileDir)
 
 	start := time.Now()
-	fc := &FastCache{fast: fastcache.LoadFromFileOrNew(config.FastCacheFileDir, config.LocalCacheSizeMiB*int(units.MiB))}
+	fc := newFastCacheWith(fastcache.LoadFromFileOrNew(config.FastCacheFileDir, config.LocalCacheSizeMiB*int(units.MiB)))
 	stats := fc.UpdateStats().(fastcache.Stats)
 
 	logger.Info("Initialized local trie node cache (fastCache)",
@@ -69,21 +85,22 @@ func newFastCache(config *TrieNodeCacheConfig) TrieNodeCache {
 }
 
 func (cache *FastCache) Get(k []byte) []byte {
-	return cache.fast.Get(nil, k)
+	return cache.cache().Get(nil, k)
 }
 
 func (cache *FastCache) Set(k, v []byte) {
-	cache.fast.Set(k, v)
+	cache.cache().Set(k, v)
 }
 
 func (cache *FastCache) Has(k []byte) ([]byte, bool) {
-	return cache.fast.HasGet(nil, k)
+	return cache.cache().HasGet(nil, k)
 }
 
 func (cache *FastCache) UpdateStats() interface{} {
 	var stats fastcache.Stats
-	cache.fast.UpdateStats(&stats)
+	cache.cache().UpdateStats(&stats)
 
+	memcacheFastHits.Update(int64(stats.GetCalls - stats.Misses))
 	memcacheFastMisses.Update(int64(stats.Misses))
 	memcacheFastCollisions.Update(int64(stats.Collisions))
 	memcacheFastCorruptions.Update(int64(stats.Corruptions))
@@ -100,7 +117,21 @@ func (cache *FastCache) UpdateStats() interface{} {
 }
 
 func (cache *FastCache) SaveToFile(filePath string, concurrency int) error {
-	return cache.fast.SaveToFileConcurrent(filePath, concurrency)
+	return cache.cache().SaveToFileConcurrent(filePath, concurrency)
+}
+
+// Resize replaces the cache with an empty cache of the given size in MiB.
+// The cached nodes are dropped, since fastcache can neither be resized nor iterated.
+func (cache *FastCache) Resize(sizeMiB int) error {
+	if sizeMiB <= 0 {
+		return errInvalidCacheSize
+	}
+	old := cache.cache()
+	cache.fast.Store(fastcache.New(sizeMiB * int(units.MiB)))
+	old.Reset()
+
+	logger.Info("Resized local trie node cache (fastCache)", "MaxMiB", sizeMiB)
+	return nil
 }
 
 func (cache *FastCache) Close() error {
@@ -16,6 +16,12 @@
 
 package statedb
 
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
 func getTestFastCacheConfig() *TrieNodeCacheConfig {
 	return &TrieNodeCacheConfig{
 		CacheType:           CacheTypeLocal,
@@ -24,3 +30,25 @@ func getTestFastCacheConfig() *TrieNodeCacheConfig {
 		FastCacheSavePeriod: 0,
 	}
 }
+
+func TestFastCache_Resize(t *testing.T) {
+	config := getTestFastCacheConfig()
+	config.FastCacheFileDir = ""
+	db := NewDatabaseWithNewCache(nil, config)
+
+	db.TrieNodeCache().Set([]byte("key"), []byte("value"))
+	assert.Equal(t, []byte("value"), db.TrieNodeCache().Get([]byte("key")))
+
+	// The cache is replaced by an empty cache of the new size
+	assert.NoError(t, db.ResizeTrieNodeCache(200))
+	assert.Equal(t, 200, db.GetTrieNodeCacheConfig().LocalCacheSizeMiB)
+	assert.Nil(t, db.TrieNodeCache().Get([]byte("key")))
+	db.TrieNodeCache().Set([]byte("key"), []byte("value"))
+	assert.Equal(t, []byte("value"), db.TrieNodeCache().Get([]byte("key")))
+
+	assert.Equal(t, errInvalidCacheSize, db.ResizeTrieNodeCache(0))
+
+	// The disabled cache cannot be resized
+	config.LocalCacheSizeMiB = 0
+	assert.Equal(t, errResizingDisabledTrieNodeCache, NewDatabaseWithNewCache(nil, config).ResizeTrieNodeCache(100))
+}
@@ -89,6 +89,15 @@ func (cache *HybridCache) SaveToFile(filePath string, concurrency int) error {
 	return nil
 }
 
+// Resize changes the size of the local cache.
+func (cache *HybridCache) Resize(sizeMiB int) error {
+	local, ok := cache.local.(resizableCache)
+	if !ok {
+		return errNotResizableCache
+	}
+	return local.Resize(sizeMiB)
+}
+
 func (cache *HybridCache) PublishBlock(msg string) error {
 	return cache.remote.PublishBlock(msg)
 }
@@ -1128,8 +1128,28 @@ func (db *Database) UpdateMetricNodes() {
 var (
 	errDisabledTrieNodeCache         = errors.New("trie node cache is disabled, nothing to save to file")
 	errSavingTrieNodeCacheInProgress = errors.New("saving trie node cache has been triggered already")
+	errResizingDisabledTrieNodeCache = errors.New("trie node cache is disabled, restart the node with the cache size to enable it")
 )
 
+// ResizeTrieNodeCache changes the size of the local trie node cache in MiB at runtime.
+// The cached nodes are dropped, and the cache cannot be enabled if it was disabled at start.
+func (db *Database) ResizeTrieNodeCache(sizeMiB int) error {
+	if db.trieNodeCache == nil {
+		return errResizingDisabledTrieNodeCache
+	}
+	cache, ok := db.trieNodeCache.(resizableCache)
+	if !ok {
+		return errNotResizableCache
+	}
+	if err := cache.Resize(sizeMiB); err != nil {
+		return err
+	}
+	if db.trieNodeCacheConfig != nil {
+		db.trieNodeCacheConfig.LocalCacheSizeMiB = sizeMiB
+	}
+	return nil
+}
+
 func (db *Database) CanSaveTrieNodeCacheToFile() error {
 	if db.trieNodeCache == nil {
 		return errDisabledTrieNodeCache
@@ -102,7 +102,6 @@ func (t *Trie) Prove(key []byte, fromLevel uint, proofDB ProofDBWriter) error {
 	return nil
 }
 
-// NOTE-Klaytn-RemoveLater Below Prove is only used in tests, not in core codes.
 // Prove constructs a merkle proof for key. The result contains all encoded nodes
 // on the path to the value at key. The value itself is also included in the last
 // node and can be retrieved by verifying the proof.
@@ -110,7 +109,7 @@ func (t *Trie) Prove(key []byte, fromLevel uint, proofDB ProofDBWriter) error {
 // If the trie does not contain a value for key, the returned proof contains all
 // nodes of the longest existing prefix of the key (at least the root node), ending
 // with the node that proves the absence of the key.
-func (t *SecureTrie) Prove(key []byte, fromLevel uint, proofDB database.DBManager) error {
+func (t *SecureTrie) Prove(key []byte, fromLevel uint, proofDB ProofDBWriter) error {
 	return t.trie.Prove(key, fromLevel, proofDB)
 }
 
@@ -0,0 +1,200 @@
+// Copyright 2022 The klaytn Authors
+// This file is part of the klaytn library.
+//
+// The klaytn library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The klaytn library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the klaytn library. If not, see <http://www.gnu.org/licenses/>.
+
+package work
+
+import (
+	"errors"
+	"sync"
+
+	"github.com/klaytn/klaytn/blockchain/types"
+	"github.com/klaytn/klaytn/common"
+	"github.com/klaytn/klaytn/crypto"
+	"github.com/rcrowley/go-metrics"
+)
+
+const (
+	// maxBundles is the maximum number of the bundles waiting for inclusion.
+	maxBundles = 256
+	// maxBundleTxs is the maximum number of the transactions in a bundle.
+	maxBundleTxs = 64
+	// DefaultBundleLifetime is the number of blocks a bundle waits for inclusion if its maximum block number is not given.
+	DefaultBundleLifetime = 25
+)
+
+var (
+	ErrEmptyBundle         = errors.New("bundle has no transaction")
+	ErrTooManyBundleTxs    = errors.New("bundle has too many transactions")
+	ErrTooManyBundles      = errors.New("too many bundles are waiting for inclusion")
+	ErrBundleExpired       = errors.New("bundle is expired")
+	ErrBundleAlreadyExists = errors.New("bundle already exists")
+	ErrBundleNotProposer   = errors.New("bundles are accepted only by consensus nodes")
+
+	bundleIncludedCounter = metrics.NewRegisteredCounter("miner/bundle/included", nil)
+	bundleFailedCounter   = metrics.NewRegisteredCounter("miner/bundle/failed", nil)
+)
+
+// Bundle is a list of transactions which must be included contiguously at the top of a block, or not at all.
+// It waits for inclusion until the block of MaxBlockNumber.
+type Bundle struct {
+	Txs            types.Transactions
+	MaxBlockNumber uint64
+}
+
+// Hash returns the hash of the bundle, which is the hash of the transaction hashes in order.
+func (b *Bundle) Hash() common.Hash {
+	hashes := make([][]byte, len(b.Txs))
+	for i, tx := range b.Txs {
+		hashes[i] = tx.Hash().Bytes()
+	}
+	return crypto.Keccak256Hash(hashes...)
+}
+
+// bundlePool keeps the bundles submitted to the proposer in the order of the submission
+// until they are included in a block or expired.
+type bundlePool struct {
+	mu      sync.Mutex
+	bundles []*Bundle
+}
+
+// add appends the bundle to be included in the blocks after the given current block.
+func (p *bundlePool) add(bundle *Bundle, current uint64) error {
+	if len(bundle.Txs) == 0 {
+		return ErrEmptyBundle
+	}
+	if len(bundle.Txs) > maxBundleTxs {
+		return ErrTooManyBundleTxs
+	}
+	if bundle.MaxBlockNumber == 0 {
+		bundle.MaxBlockNumber = current + DefaultBundleLifetime
+	}
+	if bundle.MaxBlockNumber <= current {
+		return ErrBundleExpired
+	}
+
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	if len(p.bundles) >= maxBundles {
+		return ErrTooManyBundles
+	}
+	hash := bundle.Hash()
+	for _, b := range p.bundles {
+		if b.Hash() == hash {
+			return ErrBundleAlreadyExists
+		}
+	}
+	p.bundles = append(p.bundles, bundle)
+	return nil
+}
+
+// remove deletes the bundle of the given hash, returning whether the bundle was found.
+func (p *bundlePool) remove(hash common.Hash) bool {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	for i, b := range p.bundles {
+		if b.Hash() == hash {
+			p.bundles = append(p.bundles[:i:i], p.bundles[i+1:]...)
+			return true
+		}
+	}
+	return false
+}
+
+// pending returns the bundles waiting for inclusion.
+func (p *bundlePool) pending() []*Bundle {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	return append([]*Bundle(nil), p.bundles...)
+}
+
+// prune deletes the bundles included in the new head block, and the bundles which cannot be included anymore.
+func (p *bundlePool) prune(head *types.Block) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	bundles := p.bundles[:0]
+	for _, b := range p.bundles {
+		if head.Transaction(b.Txs[0].Hash()) != nil {
+			bundleIncludedCounter.Inc(1)
+			continue
+		}
+		if b.MaxBlockNumber <= head.NumberU64() {
+			continue
+		}
+		bundles = append(bundles, b)
+	}
+	for i := len(bundles); i < len(p.bundles); i++ {
+		p.bundles[i] = nil
+	}
+	p.bundles = bundles
+}
+
+// commitBundles applies the bundles at the top of the block. A bundle is applied only if all of its
+// transactions are executed successfully; otherwise, the state and the block are rolled back to the state
+// before the bundle, and the bundle is left for the next blocks. The bundles are applied under the time
+// limit of the block, and no more bundles are applied once the limit is reached.
+func (env *Task) commitBundles(bundles []*Bundle, bc BlockChain, rewardbase common.Address, timer *txTimer) []*types.Log {
+	var coalescedLogs []*types.Log
+	vmConfig := timer.vmConfig(bc)
+
+	for _, bundle := range bundles {
+		if timer.timedOut() {
+			logger.Warn("Bundles are left due to time limit", "number", env.header.Number)
+			timeLimitReachedCounter.Inc(1)
+			break
+		}
+		if bundle.MaxBlockNumber < env.header.Number.Uint64() {
+			continue
+		}
+		var (
+			snap    = env.state.Snapshot()
+			gasUsed = env.header.GasUsed
+			ntxs    = len(env.txs)
+			logs    []*types.Log
+			failed  bool
+		)
+		for _, tx := range bundle.Txs {
+			if timer.timedOut() {
+				// The bundle cannot be included contiguously within the time limit
+				failed = true
+				break
+			}
+			env.state.Prepare(tx.Hash(), common.Hash{}, env.tcount+len(env.txs)-ntxs)
+			err, txLogs := env.commitTransaction(tx, bc, rewardbase, vmConfig)
+			if err != nil || env.receipts[len(env.receipts)-1].Status != types.ReceiptStatusSuccessful {
+				logger.Trace("Bundle failed", "bundle", bundle.Hash(), "tx", tx.Hash(), "err", err)
+				failed = true
+				break
+			}
+			logs = append(logs, txLogs...)
+		}
+		if failed {
+			bundleFailedCounter.Inc(1)
+			env.state.RevertToSnapshot(snap)
+			env.header.GasUsed = gasUsed
+			env.txs = env.txs[:ntxs]
+			env.receipts = env.receipts[:ntxs]
+			env.internalTxTraces = env.internalTxTraces[:ntxs]
+			continue
+		}
+		env.tcount += len(bundle.Txs)
+		coalescedLogs = append(coalescedLogs, logs...)
+	}
+	return coalescedLogs
+}
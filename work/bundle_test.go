@@ -0,0 +1,145 @@
+// Copyright 2022 The klaytn Authors
+// This file is part of the klaytn library.
+//
+// The klaytn library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The klaytn library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the klaytn library. If not, see <http://www.gnu.org/licenses/>.
+
+package work
+
+import (
+	"math/big"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/klaytn/klaytn/blockchain/state"
+	"github.com/klaytn/klaytn/blockchain/types"
+	"github.com/klaytn/klaytn/blockchain/vm"
+	"github.com/klaytn/klaytn/common"
+	"github.com/klaytn/klaytn/params"
+	"github.com/klaytn/klaytn/storage/database"
+	"github.com/klaytn/klaytn/work/mocks"
+	"github.com/stretchr/testify/assert"
+)
+
+func newBundleTx(nonce uint64) *types.Transaction {
+	return types.NewTransaction(nonce, common.HexToAddress("0x1111"), big.NewInt(1), 21000, big.NewInt(25), nil)
+}
+
+func TestBundlePool(t *testing.T) {
+	var pool bundlePool
+
+	assert.Equal(t, ErrEmptyBundle, pool.add(&Bundle{}, 10))
+	assert.Equal(t, ErrTooManyBundleTxs, pool.add(&Bundle{Txs: make(types.Transactions, maxBundleTxs+1)}, 10))
+	assert.Equal(t, ErrBundleExpired, pool.add(&Bundle{Txs: types.Transactions{newBundleTx(0)}, MaxBlockNumber: 10}, 10))
+
+	// The maximum block number is set by default
+	b1 := &Bundle{Txs: types.Transactions{newBundleTx(0), newBundleTx(1)}}
+	assert.NoError(t, pool.add(b1, 10))
+	assert.Equal(t, uint64(10+DefaultBundleLifetime), b1.MaxBlockNumber)
+	assert.Equal(t, ErrBundleAlreadyExists, pool.add(&Bundle{Txs: types.Transactions{newBundleTx(0), newBundleTx(1)}}, 10))
+
+	b2 := &Bundle{Txs: types.Transactions{newBundleTx(2)}, MaxBlockNumber: 11}
+	b3 := &Bundle{Txs: types.Transactions{newBundleTx(3)}, MaxBlockNumber: 12}
+	assert.NoError(t, pool.add(b2, 10))
+	assert.NoError(t, pool.add(b3, 10))
+	assert.Equal(t, []*Bundle{b1, b2, b3}, pool.pending())
+
+	// The included bundle and the expired bundle are pruned
+	head := types.NewBlock(&types.Header{Number: big.NewInt(11)}, types.Transactions{b1.Txs[0], b1.Txs[1]}, nil)
+	pool.prune(head)
+	assert.Equal(t, []*Bundle{b3}, pool.pending())
+
+	assert.True(t, pool.remove(b3.Hash()))
+	assert.False(t, pool.remove(b3.Hash()))
+	assert.Empty(t, pool.pending())
+}
+
+func TestCommitBundles(t *testing.T) {
+	mockCtrl := gomock.NewController(t)
+	defer mockCtrl.Finish()
+	bc := mocks.NewMockBlockChain(mockCtrl)
+
+	failing := newBundleTx(3)
+	bc.EXPECT().IsInternalTxTracingEnabled().Return(true).AnyTimes()
+	bc.EXPECT().ApplyTransaction(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).DoAndReturn(
+		func(config *params.ChainConfig, author *common.Address, statedb *state.StateDB, header *types.Header, tx *types.Transaction, usedGas *uint64, vmConfig *vm.Config) (*types.Receipt, *vm.InternalTxTrace, error) {
+			*usedGas += tx.Gas()
+			receipt := &types.Receipt{Status: types.ReceiptStatusSuccessful, GasUsed: tx.Gas()}
+			if tx == failing {
+				receipt.Status = types.ReceiptStatusFailed
+			}
+			assert.True(t, vmConfig.EnableInternalTxTracing)
+			return receipt, &vm.InternalTxTrace{Type: "CALL", To: tx.To()}, nil
+		}).Times(5)
+
+	statedb, _ := state.New(common.Hash{}, state.NewDatabase(database.NewMemoryDBManager()), nil)
+	env := NewTask(params.TestChainConfig, types.LatestSignerForChainID(params.TestChainConfig.ChainID), statedb, &types.Header{Number: big.NewInt(10)})
+
+	bundles := []*Bundle{
+		{Txs: types.Transactions{newBundleTx(0), newBundleTx(1)}, MaxBlockNumber: 10},
+		{Txs: types.Transactions{newBundleTx(2), failing}, MaxBlockNumber: 10},
+		{Txs: types.Transactions{newBundleTx(4)}, MaxBlockNumber: 9}, // expired
+		{Txs: types.Transactions{newBundleTx(5)}, MaxBlockNumber: 10},
+	}
+	timer := env.startTxTimer(time.Minute)
+	defer timer.stop()
+	env.commitBundles(bundles, bc, common.Address{}, timer)
+
+	assert.Equal(t, []*types.Transaction{bundles[0].Txs[0], bundles[0].Txs[1], bundles[3].Txs[0]}, env.Transactions())
+	assert.Len(t, env.Receipts(), 3)
+	// The traces of the failed bundle are dropped with its receipts
+	if assert.Len(t, env.internalTxTraces, 3) {
+		for i, tx := range env.Transactions() {
+			assert.Equal(t, tx.To(), env.internalTxTraces[i].To)
+		}
+	}
+	assert.Equal(t, 3, env.tcount)
+	assert.Equal(t, uint64(3*21000), env.header.GasUsed)
+}
+
+func TestCommitBundles_TimeLimit(t *testing.T) {
+	mockCtrl := gomock.NewController(t)
+	defer mockCtrl.Finish()
+	bc := mocks.NewMockBlockChain(mockCtrl)
+
+	slow := newBundleTx(1)
+	bc.EXPECT().IsInternalTxTracingEnabled().Return(false).AnyTimes()
+	bc.EXPECT().ApplyTransaction(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).DoAndReturn(
+		func(config *params.ChainConfig, author *common.Address, statedb *state.StateDB, header *types.Header, tx *types.Transaction, usedGas *uint64, vmConfig *vm.Config) (*types.Receipt, *vm.InternalTxTrace, error) {
+			if tx == slow {
+				time.Sleep(100 * time.Millisecond)
+			}
+			*usedGas += tx.Gas()
+			return &types.Receipt{Status: types.ReceiptStatusSuccessful, GasUsed: tx.Gas()}, nil, nil
+		}).Times(2)
+
+	statedb, _ := state.New(common.Hash{}, state.NewDatabase(database.NewMemoryDBManager()), nil)
+	env := NewTask(params.TestChainConfig, types.LatestSignerForChainID(params.TestChainConfig.ChainID), statedb, &types.Header{Number: big.NewInt(10)})
+
+	bundles := []*Bundle{
+		{Txs: types.Transactions{newBundleTx(0)}, MaxBlockNumber: 10},
+		{Txs: types.Transactions{slow, newBundleTx(2)}, MaxBlockNumber: 10}, // runs past the time limit
+		{Txs: types.Transactions{newBundleTx(3)}, MaxBlockNumber: 10},
+	}
+	timer := env.startTxTimer(50 * time.Millisecond)
+	defer timer.stop()
+	env.commitBundles(bundles, bc, common.Address{}, timer)
+
+	// The bundle running past the time limit is rolled back, and no more bundles are applied
+	assert.True(t, timer.timedOut())
+	assert.Equal(t, []*types.Transaction{bundles[0].Txs[0]}, env.Transactions())
+	assert.Len(t, env.Receipts(), 1)
+	assert.Equal(t, 1, env.tcount)
+	assert.Equal(t, uint64(21000), env.header.GasUsed)
+}
@@ -13,6 +13,7 @@ import (
 	blockchain "github.com/klaytn/klaytn/blockchain"
 	state "github.com/klaytn/klaytn/blockchain/state"
 	types "github.com/klaytn/klaytn/blockchain/types"
+	account "github.com/klaytn/klaytn/blockchain/types/account"
 	vm "github.com/klaytn/klaytn/blockchain/vm"
 	common "github.com/klaytn/klaytn/common"
 	consensus "github.com/klaytn/klaytn/consensus"
@@ -551,6 +552,36 @@ func (mr *MockBlockChainMockRecorder) HasHeader(arg0, arg1 interface{}) *gomock.
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "HasHeader", reflect.TypeOf((*MockBlockChain)(nil).HasHeader), arg0, arg1)
 }
 
+// HistoricalAccount mocks base method.
+func (m *MockBlockChain) HistoricalAccount(arg0 common.Address, arg1 uint64) (account.Account, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "HistoricalAccount", arg0, arg1)
+	ret0, _ := ret[0].(account.Account)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// HistoricalAccount indicates an expected call of HistoricalAccount.
+func (mr *MockBlockChainMockRecorder) HistoricalAccount(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "HistoricalAccount", reflect.TypeOf((*MockBlockChain)(nil).HistoricalAccount), arg0, arg1)
+}
+
+// HistoricalStorage mocks base method.
+func (m *MockBlockChain) HistoricalStorage(arg0 common.Address, arg1 common.Hash, arg2 uint64) (common.Hash, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "HistoricalStorage", arg0, arg1, arg2)
+	ret0, _ := ret[0].(common.Hash)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// HistoricalStorage indicates an expected call of HistoricalStorage.
+func (mr *MockBlockChainMockRecorder) HistoricalStorage(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "HistoricalStorage", reflect.TypeOf((*MockBlockChain)(nil).HistoricalStorage), arg0, arg1, arg2)
+}
+
 // InsertChain mocks base method.
 func (m *MockBlockChain) InsertChain(arg0 types.Blocks) (int, error) {
 	m.ctrl.T.Helper()
@@ -610,6 +641,34 @@ func (mr *MockBlockChainMockRecorder) IsParallelDBWrite() *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "IsParallelDBWrite", reflect.TypeOf((*MockBlockChain)(nil).IsParallelDBWrite))
 }
 
+// IsInternalTxTracingEnabled mocks base method.
+func (m *MockBlockChain) IsInternalTxTracingEnabled() bool {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "IsInternalTxTracingEnabled")
+	ret0, _ := ret[0].(bool)
+	return ret0
+}
+
+// IsInternalTxTracingEnabled indicates an expected call of IsInternalTxTracingEnabled.
+func (mr *MockBlockChainMockRecorder) IsInternalTxTracingEnabled() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "IsInternalTxTracingEnabled", reflect.TypeOf((*MockBlockChain)(nil).IsInternalTxTracingEnabled))
+}
+
+// TxIndexDone mocks base method.
+func (m *MockBlockChain) TxIndexDone() bool {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "TxIndexDone")
+	ret0, _ := ret[0].(bool)
+	return ret0
+}
+
+// TxIndexDone indicates an expected call of TxIndexDone.
+func (mr *MockBlockChainMockRecorder) TxIndexDone() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "TxIndexDone", reflect.TypeOf((*MockBlockChain)(nil).TxIndexDone))
+}
+
 // IsSenderTxHashIndexingEnabled mocks base method.
 func (m *MockBlockChain) IsSenderTxHashIndexingEnabled() bool {
 	m.ctrl.T.Helper()
@@ -678,6 +737,20 @@ func (mr *MockBlockChainMockRecorder) ResetWithGenesisBlock(arg0 interface{}) *g
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ResetWithGenesisBlock", reflect.TypeOf((*MockBlockChain)(nil).ResetWithGenesisBlock), arg0)
 }
 
+// ResizeTrieNodeCache mocks base method.
+func (m *MockBlockChain) ResizeTrieNodeCache(arg0 int) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ResizeTrieNodeCache", arg0)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// ResizeTrieNodeCache indicates an expected call of ResizeTrieNodeCache.
+func (mr *MockBlockChainMockRecorder) ResizeTrieNodeCache(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ResizeTrieNodeCache", reflect.TypeOf((*MockBlockChain)(nil).ResizeTrieNodeCache), arg0)
+}
+
 // Rollback mocks base method.
 func (m *MockBlockChain) Rollback(arg0 []common.Hash) {
 	m.ctrl.T.Helper()
@@ -992,6 +1065,20 @@ func (mr *MockBlockChainMockRecorder) SubscribeRemovedLogsEvent(arg0 interface{}
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SubscribeRemovedLogsEvent", reflect.TypeOf((*MockBlockChain)(nil).SubscribeRemovedLogsEvent), arg0)
 }
 
+// SubscribeReorgEvent mocks base method.
+func (m *MockBlockChain) SubscribeReorgEvent(arg0 chan<- blockchain.ReorgEvent) event.Subscription {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SubscribeReorgEvent", arg0)
+	ret0, _ := ret[0].(event.Subscription)
+	return ret0
+}
+
+// SubscribeReorgEvent indicates an expected call of SubscribeReorgEvent.
+func (mr *MockBlockChainMockRecorder) SubscribeReorgEvent(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SubscribeReorgEvent", reflect.TypeOf((*MockBlockChain)(nil).SubscribeReorgEvent), arg0)
+}
+
 // TrieNode mocks base method.
 func (m *MockBlockChain) TrieNode(arg0 common.Hash) ([]byte, error) {
 	m.ctrl.T.Helper()
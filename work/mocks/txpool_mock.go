@@ -81,6 +81,21 @@ func (mr *MockTxPoolMockRecorder) Content() *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Content", reflect.TypeOf((*MockTxPool)(nil).Content))
 }
 
+// ContentFrom mocks base method.
+func (m *MockTxPool) ContentFrom(arg0 common.Address) (types.Transactions, types.Transactions) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ContentFrom", arg0)
+	ret0, _ := ret[0].(types.Transactions)
+	ret1, _ := ret[1].(types.Transactions)
+	return ret0, ret1
+}
+
+// ContentFrom indicates an expected call of ContentFrom.
+func (mr *MockTxPoolMockRecorder) ContentFrom(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ContentFrom", reflect.TypeOf((*MockTxPool)(nil).ContentFrom), arg0)
+}
+
 // GasPrice mocks base method.
 func (m *MockTxPool) GasPrice() *big.Int {
 	m.ctrl.T.Helper()
@@ -135,6 +150,20 @@ func (mr *MockTxPoolMockRecorder) HandleTxMsg(arg0 interface{}) *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "HandleTxMsg", reflect.TypeOf((*MockTxPool)(nil).HandleTxMsg), arg0)
 }
 
+// Limits mocks base method.
+func (m *MockTxPool) Limits() blockchain.TxPoolLimits {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Limits")
+	ret0, _ := ret[0].(blockchain.TxPoolLimits)
+	return ret0
+}
+
+// Limits indicates an expected call of Limits.
+func (mr *MockTxPoolMockRecorder) Limits() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Limits", reflect.TypeOf((*MockTxPool)(nil).Limits))
+}
+
 // Pending mocks base method.
 func (m *MockTxPool) Pending() (map[common.Address]types.Transactions, error) {
 	m.ctrl.T.Helper()
@@ -162,6 +191,18 @@ func (mr *MockTxPoolMockRecorder) SetGasPrice(arg0 interface{}) *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetGasPrice", reflect.TypeOf((*MockTxPool)(nil).SetGasPrice), arg0)
 }
 
+// SetLimits mocks base method.
+func (m *MockTxPool) SetLimits(arg0 blockchain.TxPoolLimits) {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "SetLimits", arg0)
+}
+
+// SetLimits indicates an expected call of SetLimits.
+func (mr *MockTxPoolMockRecorder) SetLimits(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetLimits", reflect.TypeOf((*MockTxPool)(nil).SetLimits), arg0)
+}
+
 // StartSpamThrottler mocks base method.
 func (m *MockTxPool) StartSpamThrottler(arg0 *blockchain.ThrottlerConfig) error {
 	m.ctrl.T.Helper()
@@ -215,6 +256,20 @@ func (mr *MockTxPoolMockRecorder) StopSpamThrottler() *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "StopSpamThrottler", reflect.TypeOf((*MockTxPool)(nil).StopSpamThrottler))
 }
 
+// SubscribeDroppedTxsEvent mocks base method.
+func (m *MockTxPool) SubscribeDroppedTxsEvent(arg0 chan<- blockchain.DroppedTxsEvent) event.Subscription {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SubscribeDroppedTxsEvent", arg0)
+	ret0, _ := ret[0].(event.Subscription)
+	return ret0
+}
+
+// SubscribeDroppedTxsEvent indicates an expected call of SubscribeDroppedTxsEvent.
+func (mr *MockTxPoolMockRecorder) SubscribeDroppedTxsEvent(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SubscribeDroppedTxsEvent", reflect.TypeOf((*MockTxPool)(nil).SubscribeDroppedTxsEvent), arg0)
+}
+
 // SubscribeNewTxsEvent mocks base method.
 func (m *MockTxPool) SubscribeNewTxsEvent(arg0 chan<- blockchain.NewTxsEvent) event.Subscription {
 	m.ctrl.T.Helper()
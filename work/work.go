@@ -30,6 +30,7 @@ import (
 	"github.com/klaytn/klaytn/blockchain"
 	"github.com/klaytn/klaytn/blockchain/state"
 	"github.com/klaytn/klaytn/blockchain/types"
+	"github.com/klaytn/klaytn/blockchain/types/account"
 	"github.com/klaytn/klaytn/blockchain/vm"
 	"github.com/klaytn/klaytn/common"
 	"github.com/klaytn/klaytn/consensus"
@@ -60,6 +61,10 @@ type TxPool interface {
 	// NewTxsEvent and send events to the given channel.
 	SubscribeNewTxsEvent(chan<- blockchain.NewTxsEvent) event.Subscription
 
+	// SubscribeDroppedTxsEvent should return an event subscription of
+	// DroppedTxsEvent and send events to the given channel.
+	SubscribeDroppedTxsEvent(chan<- blockchain.DroppedTxsEvent) event.Subscription
+
 	GetPendingNonce(addr common.Address) uint64
 	AddLocal(tx *types.Transaction) error
 	GasPrice() *big.Int
@@ -68,8 +73,11 @@ type TxPool interface {
 	Get(hash common.Hash) *types.Transaction
 	Stats() (int, int)
 	Content() (map[common.Address]types.Transactions, map[common.Address]types.Transactions)
+	ContentFrom(addr common.Address) (types.Transactions, types.Transactions)
 	StartSpamThrottler(conf *blockchain.ThrottlerConfig) error
 	StopSpamThrottler()
+	Limits() blockchain.TxPoolLimits
+	SetLimits(limits blockchain.TxPoolLimits)
 }
 
 // Backend wraps all methods required for mining.
@@ -205,6 +213,26 @@ func (self *Miner) SetExtra(extra []byte) error {
 	return nil
 }
 
+// AddBundle submits the bundle to be included contiguously at the top of a block, or not at all.
+// The bundle waits for inclusion until the block of its MaxBlockNumber, or DefaultBundleLifetime blocks
+// from the current block if it is not given.
+func (self *Miner) AddBundle(bundle *Bundle) error {
+	if self.worker.nodetype != common.CONSENSUSNODE {
+		return ErrBundleNotProposer
+	}
+	return self.worker.bundles.add(bundle, self.worker.chain.CurrentBlock().NumberU64())
+}
+
+// RemoveBundle cancels the bundle of the given hash, returning whether the bundle was waiting for inclusion.
+func (self *Miner) RemoveBundle(hash common.Hash) bool {
+	return self.worker.bundles.remove(hash)
+}
+
+// Bundles returns the bundles waiting for inclusion.
+func (self *Miner) Bundles() []*Bundle {
+	return self.worker.bundles.pending()
+}
+
 // Pending returns the currently pending block and associated state.
 func (self *Miner) Pending() (*types.Block, *state.StateDB) {
 	return self.worker.pending()
@@ -262,17 +290,22 @@ type BlockChain interface {
 	Stop()
 
 	SubscribeRemovedLogsEvent(ch chan<- blockchain.RemovedLogsEvent) event.Subscription
+	SubscribeReorgEvent(ch chan<- blockchain.ReorgEvent) event.Subscription
 	SubscribeChainHeadEvent(ch chan<- blockchain.ChainHeadEvent) event.Subscription
 	SubscribeChainSideEvent(ch chan<- blockchain.ChainSideEvent) event.Subscription
 	SubscribeLogsEvent(ch chan<- []*types.Log) event.Subscription
 	IsParallelDBWrite() bool
 	IsSenderTxHashIndexingEnabled() bool
+	IsInternalTxTracingEnabled() bool
+	TxIndexDone() bool
 
 	Processor() blockchain.Processor
 	BadBlocks() ([]blockchain.BadBlockArgs, error)
 	StateAt(root common.Hash) (*state.StateDB, error)
 	StateAtWithPersistent(root common.Hash) (*state.StateDB, error)
 	StateAtWithGCLock(root common.Hash) (*state.StateDB, error)
+	HistoricalAccount(addr common.Address, number uint64) (account.Account, error)
+	HistoricalStorage(addr common.Address, key common.Hash, number uint64) (common.Hash, error)
 	Export(w io.Writer) error
 	Engine() consensus.Engine
 	GetTxLookupInfoAndReceipt(txHash common.Hash) (*types.Transaction, common.Hash, uint64, uint64, *types.Receipt)
@@ -305,6 +338,7 @@ type BlockChain interface {
 
 	// Save trie node cache to this
 	SaveTrieNodeCacheToDisk() error
+	ResizeTrieNodeCache(sizeMiB int) error
 
 	// KES
 	BlockSubscriptionLoop(pool *blockchain.TxPool)
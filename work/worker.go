@@ -113,9 +113,10 @@ type Task struct {
 
 	Block *types.Block // the new block
 
-	header   *types.Header
-	txs      []*types.Transaction
-	receipts []*types.Receipt
+	header           *types.Header
+	txs              []*types.Transaction
+	receipts         []*types.Receipt
+	internalTxTraces []*vm.InternalTxTrace // nil traces if the internal transactions are not traced
 
 	createdAt time.Time
 }
@@ -160,6 +161,8 @@ type worker struct {
 	snapshotBlock *types.Block
 	snapshotState *state.StateDB
 
+	bundles bundlePool // bundles to be included at the top of the blocks
+
 	// atomic status counters
 	mining int32
 	atWork int32
@@ -312,7 +315,8 @@ func (self *worker) update() {
 		// A real event arrived, process interesting content
 		select {
 		// Handle ChainHeadEvent
-		case <-self.chainHeadCh:
+		case ev := <-self.chainHeadCh:
+			self.bundles.prune(ev.Block)
 			// istanbul BFT
 			if h, ok := self.engine.(consensus.Handler); ok {
 				h.NewChainHead()
@@ -427,7 +431,13 @@ func (self *worker) wait(TxResendUseLegacy bool) {
 			logs := work.state.Logs()
 			work.stateMu.RUnlock()
 
-			events = append(events, blockchain.ChainEvent{Block: block, Hash: block.Hash(), Logs: logs})
+			events = append(events, blockchain.ChainEvent{
+				Block:            block,
+				Hash:             block.Hash(),
+				Receipts:         work.receipts,
+				Logs:             logs,
+				InternalTxTraces: work.internalTxTraces,
+			})
 			if result.Status == blockchain.CanonStatTy {
 				events = append(events, blockchain.ChainHeadEvent{Block: block})
 			}
@@ -469,6 +479,9 @@ func (self *worker) makeCurrent(parent *types.Block, header *types.Header) error
 	if err != nil {
 		return err
 	}
+	// Record the original values of the written state, so that the block chain
+	// can store the reverse state diff of the block if the state history is enabled.
+	stateDB.StartHistoryRecording()
 	work := NewTask(self.config, types.MakeSigner(self.config, header.Number), stateDB, header)
 	if self.nodetype != common.CONSENSUSNODE {
 		work.Block = parent
@@ -554,7 +567,7 @@ func (self *worker) commitNewWork() {
 	work := self.current
 	if self.nodetype == common.CONSENSUSNODE {
 		txs := types.NewTransactionsByTimeAndNonce(self.current.signer, pending)
-		work.commitTransactions(self.mux, txs, self.chain, self.rewardbase)
+		work.commitTransactions(self.mux, self.bundles.pending(), txs, self.chain, self.rewardbase)
 		finishedCommitTx := time.Now()
 
 		// Create the new block to seal with the consensus engine
@@ -621,8 +634,12 @@ func (self *worker) updateSnapshot() {
 	self.snapshotState = self.current.state.Copy()
 }
 
-func (env *Task) commitTransactions(mux *event.TypeMux, txs *types.TransactionsByTimeAndNonce, bc BlockChain, rewardbase common.Address) {
-	coalescedLogs := env.ApplyTransactions(txs, bc, rewardbase)
+func (env *Task) commitTransactions(mux *event.TypeMux, bundles []*Bundle, txs *types.TransactionsByTimeAndNonce, bc BlockChain, rewardbase common.Address) {
+	// The bundles and the transactions share the time limit of the block
+	timer := env.startTxTimer(params.BlockGenerationTimeLimit)
+	coalescedLogs := env.commitBundles(bundles, bc, rewardbase, timer)
+	coalescedLogs = append(coalescedLogs, env.applyTransactions(txs, bc, rewardbase, timer)...)
+	timer.stop()
 
 	if len(coalescedLogs) > 0 || env.tcount > 0 {
 		// make a copy, the state caches the logs and these logs get "upgraded" from pending to mined
@@ -644,20 +661,27 @@ func (env *Task) commitTransactions(mux *event.TypeMux, txs *types.TransactionsB
 	}
 }
 
-func (env *Task) ApplyTransactions(txs *types.TransactionsByTimeAndNonce, bc BlockChain, rewardbase common.Address) []*types.Log {
-	var coalescedLogs []*types.Log
+// txTimer limits the execution time of all transactions in a block.
+type txTimer struct {
+	abort  int32     // To break the loops committing transactions when timed out
+	chDone chan bool // To stop the goroutine of the timer when processing txs is completed
 
-	// Limit the execution time of all transactions in a block
-	var abort int32 = 0       // To break the below commitTransaction for loop when timed out
-	chDone := make(chan bool) // To stop the goroutine below when processing txs is completed
-
-	// chEVM is used to notify the below goroutine of the running EVM so it can call evm.Cancel
+	// chEVM is used to notify the goroutine of the timer of the running EVM so it can call evm.Cancel
 	// when timed out.  We use a buffered channel to prevent the main EVM execution routine
 	// from being blocked due to the channel communication.
-	chEVM := make(chan *vm.EVM, 1)
+	chEVM chan *vm.EVM
+}
+
+// startTxTimer starts the timer which aborts the execution of the transactions after the given limit.
+func (env *Task) startTxTimer(limit time.Duration) *txTimer {
+	timer := &txTimer{
+		chDone: make(chan bool),
+		chEVM:  make(chan *vm.EVM, 1),
+	}
 
 	go func() {
-		blockTimer := time.NewTimer(params.BlockGenerationTimeLimit)
+		blockTimer := time.NewTimer(limit)
+		defer blockTimer.Stop()
 		timeout := false
 		var evm *vm.EVM
 
@@ -665,13 +689,13 @@ func (env *Task) ApplyTransactions(txs *types.TransactionsByTimeAndNonce, bc Blo
 			select {
 			case <-blockTimer.C:
 				timeout = true
-				atomic.StoreInt32(&abort, 1)
+				atomic.StoreInt32(&timer.abort, 1)
 
-			case <-chDone:
+			case <-timer.chDone:
 				// Everything is done. Stop this goroutine.
 				return
 
-			case evm = <-chEVM:
+			case evm = <-timer.chEVM:
 			}
 
 			if timeout && evm != nil {
@@ -684,18 +708,46 @@ func (env *Task) ApplyTransactions(txs *types.TransactionsByTimeAndNonce, bc Blo
 			}
 		}
 	}()
+	return timer
+}
+
+// timedOut returns true if the time limit has been reached.
+func (timer *txTimer) timedOut() bool {
+	return atomic.LoadInt32(&timer.abort) == 1
+}
 
-	vmConfig := &vm.Config{
-		RunningEVM:               chEVM,
+// stop stops the goroutine that has been handling the timer.
+func (timer *txTimer) stop() {
+	close(timer.chDone)
+}
+
+// vmConfig returns the EVM configuration which reports the running EVM to the timer.
+func (timer *txTimer) vmConfig(bc BlockChain) *vm.Config {
+	return &vm.Config{
+		RunningEVM:               timer.chEVM,
 		UseOpcodeComputationCost: true,
+		EnableInternalTxTracing:  bc.IsInternalTxTracingEnabled(),
 	}
+}
+
+// ApplyTransactions applies the transactions within params.BlockGenerationTimeLimit.
+func (env *Task) ApplyTransactions(txs *types.TransactionsByTimeAndNonce, bc BlockChain, rewardbase common.Address) []*types.Log {
+	timer := env.startTxTimer(params.BlockGenerationTimeLimit)
+	defer timer.stop()
+
+	return env.applyTransactions(txs, bc, rewardbase, timer)
+}
+
+func (env *Task) applyTransactions(txs *types.TransactionsByTimeAndNonce, bc BlockChain, rewardbase common.Address, timer *txTimer) []*types.Log {
+	var coalescedLogs []*types.Log
+	vmConfig := timer.vmConfig(bc)
 
 	var numTxsChecked int64 = 0
 	var numTxsNonceTooLow int64 = 0
 	var numTxsNonceTooHigh int64 = 0
 	var numTxsGasLimitReached int64 = 0
 CommitTransactionLoop:
-	for atomic.LoadInt32(&abort) == 0 {
+	for !timer.timedOut() {
 		// Retrieve the next transaction and abort if all done
 		tx := txs.Peek()
 		if tx == nil {
@@ -781,16 +833,13 @@ CommitTransactionLoop:
 	nonceTooHighTxsGauge.Update(numTxsNonceTooHigh)
 	gasLimitReachedTxsGauge.Update(numTxsGasLimitReached)
 
-	// Stop the goroutine that has been handling the timer.
-	chDone <- true
-
 	return coalescedLogs
 }
 
 func (env *Task) commitTransaction(tx *types.Transaction, bc BlockChain, rewardbase common.Address, vmConfig *vm.Config) (error, []*types.Log) {
 	snap := env.state.Snapshot()
 
-	receipt, _, err := bc.ApplyTransaction(env.config, &rewardbase, env.state, env.header, tx, &env.header.GasUsed, vmConfig)
+	receipt, internalTxTrace, err := bc.ApplyTransaction(env.config, &rewardbase, env.state, env.header, tx, &env.header.GasUsed, vmConfig)
 	if err != nil {
 		if err != vm.ErrInsufficientBalance && err != vm.ErrTotalTimeLimitReached {
 			tx.MarkUnexecutable(true)
@@ -800,6 +849,7 @@ func (env *Task) commitTransaction(tx *types.Transaction, bc BlockChain, rewardb
 	}
 	env.txs = append(env.txs, tx)
 	env.receipts = append(env.receipts, receipt)
+	env.internalTxTraces = append(env.internalTxTraces, internalTxTrace)
 
 	return nil, receipt.Logs
 }
@@ -17,8 +17,11 @@
 package work
 
 import (
+	"errors"
+
 	"github.com/klaytn/klaytn/blockchain/state"
 	"github.com/klaytn/klaytn/blockchain/types"
+	"github.com/klaytn/klaytn/common"
 )
 
 type FakeWorker struct{}
@@ -38,3 +41,6 @@ func (*FakeWorker) HashRate() (tot int64)                   { return 0 }
 func (*FakeWorker) SetExtra([]byte) error                   { return nil }
 func (*FakeWorker) Pending() (*types.Block, *state.StateDB) { return nil, nil }
 func (*FakeWorker) PendingBlock() *types.Block              { return nil }
+func (*FakeWorker) AddBundle(*Bundle) error                 { return errors.New("worker is disabled") }
+func (*FakeWorker) RemoveBundle(common.Hash) bool           { return false }
+func (*FakeWorker) Bundles() []*Bundle                      { return nil }